@@ -0,0 +1,500 @@
+package main
+
+import (
+	"image"
+
+	"github.com/neurlang/wayland/fractionalscaleserver"
+	"github.com/neurlang/wayland/presentationserver"
+	"github.com/neurlang/wayland/wlserver"
+)
+
+// compositor handles the wl_compositor requests
+type compositor struct {
+	h *headless
+}
+
+func (c compositor) HandleCompositorCreateSurface(req wlserver.CompositorCreateSurfaceRequest) {
+	s := &surface{h: c.h, res: req.Id, scale: 1}
+	req.Id.SetHandler(s)
+	req.Id.OnDestroy(s.destroyed)
+}
+
+func (c compositor) HandleCompositorCreateRegion(req wlserver.CompositorCreateRegionRequest) {
+	req.Id.SetHandler(&region{})
+}
+
+// region is a wl_region, a union and difference of rectangles
+type region struct {
+	ops []regionOp
+}
+
+type regionOp struct {
+	r   image.Rectangle
+	add bool
+}
+
+func (r *region) HandleRegionAdd(req wlserver.RegionAddRequest) {
+	r.ops = append(r.ops, regionOp{image.Rect(int(req.X), int(req.Y), int(req.X+req.Width), int(req.Y+req.Height)), true})
+}
+
+func (r *region) HandleRegionSubtract(req wlserver.RegionSubtractRequest) {
+	r.ops = append(r.ops, regionOp{image.Rect(int(req.X), int(req.Y), int(req.X+req.Width), int(req.Y+req.Height)), false})
+}
+
+// contains reports whether the point is inside the region, the last rectangle containing it decides
+func (r *region) contains(p image.Point) bool {
+	for i := len(r.ops) - 1; i >= 0; i-- {
+		if p.In(r.ops[i].r) {
+			return r.ops[i].add
+		}
+	}
+	return false
+}
+
+// copy returns the region as it is now, later requests to the wl_region do not change it
+func (r *region) copy() *region {
+	return &region{append([]regionOp(nil), r.ops...)}
+}
+
+// surfaceState is the double-buffered state of a wl_surface
+type surfaceState struct {
+	attached bool
+	buffer   *wlserver.Buffer
+	dx, dy   int32
+	inputSet bool
+	input    *region
+	scale    int32
+	frames   []*wlserver.Callback
+
+	sourceSet      bool
+	source         cropRect
+	destinationSet bool
+	destination    image.Point
+
+	feedbacks []*presentationserver.PresentationFeedback
+}
+
+// merge applies the later state over the earlier one
+func (st *surfaceState) merge(next *surfaceState) {
+	if next.attached {
+		st.attached = true
+		st.buffer = next.buffer
+	}
+	st.dx += next.dx
+	st.dy += next.dy
+	if next.inputSet {
+		st.inputSet = true
+		st.input = next.input
+	}
+	if next.scale != 0 {
+		st.scale = next.scale
+	}
+	st.frames = append(st.frames, next.frames...)
+	if next.sourceSet {
+		st.sourceSet = true
+		st.source = next.source
+	}
+	if next.destinationSet {
+		st.destinationSet = true
+		st.destination = next.destination
+	}
+	if len(next.feedbacks) != 0 {
+		// the earlier content update is superseded
+		for _, fb := range st.feedbacks {
+			if !fb.Destroyed() {
+				fb.SendDiscarded()
+			}
+		}
+		st.feedbacks = next.feedbacks
+	}
+}
+
+// surface is a wl_surface, its contents are copied from the buffer on commit
+type surface struct {
+	h   *headless
+	res *wlserver.Surface
+
+	pending surfaceState
+	// cached is the state committed by a synchronized subsurface, applied by the commit of the parent
+	cached    surfaceState
+	hasCached bool
+
+	// image is the contents of the surface, nil when no buffer is attached
+	image *image.RGBA
+	scale int32
+	input *region
+	// offset is the accumulated offset of the attached buffers, moving the surface
+	offset image.Point
+	// source and destination are the crop and scale of the viewport
+	viewport    *viewport
+	source      cropRect
+	destination image.Point
+
+	fractionalScale *fractionalscaleserver.FractionalScaleV1
+	// feedbacks are the wp_presentation_feedback resources waiting for the next repaint
+	feedbacks []*presentationserver.PresentationFeedback
+
+	role     string
+	xdg      *xdgSurface
+	sub      *subsurface
+	children []*subsurface
+	outputs  bool
+	gone     bool
+}
+
+// size returns the size of the surface in the output coordinates
+func (s *surface) size() image.Point {
+	if s.image == nil {
+		return image.Point{}
+	}
+	if s.destination != (image.Point{}) {
+		return s.destination
+	}
+	if !s.source.unset() {
+		return image.Pt(int(s.source.width), int(s.source.height))
+	}
+	return s.image.Rect.Size().Div(int(s.scale))
+}
+
+// setRole gives the surface a role, it fails when the surface has another one
+func (s *surface) setRole(role string) bool {
+	if s.role != "" && s.role != role {
+		return false
+	}
+	s.role = role
+	return true
+}
+
+func (s *surface) HandleSurfaceAttach(req wlserver.SurfaceAttachRequest) {
+	if s.res.Version() >= 5 {
+		if req.X != 0 || req.Y != 0 {
+			s.res.Client().PostError(s.res, wlserver.SurfaceErrorInvalidOffset, "attach offset must be zero, use wl_surface.offset")
+			return
+		}
+	} else {
+		s.pending.dx = req.X
+		s.pending.dy = req.Y
+	}
+	s.pending.attached = true
+	s.pending.buffer = req.Buffer
+}
+
+func (s *surface) HandleSurfaceOffset(req wlserver.SurfaceOffsetRequest) {
+	s.pending.dx = req.X
+	s.pending.dy = req.Y
+}
+
+func (s *surface) HandleSurfaceFrame(req wlserver.SurfaceFrameRequest) {
+	s.pending.frames = append(s.pending.frames, req.Callback)
+}
+
+func (s *surface) HandleSurfaceSetInputRegion(req wlserver.SurfaceSetInputRegionRequest) {
+	s.pending.inputSet = true
+	s.pending.input = nil
+	if req.Region != nil {
+		if r, ok := req.Region.Handler().(*region); ok {
+			s.pending.input = r.copy()
+		}
+	}
+}
+
+func (s *surface) HandleSurfaceSetBufferScale(req wlserver.SurfaceSetBufferScaleRequest) {
+	if req.Scale < 1 {
+		s.res.Client().PostError(s.res, wlserver.SurfaceErrorInvalidScale, "buffer scale must be at least one")
+		return
+	}
+	s.pending.scale = req.Scale
+}
+
+func (s *surface) HandleSurfaceCommit(req wlserver.SurfaceCommitRequest) {
+	if s.xdg != nil && !s.xdg.commitAllowed(&s.pending) {
+		return
+	}
+	state := s.pending
+	s.pending = surfaceState{}
+	if s.sub != nil && s.sub.synchronized() {
+		s.cached.merge(&state)
+		s.hasCached = true
+		return
+	}
+	s.apply(&state)
+}
+
+// apply makes the committed state current, together with the cached state of the synchronized
+// subsurfaces and the pending position and order of all the subsurfaces
+func (s *surface) apply(state *surfaceState) {
+	if state.scale != 0 {
+		s.scale = state.scale
+	}
+	if state.inputSet {
+		s.input = state.input
+	}
+	s.offset = s.offset.Add(image.Pt(int(state.dx), int(state.dy)))
+	if state.sourceSet {
+		s.source = state.source
+		s.h.damage()
+	}
+	if state.destinationSet {
+		s.destination = state.destination
+		s.h.damage()
+	}
+	if state.attached {
+		s.image = nil
+		if state.buffer != nil && !state.buffer.Destroyed() {
+			if b, ok := state.buffer.Handler().(*shmBuffer); ok {
+				img, err := b.image()
+				if err != nil {
+					state.buffer.Client().PostError(state.buffer, wlserver.ShmErrorInvalidFd, err.Error())
+					return
+				}
+				s.image = img
+			}
+			state.buffer.SendRelease()
+		}
+		s.h.damage()
+	}
+	s.checkViewport()
+	s.present(state.feedbacks)
+	s.h.pending = append(s.h.pending, state.frames...)
+	s.h.scheduleRepaint()
+
+	for _, c := range s.children {
+		c.x, c.y = c.pendingX, c.pendingY
+		if c.surface.hasCached {
+			cached := c.surface.cached
+			c.surface.cached = surfaceState{}
+			c.surface.hasCached = false
+			c.surface.apply(&cached)
+		}
+	}
+	if s.xdg != nil {
+		s.xdg.committed()
+	}
+	if s.image != nil && !s.outputs {
+		s.outputs = true
+		s.h.enterOutputs(s)
+	}
+}
+
+// destroyed unmaps the surface
+func (s *surface) destroyed() {
+	s.gone = true
+	if s.viewport != nil {
+		s.viewport.surface = nil
+	}
+	if s.xdg != nil {
+		s.xdg.unmap()
+	}
+	if s.sub != nil {
+		s.sub.unlink()
+	}
+	for _, c := range s.children {
+		c.parent = nil
+	}
+	s.children = nil
+	s.h.seat.surfaceDestroyed(s)
+	s.h.damage()
+}
+
+// subcompositor handles the wl_subcompositor requests
+type subcompositor struct {
+	h *headless
+}
+
+func (sc subcompositor) HandleSubcompositorGetSubsurface(req wlserver.SubcompositorGetSubsurfaceRequest) {
+	s, _ := req.Surface.Handler().(*surface)
+	parent, _ := req.Parent.Handler().(*surface)
+	if s == nil || parent == nil {
+		return
+	}
+	for p := parent; p != nil; p = p.parentSurface() {
+		if p == s {
+			req.Resource.Client().PostError(req.Resource, wlserver.SubcompositorErrorBadParent, "subsurface would be its own ancestor")
+			return
+		}
+	}
+	if !s.setRole("wl_subsurface") || s.sub != nil {
+		req.Resource.Client().PostError(req.Resource, wlserver.SubcompositorErrorBadSurface, "surface already has a role")
+		return
+	}
+	sub := &subsurface{res: req.Id, surface: s, parent: parent, sync: true}
+	s.sub = sub
+	parent.children = append(parent.children, sub)
+	req.Id.SetHandler(sub)
+	req.Id.OnDestroy(func() {
+		sub.unlink()
+		s.sub = nil
+		sc.h.damage()
+	})
+}
+
+// subsurface is a wl_subsurface, it is positioned relative to its parent
+type subsurface struct {
+	res                *wlserver.Subsurface
+	surface            *surface
+	parent             *surface
+	x, y               int32
+	pendingX, pendingY int32
+	sync               bool
+	below              bool
+}
+
+func (s *surface) parentSurface() *surface {
+	if s.sub == nil {
+		return nil
+	}
+	return s.sub.parent
+}
+
+// synchronized reports whether the subsurface or one of its ancestors is in the synchronized mode
+func (sub *subsurface) synchronized() bool {
+	for sub != nil {
+		if sub.sync {
+			return true
+		}
+		if sub.parent == nil {
+			return false
+		}
+		sub = sub.parent.sub
+	}
+	return false
+}
+
+// unlink removes the subsurface from the children of its parent
+func (sub *subsurface) unlink() {
+	if sub.parent == nil {
+		return
+	}
+	children := sub.parent.children
+	for i, c := range children {
+		if c == sub {
+			sub.parent.children = append(children[:i:i], children[i+1:]...)
+			break
+		}
+	}
+	sub.parent = nil
+}
+
+func (sub *subsurface) HandleSubsurfaceSetPosition(req wlserver.SubsurfaceSetPositionRequest) {
+	sub.pendingX, sub.pendingY = req.X, req.Y
+}
+
+func (sub *subsurface) HandleSubsurfacePlaceAbove(req wlserver.SubsurfacePlaceAboveRequest) {
+	sub.place(req.Sibling, true)
+}
+
+func (sub *subsurface) HandleSubsurfacePlaceBelow(req wlserver.SubsurfacePlaceBelowRequest) {
+	sub.place(req.Sibling, false)
+}
+
+// place moves the subsurface next to the sibling, or to the nearest place above or below the parent
+func (sub *subsurface) place(sibling *wlserver.Surface, above bool) {
+	if sub.parent == nil {
+		return
+	}
+	other, _ := sibling.Handler().(*surface)
+	if other != sub.parent && (other == nil || other.sub == nil || other.sub.parent != sub.parent || other == sub.surface) {
+		sub.res.Client().PostError(sub.res, wlserver.SubsurfaceErrorBadSurface, "sibling is not a sibling or the parent")
+		return
+	}
+	parent := sub.parent
+	sub.unlink()
+	sub.parent = parent
+	children := parent.children
+	index := 0
+	if other == parent {
+		sub.below = !above
+		if !above {
+			index = len(children)
+			for i, c := range children {
+				if !c.below {
+					index = i
+					break
+				}
+			}
+		} else {
+			for index < len(children) && children[index].below {
+				index++
+			}
+		}
+	} else {
+		sub.below = other.sub.below
+		for i, c := range children {
+			if c == other.sub {
+				index = i
+				if above {
+					index++
+				}
+				break
+			}
+		}
+	}
+	children = append(children, nil)
+	copy(children[index+1:], children[index:])
+	children[index] = sub
+	parent.children = children
+}
+
+func (sub *subsurface) HandleSubsurfaceSetSync(req wlserver.SubsurfaceSetSyncRequest) {
+	sub.sync = true
+}
+
+func (sub *subsurface) HandleSubsurfaceSetDesync(req wlserver.SubsurfaceSetDesyncRequest) {
+	sub.sync = false
+	if !sub.synchronized() && sub.surface.hasCached {
+		cached := sub.surface.cached
+		sub.surface.cached = surfaceState{}
+		sub.surface.hasCached = false
+		sub.surface.apply(&cached)
+	}
+}
+
+// placed is a surface at its position in the output
+type placed struct {
+	s    *surface
+	x, y int
+}
+
+// stack returns the visible surfaces from the bottom to the top
+func (h *headless) stack() (list []placed) {
+	for _, w := range h.windows {
+		x, y := w.origin()
+		list = appendTree(list, w.surface, x, y)
+	}
+	return list
+}
+
+func appendTree(list []placed, s *surface, x, y int) []placed {
+	x += s.offset.X
+	y += s.offset.Y
+	for _, c := range s.children {
+		if c.below {
+			list = appendTree(list, c.surface, x+int(c.x), y+int(c.y))
+		}
+	}
+	if s.image != nil {
+		list = append(list, placed{s, x, y})
+	}
+	for _, c := range s.children {
+		if !c.below {
+			list = appendTree(list, c.surface, x+int(c.x), y+int(c.y))
+		}
+	}
+	return list
+}
+
+// surfaceAt returns the topmost surface accepting the input at the point, and the point in its coordinates
+func (h *headless) surfaceAt(p image.Point) (*surface, image.Point) {
+	list := h.stack()
+	for i := len(list) - 1; i >= 0; i-- {
+		pl := list[i]
+		local := p.Sub(image.Pt(pl.x, pl.y))
+		if !local.In(image.Rectangle{Max: pl.s.size()}) {
+			continue
+		}
+		if pl.s.input == nil || pl.s.input.contains(local) {
+			return pl.s, local
+		}
+	}
+	return nil, image.Point{}
+}
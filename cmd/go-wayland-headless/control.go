@@ -0,0 +1,267 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// the evdev codes of the pointer buttons
+const (
+	btnLeft   = 272
+	btnRight  = 273
+	btnMiddle = 274
+)
+
+// keyShift is the evdev code of the left shift key
+const keyShift = 42
+
+// asciiKey is the evdev code of an ASCII character on the US layout, typed with the shift
+// key when shifted
+type asciiKey struct {
+	code    uint32
+	shifted bool
+}
+
+// asciiKeys are the keys of the ASCII characters that can be typed
+var asciiKeys = map[byte]asciiKey{}
+
+func init() {
+	rows := []struct {
+		codes         []uint32
+		plain, shifts string
+	}{
+		{[]uint32{2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}, "1234567890-=", "!@#$%^&*()_+"},
+		{[]uint32{16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27}, "qwertyuiop[]", "QWERTYUIOP{}"},
+		{[]uint32{30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41}, "asdfghjkl;'`", "ASDFGHJKL:\"~"},
+		{[]uint32{43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53}, "\\zxcvbnm,./", "|ZXCVBNM<>?"},
+		{[]uint32{57, 28, 15}, " \n\t", ""},
+	}
+	for _, row := range rows {
+		for i, code := range row.codes {
+			asciiKeys[row.plain[i]] = asciiKey{code, false}
+			if i < len(row.shifts) {
+				asciiKeys[row.shifts[i]] = asciiKey{code, true}
+			}
+		}
+	}
+}
+
+// control is the listener of the control socket
+type control struct {
+	h  *headless
+	ln *net.UnixListener
+}
+
+// listenControl listens on the control socket, the commands run on the goroutine of the Server
+func (h *headless) listenControl(path string) (*control, error) {
+	if _, err := os.Stat(path); err == nil {
+		// a stale socket left by a compositor that was killed, the wayland socket is locked
+		// so no other compositor is using it
+		os.Remove(path)
+	}
+	ln, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	ctl := &control{h: h, ln: ln}
+	go ctl.accept()
+	return ctl, nil
+}
+
+// Close stops listening and removes the socket
+func (ctl *control) Close() error {
+	return ctl.ln.Close()
+}
+
+func (ctl *control) accept() {
+	for {
+		conn, err := ctl.ln.AcceptUnix()
+		if err != nil {
+			return
+		}
+		go ctl.serve(conn)
+	}
+}
+
+func (ctl *control) serve(conn *net.UnixConn) {
+	defer conn.Close()
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		done := make(chan error, 1)
+		ctl.h.server.Invoke(func() {
+			done <- ctl.h.command(line)
+		})
+		var err error
+		select {
+		case err = <-done:
+		case <-ctl.h.stopped:
+			err = errors.New("compositor stopped")
+		}
+		if err != nil {
+			fmt.Fprintf(conn, "error: %v\n", err)
+		} else {
+			fmt.Fprintf(conn, "ok\n")
+		}
+	}
+}
+
+// command runs a line of the control socket
+func (h *headless) command(line string) error {
+	fields := strings.Fields(line)
+	args := fields[1:]
+	switch fields[0] {
+	case "motion":
+		if len(args) != 2 {
+			return errors.New("usage: motion X Y")
+		}
+		x, err := strconv.ParseFloat(args[0], 64)
+		if err != nil {
+			return err
+		}
+		y, err := strconv.ParseFloat(args[1], 64)
+		if err != nil {
+			return err
+		}
+		h.seat.motion(image.Pt(int(x), int(y)))
+	case "button":
+		if len(args) != 2 {
+			return errors.New("usage: button left|right|middle|CODE press|release")
+		}
+		button, err := parseButton(args[0])
+		if err != nil {
+			return err
+		}
+		pressed, err := parseState(args[1])
+		if err != nil {
+			return err
+		}
+		h.seat.button(button, pressed)
+	case "click":
+		if len(args) > 1 {
+			return errors.New("usage: click [left|right|middle|CODE]")
+		}
+		button := uint32(btnLeft)
+		if len(args) == 1 {
+			var err error
+			if button, err = parseButton(args[0]); err != nil {
+				return err
+			}
+		}
+		h.seat.button(button, true)
+		h.seat.button(button, false)
+	case "axis":
+		if len(args) != 2 {
+			return errors.New("usage: axis vertical|horizontal VALUE")
+		}
+		var axis uint32
+		switch args[0] {
+		case "vertical":
+			axis = 0
+		case "horizontal":
+			axis = 1
+		default:
+			return fmt.Errorf("unknown axis %q", args[0])
+		}
+		value, err := strconv.ParseFloat(args[1], 32)
+		if err != nil {
+			return err
+		}
+		h.seat.axis(axis, float32(value))
+	case "suspend", "resume":
+		if len(args) != 0 {
+			return fmt.Errorf("usage: %s", fields[0])
+		}
+		h.suspend(fields[0] == "suspend")
+	case "scale":
+		if len(args) != 1 {
+			return errors.New("usage: scale FACTOR")
+		}
+		value, err := strconv.ParseFloat(args[0], 64)
+		if err != nil {
+			return err
+		}
+		scale120, err := parseScale(value)
+		if err != nil {
+			return err
+		}
+		h.setScale(scale120)
+	case "key":
+		if len(args) != 2 {
+			return errors.New("usage: key CODE press|release")
+		}
+		code, err := strconv.ParseUint(args[0], 10, 32)
+		if err != nil {
+			return err
+		}
+		pressed, err := parseState(args[1])
+		if err != nil {
+			return err
+		}
+		h.seat.key(uint32(code), pressed)
+	case "type":
+		text := strings.TrimSpace(strings.TrimPrefix(line, "type"))
+		for i := 0; i < len(text); i++ {
+			if _, ok := asciiKeys[text[i]]; !ok {
+				return fmt.Errorf("cannot type %q", text[i])
+			}
+		}
+		for i := 0; i < len(text); i++ {
+			k := asciiKeys[text[i]]
+			if k.shifted {
+				h.seat.key(keyShift, true)
+			}
+			h.seat.key(k.code, true)
+			h.seat.key(k.code, false)
+			if k.shifted {
+				h.seat.key(keyShift, false)
+			}
+		}
+	case "screenshot":
+		if len(args) != 1 {
+			return errors.New("usage: screenshot FILE")
+		}
+		h.composite()
+		return h.canvas.writePNG(args[0])
+	case "quit":
+		h.server.Close()
+	default:
+		return fmt.Errorf("unknown command %q", fields[0])
+	}
+	return nil
+}
+
+func parseButton(s string) (uint32, error) {
+	switch s {
+	case "left":
+		return btnLeft, nil
+	case "right":
+		return btnRight, nil
+	case "middle":
+		return btnMiddle, nil
+	}
+	code, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unknown button %q", s)
+	}
+	return uint32(code), nil
+}
+
+func parseState(s string) (bool, error) {
+	switch s {
+	case "press":
+		return true, nil
+	case "release":
+		return false, nil
+	}
+	return false, fmt.Errorf("unknown state %q, want press or release", s)
+}
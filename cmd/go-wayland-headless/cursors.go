@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// cursorNames are the cursors of the built-in theme, the ones loaded by wlcursor users
+var cursorNames = []string{
+	"left_ptr", "default",
+	"bottom_left_corner", "bottom_right_corner", "bottom_side",
+	"left_side", "right_side",
+	"top_left_corner", "top_right_corner", "top_side",
+	"grabbing", "xterm", "hand1", "watch",
+	"dnd-move", "dnd-copy", "dnd-none",
+}
+
+// cursorSize is the size of the arrow of the built-in theme
+const cursorSize = 16
+
+// writeCursorTheme writes the cursor theme "default" to dir, a directory of XCURSOR_PATH. All
+// the cursors are the same arrow, the clients only need them to load.
+func writeCursorTheme(dir string) error {
+	theme := filepath.Join(dir, "default")
+	if err := os.MkdirAll(filepath.Join(theme, "cursors"), 0755); err != nil {
+		return err
+	}
+	// wlcursor only uses a theme directory naming the theme it inherits
+	index := "[Icon Theme]\nName=headless\nInherits=headless\n"
+	if err := ioutil.WriteFile(filepath.Join(theme, "index.theme"), []byte(index), 0644); err != nil {
+		return err
+	}
+	data := arrowXcursor()
+	for _, name := range cursorNames {
+		if err := ioutil.WriteFile(filepath.Join(theme, "cursors", name), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// arrowXcursor returns the Xcursor file of a black arrow with a white outline
+func arrowXcursor() []byte {
+	const (
+		fileHeader  = 16
+		tocEntry    = 12
+		imageHeader = 36
+		imageType   = 0xfffd0002
+	)
+	le := binary.LittleEndian
+	data := make([]byte, fileHeader+tocEntry+imageHeader+4*cursorSize*cursorSize)
+	copy(data, "Xcur")
+	le.PutUint32(data[4:], fileHeader)
+	le.PutUint32(data[8:], 0x10000)
+	le.PutUint32(data[12:], 1)
+	toc := data[fileHeader:]
+	le.PutUint32(toc[0:], imageType)
+	le.PutUint32(toc[4:], cursorSize)
+	le.PutUint32(toc[8:], fileHeader+tocEntry)
+	img := toc[tocEntry:]
+	for i, v := range []uint32{imageHeader, imageType, cursorSize, 1, cursorSize, cursorSize, 0, 0, 0} {
+		le.PutUint32(img[4*i:], v)
+	}
+	pix := img[imageHeader:]
+	for y := 0; y < cursorSize; y++ {
+		// the arrow is the triangle x <= y/2 of the upper part and the stem below it
+		width := y/2 + 1
+		if y >= 12 {
+			width = 0
+			if y < cursorSize-1 {
+				width = 3
+			}
+		}
+		for x := 0; x < width; x++ {
+			color := uint32(0xff000000)
+			if x == 0 || x == width-1 || (y == 11 && x > 0) {
+				color = 0xffffffff
+			}
+			le.PutUint32(pix[4*(y*cursorSize+x):], color)
+		}
+	}
+	return data
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/neurlang/wayland/fractionalscaleserver"
+)
+
+// fractionalScaleManager handles the wp_fractional_scale_manager_v1 requests
+type fractionalScaleManager struct {
+	h *headless
+}
+
+func (m fractionalScaleManager) HandleFractionalScaleManagerV1GetFractionalScale(req fractionalscaleserver.FractionalScaleManagerV1GetFractionalScaleRequest) {
+	s, _ := req.Surface.Handler().(*surface)
+	if s == nil {
+		return
+	}
+	if s.fractionalScale != nil {
+		req.Resource.Client().PostError(req.Resource, fractionalscaleserver.FractionalScaleManagerV1ErrorFractionalScaleExists, "surface already has a fractional scale")
+		return
+	}
+	s.fractionalScale = req.Id
+	req.Id.OnDestroy(func() {
+		s.fractionalScale = nil
+	})
+	req.Id.SendPreferredScale(m.h.scale120)
+}
+
+// parseScale returns the scale in 120ths, the denominator of the fractional scales
+func parseScale(value float64) (uint32, error) {
+	if value < 1.0/120 || value > 100 || math.IsNaN(value) {
+		return 0, fmt.Errorf("invalid scale %v", value)
+	}
+	return uint32(math.Round(value * 120)), nil
+}
+
+// setScale tells the surfaces the scale to render at
+func (h *headless) setScale(scale120 uint32) {
+	if h.scale120 == scale120 {
+		return
+	}
+	h.scale120 = scale120
+	for _, w := range h.windows {
+		sendScale(w.surface, scale120)
+	}
+}
+
+func sendScale(s *surface, scale120 uint32) {
+	if s.fractionalScale != nil {
+		s.fractionalScale.SendPreferredScale(scale120)
+	}
+	for _, c := range s.children {
+		sendScale(c.surface, scale120)
+	}
+}
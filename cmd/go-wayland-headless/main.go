@@ -0,0 +1,281 @@
+// go-wayland-headless is a compositor that needs neither a GPU nor a seat, for running clients in CI.
+//
+// Usage:
+//
+//	go-wayland-headless [flags] [client args...]
+//
+// It implements wl_compositor, wl_subcompositor, wl_shm, wl_seat, wl_output, xdg_wm_base, wp_viewporter,
+// wp_fractional_scale_manager_v1 and wp_presentation, composites the shm buffers of the clients
+// in software and writes every repainted frame to a PNG file of the -out directory. When a client
+// command is given, it is started with WAYLAND_DISPLAY naming the socket and the compositor exits
+// with its exit status. The client also gets a built-in cursor theme, unless -cursors=false.
+//
+// The input is injected over the control socket, by default the socket path followed by
+// "-control". It accepts one command per line and answers "ok" or "error: reason":
+//
+//	motion X Y                       move the pointer to the output coordinates
+//	button left|right|middle|CODE press|release
+//	click [left|right|middle|CODE]   press and release a button
+//	axis vertical|horizontal VALUE   scroll
+//	suspend                          tell the windows they are not shown
+//	resume                           tell the windows they are shown again
+//	scale FACTOR                     tell the surfaces the fractional scale to render at, such as 1.5
+//	key CODE press|release           evdev key code, such as 30 for A
+//	type TEXT                        type the ASCII text on the US layout
+//	screenshot FILE                  write the current frame to a PNG file
+//	quit                             stop the compositor
+//
+// For example, with socat:
+//
+//	echo "click left" | socat - UNIX-CONNECT:$XDG_RUNTIME_DIR/wayland-0-control
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/neurlang/wayland/fractionalscale"
+	"github.com/neurlang/wayland/presentation"
+	"github.com/neurlang/wayland/presentationserver"
+	"github.com/neurlang/wayland/viewporter"
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wlserver"
+	"github.com/neurlang/wayland/xdg"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("go-wayland-headless: ")
+
+	socket := flag.String("socket", "", "name of the socket in XDG_RUNTIME_DIR, or a path (default the first free wayland-N)")
+	control := flag.String("control", "", "path of the control socket (default the socket path followed by -control)")
+	out := flag.String("out", "frames", "directory of the PNG files of the frames, none when empty")
+	width := flag.Int("width", 1024, "width of the output")
+	height := flag.Int("height", 768, "height of the output")
+	refresh := flag.Int("refresh", 60, "refresh rate of the output in Hz")
+	frames := flag.Int("frames", 0, "exit after writing the number of frames, 0 for no limit")
+	keymap := flag.String("keymap", "", "file of the XKB keymap sent to the clients (default the US layout)")
+	cursors := flag.Bool("cursors", true, "give the client a built-in cursor theme, for systems without one")
+	scale := flag.Float64("scale", 1, "preferred fractional scale of the surfaces")
+	verbose := flag.Bool("v", false, "log the clients and the windows")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: go-wayland-headless [flags] [client args...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	scale120, err := parseScale(*scale)
+	if *width <= 0 || *height <= 0 || *refresh <= 0 || err != nil {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	km := []byte(defaultKeymap)
+	if *keymap != "" {
+		var err error
+		if km, err = ioutil.ReadFile(*keymap); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *out != "" {
+		if err := os.MkdirAll(*out, 0755); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	server := wlserver.New()
+	h, err := newHeadless(server, config{
+		width:     int32(*width),
+		height:    int32(*height),
+		refresh:   int32(*refresh),
+		out:       *out,
+		maxFrames: *frames,
+		keymap:    km,
+		scale120:  scale120,
+		verbose:   *verbose,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *verbose {
+		server.SetLog(os.Stderr)
+	}
+
+	name, err := server.Listen(*socket)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *control == "" {
+		*control = name + "-control"
+		if !filepath.IsAbs(name) {
+			*control = filepath.Join(os.Getenv("XDG_RUNTIME_DIR"), *control)
+		}
+	}
+	ctl, err := h.listenControl(*control)
+	if err != nil {
+		server.Close()
+		log.Fatal(err)
+	}
+	log.Printf("listening on %s, control socket %s", name, *control)
+
+	var cmd *exec.Cmd
+	var cursorDir string
+	exited := make(chan error, 1)
+	if flag.NArg() > 0 {
+		cmd = exec.Command(flag.Arg(0), flag.Args()[1:]...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		cmd.Env = append(os.Environ(), "WAYLAND_DISPLAY="+name)
+		if *cursors {
+			var err error
+			cursorDir, err = ioutil.TempDir("", "go-wayland-headless")
+			if err == nil {
+				err = writeCursorTheme(cursorDir)
+			}
+			if err != nil {
+				server.Close()
+				log.Fatal(err)
+			}
+			cmd.Env = append(cmd.Env, "XCURSOR_PATH="+cursorDir, "XCURSOR_THEME=default")
+		}
+		if err := cmd.Start(); err != nil {
+			server.Close()
+			log.Fatal(err)
+		}
+		go func() {
+			exited <- cmd.Wait()
+			server.Close()
+		}()
+	}
+
+	server.Run()
+	close(h.stopped)
+	ctl.Close()
+	if cmd == nil {
+		return
+	}
+	var exitErr error
+	select {
+	case exitErr = <-exited:
+	case <-time.After(5 * time.Second):
+		cmd.Process.Kill()
+		exitErr = <-exited
+	}
+	if cursorDir != "" {
+		os.RemoveAll(cursorDir)
+	}
+	var exit *exec.ExitError
+	if errors.As(exitErr, &exit) {
+		log.Printf("client: %v", exitErr)
+		os.Exit(exit.ExitCode())
+	}
+	if exitErr != nil {
+		log.Fatal(exitErr)
+	}
+}
+
+// config is the configuration of the compositor taken from the flags
+type config struct {
+	width, height int32
+	refresh       int32
+	out           string
+	maxFrames     int
+	keymap        []byte
+	// scale120 is the preferred scale of the surfaces in 120ths
+	scale120 uint32
+	verbose  bool
+}
+
+// headless is the state of the compositor, it is used on the goroutine running the Server
+type headless struct {
+	config
+	server  *wlserver.Server
+	start   time.Time
+	stopped chan struct{}
+
+	// outputs are the wl_output resources of the clients
+	outputs map[*wlserver.Client][]*wlserver.Output
+
+	// windows are the mapped toplevels and popups, from the bottom to the top
+	windows []*xdgSurface
+	cascade int32
+
+	// pending are the frame callbacks done by the next repaint
+	pending   []*wlserver.Callback
+	scheduled bool
+	dirty     bool
+	frame     int
+	canvas    *canvas
+	// presenting are the surfaces with presentation feedbacks for the next repaint
+	presenting []*surface
+
+	seat *seat
+}
+
+func newHeadless(server *wlserver.Server, c config) (*headless, error) {
+	h := &headless{
+		config:  c,
+		server:  server,
+		start:   time.Now(),
+		stopped: make(chan struct{}),
+		outputs: make(map[*wlserver.Client][]*wlserver.Output),
+		canvas:  newCanvas(int(c.width), int(c.height)),
+	}
+	seat, err := newSeat(h)
+	if err != nil {
+		return nil, err
+	}
+	h.seat = seat
+
+	server.OnClient(func(c *wlserver.Client) {
+		h.logf("client connected")
+		c.OnDestroy(func() {
+			h.logf("client disconnected")
+		})
+	})
+	server.AddGlobal(wl.CompositorInterface, 6, func(r wlserver.Resource) {
+		r.SetHandler(compositor{h})
+	})
+	server.AddGlobal(wl.SubcompositorInterface, 1, func(r wlserver.Resource) {
+		r.SetHandler(subcompositor{h})
+	})
+	server.AddGlobal(wl.ShmInterface, 2, func(r wlserver.Resource) {
+		shm := r.(*wlserver.Shm)
+		shm.SetHandler(shmHandler{h})
+		shm.SendFormat(wlserver.ShmFormatArgb8888)
+		shm.SendFormat(wlserver.ShmFormatXrgb8888)
+	})
+	server.AddGlobal(wl.SeatInterface, 9, h.seat.bind)
+	server.AddGlobal(wl.OutputInterface, 4, h.bindOutput)
+	server.AddGlobal(xdg.WmBaseInterface, 6, func(r wlserver.Resource) {
+		r.SetHandler(wmBase{h})
+	})
+	server.AddGlobal(viewporter.ViewporterInterface, 1, func(r wlserver.Resource) {
+		r.SetHandler(viewporterHandler{h})
+	})
+	server.AddGlobal(fractionalscale.FractionalScaleManagerV1Interface, 1, func(r wlserver.Resource) {
+		r.SetHandler(fractionalScaleManager{h})
+	})
+	server.AddGlobal(presentation.PresentationInterface, 1, func(r wlserver.Resource) {
+		presentationHandler{h}.bind(r.(*presentationserver.Presentation))
+	})
+	return h, nil
+}
+
+func (h *headless) logf(format string, args ...interface{}) {
+	if h.verbose {
+		log.Printf(format, args...)
+	}
+}
+
+// now is the timestamp of the events in milliseconds
+func (h *headless) now() uint32 {
+	return uint32(time.Since(h.start) / time.Millisecond)
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wlclient"
+	"github.com/neurlang/wayland/wlserver"
+	"github.com/neurlang/wayland/xdg"
+	"golang.org/x/sys/unix"
+)
+
+// setenv sets an environment variable for the duration of the test
+func setenv(t *testing.T, key, value string) {
+	old, ok := os.LookupEnv(key)
+	os.Setenv(key, value)
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, old)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+// startHeadless runs the compositor on a socket of a temporary XDG_RUNTIME_DIR, it returns the
+// name of the socket and the path of the control socket
+func startHeadless(t *testing.T, out string) (string, string) {
+	dir := t.TempDir()
+	setenv(t, "XDG_RUNTIME_DIR", dir)
+	server := wlserver.New()
+	h, err := newHeadless(server, config{
+		width:    320,
+		height:   240,
+		refresh:  60,
+		out:      out,
+		keymap:   []byte(defaultKeymap),
+		scale120: 120,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	name, err := server.Listen("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	control := filepath.Join(dir, name+"-control")
+	ctl, err := h.listenControl(control)
+	if err != nil {
+		server.Close()
+		t.Fatal(err)
+	}
+	ran := make(chan error, 1)
+	go func() {
+		ran <- server.Run()
+	}()
+	t.Cleanup(func() {
+		server.Close()
+		<-ran
+		close(h.stopped)
+		ctl.Close()
+	})
+	return name, control
+}
+
+// pointerEvents records the pointer events of the client
+type pointerEvents struct {
+	enters  []image.Point
+	motions []image.Point
+	buttons []uint32
+}
+
+func (e *pointerEvents) HandlePointerEnter(ev wl.PointerEnterEvent) {
+	e.enters = append(e.enters, image.Pt(int(ev.SurfaceX), int(ev.SurfaceY)))
+}
+
+func (e *pointerEvents) HandlePointerMotion(ev wl.PointerMotionEvent) {
+	e.motions = append(e.motions, image.Pt(int(ev.SurfaceX), int(ev.SurfaceY)))
+}
+
+func (e *pointerEvents) HandlePointerButton(ev wl.PointerButtonEvent) {
+	e.buttons = append(e.buttons, ev.Button<<1|ev.State)
+}
+
+// configureAck acknowledges the configure events of the xdg_surface
+type configureAck struct {
+	xs         *xdg.Surface
+	configured bool
+}
+
+func (c *configureAck) HandleSurfaceConfigure(ev xdg.SurfaceConfigureEvent) {
+	c.xs.AckConfigure(ev.Serial)
+	c.configured = true
+}
+
+// readPNG decodes the PNG file, retrying while it is being written
+func readPNG(t *testing.T, name string) image.Image {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		f, err := os.Open(name)
+		if err == nil {
+			img, err := png.Decode(f)
+			f.Close()
+			if err == nil {
+				return img
+			}
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("no frame %s: %v", name, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+var red = color.RGBA{0xff, 0, 0, 0xff}
+
+// checkWindow checks the frame shows the red window of 64x48 at the cascade position 32,32
+func checkWindow(t *testing.T, name string) {
+	img := readPNG(t, name)
+	for _, pt := range []image.Point{{32, 32}, {95, 79}} {
+		if c := color.RGBAModel.Convert(img.At(pt.X, pt.Y)); c != red {
+			t.Errorf("%s: pixel at %v is %v, want the window %v", name, pt, c, red)
+		}
+	}
+	for _, pt := range []image.Point{{31, 31}, {96, 80}} {
+		if c := color.RGBAModel.Convert(img.At(pt.X, pt.Y)); c != background {
+			t.Errorf("%s: pixel at %v is %v, want the background %v", name, pt, c, background)
+		}
+	}
+}
+
+// TestHeadless shows the shm buffer of a client, writes it to a frame and injects pointer events
+// over the control socket
+func TestHeadless(t *testing.T) {
+	out := t.TempDir()
+	name, control := startHeadless(t, out)
+
+	display, err := wl.Connect(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer display.Context().Close()
+	registry, err := display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	globals := wl.NewGlobalTracker(registry)
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+	bind := func(iface string, version uint32) wl.Proxy {
+		p, err := globals.Bind(iface, version, version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return p
+	}
+	compositor := bind("wl_compositor", 4).(*wl.Compositor)
+	shm := bind("wl_shm", 1).(*wl.Shm)
+	wm := bind("xdg_wm_base", 1).(*xdg.WmBase)
+	seat := bind("wl_seat", 5).(*wl.Seat)
+
+	pointer, err := seat.GetPointer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events pointerEvents
+	pointer.AddEnterHandler(&events)
+	pointer.AddMotionHandler(&events)
+	pointer.AddButtonHandler(&events)
+
+	surface, err := compositor.CreateSurface()
+	if err != nil {
+		t.Fatal(err)
+	}
+	xs, err := wm.GetXdgSurface(surface)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ack := &configureAck{xs: xs}
+	xs.AddConfigureHandler(ack)
+	if _, err := xs.GetToplevel(); err != nil {
+		t.Fatal(err)
+	}
+	if err := surface.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+	if !ack.configured {
+		t.Fatal("the toplevel was not configured")
+	}
+
+	// a red buffer of 64x48
+	const width, height, stride = 64, 48, 64 * 4
+	file, err := sys.CreateAnonymousFile(stride * height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	data, err := unix.Mmap(int(file.Fd()), 0, stride*height, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_SHARED)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unix.Munmap(data)
+	for i := 0; i < len(data); i += 4 {
+		// ARGB8888 in little endian
+		data[i], data[i+1], data[i+2], data[i+3] = 0, 0, 0xff, 0xff
+	}
+	pool, err := shm.CreatePool(file.Fd(), stride*height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buffer, err := pool.CreateBuffer(0, width, height, stride, wl.ShmFormatArgb8888)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := surface.Attach(buffer, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := surface.Damage(0, 0, width, height); err != nil {
+		t.Fatal(err)
+	}
+	if err := surface.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+	checkWindow(t, filepath.Join(out, "frame-000001.png"))
+
+	conn, err := net.Dial("unix", control)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	replies := bufio.NewReader(conn)
+	screenshot := filepath.Join(out, "screenshot.png")
+	for _, command := range []string{"motion 42 42", "click", "motion 50 44", "screenshot " + screenshot} {
+		if _, err := conn.Write([]byte(command + "\n")); err != nil {
+			t.Fatal(err)
+		}
+		reply, err := replies.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reply != "ok\n" {
+			t.Errorf("%s: %q, want ok", command, reply)
+		}
+	}
+	if _, err := conn.Write([]byte("jump\n")); err != nil {
+		t.Fatal(err)
+	}
+	if reply, err := replies.ReadString('\n'); err != nil || reply != "error: unknown command \"jump\"\n" {
+		t.Errorf("unknown command: %q, %v", reply, err)
+	}
+	checkWindow(t, screenshot)
+
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+	const left = 0x110
+	if len(events.enters) != 1 || events.enters[0] != image.Pt(10, 10) {
+		t.Errorf("entered at %v, want 10,10", events.enters)
+	}
+	if len(events.buttons) != 2 || events.buttons[0] != left<<1|wl.PointerButtonStatePressed ||
+		events.buttons[1] != left<<1|wl.PointerButtonStateReleased {
+		t.Errorf("buttons %v, want the left button pressed and released", events.buttons)
+	}
+	if len(events.motions) != 1 || events.motions[0] != image.Pt(18, 12) {
+		t.Errorf("moved to %v, want 18,12", events.motions)
+	}
+}
+
+// connectShm connects a client binding wl_compositor and wl_shm
+func connectShm(t *testing.T, name string) (*wl.Display, *wl.Compositor, *wl.Shm) {
+	display, err := wl.Connect(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { display.Context().Close() })
+	registry, err := display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	globals := wl.NewGlobalTracker(registry)
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+	compositor, err := globals.Bind("wl_compositor", 4, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shm, err := globals.Bind("wl_shm", 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return display, compositor.(*wl.Compositor), shm.(*wl.Shm)
+}
+
+// checkShmError checks the roundtrip fails with the invalid_fd error of wl_shm
+func checkShmError(t *testing.T, display *wl.Display, iface string) {
+	err := wlclient.DisplayRoundtrip(display)
+	var perr *wl.ProtocolError
+	if !errors.As(err, &perr) || perr.Interface != iface || perr.Code != wl.ShmErrorInvalidFd {
+		t.Fatalf("roundtrip returned %v, want the invalid_fd error of the %s", err, iface)
+	}
+}
+
+// TestHeadlessTruncatedPool checks a client truncating the file of its pool, or creating a pool
+// larger than the file, gets an error and the compositor keeps running
+func TestHeadlessTruncatedPool(t *testing.T) {
+	name, _ := startHeadless(t, t.TempDir())
+
+	const width, height, stride = 64, 48, 64 * 4
+	display, compositor, shm := connectShm(t, name)
+	file, err := sys.CreateAnonymousFile(stride * height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	pool, err := shm.CreatePool(file.Fd(), stride*height)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buffer, err := pool.CreateBuffer(0, width, height, stride, wl.ShmFormatArgb8888)
+	if err != nil {
+		t.Fatal(err)
+	}
+	surface, err := compositor.CreateSurface()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+	if err := file.Truncate(0); err != nil {
+		t.Fatal(err)
+	}
+	if err := surface.Attach(buffer, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := surface.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	checkShmError(t, display, "wl_buffer")
+
+	display, _, shm = connectShm(t, name)
+	if _, err := shm.CreatePool(file.Fd(), stride*height); err != nil {
+		t.Fatal(err)
+	}
+	checkShmError(t, display, "wl_shm")
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/neurlang/wayland/wlserver"
+)
+
+// background is the color of the output where there is no window
+var background = color.RGBA{0x30, 0x30, 0x38, 0xff}
+
+// canvas is the composited output
+type canvas struct {
+	*image.RGBA
+}
+
+func newCanvas(width, height int) *canvas {
+	return &canvas{image.NewRGBA(image.Rect(0, 0, width, height))}
+}
+
+func (h *headless) bindOutput(r wlserver.Resource) {
+	out := r.(*wlserver.Output)
+	c := out.Client()
+	h.outputs[c] = append(h.outputs[c], out)
+	out.OnDestroy(func() {
+		for i, o := range h.outputs[c] {
+			if o == out {
+				h.outputs[c] = append(h.outputs[c][:i:i], h.outputs[c][i+1:]...)
+				break
+			}
+		}
+		if len(h.outputs[c]) == 0 {
+			delete(h.outputs, c)
+		}
+	})
+	// a 96 dpi monitor
+	out.SendGeometry(0, 0, h.width*254/960, h.height*254/960, wlserver.OutputSubpixelUnknown,
+		"go-wayland", "headless", wlserver.OutputTransformNormal)
+	out.SendMode(wlserver.OutputModeCurrent|wlserver.OutputModePreferred, h.width, h.height, h.refresh*1000)
+	if out.Version() >= 2 {
+		out.SendScale(1)
+	}
+	if out.Version() >= 4 {
+		out.SendName("HEADLESS-1")
+		out.SendDescription("go-wayland headless output")
+	}
+	if out.Version() >= 2 {
+		out.SendDone()
+	}
+}
+
+// enterOutputs tells the client the surface is shown on the output
+func (h *headless) enterOutputs(s *surface) {
+	for _, out := range h.outputs[s.res.Client()] {
+		s.res.SendEnter(out)
+	}
+}
+
+// raise moves the window and its popups to the top
+func (h *headless) raise(w *xdgSurface) {
+	var top, rest []*xdgSurface
+	for _, other := range h.windows {
+		if other.toplevelOf() == w {
+			top = append(top, other)
+		} else {
+			rest = append(rest, other)
+		}
+	}
+	h.windows = append(rest, top...)
+	h.damage()
+}
+
+// damage makes the next repaint write a frame
+func (h *headless) damage() {
+	h.dirty = true
+	h.scheduleRepaint()
+}
+
+// scheduleRepaint repaints the output on the next refresh of the output
+func (h *headless) scheduleRepaint() {
+	if h.scheduled {
+		return
+	}
+	h.scheduled = true
+	period := time.Second / time.Duration(h.refresh)
+	next := period - time.Since(h.start)%period
+	time.AfterFunc(next, func() {
+		h.server.Invoke(h.repaint)
+	})
+}
+
+// repaint composites the windows, writes the frame and tells the clients to draw the next one
+func (h *headless) repaint() {
+	h.scheduled = false
+	if h.dirty {
+		h.dirty = false
+		h.composite()
+		if h.out != "" {
+			h.frame++
+			name := filepath.Join(h.out, fmt.Sprintf("frame-%06d.png", h.frame))
+			if err := h.canvas.writePNG(name); err != nil {
+				h.logf("%v", err)
+			}
+			if h.maxFrames > 0 && h.frame >= h.maxFrames {
+				h.logf("wrote %d frames", h.frame)
+				h.server.Close()
+			}
+		}
+	}
+	h.sendPresented()
+	now := h.now()
+	for _, cb := range h.pending {
+		if !cb.Destroyed() {
+			cb.SendDone(now)
+		}
+	}
+	h.pending = nil
+}
+
+// composite draws the surfaces over the background
+func (h *headless) composite() {
+	draw.Draw(h.canvas, h.canvas.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+	for _, pl := range h.stack() {
+		img := pl.s.contents()
+		r := img.Bounds().Add(image.Pt(pl.x, pl.y))
+		draw.Draw(h.canvas, r, img, image.Point{}, draw.Over)
+	}
+}
+
+func (c *canvas) writePNG(name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, c.RGBA); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"time"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/neurlang/wayland/presentationserver"
+)
+
+// presentationHandler handles the wp_presentation requests
+type presentationHandler struct {
+	h *headless
+}
+
+func (p presentationHandler) bind(r *presentationserver.Presentation) {
+	r.SetHandler(p)
+	r.SendClockId(unix.CLOCK_MONOTONIC)
+}
+
+func (p presentationHandler) HandlePresentationFeedback(req presentationserver.PresentationFeedbackRequest) {
+	s, _ := req.Surface.Handler().(*surface)
+	if s == nil {
+		return
+	}
+	s.pending.feedbacks = append(s.pending.feedbacks, req.Callback)
+}
+
+// present replaces the feedbacks of the surface waiting for the next repaint, the content
+// update they are about is superseded by the committed one
+func (s *surface) present(feedbacks []*presentationserver.PresentationFeedback) {
+	for _, fb := range s.feedbacks {
+		if !fb.Destroyed() {
+			fb.SendDiscarded()
+		}
+	}
+	if len(s.feedbacks) == 0 && len(feedbacks) != 0 {
+		s.h.presenting = append(s.h.presenting, s)
+	}
+	s.feedbacks = feedbacks
+}
+
+// sendPresented tells the clients their content updates were shown by the repaint
+func (h *headless) sendPresented() {
+	if len(h.presenting) == 0 {
+		return
+	}
+	var ts unix.Timespec
+	_ = unix.ClockGettime(unix.CLOCK_MONOTONIC, &ts)
+	period := time.Second / time.Duration(h.refresh)
+	seq := uint64(time.Since(h.start) / period)
+	for _, s := range h.presenting {
+		for _, fb := range s.feedbacks {
+			if fb.Destroyed() {
+				continue
+			}
+			if s.gone || s.image == nil {
+				fb.SendDiscarded()
+				continue
+			}
+			for _, out := range h.outputs[s.res.Client()] {
+				fb.SendSyncOutput(out)
+			}
+			fb.SendPresented(uint32(uint64(ts.Sec)>>32), uint32(ts.Sec), uint32(ts.Nsec), uint32(period),
+				uint32(seq>>32), uint32(seq), presentationserver.PresentationFeedbackKindVsync)
+		}
+		s.feedbacks = nil
+	}
+	h.presenting = nil
+}
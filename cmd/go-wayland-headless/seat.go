@@ -0,0 +1,290 @@
+package main
+
+import (
+	"image"
+	"os"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wlserver"
+)
+
+// defaultKeymap is the US layout, compiled by the xkbcommon of the clients from the XKB data
+// of the system
+const defaultKeymap = `xkb_keymap {
+	xkb_keycodes { include "evdev+aliases(qwerty)" };
+	xkb_types { include "complete" };
+	xkb_compat { include "complete" };
+	xkb_symbols { include "pc+us+inet(evdev)" };
+	xkb_geometry { include "pc(pc105)" };
+};
+`
+
+// the modifier masks of the keymap
+const (
+	modShift = 1 << 0
+	modCtrl  = 1 << 2
+	modAlt   = 1 << 3
+	modSuper = 1 << 6
+)
+
+// modifierKeys are the evdev codes of the modifier keys
+var modifierKeys = map[uint32]uint32{
+	42: modShift, 54: modShift,
+	29: modCtrl, 97: modCtrl,
+	56: modAlt, 100: modAlt,
+	125: modSuper, 126: modSuper,
+}
+
+// seat is the only wl_seat, its input is injected by the control socket
+type seat struct {
+	h      *headless
+	keymap *os.File
+	size   uint32
+
+	pointers  map[*wlserver.Client][]*wlserver.Pointer
+	keyboards map[*wlserver.Client][]*wlserver.Keyboard
+
+	cursor    image.Point
+	pointer   *surface
+	keyboard  *xdgSurface
+	pressed   []int32
+	modifiers uint32
+}
+
+func newSeat(h *headless) (*seat, error) {
+	f, err := sys.CreateAnonymousFile(int64(len(h.keymap) + 1))
+	if err != nil && err != sys.ErrUnlink {
+		return nil, err
+	}
+	if _, err := f.WriteAt(append(h.keymap, 0), 0); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return &seat{
+		h:         h,
+		keymap:    f,
+		size:      uint32(len(h.keymap) + 1),
+		pointers:  make(map[*wlserver.Client][]*wlserver.Pointer),
+		keyboards: make(map[*wlserver.Client][]*wlserver.Keyboard),
+	}, nil
+}
+
+func (st *seat) bind(r wlserver.Resource) {
+	res := r.(*wlserver.Seat)
+	res.SetHandler(st)
+	res.SendCapabilities(wlserver.SeatCapabilityPointer | wlserver.SeatCapabilityKeyboard)
+	if res.Version() >= 2 {
+		res.SendName("headless")
+	}
+}
+
+func (st *seat) HandleSeatGetPointer(req wlserver.SeatGetPointerRequest) {
+	c := req.Resource.Client()
+	p := req.Id
+	st.pointers[c] = append(st.pointers[c], p)
+	p.OnDestroy(func() {
+		st.pointers[c] = removePointer(st.pointers[c], p)
+		if len(st.pointers[c]) == 0 {
+			delete(st.pointers, c)
+		}
+	})
+}
+
+func (st *seat) HandleSeatGetKeyboard(req wlserver.SeatGetKeyboardRequest) {
+	c := req.Resource.Client()
+	k := req.Id
+	st.keyboards[c] = append(st.keyboards[c], k)
+	k.OnDestroy(func() {
+		st.keyboards[c] = removeKeyboard(st.keyboards[c], k)
+		if len(st.keyboards[c]) == 0 {
+			delete(st.keyboards, c)
+		}
+	})
+	k.SendKeymap(wlserver.KeyboardKeymapFormatXkbV1, st.keymap.Fd(), st.size)
+	if k.Version() >= 4 {
+		k.SendRepeatInfo(25, 600)
+	}
+	if st.keyboard != nil && st.keyboard.res.Client() == c {
+		k.SendEnter(st.h.server.NextSerial(), st.keyboard.surface.res, st.pressed)
+		k.SendModifiers(st.h.server.NextSerial(), st.modifiers, 0, 0, 0)
+	}
+}
+
+func removePointer(list []*wlserver.Pointer, p *wlserver.Pointer) []*wlserver.Pointer {
+	for i, q := range list {
+		if q == p {
+			return append(list[:i:i], list[i+1:]...)
+		}
+	}
+	return list
+}
+
+func removeKeyboard(list []*wlserver.Keyboard, k *wlserver.Keyboard) []*wlserver.Keyboard {
+	for i, q := range list {
+		if q == k {
+			return append(list[:i:i], list[i+1:]...)
+		}
+	}
+	return list
+}
+
+// motion moves the pointer, sending enter and leave when it crosses the surfaces
+func (st *seat) motion(pt image.Point) {
+	st.cursor = pt
+	s, local := st.h.surfaceAt(pt)
+	if s != st.pointer {
+		if st.pointer != nil {
+			serial := st.h.server.NextSerial()
+			for _, p := range st.pointers[st.pointer.res.Client()] {
+				p.SendLeave(serial, st.pointer.res)
+				st.pointerFrame(p)
+			}
+		}
+		st.pointer = s
+		if s != nil {
+			serial := st.h.server.NextSerial()
+			for _, p := range st.pointers[s.res.Client()] {
+				p.SendEnter(serial, s.res, float32(local.X), float32(local.Y))
+				st.pointerFrame(p)
+			}
+		}
+		return
+	}
+	if s == nil {
+		return
+	}
+	for _, p := range st.pointers[s.res.Client()] {
+		p.SendMotion(st.h.now(), float32(local.X), float32(local.Y))
+		st.pointerFrame(p)
+	}
+}
+
+func (st *seat) pointerFrame(p *wlserver.Pointer) {
+	if p.Version() >= 5 {
+		p.SendFrame()
+	}
+}
+
+// button presses or releases a button, a press activates the window under the pointer
+func (st *seat) button(button uint32, pressed bool) {
+	state := uint32(wlserver.PointerButtonStateReleased)
+	if pressed {
+		state = wlserver.PointerButtonStatePressed
+		if w := xdgOf(st.pointer); w != nil {
+			st.focus(w.toplevelOf())
+		}
+	}
+	if st.pointer == nil {
+		return
+	}
+	serial := st.h.server.NextSerial()
+	for _, p := range st.pointers[st.pointer.res.Client()] {
+		p.SendButton(serial, st.h.now(), button, state)
+		st.pointerFrame(p)
+	}
+}
+
+// axis scrolls by the value
+func (st *seat) axis(axis uint32, value float32) {
+	if st.pointer == nil {
+		return
+	}
+	// a wheel, 10 per step
+	value120 := int32(value * 12)
+	for _, p := range st.pointers[st.pointer.res.Client()] {
+		if p.Version() >= 8 && value120 != 0 {
+			p.SendAxisValue120(axis, value120)
+		}
+		if p.Version() >= 9 {
+			p.SendAxisRelativeDirection(axis, wlserver.PointerAxisRelativeDirectionIdentical)
+		}
+		p.SendAxis(st.h.now(), axis, value)
+		st.pointerFrame(p)
+	}
+}
+
+// key presses or releases a key in the focused window
+func (st *seat) key(code uint32, pressed bool) {
+	state := uint32(wlserver.KeyboardKeyStateReleased)
+	if pressed {
+		state = wlserver.KeyboardKeyStatePressed
+		st.pressed = append(st.pressed, int32(code))
+	} else {
+		for i, k := range st.pressed {
+			if k == int32(code) {
+				st.pressed = append(st.pressed[:i:i], st.pressed[i+1:]...)
+				break
+			}
+		}
+	}
+	mods := st.modifiers
+	if mask, ok := modifierKeys[code]; ok {
+		mods &^= mask
+		for _, k := range st.pressed {
+			mods |= modifierKeys[uint32(k)]
+		}
+	}
+	if st.keyboard == nil {
+		st.modifiers = mods
+		return
+	}
+	c := st.keyboard.res.Client()
+	serial := st.h.server.NextSerial()
+	for _, k := range st.keyboards[c] {
+		k.SendKey(serial, st.h.now(), code, state)
+	}
+	if mods != st.modifiers {
+		st.modifiers = mods
+		serial = st.h.server.NextSerial()
+		for _, k := range st.keyboards[c] {
+			k.SendModifiers(serial, mods, 0, 0, 0)
+		}
+	}
+}
+
+// focus gives the keyboard focus to the toplevel and raises it
+func (st *seat) focus(w *xdgSurface) {
+	if w == st.keyboard {
+		return
+	}
+	if old := st.keyboard; old != nil {
+		serial := st.h.server.NextSerial()
+		for _, k := range st.keyboards[old.res.Client()] {
+			k.SendLeave(serial, old.surface.res)
+		}
+	}
+	st.keyboard = w
+	if w == nil {
+		return
+	}
+	st.h.raise(w)
+	serial := st.h.server.NextSerial()
+	for _, k := range st.keyboards[w.res.Client()] {
+		k.SendEnter(serial, w.surface.res, st.pressed)
+		k.SendModifiers(serial, st.modifiers, 0, 0, 0)
+	}
+}
+
+// windowUnmapped moves the keyboard focus to the topmost toplevel left
+func (st *seat) windowUnmapped(w *xdgSurface) {
+	if st.pointer != nil && xdgOf(st.pointer) == w {
+		st.pointer = nil
+	}
+	if st.keyboard != w {
+		return
+	}
+	st.keyboard = nil
+	for i := len(st.h.windows) - 1; i >= 0; i-- {
+		if st.h.windows[i].toplevel != nil {
+			st.focus(st.h.windows[i])
+			return
+		}
+	}
+}
+
+// surfaceDestroyed forgets the destroyed surface
+func (st *seat) surfaceDestroyed(s *surface) {
+	if st.pointer == s {
+		st.pointer = nil
+	}
+}
@@ -0,0 +1,373 @@
+package main
+
+import (
+	"image"
+
+	"github.com/neurlang/wayland/xdgserver"
+)
+
+// wmBase handles the xdg_wm_base requests
+type wmBase struct {
+	h *headless
+}
+
+func (wm wmBase) HandleWmBaseCreatePositioner(req xdgserver.WmBaseCreatePositionerRequest) {
+	req.Id.SetHandler(&positioner{})
+}
+
+func (wm wmBase) HandleWmBaseGetXdgSurface(req xdgserver.WmBaseGetXdgSurfaceRequest) {
+	s, _ := req.Surface.Handler().(*surface)
+	if s == nil {
+		return
+	}
+	if s.xdg != nil || (s.role != "" && s.role != "xdg_surface") {
+		req.Resource.Client().PostError(req.Resource, xdgserver.WmBaseErrorRole, "wl_surface already has a role")
+		return
+	}
+	if s.image != nil {
+		req.Resource.Client().PostError(req.Id, xdgserver.SurfaceErrorUnconfiguredBuffer, "wl_surface has a buffer attached")
+		return
+	}
+	x := &xdgSurface{h: wm.h, res: req.Id, surface: s}
+	s.role = "xdg_surface"
+	s.xdg = x
+	req.Id.SetHandler(x)
+	req.Id.OnDestroy(func() {
+		x.unmap()
+		s.xdg = nil
+	})
+}
+
+// positioner is a xdg_positioner, the constraint adjustment is ignored as the output is large enough
+type positioner struct {
+	size   image.Point
+	anchor image.Rectangle
+	edge   uint32
+	grav   uint32
+	offset image.Point
+}
+
+func (p *positioner) HandlePositionerSetSize(req xdgserver.PositionerSetSizeRequest) {
+	if req.Width <= 0 || req.Height <= 0 {
+		req.Resource.Client().PostError(req.Resource, xdgserver.PositionerErrorInvalidInput, "invalid size")
+		return
+	}
+	p.size = image.Pt(int(req.Width), int(req.Height))
+}
+
+func (p *positioner) HandlePositionerSetAnchorRect(req xdgserver.PositionerSetAnchorRectRequest) {
+	if req.Width < 0 || req.Height < 0 {
+		req.Resource.Client().PostError(req.Resource, xdgserver.PositionerErrorInvalidInput, "invalid anchor rectangle")
+		return
+	}
+	p.anchor = image.Rect(int(req.X), int(req.Y), int(req.X+req.Width), int(req.Y+req.Height))
+}
+
+func (p *positioner) HandlePositionerSetAnchor(req xdgserver.PositionerSetAnchorRequest) {
+	p.edge = req.Anchor
+}
+
+func (p *positioner) HandlePositionerSetGravity(req xdgserver.PositionerSetGravityRequest) {
+	p.grav = req.Gravity
+}
+
+func (p *positioner) HandlePositionerSetOffset(req xdgserver.PositionerSetOffsetRequest) {
+	p.offset = image.Pt(int(req.X), int(req.Y))
+}
+
+// place returns the popup rectangle relative to the window geometry of the parent
+func (p *positioner) place() image.Rectangle {
+	var pt image.Point
+	switch p.edge {
+	case xdgserver.PositionerAnchorTop, xdgserver.PositionerAnchorTopLeft, xdgserver.PositionerAnchorTopRight:
+		pt.Y = p.anchor.Min.Y
+	case xdgserver.PositionerAnchorBottom, xdgserver.PositionerAnchorBottomLeft, xdgserver.PositionerAnchorBottomRight:
+		pt.Y = p.anchor.Max.Y
+	default:
+		pt.Y = (p.anchor.Min.Y + p.anchor.Max.Y) / 2
+	}
+	switch p.edge {
+	case xdgserver.PositionerAnchorLeft, xdgserver.PositionerAnchorTopLeft, xdgserver.PositionerAnchorBottomLeft:
+		pt.X = p.anchor.Min.X
+	case xdgserver.PositionerAnchorRight, xdgserver.PositionerAnchorTopRight, xdgserver.PositionerAnchorBottomRight:
+		pt.X = p.anchor.Max.X
+	default:
+		pt.X = (p.anchor.Min.X + p.anchor.Max.X) / 2
+	}
+	switch p.grav {
+	case xdgserver.PositionerGravityTop, xdgserver.PositionerGravityTopLeft, xdgserver.PositionerGravityTopRight:
+		pt.Y -= p.size.Y
+	case xdgserver.PositionerGravityBottom, xdgserver.PositionerGravityBottomLeft, xdgserver.PositionerGravityBottomRight:
+	default:
+		pt.Y -= p.size.Y / 2
+	}
+	switch p.grav {
+	case xdgserver.PositionerGravityLeft, xdgserver.PositionerGravityTopLeft, xdgserver.PositionerGravityBottomLeft:
+		pt.X -= p.size.X
+	case xdgserver.PositionerGravityRight, xdgserver.PositionerGravityTopRight, xdgserver.PositionerGravityBottomRight:
+	default:
+		pt.X -= p.size.X / 2
+	}
+	pt = pt.Add(p.offset)
+	return image.Rectangle{pt, pt.Add(p.size)}
+}
+
+// xdgSurface is a xdg_surface with the role of a toplevel or a popup
+type xdgSurface struct {
+	h       *headless
+	res     *xdgserver.Surface
+	surface *surface
+
+	toplevel *xdgserver.Toplevel
+	popup    *xdgserver.Popup
+	parent   *xdgSurface
+	// rect is the position of the toplevel in the output, or of the popup relative to its parent
+	rect image.Rectangle
+
+	geometry    image.Rectangle
+	newGeometry *image.Rectangle
+	title       string
+
+	maximized, fullscreen bool
+	suspended             bool
+	configured            bool
+	sent                  uint32
+	mapped                bool
+}
+
+func (x *xdgSurface) HandleSurfaceGetToplevel(req xdgserver.SurfaceGetToplevelRequest) {
+	if x.toplevel != nil || x.popup != nil {
+		req.Resource.Client().PostError(req.Resource, xdgserver.SurfaceErrorAlreadyConstructed, "xdg_surface already has a role object")
+		return
+	}
+	x.toplevel = req.Id
+	req.Id.SetHandler(x)
+	req.Id.OnDestroy(x.unmap)
+}
+
+func (x *xdgSurface) HandleSurfaceGetPopup(req xdgserver.SurfaceGetPopupRequest) {
+	if x.toplevel != nil || x.popup != nil {
+		req.Resource.Client().PostError(req.Resource, xdgserver.SurfaceErrorAlreadyConstructed, "xdg_surface already has a role object")
+		return
+	}
+	p, _ := req.Positioner.Handler().(*positioner)
+	if p == nil || p.size == (image.Point{}) {
+		req.Resource.Client().PostError(req.Resource, xdgserver.WmBaseErrorInvalidPositioner, "incomplete positioner")
+		return
+	}
+	if req.Parent != nil {
+		x.parent, _ = req.Parent.Handler().(*xdgSurface)
+	}
+	x.popup = req.Id
+	x.rect = p.place()
+	req.Id.SetHandler(x)
+	req.Id.OnDestroy(x.unmap)
+}
+
+func (x *xdgSurface) HandleSurfaceSetWindowGeometry(req xdgserver.SurfaceSetWindowGeometryRequest) {
+	if req.Width <= 0 || req.Height <= 0 {
+		req.Resource.Client().PostError(req.Resource, xdgserver.WmBaseErrorInvalidSurfaceState, "invalid window geometry size")
+		return
+	}
+	r := image.Rect(int(req.X), int(req.Y), int(req.X+req.Width), int(req.Y+req.Height))
+	x.newGeometry = &r
+}
+
+func (x *xdgSurface) HandleSurfaceAckConfigure(req xdgserver.SurfaceAckConfigureRequest) {
+	if req.Serial > x.sent || x.sent == 0 {
+		req.Resource.Client().PostError(req.Resource, xdgserver.WmBaseErrorInvalidSurfaceState, "wrong configure serial")
+		return
+	}
+	x.configured = true
+}
+
+func (x *xdgSurface) HandleToplevelSetTitle(req xdgserver.ToplevelSetTitleRequest) {
+	x.title = req.Title
+	x.h.logf("window title %q", req.Title)
+}
+
+func (x *xdgSurface) HandleToplevelSetMaximized(req xdgserver.ToplevelSetMaximizedRequest) {
+	x.maximized = true
+	x.configure()
+}
+
+func (x *xdgSurface) HandleToplevelUnsetMaximized(req xdgserver.ToplevelUnsetMaximizedRequest) {
+	x.maximized = false
+	x.configure()
+}
+
+func (x *xdgSurface) HandleToplevelSetFullscreen(req xdgserver.ToplevelSetFullscreenRequest) {
+	x.fullscreen = true
+	x.configure()
+}
+
+func (x *xdgSurface) HandleToplevelUnsetFullscreen(req xdgserver.ToplevelUnsetFullscreenRequest) {
+	x.fullscreen = false
+	x.configure()
+}
+
+func (x *xdgSurface) HandlePopupReposition(req xdgserver.PopupRepositionRequest) {
+	if p, ok := req.Positioner.Handler().(*positioner); ok {
+		x.rect = p.place()
+		x.popup.SendRepositioned(req.Token)
+		x.configure()
+		x.h.damage()
+	}
+}
+
+// commitAllowed checks the state committed to the wl_surface
+func (x *xdgSurface) commitAllowed(state *surfaceState) bool {
+	if x.toplevel == nil && x.popup == nil {
+		x.res.Client().PostError(x.res, xdgserver.SurfaceErrorNotConstructed, "xdg_surface has no role object")
+		return false
+	}
+	if state.attached && state.buffer != nil && !x.configured {
+		x.res.Client().PostError(x.res, xdgserver.SurfaceErrorUnconfiguredBuffer, "buffer committed before the first configure was acknowledged")
+		return false
+	}
+	return true
+}
+
+// committed maps the window once it has a buffer, the initial commit is answered by a configure
+func (x *xdgSurface) committed() {
+	if x.newGeometry != nil {
+		x.geometry = *x.newGeometry
+		x.newGeometry = nil
+	}
+	if x.sent == 0 {
+		x.configure()
+		return
+	}
+	switch {
+	case x.surface.image != nil && !x.mapped:
+		x.map_()
+	case x.surface.image == nil && x.mapped:
+		x.unmap()
+	}
+}
+
+// geometryRect returns the window geometry, the whole surface when not set
+func (x *xdgSurface) geometryRect() image.Rectangle {
+	if x.geometry.Empty() {
+		return image.Rectangle{Max: x.surface.size()}
+	}
+	return x.geometry
+}
+
+// configure sends the configure sequence of the role
+func (x *xdgSurface) configure() {
+	switch {
+	case x.toplevel != nil:
+		if x.sent == 0 {
+			if x.toplevel.Version() >= 4 {
+				x.toplevel.SendConfigureBounds(x.h.width, x.h.height)
+			}
+			// there is no window menu and nowhere to minimize to
+			if x.toplevel.Version() >= 5 {
+				x.toplevel.SendWmCapabilities([]int32{xdgserver.ToplevelWmCapabilitiesMaximize,
+					xdgserver.ToplevelWmCapabilitiesFullscreen})
+			}
+		}
+		var width, height int32
+		states := []int32{xdgserver.ToplevelStateActivated}
+		if x.maximized {
+			width, height = x.h.width, x.h.height
+			states = append(states, xdgserver.ToplevelStateMaximized)
+		}
+		if x.fullscreen {
+			width, height = x.h.width, x.h.height
+			states = append(states, xdgserver.ToplevelStateFullscreen)
+		}
+		if x.suspended && x.toplevel.Version() >= 6 {
+			states = append(states, xdgserver.ToplevelStateSuspended)
+		}
+		x.toplevel.SendConfigure(width, height, states)
+	case x.popup != nil:
+		x.popup.SendConfigure(int32(x.rect.Min.X), int32(x.rect.Min.Y), int32(x.rect.Dx()), int32(x.rect.Dy()))
+	default:
+		return
+	}
+	x.sent = x.h.server.NextSerial()
+	x.res.SendConfigure(x.sent)
+}
+
+// suspend tells the mapped toplevels whether they are shown, as if the output was switched off
+func (h *headless) suspend(suspended bool) {
+	for _, w := range h.windows {
+		if w.toplevel != nil && w.suspended != suspended {
+			w.suspended = suspended
+			w.configure()
+		}
+	}
+}
+
+func (x *xdgSurface) map_() {
+	x.mapped = true
+	if x.toplevel != nil {
+		if x.maximized || x.fullscreen {
+			x.rect = image.Rectangle{Max: image.Pt(int(x.h.width), int(x.h.height))}
+		} else {
+			// cascade the new windows
+			x.h.cascade = (x.h.cascade + 32) % (x.h.height / 2)
+			x.rect = image.Rectangle{Min: image.Pt(int(x.h.cascade), int(x.h.cascade))}
+		}
+		x.h.logf("map toplevel %q", x.title)
+	}
+	x.h.windows = append(x.h.windows, x)
+	if x.toplevel != nil {
+		x.h.seat.focus(x)
+	}
+	x.h.damage()
+}
+
+// unmap removes the window and the popups of it from the output
+func (x *xdgSurface) unmap() {
+	if !x.mapped {
+		return
+	}
+	x.mapped = false
+	for i, w := range x.h.windows {
+		if w == x {
+			x.h.windows = append(x.h.windows[:i:i], x.h.windows[i+1:]...)
+			break
+		}
+	}
+	for _, w := range append([]*xdgSurface(nil), x.h.windows...) {
+		if w.parent == x && w.popup != nil {
+			w.popup.SendPopupDone()
+			w.unmap()
+		}
+	}
+	x.h.seat.windowUnmapped(x)
+	x.h.damage()
+}
+
+// origin returns the position of the surface in the output
+func (x *xdgSurface) origin() (int, int) {
+	pt := x.rect.Min
+	if x.popup != nil && x.parent != nil {
+		px, py := x.parent.origin()
+		pt = pt.Add(image.Pt(px, py)).Add(x.parent.geometryRect().Min)
+	}
+	pt = pt.Sub(x.geometryRect().Min)
+	return pt.X, pt.Y
+}
+
+// toplevelOf returns the toplevel of the popup chain
+func (x *xdgSurface) toplevelOf() *xdgSurface {
+	for x.popup != nil && x.parent != nil {
+		x = x.parent
+	}
+	return x
+}
+
+// xdgOf returns the xdg_surface the surface or its subsurface tree belongs to
+func xdgOf(s *surface) *xdgSurface {
+	for s != nil && s.xdg == nil {
+		s = s.parentSurface()
+	}
+	if s == nil {
+		return nil
+	}
+	return s.xdg
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"runtime"
+	"runtime/debug"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wlserver"
+)
+
+// shmHandler handles the wl_shm requests
+type shmHandler struct {
+	h *headless
+}
+
+func (sh shmHandler) HandleShmCreatePool(req wlserver.ShmCreatePoolRequest) {
+	fd := int(req.Fd)
+	defer sys.Close(fd)
+	if req.Size <= 0 {
+		req.Resource.Client().PostError(req.Resource, wlserver.ShmErrorInvalidStride, fmt.Sprintf("invalid size (%d)", req.Size))
+		return
+	}
+	if err := checkFileSize(fd, req.Size); err != nil {
+		req.Resource.Client().PostError(req.Resource, wlserver.ShmErrorInvalidFd, err.Error())
+		return
+	}
+	data, err := sys.Mmap(fd, 0, int(req.Size), sys.ProtRead, sys.MapShared)
+	if err != nil {
+		req.Resource.Client().PostError(req.Resource, wlserver.ShmErrorInvalidFd, fmt.Sprintf("failed mmap fd %d: %v", fd, err))
+		return
+	}
+	// the fd is kept to map the pool again when it grows
+	fd, err = sys.DupCloexec(fd)
+	if err != nil {
+		sys.Munmap(data)
+		req.Resource.Client().PostError(req.Resource, wlserver.ShmErrorInvalidFd, err.Error())
+		return
+	}
+	pool := &shmPool{res: req.Id, fd: fd, data: data, refs: 1}
+	req.Id.SetHandler(pool)
+	req.Id.OnDestroy(pool.unref)
+}
+
+// checkFileSize fails when the pool is larger than its file, the pages past the end of the file
+// cannot be read. Files that are not regular, such as devices, are not checked.
+func checkFileSize(fd int, size int32) error {
+	if n, ok := sys.RegularFileSize(fd); ok && n < int64(size) {
+		return fmt.Errorf("pool size %d larger than the file (%d)", size, n)
+	}
+	return nil
+}
+
+// shmPool is a wl_shm_pool, the memory is unmapped once the pool and all its buffers are destroyed
+type shmPool struct {
+	res  *wlserver.ShmPool
+	fd   int
+	data []byte
+	refs int
+}
+
+func (p *shmPool) unref() {
+	p.refs--
+	if p.refs == 0 {
+		sys.Munmap(p.data)
+		sys.Close(p.fd)
+		p.data = nil
+	}
+}
+
+func (p *shmPool) HandleShmPoolCreateBuffer(req wlserver.ShmPoolCreateBufferRequest) {
+	c := req.Resource.Client()
+	if req.Format != wlserver.ShmFormatArgb8888 && req.Format != wlserver.ShmFormatXrgb8888 {
+		c.PostError(req.Resource, wlserver.ShmErrorInvalidFormat, fmt.Sprintf("invalid format 0x%x", req.Format))
+		return
+	}
+	if req.Offset < 0 || req.Width <= 0 || req.Height <= 0 || req.Stride < 4*req.Width ||
+		int64(req.Offset)+int64(req.Stride)*int64(req.Height) > int64(len(p.data)) {
+		c.PostError(req.Resource, wlserver.ShmErrorInvalidStride,
+			fmt.Sprintf("invalid width %d, height %d, stride %d or offset %d", req.Width, req.Height, req.Stride, req.Offset))
+		return
+	}
+	p.refs++
+	b := &shmBuffer{
+		pool:   p,
+		offset: int(req.Offset),
+		width:  int(req.Width),
+		height: int(req.Height),
+		stride: int(req.Stride),
+		opaque: req.Format == wlserver.ShmFormatXrgb8888,
+	}
+	req.Id.SetHandler(b)
+	req.Id.OnDestroy(p.unref)
+}
+
+func (p *shmPool) HandleShmPoolResize(req wlserver.ShmPoolResizeRequest) {
+	if int(req.Size) < len(p.data) {
+		req.Resource.Client().PostError(req.Resource, wlserver.ShmErrorInvalidFd, "shrinking pool invalid")
+		return
+	}
+	if err := checkFileSize(p.fd, req.Size); err != nil {
+		req.Resource.Client().PostError(req.Resource, wlserver.ShmErrorInvalidFd, err.Error())
+		return
+	}
+	data, err := sys.Mmap(p.fd, 0, int(req.Size), sys.ProtRead, sys.MapShared)
+	if err != nil {
+		req.Resource.Client().PostError(req.Resource, wlserver.ShmErrorInvalidFd, fmt.Sprintf("failed mmap fd %d: %v", p.fd, err))
+		return
+	}
+	sys.Munmap(p.data)
+	p.data = data
+}
+
+// shmBuffer is a wl_buffer of a wl_shm_pool
+type shmBuffer struct {
+	pool                          *shmPool
+	offset, width, height, stride int
+	opaque                        bool
+}
+
+// image returns a copy of the contents of the buffer. The ARGB8888 and XRGB8888 formats are
+// premultiplied little endian BGRA, as image.RGBA is premultiplied the bytes are just reordered.
+// The client may truncate the file of the pool at any time, reading the pages past its end
+// faults, as wl_shm_buffer_begin_access of libwayland the fault fails the copy instead of
+// crashing the compositor.
+func (b *shmBuffer) image() (img *image.RGBA, err error) {
+	defer debug.SetPanicOnFault(debug.SetPanicOnFault(true))
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(runtime.Error); !ok {
+				panic(r)
+			}
+			img, err = nil, fmt.Errorf("error accessing SHM buffer: %v", r)
+		}
+	}()
+	img = image.NewRGBA(image.Rect(0, 0, b.width, b.height))
+	for y := 0; y < b.height; y++ {
+		src := b.pool.data[b.offset+y*b.stride : b.offset+y*b.stride+4*b.width]
+		dst := img.Pix[y*img.Stride : y*img.Stride+4*b.width]
+		for x := 0; x < len(src); x += 4 {
+			dst[x+0] = src[x+2]
+			dst[x+1] = src[x+1]
+			dst[x+2] = src[x+0]
+			if b.opaque {
+				dst[x+3] = 0xff
+			} else {
+				dst[x+3] = src[x+3]
+			}
+		}
+	}
+	return img, nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"image"
+	"math"
+
+	"github.com/neurlang/wayland/viewporterserver"
+)
+
+// viewporter handles the wp_viewporter requests
+type viewporterHandler struct {
+	h *headless
+}
+
+func (v viewporterHandler) HandleViewporterGetViewport(req viewporterserver.ViewporterGetViewportRequest) {
+	s, _ := req.Surface.Handler().(*surface)
+	if s == nil {
+		return
+	}
+	if s.viewport != nil {
+		req.Resource.Client().PostError(req.Resource, viewporterserver.ViewporterErrorViewportExists, "surface already has a viewport")
+		return
+	}
+	vp := &viewport{res: req.Id, surface: s}
+	s.viewport = vp
+	req.Id.SetHandler(vp)
+	req.Id.OnDestroy(func() {
+		if vp.surface == nil {
+			return
+		}
+		// the crop and scale is removed by the next commit
+		vp.surface.viewport = nil
+		vp.surface.pending.sourceSet = true
+		vp.surface.pending.source = cropRect{}
+		vp.surface.pending.destinationSet = true
+		vp.surface.pending.destination = image.Point{}
+	})
+}
+
+// cropRect is the source rectangle of a viewport in the surface coordinates, unset when empty
+type cropRect struct {
+	x, y, width, height float32
+}
+
+func (r cropRect) unset() bool {
+	return r.width <= 0 || r.height <= 0
+}
+
+// viewport is a wp_viewport, the surface shows its source rectangle scaled to the destination size
+type viewport struct {
+	res     *viewporterserver.Viewport
+	surface *surface
+}
+
+// alive checks that the surface of the viewport still exists
+func (vp *viewport) alive() bool {
+	if vp.surface == nil || vp.surface.gone {
+		vp.res.Client().PostError(vp.res, viewporterserver.ViewportErrorNoSurface, "the surface was destroyed")
+		return false
+	}
+	return true
+}
+
+func (vp *viewport) HandleViewportSetSource(req viewporterserver.ViewportSetSourceRequest) {
+	if !vp.alive() {
+		return
+	}
+	r := cropRect{req.X, req.Y, req.Width, req.Height}
+	if r == (cropRect{-1, -1, -1, -1}) {
+		r = cropRect{}
+	} else if r.x < 0 || r.y < 0 || r.width <= 0 || r.height <= 0 {
+		vp.res.Client().PostError(vp.res, viewporterserver.ViewportErrorBadValue, "invalid source rectangle")
+		return
+	}
+	vp.surface.pending.sourceSet = true
+	vp.surface.pending.source = r
+}
+
+func (vp *viewport) HandleViewportSetDestination(req viewporterserver.ViewportSetDestinationRequest) {
+	if !vp.alive() {
+		return
+	}
+	d := image.Pt(int(req.Width), int(req.Height))
+	if req.Width == -1 && req.Height == -1 {
+		d = image.Point{}
+	} else if req.Width <= 0 || req.Height <= 0 {
+		vp.res.Client().PostError(vp.res, viewporterserver.ViewportErrorBadValue, "invalid destination size")
+		return
+	}
+	vp.surface.pending.destinationSet = true
+	vp.surface.pending.destination = d
+}
+
+// checkViewport posts the errors of a committed crop and scale the buffer does not allow
+func (s *surface) checkViewport() {
+	if s.viewport == nil || s.image == nil || s.source.unset() {
+		return
+	}
+	unscaled := s.image.Rect.Size().Div(int(s.scale))
+	if s.destination == (image.Point{}) && (s.source.width != float32(math.Trunc(float64(s.source.width))) ||
+		s.source.height != float32(math.Trunc(float64(s.source.height)))) {
+		s.viewport.res.Client().PostError(s.viewport.res, viewporterserver.ViewportErrorBadSize, "source size is not integer without a destination")
+		return
+	}
+	if s.source.x+s.source.width > float32(unscaled.X) || s.source.y+s.source.height > float32(unscaled.Y) {
+		s.viewport.res.Client().PostError(s.viewport.res, viewporterserver.ViewportErrorOutOfBuffer, "source rectangle extends outside of the buffer")
+	}
+}
+
+// contents returns the image of the surface in the output coordinates, cropped and scaled by
+// the viewport and the buffer scale, sampling the nearest pixels
+func (s *surface) contents() *image.RGBA {
+	size := s.size()
+	src := s.source
+	if src.unset() {
+		unscaled := s.image.Rect.Size().Div(int(s.scale))
+		src = cropRect{0, 0, float32(unscaled.X), float32(unscaled.Y)}
+	}
+	if s.scale == 1 && size == s.image.Rect.Size() && src == (cropRect{0, 0, float32(size.X), float32(size.Y)}) {
+		return s.image
+	}
+	scale := float32(s.scale)
+	dst := image.NewRGBA(image.Rectangle{Max: size})
+	for y := 0; y < size.Y; y++ {
+		by := int((src.y + (float32(y)+0.5)*src.height/float32(size.Y)) * scale)
+		for x := 0; x < size.X; x++ {
+			bx := int((src.x + (float32(x)+0.5)*src.width/float32(size.X)) * scale)
+			dst.SetRGBA(x, y, s.image.RGBAAt(bx, by))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,183 @@
+// go-wayland-replay replays the compositor side of a protocol recording against a client.
+//
+// Usage:
+//
+//	go-wayland-replay [-timeout 5s] [-strict] [-v] [-display name] recording client [args...]
+//
+// The recording is made by running the client with WAYLAND_RECORD=recording on the machine
+// where the bug shows up. The client is started connected to one end of a socketpair, passed
+// in the WAYLAND_SOCKET environment variable as libwayland clients and wl.Connect expect it,
+// and gets the recorded events in response to the recorded requests. The replay fails when the client
+// sends a different request, see package wlreplay.
+//
+// Clients that do not support WAYLAND_SOCKET are replayed with -display: they are started with
+// WAYLAND_DISPLAY set to the name and connect to the socket of that name in XDG_RUNTIME_DIR.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wlreplay"
+
+	// the descriptions of the interfaces name the replayed messages
+	_ "github.com/neurlang/wayland/xdg"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("go-wayland-replay: ")
+
+	timeout := flag.Duration("timeout", 5*time.Second, "time to wait for every recorded request")
+	strict := flag.Bool("strict", false, "compare the arguments of the requests too")
+	verbose := flag.Bool("v", false, "log every replayed message")
+	display := flag.String("display", "", "serve the client on this WAYLAND_DISPLAY socket instead of WAYLAND_SOCKET")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: go-wayland-replay [flags] recording client [args...]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() < 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	recording, err := os.Open(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer recording.Close()
+
+	cmd := exec.Command(flag.Arg(1), flag.Args()[2:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	var server *net.UnixConn
+	var replayErr error
+	if *display == "" {
+		server, err = startSocketpair(cmd)
+	} else {
+		server, err = startListening(cmd, *display, *timeout)
+	}
+	if err != nil {
+		if cmd.Process == nil {
+			log.Fatal(err)
+		}
+		replayErr = err
+	} else {
+		p := &wlreplay.Replayer{Timeout: *timeout, Strict: *strict}
+		if *verbose {
+			p.Log = os.Stderr
+		}
+		replayErr = p.Replay(server, recording)
+		server.Close()
+	}
+
+	// the client notices the closed connection, give it the time to exit
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+	var exitErr error
+	select {
+	case exitErr = <-exited:
+	case <-time.After(*timeout):
+		cmd.Process.Kill()
+		exitErr = <-exited
+	}
+
+	if replayErr != nil {
+		var exit *exec.ExitError
+		if errors.As(exitErr, &exit) {
+			log.Printf("client: %v", exitErr)
+		}
+		log.Fatal(replayErr)
+	}
+	log.Printf("replayed %s", flag.Arg(0))
+}
+
+// startSocketpair starts the client connected to one end of a socketpair and returns the other end
+func startSocketpair(cmd *exec.Cmd) (*net.UnixConn, error) {
+	fds, err := sys.Socketpair()
+	if err != nil {
+		return nil, err
+	}
+	server, err := unixConn(fds[0], "server")
+	if err != nil {
+		sys.Close(fds[1])
+		return nil, err
+	}
+	client := os.NewFile(uintptr(fds[1]), "client")
+	defer client.Close()
+
+	// the first extra file is fd 3 of the client
+	cmd.ExtraFiles = []*os.File{client}
+	cmd.Env = append(environ(), "WAYLAND_SOCKET=3")
+	if err := cmd.Start(); err != nil {
+		server.Close()
+		return nil, err
+	}
+	return server, nil
+}
+
+// startListening starts the client with WAYLAND_DISPLAY set to name and returns its connection
+// to the socket of that name
+func startListening(cmd *exec.Cmd, name string, timeout time.Duration) (*net.UnixConn, error) {
+	path := name
+	if !filepath.IsAbs(path) {
+		dir := os.Getenv("XDG_RUNTIME_DIR")
+		if dir == "" {
+			return nil, errors.New("XDG_RUNTIME_DIR is not set")
+		}
+		path = filepath.Join(dir, name)
+	}
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	// the socket is removed once the client is connected
+	defer listener.Close()
+
+	// a later WAYLAND_DISPLAY overrides the one of the environment
+	cmd.Env = append(environ(), "WAYLAND_DISPLAY="+name)
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	listener.SetDeadline(time.Now().Add(timeout))
+	server, err := listener.AcceptUnix()
+	if err != nil {
+		return nil, fmt.Errorf("client did not connect: %w", err)
+	}
+	return server, nil
+}
+
+// environ returns the environment without the connection and the recording of the replaying process
+func environ() (env []string) {
+	for _, v := range os.Environ() {
+		if strings.HasPrefix(v, "WAYLAND_SOCKET=") || strings.HasPrefix(v, "WAYLAND_RECORD=") {
+			continue
+		}
+		env = append(env, v)
+	}
+	return env
+}
+
+func unixConn(fd int, name string) (*net.UnixConn, error) {
+	f := os.NewFile(uintptr(fd), name)
+	defer f.Close()
+	conn, err := net.FileConn(f)
+	if err != nil {
+		return nil, err
+	}
+	return conn.(*net.UnixConn), nil
+}
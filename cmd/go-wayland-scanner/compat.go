@@ -0,0 +1,43 @@
+package main
+
+// The core wl package was originally produced by an external scanner and then edited
+// by hand, and the unstable packages were produced by another external scanner. Callers
+// depend on the names they exported, so the generator keeps emitting them for the
+// interfaces concerned. None of them apply to other protocols.
+
+// legacySelfFields maps an event to the name of an extra field that refers back to the
+// proxy the event was received on
+var legacySelfFields = map[string]string{
+	"wl_callback.done":  "C",
+	"wl_buffer.release": "B",
+	"wl_pointer.motion": "P",
+	"wl_pointer.button": "P",
+}
+
+// legacyProxyFields lists extra exported fields of a proxy struct
+var legacyProxyFields = map[string][]string{
+	"wl_registry": {"Ctx *Context"},
+	"wl_surface":  {"UserData interface{}"},
+}
+
+// legacyContextFields lists the proxy fields that are initialized to the Context in the constructor
+var legacyContextFields = map[string]string{
+	"wl_registry": "Ctx",
+}
+
+// legacyPrivateFds lists events whose fd argument is unexported and read using an accessor
+var legacyPrivateFds = map[string]bool{
+	"wl_keyboard.keymap": true,
+}
+
+// legacyFieldNames renames the event fields holding the given arguments
+var legacyFieldNames = map[string]string{
+	"wl_data_device.data_offer.id": "Offer",
+	"wl_data_device.enter.id":      "Offer",
+	"wl_data_device.selection.id":  "Offer",
+}
+
+// legacyEntryNames renames the constants of the given enum entries
+var legacyEntryNames = map[string]string{
+	"zwp_linux_buffer_params_v1.error.plane_idx": "PlaneIDx",
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+)
+
+const (
+	testingImport = "testing"
+	wltestImport  = "github.com/neurlang/wayland/wltest"
+)
+
+// generateFuzz emits a test file with a native fuzz target feeding arbitrary event messages to
+// the Dispatch of every interface having events, with a handler added for every event so that
+// all the arguments get decoded. The wl package cannot import wltest, its fuzz target uses the
+// socketpair helper of its own tests instead.
+func (g *generator) generateFuzz() ([]byte, error) {
+	g.buf.Reset()
+	g.imports = map[string]string{testingImport: ""}
+
+	g.printf("// fuzzHandler handles every event of the protocol\n")
+	g.printf("type fuzzHandler struct{}\n\n")
+	for _, iface := range g.proto.Interfaces {
+		name := g.ifaceName(iface.Name)
+		for _, ev := range iface.Events {
+			evName := name + camel(ev.Name)
+			g.printf("func (fuzzHandler) Handle%s(%sEvent) {}\n", evName, evName)
+		}
+	}
+	g.printf("\n")
+
+	g.printf("// fuzzProxy is a proxy receiving the fuzzed events\n")
+	g.printf("type fuzzProxy interface {\n")
+	g.printf("%s\n", g.wl("Dispatcher"))
+	g.printf("Interface() *%s\n", g.wl("Interface"))
+	g.printf("}\n\n")
+
+	g.printf("// FuzzDispatch decodes arbitrary event messages by the Dispatch of every interface with events\n")
+	g.printf("func FuzzDispatch(f *testing.F) {\n")
+	if g.self {
+		g.printf("ctx, _ := socketpair(f)\n")
+	} else {
+		g.imports[wltestImport] = ""
+		g.printf("display, server, err := wltest.New()\n")
+		g.printf("if err != nil {\nf.Fatal(err)\n}\n")
+		g.printf("defer server.Close()\n")
+		g.printf("ctx := display.Context()\n")
+	}
+	g.printf("\nvar h fuzzHandler\n")
+	g.printf("var proxies []fuzzProxy\n")
+	for _, iface := range g.proto.Interfaces {
+		if len(iface.Events) == 0 {
+			continue
+		}
+		name := g.ifaceName(iface.Name)
+		g.printf("{\n")
+		g.printf("p := New%s(ctx)\n", name)
+		for _, ev := range iface.Events {
+			g.printf("p.Add%sHandler(h)\n", camel(ev.Name))
+		}
+		g.printf("proxies = append(proxies, p)\n")
+		g.printf("}\n")
+	}
+	g.printf("\n")
+	g.printf("for i, p := range proxies {\n")
+	g.printf("for opcode := range p.Interface().Events {\n")
+	g.printf("f.Add(uint8(i), uint16(opcode), make([]byte, 64))\n")
+	g.printf("}\n")
+	g.printf("}\n")
+	g.printf("f.Fuzz(func(t *testing.T, index uint8, opcode uint16, data []byte) {\n")
+	g.printf("p := proxies[int(index)%%len(proxies)]\n")
+	g.printf("p.Dispatch(&%s{Opcode: uint32(opcode), Data: data})\n", g.wl("Event"))
+	g.printf("})\n")
+	g.printf("}\n")
+	body := g.buf.Bytes()
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by go-wayland-scanner; DO NOT EDIT.\n")
+	fmt.Fprintf(&out, "// XML file: %s\n", g.source)
+	fmt.Fprintf(&out, "\npackage %s\n\n", g.pkg)
+	var paths []string
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	fmt.Fprintf(&out, "import (\n")
+	for _, path := range paths {
+		fmt.Fprintf(&out, "\t%q\n", path)
+	}
+	fmt.Fprintf(&out, ")\n\n")
+	out.Write(body)
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return out.Bytes(), err
+	}
+	return src, nil
+}
@@ -0,0 +1,678 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+const (
+	wlImport  = "github.com/neurlang/wayland/wl"
+	xdgImport = "github.com/neurlang/wayland/xdg"
+)
+
+// xdgInterfaces are the interfaces of the stable xdg-shell protocol living in the xdg package
+var xdgInterfaces = map[string]bool{
+	"xdg_wm_base":    true,
+	"xdg_positioner": true,
+	"xdg_surface":    true,
+	"xdg_toplevel":   true,
+	"xdg_popup":      true,
+}
+
+var goKeywords = map[string]string{
+	"break": "brk", "case": "cas", "chan": "ch", "const": "cnst", "continue": "cont",
+	"default": "def", "defer": "dfr", "else": "els", "fallthrough": "fallthru", "for": "fr",
+	"func": "fn", "go": "g", "goto": "gt", "if": "cond", "import": "imp",
+	"interface": "iface", "map": "mp", "package": "pkg", "range": "rng", "return": "ret",
+	"select": "sel", "struct": "strct", "switch": "sw", "type": "typ", "var": "vr",
+}
+
+// generator emits the Go bindings of a single protocol
+type generator struct {
+	proto    *Protocol
+	source   string
+	pkg      string
+	prefixes []string
+	client   string
+	self     bool
+	local    map[string]bool
+	imports  map[string]string
+	buf      bytes.Buffer
+}
+
+func newGenerator(proto *Protocol, source, pkg string, prefixes []string) *generator {
+	g := &generator{
+		proto:    proto,
+		source:   source,
+		pkg:      strings.ReplaceAll(pkg, "_", ""),
+		prefixes: prefixes,
+		local:    make(map[string]bool),
+		imports:  make(map[string]string),
+	}
+	g.self = g.pkg == "wl"
+	for _, iface := range proto.Interfaces {
+		g.local[iface.Name] = true
+	}
+	return g
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// camel converts a snake_case protocol identifier to CamelCase
+func camel(s string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(s, "_") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]))
+		b.WriteString(part[1:])
+	}
+	return b.String()
+}
+
+// lowerCamel converts a snake_case protocol identifier to lowerCamelCase
+func lowerCamel(s string) string {
+	c := camel(s)
+	if c == "" {
+		return c
+	}
+	return strings.ToLower(c[:1]) + c[1:]
+}
+
+// paramName returns the name of a request parameter, avoiding Go keywords and
+// the identifiers used by the generated method bodies
+func paramName(s string) string {
+	n := lowerCamel(s)
+	if k, ok := goKeywords[n]; ok {
+		return k
+	}
+	switch n {
+	case "p", "err":
+		return n + "Arg"
+	}
+	return n
+}
+
+func trimPrefixes(name string, prefixes []string) string {
+	for _, p := range prefixes {
+		if p != "" && strings.HasPrefix(name, p) {
+			return strings.TrimPrefix(name, p)
+		}
+	}
+	return name
+}
+
+// wl qualifies an identifier from the wl package
+func (g *generator) wl(name string) string {
+	if g.self {
+		return name
+	}
+	g.imports[wlImport] = "wl"
+	return "wl." + name
+}
+
+// ifaceName is the Go type name of an interface defined in this protocol
+func (g *generator) ifaceName(name string) string {
+	return camel(trimPrefixes(name, g.prefixes))
+}
+
+// typeName is the Go type of an object argument, qualified when it lives in another package.
+// Interfaces unknown to the generator are represented by the generic Proxy.
+func (g *generator) typeName(iface string) string {
+	switch {
+	case iface == "":
+		return g.wl("Proxy")
+	case g.local[iface]:
+		return "*" + g.ifaceName(iface)
+	case strings.HasPrefix(iface, "wl_"):
+		return "*" + g.wl(camel(strings.TrimPrefix(iface, "wl_")))
+	case xdgInterfaces[iface] && g.pkg != "xdg":
+		g.imports[xdgImport] = "xdg"
+		return "*xdg." + camel(strings.TrimPrefix(iface, "xdg_"))
+	}
+	return g.wl("Proxy")
+}
+
+// goType is the Go type of a non-object argument
+func goType(typ string) string {
+	switch typ {
+	case "int":
+		return "int32"
+	case "uint":
+		return "uint32"
+	case "fixed":
+		return "float32"
+	case "string":
+		return "string"
+	case "array":
+		return "[]int32"
+	case "fd":
+		return "uintptr"
+	}
+	return ""
+}
+
+// putMethod is the wl.Request method writing a non-object argument
+func putMethod(typ string) string {
+	switch typ {
+	case "int":
+		return "PutInt32"
+	case "uint":
+		return "PutUint32"
+	case "fixed":
+		return "PutFloat32"
+	case "string":
+		return "PutString"
+	case "array":
+		return "PutArray"
+	case "fd":
+		return "PutFd"
+	}
+	return ""
+}
+
+// summary joins the lines of a summary attribute, some span several lines in the XML
+func summary(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func (g *generator) doc(title string, d Description, args []Arg) {
+	if d.Summary != "" {
+		g.printf("// %s: %s\n", title, summary(d.Summary))
+	} else {
+		g.printf("// %s:\n", title)
+	}
+	lines := docLines(d.Text)
+	if len(lines) > 0 {
+		g.printf("//\n")
+		for _, l := range lines {
+			g.printf("// %s\n", l)
+		}
+	}
+	var documented []Arg
+	for _, a := range args {
+		if a.Summary != "" {
+			documented = append(documented, a)
+		}
+	}
+	if len(documented) > 0 {
+		g.printf("//\n")
+		for _, a := range documented {
+			g.printf("//  %s: %s\n", lowerCamel(a.Name), summary(a.Summary))
+		}
+	}
+}
+
+func (g *generator) generate() ([]byte, error) {
+	for i := range g.proto.Interfaces {
+		g.iface(&g.proto.Interfaces[i])
+	}
+	g.registerInterfaces()
+	body := g.buf.Bytes()
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by go-wayland-scanner; DO NOT EDIT.\n")
+	fmt.Fprintf(&out, "// XML file: %s\n", g.source)
+	if c := docLines(g.proto.Copyright); len(c) > 0 {
+		fmt.Fprintf(&out, "//\n// %s Protocol Copyright:\n//\n", camel(g.proto.Name))
+		for _, l := range c {
+			fmt.Fprintf(&out, "// %s\n", l)
+		}
+	}
+	fmt.Fprintf(&out, "\npackage %s\n\n", g.pkg)
+
+	var paths []string
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	if g.needsSync() {
+		paths = append(paths, "sync")
+	}
+	sort.Strings(paths)
+	if len(paths) > 0 {
+		fmt.Fprintf(&out, "import (\n")
+		for _, path := range paths {
+			fmt.Fprintf(&out, "\t%q\n", path)
+		}
+		fmt.Fprintf(&out, ")\n\n")
+	}
+	out.Write(body)
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return out.Bytes(), err
+	}
+	return src, nil
+}
+
+func (g *generator) needsSync() bool {
+	for _, iface := range g.proto.Interfaces {
+		if len(iface.Events) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *generator) iface(iface *Interface) {
+	name := g.ifaceName(iface.Name)
+
+	g.doc(name, iface.Description, nil)
+	g.printf("type %s struct {\n", name)
+	g.printf("%s\n", g.wl("BaseProxy"))
+	if len(iface.Events) > 0 {
+		g.printf("mu sync.RWMutex\n")
+		for _, ev := range iface.Events {
+			g.printf("%sHandlers []%s%sHandler\n", lowerCamel(ev.Name), name, camel(ev.Name))
+		}
+	}
+	if extra := legacyProxyFields[iface.Name]; len(extra) > 0 {
+		g.printf("\n")
+		for _, f := range extra {
+			g.printf("%s\n", f)
+		}
+	}
+	g.printf("}\n\n")
+
+	g.printf("// New%s creates a new %s proxy registered in the Context\n", name, iface.Name)
+	g.printf("func New%s(ctx *%s) *%s {\n", name, g.wl("Context"), name)
+	g.printf("ret := new(%s)\n", name)
+	if f, ok := legacyContextFields[iface.Name]; ok {
+		g.printf("ret.%s = ctx\n", f)
+	}
+	g.printf("ctx.Register(ret)\n")
+	g.printf("return ret\n")
+	g.printf("}\n\n")
+
+	for i := range iface.Requests {
+		g.request(iface, name, i)
+	}
+	for i := range iface.Enums {
+		g.enum(iface, name, &iface.Enums[i])
+	}
+	for i := range iface.Events {
+		g.event(iface, name, &iface.Events[i])
+	}
+	if len(iface.Events) > 0 {
+		g.dispatch(iface, name)
+	}
+	g.sinceVersions(iface, name)
+	g.describe(iface, name)
+}
+
+func (g *generator) request(iface *Interface, name string, opcode int) {
+	req := &iface.Requests[opcode]
+	method := camel(req.Name)
+
+	// puts marshal the arguments without boxing them, see wl.Context MarshalRequest
+	var params, puts, checks, bound []string
+	ret := ""
+	for _, a := range req.Args {
+		pn := paramName(a.Name)
+		switch a.Type {
+		case "new_id":
+			if a.Interface == "" {
+				params = append(params, "iface string", "version uint32", pn+" "+g.wl("Proxy"))
+				puts = append(puts, "r.PutString(iface)", "r.PutUint32(version)", "r.PutNewId("+pn+")")
+				checks = append(checks, fmt.Sprintf("if err := %s(%s); err != nil {\nreturn err\n}", g.wl("CheckNewId"), pn))
+				// the proxy is bound at the version once the request is queued
+				bound = append(bound, pn+".SetVersion(version)")
+				continue
+			}
+			ret = g.typeName(a.Interface)
+			puts = append(puts, "r.PutNewId(ret)")
+		case "object":
+			typ := g.typeName(a.Interface)
+			params = append(params, pn+" "+typ)
+			if !strings.HasPrefix(typ, "*") {
+				puts = append(puts, "r.PutProxy("+pn+")")
+				break
+			}
+			puts = append(puts, fmt.Sprintf("if %s != nil {\nr.PutObject(%s.Id())\n} else {\nr.PutObject(0)\n}", pn, pn))
+		default:
+			params = append(params, pn+" "+goType(a.Type))
+			puts = append(puts, fmt.Sprintf("r.%s(%s)", putMethod(a.Type), pn))
+		}
+	}
+
+	g.doc(method, req.Description, req.Args)
+	send := fmt.Sprintf("p.Context().MarshalRequest(p, %d, nil)", opcode)
+	if len(puts) > 0 {
+		send = fmt.Sprintf("p.Context().MarshalRequest(p, %d, func(r *%s) {\n%s\n})", opcode, g.wl("Request"), strings.Join(puts, "\n"))
+	}
+	switch {
+	case ret != "":
+		g.printf("func (p *%s) %s(%s) (%s, error) {\n", name, method, strings.Join(params, ", "), ret)
+		g.checkVersion(req, opcode, "nil, ")
+		g.printf("ret := %s(p.Context())\n", constructor(ret))
+		g.printf("ret.SetQueue(p.Queue())\n")
+		g.printf("ret.SetVersion(p.Version())\n")
+		if req.isDestructor() {
+			g.printf("err := %s\n", send)
+			g.printf("p.Unregister()\n")
+			g.printf("return ret, err\n")
+		} else {
+			g.printf("return ret, %s\n", send)
+		}
+	case req.isDestructor():
+		g.printf("func (p *%s) %s(%s) error {\n", name, method, strings.Join(params, ", "))
+		g.checkVersion(req, opcode, "")
+		g.printf("err := %s\n", send)
+		g.printf("p.Unregister()\n")
+		g.printf("return err\n")
+	default:
+		g.printf("func (p *%s) %s(%s) error {\n", name, method, strings.Join(params, ", "))
+		g.checkVersion(req, opcode, "")
+		for _, st := range checks {
+			g.printf("%s\n", st)
+		}
+		if len(bound) == 0 {
+			g.printf("return %s\n", send)
+			break
+		}
+		g.printf("if err := %s; err != nil {\nreturn err\n}\n", send)
+		for _, st := range bound {
+			g.printf("%s\n", st)
+		}
+		g.printf("return nil\n")
+	}
+	g.printf("}\n\n")
+}
+
+// checkVersion emits the check of the proxy version for the requests added after version 1
+func (g *generator) checkVersion(req *Message, opcode int, zero string) {
+	if req.since() <= 1 {
+		return
+	}
+	g.printf("if err := %s(p, %d); err != nil {\n", g.wl("CheckRequestVersion"), opcode)
+	g.printf("return %serr\n", zero)
+	g.printf("}\n")
+}
+
+// constructor returns the constructor of a proxy type, such as wl.NewSurface for *wl.Surface
+func constructor(typ string) string {
+	typ = strings.TrimPrefix(typ, "*")
+	if i := strings.IndexByte(typ, '.'); i >= 0 {
+		return typ[:i+1] + "New" + typ[i+1:]
+	}
+	return "New" + typ
+}
+
+func (g *generator) enum(iface *Interface, name string, e *Enum) {
+	prefix := name + camel(e.Name)
+	g.doc(prefix, e.Description, nil)
+	g.printf("const (\n")
+	for _, entry := range e.Entries {
+		entryName := entryConstName(iface.Name+"."+e.Name, &entry)
+		if entry.Summary != "" {
+			g.printf("// %s%s: %s\n", prefix, entryName, summary(entry.Summary))
+		}
+		g.printf("%s%s = %s\n", prefix, entryName, entry.Value)
+	}
+	g.printf(")\n\n")
+}
+
+// entryConstName is the suffix of the constant of an enum entry
+func entryConstName(key string, entry *Entry) string {
+	if n, ok := legacyEntryNames[key+"."+entry.Name]; ok {
+		return n
+	}
+	return camel(entry.Name)
+}
+
+// eventFieldName is the name of the event struct field holding an argument
+func eventFieldName(key string, a *Arg) string {
+	if f, ok := legacyFieldNames[key+"."+a.Name]; ok {
+		return f
+	}
+	return camel(a.Name)
+}
+
+func (g *generator) event(iface *Interface, name string, ev *Message) {
+	evName := name + camel(ev.Name)
+	key := iface.Name + "." + ev.Name
+
+	g.doc(evName+"Event", ev.Description, nil)
+	g.printf("type %sEvent struct {\n", evName)
+	if f, ok := legacySelfFields[key]; ok {
+		g.printf("%s *%s\n", f, name)
+	}
+	for i := range ev.Args {
+		a := &ev.Args[i]
+		field := eventFieldName(key, a)
+		switch a.Type {
+		case "new_id", "object":
+			g.printf("%s %s\n", field, g.typeName(a.Interface))
+		case "fd":
+			if legacyPrivateFds[key] {
+				field = lowerCamel(a.Name)
+			}
+			g.printf("%s uintptr\n", field)
+			g.printf("%sError error\n", field)
+		default:
+			g.printf("%s %s\n", field, goType(a.Type))
+		}
+	}
+	g.printf("}\n\n")
+
+	g.printf("// %sHandler is implemented by the receivers of %sEvent\n", evName, evName)
+	g.printf("type %sHandler interface {\n", evName)
+	g.printf("Handle%s(%sEvent)\n", evName, evName)
+	g.printf("}\n\n")
+
+	field := lowerCamel(ev.Name) + "Handlers"
+	g.printf("// Add%sHandler adds a handler for %sEvent\n", camel(ev.Name), evName)
+	g.printf("func (p *%s) Add%sHandler(h %sHandler) {\n", name, camel(ev.Name), evName)
+	g.printf("if h == nil {\nreturn\n}\n\n")
+	g.printf("p.mu.Lock()\n")
+	g.printf("p.%s = append(p.%s, h)\n", field, field)
+	g.printf("p.mu.Unlock()\n")
+	g.printf("}\n\n")
+
+	g.printf("// Remove%sHandler removes a handler previously added by Add%sHandler\n", camel(ev.Name), camel(ev.Name))
+	g.printf("func (p *%s) Remove%sHandler(h %sHandler) {\n", name, camel(ev.Name), evName)
+	g.printf("p.mu.Lock()\n")
+	g.printf("defer p.mu.Unlock()\n\n")
+	g.printf("for i, e := range p.%s {\n", field)
+	g.printf("if e == h {\n")
+	g.printf("p.%s = append(p.%s[:i:i], p.%s[i+1:]...)\n", field, field, field)
+	g.printf("break\n}\n}\n")
+	g.printf("}\n\n")
+}
+
+func (g *generator) dispatch(iface *Interface, name string) {
+	g.printf("// Dispatch decodes an event received on the %s and runs its handlers\n", iface.Name)
+	g.printf("func (p *%s) Dispatch(event *%s) {\n", name, g.wl("Event"))
+	g.printf("switch event.Opcode {\n")
+	for opcode := range iface.Events {
+		ev := &iface.Events[opcode]
+		evName := name + camel(ev.Name)
+		key := iface.Name + "." + ev.Name
+
+		// events creating objects or carrying fds are always decoded, so that the
+		// new proxies get registered and the fds do not stay queued
+		mustDecode := ev.hasFd()
+		for _, a := range ev.Args {
+			if a.Type == "new_id" {
+				mustDecode = true
+			}
+		}
+
+		g.printf("case %d:\n", opcode)
+		g.printf("p.mu.RLock()\n")
+		g.printf("handlers := p.%sHandlers\n", lowerCamel(ev.Name))
+		g.printf("p.mu.RUnlock()\n")
+		if !mustDecode {
+			g.printf("if len(handlers) == 0 {\nbreak\n}\n")
+		}
+		g.printf("ev := %sEvent{}\n", evName)
+		for i := range ev.Args {
+			a := &ev.Args[i]
+			field := "ev." + eventFieldName(key, a)
+			switch a.Type {
+			case "int":
+				g.printf("%s = event.Int32()\n", field)
+			case "uint":
+				g.printf("%s = event.Uint32()\n", field)
+			case "fixed":
+				g.printf("%s = event.Float32()\n", field)
+			case "string":
+				g.printf("%s = event.String()\n", field)
+			case "array":
+				g.printf("%s = event.Array()\n", field)
+			case "fd":
+				if legacyPrivateFds[key] {
+					field = "ev." + lowerCamel(a.Name)
+				}
+				g.printf("%s, %sError = event.FD()\n", field, field)
+			case "object":
+				if t := g.typeName(a.Interface); strings.HasPrefix(t, "*") {
+					g.printf("%s, _ = event.Proxy(p.Context()).(%s)\n", field, t)
+				} else {
+					g.printf("%s = event.Proxy(p.Context())\n", field)
+				}
+			case "new_id":
+				t := g.typeName(a.Interface)
+				if !strings.HasPrefix(t, "*") {
+					g.printf("_ = event.Uint32()\n")
+					continue
+				}
+				g.printf("if id := event.Uint32(); id != 0 && event.Err() == nil {\n")
+				g.printf("%s = new(%s)\n", field, strings.TrimPrefix(t, "*"))
+				g.printf("p.Context().RegisterMapped(%s, id)\n", field)
+				g.printf("}\n")
+			}
+		}
+		if f, ok := legacySelfFields[key]; ok {
+			g.printf("ev.%s = p\n", f)
+		}
+		if len(ev.Args) > 0 {
+			// the decoding error is reported by Context Run, the handlers are not run
+			g.printf("if event.Err() != nil {\nbreak\n}\n")
+		}
+		g.printf("for _, h := range handlers {\n")
+		g.printf("h.Handle%s(ev)\n", evName)
+		g.printf("}\n")
+	}
+	g.printf("}\n")
+	g.printf("}\n\n")
+}
+
+// sinceVersions emits the versions the requests, events and enum entries were introduced in
+func (g *generator) sinceVersions(iface *Interface, name string) {
+	g.printf("const (\n")
+	for _, e := range iface.Enums {
+		for _, entry := range e.Entries {
+			if entry.Since > 1 {
+				g.printf("%s%s%sSinceVersion = %d\n", name, camel(e.Name), entryConstName(iface.Name+"."+e.Name, &entry), entry.Since)
+			}
+		}
+	}
+	for _, ev := range iface.Events {
+		g.printf("%s%sSinceVersion = %d\n", name, camel(ev.Name), ev.since())
+	}
+	for _, req := range iface.Requests {
+		g.printf("%s%sSinceVersion = %d\n", name, camel(req.Name), req.since())
+	}
+	g.printf(")\n\n")
+}
+
+// argTypes maps the protocol XML argument types to the wl.ArgType constants
+var argTypes = map[string]string{
+	"int":    "ArgInt",
+	"uint":   "ArgUint",
+	"fixed":  "ArgFixed",
+	"string": "ArgString",
+	"object": "ArgObject",
+	"new_id": "ArgNewId",
+	"array":  "ArgArray",
+	"fd":     "ArgFd",
+}
+
+func (g *generator) describeMessages(field string, msgs []Message) {
+	if len(msgs) == 0 {
+		return
+	}
+	g.printf("%s: []%s{\n", field, g.wl("Message"))
+	for _, m := range msgs {
+		g.printf("{\nName: %q,\nSince: %d,\n", m.Name, m.since())
+		if m.isDestructor() {
+			g.printf("Destructor: true,\n")
+		}
+		if len(m.Args) > 0 {
+			g.printf("Args: []%s{\n", g.wl("Arg"))
+			for _, a := range m.Args {
+				if a.Type == "new_id" && a.Interface == "" {
+					g.printf("{Name: \"interface\", Type: %s},\n", g.wl("ArgString"))
+					g.printf("{Name: \"version\", Type: %s},\n", g.wl("ArgUint"))
+				}
+				g.printf("{Name: %q, Type: %s", a.Name, g.wl(argTypes[a.Type]))
+				if a.Interface != "" {
+					g.printf(", Interface: %q", a.Interface)
+				}
+				if a.AllowNull {
+					g.printf(", Nullable: true")
+				}
+				g.printf("},\n")
+			}
+			g.printf("},\n")
+		}
+		g.printf("},\n")
+	}
+	g.printf("},\n")
+}
+
+func (g *generator) describeEnums(enums []Enum) {
+	if len(enums) == 0 {
+		return
+	}
+	g.printf("Enums: []%s{\n", g.wl("Enum"))
+	for _, e := range enums {
+		g.printf("{\nName: %q,\n", e.Name)
+		if e.Bitfield {
+			g.printf("Bitfield: true,\n")
+		}
+		g.printf("Entries: []%s{\n", g.wl("EnumEntry"))
+		for _, entry := range e.Entries {
+			g.printf("{Name: %q, Value: %s},\n", entry.Name, entry.Value)
+		}
+		g.printf("},\n")
+		g.printf("},\n")
+	}
+	g.printf("},\n")
+}
+
+// describe emits the interface description used for tracing and by the interface registry
+func (g *generator) describe(iface *Interface, name string) {
+	g.printf("// %sInterface describes the %s interface\n", name, iface.Name)
+	g.printf("var %sInterface = &%s{\n", name, g.wl("Interface"))
+	g.printf("Name: %q,\nVersion: %d,\n", iface.Name, iface.Version)
+	g.describeMessages("Requests", iface.Requests)
+	g.describeMessages("Events", iface.Events)
+	g.describeEnums(iface.Enums)
+	g.printf("}\n\n")
+
+	g.printf("// Interface returns the description of the %s interface\n", iface.Name)
+	g.printf("func (p *%s) Interface() *%s {\n", name, g.wl("Interface"))
+	g.printf("return %sInterface\n", name)
+	g.printf("}\n\n")
+}
+
+func (g *generator) registerInterfaces() {
+	g.printf("func init() {\n")
+	for _, iface := range g.proto.Interfaces {
+		g.printf("%s(%sInterface)\n", g.wl("RegisterInterface"), g.ifaceName(iface.Name))
+	}
+	for _, iface := range g.proto.Interfaces {
+		name := g.ifaceName(iface.Name)
+		g.printf("%s(%sInterface, func(ctx *%s) %s {\nreturn New%s(ctx)\n})\n",
+			g.wl("RegisterProxy"), name, g.wl("Context"), g.wl("Proxy"), name)
+	}
+	g.printf("}\n")
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// Protocol is the root element of a Wayland protocol XML file
+type Protocol struct {
+	XMLName     xml.Name    `xml:"protocol"`
+	Name        string      `xml:"name,attr"`
+	Copyright   string      `xml:"copyright"`
+	Description Description `xml:"description"`
+	Interfaces  []Interface `xml:"interface"`
+}
+
+// Description is the summary and the free-form text describing an element
+type Description struct {
+	Summary string `xml:"summary,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Interface is a single protocol interface with its requests, events and enums
+type Interface struct {
+	Name        string      `xml:"name,attr"`
+	Version     int         `xml:"version,attr"`
+	Description Description `xml:"description"`
+	Requests    []Message   `xml:"request"`
+	Events      []Message   `xml:"event"`
+	Enums       []Enum      `xml:"enum"`
+}
+
+// Message is either a request or an event
+type Message struct {
+	Name            string      `xml:"name,attr"`
+	Type            string      `xml:"type,attr"`
+	Since           int         `xml:"since,attr"`
+	DeprecatedSince int         `xml:"deprecated-since,attr"`
+	Description     Description `xml:"description"`
+	Args            []Arg       `xml:"arg"`
+}
+
+// Arg is a single argument of a request or an event
+type Arg struct {
+	Name      string `xml:"name,attr"`
+	Type      string `xml:"type,attr"`
+	Interface string `xml:"interface,attr"`
+	Enum      string `xml:"enum,attr"`
+	Summary   string `xml:"summary,attr"`
+	AllowNull bool   `xml:"allow-null,attr"`
+}
+
+// Enum is a named set of constants, optionally a bitfield
+type Enum struct {
+	Name        string      `xml:"name,attr"`
+	Since       int         `xml:"since,attr"`
+	Bitfield    bool        `xml:"bitfield,attr"`
+	Description Description `xml:"description"`
+	Entries     []Entry     `xml:"entry"`
+}
+
+// Entry is a single constant of an enum
+type Entry struct {
+	Name        string      `xml:"name,attr"`
+	Value       string      `xml:"value,attr"`
+	Summary     string      `xml:"summary,attr"`
+	Since       int         `xml:"since,attr"`
+	Description Description `xml:"description"`
+}
+
+// since returns the version a message was introduced in, version 1 when not specified
+func (m *Message) since() int {
+	if m.Since == 0 {
+		return 1
+	}
+	return m.Since
+}
+
+func (m *Message) isDestructor() bool {
+	return m.Type == "destructor"
+}
+
+// newID returns the typed new_id argument of a request, or nil
+func (m *Message) newID() *Arg {
+	for i := range m.Args {
+		if m.Args[i].Type == "new_id" {
+			return &m.Args[i]
+		}
+	}
+	return nil
+}
+
+// hasFd reports whether a message carries a file descriptor
+func (m *Message) hasFd() bool {
+	for _, a := range m.Args {
+		if a.Type == "fd" {
+			return true
+		}
+	}
+	return false
+}
+
+// parseProtocol decodes a protocol from its XML representation
+func parseProtocol(data []byte) (*Protocol, error) {
+	p := new(Protocol)
+	if err := xml.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// docLines splits the free-form description into lines stripped of the XML indentation,
+// dropping the leading and trailing blank lines
+func docLines(text string) []string {
+	var lines []string
+	for _, l := range strings.Split(text, "\n") {
+		lines = append(lines, strings.TrimSpace(l))
+	}
+	for len(lines) > 0 && lines[0] == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
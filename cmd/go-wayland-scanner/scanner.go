@@ -0,0 +1,106 @@
+// go-wayland-scanner generates Go bindings from a Wayland protocol XML file.
+//
+// Usage:
+//
+//	go-wayland-scanner -pkg xdg_decoration -i <path or URL> -o xdg_decoration.go
+//
+// The generated proxies embed wl.BaseProxy, implement the Dispatcher interface and
+// expose Add<Event>Handler and Remove<Event>Handler methods for every event.
+//
+// With -fuzz, a test file with a native fuzz target of the generated Dispatch
+// methods is written too.
+//
+// With -server, the server flavor for the wlserver package is generated instead:
+// a resource type for every interface, decoding the requests into typed handlers
+// and sending the events. The interface descriptions are taken from the client
+// bindings imported from the -client path.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("go-wayland-scanner: ")
+
+	input := flag.String("i", "", "protocol XML file path or URL")
+	output := flag.String("o", "", "output file (default stdout)")
+	pkg := flag.String("pkg", "", "name of the generated package")
+	prefix := flag.String("prefix", "", "comma separated interface name prefixes trimmed from type names")
+	fuzz := flag.String("fuzz", "", "output file of the Dispatch fuzz test (default none)")
+	server := flag.Bool("server", false, "generate the server flavor for wlserver")
+	client := flag.String("client", "", "import path of the client bindings of the protocol, used by -server")
+	flag.Parse()
+
+	if *input == "" || *pkg == "" || (*server && *client == "") {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	data, err := load(*input)
+	if err != nil {
+		log.Fatal(err)
+	}
+	proto, err := parseProtocol(data)
+	if err != nil {
+		log.Fatalf("parsing %s: %v", *input, err)
+	}
+
+	var prefixes []string
+	if *prefix != "" {
+		prefixes = strings.Split(*prefix, ",")
+	}
+	g := newGenerator(proto, path.Base(*input), *pkg, prefixes)
+	g.client = *client
+	var src []byte
+	if *server {
+		src, err = g.generateServer()
+	} else {
+		src, err = g.generate()
+	}
+	if err != nil {
+		log.Fatalf("formatting generated code: %v", err)
+	}
+	if *fuzz != "" && !*server {
+		test, err := g.generateFuzz()
+		if err != nil {
+			log.Fatalf("formatting generated fuzz test: %v", err)
+		}
+		if err = ioutil.WriteFile(*fuzz, test, 0644); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if *output == "" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = ioutil.WriteFile(*output, src, 0644)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// load reads the protocol from a local file or fetches it over http(s)
+func load(input string) ([]byte, error) {
+	if !strings.HasPrefix(input, "http://") && !strings.HasPrefix(input, "https://") {
+		return ioutil.ReadFile(input)
+	}
+	resp, err := http.Get(input)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", input, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files")
+
+// golden compares the generated source with the golden file in testdata, -update rewrites it
+func golden(t *testing.T, name string, src []byte) {
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		if err := ioutil.WriteFile(path, src, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, want) {
+		t.Errorf("%s differs from %s, run go test -update and review the diff", name, path)
+	}
+}
+
+// testGenerator parses testdata/test.xml, a protocol with enums, a bitfield, allow-null, untyped
+// new_id, fd and object args, and requests and events added in later versions
+func testGenerator(t *testing.T) *generator {
+	data, err := ioutil.ReadFile(filepath.Join("testdata", "test.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proto, err := parseProtocol(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := newGenerator(proto, "test.xml", "test_proto", []string{"test_"})
+	g.client = "github.com/neurlang/wayland/testproto"
+	return g
+}
+
+func TestGenerateClient(t *testing.T) {
+	src, err := testGenerator(t).generate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "test.xml.go", src)
+}
+
+func TestGenerateFuzz(t *testing.T) {
+	g := testGenerator(t)
+	if _, err := g.generate(); err != nil {
+		t.Fatal(err)
+	}
+	src, err := g.generateFuzz()
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "test.xml_fuzz_test.go", src)
+}
+
+func TestGenerateServer(t *testing.T) {
+	src, err := testGenerator(t).generateServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "test_server.xml.go", src)
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path"
+	"sort"
+	"strings"
+)
+
+const (
+	wlserverImport  = "github.com/neurlang/wayland/wlserver"
+	xdgserverImport = "github.com/neurlang/wayland/xdgserver"
+)
+
+// core qualifies an identifier from the wlserver package
+func (g *generator) core(name string) string {
+	if g.pkg == "wlserver" {
+		return name
+	}
+	g.imports[wlserverImport] = "wlserver"
+	return "wlserver." + name
+}
+
+// serverType is the Go type of an object argument in the server flavor, qualified when it lives
+// in another package. Interfaces unknown to the generator are represented by the generic Resource.
+func (g *generator) serverType(iface string) string {
+	switch {
+	case iface == "":
+		return g.core("Resource")
+	case g.local[iface]:
+		return "*" + g.ifaceName(iface)
+	case strings.HasPrefix(iface, "wl_"):
+		return "*" + g.core(camel(strings.TrimPrefix(iface, "wl_")))
+	case xdgInterfaces[iface] && g.pkg != "xdgserver":
+		g.imports[xdgserverImport] = "xdgserver"
+		return "*xdgserver." + camel(strings.TrimPrefix(iface, "xdg_"))
+	}
+	return g.core("Resource")
+}
+
+// descriptor is the interface description of the client bindings, or nil when unknown
+func (g *generator) descriptor(iface string) string {
+	switch {
+	case g.local[iface]:
+		g.imports[g.client] = ""
+		return path.Base(g.client) + "." + g.ifaceName(iface) + "Interface"
+	case strings.HasPrefix(iface, "wl_"):
+		g.imports[wlImport] = ""
+		return "wl." + camel(strings.TrimPrefix(iface, "wl_")) + "Interface"
+	case xdgInterfaces[iface]:
+		g.imports[xdgImport] = ""
+		return "xdg." + camel(strings.TrimPrefix(iface, "xdg_")) + "Interface"
+	}
+	return "nil"
+}
+
+// generateServer emits the server flavor of the bindings: a resource type for every interface
+// with typed request handlers and event senders. The interface descriptions are shared with the
+// client bindings imported from g.client.
+func (g *generator) generateServer() ([]byte, error) {
+	g.buf.Reset()
+	g.imports = map[string]string{wlImport: ""}
+	for i := range g.proto.Interfaces {
+		g.serverIface(&g.proto.Interfaces[i])
+	}
+	g.registerResources()
+	body := g.buf.Bytes()
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by go-wayland-scanner; DO NOT EDIT.\n")
+	fmt.Fprintf(&out, "// XML file: %s\n", g.source)
+	if c := docLines(g.proto.Copyright); len(c) > 0 {
+		fmt.Fprintf(&out, "//\n// %s Protocol Copyright:\n//\n", camel(g.proto.Name))
+		for _, l := range c {
+			fmt.Fprintf(&out, "// %s\n", l)
+		}
+	}
+	fmt.Fprintf(&out, "\npackage %s\n\n", g.pkg)
+
+	var paths []string
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	fmt.Fprintf(&out, "import (\n")
+	for _, path := range paths {
+		fmt.Fprintf(&out, "\t%q\n", path)
+	}
+	fmt.Fprintf(&out, ")\n\n")
+	out.Write(body)
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return out.Bytes(), err
+	}
+	return src, nil
+}
+
+func (g *generator) serverIface(iface *Interface) {
+	name := g.ifaceName(iface.Name)
+
+	g.doc(name, iface.Description, nil)
+	g.printf("type %s struct {\n", name)
+	g.printf("%s\n", g.core("BaseResource"))
+	g.printf("}\n\n")
+
+	g.printf("// Interface returns the description of the %s interface\n", iface.Name)
+	g.printf("func (r *%s) Interface() *wl.Interface {\n", name)
+	g.printf("return %s\n", g.descriptor(iface.Name))
+	g.printf("}\n\n")
+
+	for i := range iface.Enums {
+		g.enum(iface, name, &iface.Enums[i])
+	}
+	for i := range iface.Requests {
+		g.serverRequest(iface, name, &iface.Requests[i])
+	}
+	g.serverDispatch(iface, name)
+	for i := range iface.Events {
+		g.serverEvent(iface, name, i)
+	}
+}
+
+func (g *generator) serverRequest(iface *Interface, name string, req *Message) {
+	reqName := name + camel(req.Name)
+
+	g.doc(reqName+"Request", req.Description, nil)
+	g.printf("type %sRequest struct {\n", reqName)
+	g.printf("Resource *%s\n", name)
+	for _, a := range req.Args {
+		field := camel(a.Name)
+		switch a.Type {
+		case "new_id":
+			if a.Interface == "" {
+				g.printf("Interface string\nVersion uint32\n%s wl.ProxyId\n", field)
+				continue
+			}
+			g.printf("%s %s\n", field, g.serverType(a.Interface))
+		case "object":
+			g.printf("%s %s\n", field, g.serverType(a.Interface))
+		default:
+			g.printf("%s %s\n", field, goType(a.Type))
+		}
+	}
+	g.printf("}\n\n")
+
+	g.printf("// %sHandler is implemented by the handlers of %sRequest, see SetHandler\n", reqName, reqName)
+	g.printf("type %sHandler interface {\n", reqName)
+	g.printf("Handle%s(%sRequest)\n", reqName, reqName)
+	g.printf("}\n\n")
+}
+
+func (g *generator) serverDispatch(iface *Interface, name string) {
+	g.printf("// Dispatch decodes a request received on the %s and runs its handler. The opcode and\n", iface.Name)
+	g.printf("// the version of the request are checked by the Client before.\n")
+	g.printf("func (r *%s) Dispatch(event *wl.Event) error {\n", name)
+	if len(iface.Requests) == 0 {
+		g.printf("return nil\n")
+		g.printf("}\n\n")
+		return
+	}
+	for _, req := range iface.Requests {
+		if req.hasObjectArgs() {
+			g.printf("c := r.Client()\n")
+			break
+		}
+	}
+	g.printf("switch event.Opcode {\n")
+	for opcode := range iface.Requests {
+		req := &iface.Requests[opcode]
+		reqName := name + camel(req.Name)
+
+		g.printf("case %d:\n", opcode)
+		g.printf("req := %sRequest{Resource: r}\n", reqName)
+		for _, a := range req.Args {
+			if a.Type == "object" || a.Type == "fd" {
+				g.printf("var err error\n")
+				break
+			}
+		}
+		// the fds taken off the event are closed when the request is not passed to the handler,
+		// the Client closes the others
+		var taken []string
+		fail := func() string {
+			var b strings.Builder
+			for _, fd := range taken {
+				fmt.Fprintf(&b, "%s(%s)\n", g.core("CloseFd"), fd)
+			}
+			b.WriteString("return err\n")
+			return b.String()
+		}
+		for _, a := range req.Args {
+			field := "req." + camel(a.Name)
+			switch a.Type {
+			case "int":
+				g.printf("%s = event.Int32()\n", field)
+			case "uint":
+				g.printf("%s = event.Uint32()\n", field)
+			case "fixed":
+				g.printf("%s = event.Float32()\n", field)
+			case "string":
+				g.printf("%s = event.String()\n", field)
+			case "array":
+				g.printf("%s = event.Array()\n", field)
+			case "fd":
+				g.printf("if %s, err = event.FD(); err != nil {\n%s}\n", field, fail())
+				taken = append(taken, field)
+			case "object":
+				t := g.serverType(a.Interface)
+				g.printf("var %s %s\n", lowerCamel(a.Name)+"Res", g.core("Resource"))
+				g.printf("if %sRes, err = c.Argument(event.Uint32(), %t, %s); err != nil {\n%s}\n",
+					lowerCamel(a.Name), a.AllowNull, g.descriptor(a.Interface), fail())
+				if strings.HasPrefix(t, "*") {
+					g.printf("%s, _ = %sRes.(%s)\n", field, lowerCamel(a.Name), t)
+				} else {
+					g.printf("%s = %sRes\n", field, lowerCamel(a.Name))
+				}
+			case "new_id":
+				if a.Interface == "" {
+					g.printf("req.Interface = event.String()\n")
+					g.printf("req.Version = event.Uint32()\n")
+					g.printf("%s = wl.ProxyId(event.Uint32())\n", field)
+					continue
+				}
+				t := g.serverType(a.Interface)
+				g.printf("%s = new(%s)\n", field, strings.TrimPrefix(t, "*"))
+				g.printf("if err := c.NewId(%s, event.Uint32(), r.Version()); err != nil {\n%s}\n", field, fail())
+			}
+		}
+		if len(req.Args) > 0 {
+			g.printf("if err := event.Err(); err != nil {\n%s}\n", fail())
+		}
+		g.printf("if h, ok := r.Handler().(%sHandler); ok {\n", reqName)
+		g.printf("h.Handle%s(req)\n", reqName)
+		if len(taken) > 0 {
+			g.printf("} else {\n")
+			for _, fd := range taken {
+				g.printf("%s(%s)\n", g.core("CloseFd"), fd)
+			}
+		}
+		g.printf("}\n")
+	}
+	g.printf("}\n")
+	g.printf("return nil\n")
+	g.printf("}\n\n")
+}
+
+func (g *generator) serverEvent(iface *Interface, name string, opcode int) {
+	ev := &iface.Events[opcode]
+	method := "Send" + camel(ev.Name)
+
+	var params, sends []string
+	ret := ""
+	for _, a := range ev.Args {
+		pn := paramName(a.Name)
+		switch a.Type {
+		case "new_id":
+			ret = g.serverType(a.Interface)
+			sends = append(sends, "ret")
+		case "object":
+			params = append(params, pn+" "+g.serverType(a.Interface))
+			sends = append(sends, pn)
+		default:
+			params = append(params, pn+" "+goType(a.Type))
+			sends = append(sends, pn)
+		}
+	}
+
+	g.doc(method, ev.Description, ev.Args)
+	if ev.isDestructor() {
+		g.printf("//\n// The resource is destroyed once the event is sent.\n")
+	}
+	send := fmt.Sprintf("r.Client().SendEvent(%s)", strings.Join(append([]string{"r", fmt.Sprint(opcode)}, sends...), ", "))
+	zero := ""
+	if ret != "" {
+		g.printf("func (r *%s) %s(%s) (%s, error) {\n", name, method, strings.Join(params, ", "), ret)
+		zero = "nil, "
+	} else {
+		g.printf("func (r *%s) %s(%s) error {\n", name, method, strings.Join(params, ", "))
+	}
+	if ev.since() > 1 {
+		g.printf("if err := %s(r, %d); err != nil {\n", g.core("CheckEventVersion"), opcode)
+		g.printf("return %serr\n", zero)
+		g.printf("}\n")
+	}
+	if ret != "" {
+		g.printf("ret := new(%s)\n", strings.TrimPrefix(ret, "*"))
+		g.printf("r.Client().NewResource(ret, r.Version())\n")
+	}
+	if ev.isDestructor() {
+		g.printf("defer r.Client().Destroy(r)\n")
+	}
+	if ret != "" {
+		g.printf("return ret, %s\n", send)
+	} else {
+		g.printf("return %s\n", send)
+	}
+	g.printf("}\n\n")
+}
+
+func (g *generator) registerResources() {
+	g.printf("func init() {\n")
+	for _, iface := range g.proto.Interfaces {
+		name := g.ifaceName(iface.Name)
+		g.printf("%s(%s, func() %s {\nreturn new(%s)\n})\n",
+			g.core("RegisterResource"), g.descriptor(iface.Name), g.core("Resource"), name)
+	}
+	g.printf("}\n")
+}
+
+// hasObjectArgs reports whether the message has object arguments or creates typed objects,
+// which are looked up or registered on the client
+func (m *Message) hasObjectArgs() bool {
+	for _, a := range m.Args {
+		if a.Type == "object" || (a.Type == "new_id" && a.Interface != "") {
+			return true
+		}
+	}
+	return false
+}
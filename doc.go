@@ -7,13 +7,15 @@ Simple shm demo, draws into shared memory, does not rely on the window package.
 
 # go-wayland-smoke
 
-Smoke demo. Reacts on mouse input, uses the window package.
+Smoke demo. Reacts on mouse input, uses the window package. With -timings
+prints a report of the frame timings every 120 presented frames.
 
 # go-wayland-imageviewer
 
 ImageViewer demo. Displays image file. Does not use window package, draws
 it's own decorations. Draws fonts in the titlebar, for this it needs the
-Deja Vu font fonts-dejavu.
+Deja Vu font fonts-dejavu. Lets the compositor scale the image when it
+supports wp_viewporter.
 
 # go-wayland-editor
 
@@ -28,6 +30,45 @@ Provides basic OS functions like the creation of anonymous temporary file
 
 Utility functions found in the wayland-client, provided for convenience.
 
+# wlreplay
+
+Replays the compositor side of a protocol recording against a client. Clients
+record both directions of the protocol to the file named by WAYLAND_RECORD.
+
+# cmd/go-wayland-replay
+
+Runs a client binary against a protocol recording over a socketpair, so that
+a bug report can become a regression test. Clients that do not support
+WAYLAND_SOCKET connect to the WAYLAND_DISPLAY socket given by -display.
+
+# wlserver
+
+The compositor side of the wire protocol. Listens on a wayland-N socket,
+tracks the resources of every client and dispatches the requests to typed
+handlers. Contains the server flavor of the wayland.xml bindings.
+
+# xdgserver
+
+The server flavor of the stable xdg protocol bindings. Depends on wlserver.
+
+# viewporterserver
+
+The server flavor of the stable viewporter protocol bindings. Depends on wlserver.
+
+# fractionalscaleserver
+
+The server flavor of the fractional scale protocol bindings. Depends on wlserver.
+
+# presentationserver
+
+The server flavor of the presentation time protocol bindings. Depends on wlserver.
+
+# cmd/go-wayland-headless
+
+A compositor for CI that needs neither a GPU nor a seat. Composites the shm
+buffers in software and writes every frame to a PNG file, the input is
+injected over a control socket.
+
 # xkbcommon
 
 Wrapper around the C library libxkbcommon. Used inside the window package.
@@ -37,7 +78,9 @@ operation.
 # window
 
 Implements a window model on top of wayland. Aims to be a lot like the original
-window.c code. Uses wl.
+window.c code. Uses wl. Renders the buffers at the fractional scale preferred
+by the compositor when it supports wp_viewporter too. Reports when the frames
+are presented when the compositor supports wp_presentation.
 
 # wlcursor
 
@@ -62,9 +105,29 @@ Unstable wayland protocols. Depends on wl.
 The wayland itself, does not require any external deps (except for wayland
 server during runtime).
 
+Requests may be sent from any goroutine, for example a render goroutine committing
+surfaces while the UI goroutine dispatches input. Each request is queued atomically
+with its fds, and the ids of new objects are allocated when their request is queued,
+so the compositor receives them in order.
+
 # xdg
 
 Stable xdg protocol. Depends on wl.
 
+# viewporter
+
+Stable viewporter protocol, the compositor crops and scales the surfaces.
+Depends on wl.
+
+# fractionalscale
+
+Staging fractional scale protocol, the compositor suggests a scale such as 1.5
+for the buffers of a surface. Depends on wl.
+
+# presentation
+
+Stable presentation time protocol, the compositor reports when and how the
+content updates were shown. Depends on wl.
+
 */
 package wayland
@@ -0,0 +1,255 @@
+// Code generated by go-wayland-scanner; DO NOT EDIT.
+// XML file: fractional-scale-v1.xml
+//
+// FractionalScaleV1 Protocol Copyright:
+//
+// Copyright © 2022 Kenny Levinsen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the next
+// paragraph) shall be included in all copies or substantial portions of the
+// Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package fractionalscale
+
+import (
+	"github.com/neurlang/wayland/wl"
+	"sync"
+)
+
+// FractionalScaleManagerV1: fractional surface scale information
+//
+// A global interface for requesting surfaces to use fractional scales.
+type FractionalScaleManagerV1 struct {
+	wl.BaseProxy
+}
+
+// NewFractionalScaleManagerV1 creates a new wp_fractional_scale_manager_v1 proxy registered in the Context
+func NewFractionalScaleManagerV1(ctx *wl.Context) *FractionalScaleManagerV1 {
+	ret := new(FractionalScaleManagerV1)
+	ctx.Register(ret)
+	return ret
+}
+
+// Destroy: unbind the fractional surface scale interface
+//
+// Informs the server that the client will not be using this protocol
+// object anymore. This does not affect any other objects,
+// wp_fractional_scale_v1 objects included.
+func (p *FractionalScaleManagerV1) Destroy() error {
+	err := p.Context().MarshalRequest(p, 0, nil)
+	p.Unregister()
+	return err
+}
+
+// GetFractionalScale: extend surface interface for scale information
+//
+// Create an add-on object for the the wl_surface to let the compositor
+// request fractional scales. If the given wl_surface already has a
+// wp_fractional_scale_v1 object associated, the fractional_scale_exists
+// protocol error is raised.
+//
+//	id: the new surface scale info interface id
+//	surface: the surface
+func (p *FractionalScaleManagerV1) GetFractionalScale(surface *wl.Surface) (*FractionalScaleV1, error) {
+	ret := NewFractionalScaleV1(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 1, func(r *wl.Request) {
+		r.PutNewId(ret)
+		if surface != nil {
+			r.PutObject(surface.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
+}
+
+// FractionalScaleManagerV1Error:
+const (
+	// FractionalScaleManagerV1ErrorFractionalScaleExists: the surface already has a fractional_scale object associated
+	FractionalScaleManagerV1ErrorFractionalScaleExists = 0
+)
+
+const (
+	FractionalScaleManagerV1DestroySinceVersion            = 1
+	FractionalScaleManagerV1GetFractionalScaleSinceVersion = 1
+)
+
+// FractionalScaleManagerV1Interface describes the wp_fractional_scale_manager_v1 interface
+var FractionalScaleManagerV1Interface = &wl.Interface{
+	Name:    "wp_fractional_scale_manager_v1",
+	Version: 1,
+	Requests: []wl.Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "get_fractional_scale",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "id", Type: wl.ArgNewId, Interface: "wp_fractional_scale_v1"},
+				{Name: "surface", Type: wl.ArgObject, Interface: "wl_surface"},
+			},
+		},
+	},
+	Enums: []wl.Enum{
+		{
+			Name: "error",
+			Entries: []wl.EnumEntry{
+				{Name: "fractional_scale_exists", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wp_fractional_scale_manager_v1 interface
+func (p *FractionalScaleManagerV1) Interface() *wl.Interface {
+	return FractionalScaleManagerV1Interface
+}
+
+// FractionalScaleV1: fractional scale interface to a wl_surface
+//
+// An additional interface to a wl_surface object which allows the compositor
+// to inform the client of the preferred scale.
+type FractionalScaleV1 struct {
+	wl.BaseProxy
+	mu                     sync.RWMutex
+	preferredScaleHandlers []FractionalScaleV1PreferredScaleHandler
+}
+
+// NewFractionalScaleV1 creates a new wp_fractional_scale_v1 proxy registered in the Context
+func NewFractionalScaleV1(ctx *wl.Context) *FractionalScaleV1 {
+	ret := new(FractionalScaleV1)
+	ctx.Register(ret)
+	return ret
+}
+
+// Destroy: remove surface scale information for surface
+//
+// Destroy the fractional scale object. When this object is destroyed,
+// preferred_scale events will no longer be sent.
+func (p *FractionalScaleV1) Destroy() error {
+	err := p.Context().MarshalRequest(p, 0, nil)
+	p.Unregister()
+	return err
+}
+
+// FractionalScaleV1PreferredScaleEvent: notify of new preferred scale
+//
+// Notification of a new preferred scale for this surface that the
+// compositor suggests that the client should use.
+//
+// The sent scale is the numerator of a fraction with a denominator of 120.
+type FractionalScaleV1PreferredScaleEvent struct {
+	Scale uint32
+}
+
+// FractionalScaleV1PreferredScaleHandler is implemented by the receivers of FractionalScaleV1PreferredScaleEvent
+type FractionalScaleV1PreferredScaleHandler interface {
+	HandleFractionalScaleV1PreferredScale(FractionalScaleV1PreferredScaleEvent)
+}
+
+// AddPreferredScaleHandler adds a handler for FractionalScaleV1PreferredScaleEvent
+func (p *FractionalScaleV1) AddPreferredScaleHandler(h FractionalScaleV1PreferredScaleHandler) {
+	if h == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.preferredScaleHandlers = append(p.preferredScaleHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemovePreferredScaleHandler removes a handler previously added by AddPreferredScaleHandler
+func (p *FractionalScaleV1) RemovePreferredScaleHandler(h FractionalScaleV1PreferredScaleHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.preferredScaleHandlers {
+		if e == h {
+			p.preferredScaleHandlers = append(p.preferredScaleHandlers[:i:i], p.preferredScaleHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// Dispatch decodes an event received on the wp_fractional_scale_v1 and runs its handlers
+func (p *FractionalScaleV1) Dispatch(event *wl.Event) {
+	switch event.Opcode {
+	case 0:
+		p.mu.RLock()
+		handlers := p.preferredScaleHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := FractionalScaleV1PreferredScaleEvent{}
+		ev.Scale = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleFractionalScaleV1PreferredScale(ev)
+		}
+	}
+}
+
+const (
+	FractionalScaleV1PreferredScaleSinceVersion = 1
+	FractionalScaleV1DestroySinceVersion        = 1
+)
+
+// FractionalScaleV1Interface describes the wp_fractional_scale_v1 interface
+var FractionalScaleV1Interface = &wl.Interface{
+	Name:    "wp_fractional_scale_v1",
+	Version: 1,
+	Requests: []wl.Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+	},
+	Events: []wl.Message{
+		{
+			Name:  "preferred_scale",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "scale", Type: wl.ArgUint},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wp_fractional_scale_v1 interface
+func (p *FractionalScaleV1) Interface() *wl.Interface {
+	return FractionalScaleV1Interface
+}
+
+func init() {
+	wl.RegisterInterface(FractionalScaleManagerV1Interface)
+	wl.RegisterInterface(FractionalScaleV1Interface)
+	wl.RegisterProxy(FractionalScaleManagerV1Interface, func(ctx *wl.Context) wl.Proxy {
+		return NewFractionalScaleManagerV1(ctx)
+	})
+	wl.RegisterProxy(FractionalScaleV1Interface, func(ctx *wl.Context) wl.Proxy {
+		return NewFractionalScaleV1(ctx)
+	})
+}
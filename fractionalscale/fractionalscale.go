@@ -0,0 +1,5 @@
+// Package fractionalscale implements the staging wp_fractional_scale_v1 protocol, the compositor
+// suggests a fractional scale of the surfaces, presented at their logical size by a wp_viewport
+package fractionalscale
+
+//go:generate go run ../cmd/go-wayland-scanner -pkg fractionalscale -prefix wp_ -i fractional-scale-v1.xml -o fractional-scale-v1.xml.go -fuzz fractional-scale-v1.xml_fuzz_test.go
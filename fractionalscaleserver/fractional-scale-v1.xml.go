@@ -0,0 +1,174 @@
+// Code generated by go-wayland-scanner; DO NOT EDIT.
+// XML file: fractional-scale-v1.xml
+//
+// FractionalScaleV1 Protocol Copyright:
+//
+// Copyright © 2022 Kenny Levinsen
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the next
+// paragraph) shall be included in all copies or substantial portions of the
+// Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package fractionalscaleserver
+
+import (
+	"github.com/neurlang/wayland/fractionalscale"
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wlserver"
+)
+
+// FractionalScaleManagerV1: fractional surface scale information
+//
+// A global interface for requesting surfaces to use fractional scales.
+type FractionalScaleManagerV1 struct {
+	wlserver.BaseResource
+}
+
+// Interface returns the description of the wp_fractional_scale_manager_v1 interface
+func (r *FractionalScaleManagerV1) Interface() *wl.Interface {
+	return fractionalscale.FractionalScaleManagerV1Interface
+}
+
+// FractionalScaleManagerV1Error:
+const (
+	// FractionalScaleManagerV1ErrorFractionalScaleExists: the surface already has a fractional_scale object associated
+	FractionalScaleManagerV1ErrorFractionalScaleExists = 0
+)
+
+// FractionalScaleManagerV1DestroyRequest: unbind the fractional surface scale interface
+//
+// Informs the server that the client will not be using this protocol
+// object anymore. This does not affect any other objects,
+// wp_fractional_scale_v1 objects included.
+type FractionalScaleManagerV1DestroyRequest struct {
+	Resource *FractionalScaleManagerV1
+}
+
+// FractionalScaleManagerV1DestroyHandler is implemented by the handlers of FractionalScaleManagerV1DestroyRequest, see SetHandler
+type FractionalScaleManagerV1DestroyHandler interface {
+	HandleFractionalScaleManagerV1Destroy(FractionalScaleManagerV1DestroyRequest)
+}
+
+// FractionalScaleManagerV1GetFractionalScaleRequest: extend surface interface for scale information
+//
+// Create an add-on object for the the wl_surface to let the compositor
+// request fractional scales. If the given wl_surface already has a
+// wp_fractional_scale_v1 object associated, the fractional_scale_exists
+// protocol error is raised.
+type FractionalScaleManagerV1GetFractionalScaleRequest struct {
+	Resource *FractionalScaleManagerV1
+	Id       *FractionalScaleV1
+	Surface  *wlserver.Surface
+}
+
+// FractionalScaleManagerV1GetFractionalScaleHandler is implemented by the handlers of FractionalScaleManagerV1GetFractionalScaleRequest, see SetHandler
+type FractionalScaleManagerV1GetFractionalScaleHandler interface {
+	HandleFractionalScaleManagerV1GetFractionalScale(FractionalScaleManagerV1GetFractionalScaleRequest)
+}
+
+// Dispatch decodes a request received on the wp_fractional_scale_manager_v1 and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *FractionalScaleManagerV1) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := FractionalScaleManagerV1DestroyRequest{Resource: r}
+		if h, ok := r.Handler().(FractionalScaleManagerV1DestroyHandler); ok {
+			h.HandleFractionalScaleManagerV1Destroy(req)
+		}
+	case 1:
+		req := FractionalScaleManagerV1GetFractionalScaleRequest{Resource: r}
+		var err error
+		req.Id = new(FractionalScaleV1)
+		if err := c.NewId(req.Id, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		var surfaceRes wlserver.Resource
+		if surfaceRes, err = c.Argument(event.Uint32(), false, wl.SurfaceInterface); err != nil {
+			return err
+		}
+		req.Surface, _ = surfaceRes.(*wlserver.Surface)
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(FractionalScaleManagerV1GetFractionalScaleHandler); ok {
+			h.HandleFractionalScaleManagerV1GetFractionalScale(req)
+		}
+	}
+	return nil
+}
+
+// FractionalScaleV1: fractional scale interface to a wl_surface
+//
+// An additional interface to a wl_surface object which allows the compositor
+// to inform the client of the preferred scale.
+type FractionalScaleV1 struct {
+	wlserver.BaseResource
+}
+
+// Interface returns the description of the wp_fractional_scale_v1 interface
+func (r *FractionalScaleV1) Interface() *wl.Interface {
+	return fractionalscale.FractionalScaleV1Interface
+}
+
+// FractionalScaleV1DestroyRequest: remove surface scale information for surface
+//
+// Destroy the fractional scale object. When this object is destroyed,
+// preferred_scale events will no longer be sent.
+type FractionalScaleV1DestroyRequest struct {
+	Resource *FractionalScaleV1
+}
+
+// FractionalScaleV1DestroyHandler is implemented by the handlers of FractionalScaleV1DestroyRequest, see SetHandler
+type FractionalScaleV1DestroyHandler interface {
+	HandleFractionalScaleV1Destroy(FractionalScaleV1DestroyRequest)
+}
+
+// Dispatch decodes a request received on the wp_fractional_scale_v1 and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *FractionalScaleV1) Dispatch(event *wl.Event) error {
+	switch event.Opcode {
+	case 0:
+		req := FractionalScaleV1DestroyRequest{Resource: r}
+		if h, ok := r.Handler().(FractionalScaleV1DestroyHandler); ok {
+			h.HandleFractionalScaleV1Destroy(req)
+		}
+	}
+	return nil
+}
+
+// SendPreferredScale: notify of new preferred scale
+//
+// Notification of a new preferred scale for this surface that the
+// compositor suggests that the client should use.
+//
+// The sent scale is the numerator of a fraction with a denominator of 120.
+//
+//	scale: the new preferred scale
+func (r *FractionalScaleV1) SendPreferredScale(scale uint32) error {
+	return r.Client().SendEvent(r, 0, scale)
+}
+
+func init() {
+	wlserver.RegisterResource(fractionalscale.FractionalScaleManagerV1Interface, func() wlserver.Resource {
+		return new(FractionalScaleManagerV1)
+	})
+	wlserver.RegisterResource(fractionalscale.FractionalScaleV1Interface, func() wlserver.Resource {
+		return new(FractionalScaleV1)
+	})
+}
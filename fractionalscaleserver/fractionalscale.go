@@ -0,0 +1,11 @@
+// Package fractionalscaleserver implements the compositor side of the staging wp_fractional_scale_v1 protocol
+//
+// The resource types are generated from fractional-scale-v1.xml in the server flavor described in
+// package wlserver, a global of the wp_fractional_scale_manager_v1 is added by
+//
+//	server.AddGlobal(fractionalscale.FractionalScaleManagerV1Interface, 1, func(r wlserver.Resource) {
+//		r.SetHandler(myManager)
+//	})
+package fractionalscaleserver
+
+//go:generate go run ../cmd/go-wayland-scanner -server -client github.com/neurlang/wayland/fractionalscale -pkg fractionalscaleserver -prefix wp_ -i ../fractionalscale/fractional-scale-v1.xml -o fractional-scale-v1.xml.go
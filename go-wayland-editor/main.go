@@ -107,7 +107,7 @@ func (*textEntry) TouchFrame(widget *window.Widget, input *window.Input) {
 func (*textEntry) TouchCancel(widget *window.Widget, width int32, height int32) {
 }
 
-func (*textEntry) Resize(widget *window.Widget, width int32, height int32, totalwidth int32, totalheight int32) {
+func (*textEntry) Resize(widget *window.Widget, width int32, height int32, pwidth int32, pheight int32) {
 }
 func (*textEntry) Enter(widget *window.Widget, input *window.Input, x float32, y float32) {
 }
@@ -197,7 +197,7 @@ func textEntryAllocate(textEntry *textEntry, x int32, y int32,
 	textEntry.widget.SetAllocation(x, y, width, height)
 }
 
-func (editor *editor) Resize(Widget *window.Widget, width int32, height int32, totalwidth int32, totalheight int32) {
+func (editor *editor) Resize(Widget *window.Widget, width int32, height int32, pwidth int32, pheight int32) {
 
 	var allocation = editor.widget.GetAllocation()
 
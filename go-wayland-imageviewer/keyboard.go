@@ -37,7 +37,7 @@ func (app *appState) redecorate(configuring bool) {
 		}
 
 		// Damage the surface
-		if err := app.surface.DamageBuffer(0, 0, app.width, app.height); err != nil {
+		if err := damageBuffer(app.surface, app.width, app.height, app.width, app.height); err != nil {
 			log.Fatalf("unable to damage buffer: %v", err)
 		}
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/neurlang/wayland/external/swizzle"
 	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/viewporter"
 	"github.com/neurlang/wayland/wl"
 	"github.com/neurlang/wayland/wlclient"
 	"github.com/neurlang/wayland/wlcursor"
@@ -29,6 +30,7 @@ type appState struct {
 
 	display    *wl.Display
 	registry   *wl.Registry
+	globals    *wl.GlobalTracker
 	shm        *wl.Shm
 	compositor *wl.Compositor
 	wmBase     *xdg.WmBase
@@ -38,6 +40,13 @@ type appState struct {
 	xdgSurface  *xdg.Surface
 	xdgTopLevel *xdg.Toplevel
 
+	// Scaling the image by the compositor, nil without wp_viewporter
+	viewporter      *viewporter.Viewporter
+	subcompositor   *wl.Subcompositor
+	imageSurface    *wl.Surface
+	imageSubsurface *wl.Subsurface
+	imageViewport   *viewporter.Viewport
+
 	keyboard *wl.Keyboard
 	pointer  *wl.Pointer
 
@@ -99,6 +108,13 @@ func main() {
 
 	}
 
+	if app.imageSurface != nil {
+		app.releaseImageSurface()
+	}
+	if app.viewporter != nil {
+		app.releaseViewporter()
+	}
+
 	if app.toplevelDecoration != nil {
 		app.releaseToplevelDecoration()
 	}
@@ -200,12 +216,32 @@ func run(app *appState) {
 	}
 	app.registry = registry
 
-	// Add global interfaces registrar handler
-	registry.AddGlobalHandler(app)
+	// Track the global interfaces, seats are bound as they appear
+	app.globals = wl.NewGlobalTracker(registry)
+	app.globals.AddGlobalAddHandler(app)
 
 	// Wait for interfaces to register
 	_ = wlclient.DisplayRoundtrip(app.display)
 
+	shm, err := app.globals.Bind("wl_shm", 1, 0)
+	if err != nil {
+		log.Fatalf("unable to bind wl_shm interface: %v", err)
+	}
+	app.shm = shm.(*wl.Shm)
+	compositor, err := app.globals.Bind("wl_compositor", 1, 0)
+	if err != nil {
+		log.Fatalf("unable to bind wl_compositor interface: %v", err)
+	}
+	app.compositor = compositor.(*wl.Compositor)
+	wmBase, err := app.globals.Bind("xdg_wm_base", 1, 0)
+	if err != nil {
+		log.Fatalf("unable to bind xdg_wm_base interface: %v", err)
+	}
+	app.wmBase = wmBase.(*xdg.WmBase)
+	// Add xdg_wmbase ping handler `app.HandleWmBasePing`
+	app.wmBase.AddPingHandler(app)
+	app.bindViewporter()
+
 	log.Print("all interfaces registered")
 
 	// Create a wl_surface for toplevel window
@@ -237,6 +273,10 @@ func run(app *appState) {
 	app.xdgTopLevel = xdgTopLevel
 	log.Print("got xdg_toplevel")
 
+	if app.viewporter != nil {
+		app.createImageSurface()
+	}
+
 	if app.decorationManager != nil {
 		//tld, err := app.decorationManager.GetToplevelDecoration(xdgTopLevel)
 		//if err != nil {
@@ -286,43 +326,22 @@ func (app *appState) Context() *wl.Context {
 	return app.display.Context()
 }
 
-func (app *appState) HandleRegistryGlobal(e wl.RegistryGlobalEvent) {
-	log.Printf("discovered an interface: %q\n", e.Interface)
+func (app *appState) HandleGlobalAdd(g wl.Global) {
+	log.Printf("discovered an interface: %q\n", g.Interface)
 
-	switch e.Interface {
-	case "wl_shm":
-		shm := wl.NewShm(app.Context())
-		err := app.registry.Bind(e.Name, e.Interface, e.Version, shm)
-		if err != nil {
-			log.Fatalf("unable to bind wl_shm interface: %v", err)
-		}
-		app.shm = shm
-	case "wl_compositor":
-		compositor := wl.NewCompositor(app.Context())
-		err := app.registry.Bind(e.Name, e.Interface, e.Version, compositor)
-		if err != nil {
-			log.Fatalf("unable to bind wl_compositor interface: %v", err)
-		}
-		app.compositor = compositor
-	case "xdg_wm_base":
-		wmBase := xdg.NewWmBase(app.Context())
-		err := app.registry.Bind(e.Name, e.Interface, e.Version, wmBase)
-		if err != nil {
-			log.Fatalf("unable to bind xdg_wm_base interface: %v", err)
-		}
-		app.wmBase = wmBase
-		// Add xdg_wmbase ping handler `app.HandleWmBasePing`
-		wmBase.AddPingHandler(app)
+	switch g.Interface {
 	case "wl_seat":
-		seat := wl.NewSeat(app.Context())
-		err := app.registry.Bind(e.Name, e.Interface, e.Version, seat)
+		if app.seat != nil {
+			break
+		}
+		seat, err := app.globals.BindGlobal(g, 1, 0)
 		if err != nil {
 			log.Fatalf("unable to bind wl_seat interface: %v", err)
 		}
-		app.seat = seat
+		app.seat = seat.(*wl.Seat)
 		// Add Keyboard & Pointer handlers
-		seat.AddCapabilitiesHandler(app)
-		seat.AddNameHandler(app)
+		app.seat.AddCapabilitiesHandler(app)
+		app.seat.AddNameHandler(app)
 	case "zxdg_decoration_manager_v1":
 		//_ = unstable.GetNewFunc
 		//app.haveDecorationManager = true
@@ -386,11 +405,16 @@ func (app *appState) HandleToplevelConfigure(e xdg.ToplevelConfigureEvent) {
 		return
 	}
 
-	// Resize the proxy image to new frame size
-	// and set it to frame image
-	log.Print("resizing frame")
-	app.frame = resize.Resize(uint(width), uint(height), app.pImage, resize.Bilinear).(*image.RGBA)
-	log.Print("done resizing frame")
+	if app.imageViewport != nil {
+		// The compositor scales the image, the frame only holds the decoration
+		app.frame = image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	} else {
+		// Resize the proxy image to new frame size
+		// and set it to frame image
+		log.Print("resizing frame")
+		app.frame = resize.Resize(uint(width), uint(height), app.pImage, resize.Bilinear).(*image.RGBA)
+		log.Print("done resizing frame")
+	}
 
 	app.frame.Rect.Min.X = 0
 	app.frame.Rect.Min.Y = 0
@@ -404,6 +428,19 @@ func (app *appState) HandleToplevelConfigure(e xdg.ToplevelConfigureEvent) {
 
 	app.width = int32(app.frame.Rect.Max.X)
 	app.height = int32(app.frame.Rect.Max.Y)
+
+	if app.imageViewport != nil {
+		app.scaleImage()
+	}
+}
+
+// damageBuffer damages the whole buffer of the surface. wl_surface.damage_buffer needs version 4,
+// the older compositors get the damage in surface coordinates, the size of the surface.
+func damageBuffer(surface *wl.Surface, bufferWidth, bufferHeight, width, height int32) error {
+	if surface.Version() >= wl.SurfaceDamageBufferSinceVersion {
+		return surface.DamageBuffer(0, 0, bufferWidth, bufferHeight)
+	}
+	return surface.Damage(0, 0, width, height)
 }
 
 func (app *appState) loadCursors() {
@@ -461,10 +498,16 @@ func (app *appState) loadCursors() {
 }
 
 func (app *appState) drawFrame() *wl.Buffer {
+	return app.drawImage(app.frame)
+}
+
+func (app *appState) drawImage(img *image.RGBA) *wl.Buffer {
 	log.Print("drawing frame")
 
-	stride := app.width * 4
-	size := stride * app.height
+	width := int32(img.Rect.Dx())
+	height := int32(img.Rect.Dy())
+	stride := width * 4
+	size := stride * height
 
 	file, err := sys.CreateAnonymousFile(int64(size))
 	if err != nil {
@@ -481,7 +524,7 @@ func (app *appState) drawFrame() *wl.Buffer {
 		log.Fatalf("unable to create shm pool: %v", err)
 	}
 
-	buf, err := pool.CreateBuffer(0, app.width, app.height, stride, wl.ShmFormatArgb8888)
+	buf, err := pool.CreateBuffer(0, width, height, stride, wl.ShmFormatArgb8888)
 	if err != nil {
 		log.Fatalf("unable to create wlclient.Buffer from shm pool: %v", err)
 	}
@@ -495,7 +538,7 @@ func (app *appState) drawFrame() *wl.Buffer {
 	}
 
 	// Convert RGBA to BGRA
-	copy(data, app.frame.Pix)
+	copy(data, img.Pix)
 	swizzle.BGRA(data)
 
 	if err := sys.Munmap(data); err != nil {
@@ -596,7 +639,7 @@ func (app *appState) releaseShm() {
 	app.shm = nil
 }
 func (app *appState) releaseRegistry() {
-	app.registry.RemoveGlobalHandler(app)
+	app.globals.RemoveGlobalAddHandler(app)
 	app.registry.Unregister()
 	app.registry = nil
 }
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"log"
+
+	"github.com/neurlang/wayland/viewporter"
+	"github.com/neurlang/wayland/wl"
+)
+
+// The image is shown by a subsurface inside the decoration, the compositor scales
+// its buffer to the window by a wp_viewport instead of resizing it on the CPU
+
+func (app *appState) bindViewporter() {
+	vp, err := app.globals.Bind("wp_viewporter", 1, 1)
+	if err != nil {
+		log.Printf("resizing on the CPU: %v", err)
+		return
+	}
+	subcompositor, err := app.globals.Bind("wl_subcompositor", 1, 1)
+	if err != nil {
+		log.Printf("resizing on the CPU: %v", err)
+		if err := vp.(*viewporter.Viewporter).Destroy(); err != nil {
+			log.Println("unable to destroy wp_viewporter:", err)
+		}
+		return
+	}
+	app.viewporter = vp.(*viewporter.Viewporter)
+	app.subcompositor = subcompositor.(*wl.Subcompositor)
+}
+
+func (app *appState) createImageSurface() {
+	surface, err := app.compositor.CreateSurface()
+	if err != nil {
+		log.Fatalf("unable to create compositor surface: %v", err)
+	}
+	app.imageSurface = surface
+
+	subsurface, err := app.subcompositor.GetSubsurface(surface, app.surface)
+	if err != nil {
+		log.Fatalf("unable to get wl_subsurface: %v", err)
+	}
+	app.imageSubsurface = subsurface
+
+	// An empty input region leaves the pointer to the decoration
+	region, err := app.compositor.CreateRegion()
+	if err != nil {
+		log.Fatalf("unable to create region: %v", err)
+	}
+	if err := surface.SetInputRegion(region); err != nil {
+		log.Fatalf("unable to set input region: %v", err)
+	}
+	if err := region.Destroy(); err != nil {
+		log.Printf("unable to destroy region: %v", err)
+	}
+
+	viewport, err := app.viewporter.GetViewport(surface)
+	if err != nil {
+		log.Fatalf("unable to get wp_viewport: %v", err)
+	}
+	app.imageViewport = viewport
+
+	// The proxy image is uploaded once, at its own size
+	buffer := app.drawImage(app.pImage)
+	if err := surface.Attach(buffer, 0, 0); err != nil {
+		log.Fatalf("unable to attach buffer to surface: %v", err)
+	}
+	_, _, width, height := app.imageRect()
+	if err := damageBuffer(surface, int32(app.pImage.Rect.Dx()), int32(app.pImage.Rect.Dy()), width, height); err != nil {
+		log.Fatalf("unable to damage buffer: %v", err)
+	}
+	app.scaleImage()
+	log.Print("created image subsurface")
+}
+
+// imageRect returns the area of the window not covered by the decoration
+func (app *appState) imageRect() (x, y, width, height int32) {
+	width, height = app.width, app.height
+	if app.decoration != nil {
+		x, y = Border, Border+int32(app.decoration.Titlebar)
+		width -= 2 * Border
+		height -= 2*Border + int32(app.decoration.Titlebar)
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	return
+}
+
+// scaleImage fits the image subsurface to the window, applied by the next commit of the window
+func (app *appState) scaleImage() {
+	x, y, width, height := app.imageRect()
+
+	if err := app.imageSubsurface.SetPosition(x, y); err != nil {
+		log.Fatalf("unable to set subsurface position: %v", err)
+	}
+	if err := app.imageViewport.SetDestination(width, height); err != nil {
+		log.Fatalf("unable to set viewport destination: %v", err)
+	}
+	if err := app.imageSurface.Commit(); err != nil {
+		log.Fatalf("unable to commit surface state: %v", err)
+	}
+}
+
+func (app *appState) releaseImageSurface() {
+	if err := app.imageViewport.Destroy(); err != nil {
+		log.Println("unable to destroy wp_viewport:", err)
+	}
+	app.imageViewport = nil
+
+	if err := app.imageSubsurface.Destroy(); err != nil {
+		log.Println("unable to destroy wl_subsurface:", err)
+	}
+	app.imageSubsurface = nil
+
+	if err := app.imageSurface.Destroy(); err != nil {
+		log.Println("unable to destroy wl_surface:", err)
+	}
+	app.imageSurface = nil
+}
+
+func (app *appState) releaseViewporter() {
+	if err := app.viewporter.Destroy(); err != nil {
+		log.Println("unable to destroy wp_viewporter:", err)
+	}
+	app.viewporter = nil
+
+	if err := app.subcompositor.Destroy(); err != nil {
+		log.Println("unable to destroy wl_subcompositor:", err)
+	}
+	app.subcompositor = nil
+}
@@ -131,39 +131,6 @@ func (d *display) HandleWmBasePing(ev zxdg.WmBasePingEvent) {
 	d.shell.Pong(ev.Serial)
 }
 
-func (d *display) HandleRegistryGlobal(ev wl.RegistryGlobalEvent) {
-	d.RegistryGlobal(d.registry, ev.Name, ev.Interface, ev.Version)
-}
-
-func (d *display) RegistryGlobal(reg *wl.Registry, goid uint32, face string,
-	version uint32) {
-
-	goFace := face
-
-	switch goFace {
-	case "wl_compositor":
-		d.compositor = wlclient.RegistryBindCompositorInterface(d.registry, goid, 1)
-
-	case "xdg_wm_base":
-		d.shell = wlclient.RegistryBindWmBaseInterface(d.registry, goid, 1)
-
-		zxdg.WmBaseAddListener(d.shell, d)
-
-	case "zwp_fullscreen_shell_v1":
-
-	case "wl_shm":
-		d.shm = wlclient.RegistryBindShmInterface(d.registry, goid, 1)
-		wlclient.ShmAddListener(d.shm, d)
-
-	default:
-		log.Println("Other register global", goFace)
-	}
-}
-
-func (*display) HandleRegistryGlobalRemove(ev wl.RegistryGlobalRemoveEvent) {
-
-}
-
 func createDisplay() *display {
 	disp := &display{
 		hasXrgb: false,
@@ -184,11 +151,24 @@ func createDisplay() *display {
 
 	disp.registry = reg
 
-	wlclient.RegistryAddListener(disp.registry, disp)
+	globals := wl.NewGlobalTracker(disp.registry)
 	handle(wlclient.DisplayRoundtrip(disp.display))
-	if disp.shm == nil {
+
+	compositor, err := globals.Bind("wl_compositor", 1, 1)
+	handle(err)
+	disp.compositor = compositor.(*wl.Compositor)
+
+	if shell, err := globals.Bind("xdg_wm_base", 1, 1); err == nil {
+		disp.shell = shell.(*zxdg.WmBase)
+		zxdg.WmBaseAddListener(disp.shell, disp)
+	}
+
+	shm, err := globals.Bind("wl_shm", 1, 1)
+	if err != nil {
 		log.Fatal("No wl_shm global\n")
 	}
+	disp.shm = shm.(*wl.Shm)
+	wlclient.ShmAddListener(disp.shm, disp)
 
 	handle(wlclient.DisplayRoundtrip(disp.display))
 
@@ -29,6 +29,7 @@ import "github.com/neurlang/wayland/wl"
 import "github.com/neurlang/wayland/window"
 import xkb "github.com/neurlang/wayland/xkbcommon"
 import "fmt"
+import "flag"
 
 type smoke struct {
 	display     *window.Display
@@ -47,6 +48,20 @@ type smoke struct {
 	}
 	pipe   bool
 	lx, ly float32
+
+	timings   *window.FrameTimings
+	presented int
+}
+
+// timingsFrames is the number of frames of the timing report, printed after as many frames
+const timingsFrames = 120
+
+func (smoke *smoke) Presented(info window.PresentationInfo) {
+	smoke.timings.Add(info)
+	smoke.presented++
+	if smoke.presented%timingsFrames == 0 {
+		fmt.Println("frame timings:", smoke.timings)
+	}
 }
 
 func diffuse(smoke *smoke, time uint32, source []float32, dest []float32, width int32, height int32) {
@@ -502,6 +517,9 @@ func (smoke *smoke) free() {
 
 func main() {
 
+	var timings = flag.Bool("timings", false, "print a report of the frame timings every 120 presented frames")
+	flag.Parse()
+
 	var smoke smoke
 
 	d, err := window.DisplayCreate([]string{})
@@ -520,6 +538,10 @@ func main() {
 	smoke.window.SetTitle("smoke")
 	smoke.window.SetBufferType(window.BufferTypeShm)
 	smoke.window.SetKeyboardHandler(&smoke)
+	if *timings {
+		smoke.timings = window.NewFrameTimings(timingsFrames)
+		smoke.window.OnPresented(smoke.Presented)
+	}
 	rand.Seed(int64(time.Now().Nanosecond()))
 
 	var size = int(smoke.height * smoke.width)
@@ -226,6 +226,7 @@ func (s *textarea) Enter(widget *window.Widget, input *window.Input, x float32,
 	s.StringGrid.Motion(ObjectPosition{int((x + float32(s.StringGrid.CellWidth)*0.5) / float32(s.StringGrid.CellWidth)), int(y / float32(s.StringGrid.CellHeight))})
 
 }
+
 func (s *textarea) Leave(widget *window.Widget, input *window.Input) {
 
 	s.mutex.Lock()
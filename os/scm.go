@@ -27,16 +27,31 @@ func fallocate(fd int, mode uint32, off int64, size int64) error {
 	return ErrUnsupportedOS
 }
 
-// UnixRights calls a system call
-func UnixRights(int) []byte {
+// UnixRights calls a system call to encode the fds into a SCM_RIGHTS control message
+func UnixRights(...int) []byte {
 	return nil
 }
 
+// DupCloexec duplicates the fd, the duplicate is closed on exec
+func DupCloexec(fd int) (int, error) {
+	return -1, ErrUnsupportedOS
+}
+
 // Sendmsg sends information on fd using a Sendmsg system call
 func Sendmsg(fd int, msg []byte, oob []byte, sockaddr Sockaddr, z int) error {
 	return ErrUnsupportedOS
 }
 
+// CmsgSpace returns the size of the control message buffer holding a message with datalen bytes of data
+func CmsgSpace(datalen int) int {
+	return 0
+}
+
+// Socketpair creates a pair of connected unix stream sockets that are closed on exec
+func Socketpair() (fds [2]int, err error) {
+	return fds, ErrUnsupportedOS
+}
+
 // Mmap calls the system call to map memory on a fd
 func Mmap(fd int, offset int64, length int, prot int, flags int) (data []byte, err error) {
 	return nil, ErrUnsupportedOS
@@ -52,6 +67,22 @@ func Close(fd int) error {
 	return ErrUnsupportedOS
 }
 
+// Pread reads from the fd at the offset, without changing the file offset
+func Pread(fd int, p []byte, offset int64) (int, error) {
+	return 0, ErrUnsupportedOS
+}
+
+// RegularFileSize returns the size of the file of the fd, ok is false when it is not a regular file
+func RegularFileSize(fd int) (size int64, ok bool) {
+	return 0, false
+}
+
+// TryLockFile takes an exclusive lock of the file of the fd without blocking, it fails when
+// the file is locked by another open file. The lock is released when the fd is closed.
+func TryLockFile(fd int) error {
+	return ErrUnsupportedOS
+}
+
 // ProtRead Pages may be read
 const ProtRead = 0x1
 
@@ -63,3 +94,6 @@ const MapShared = 0x01
 
 // MapPrivate Private mapping
 const MapPrivate = 0x02
+
+// MsgCtrunc Control data was discarded due to lack of space in the control message buffer
+const MsgCtrunc = 0x8
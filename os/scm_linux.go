@@ -27,9 +27,14 @@ func fallocate(fd int, mode uint32, off int64, size int64) error {
 	return syscall.Fallocate(fd, mode, off, size)
 }
 
-// UnixRights calls a system call
-func UnixRights(fd int) []byte {
-	return syscall.UnixRights(fd)
+// UnixRights calls a system call to encode the fds into a SCM_RIGHTS control message
+func UnixRights(fds ...int) []byte {
+	return syscall.UnixRights(fds...)
+}
+
+// DupCloexec duplicates the fd, the duplicate is closed on exec
+func DupCloexec(fd int) (int, error) {
+	return unix.FcntlInt(uintptr(fd), unix.F_DUPFD_CLOEXEC, 0)
 }
 
 // Sendmsg sends information on fd using a Sendmsg system call
@@ -37,6 +42,24 @@ func Sendmsg(fd int, msg []byte, oob []byte, sockaddr Sockaddr, z int) error {
 	return unix.Sendmsg(fd, msg, oob, sockaddr, z)
 }
 
+// CmsgSpace returns the size of the control message buffer holding a message with datalen bytes of data
+func CmsgSpace(datalen int) int {
+	return syscall.CmsgSpace(datalen)
+}
+
+// Socketpair creates a pair of connected unix stream sockets that are closed on exec
+func Socketpair() (fds [2]int, err error) {
+	syscall.ForkLock.RLock()
+	defer syscall.ForkLock.RUnlock()
+	fds, err = syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	if err != nil {
+		return fds, err
+	}
+	syscall.CloseOnExec(fds[0])
+	syscall.CloseOnExec(fds[1])
+	return fds, nil
+}
+
 // Mmap calls the system call to map memory on a fd
 func Mmap(fd int, offset int64, length int, prot int, flags int) (data []byte, err error) {
 	return syscall.Mmap(fd, offset, length, prot, flags)
@@ -52,6 +75,26 @@ func Close(fd int) error {
 	return syscall.Close(fd)
 }
 
+// Pread reads from the fd at the offset, without changing the file offset
+func Pread(fd int, p []byte, offset int64) (int, error) {
+	return syscall.Pread(fd, p, offset)
+}
+
+// RegularFileSize returns the size of the file of the fd, ok is false when it is not a regular file
+func RegularFileSize(fd int) (size int64, ok bool) {
+	var st syscall.Stat_t
+	if syscall.Fstat(fd, &st) != nil || st.Mode&syscall.S_IFMT != syscall.S_IFREG {
+		return 0, false
+	}
+	return st.Size, true
+}
+
+// TryLockFile takes an exclusive lock of the file of the fd without blocking, it fails when
+// the file is locked by another open file. The lock is released when the fd is closed.
+func TryLockFile(fd int) error {
+	return unix.Flock(fd, unix.LOCK_EX|unix.LOCK_NB)
+}
+
 // ProtRead Pages may be read
 const ProtRead = syscall.PROT_READ
 
@@ -63,3 +106,6 @@ const MapShared = syscall.MAP_SHARED
 
 // MapPrivate Private mapping
 const MapPrivate = syscall.MAP_PRIVATE
+
+// MsgCtrunc Control data was discarded due to lack of space in the control message buffer
+const MsgCtrunc = syscall.MSG_CTRUNC
@@ -0,0 +1,563 @@
+// Code generated by go-wayland-scanner; DO NOT EDIT.
+// XML file: presentation-time.xml
+//
+// PresentationTime Protocol Copyright:
+//
+// Copyright © 2013-2014 Collabora, Ltd.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the next
+// paragraph) shall be included in all copies or substantial portions of the
+// Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package presentation
+
+import (
+	"github.com/neurlang/wayland/wl"
+	"sync"
+)
+
+// Presentation: timed presentation related wl_surface requests
+//
+// The main feature of this interface is accurate presentation
+// timing feedback to ensure smooth video playback while maintaining
+// audio/video synchronization. Some features use the concept of a
+// presentation clock, which is defined in the
+// presentation.clock_id event.
+//
+// A content update for a wl_surface is submitted by a
+// wl_surface.commit request. Request 'feedback' associates with
+// the wl_surface.commit and provides feedback on the content
+// update, particularly the final realized presentation time.
+//
+// When the final realized presentation time is available, e.g.
+// after a framebuffer flip completes, the requested
+// presentation_feedback.presented events are sent. The final
+// presentation time can differ from the compositor's predicted
+// display update time and the update's target time, especially
+// when the compositor misses its target vertical blanking period.
+type Presentation struct {
+	wl.BaseProxy
+	mu              sync.RWMutex
+	clockIdHandlers []PresentationClockIdHandler
+}
+
+// NewPresentation creates a new wp_presentation proxy registered in the Context
+func NewPresentation(ctx *wl.Context) *Presentation {
+	ret := new(Presentation)
+	ctx.Register(ret)
+	return ret
+}
+
+// Destroy: unbind from the presentation interface
+//
+// Informs the server that the client will no longer be using
+// this protocol object. Existing objects created by this object
+// are not affected.
+func (p *Presentation) Destroy() error {
+	err := p.Context().MarshalRequest(p, 0, nil)
+	p.Unregister()
+	return err
+}
+
+// Feedback: request presentation feedback information
+//
+// Request presentation feedback for the current content submission
+// on the given surface. This creates a new presentation_feedback
+// object, which will deliver the feedback information once. If
+// multiple presentation_feedback objects are created for the same
+// submission, they will all deliver the same information.
+//
+// For details on what information is returned, see the
+// presentation_feedback interface.
+//
+//	surface: target surface
+//	callback: new feedback object
+func (p *Presentation) Feedback(surface *wl.Surface) (*PresentationFeedback, error) {
+	ret := NewPresentationFeedback(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 1, func(r *wl.Request) {
+		if surface != nil {
+			r.PutObject(surface.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutNewId(ret)
+	})
+}
+
+// PresentationError: fatal presentation errors
+//
+// These fatal protocol errors may be emitted in response to
+// illegal presentation requests.
+const (
+	// PresentationErrorInvalidTimestamp: invalid value in tv_nsec
+	PresentationErrorInvalidTimestamp = 0
+	// PresentationErrorInvalidFlag: invalid flag
+	PresentationErrorInvalidFlag = 1
+)
+
+// PresentationClockIdEvent: clock ID for timestamps
+//
+// This event tells the client in which clock domain the
+// compositor interprets the timestamps used by the presentation
+// extension. This clock is called the presentation clock.
+//
+// The compositor sends this event when the client binds to the
+// presentation interface. The presentation clock does not change
+// during the lifetime of the client connection.
+//
+// The clock identifier is platform dependent. On POSIX platforms, the
+// identifier value is one of the clockid_t values accepted by
+// clock_gettime(). clock_gettime() is defined by POSIX.1-2001.
+//
+// Timestamps in this clock domain are expressed as tv_sec_hi,
+// tv_sec_lo, tv_nsec triples, each component being an unsigned
+// 32-bit value. Whole seconds are in tv_sec which is a 64-bit
+// value combined from tv_sec_hi and tv_sec_lo, and the
+// additional fractional part in tv_nsec as nanoseconds. Hence,
+// for valid timestamps tv_nsec must be in [0, 999999999].
+//
+// Note that clock_id applies only to the presentation clock,
+// and implies nothing about e.g. the timestamps used in the
+// Wayland core protocol input events.
+//
+// Compositors should prefer a clock which does not jump and is
+// not slewed e.g. by NTP. The absolute value of the clock is
+// irrelevant. Precision of one millisecond or better is
+// recommended. Clients must be able to query the current clock
+// value directly, not by asking the compositor.
+type PresentationClockIdEvent struct {
+	ClkId uint32
+}
+
+// PresentationClockIdHandler is implemented by the receivers of PresentationClockIdEvent
+type PresentationClockIdHandler interface {
+	HandlePresentationClockId(PresentationClockIdEvent)
+}
+
+// AddClockIdHandler adds a handler for PresentationClockIdEvent
+func (p *Presentation) AddClockIdHandler(h PresentationClockIdHandler) {
+	if h == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.clockIdHandlers = append(p.clockIdHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemoveClockIdHandler removes a handler previously added by AddClockIdHandler
+func (p *Presentation) RemoveClockIdHandler(h PresentationClockIdHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.clockIdHandlers {
+		if e == h {
+			p.clockIdHandlers = append(p.clockIdHandlers[:i:i], p.clockIdHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// Dispatch decodes an event received on the wp_presentation and runs its handlers
+func (p *Presentation) Dispatch(event *wl.Event) {
+	switch event.Opcode {
+	case 0:
+		p.mu.RLock()
+		handlers := p.clockIdHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := PresentationClockIdEvent{}
+		ev.ClkId = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandlePresentationClockId(ev)
+		}
+	}
+}
+
+const (
+	PresentationClockIdSinceVersion  = 1
+	PresentationDestroySinceVersion  = 1
+	PresentationFeedbackSinceVersion = 1
+)
+
+// PresentationInterface describes the wp_presentation interface
+var PresentationInterface = &wl.Interface{
+	Name:    "wp_presentation",
+	Version: 1,
+	Requests: []wl.Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "feedback",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "surface", Type: wl.ArgObject, Interface: "wl_surface"},
+				{Name: "callback", Type: wl.ArgNewId, Interface: "wp_presentation_feedback"},
+			},
+		},
+	},
+	Events: []wl.Message{
+		{
+			Name:  "clock_id",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "clk_id", Type: wl.ArgUint},
+			},
+		},
+	},
+	Enums: []wl.Enum{
+		{
+			Name: "error",
+			Entries: []wl.EnumEntry{
+				{Name: "invalid_timestamp", Value: 0},
+				{Name: "invalid_flag", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wp_presentation interface
+func (p *Presentation) Interface() *wl.Interface {
+	return PresentationInterface
+}
+
+// PresentationFeedback: presentation time feedback event
+//
+// A presentation_feedback object returns an indication that a
+// wl_surface content update has become visible to the user.
+// One object corresponds to one content update submission
+// (wl_surface.commit). There are two possible outcomes: the
+// content update is presented to the user, and a presentation
+// timestamp delivered; or, the user did not see the content
+// update because it was superseded or its surface destroyed,
+// and the content update is discarded.
+//
+// Once a presentation_feedback object has delivered a 'presented'
+// or 'discarded' event it is automatically destroyed.
+type PresentationFeedback struct {
+	wl.BaseProxy
+	mu                 sync.RWMutex
+	syncOutputHandlers []PresentationFeedbackSyncOutputHandler
+	presentedHandlers  []PresentationFeedbackPresentedHandler
+	discardedHandlers  []PresentationFeedbackDiscardedHandler
+}
+
+// NewPresentationFeedback creates a new wp_presentation_feedback proxy registered in the Context
+func NewPresentationFeedback(ctx *wl.Context) *PresentationFeedback {
+	ret := new(PresentationFeedback)
+	ctx.Register(ret)
+	return ret
+}
+
+// PresentationFeedbackKind: bitmask of flags in presented event
+//
+// These flags provide information about how the presentation of
+// the related content update was done. The intent is to help
+// clients assess the reliability of the feedback and the visual
+// quality with respect to possible tearing and timings.
+const (
+	// PresentationFeedbackKindVsync: presentation was vsync'd
+	PresentationFeedbackKindVsync = 0x1
+	// PresentationFeedbackKindHwClock: hardware provided the presentation timestamp
+	PresentationFeedbackKindHwClock = 0x2
+	// PresentationFeedbackKindHwCompletion: hardware signalled the start of the presentation
+	PresentationFeedbackKindHwCompletion = 0x4
+	// PresentationFeedbackKindZeroCopy: presentation was done zero-copy
+	PresentationFeedbackKindZeroCopy = 0x8
+)
+
+// PresentationFeedbackSyncOutputEvent: presentation synchronized to this output
+//
+// As presentation can be synchronized to only one output at a
+// time, this event tells which output it was. This event is only
+// sent prior to the presented event.
+//
+// As clients may bind to the same global wl_output multiple
+// times, this event is sent for each bound instance that matches
+// the synchronized output. If a client has not bound to the
+// right wl_output global at all, this event is not sent.
+type PresentationFeedbackSyncOutputEvent struct {
+	Output *wl.Output
+}
+
+// PresentationFeedbackSyncOutputHandler is implemented by the receivers of PresentationFeedbackSyncOutputEvent
+type PresentationFeedbackSyncOutputHandler interface {
+	HandlePresentationFeedbackSyncOutput(PresentationFeedbackSyncOutputEvent)
+}
+
+// AddSyncOutputHandler adds a handler for PresentationFeedbackSyncOutputEvent
+func (p *PresentationFeedback) AddSyncOutputHandler(h PresentationFeedbackSyncOutputHandler) {
+	if h == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.syncOutputHandlers = append(p.syncOutputHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemoveSyncOutputHandler removes a handler previously added by AddSyncOutputHandler
+func (p *PresentationFeedback) RemoveSyncOutputHandler(h PresentationFeedbackSyncOutputHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.syncOutputHandlers {
+		if e == h {
+			p.syncOutputHandlers = append(p.syncOutputHandlers[:i:i], p.syncOutputHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// PresentationFeedbackPresentedEvent: the content update was displayed
+//
+// The associated content update was displayed to the user at the
+// indicated time (tv_sec_hi/lo, tv_nsec). For the interpretation of
+// the timestamp, see presentation.clock_id event.
+//
+// The timestamp corresponds to the time when the content update
+// turned into light the first time on the surface's main output.
+// Compositors may approximate this from the framebuffer flip
+// completion events from the system, and the latency of the
+// physical display path if known.
+//
+// The refresh argument gives the compositor's prediction of how
+// many nanoseconds after tv_sec, tv_nsec the very next output
+// refresh may occur. This is to further aid clients in
+// estimating the compositor's prediction of how many nanoseconds
+// after the presentation the next output refresh may occur. If
+// the output does not have a constant refresh rate, explicit
+// video mode switches excluded, then the refresh argument must
+// be zero.
+//
+// The 64-bit value combined from seq_hi and seq_lo is the value
+// of the output's vertical retrace counter when the content
+// update was first scanned out to the display. This value must
+// be compatible with the definition of MSC in
+// GLX_OML_sync_control specification. Note, that if the display
+// path has a non-zero latency, the time instant specified by
+// this counter may differ from the timestamp's.
+//
+// If the output does not have a concept of vertical retrace or a
+// refresh cycle, or the output device is self-refreshing without
+// a way to query the refresh count, then the arguments seq_hi
+// and seq_lo must be zero.
+type PresentationFeedbackPresentedEvent struct {
+	TvSecHi uint32
+	TvSecLo uint32
+	TvNsec  uint32
+	Refresh uint32
+	SeqHi   uint32
+	SeqLo   uint32
+	Flags   uint32
+}
+
+// PresentationFeedbackPresentedHandler is implemented by the receivers of PresentationFeedbackPresentedEvent
+type PresentationFeedbackPresentedHandler interface {
+	HandlePresentationFeedbackPresented(PresentationFeedbackPresentedEvent)
+}
+
+// AddPresentedHandler adds a handler for PresentationFeedbackPresentedEvent
+func (p *PresentationFeedback) AddPresentedHandler(h PresentationFeedbackPresentedHandler) {
+	if h == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.presentedHandlers = append(p.presentedHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemovePresentedHandler removes a handler previously added by AddPresentedHandler
+func (p *PresentationFeedback) RemovePresentedHandler(h PresentationFeedbackPresentedHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.presentedHandlers {
+		if e == h {
+			p.presentedHandlers = append(p.presentedHandlers[:i:i], p.presentedHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// PresentationFeedbackDiscardedEvent: the content update was not displayed
+//
+// The content update was never displayed to the user.
+type PresentationFeedbackDiscardedEvent struct {
+}
+
+// PresentationFeedbackDiscardedHandler is implemented by the receivers of PresentationFeedbackDiscardedEvent
+type PresentationFeedbackDiscardedHandler interface {
+	HandlePresentationFeedbackDiscarded(PresentationFeedbackDiscardedEvent)
+}
+
+// AddDiscardedHandler adds a handler for PresentationFeedbackDiscardedEvent
+func (p *PresentationFeedback) AddDiscardedHandler(h PresentationFeedbackDiscardedHandler) {
+	if h == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.discardedHandlers = append(p.discardedHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemoveDiscardedHandler removes a handler previously added by AddDiscardedHandler
+func (p *PresentationFeedback) RemoveDiscardedHandler(h PresentationFeedbackDiscardedHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.discardedHandlers {
+		if e == h {
+			p.discardedHandlers = append(p.discardedHandlers[:i:i], p.discardedHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// Dispatch decodes an event received on the wp_presentation_feedback and runs its handlers
+func (p *PresentationFeedback) Dispatch(event *wl.Event) {
+	switch event.Opcode {
+	case 0:
+		p.mu.RLock()
+		handlers := p.syncOutputHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := PresentationFeedbackSyncOutputEvent{}
+		ev.Output, _ = event.Proxy(p.Context()).(*wl.Output)
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandlePresentationFeedbackSyncOutput(ev)
+		}
+	case 1:
+		p.mu.RLock()
+		handlers := p.presentedHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := PresentationFeedbackPresentedEvent{}
+		ev.TvSecHi = event.Uint32()
+		ev.TvSecLo = event.Uint32()
+		ev.TvNsec = event.Uint32()
+		ev.Refresh = event.Uint32()
+		ev.SeqHi = event.Uint32()
+		ev.SeqLo = event.Uint32()
+		ev.Flags = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandlePresentationFeedbackPresented(ev)
+		}
+	case 2:
+		p.mu.RLock()
+		handlers := p.discardedHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := PresentationFeedbackDiscardedEvent{}
+		for _, h := range handlers {
+			h.HandlePresentationFeedbackDiscarded(ev)
+		}
+	}
+}
+
+const (
+	PresentationFeedbackSyncOutputSinceVersion = 1
+	PresentationFeedbackPresentedSinceVersion  = 1
+	PresentationFeedbackDiscardedSinceVersion  = 1
+)
+
+// PresentationFeedbackInterface describes the wp_presentation_feedback interface
+var PresentationFeedbackInterface = &wl.Interface{
+	Name:    "wp_presentation_feedback",
+	Version: 1,
+	Events: []wl.Message{
+		{
+			Name:  "sync_output",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "output", Type: wl.ArgObject, Interface: "wl_output"},
+			},
+		},
+		{
+			Name:       "presented",
+			Since:      1,
+			Destructor: true,
+			Args: []wl.Arg{
+				{Name: "tv_sec_hi", Type: wl.ArgUint},
+				{Name: "tv_sec_lo", Type: wl.ArgUint},
+				{Name: "tv_nsec", Type: wl.ArgUint},
+				{Name: "refresh", Type: wl.ArgUint},
+				{Name: "seq_hi", Type: wl.ArgUint},
+				{Name: "seq_lo", Type: wl.ArgUint},
+				{Name: "flags", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:       "discarded",
+			Since:      1,
+			Destructor: true,
+		},
+	},
+	Enums: []wl.Enum{
+		{
+			Name:     "kind",
+			Bitfield: true,
+			Entries: []wl.EnumEntry{
+				{Name: "vsync", Value: 0x1},
+				{Name: "hw_clock", Value: 0x2},
+				{Name: "hw_completion", Value: 0x4},
+				{Name: "zero_copy", Value: 0x8},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wp_presentation_feedback interface
+func (p *PresentationFeedback) Interface() *wl.Interface {
+	return PresentationFeedbackInterface
+}
+
+func init() {
+	wl.RegisterInterface(PresentationInterface)
+	wl.RegisterInterface(PresentationFeedbackInterface)
+	wl.RegisterProxy(PresentationInterface, func(ctx *wl.Context) wl.Proxy {
+		return NewPresentation(ctx)
+	})
+	wl.RegisterProxy(PresentationFeedbackInterface, func(ctx *wl.Context) wl.Proxy {
+		return NewPresentationFeedback(ctx)
+	})
+}
@@ -0,0 +1,59 @@
+// Code generated by go-wayland-scanner; DO NOT EDIT.
+// XML file: presentation-time.xml
+
+package presentation
+
+import (
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wltest"
+	"testing"
+)
+
+// fuzzHandler handles every event of the protocol
+type fuzzHandler struct{}
+
+func (fuzzHandler) HandlePresentationClockId(PresentationClockIdEvent)                       {}
+func (fuzzHandler) HandlePresentationFeedbackSyncOutput(PresentationFeedbackSyncOutputEvent) {}
+func (fuzzHandler) HandlePresentationFeedbackPresented(PresentationFeedbackPresentedEvent)   {}
+func (fuzzHandler) HandlePresentationFeedbackDiscarded(PresentationFeedbackDiscardedEvent)   {}
+
+// fuzzProxy is a proxy receiving the fuzzed events
+type fuzzProxy interface {
+	wl.Dispatcher
+	Interface() *wl.Interface
+}
+
+// FuzzDispatch decodes arbitrary event messages by the Dispatch of every interface with events
+func FuzzDispatch(f *testing.F) {
+	display, server, err := wltest.New()
+	if err != nil {
+		f.Fatal(err)
+	}
+	defer server.Close()
+	ctx := display.Context()
+
+	var h fuzzHandler
+	var proxies []fuzzProxy
+	{
+		p := NewPresentation(ctx)
+		p.AddClockIdHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewPresentationFeedback(ctx)
+		p.AddSyncOutputHandler(h)
+		p.AddPresentedHandler(h)
+		p.AddDiscardedHandler(h)
+		proxies = append(proxies, p)
+	}
+
+	for i, p := range proxies {
+		for opcode := range p.Interface().Events {
+			f.Add(uint8(i), uint16(opcode), make([]byte, 64))
+		}
+	}
+	f.Fuzz(func(t *testing.T, index uint8, opcode uint16, data []byte) {
+		p := proxies[int(index)%len(proxies)]
+		p.Dispatch(&wl.Event{Opcode: uint32(opcode), Data: data})
+	})
+}
@@ -0,0 +1,5 @@
+// Package presentation implements the stable wp_presentation protocol, the compositor tells when
+// the content updates of a surface were presented
+package presentation
+
+//go:generate go run ../cmd/go-wayland-scanner -pkg presentation -prefix wp_ -i presentation-time.xml -o presentation-time.xml.go -fuzz presentation-time.xml_fuzz_test.go
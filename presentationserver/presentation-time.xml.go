@@ -0,0 +1,303 @@
+// Code generated by go-wayland-scanner; DO NOT EDIT.
+// XML file: presentation-time.xml
+//
+// PresentationTime Protocol Copyright:
+//
+// Copyright © 2013-2014 Collabora, Ltd.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the next
+// paragraph) shall be included in all copies or substantial portions of the
+// Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package presentationserver
+
+import (
+	"github.com/neurlang/wayland/presentation"
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wlserver"
+)
+
+// Presentation: timed presentation related wl_surface requests
+//
+// The main feature of this interface is accurate presentation
+// timing feedback to ensure smooth video playback while maintaining
+// audio/video synchronization. Some features use the concept of a
+// presentation clock, which is defined in the
+// presentation.clock_id event.
+//
+// A content update for a wl_surface is submitted by a
+// wl_surface.commit request. Request 'feedback' associates with
+// the wl_surface.commit and provides feedback on the content
+// update, particularly the final realized presentation time.
+//
+// When the final realized presentation time is available, e.g.
+// after a framebuffer flip completes, the requested
+// presentation_feedback.presented events are sent. The final
+// presentation time can differ from the compositor's predicted
+// display update time and the update's target time, especially
+// when the compositor misses its target vertical blanking period.
+type Presentation struct {
+	wlserver.BaseResource
+}
+
+// Interface returns the description of the wp_presentation interface
+func (r *Presentation) Interface() *wl.Interface {
+	return presentation.PresentationInterface
+}
+
+// PresentationError: fatal presentation errors
+//
+// These fatal protocol errors may be emitted in response to
+// illegal presentation requests.
+const (
+	// PresentationErrorInvalidTimestamp: invalid value in tv_nsec
+	PresentationErrorInvalidTimestamp = 0
+	// PresentationErrorInvalidFlag: invalid flag
+	PresentationErrorInvalidFlag = 1
+)
+
+// PresentationDestroyRequest: unbind from the presentation interface
+//
+// Informs the server that the client will no longer be using
+// this protocol object. Existing objects created by this object
+// are not affected.
+type PresentationDestroyRequest struct {
+	Resource *Presentation
+}
+
+// PresentationDestroyHandler is implemented by the handlers of PresentationDestroyRequest, see SetHandler
+type PresentationDestroyHandler interface {
+	HandlePresentationDestroy(PresentationDestroyRequest)
+}
+
+// PresentationFeedbackRequest: request presentation feedback information
+//
+// Request presentation feedback for the current content submission
+// on the given surface. This creates a new presentation_feedback
+// object, which will deliver the feedback information once. If
+// multiple presentation_feedback objects are created for the same
+// submission, they will all deliver the same information.
+//
+// For details on what information is returned, see the
+// presentation_feedback interface.
+type PresentationFeedbackRequest struct {
+	Resource *Presentation
+	Surface  *wlserver.Surface
+	Callback *PresentationFeedback
+}
+
+// PresentationFeedbackHandler is implemented by the handlers of PresentationFeedbackRequest, see SetHandler
+type PresentationFeedbackHandler interface {
+	HandlePresentationFeedback(PresentationFeedbackRequest)
+}
+
+// Dispatch decodes a request received on the wp_presentation and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Presentation) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := PresentationDestroyRequest{Resource: r}
+		if h, ok := r.Handler().(PresentationDestroyHandler); ok {
+			h.HandlePresentationDestroy(req)
+		}
+	case 1:
+		req := PresentationFeedbackRequest{Resource: r}
+		var err error
+		var surfaceRes wlserver.Resource
+		if surfaceRes, err = c.Argument(event.Uint32(), false, wl.SurfaceInterface); err != nil {
+			return err
+		}
+		req.Surface, _ = surfaceRes.(*wlserver.Surface)
+		req.Callback = new(PresentationFeedback)
+		if err := c.NewId(req.Callback, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(PresentationFeedbackHandler); ok {
+			h.HandlePresentationFeedback(req)
+		}
+	}
+	return nil
+}
+
+// SendClockId: clock ID for timestamps
+//
+// This event tells the client in which clock domain the
+// compositor interprets the timestamps used by the presentation
+// extension. This clock is called the presentation clock.
+//
+// The compositor sends this event when the client binds to the
+// presentation interface. The presentation clock does not change
+// during the lifetime of the client connection.
+//
+// The clock identifier is platform dependent. On POSIX platforms, the
+// identifier value is one of the clockid_t values accepted by
+// clock_gettime(). clock_gettime() is defined by POSIX.1-2001.
+//
+// Timestamps in this clock domain are expressed as tv_sec_hi,
+// tv_sec_lo, tv_nsec triples, each component being an unsigned
+// 32-bit value. Whole seconds are in tv_sec which is a 64-bit
+// value combined from tv_sec_hi and tv_sec_lo, and the
+// additional fractional part in tv_nsec as nanoseconds. Hence,
+// for valid timestamps tv_nsec must be in [0, 999999999].
+//
+// Note that clock_id applies only to the presentation clock,
+// and implies nothing about e.g. the timestamps used in the
+// Wayland core protocol input events.
+//
+// Compositors should prefer a clock which does not jump and is
+// not slewed e.g. by NTP. The absolute value of the clock is
+// irrelevant. Precision of one millisecond or better is
+// recommended. Clients must be able to query the current clock
+// value directly, not by asking the compositor.
+//
+//	clkId: platform clock identifier
+func (r *Presentation) SendClockId(clkId uint32) error {
+	return r.Client().SendEvent(r, 0, clkId)
+}
+
+// PresentationFeedback: presentation time feedback event
+//
+// A presentation_feedback object returns an indication that a
+// wl_surface content update has become visible to the user.
+// One object corresponds to one content update submission
+// (wl_surface.commit). There are two possible outcomes: the
+// content update is presented to the user, and a presentation
+// timestamp delivered; or, the user did not see the content
+// update because it was superseded or its surface destroyed,
+// and the content update is discarded.
+//
+// Once a presentation_feedback object has delivered a 'presented'
+// or 'discarded' event it is automatically destroyed.
+type PresentationFeedback struct {
+	wlserver.BaseResource
+}
+
+// Interface returns the description of the wp_presentation_feedback interface
+func (r *PresentationFeedback) Interface() *wl.Interface {
+	return presentation.PresentationFeedbackInterface
+}
+
+// PresentationFeedbackKind: bitmask of flags in presented event
+//
+// These flags provide information about how the presentation of
+// the related content update was done. The intent is to help
+// clients assess the reliability of the feedback and the visual
+// quality with respect to possible tearing and timings.
+const (
+	// PresentationFeedbackKindVsync: presentation was vsync'd
+	PresentationFeedbackKindVsync = 0x1
+	// PresentationFeedbackKindHwClock: hardware provided the presentation timestamp
+	PresentationFeedbackKindHwClock = 0x2
+	// PresentationFeedbackKindHwCompletion: hardware signalled the start of the presentation
+	PresentationFeedbackKindHwCompletion = 0x4
+	// PresentationFeedbackKindZeroCopy: presentation was done zero-copy
+	PresentationFeedbackKindZeroCopy = 0x8
+)
+
+// Dispatch decodes a request received on the wp_presentation_feedback and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *PresentationFeedback) Dispatch(event *wl.Event) error {
+	return nil
+}
+
+// SendSyncOutput: presentation synchronized to this output
+//
+// As presentation can be synchronized to only one output at a
+// time, this event tells which output it was. This event is only
+// sent prior to the presented event.
+//
+// As clients may bind to the same global wl_output multiple
+// times, this event is sent for each bound instance that matches
+// the synchronized output. If a client has not bound to the
+// right wl_output global at all, this event is not sent.
+//
+//	output: presentation output
+func (r *PresentationFeedback) SendSyncOutput(output *wlserver.Output) error {
+	return r.Client().SendEvent(r, 0, output)
+}
+
+// SendPresented: the content update was displayed
+//
+// The associated content update was displayed to the user at the
+// indicated time (tv_sec_hi/lo, tv_nsec). For the interpretation of
+// the timestamp, see presentation.clock_id event.
+//
+// The timestamp corresponds to the time when the content update
+// turned into light the first time on the surface's main output.
+// Compositors may approximate this from the framebuffer flip
+// completion events from the system, and the latency of the
+// physical display path if known.
+//
+// The refresh argument gives the compositor's prediction of how
+// many nanoseconds after tv_sec, tv_nsec the very next output
+// refresh may occur. This is to further aid clients in
+// estimating the compositor's prediction of how many nanoseconds
+// after the presentation the next output refresh may occur. If
+// the output does not have a constant refresh rate, explicit
+// video mode switches excluded, then the refresh argument must
+// be zero.
+//
+// The 64-bit value combined from seq_hi and seq_lo is the value
+// of the output's vertical retrace counter when the content
+// update was first scanned out to the display. This value must
+// be compatible with the definition of MSC in
+// GLX_OML_sync_control specification. Note, that if the display
+// path has a non-zero latency, the time instant specified by
+// this counter may differ from the timestamp's.
+//
+// If the output does not have a concept of vertical retrace or a
+// refresh cycle, or the output device is self-refreshing without
+// a way to query the refresh count, then the arguments seq_hi
+// and seq_lo must be zero.
+//
+//	tvSecHi: high 32 bits of the seconds part of the presentation timestamp
+//	tvSecLo: low 32 bits of the seconds part of the presentation timestamp
+//	tvNsec: nanoseconds part of the presentation timestamp
+//	refresh: nanoseconds till next refresh
+//	seqHi: high 32 bits of refresh counter
+//	seqLo: low 32 bits of refresh counter
+//	flags: combination of 'kind' values
+//
+// The resource is destroyed once the event is sent.
+func (r *PresentationFeedback) SendPresented(tvSecHi uint32, tvSecLo uint32, tvNsec uint32, refresh uint32, seqHi uint32, seqLo uint32, flags uint32) error {
+	defer r.Client().Destroy(r)
+	return r.Client().SendEvent(r, 1, tvSecHi, tvSecLo, tvNsec, refresh, seqHi, seqLo, flags)
+}
+
+// SendDiscarded: the content update was not displayed
+//
+// The content update was never displayed to the user.
+//
+// The resource is destroyed once the event is sent.
+func (r *PresentationFeedback) SendDiscarded() error {
+	defer r.Client().Destroy(r)
+	return r.Client().SendEvent(r, 2)
+}
+
+func init() {
+	wlserver.RegisterResource(presentation.PresentationInterface, func() wlserver.Resource {
+		return new(Presentation)
+	})
+	wlserver.RegisterResource(presentation.PresentationFeedbackInterface, func() wlserver.Resource {
+		return new(PresentationFeedback)
+	})
+}
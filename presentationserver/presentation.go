@@ -0,0 +1,12 @@
+// Package presentationserver implements the compositor side of the stable wp_presentation protocol
+//
+// The resource types are generated from presentation-time.xml in the server flavor described in
+// package wlserver, a global of the wp_presentation is added by
+//
+//	server.AddGlobal(presentation.PresentationInterface, 1, func(r wlserver.Resource) {
+//		r.SetHandler(myPresentation)
+//		r.(*presentationserver.Presentation).SendClockId(clockID)
+//	})
+package presentationserver
+
+//go:generate go run ../cmd/go-wayland-scanner -server -client github.com/neurlang/wayland/presentation -pkg presentationserver -prefix wp_ -i ../presentation/presentation-time.xml -o presentation-time.xml.go
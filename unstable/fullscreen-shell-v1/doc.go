@@ -1,3 +1,4 @@
 package fullscreenshell
 
-//go:generate go run ../../cmd/go-wayland-scanner/scanner.go -pkg fullscreen_shell -i https://gitlab.freedesktop.org/wayland/wayland-protocols/-/raw/d10d18f3d49374d2e3eb96d63511f32795aab5f7/unstable/fullscreen-shell/fullscreen-shell-unstable-v1.xml -o fullscreen_shell.go
+// fullscreen-shell-unstable-v1.xml is unstable/fullscreen-shell/fullscreen-shell-unstable-v1.xml of wayland-protocols d10d18f3d49374d2e3eb96d63511f32795aab5f7
+//go:generate go run ../../cmd/go-wayland-scanner -pkg fullscreen_shell -i fullscreen-shell-unstable-v1.xml -o fullscreen_shell.go -fuzz fullscreen_shell_fuzz_test.go
@@ -0,0 +1,62 @@
+// Code generated by go-wayland-scanner; DO NOT EDIT.
+// XML file: fullscreen-shell-unstable-v1.xml
+
+package fullscreenshell
+
+import (
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wltest"
+	"testing"
+)
+
+// fuzzHandler handles every event of the protocol
+type fuzzHandler struct{}
+
+func (fuzzHandler) HandleZwpFullscreenShellV1Capability(ZwpFullscreenShellV1CapabilityEvent) {}
+func (fuzzHandler) HandleZwpFullscreenShellModeFeedbackV1ModeSuccessful(ZwpFullscreenShellModeFeedbackV1ModeSuccessfulEvent) {
+}
+func (fuzzHandler) HandleZwpFullscreenShellModeFeedbackV1ModeFailed(ZwpFullscreenShellModeFeedbackV1ModeFailedEvent) {
+}
+func (fuzzHandler) HandleZwpFullscreenShellModeFeedbackV1PresentCancelled(ZwpFullscreenShellModeFeedbackV1PresentCancelledEvent) {
+}
+
+// fuzzProxy is a proxy receiving the fuzzed events
+type fuzzProxy interface {
+	wl.Dispatcher
+	Interface() *wl.Interface
+}
+
+// FuzzDispatch decodes arbitrary event messages by the Dispatch of every interface with events
+func FuzzDispatch(f *testing.F) {
+	display, server, err := wltest.New()
+	if err != nil {
+		f.Fatal(err)
+	}
+	defer server.Close()
+	ctx := display.Context()
+
+	var h fuzzHandler
+	var proxies []fuzzProxy
+	{
+		p := NewZwpFullscreenShellV1(ctx)
+		p.AddCapabilityHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewZwpFullscreenShellModeFeedbackV1(ctx)
+		p.AddModeSuccessfulHandler(h)
+		p.AddModeFailedHandler(h)
+		p.AddPresentCancelledHandler(h)
+		proxies = append(proxies, p)
+	}
+
+	for i, p := range proxies {
+		for opcode := range p.Interface().Events {
+			f.Add(uint8(i), uint16(opcode), make([]byte, 64))
+		}
+	}
+	f.Fuzz(func(t *testing.T, index uint8, opcode uint16, data []byte) {
+		p := proxies[int(index)%len(proxies)]
+		p.Dispatch(&wl.Event{Opcode: uint32(opcode), Data: data})
+	})
+}
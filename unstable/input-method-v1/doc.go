@@ -1,3 +1,3 @@
 package inputmethod
 
-//go:generate ../../../../../../bin/wl-scanner -pkg input_method -source input-method-unstable-v1.xml -output input_method.go
+//go:generate go run ../../cmd/go-wayland-scanner -pkg input_method -i input-method-unstable-v1.xml -o input_method.go -fuzz input_method_fuzz_test.go
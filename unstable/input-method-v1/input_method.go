@@ -1,9 +1,28 @@
-// package input_method acts as a client for the input_method_unstable_v1 wayland protocol.
-
-// generated by wl-scanner
-// https://github.com/dkolbly/wl-scanner
-// from: input-method-unstable-v1.xml
-// on 2021-03-28 12:39:56 +0200
+// Code generated by go-wayland-scanner; DO NOT EDIT.
+// XML file: input-method-unstable-v1.xml
+//
+// InputMethodUnstableV1 Protocol Copyright:
+//
+// Copyright © 2012, 2013 Intel Corporation
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the next
+// paragraph) shall be included in all copies or substantial portions of the
+// Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
 
 package inputmethod
 
@@ -12,576 +31,1080 @@ import (
 	"sync"
 )
 
+// ZwpInputMethodContextV1: input method context
+//
+// Corresponds to a text input on the input method side. An input method context
+// is created on text input activation on the input method side. It allows
+// receiving information about the text input from the application via events.
+// Input method contexts do not keep state after deactivation and should be
+// destroyed after deactivation is handled.
+//
+// Text is generally UTF-8 encoded, indices and lengths are in bytes.
+//
+// Serials are used to synchronize the state between the text input and
+// an input method. New serials are sent by the text input in the
+// commit_state request and are used by the input method to indicate
+// the known text input state in events like preedit_string, commit_string,
+// and keysym. The text input can then ignore events from the input method
+// which are based on an outdated state (for example after a reset).
+//
+// Warning! The protocol described in this file is experimental and
+// backward incompatible changes may be made. Backward compatible changes
+// may be added together with the corresponding interface version bump.
+// Backward incompatible changes are done by bumping the version number in
+// the protocol and interface names and resetting the interface version.
+// Once the protocol is to be declared stable, the 'z' prefix and the
+// version number in the protocol and interface names are removed and the
+// interface version number is reset.
+type ZwpInputMethodContextV1 struct {
+	wl.BaseProxy
+	mu                        sync.RWMutex
+	surroundingTextHandlers   []ZwpInputMethodContextV1SurroundingTextHandler
+	resetHandlers             []ZwpInputMethodContextV1ResetHandler
+	contentTypeHandlers       []ZwpInputMethodContextV1ContentTypeHandler
+	invokeActionHandlers      []ZwpInputMethodContextV1InvokeActionHandler
+	commitStateHandlers       []ZwpInputMethodContextV1CommitStateHandler
+	preferredLanguageHandlers []ZwpInputMethodContextV1PreferredLanguageHandler
+}
+
+// NewZwpInputMethodContextV1 creates a new zwp_input_method_context_v1 proxy registered in the Context
+func NewZwpInputMethodContextV1(ctx *wl.Context) *ZwpInputMethodContextV1 {
+	ret := new(ZwpInputMethodContextV1)
+	ctx.Register(ret)
+	return ret
+}
+
+// Destroy:
+func (p *ZwpInputMethodContextV1) Destroy() error {
+	err := p.Context().MarshalRequest(p, 0, nil)
+	p.Unregister()
+	return err
+}
+
+// CommitString: commit string
+//
+// Send the commit string text for insertion to the application.
+//
+// The text to commit could be either just a single character after a key
+// press or the result of some composing (pre-edit). It could be also an
+// empty text when some text should be removed (see
+// delete_surrounding_text) or when the input cursor should be moved (see
+// cursor_position).
+//
+// Any previously set composing text will be removed.
+//
+//	serial: serial of the latest known text input state
+func (p *ZwpInputMethodContextV1) CommitString(serial uint32, text string) error {
+	return p.Context().MarshalRequest(p, 1, func(r *wl.Request) {
+		r.PutUint32(serial)
+		r.PutString(text)
+	})
+}
+
+// PreeditString: pre-edit string
+//
+// Send the pre-edit string text to the application text input.
+//
+// The commit text can be used to replace the pre-edit text on reset (for
+// example on unfocus).
+//
+// Previously sent preedit_style and preedit_cursor requests are also
+// processed by the text_input.
+//
+//	serial: serial of the latest known text input state
+func (p *ZwpInputMethodContextV1) PreeditString(serial uint32, text string, commit string) error {
+	return p.Context().MarshalRequest(p, 2, func(r *wl.Request) {
+		r.PutUint32(serial)
+		r.PutString(text)
+		r.PutString(commit)
+	})
+}
+
+// PreeditStyling: pre-edit styling
+//
+// Set the styling information on composing text. The style is applied for
+// length in bytes from index relative to the beginning of
+// the composing text (as byte offset). Multiple styles can
+// be applied to a composing text.
+//
+// This request should be sent before sending a preedit_string request.
+func (p *ZwpInputMethodContextV1) PreeditStyling(index uint32, length uint32, style uint32) error {
+	return p.Context().MarshalRequest(p, 3, func(r *wl.Request) {
+		r.PutUint32(index)
+		r.PutUint32(length)
+		r.PutUint32(style)
+	})
+}
+
+// PreeditCursor: pre-edit cursor
+//
+// Set the cursor position inside the composing text (as byte offset)
+// relative to the start of the composing text.
+//
+// When index is negative no cursor should be displayed.
+//
+// This request should be sent before sending a preedit_string request.
+func (p *ZwpInputMethodContextV1) PreeditCursor(index int32) error {
+	return p.Context().MarshalRequest(p, 4, func(r *wl.Request) {
+		r.PutInt32(index)
+	})
+}
+
+// DeleteSurroundingText: delete text
+//
+// Remove the surrounding text.
+//
+// This request will be handled on the text_input side directly following
+// a commit_string request.
+func (p *ZwpInputMethodContextV1) DeleteSurroundingText(index int32, length uint32) error {
+	return p.Context().MarshalRequest(p, 5, func(r *wl.Request) {
+		r.PutInt32(index)
+		r.PutUint32(length)
+	})
+}
+
+// CursorPosition: set cursor to a new position
+//
+// Set the cursor and anchor to a new position. Index is the new cursor
+// position in bytes (when >= 0 this is relative to the end of the inserted text,
+// otherwise it is relative to the beginning of the inserted text). Anchor is
+// the new anchor position in bytes (when >= 0 this is relative to the end of the
+// inserted text, otherwise it is relative to the beginning of the inserted
+// text). When there should be no selected text, anchor should be the same
+// as index.
+//
+// This request will be handled on the text_input side directly following
+// a commit_string request.
+func (p *ZwpInputMethodContextV1) CursorPosition(index int32, anchor int32) error {
+	return p.Context().MarshalRequest(p, 6, func(r *wl.Request) {
+		r.PutInt32(index)
+		r.PutInt32(anchor)
+	})
+}
+
+// ModifiersMap:
+func (p *ZwpInputMethodContextV1) ModifiersMap(mp []int32) error {
+	return p.Context().MarshalRequest(p, 7, func(r *wl.Request) {
+		r.PutArray(mp)
+	})
+}
+
+// Keysym: keysym
+//
+// Notify when a key event was sent. Key events should not be used for
+// normal text input operations, which should be done with commit_string,
+// delete_surrounding_text, etc. The key event follows the wl_keyboard key
+// event convention. Sym is an XKB keysym, state is a wl_keyboard key_state.
+//
+//	serial: serial of the latest known text input state
+func (p *ZwpInputMethodContextV1) Keysym(serial uint32, time uint32, sym uint32, state uint32, modifiers uint32) error {
+	return p.Context().MarshalRequest(p, 8, func(r *wl.Request) {
+		r.PutUint32(serial)
+		r.PutUint32(time)
+		r.PutUint32(sym)
+		r.PutUint32(state)
+		r.PutUint32(modifiers)
+	})
+}
+
+// GrabKeyboard: grab hardware keyboard
+//
+// Allow an input method to receive hardware keyboard input and process
+// key events to generate text events (with pre-edit) over the wire. This
+// allows input methods which compose multiple key events for inputting
+// text like it is done for CJK languages.
+func (p *ZwpInputMethodContextV1) GrabKeyboard() (*wl.Keyboard, error) {
+	ret := wl.NewKeyboard(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 9, func(r *wl.Request) {
+		r.PutNewId(ret)
+	})
+}
+
+// Key: forward key event
+//
+// Forward a wl_keyboard::key event to the client that was not processed
+// by the input method itself. Should be used when filtering key events
+// with grab_keyboard.  The arguments should be the ones from the
+// wl_keyboard::key event.
+//
+// For generating custom key events use the keysym request instead.
+//
+//	serial: serial from wl_keyboard::key
+//	time: time from wl_keyboard::key
+//	key: key from wl_keyboard::key
+//	state: state from wl_keyboard::key
+func (p *ZwpInputMethodContextV1) Key(serial uint32, time uint32, key uint32, state uint32) error {
+	return p.Context().MarshalRequest(p, 10, func(r *wl.Request) {
+		r.PutUint32(serial)
+		r.PutUint32(time)
+		r.PutUint32(key)
+		r.PutUint32(state)
+	})
+}
+
+// Modifiers: forward modifiers event
+//
+// Forward a wl_keyboard::modifiers event to the client that was not
+// processed by the input method itself.  Should be used when filtering
+// key events with grab_keyboard. The arguments should be the ones
+// from the wl_keyboard::modifiers event.
+//
+//	serial: serial from wl_keyboard::modifiers
+//	modsDepressed: mods_depressed from wl_keyboard::modifiers
+//	modsLatched: mods_latched from wl_keyboard::modifiers
+//	modsLocked: mods_locked from wl_keyboard::modifiers
+//	group: group from wl_keyboard::modifiers
+func (p *ZwpInputMethodContextV1) Modifiers(serial uint32, modsDepressed uint32, modsLatched uint32, modsLocked uint32, group uint32) error {
+	return p.Context().MarshalRequest(p, 11, func(r *wl.Request) {
+		r.PutUint32(serial)
+		r.PutUint32(modsDepressed)
+		r.PutUint32(modsLatched)
+		r.PutUint32(modsLocked)
+		r.PutUint32(group)
+	})
+}
+
+// Language:
+//
+//	serial: serial of the latest known text input state
+func (p *ZwpInputMethodContextV1) Language(serial uint32, language string) error {
+	return p.Context().MarshalRequest(p, 12, func(r *wl.Request) {
+		r.PutUint32(serial)
+		r.PutString(language)
+	})
+}
+
+// TextDirection:
+//
+//	serial: serial of the latest known text input state
+func (p *ZwpInputMethodContextV1) TextDirection(serial uint32, direction uint32) error {
+	return p.Context().MarshalRequest(p, 13, func(r *wl.Request) {
+		r.PutUint32(serial)
+		r.PutUint32(direction)
+	})
+}
+
+// ZwpInputMethodContextV1SurroundingTextEvent: surrounding text event
+//
+// The plain surrounding text around the input position. Cursor is the
+// position in bytes within the surrounding text relative to the beginning
+// of the text. Anchor is the position in bytes of the selection anchor
+// within the surrounding text relative to the beginning of the text. If
+// there is no selected text then anchor is the same as cursor.
 type ZwpInputMethodContextV1SurroundingTextEvent struct {
 	Text   string
 	Cursor uint32
 	Anchor uint32
 }
 
+// ZwpInputMethodContextV1SurroundingTextHandler is implemented by the receivers of ZwpInputMethodContextV1SurroundingTextEvent
 type ZwpInputMethodContextV1SurroundingTextHandler interface {
 	HandleZwpInputMethodContextV1SurroundingText(ZwpInputMethodContextV1SurroundingTextEvent)
 }
 
+// AddSurroundingTextHandler adds a handler for ZwpInputMethodContextV1SurroundingTextEvent
 func (p *ZwpInputMethodContextV1) AddSurroundingTextHandler(h ZwpInputMethodContextV1SurroundingTextHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.surroundingTextHandlers = append(p.surroundingTextHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.surroundingTextHandlers = append(p.surroundingTextHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveSurroundingTextHandler removes a handler previously added by AddSurroundingTextHandler
 func (p *ZwpInputMethodContextV1) RemoveSurroundingTextHandler(h ZwpInputMethodContextV1SurroundingTextHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.surroundingTextHandlers {
 		if e == h {
-			p.surroundingTextHandlers = append(p.surroundingTextHandlers[:i], p.surroundingTextHandlers[i+1:]...)
+			p.surroundingTextHandlers = append(p.surroundingTextHandlers[:i:i], p.surroundingTextHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// ZwpInputMethodContextV1ResetEvent:
 type ZwpInputMethodContextV1ResetEvent struct {
 }
 
+// ZwpInputMethodContextV1ResetHandler is implemented by the receivers of ZwpInputMethodContextV1ResetEvent
 type ZwpInputMethodContextV1ResetHandler interface {
 	HandleZwpInputMethodContextV1Reset(ZwpInputMethodContextV1ResetEvent)
 }
 
+// AddResetHandler adds a handler for ZwpInputMethodContextV1ResetEvent
 func (p *ZwpInputMethodContextV1) AddResetHandler(h ZwpInputMethodContextV1ResetHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.resetHandlers = append(p.resetHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.resetHandlers = append(p.resetHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveResetHandler removes a handler previously added by AddResetHandler
 func (p *ZwpInputMethodContextV1) RemoveResetHandler(h ZwpInputMethodContextV1ResetHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.resetHandlers {
 		if e == h {
-			p.resetHandlers = append(p.resetHandlers[:i], p.resetHandlers[i+1:]...)
+			p.resetHandlers = append(p.resetHandlers[:i:i], p.resetHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// ZwpInputMethodContextV1ContentTypeEvent:
 type ZwpInputMethodContextV1ContentTypeEvent struct {
 	Hint    uint32
 	Purpose uint32
 }
 
+// ZwpInputMethodContextV1ContentTypeHandler is implemented by the receivers of ZwpInputMethodContextV1ContentTypeEvent
 type ZwpInputMethodContextV1ContentTypeHandler interface {
 	HandleZwpInputMethodContextV1ContentType(ZwpInputMethodContextV1ContentTypeEvent)
 }
 
+// AddContentTypeHandler adds a handler for ZwpInputMethodContextV1ContentTypeEvent
 func (p *ZwpInputMethodContextV1) AddContentTypeHandler(h ZwpInputMethodContextV1ContentTypeHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.contentTypeHandlers = append(p.contentTypeHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.contentTypeHandlers = append(p.contentTypeHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveContentTypeHandler removes a handler previously added by AddContentTypeHandler
 func (p *ZwpInputMethodContextV1) RemoveContentTypeHandler(h ZwpInputMethodContextV1ContentTypeHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.contentTypeHandlers {
 		if e == h {
-			p.contentTypeHandlers = append(p.contentTypeHandlers[:i], p.contentTypeHandlers[i+1:]...)
+			p.contentTypeHandlers = append(p.contentTypeHandlers[:i:i], p.contentTypeHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// ZwpInputMethodContextV1InvokeActionEvent:
 type ZwpInputMethodContextV1InvokeActionEvent struct {
 	Button uint32
 	Index  uint32
 }
 
+// ZwpInputMethodContextV1InvokeActionHandler is implemented by the receivers of ZwpInputMethodContextV1InvokeActionEvent
 type ZwpInputMethodContextV1InvokeActionHandler interface {
 	HandleZwpInputMethodContextV1InvokeAction(ZwpInputMethodContextV1InvokeActionEvent)
 }
 
+// AddInvokeActionHandler adds a handler for ZwpInputMethodContextV1InvokeActionEvent
 func (p *ZwpInputMethodContextV1) AddInvokeActionHandler(h ZwpInputMethodContextV1InvokeActionHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.invokeActionHandlers = append(p.invokeActionHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.invokeActionHandlers = append(p.invokeActionHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveInvokeActionHandler removes a handler previously added by AddInvokeActionHandler
 func (p *ZwpInputMethodContextV1) RemoveInvokeActionHandler(h ZwpInputMethodContextV1InvokeActionHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.invokeActionHandlers {
 		if e == h {
-			p.invokeActionHandlers = append(p.invokeActionHandlers[:i], p.invokeActionHandlers[i+1:]...)
+			p.invokeActionHandlers = append(p.invokeActionHandlers[:i:i], p.invokeActionHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// ZwpInputMethodContextV1CommitStateEvent:
 type ZwpInputMethodContextV1CommitStateEvent struct {
 	Serial uint32
 }
 
+// ZwpInputMethodContextV1CommitStateHandler is implemented by the receivers of ZwpInputMethodContextV1CommitStateEvent
 type ZwpInputMethodContextV1CommitStateHandler interface {
 	HandleZwpInputMethodContextV1CommitState(ZwpInputMethodContextV1CommitStateEvent)
 }
 
+// AddCommitStateHandler adds a handler for ZwpInputMethodContextV1CommitStateEvent
 func (p *ZwpInputMethodContextV1) AddCommitStateHandler(h ZwpInputMethodContextV1CommitStateHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.commitStateHandlers = append(p.commitStateHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.commitStateHandlers = append(p.commitStateHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveCommitStateHandler removes a handler previously added by AddCommitStateHandler
 func (p *ZwpInputMethodContextV1) RemoveCommitStateHandler(h ZwpInputMethodContextV1CommitStateHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.commitStateHandlers {
 		if e == h {
-			p.commitStateHandlers = append(p.commitStateHandlers[:i], p.commitStateHandlers[i+1:]...)
+			p.commitStateHandlers = append(p.commitStateHandlers[:i:i], p.commitStateHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// ZwpInputMethodContextV1PreferredLanguageEvent:
 type ZwpInputMethodContextV1PreferredLanguageEvent struct {
 	Language string
 }
 
+// ZwpInputMethodContextV1PreferredLanguageHandler is implemented by the receivers of ZwpInputMethodContextV1PreferredLanguageEvent
 type ZwpInputMethodContextV1PreferredLanguageHandler interface {
 	HandleZwpInputMethodContextV1PreferredLanguage(ZwpInputMethodContextV1PreferredLanguageEvent)
 }
 
+// AddPreferredLanguageHandler adds a handler for ZwpInputMethodContextV1PreferredLanguageEvent
 func (p *ZwpInputMethodContextV1) AddPreferredLanguageHandler(h ZwpInputMethodContextV1PreferredLanguageHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.preferredLanguageHandlers = append(p.preferredLanguageHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.preferredLanguageHandlers = append(p.preferredLanguageHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemovePreferredLanguageHandler removes a handler previously added by AddPreferredLanguageHandler
 func (p *ZwpInputMethodContextV1) RemovePreferredLanguageHandler(h ZwpInputMethodContextV1PreferredLanguageHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.preferredLanguageHandlers {
 		if e == h {
-			p.preferredLanguageHandlers = append(p.preferredLanguageHandlers[:i], p.preferredLanguageHandlers[i+1:]...)
+			p.preferredLanguageHandlers = append(p.preferredLanguageHandlers[:i:i], p.preferredLanguageHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// Dispatch decodes an event received on the zwp_input_method_context_v1 and runs its handlers
 func (p *ZwpInputMethodContextV1) Dispatch(event *wl.Event) {
 	switch event.Opcode {
 	case 0:
-		if len(p.surroundingTextHandlers) > 0 {
-			ev := ZwpInputMethodContextV1SurroundingTextEvent{}
-			ev.Text = event.String()
-			ev.Cursor = event.Uint32()
-			ev.Anchor = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.surroundingTextHandlers {
-				h.HandleZwpInputMethodContextV1SurroundingText(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.surroundingTextHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := ZwpInputMethodContextV1SurroundingTextEvent{}
+		ev.Text = event.String()
+		ev.Cursor = event.Uint32()
+		ev.Anchor = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleZwpInputMethodContextV1SurroundingText(ev)
 		}
 	case 1:
-		if len(p.resetHandlers) > 0 {
-			ev := ZwpInputMethodContextV1ResetEvent{}
-			p.mu.RLock()
-			for _, h := range p.resetHandlers {
-				h.HandleZwpInputMethodContextV1Reset(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.resetHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := ZwpInputMethodContextV1ResetEvent{}
+		for _, h := range handlers {
+			h.HandleZwpInputMethodContextV1Reset(ev)
 		}
 	case 2:
-		if len(p.contentTypeHandlers) > 0 {
-			ev := ZwpInputMethodContextV1ContentTypeEvent{}
-			ev.Hint = event.Uint32()
-			ev.Purpose = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.contentTypeHandlers {
-				h.HandleZwpInputMethodContextV1ContentType(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.contentTypeHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := ZwpInputMethodContextV1ContentTypeEvent{}
+		ev.Hint = event.Uint32()
+		ev.Purpose = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleZwpInputMethodContextV1ContentType(ev)
 		}
 	case 3:
-		if len(p.invokeActionHandlers) > 0 {
-			ev := ZwpInputMethodContextV1InvokeActionEvent{}
-			ev.Button = event.Uint32()
-			ev.Index = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.invokeActionHandlers {
-				h.HandleZwpInputMethodContextV1InvokeAction(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.invokeActionHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := ZwpInputMethodContextV1InvokeActionEvent{}
+		ev.Button = event.Uint32()
+		ev.Index = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleZwpInputMethodContextV1InvokeAction(ev)
 		}
 	case 4:
-		if len(p.commitStateHandlers) > 0 {
-			ev := ZwpInputMethodContextV1CommitStateEvent{}
-			ev.Serial = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.commitStateHandlers {
-				h.HandleZwpInputMethodContextV1CommitState(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.commitStateHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := ZwpInputMethodContextV1CommitStateEvent{}
+		ev.Serial = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleZwpInputMethodContextV1CommitState(ev)
 		}
 	case 5:
-		if len(p.preferredLanguageHandlers) > 0 {
-			ev := ZwpInputMethodContextV1PreferredLanguageEvent{}
-			ev.Language = event.String()
-			p.mu.RLock()
-			for _, h := range p.preferredLanguageHandlers {
-				h.HandleZwpInputMethodContextV1PreferredLanguage(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.preferredLanguageHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := ZwpInputMethodContextV1PreferredLanguageEvent{}
+		ev.Language = event.String()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleZwpInputMethodContextV1PreferredLanguage(ev)
 		}
 	}
 }
 
-type ZwpInputMethodContextV1 struct {
-	wl.BaseProxy
-	mu                        sync.RWMutex
-	surroundingTextHandlers   []ZwpInputMethodContextV1SurroundingTextHandler
-	resetHandlers             []ZwpInputMethodContextV1ResetHandler
-	contentTypeHandlers       []ZwpInputMethodContextV1ContentTypeHandler
-	invokeActionHandlers      []ZwpInputMethodContextV1InvokeActionHandler
-	commitStateHandlers       []ZwpInputMethodContextV1CommitStateHandler
-	preferredLanguageHandlers []ZwpInputMethodContextV1PreferredLanguageHandler
-}
-
-func NewZwpInputMethodContextV1(ctx *wl.Context) *ZwpInputMethodContextV1 {
-	ret := new(ZwpInputMethodContextV1)
-	ctx.Register(ret)
-	return ret
-}
-
-// Destroy will .
-//
-//
-func (p *ZwpInputMethodContextV1) Destroy() error {
-	return p.Context().SendRequest(p, 0)
-}
-
-// CommitString will commit string.
-//
-//
-// Send the commit string text for insertion to the application.
-//
-// The text to commit could be either just a single character after a key
-// press or the result of some composing (pre-edit). It could be also an
-// empty text when some text should be removed (see
-// delete_surrounding_text) or when the input cursor should be moved (see
-// cursor_position).
-//
-// Any previously set composing text will be removed.
-//
-func (p *ZwpInputMethodContextV1) CommitString(serial uint32, text string) error {
-	return p.Context().SendRequest(p, 1, serial, text)
-}
-
-// PreeditString will pre-edit string.
-//
-//
-// Send the pre-edit string text to the application text input.
-//
-// The commit text can be used to replace the pre-edit text on reset (for
-// example on unfocus).
-//
-// Previously sent preedit_style and preedit_cursor requests are also
-// processed by the text_input.
-//
-func (p *ZwpInputMethodContextV1) PreeditString(serial uint32, text string, commit string) error {
-	return p.Context().SendRequest(p, 2, serial, text, commit)
-}
-
-// PreeditStyling will pre-edit styling.
-//
-//
-// Set the styling information on composing text. The style is applied for
-// length in bytes from index relative to the beginning of
-// the composing text (as byte offset). Multiple styles can
-// be applied to a composing text.
-//
-// This request should be sent before sending a preedit_string request.
-//
-func (p *ZwpInputMethodContextV1) PreeditStyling(index uint32, length uint32, style uint32) error {
-	return p.Context().SendRequest(p, 3, index, length, style)
-}
-
-// PreeditCursor will pre-edit cursor.
-//
-//
-// Set the cursor position inside the composing text (as byte offset)
-// relative to the start of the composing text.
-//
-// When index is negative no cursor should be displayed.
-//
-// This request should be sent before sending a preedit_string request.
-//
-func (p *ZwpInputMethodContextV1) PreeditCursor(index int32) error {
-	return p.Context().SendRequest(p, 4, index)
-}
-
-// DeleteSurroundingText will delete text.
-//
-//
-// Remove the surrounding text.
-//
-// This request will be handled on the text_input side directly following
-// a commit_string request.
-//
-func (p *ZwpInputMethodContextV1) DeleteSurroundingText(index int32, length uint32) error {
-	return p.Context().SendRequest(p, 5, index, length)
-}
-
-// CursorPosition will set cursor to a new position.
-//
-//
-// Set the cursor and anchor to a new position. Index is the new cursor
-// position in bytes (when >= 0 this is relative to the end of the inserted text,
-// otherwise it is relative to the beginning of the inserted text). Anchor is
-// the new anchor position in bytes (when >= 0 this is relative to the end of the
-// inserted text, otherwise it is relative to the beginning of the inserted
-// text). When there should be no selected text, anchor should be the same
-// as index.
-//
-// This request will be handled on the text_input side directly following
-// a commit_string request.
-//
-func (p *ZwpInputMethodContextV1) CursorPosition(index int32, anchor int32) error {
-	return p.Context().SendRequest(p, 6, index, anchor)
-}
-
-// ModifiersMap will .
-//
-//
-func (p *ZwpInputMethodContextV1) ModifiersMap(map_ []int32) error {
-	return p.Context().SendRequest(p, 7, map_)
-}
-
-// Keysym will keysym.
-//
-//
-// Notify when a key event was sent. Key events should not be used for
-// normal text input operations, which should be done with commit_string,
-// delete_surrounding_text, etc. The key event follows the wl_keyboard key
-// event convention. Sym is an XKB keysym, state is a wl_keyboard key_state.
-//
-func (p *ZwpInputMethodContextV1) Keysym(serial uint32, time uint32, sym uint32, state uint32, modifiers uint32) error {
-	return p.Context().SendRequest(p, 8, serial, time, sym, state, modifiers)
-}
+const (
+	ZwpInputMethodContextV1SurroundingTextSinceVersion       = 1
+	ZwpInputMethodContextV1ResetSinceVersion                 = 1
+	ZwpInputMethodContextV1ContentTypeSinceVersion           = 1
+	ZwpInputMethodContextV1InvokeActionSinceVersion          = 1
+	ZwpInputMethodContextV1CommitStateSinceVersion           = 1
+	ZwpInputMethodContextV1PreferredLanguageSinceVersion     = 1
+	ZwpInputMethodContextV1DestroySinceVersion               = 1
+	ZwpInputMethodContextV1CommitStringSinceVersion          = 1
+	ZwpInputMethodContextV1PreeditStringSinceVersion         = 1
+	ZwpInputMethodContextV1PreeditStylingSinceVersion        = 1
+	ZwpInputMethodContextV1PreeditCursorSinceVersion         = 1
+	ZwpInputMethodContextV1DeleteSurroundingTextSinceVersion = 1
+	ZwpInputMethodContextV1CursorPositionSinceVersion        = 1
+	ZwpInputMethodContextV1ModifiersMapSinceVersion          = 1
+	ZwpInputMethodContextV1KeysymSinceVersion                = 1
+	ZwpInputMethodContextV1GrabKeyboardSinceVersion          = 1
+	ZwpInputMethodContextV1KeySinceVersion                   = 1
+	ZwpInputMethodContextV1ModifiersSinceVersion             = 1
+	ZwpInputMethodContextV1LanguageSinceVersion              = 1
+	ZwpInputMethodContextV1TextDirectionSinceVersion         = 1
+)
 
-// GrabKeyboard will grab hardware keyboard.
-//
-//
-// Allow an input method to receive hardware keyboard input and process
-// key events to generate text events (with pre-edit) over the wire. This
-// allows input methods which compose multiple key events for inputting
-// text like it is done for CJK languages.
-//
-func (p *ZwpInputMethodContextV1) GrabKeyboard() (*wl.Keyboard, error) {
-	ret := wl.NewKeyboard(p.Context())
-	return ret, p.Context().SendRequest(p, 9, wl.Proxy(ret))
+// ZwpInputMethodContextV1Interface describes the zwp_input_method_context_v1 interface
+var ZwpInputMethodContextV1Interface = &wl.Interface{
+	Name:    "zwp_input_method_context_v1",
+	Version: 1,
+	Requests: []wl.Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "commit_string",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "serial", Type: wl.ArgUint},
+				{Name: "text", Type: wl.ArgString},
+			},
+		},
+		{
+			Name:  "preedit_string",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "serial", Type: wl.ArgUint},
+				{Name: "text", Type: wl.ArgString},
+				{Name: "commit", Type: wl.ArgString},
+			},
+		},
+		{
+			Name:  "preedit_styling",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "index", Type: wl.ArgUint},
+				{Name: "length", Type: wl.ArgUint},
+				{Name: "style", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "preedit_cursor",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "index", Type: wl.ArgInt},
+			},
+		},
+		{
+			Name:  "delete_surrounding_text",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "index", Type: wl.ArgInt},
+				{Name: "length", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "cursor_position",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "index", Type: wl.ArgInt},
+				{Name: "anchor", Type: wl.ArgInt},
+			},
+		},
+		{
+			Name:  "modifiers_map",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "map_", Type: wl.ArgArray},
+			},
+		},
+		{
+			Name:  "keysym",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "serial", Type: wl.ArgUint},
+				{Name: "time", Type: wl.ArgUint},
+				{Name: "sym", Type: wl.ArgUint},
+				{Name: "state", Type: wl.ArgUint},
+				{Name: "modifiers", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "grab_keyboard",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "keyboard", Type: wl.ArgNewId, Interface: "wl_keyboard"},
+			},
+		},
+		{
+			Name:  "key",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "serial", Type: wl.ArgUint},
+				{Name: "time", Type: wl.ArgUint},
+				{Name: "key", Type: wl.ArgUint},
+				{Name: "state", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "modifiers",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "serial", Type: wl.ArgUint},
+				{Name: "mods_depressed", Type: wl.ArgUint},
+				{Name: "mods_latched", Type: wl.ArgUint},
+				{Name: "mods_locked", Type: wl.ArgUint},
+				{Name: "group", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "language",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "serial", Type: wl.ArgUint},
+				{Name: "language", Type: wl.ArgString},
+			},
+		},
+		{
+			Name:  "text_direction",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "serial", Type: wl.ArgUint},
+				{Name: "direction", Type: wl.ArgUint},
+			},
+		},
+	},
+	Events: []wl.Message{
+		{
+			Name:  "surrounding_text",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "text", Type: wl.ArgString},
+				{Name: "cursor", Type: wl.ArgUint},
+				{Name: "anchor", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "reset",
+			Since: 1,
+		},
+		{
+			Name:  "content_type",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "hint", Type: wl.ArgUint},
+				{Name: "purpose", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "invoke_action",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "button", Type: wl.ArgUint},
+				{Name: "index", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "commit_state",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "serial", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "preferred_language",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "language", Type: wl.ArgString},
+			},
+		},
+	},
 }
 
-// Key will forward key event.
-//
-//
-// Forward a wl_keyboard::key event to the client that was not processed
-// by the input method itself. Should be used when filtering key events
-// with grab_keyboard.  The arguments should be the ones from the
-// wl_keyboard::key event.
-//
-// For generating custom key events use the keysym request instead.
-//
-func (p *ZwpInputMethodContextV1) Key(serial uint32, time uint32, key uint32, state uint32) error {
-	return p.Context().SendRequest(p, 10, serial, time, key, state)
+// Interface returns the description of the zwp_input_method_context_v1 interface
+func (p *ZwpInputMethodContextV1) Interface() *wl.Interface {
+	return ZwpInputMethodContextV1Interface
 }
 
-// Modifiers will forward modifiers event.
+// ZwpInputMethodV1: input method
 //
-//
-// Forward a wl_keyboard::modifiers event to the client that was not
-// processed by the input method itself.  Should be used when filtering
-// key events with grab_keyboard. The arguments should be the ones
-// from the wl_keyboard::modifiers event.
-//
-func (p *ZwpInputMethodContextV1) Modifiers(serial uint32, modsDepressed uint32, modsLatched uint32, modsLocked uint32, group uint32) error {
-	return p.Context().SendRequest(p, 11, serial, modsDepressed, modsLatched, modsLocked, group)
+// An input method object is responsible for composing text in response to
+// input from hardware or virtual keyboards. There is one input method
+// object per seat. On activate there is a new input method context object
+// created which allows the input method to communicate with the text input.
+type ZwpInputMethodV1 struct {
+	wl.BaseProxy
+	mu                 sync.RWMutex
+	activateHandlers   []ZwpInputMethodV1ActivateHandler
+	deactivateHandlers []ZwpInputMethodV1DeactivateHandler
 }
 
-// Language will .
-//
-//
-func (p *ZwpInputMethodContextV1) Language(serial uint32, language string) error {
-	return p.Context().SendRequest(p, 12, serial, language)
+// NewZwpInputMethodV1 creates a new zwp_input_method_v1 proxy registered in the Context
+func NewZwpInputMethodV1(ctx *wl.Context) *ZwpInputMethodV1 {
+	ret := new(ZwpInputMethodV1)
+	ctx.Register(ret)
+	return ret
 }
 
-// TextDirection will .
-//
+// ZwpInputMethodV1ActivateEvent: activate event
 //
-func (p *ZwpInputMethodContextV1) TextDirection(serial uint32, direction uint32) error {
-	return p.Context().SendRequest(p, 13, serial, direction)
-}
-
+// A text input was activated. Creates an input method context object
+// which allows communication with the text input.
 type ZwpInputMethodV1ActivateEvent struct {
 	Id *ZwpInputMethodContextV1
 }
 
+// ZwpInputMethodV1ActivateHandler is implemented by the receivers of ZwpInputMethodV1ActivateEvent
 type ZwpInputMethodV1ActivateHandler interface {
 	HandleZwpInputMethodV1Activate(ZwpInputMethodV1ActivateEvent)
 }
 
+// AddActivateHandler adds a handler for ZwpInputMethodV1ActivateEvent
 func (p *ZwpInputMethodV1) AddActivateHandler(h ZwpInputMethodV1ActivateHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.activateHandlers = append(p.activateHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.activateHandlers = append(p.activateHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveActivateHandler removes a handler previously added by AddActivateHandler
 func (p *ZwpInputMethodV1) RemoveActivateHandler(h ZwpInputMethodV1ActivateHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.activateHandlers {
 		if e == h {
-			p.activateHandlers = append(p.activateHandlers[:i], p.activateHandlers[i+1:]...)
+			p.activateHandlers = append(p.activateHandlers[:i:i], p.activateHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// ZwpInputMethodV1DeactivateEvent: deactivate event
+//
+// The text input corresponding to the context argument was deactivated.
+// The input method context should be destroyed after deactivation is
+// handled.
 type ZwpInputMethodV1DeactivateEvent struct {
 	Context *ZwpInputMethodContextV1
 }
 
+// ZwpInputMethodV1DeactivateHandler is implemented by the receivers of ZwpInputMethodV1DeactivateEvent
 type ZwpInputMethodV1DeactivateHandler interface {
 	HandleZwpInputMethodV1Deactivate(ZwpInputMethodV1DeactivateEvent)
 }
 
+// AddDeactivateHandler adds a handler for ZwpInputMethodV1DeactivateEvent
 func (p *ZwpInputMethodV1) AddDeactivateHandler(h ZwpInputMethodV1DeactivateHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.deactivateHandlers = append(p.deactivateHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.deactivateHandlers = append(p.deactivateHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveDeactivateHandler removes a handler previously added by AddDeactivateHandler
 func (p *ZwpInputMethodV1) RemoveDeactivateHandler(h ZwpInputMethodV1DeactivateHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.deactivateHandlers {
 		if e == h {
-			p.deactivateHandlers = append(p.deactivateHandlers[:i], p.deactivateHandlers[i+1:]...)
+			p.deactivateHandlers = append(p.deactivateHandlers[:i:i], p.deactivateHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// Dispatch decodes an event received on the zwp_input_method_v1 and runs its handlers
 func (p *ZwpInputMethodV1) Dispatch(event *wl.Event) {
 	switch event.Opcode {
 	case 0:
-		if len(p.activateHandlers) > 0 {
-			ev := ZwpInputMethodV1ActivateEvent{}
-			ev.Id = event.Proxy(p.Context()).(*ZwpInputMethodContextV1)
-			p.mu.RLock()
-			for _, h := range p.activateHandlers {
-				h.HandleZwpInputMethodV1Activate(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.activateHandlers
+		p.mu.RUnlock()
+		ev := ZwpInputMethodV1ActivateEvent{}
+		if id := event.Uint32(); id != 0 && event.Err() == nil {
+			ev.Id = new(ZwpInputMethodContextV1)
+			p.Context().RegisterMapped(ev.Id, id)
+		}
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleZwpInputMethodV1Activate(ev)
 		}
 	case 1:
-		if len(p.deactivateHandlers) > 0 {
-			ev := ZwpInputMethodV1DeactivateEvent{}
-			ev.Context = event.Proxy(p.Context()).(*ZwpInputMethodContextV1)
-			p.mu.RLock()
-			for _, h := range p.deactivateHandlers {
-				h.HandleZwpInputMethodV1Deactivate(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.deactivateHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := ZwpInputMethodV1DeactivateEvent{}
+		ev.Context, _ = event.Proxy(p.Context()).(*ZwpInputMethodContextV1)
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleZwpInputMethodV1Deactivate(ev)
 		}
 	}
 }
 
-type ZwpInputMethodV1 struct {
-	wl.BaseProxy
-	mu                 sync.RWMutex
-	activateHandlers   []ZwpInputMethodV1ActivateHandler
-	deactivateHandlers []ZwpInputMethodV1DeactivateHandler
+const (
+	ZwpInputMethodV1ActivateSinceVersion   = 1
+	ZwpInputMethodV1DeactivateSinceVersion = 1
+)
+
+// ZwpInputMethodV1Interface describes the zwp_input_method_v1 interface
+var ZwpInputMethodV1Interface = &wl.Interface{
+	Name:    "zwp_input_method_v1",
+	Version: 1,
+	Events: []wl.Message{
+		{
+			Name:  "activate",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "id", Type: wl.ArgNewId, Interface: "zwp_input_method_context_v1"},
+			},
+		},
+		{
+			Name:  "deactivate",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "context", Type: wl.ArgObject, Interface: "zwp_input_method_context_v1"},
+			},
+		},
+	},
 }
 
-func NewZwpInputMethodV1(ctx *wl.Context) *ZwpInputMethodV1 {
-	ret := new(ZwpInputMethodV1)
-	ctx.Register(ret)
-	return ret
+// Interface returns the description of the zwp_input_method_v1 interface
+func (p *ZwpInputMethodV1) Interface() *wl.Interface {
+	return ZwpInputMethodV1Interface
 }
 
+// ZwpInputPanelV1: interface for implementing keyboards
+//
+// Only one client can bind this interface at a time.
 type ZwpInputPanelV1 struct {
 	wl.BaseProxy
 }
 
+// NewZwpInputPanelV1 creates a new zwp_input_panel_v1 proxy registered in the Context
 func NewZwpInputPanelV1(ctx *wl.Context) *ZwpInputPanelV1 {
 	ret := new(ZwpInputPanelV1)
 	ctx.Register(ret)
 	return ret
 }
 
-// GetInputPanelSurface will .
-//
-//
+// GetInputPanelSurface:
 func (p *ZwpInputPanelV1) GetInputPanelSurface(surface *wl.Surface) (*ZwpInputPanelSurfaceV1, error) {
 	ret := NewZwpInputPanelSurfaceV1(p.Context())
-	return ret, p.Context().SendRequest(p, 0, wl.Proxy(ret), surface)
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 0, func(r *wl.Request) {
+		r.PutNewId(ret)
+		if surface != nil {
+			r.PutObject(surface.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
+}
+
+const (
+	ZwpInputPanelV1GetInputPanelSurfaceSinceVersion = 1
+)
+
+// ZwpInputPanelV1Interface describes the zwp_input_panel_v1 interface
+var ZwpInputPanelV1Interface = &wl.Interface{
+	Name:    "zwp_input_panel_v1",
+	Version: 1,
+	Requests: []wl.Message{
+		{
+			Name:  "get_input_panel_surface",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "id", Type: wl.ArgNewId, Interface: "zwp_input_panel_surface_v1"},
+				{Name: "surface", Type: wl.ArgObject, Interface: "wl_surface"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the zwp_input_panel_v1 interface
+func (p *ZwpInputPanelV1) Interface() *wl.Interface {
+	return ZwpInputPanelV1Interface
 }
 
+// ZwpInputPanelSurfaceV1:
 type ZwpInputPanelSurfaceV1 struct {
 	wl.BaseProxy
 }
 
+// NewZwpInputPanelSurfaceV1 creates a new zwp_input_panel_surface_v1 proxy registered in the Context
 func NewZwpInputPanelSurfaceV1(ctx *wl.Context) *ZwpInputPanelSurfaceV1 {
 	ret := new(ZwpInputPanelSurfaceV1)
 	ctx.Register(ret)
 	return ret
 }
 
-// SetToplevel will set the surface type as a keyboard.
-//
+// SetToplevel: set the surface type as a keyboard
 //
 // Set the input_panel_surface type to keyboard.
 //
 // A keyboard surface is only shown when a text input is active.
-//
 func (p *ZwpInputPanelSurfaceV1) SetToplevel(output *wl.Output, position uint32) error {
-	return p.Context().SendRequest(p, 0, output, position)
+	return p.Context().MarshalRequest(p, 0, func(r *wl.Request) {
+		if output != nil {
+			r.PutObject(output.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutUint32(position)
+	})
 }
 
-// SetOverlayPanel will set the surface type as an overlay panel.
-//
+// SetOverlayPanel: set the surface type as an overlay panel
 //
 // Set the input_panel_surface to be an overlay panel.
 //
 // This is shown near the input cursor above the application window when
 // a text input is active.
-//
 func (p *ZwpInputPanelSurfaceV1) SetOverlayPanel() error {
-	return p.Context().SendRequest(p, 1)
+	return p.Context().MarshalRequest(p, 1, nil)
 }
 
+// ZwpInputPanelSurfaceV1Position:
 const (
 	ZwpInputPanelSurfaceV1PositionCenterBottom = 0
 )
+
+const (
+	ZwpInputPanelSurfaceV1SetToplevelSinceVersion     = 1
+	ZwpInputPanelSurfaceV1SetOverlayPanelSinceVersion = 1
+)
+
+// ZwpInputPanelSurfaceV1Interface describes the zwp_input_panel_surface_v1 interface
+var ZwpInputPanelSurfaceV1Interface = &wl.Interface{
+	Name:    "zwp_input_panel_surface_v1",
+	Version: 1,
+	Requests: []wl.Message{
+		{
+			Name:  "set_toplevel",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "output", Type: wl.ArgObject, Interface: "wl_output"},
+				{Name: "position", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "set_overlay_panel",
+			Since: 1,
+		},
+	},
+	Enums: []wl.Enum{
+		{
+			Name: "position",
+			Entries: []wl.EnumEntry{
+				{Name: "center_bottom", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the zwp_input_panel_surface_v1 interface
+func (p *ZwpInputPanelSurfaceV1) Interface() *wl.Interface {
+	return ZwpInputPanelSurfaceV1Interface
+}
+
+func init() {
+	wl.RegisterInterface(ZwpInputMethodContextV1Interface)
+	wl.RegisterInterface(ZwpInputMethodV1Interface)
+	wl.RegisterInterface(ZwpInputPanelV1Interface)
+	wl.RegisterInterface(ZwpInputPanelSurfaceV1Interface)
+	wl.RegisterProxy(ZwpInputMethodContextV1Interface, func(ctx *wl.Context) wl.Proxy {
+		return NewZwpInputMethodContextV1(ctx)
+	})
+	wl.RegisterProxy(ZwpInputMethodV1Interface, func(ctx *wl.Context) wl.Proxy {
+		return NewZwpInputMethodV1(ctx)
+	})
+	wl.RegisterProxy(ZwpInputPanelV1Interface, func(ctx *wl.Context) wl.Proxy {
+		return NewZwpInputPanelV1(ctx)
+	})
+	wl.RegisterProxy(ZwpInputPanelSurfaceV1Interface, func(ctx *wl.Context) wl.Proxy {
+		return NewZwpInputPanelSurfaceV1(ctx)
+	})
+}
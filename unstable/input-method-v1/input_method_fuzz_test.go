@@ -0,0 +1,72 @@
+// Code generated by go-wayland-scanner; DO NOT EDIT.
+// XML file: input-method-unstable-v1.xml
+
+package inputmethod
+
+import (
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wltest"
+	"testing"
+)
+
+// fuzzHandler handles every event of the protocol
+type fuzzHandler struct{}
+
+func (fuzzHandler) HandleZwpInputMethodContextV1SurroundingText(ZwpInputMethodContextV1SurroundingTextEvent) {
+}
+func (fuzzHandler) HandleZwpInputMethodContextV1Reset(ZwpInputMethodContextV1ResetEvent) {}
+func (fuzzHandler) HandleZwpInputMethodContextV1ContentType(ZwpInputMethodContextV1ContentTypeEvent) {
+}
+func (fuzzHandler) HandleZwpInputMethodContextV1InvokeAction(ZwpInputMethodContextV1InvokeActionEvent) {
+}
+func (fuzzHandler) HandleZwpInputMethodContextV1CommitState(ZwpInputMethodContextV1CommitStateEvent) {
+}
+func (fuzzHandler) HandleZwpInputMethodContextV1PreferredLanguage(ZwpInputMethodContextV1PreferredLanguageEvent) {
+}
+func (fuzzHandler) HandleZwpInputMethodV1Activate(ZwpInputMethodV1ActivateEvent)     {}
+func (fuzzHandler) HandleZwpInputMethodV1Deactivate(ZwpInputMethodV1DeactivateEvent) {}
+
+// fuzzProxy is a proxy receiving the fuzzed events
+type fuzzProxy interface {
+	wl.Dispatcher
+	Interface() *wl.Interface
+}
+
+// FuzzDispatch decodes arbitrary event messages by the Dispatch of every interface with events
+func FuzzDispatch(f *testing.F) {
+	display, server, err := wltest.New()
+	if err != nil {
+		f.Fatal(err)
+	}
+	defer server.Close()
+	ctx := display.Context()
+
+	var h fuzzHandler
+	var proxies []fuzzProxy
+	{
+		p := NewZwpInputMethodContextV1(ctx)
+		p.AddSurroundingTextHandler(h)
+		p.AddResetHandler(h)
+		p.AddContentTypeHandler(h)
+		p.AddInvokeActionHandler(h)
+		p.AddCommitStateHandler(h)
+		p.AddPreferredLanguageHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewZwpInputMethodV1(ctx)
+		p.AddActivateHandler(h)
+		p.AddDeactivateHandler(h)
+		proxies = append(proxies, p)
+	}
+
+	for i, p := range proxies {
+		for opcode := range p.Interface().Events {
+			f.Add(uint8(i), uint16(opcode), make([]byte, 64))
+		}
+	}
+	f.Fuzz(func(t *testing.T, index uint8, opcode uint16, data []byte) {
+		p := proxies[int(index)%len(proxies)]
+		p.Dispatch(&wl.Event{Opcode: uint32(opcode), Data: data})
+	})
+}
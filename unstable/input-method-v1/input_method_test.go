@@ -0,0 +1,85 @@
+package inputmethod_test
+
+import (
+	"testing"
+
+	inputmethod "github.com/neurlang/wayland/unstable/input-method-v1"
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wlclient"
+	"github.com/neurlang/wayland/wltest"
+)
+
+// contextEvents handles the contexts created by activate and their events
+type contextEvents struct {
+	contexts []*inputmethod.ZwpInputMethodContextV1
+	resets   int
+	serials  []uint32
+}
+
+func (e *contextEvents) HandleZwpInputMethodV1Activate(ev inputmethod.ZwpInputMethodV1ActivateEvent) {
+	e.contexts = append(e.contexts, ev.Id)
+	ev.Id.AddResetHandler(e)
+	ev.Id.AddCommitStateHandler(e)
+}
+
+func (e *contextEvents) HandleZwpInputMethodContextV1Reset(inputmethod.ZwpInputMethodContextV1ResetEvent) {
+	e.resets++
+}
+
+func (e *contextEvents) HandleZwpInputMethodContextV1CommitState(ev inputmethod.ZwpInputMethodContextV1CommitStateEvent) {
+	e.serials = append(e.serials, ev.Serial)
+}
+
+// TestActivateEvents sends the events of a context in the same read as the activate event creating it,
+// they are delivered to the handlers added by the activate handler
+func TestActivateEvents(t *testing.T) {
+	display, srv, err := wltest.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	defer display.Context().Close()
+	srv.AddGlobal("zwp_input_method_v1", 1)
+	registry, err := display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	globals := wl.NewGlobalTracker(registry)
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+	p, err := globals.Bind("zwp_input_method_v1", 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events contextEvents
+	p.(*inputmethod.ZwpInputMethodV1).AddActivateHandler(&events)
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+
+	const id = wl.ProxyId(0xff000000)
+	for _, err := range []error{
+		srv.SendEvent(p.Id(), 0, id),
+		srv.SendEvent(id, 1),
+		srv.SendEvent(id, 4, uint32(7)),
+	} {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	srv.Flush()
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events.contexts) != 1 || events.contexts[0].Id() != id {
+		t.Fatalf("activated %v, want the context %d", events.contexts, id)
+	}
+	if events.resets != 1 || len(events.serials) != 1 || events.serials[0] != 7 {
+		t.Errorf("%d resets and commit states %v, want 1 reset and [7]", events.resets, events.serials)
+	}
+}
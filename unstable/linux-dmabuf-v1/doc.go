@@ -1,3 +1,4 @@
 package linuxdmabuf
 
-//go:generate go run ../../cmd/go-wayland-scanner/scanner.go -pkg linux_dmabuf -i https://gitlab.freedesktop.org/wayland/wayland-protocols/-/raw/d10d18f3d49374d2e3eb96d63511f32795aab5f7/unstable/linux-dmabuf/linux-dmabuf-unstable-v1.xml -o linux_dmabuf.go
+// linux-dmabuf-unstable-v1.xml is unstable/linux-dmabuf/linux-dmabuf-unstable-v1.xml of wayland-protocols d10d18f3d49374d2e3eb96d63511f32795aab5f7
+//go:generate go run ../../cmd/go-wayland-scanner -pkg linux_dmabuf -i linux-dmabuf-unstable-v1.xml -o linux_dmabuf.go -fuzz linux_dmabuf_fuzz_test.go
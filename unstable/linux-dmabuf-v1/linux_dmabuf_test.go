@@ -0,0 +1,76 @@
+package linuxdmabuf_test
+
+import (
+	"testing"
+
+	linuxdmabuf "github.com/neurlang/wayland/unstable/linux-dmabuf-v1"
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wlclient"
+	"github.com/neurlang/wayland/wltest"
+)
+
+// bufferEvents handles the buffers created by the created event and their release
+type bufferEvents struct {
+	buffers  []*wl.Buffer
+	releases int
+}
+
+func (e *bufferEvents) HandleZwpLinuxBufferParamsV1Created(ev linuxdmabuf.ZwpLinuxBufferParamsV1CreatedEvent) {
+	e.buffers = append(e.buffers, ev.Buffer)
+	ev.Buffer.AddReleaseHandler(e)
+}
+
+func (e *bufferEvents) HandleBufferRelease(wl.BufferReleaseEvent) {
+	e.releases++
+}
+
+// TestCreatedEvents sends the release of a buffer in the same read as the created event creating it,
+// it is delivered to the handler added by the created handler
+func TestCreatedEvents(t *testing.T) {
+	display, srv, err := wltest.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	defer display.Context().Close()
+	name := srv.AddGlobal("zwp_linux_dmabuf_v1", 3)
+	registry, err := display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dmabuf := linuxdmabuf.NewZwpLinuxDmabufV1(display.Context())
+	if err := registry.Bind(name, "zwp_linux_dmabuf_v1", 3, dmabuf); err != nil {
+		t.Fatal(err)
+	}
+	params, err := dmabuf.CreateParams()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events bufferEvents
+	params.AddCreatedHandler(&events)
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+
+	const id = wl.ProxyId(0xff000000)
+	if err := srv.SendEvent(params.Id(), 0, id); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.SendEvent(id, 0); err != nil {
+		t.Fatal(err)
+	}
+	srv.Flush()
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events.buffers) != 1 || events.buffers[0].Id() != id {
+		t.Fatalf("created %v, want the buffer %d", events.buffers, id)
+	}
+	if events.releases != 1 {
+		t.Errorf("%d releases, want 1", events.releases)
+	}
+}
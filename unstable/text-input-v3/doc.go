@@ -1,3 +1,4 @@
 package textinput
 
-//go:generate go run ../../cmd/go-wayland-scanner/scanner.go -pkg text_input -i https://gitlab.freedesktop.org/wayland/wayland-protocols/-/raw/d10d18f3d49374d2e3eb96d63511f32795aab5f7/unstable/text-input/text-input-unstable-v3.xml -o text_input.go
+// text-input-unstable-v3.xml is unstable/text-input/text-input-unstable-v3.xml of wayland-protocols d10d18f3d49374d2e3eb96d63511f32795aab5f7
+//go:generate go run ../../cmd/go-wayland-scanner -pkg text_input -i text-input-unstable-v3.xml -o text_input.go -fuzz text_input_fuzz_test.go
@@ -0,0 +1,60 @@
+// Code generated by go-wayland-scanner; DO NOT EDIT.
+// XML file: text-input-unstable-v3.xml
+
+package textinput
+
+import (
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wltest"
+	"testing"
+)
+
+// fuzzHandler handles every event of the protocol
+type fuzzHandler struct{}
+
+func (fuzzHandler) HandleZwpTextInputV3Enter(ZwpTextInputV3EnterEvent)                 {}
+func (fuzzHandler) HandleZwpTextInputV3Leave(ZwpTextInputV3LeaveEvent)                 {}
+func (fuzzHandler) HandleZwpTextInputV3PreeditString(ZwpTextInputV3PreeditStringEvent) {}
+func (fuzzHandler) HandleZwpTextInputV3CommitString(ZwpTextInputV3CommitStringEvent)   {}
+func (fuzzHandler) HandleZwpTextInputV3DeleteSurroundingText(ZwpTextInputV3DeleteSurroundingTextEvent) {
+}
+func (fuzzHandler) HandleZwpTextInputV3Done(ZwpTextInputV3DoneEvent) {}
+
+// fuzzProxy is a proxy receiving the fuzzed events
+type fuzzProxy interface {
+	wl.Dispatcher
+	Interface() *wl.Interface
+}
+
+// FuzzDispatch decodes arbitrary event messages by the Dispatch of every interface with events
+func FuzzDispatch(f *testing.F) {
+	display, server, err := wltest.New()
+	if err != nil {
+		f.Fatal(err)
+	}
+	defer server.Close()
+	ctx := display.Context()
+
+	var h fuzzHandler
+	var proxies []fuzzProxy
+	{
+		p := NewZwpTextInputV3(ctx)
+		p.AddEnterHandler(h)
+		p.AddLeaveHandler(h)
+		p.AddPreeditStringHandler(h)
+		p.AddCommitStringHandler(h)
+		p.AddDeleteSurroundingTextHandler(h)
+		p.AddDoneHandler(h)
+		proxies = append(proxies, p)
+	}
+
+	for i, p := range proxies {
+		for opcode := range p.Interface().Events {
+			f.Add(uint8(i), uint16(opcode), make([]byte, 64))
+		}
+	}
+	f.Fuzz(func(t *testing.T, index uint8, opcode uint16, data []byte) {
+		p := proxies[int(index)%len(proxies)]
+		p.Dispatch(&wl.Event{Opcode: uint32(opcode), Data: data})
+	})
+}
@@ -4,6 +4,9 @@ import "github.com/neurlang/wayland/wl"
 import tiv3 "github.com/neurlang/wayland/unstable/text-input-v3"
 import imv1 "github.com/neurlang/wayland/unstable/input-method-v1"
 import xdgd1 "github.com/neurlang/wayland/unstable/xdg-decoration-v1"
+import "github.com/neurlang/wayland/viewporter"
+import "github.com/neurlang/wayland/fractionalscale"
+import "github.com/neurlang/wayland/presentation"
 
 func GetNewFunc(iface string) func(*wl.Context) wl.Proxy {
 	switch iface {
@@ -19,6 +22,18 @@ func GetNewFunc(iface string) func(*wl.Context) wl.Proxy {
 		return func(ctx *wl.Context) wl.Proxy {
 			return xdgd1.NewZxdgDecorationManagerV1(ctx)
 		}
+	case "wp_viewporter":
+		return func(ctx *wl.Context) wl.Proxy {
+			return viewporter.NewViewporter(ctx)
+		}
+	case "wp_fractional_scale_manager_v1":
+		return func(ctx *wl.Context) wl.Proxy {
+			return fractionalscale.NewFractionalScaleManagerV1(ctx)
+		}
+	case "wp_presentation":
+		return func(ctx *wl.Context) wl.Proxy {
+			return presentation.NewPresentation(ctx)
+		}
 	// TODO: add more
 	default:
 		return nil
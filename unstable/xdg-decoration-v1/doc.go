@@ -1,3 +1,4 @@
 package xdgdecoration
 
-//go:generate go run ../../cmd/go-wayland-scanner/scanner.go -pkg xdg_decoration -i https://gitlab.freedesktop.org/wayland/wayland-protocols/-/raw/d10d18f3d49374d2e3eb96d63511f32795aab5f7/unstable/xdg-decoration/xdg-decoration-unstable-v1.xml -o xdg_decoration.go
+// xdg-decoration-unstable-v1.xml is unstable/xdg-decoration/xdg-decoration-unstable-v1.xml of wayland-protocols d10d18f3d49374d2e3eb96d63511f32795aab5f7
+//go:generate go run ../../cmd/go-wayland-scanner -pkg xdg_decoration -i xdg-decoration-unstable-v1.xml -o xdg_decoration.go -fuzz xdg_decoration_fuzz_test.go
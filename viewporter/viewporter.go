@@ -0,0 +1,4 @@
+// Package viewporter implements the stable wp_viewporter protocol, cropping and scaling surface contents in the compositor
+package viewporter
+
+//go:generate go run ../cmd/go-wayland-scanner -pkg viewporter -prefix wp_ -i viewporter.xml -o viewporter.xml.go
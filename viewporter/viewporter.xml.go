@@ -0,0 +1,331 @@
+// Code generated by go-wayland-scanner; DO NOT EDIT.
+// XML file: viewporter.xml
+//
+// Viewporter Protocol Copyright:
+//
+// Copyright © 2013-2016 Collabora, Ltd.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the next
+// paragraph) shall be included in all copies or substantial portions of the
+// Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package viewporter
+
+import (
+	"github.com/neurlang/wayland/wl"
+)
+
+// Viewporter: surface cropping and scaling
+//
+// The global interface exposing surface cropping and scaling
+// capabilities is used to instantiate an interface extension for a
+// wl_surface object. This extended interface will then allow
+// cropping and scaling the surface contents, effectively
+// disconnecting the direct relationship between the buffer and the
+// surface size.
+type Viewporter struct {
+	wl.BaseProxy
+}
+
+// NewViewporter creates a new wp_viewporter proxy registered in the Context
+func NewViewporter(ctx *wl.Context) *Viewporter {
+	ret := new(Viewporter)
+	ctx.Register(ret)
+	return ret
+}
+
+// Destroy: unbind from the cropping and scaling interface
+//
+// Informs the server that the client will not be using this
+// protocol object anymore. This does not affect any other objects,
+// wp_viewport objects included.
+func (p *Viewporter) Destroy() error {
+	err := p.Context().MarshalRequest(p, 0, nil)
+	p.Unregister()
+	return err
+}
+
+// GetViewport: extend surface interface for crop and scale
+//
+// Instantiate an interface extension for the given wl_surface to
+// crop and scale its content. If the given wl_surface already has
+// a wp_viewport object associated, the viewport_exists
+// protocol error is raised.
+//
+//	id: the new viewport interface id
+//	surface: the surface
+func (p *Viewporter) GetViewport(surface *wl.Surface) (*Viewport, error) {
+	ret := NewViewport(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 1, func(r *wl.Request) {
+		r.PutNewId(ret)
+		if surface != nil {
+			r.PutObject(surface.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
+}
+
+// ViewporterError:
+const (
+	// ViewporterErrorViewportExists: the surface already has a viewport object associated
+	ViewporterErrorViewportExists = 0
+)
+
+const (
+	ViewporterDestroySinceVersion     = 1
+	ViewporterGetViewportSinceVersion = 1
+)
+
+// ViewporterInterface describes the wp_viewporter interface
+var ViewporterInterface = &wl.Interface{
+	Name:    "wp_viewporter",
+	Version: 1,
+	Requests: []wl.Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "get_viewport",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "id", Type: wl.ArgNewId, Interface: "wp_viewport"},
+				{Name: "surface", Type: wl.ArgObject, Interface: "wl_surface"},
+			},
+		},
+	},
+	Enums: []wl.Enum{
+		{
+			Name: "error",
+			Entries: []wl.EnumEntry{
+				{Name: "viewport_exists", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wp_viewporter interface
+func (p *Viewporter) Interface() *wl.Interface {
+	return ViewporterInterface
+}
+
+// Viewport: crop and scale interface to a wl_surface
+//
+// An additional interface to a wl_surface object, which allows the
+// client to specify the cropping and scaling of the surface
+// contents.
+//
+// This interface works with two concepts: the source rectangle (src_x,
+// src_y, src_width, src_height), and the destination size (dst_width,
+// dst_height). The contents of the source rectangle are scaled to the
+// destination size, and content outside the source rectangle is ignored.
+// This state is double-buffered, and is applied on the next
+// wl_surface.commit.
+//
+// The two parts of crop and scale state are independent: the source
+// rectangle, and the destination size. Initially both are unset, that
+// is, no scaling is applied. The whole of the current wl_buffer is
+// used as the source, and the surface size is as defined in
+// wl_surface.attach.
+//
+// If the destination size is set, it causes the surface size to become
+// dst_width, dst_height. The source (rectangle) is scaled to exactly
+// this size. This overrides whatever the attached wl_buffer size is,
+// unless the wl_buffer is NULL. If the wl_buffer is NULL, the surface
+// has no content and therefore no size. Otherwise, the size is always
+// at least 1x1 in surface local coordinates.
+//
+// If the source rectangle is set, it defines what area of the wl_buffer is
+// taken as the source. If the source rectangle is set and the destination
+// size is not set, then src_width and src_height must be integers, and the
+// surface size becomes the source rectangle size. This results in cropping
+// without scaling. If src_width or src_height are not integers and
+// destination size is not set, the bad_size protocol error is raised when
+// the surface state is applied.
+//
+// The coordinate transformations from buffer pixel coordinates up to
+// the surface-local coordinates happen in the following order:
+// 1. buffer_transform (wl_surface.set_buffer_transform)
+// 2. buffer_scale (wl_surface.set_buffer_scale)
+// 3. crop and scale (wp_viewport.set*)
+// This means, that the source rectangle coordinates of crop and scale
+// are given in the coordinates after the buffer transform and scale,
+// i.e. in the coordinates that would be the surface-local coordinates
+// if the crop and scale was not applied.
+//
+// If src_x or src_y are negative, the bad_value protocol error is raised.
+// Otherwise, if the source rectangle is partially or completely outside of
+// the non-NULL wl_buffer, then the out_of_buffer protocol error is raised
+// when the surface state is applied. A NULL wl_buffer does not raise the
+// out_of_buffer error.
+//
+// If the wl_surface associated with the wp_viewport is destroyed,
+// all wp_viewport requests except 'destroy' raise the protocol error
+// no_surface.
+//
+// If the wp_viewport object is destroyed, the crop and scale
+// state is removed from the wl_surface. The change will be applied
+// on the next wl_surface.commit.
+type Viewport struct {
+	wl.BaseProxy
+}
+
+// NewViewport creates a new wp_viewport proxy registered in the Context
+func NewViewport(ctx *wl.Context) *Viewport {
+	ret := new(Viewport)
+	ctx.Register(ret)
+	return ret
+}
+
+// Destroy: remove scaling and cropping from the surface
+//
+// The associated wl_surface's crop and scale state is removed.
+// The change is applied on the next wl_surface.commit.
+func (p *Viewport) Destroy() error {
+	err := p.Context().MarshalRequest(p, 0, nil)
+	p.Unregister()
+	return err
+}
+
+// SetSource: set the source rectangle for cropping
+//
+// Set the source rectangle of the associated wl_surface. See
+// wp_viewport for the description, and relation to the wl_buffer
+// size.
+//
+// If all of x, y, width and height are -1.0, the source rectangle is
+// unset instead. Any other set of values where width or height are zero
+// or negative, or x or y are negative, raise the bad_value protocol
+// error.
+//
+// The crop and scale state is double-buffered, see wl_surface.commit.
+//
+//	x: source rectangle x
+//	y: source rectangle y
+//	width: source rectangle width
+//	height: source rectangle height
+func (p *Viewport) SetSource(x float32, y float32, width float32, height float32) error {
+	return p.Context().MarshalRequest(p, 1, func(r *wl.Request) {
+		r.PutFloat32(x)
+		r.PutFloat32(y)
+		r.PutFloat32(width)
+		r.PutFloat32(height)
+	})
+}
+
+// SetDestination: set the surface size for scaling
+//
+// Set the destination size of the associated wl_surface. See
+// wp_viewport for the description, and relation to the wl_buffer
+// size.
+//
+// If width is -1 and height is -1, the destination size is unset
+// instead. Any other pair of values for width and height that
+// contains zero or negative values raises the bad_value protocol
+// error.
+//
+// The crop and scale state is double-buffered, see wl_surface.commit.
+//
+//	width: surface width
+//	height: surface height
+func (p *Viewport) SetDestination(width int32, height int32) error {
+	return p.Context().MarshalRequest(p, 2, func(r *wl.Request) {
+		r.PutInt32(width)
+		r.PutInt32(height)
+	})
+}
+
+// ViewportError:
+const (
+	// ViewportErrorBadValue: negative or zero values in width or height
+	ViewportErrorBadValue = 0
+	// ViewportErrorBadSize: destination size is not integer
+	ViewportErrorBadSize = 1
+	// ViewportErrorOutOfBuffer: source rectangle extends outside of the content area
+	ViewportErrorOutOfBuffer = 2
+	// ViewportErrorNoSurface: the wl_surface was destroyed
+	ViewportErrorNoSurface = 3
+)
+
+const (
+	ViewportDestroySinceVersion        = 1
+	ViewportSetSourceSinceVersion      = 1
+	ViewportSetDestinationSinceVersion = 1
+)
+
+// ViewportInterface describes the wp_viewport interface
+var ViewportInterface = &wl.Interface{
+	Name:    "wp_viewport",
+	Version: 1,
+	Requests: []wl.Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "set_source",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "x", Type: wl.ArgFixed},
+				{Name: "y", Type: wl.ArgFixed},
+				{Name: "width", Type: wl.ArgFixed},
+				{Name: "height", Type: wl.ArgFixed},
+			},
+		},
+		{
+			Name:  "set_destination",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "width", Type: wl.ArgInt},
+				{Name: "height", Type: wl.ArgInt},
+			},
+		},
+	},
+	Enums: []wl.Enum{
+		{
+			Name: "error",
+			Entries: []wl.EnumEntry{
+				{Name: "bad_value", Value: 0},
+				{Name: "bad_size", Value: 1},
+				{Name: "out_of_buffer", Value: 2},
+				{Name: "no_surface", Value: 3},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wp_viewport interface
+func (p *Viewport) Interface() *wl.Interface {
+	return ViewportInterface
+}
+
+func init() {
+	wl.RegisterInterface(ViewporterInterface)
+	wl.RegisterInterface(ViewportInterface)
+	wl.RegisterProxy(ViewporterInterface, func(ctx *wl.Context) wl.Proxy {
+		return NewViewporter(ctx)
+	})
+	wl.RegisterProxy(ViewportInterface, func(ctx *wl.Context) wl.Proxy {
+		return NewViewport(ctx)
+	})
+}
@@ -0,0 +1,11 @@
+// Package viewporterserver implements the compositor side of the stable wp_viewporter protocol
+//
+// The resource types are generated from viewporter.xml in the server flavor described in
+// package wlserver, a global of the wp_viewporter is added by
+//
+//	server.AddGlobal(viewporter.ViewporterInterface, 1, func(r wlserver.Resource) {
+//		r.SetHandler(myViewporter)
+//	})
+package viewporterserver
+
+//go:generate go run ../cmd/go-wayland-scanner -server -client github.com/neurlang/wayland/viewporter -pkg viewporterserver -prefix wp_ -i ../viewporter/viewporter.xml -o viewporter.xml.go
@@ -0,0 +1,302 @@
+// Code generated by go-wayland-scanner; DO NOT EDIT.
+// XML file: viewporter.xml
+//
+// Viewporter Protocol Copyright:
+//
+// Copyright © 2013-2016 Collabora, Ltd.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a
+// copy of this software and associated documentation files (the "Software"),
+// to deal in the Software without restriction, including without limitation
+// the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the
+// Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the next
+// paragraph) shall be included in all copies or substantial portions of the
+// Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.  IN NO EVENT SHALL
+// THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING
+// FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER
+// DEALINGS IN THE SOFTWARE.
+
+package viewporterserver
+
+import (
+	"github.com/neurlang/wayland/viewporter"
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wlserver"
+)
+
+// Viewporter: surface cropping and scaling
+//
+// The global interface exposing surface cropping and scaling
+// capabilities is used to instantiate an interface extension for a
+// wl_surface object. This extended interface will then allow
+// cropping and scaling the surface contents, effectively
+// disconnecting the direct relationship between the buffer and the
+// surface size.
+type Viewporter struct {
+	wlserver.BaseResource
+}
+
+// Interface returns the description of the wp_viewporter interface
+func (r *Viewporter) Interface() *wl.Interface {
+	return viewporter.ViewporterInterface
+}
+
+// ViewporterError:
+const (
+	// ViewporterErrorViewportExists: the surface already has a viewport object associated
+	ViewporterErrorViewportExists = 0
+)
+
+// ViewporterDestroyRequest: unbind from the cropping and scaling interface
+//
+// Informs the server that the client will not be using this
+// protocol object anymore. This does not affect any other objects,
+// wp_viewport objects included.
+type ViewporterDestroyRequest struct {
+	Resource *Viewporter
+}
+
+// ViewporterDestroyHandler is implemented by the handlers of ViewporterDestroyRequest, see SetHandler
+type ViewporterDestroyHandler interface {
+	HandleViewporterDestroy(ViewporterDestroyRequest)
+}
+
+// ViewporterGetViewportRequest: extend surface interface for crop and scale
+//
+// Instantiate an interface extension for the given wl_surface to
+// crop and scale its content. If the given wl_surface already has
+// a wp_viewport object associated, the viewport_exists
+// protocol error is raised.
+type ViewporterGetViewportRequest struct {
+	Resource *Viewporter
+	Id       *Viewport
+	Surface  *wlserver.Surface
+}
+
+// ViewporterGetViewportHandler is implemented by the handlers of ViewporterGetViewportRequest, see SetHandler
+type ViewporterGetViewportHandler interface {
+	HandleViewporterGetViewport(ViewporterGetViewportRequest)
+}
+
+// Dispatch decodes a request received on the wp_viewporter and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Viewporter) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := ViewporterDestroyRequest{Resource: r}
+		if h, ok := r.Handler().(ViewporterDestroyHandler); ok {
+			h.HandleViewporterDestroy(req)
+		}
+	case 1:
+		req := ViewporterGetViewportRequest{Resource: r}
+		var err error
+		req.Id = new(Viewport)
+		if err := c.NewId(req.Id, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		var surfaceRes wlserver.Resource
+		if surfaceRes, err = c.Argument(event.Uint32(), false, wl.SurfaceInterface); err != nil {
+			return err
+		}
+		req.Surface, _ = surfaceRes.(*wlserver.Surface)
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(ViewporterGetViewportHandler); ok {
+			h.HandleViewporterGetViewport(req)
+		}
+	}
+	return nil
+}
+
+// Viewport: crop and scale interface to a wl_surface
+//
+// An additional interface to a wl_surface object, which allows the
+// client to specify the cropping and scaling of the surface
+// contents.
+//
+// This interface works with two concepts: the source rectangle (src_x,
+// src_y, src_width, src_height), and the destination size (dst_width,
+// dst_height). The contents of the source rectangle are scaled to the
+// destination size, and content outside the source rectangle is ignored.
+// This state is double-buffered, and is applied on the next
+// wl_surface.commit.
+//
+// The two parts of crop and scale state are independent: the source
+// rectangle, and the destination size. Initially both are unset, that
+// is, no scaling is applied. The whole of the current wl_buffer is
+// used as the source, and the surface size is as defined in
+// wl_surface.attach.
+//
+// If the destination size is set, it causes the surface size to become
+// dst_width, dst_height. The source (rectangle) is scaled to exactly
+// this size. This overrides whatever the attached wl_buffer size is,
+// unless the wl_buffer is NULL. If the wl_buffer is NULL, the surface
+// has no content and therefore no size. Otherwise, the size is always
+// at least 1x1 in surface local coordinates.
+//
+// If the source rectangle is set, it defines what area of the wl_buffer is
+// taken as the source. If the source rectangle is set and the destination
+// size is not set, then src_width and src_height must be integers, and the
+// surface size becomes the source rectangle size. This results in cropping
+// without scaling. If src_width or src_height are not integers and
+// destination size is not set, the bad_size protocol error is raised when
+// the surface state is applied.
+//
+// The coordinate transformations from buffer pixel coordinates up to
+// the surface-local coordinates happen in the following order:
+// 1. buffer_transform (wl_surface.set_buffer_transform)
+// 2. buffer_scale (wl_surface.set_buffer_scale)
+// 3. crop and scale (wp_viewport.set*)
+// This means, that the source rectangle coordinates of crop and scale
+// are given in the coordinates after the buffer transform and scale,
+// i.e. in the coordinates that would be the surface-local coordinates
+// if the crop and scale was not applied.
+//
+// If src_x or src_y are negative, the bad_value protocol error is raised.
+// Otherwise, if the source rectangle is partially or completely outside of
+// the non-NULL wl_buffer, then the out_of_buffer protocol error is raised
+// when the surface state is applied. A NULL wl_buffer does not raise the
+// out_of_buffer error.
+//
+// If the wl_surface associated with the wp_viewport is destroyed,
+// all wp_viewport requests except 'destroy' raise the protocol error
+// no_surface.
+//
+// If the wp_viewport object is destroyed, the crop and scale
+// state is removed from the wl_surface. The change will be applied
+// on the next wl_surface.commit.
+type Viewport struct {
+	wlserver.BaseResource
+}
+
+// Interface returns the description of the wp_viewport interface
+func (r *Viewport) Interface() *wl.Interface {
+	return viewporter.ViewportInterface
+}
+
+// ViewportError:
+const (
+	// ViewportErrorBadValue: negative or zero values in width or height
+	ViewportErrorBadValue = 0
+	// ViewportErrorBadSize: destination size is not integer
+	ViewportErrorBadSize = 1
+	// ViewportErrorOutOfBuffer: source rectangle extends outside of the content area
+	ViewportErrorOutOfBuffer = 2
+	// ViewportErrorNoSurface: the wl_surface was destroyed
+	ViewportErrorNoSurface = 3
+)
+
+// ViewportDestroyRequest: remove scaling and cropping from the surface
+//
+// The associated wl_surface's crop and scale state is removed.
+// The change is applied on the next wl_surface.commit.
+type ViewportDestroyRequest struct {
+	Resource *Viewport
+}
+
+// ViewportDestroyHandler is implemented by the handlers of ViewportDestroyRequest, see SetHandler
+type ViewportDestroyHandler interface {
+	HandleViewportDestroy(ViewportDestroyRequest)
+}
+
+// ViewportSetSourceRequest: set the source rectangle for cropping
+//
+// Set the source rectangle of the associated wl_surface. See
+// wp_viewport for the description, and relation to the wl_buffer
+// size.
+//
+// If all of x, y, width and height are -1.0, the source rectangle is
+// unset instead. Any other set of values where width or height are zero
+// or negative, or x or y are negative, raise the bad_value protocol
+// error.
+//
+// The crop and scale state is double-buffered, see wl_surface.commit.
+type ViewportSetSourceRequest struct {
+	Resource *Viewport
+	X        float32
+	Y        float32
+	Width    float32
+	Height   float32
+}
+
+// ViewportSetSourceHandler is implemented by the handlers of ViewportSetSourceRequest, see SetHandler
+type ViewportSetSourceHandler interface {
+	HandleViewportSetSource(ViewportSetSourceRequest)
+}
+
+// ViewportSetDestinationRequest: set the surface size for scaling
+//
+// Set the destination size of the associated wl_surface. See
+// wp_viewport for the description, and relation to the wl_buffer
+// size.
+//
+// If width is -1 and height is -1, the destination size is unset
+// instead. Any other pair of values for width and height that
+// contains zero or negative values raises the bad_value protocol
+// error.
+//
+// The crop and scale state is double-buffered, see wl_surface.commit.
+type ViewportSetDestinationRequest struct {
+	Resource *Viewport
+	Width    int32
+	Height   int32
+}
+
+// ViewportSetDestinationHandler is implemented by the handlers of ViewportSetDestinationRequest, see SetHandler
+type ViewportSetDestinationHandler interface {
+	HandleViewportSetDestination(ViewportSetDestinationRequest)
+}
+
+// Dispatch decodes a request received on the wp_viewport and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Viewport) Dispatch(event *wl.Event) error {
+	switch event.Opcode {
+	case 0:
+		req := ViewportDestroyRequest{Resource: r}
+		if h, ok := r.Handler().(ViewportDestroyHandler); ok {
+			h.HandleViewportDestroy(req)
+		}
+	case 1:
+		req := ViewportSetSourceRequest{Resource: r}
+		req.X = event.Float32()
+		req.Y = event.Float32()
+		req.Width = event.Float32()
+		req.Height = event.Float32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(ViewportSetSourceHandler); ok {
+			h.HandleViewportSetSource(req)
+		}
+	case 2:
+		req := ViewportSetDestinationRequest{Resource: r}
+		req.Width = event.Int32()
+		req.Height = event.Int32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(ViewportSetDestinationHandler); ok {
+			h.HandleViewportSetDestination(req)
+		}
+	}
+	return nil
+}
+
+func init() {
+	wlserver.RegisterResource(viewporter.ViewporterInterface, func() wlserver.Resource {
+		return new(Viewporter)
+	})
+	wlserver.RegisterResource(viewporter.ViewportInterface, func() wlserver.Resource {
+		return new(Viewport)
+	})
+}
@@ -11,3 +11,52 @@ const ModAltMask ModType = 0x02
 
 // ModControlMask is the Control modifier mask - provided for convenience only
 const ModControlMask ModType = 0x04
+
+// WmCapability is a mask of the window management features offered by the compositor
+type WmCapability uint32
+
+// WmCapabilityWindowMenu is the window menu shown by the compositor
+const WmCapabilityWindowMenu WmCapability = 0x01
+
+// WmCapabilityMaximize is maximizing the window, see SetMaximized
+const WmCapabilityMaximize WmCapability = 0x02
+
+// WmCapabilityFullscreen is making the window fullscreen, see SetFullscreen
+const WmCapabilityFullscreen WmCapability = 0x04
+
+// WmCapabilityMinimize is minimizing the window, see SetMinimized
+const WmCapabilityMinimize WmCapability = 0x08
+
+// WmCapabilityAll are all the features, assumed unless the compositor advertises otherwise
+const WmCapabilityAll = WmCapabilityWindowMenu | WmCapabilityMaximize | WmCapabilityFullscreen |
+	WmCapabilityMinimize
+
+// TiledEdge is a mask of the window edges adjacent to other windows of a tiling layout
+type TiledEdge uint32
+
+// TiledEdgeLeft is the left edge
+const TiledEdgeLeft TiledEdge = 0x01
+
+// TiledEdgeRight is the right edge
+const TiledEdgeRight TiledEdge = 0x02
+
+// TiledEdgeTop is the top edge
+const TiledEdgeTop TiledEdge = 0x04
+
+// TiledEdgeBottom is the bottom edge
+const TiledEdgeBottom TiledEdge = 0x08
+
+// PresentationFlag is a mask telling how a frame was presented, see PresentationInfo
+type PresentationFlag uint32
+
+// PresentationVsync is a presentation synchronized to the vertical blanking, without tearing
+const PresentationVsync PresentationFlag = 0x01
+
+// PresentationHwClock is a timestamp provided by the hardware
+const PresentationHwClock PresentationFlag = 0x02
+
+// PresentationHwCompletion is a presentation signalled by the hardware
+const PresentationHwCompletion PresentationFlag = 0x04
+
+// PresentationZeroCopy is a presentation of the buffer itself, without a copy
+const PresentationZeroCopy PresentationFlag = 0x08
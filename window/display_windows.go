@@ -40,3 +40,8 @@ func DisplayRun(d *Display) {
 
 	winc.RunMainLoop()
 }
+
+// HasViewporter reports whether the compositor can crop and scale the surfaces, never on Windows
+func (d *Display) HasViewporter() bool {
+	return false
+}
@@ -49,7 +49,9 @@ func (frame *windowFrame) Resize(Widget *Widget, width int32, height int32, pwid
 		interior.Width, interior.Height)
 
 	if child.Userdata != nil {
-		child.Userdata.Resize(child, interior.Width, interior.Height, width, height)
+		pwidth, pheight := windowBufferSize(Widget.Window, interior.Width, interior.Height)
+
+		child.Userdata.Resize(child, interior.Width, interior.Height, pwidth, pheight)
 
 		if Widget.Window.fullscreen {
 			width = child.allocation.Width
@@ -152,6 +154,11 @@ type frame struct {
 	geometryDirty int
 
 	status uint32
+
+	// the buttons requested when the frame was created
+	requested uint32
+	// the requested buttons offered by the compositor
+	buttons uint32
 }
 
 type theme struct {
@@ -164,5 +171,17 @@ func frameCreate(
 	title string,
 	icon cairo.Surface,
 ) *frame {
-	return new(frame)
+	return &frame{width: width, height: height, title: title, theme: t, requested: buttons, buttons: buttons}
+}
+
+// setButtons hides the buttons of the features the compositor does not offer
+func (f *frame) setButtons(caps WmCapability) {
+	f.buttons = f.requested
+	if caps&WmCapabilityMaximize == 0 {
+		f.buttons &^= FrameButtonMaximize
+	}
+	if caps&WmCapabilityMinimize == 0 {
+		f.buttons &^= FrameButtonMinimize
+	}
+	f.status |= FrameStatusRepaint
 }
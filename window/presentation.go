@@ -0,0 +1,120 @@
+package window
+
+import (
+	"fmt"
+	"time"
+)
+
+// PresentationInfo is the feedback of the compositor on a frame of a window, see OnPresented
+type PresentationInfo struct {
+	// Time is when the frame was shown, in the clock of ClockID such as CLOCK_MONOTONIC
+	Time time.Duration
+	// Refresh is the time until the next refresh of the output, zero when its refresh rate varies
+	Refresh time.Duration
+	// Sequence is the vertical retrace counter of the output, zero when it has none
+	Sequence uint64
+	Flags    PresentationFlag
+	ClockID  uint32
+	// Discarded is set when the frame was never shown, the other fields are zero then
+	Discarded bool
+}
+
+// FrameTimings is a rolling report of the presentation of the last frames of a window
+type FrameTimings struct {
+	frames []PresentationInfo
+	next   int
+	full   bool
+}
+
+// FrameReport summarizes the frames of FrameTimings
+type FrameReport struct {
+	Presented int
+	Discarded int
+	// Missed is the number of refreshes of the output between the presented frames without a new frame
+	Missed uint64
+	// Interval is the mean time between the presented frames, MinInterval and MaxInterval its extremes
+	Interval    time.Duration
+	MinInterval time.Duration
+	MaxInterval time.Duration
+	// Refresh is the refresh period of the output at the last frame
+	Refresh time.Duration
+}
+
+// NewFrameTimings creates a report of the last frames
+func NewFrameTimings(frames int) *FrameTimings {
+	if frames < 2 {
+		frames = 2
+	}
+	return &FrameTimings{frames: make([]PresentationInfo, frames)}
+}
+
+// Add records the feedback of a frame, replacing the oldest one
+func (t *FrameTimings) Add(info PresentationInfo) {
+	t.frames[t.next] = info
+	t.next++
+	if t.next == len(t.frames) {
+		t.next = 0
+		t.full = true
+	}
+}
+
+// Report summarizes the recorded frames
+func (t *FrameTimings) Report() (r FrameReport) {
+	var frames = t.frames[:t.next]
+	if t.full {
+		frames = append(t.frames[t.next:len(t.frames):len(t.frames)], frames...)
+	}
+	var first, last *PresentationInfo
+	for i := range frames {
+		var info = &frames[i]
+		if info.Discarded {
+			r.Discarded++
+			continue
+		}
+		r.Presented++
+		r.Refresh = info.Refresh
+		if last != nil {
+			var interval = info.Time - last.Time
+			if r.MinInterval == 0 || interval < r.MinInterval {
+				r.MinInterval = interval
+			}
+			if interval > r.MaxInterval {
+				r.MaxInterval = interval
+			}
+			r.Missed += missedRefreshes(last, info)
+		} else {
+			first = info
+		}
+		last = info
+	}
+	if r.Presented > 1 {
+		r.Interval = (last.Time - first.Time) / time.Duration(r.Presented-1)
+	}
+	return r
+}
+
+// missedRefreshes counts the refreshes between two presented frames, by the retrace counter
+// or else by the refresh period
+func missedRefreshes(prev, next *PresentationInfo) uint64 {
+	if prev.Sequence != 0 && next.Sequence > prev.Sequence {
+		return next.Sequence - prev.Sequence - 1
+	}
+	if prev.Refresh > 0 && next.Time > prev.Time {
+		var refreshes = (next.Time - prev.Time + prev.Refresh/2) / prev.Refresh
+		if refreshes > 1 {
+			return uint64(refreshes - 1)
+		}
+	}
+	return 0
+}
+
+// String formats the report for logging
+func (t *FrameTimings) String() string {
+	var r = t.Report()
+	var fps float64
+	if r.Interval > 0 {
+		fps = float64(time.Second) / float64(r.Interval)
+	}
+	return fmt.Sprintf("%d frames %.1f fps, interval %v min %v max %v, refresh %v, %d missed, %d discarded",
+		r.Presented, fps, r.Interval, r.MinInterval, r.MaxInterval, r.Refresh, r.Missed, r.Discarded)
+}
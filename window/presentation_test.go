@@ -0,0 +1,105 @@
+package window
+
+import (
+	"testing"
+	"time"
+)
+
+const ms = time.Millisecond
+
+// presented returns the feedback of a frame shown at the time
+func presented(at time.Duration, refresh time.Duration, sequence uint64) PresentationInfo {
+	return PresentationInfo{Time: at, Refresh: refresh, Sequence: sequence}
+}
+
+var discarded = PresentationInfo{Discarded: true}
+
+func TestFrameTimingsReport(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		size   int
+		frames []PresentationInfo
+		want   FrameReport
+	}{
+		{
+			name: "empty",
+			size: 4,
+		},
+		{
+			name:   "single frame",
+			size:   4,
+			frames: []PresentationInfo{presented(10*ms, 16*ms, 0)},
+			want:   FrameReport{Presented: 1, Refresh: 16 * ms},
+		},
+		{
+			name: "missed by sequence",
+			size: 4,
+			// the retrace counter takes precedence over the 2 refreshes missed by time
+			frames: []PresentationInfo{presented(0, 16*ms, 10), presented(16*ms, 16*ms, 11), presented(66*ms, 16*ms, 15)},
+			want: FrameReport{Presented: 3, Missed: 3, Interval: 33 * ms, MinInterval: 16 * ms, MaxInterval: 50 * ms,
+				Refresh: 16 * ms},
+		},
+		{
+			name:   "missed by refresh",
+			size:   4,
+			frames: []PresentationInfo{presented(0, 16*ms, 0), presented(16*ms, 16*ms, 0), presented(48*ms, 16*ms, 0), presented(64*ms, 16*ms, 0)},
+			want: FrameReport{Presented: 4, Missed: 1, Interval: 64 * ms / 3, MinInterval: 16 * ms, MaxInterval: 32 * ms,
+				Refresh: 16 * ms},
+		},
+		{
+			name:   "variable refresh",
+			size:   4,
+			frames: []PresentationInfo{presented(0, 0, 0), presented(40*ms, 0, 0), presented(50*ms, 0, 0)},
+			want:   FrameReport{Presented: 3, Interval: 25 * ms, MinInterval: 10 * ms, MaxInterval: 40 * ms},
+		},
+		{
+			name:   "discarded",
+			size:   4,
+			frames: []PresentationInfo{presented(0, 16*ms, 0), discarded, presented(32*ms, 8*ms, 0), discarded},
+			want: FrameReport{Presented: 2, Discarded: 2, Missed: 1, Interval: 32 * ms, MinInterval: 32 * ms,
+				MaxInterval: 32 * ms, Refresh: 8 * ms},
+		},
+		{
+			name: "wrap around",
+			size: 3,
+			// only the last 3 frames are kept, in order
+			frames: []PresentationInfo{presented(0, 0, 1), discarded, presented(20*ms, 0, 3), presented(40*ms, 0, 5),
+				presented(80*ms, 0, 6)},
+			want: FrameReport{Presented: 3, Missed: 1, Interval: 30 * ms, MinInterval: 20 * ms, MaxInterval: 40 * ms},
+		},
+		{
+			name:   "at least 2 frames",
+			size:   1,
+			frames: []PresentationInfo{presented(0, 0, 0), presented(10*ms, 0, 0), presented(30*ms, 0, 0)},
+			want:   FrameReport{Presented: 2, Interval: 20 * ms, MinInterval: 20 * ms, MaxInterval: 20 * ms},
+		},
+	} {
+		timings := NewFrameTimings(test.size)
+		for _, info := range test.frames {
+			timings.Add(info)
+		}
+		if got := timings.Report(); got != test.want {
+			t.Errorf("%s: report %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestMissedRefreshes(t *testing.T) {
+	for _, test := range []struct {
+		prev, next PresentationInfo
+		want       uint64
+	}{
+		{presented(0, 16*ms, 7), presented(16*ms, 16*ms, 8), 0},
+		{presented(0, 16*ms, 7), presented(16*ms, 16*ms, 10), 2},
+		// a counter that did not advance falls back to the refresh period, rounded
+		{presented(0, 16*ms, 7), presented(40*ms, 16*ms, 7), 2},
+		{presented(0, 16*ms, 0), presented(23*ms, 16*ms, 0), 0},
+		{presented(0, 16*ms, 0), presented(24*ms, 16*ms, 0), 1},
+		{presented(0, 0, 0), presented(100*ms, 0, 0), 0},
+		{presented(50*ms, 16*ms, 0), presented(10*ms, 16*ms, 0), 0},
+	} {
+		if got := missedRefreshes(&test.prev, &test.next); got != test.want {
+			t.Errorf("missed refreshes from %+v to %+v: %d, want %d", test.prev, test.next, got, test.want)
+		}
+	}
+}
@@ -3,10 +3,14 @@ package window
 import "github.com/neurlang/wayland/wl"
 
 type WidgetHandler interface {
+	// Resize gives the logical size of the widget, and pwidth and pheight, the size in pixels
+	// of its buffer at the preferred scale of the window
 	Resize(Widget *Widget, width int32, height int32, pwidth int32, pheight int32)
 	Redraw(Widget *Widget)
 	Enter(Widget *Widget, Input *Input, x float32, y float32)
 	Leave(Widget *Widget, Input *Input)
+	// Motion gives the pointer position in the pixels of the buffer, the position on the surface
+	// times the preferred scale of the window
 	Motion(Widget *Widget, Input *Input, time uint32, x float32, y float32) int
 	Button(
 		Widget *Widget,
@@ -1,6 +1,10 @@
 package window
 
-import cairo "github.com/neurlang/wayland/cairoshim"
+import (
+	"errors"
+
+	cairo "github.com/neurlang/wayland/cairoshim"
+)
 
 type Widget struct {
 	Userdata interface{}
@@ -95,3 +99,13 @@ func (w *Widget) ScheduleRedraw() {
 
 	}
 }
+
+// SetViewportSource crops the widget to the rectangle of its buffer, not supported on Windows
+func (w *Widget) SetViewportSource(x, y, width, height float32) error {
+	return errors.New("no_viewporter")
+}
+
+// SetViewportDestination scales the widget to width and height, not supported on Windows
+func (w *Widget) SetViewportDestination(width, height int32) error {
+	return errors.New("no_viewporter")
+}
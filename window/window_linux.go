@@ -26,6 +26,9 @@ import "github.com/neurlang/wayland/wlclient"
 import "github.com/neurlang/wayland/wlcursor"
 import "github.com/neurlang/wayland/wl"
 import zxdg "github.com/neurlang/wayland/xdg"
+import "github.com/neurlang/wayland/viewporter"
+import "github.com/neurlang/wayland/fractionalscale"
+import "github.com/neurlang/wayland/presentation"
 import cairo "github.com/neurlang/wayland/cairoshim"
 
 import "os"
@@ -36,6 +39,7 @@ import xkb "github.com/neurlang/wayland/xkbcommon"
 import "errors"
 
 import "fmt"
+import "time"
 
 type runner interface {
 	Run(uint32)
@@ -74,15 +78,10 @@ const CursorBlank = 16
 const ZwpRelativePointerManagerV1Version = 1
 const ZwpPointerConstraintsV1Version = 1
 
-type global struct {
-	name    uint32
-	iface   string
-	version uint32
-}
-
 type Display struct {
 	Display            *wl.Display
 	registry           *wl.Registry
+	globals            *wl.GlobalTracker
 	compositor         *wl.Compositor
 	subcompositor      *wl.Subcompositor
 	shm                *wl.Shm
@@ -90,6 +89,10 @@ type Display struct {
 	dataDeviceVersion  int
 	textCursorPosition *struct{}
 	xdgShell           *zxdg.WmBase
+	viewporter         *viewporter.Viewporter
+	fractionalScale    *fractionalscale.FractionalScaleManagerV1
+	presentation       *presentation.Presentation
+	presentationClock  uint32
 	serial             uint32
 
 	//display_fd        int32
@@ -106,7 +109,6 @@ type Display struct {
 
 	running bool
 
-	globalList []*global
 	//	pad9		uint64
 	//	pada		uint64
 	windowList [2]*Window
@@ -115,7 +117,7 @@ type Display struct {
 	inputList []*Input
 	//	padd		uint64
 	//	pade		uint64
-	outputList [2]*output
+	outputList []*output
 	//	padf		uint64
 	//	padg		uint64
 
@@ -185,6 +187,11 @@ type surface struct {
 	bufferScale     int32
 
 	cairoSurface cairo.Surface
+
+	viewport            *viewporter.Viewport
+	viewportDestination Rectangle
+	// the logical size the buffers at a fractional scale are presented at
+	scaledDestination Rectangle
 }
 
 func (s *surface) HandleCallbackDone(ev wl.CallbackDoneEvent) {
@@ -246,6 +253,19 @@ type Window struct {
 
 	fullscreen bool
 	maximized  bool
+	suspended  bool
+	tiled      TiledEdge
+
+	// the features the compositor offers, all of them unless it says otherwise
+	wmCapabilities WmCapability
+	// the size recommended for the window geometry, zero when unknown
+	bounds Rectangle
+
+	fractionalScale *fractionalscale.FractionalScaleV1
+	// the scale of the buffers in 120ths, zero without fractional scaling
+	preferredScale uint32
+
+	presentedHandler func(PresentationInfo)
 
 	preferredFormat int
 
@@ -327,8 +347,12 @@ func (Window *Window) ToplevelConfigure(
 	height int32,
 	states []int32,
 ) {
+	var suspended = Window.suspended
+
 	Window.maximized = false
 	Window.fullscreen = false
+	Window.suspended = false
+	Window.tiled = 0
 	Window.resizing = 0
 	Window.focused = 0
 
@@ -342,11 +366,26 @@ func (Window *Window) ToplevelConfigure(
 			Window.resizing = 1
 		case zxdg.ToplevelStateActivated:
 			Window.focused = 1
+		case zxdg.ToplevelStateTiledLeft:
+			Window.tiled |= TiledEdgeLeft
+		case zxdg.ToplevelStateTiledRight:
+			Window.tiled |= TiledEdgeRight
+		case zxdg.ToplevelStateTiledTop:
+			Window.tiled |= TiledEdgeTop
+		case zxdg.ToplevelStateTiledBottom:
+			Window.tiled |= TiledEdgeBottom
+		case zxdg.ToplevelStateSuspended:
+			Window.suspended = true
 		default:
 			/* Unknown state */
 		}
 	}
 
+	// the frames skipped while suspended are redrawn at once
+	if suspended && !Window.suspended {
+		Window.redrawNeeded = 1
+	}
+
 	// better have this to be sure
 	if (width < 0) || (height < 0) {
 		return
@@ -365,6 +404,62 @@ func (Window *Window) ToplevelConfigure(
 	}
 }
 
+func (Window *Window) HandleToplevelConfigureBounds(ev zxdg.ToplevelConfigureBoundsEvent) {
+	Window.bounds.Width = ev.Width
+	Window.bounds.Height = ev.Height
+}
+
+func (Window *Window) HandleToplevelWmCapabilities(ev zxdg.ToplevelWmCapabilitiesEvent) {
+	Window.wmCapabilities = 0
+	for _, c := range ev.Capabilities {
+		switch c {
+		case zxdg.ToplevelWmCapabilitiesWindowMenu:
+			Window.wmCapabilities |= WmCapabilityWindowMenu
+		case zxdg.ToplevelWmCapabilitiesMaximize:
+			Window.wmCapabilities |= WmCapabilityMaximize
+		case zxdg.ToplevelWmCapabilitiesFullscreen:
+			Window.wmCapabilities |= WmCapabilityFullscreen
+		case zxdg.ToplevelWmCapabilitiesMinimize:
+			Window.wmCapabilities |= WmCapabilityMinimize
+		}
+	}
+	if Window.frame != nil {
+		Window.frame.frame.setButtons(Window.wmCapabilities)
+		Window.frame.widget.ScheduleRedraw()
+	}
+}
+
+// WmCapabilities returns the window management features offered by the compositor, a window
+// decoration should hide the buttons of the missing ones
+func (Window *Window) WmCapabilities() WmCapability {
+	return Window.wmCapabilities
+}
+
+// FrameButtons returns the buttons of the frame, without those the compositor does not offer
+func (Window *Window) FrameButtons() uint32 {
+	if Window.frame == nil {
+		return FrameButtonNone
+	}
+	return Window.frame.frame.buttons
+}
+
+// Suspended reports whether the compositor does not show the window, for example when it is
+// occluded, the window is not redrawn until it is shown again
+func (Window *Window) Suspended() bool {
+	return Window.suspended
+}
+
+// TiledEdges returns the edges of the window adjacent to other windows of a tiling layout
+func (Window *Window) TiledEdges() TiledEdge {
+	return Window.tiled
+}
+
+// Bounds returns the largest size recommended for the window, such as the size of the output
+// without the panels, zero when unknown
+func (Window *Window) Bounds() (width int32, height int32) {
+	return Window.bounds.Width, Window.bounds.Height
+}
+
 func (Window *Window) HandleToplevelClose(ev zxdg.ToplevelCloseEvent) {
 	Window.ToplevelClose(Window.xdgToplevel)
 }
@@ -431,10 +526,14 @@ func (l *widgetList) Insert(w *Widget) {
 }
 
 type WidgetHandler interface {
+	// Resize gives the logical size of the widget, and pwidth and pheight, the size in pixels
+	// of its buffer at the preferred scale of the window
 	Resize(Widget *Widget, width int32, height int32, pwidth int32, pheight int32)
 	Redraw(Widget *Widget)
 	Enter(Widget *Widget, Input *Input, x float32, y float32)
 	Leave(Widget *Widget, Input *Input)
+	// Motion gives the pointer position in the pixels of the buffer, the position on the surface
+	// times the preferred scale of the window
 	Motion(Widget *Widget, Input *Input, time uint32, x float32, y float32) int
 	Button(
 		Widget *Widget,
@@ -525,6 +624,11 @@ type Input struct {
 	selectionOffer *dataOffer
 	dragOffer      *dataOffer
 	offerData      map[*wl.DataOffer]*dataOffer
+
+	// the high-resolution scroll not yet passed on as a whole step, per axis
+	axisValue120 [2]int32
+	// the physical direction of the scroll, per axis
+	axisRelativeDirection [2]uint32
 }
 
 func (input *Input) HandleCallbackDone(ev wl.CallbackDoneEvent) {
@@ -707,9 +811,15 @@ func (input *Input) Destroy() {
 	input.touch = nil
 	input.pointer = nil
 	input.keyboard = nil
-	input.pointerSurface.Destroy()
+	if input.pointerSurface != nil {
+		input.pointerSurface.Destroy()
+	}
 
-	wlclient.SeatDestroy(input.seat)
+	if input.seatVersion >= wl.SeatReleaseSinceVersion {
+		_ = input.seat.Release()
+	} else {
+		wlclient.SeatDestroy(input.seat)
+	}
 
 }
 
@@ -750,6 +860,8 @@ type output struct {
 	scale          int32
 	maker          string
 	model          string
+	name           string
+	description    string
 }
 
 type shmPool struct {
@@ -1010,6 +1122,43 @@ func (input *Input) PointerAxisDiscrete(wlPointer *wl.Pointer, axis uint32, disc
 	}
 }
 
+func (input *Input) HandlePointerAxisValue120(ev wl.PointerAxisValue120Event) {
+	input.PointerAxisValue120(nil, ev.Axis, ev.Value120)
+}
+
+// PointerAxisValue120 accumulates the high-resolution scroll, which replaces axis_discrete since
+// wl_pointer version 8, and passes every whole step on as AxisDiscrete
+func (input *Input) PointerAxisValue120(wlPointer *wl.Pointer, axis uint32, value120 int32) {
+	if axis >= uint32(len(input.axisValue120)) {
+		return
+	}
+	var acc = &input.axisValue120[axis]
+	// a change of direction drops the partial step
+	if (*acc < 0) != (value120 < 0) {
+		*acc = 0
+	}
+	*acc += value120
+	if steps := *acc / 120; steps != 0 {
+		*acc -= steps * 120
+		input.PointerAxisDiscrete(wlPointer, axis, steps)
+	}
+}
+
+func (input *Input) HandlePointerAxisRelativeDirection(ev wl.PointerAxisRelativeDirectionEvent) {
+	if ev.Axis < uint32(len(input.axisRelativeDirection)) {
+		input.axisRelativeDirection[ev.Axis] = ev.Direction
+	}
+}
+
+// AxisRelativeDirection returns the physical direction of the scroll on the axis relative to
+// the axis events, wl.PointerAxisRelativeDirectionInverted for natural scrolling
+func (input *Input) AxisRelativeDirection(axis uint32) uint32 {
+	if axis >= uint32(len(input.axisRelativeDirection)) {
+		return wl.PointerAxisRelativeDirectionIdentical
+	}
+	return input.axisRelativeDirection[axis]
+}
+
 type SeatHandler interface {
 	Capabilities(i *Input, seat *wl.Seat, caps uint32)
 	Name(i *Input, seat *wl.Seat, name string)
@@ -1033,6 +1182,8 @@ func (input *Input) seatCapabilitiesPointer(seat *wl.Seat, caps uint32) {
 		}
 		wlclient.PointerSetUserData(input.pointer, input)
 		wlclient.PointerAddListener(input.pointer, input)
+		input.pointer.AddAxisValue120Handler(input)
+		input.pointer.AddAxisRelativeDirectionHandler(input)
 
 	} else if ((caps & wl.SeatCapabilityPointer) == 0) && (nil != input.pointer) {
 		if input.seatVersion >= wl.PointerReleaseSinceVersion {
@@ -1772,10 +1923,25 @@ func shmSurfaceSwap(surface *shmSurface, bufferTransform uint32,
 		&serverAllocation.Width,
 		&serverAllocation.Height)
 
-	_ = surface.surface.Attach(leaf.data.buffer,
-		surface.dx, surface.dy)
-	_ = surface.surface.Damage(0, 0,
-		serverAllocation.Width, serverAllocation.Height)
+	// since wl_surface version 5 the attach offset is a protocol error, it is sent separately
+	if surface.surface.Version() >= wl.SurfaceOffsetSinceVersion {
+		if surface.dx != 0 || surface.dy != 0 {
+			_ = surface.surface.Offset(surface.dx, surface.dy)
+		}
+		_ = surface.surface.Attach(leaf.data.buffer, 0, 0)
+	} else {
+		_ = surface.surface.Attach(leaf.data.buffer,
+			surface.dx, surface.dy)
+	}
+	// buffer damage stays correct when a viewport scales the surface
+	if surface.surface.Version() >= wl.SurfaceDamageBufferSinceVersion {
+		_ = surface.surface.DamageBuffer(0, 0,
+			int32((*leaf.cairoSurface).ImageSurfaceGetWidth()),
+			int32((*leaf.cairoSurface).ImageSurfaceGetHeight()))
+	} else {
+		_ = surface.surface.Damage(0, 0,
+			serverAllocation.Width, serverAllocation.Height)
+	}
 	_ = surface.surface.Commit()
 
 	leaf.busy = 1
@@ -1910,9 +2076,30 @@ func surfaceFlush(surface *surface) {
 		surface.inputRegion = nil
 	}
 
+	if surface.Window.preferredScale != 0 && surface.viewportDestination.Width <= 0 &&
+		surface.scaledDestination != surface.allocation {
+		// the buffer at the fractional scale is presented at the logical size
+		if viewport, err := surfaceGetViewport(surface); err == nil {
+			_ = viewport.SetDestination(surface.allocation.Width, surface.allocation.Height)
+			surface.scaledDestination = surface.allocation
+		}
+	}
+
+	if surface == surface.Window.mainSurface {
+		windowRequestPresentationFeedback(surface.Window)
+	}
+
 	(*surface.toysurface).swap(uint32(surface.bufferTransform), surface.bufferScale,
 		&surface.serverAllocation)
 
+	if surface.viewportDestination.Width > 0 {
+		surface.serverAllocation.Width = surface.viewportDestination.Width
+		surface.serverAllocation.Height = surface.viewportDestination.Height
+	} else if surface.scaledDestination.Width > 0 {
+		surface.serverAllocation.Width = surface.scaledDestination.Width
+		surface.serverAllocation.Height = surface.scaledDestination.Height
+	}
+
 	surface.cairoSurface.Destroy()
 	surface.cairoSurface = nil
 }
@@ -1936,9 +2123,11 @@ func surfaceCreateSurface(surface *surface, flags uint32) {
 		surface.toysurface = &toy
 	}
 
+	width, height := windowBufferSize(surface.Window, allocation.Width, allocation.Height)
+
 	surface.cairoSurface = (*surface.toysurface).prepare(
 		0, 0,
-		allocation.Width, allocation.Height, flags,
+		width, height, flags,
 		uint32(surface.bufferTransform), surface.bufferScale)
 
 }
@@ -1978,6 +2167,10 @@ func surfaceDestroy(surface *surface) {
 		wlclient.SubsurfaceDestroy(surface.subsurface)
 	}
 
+	if surface.viewport != nil {
+		_ = surface.viewport.Destroy()
+	}
+
 	surface.surface_.Destroy()
 
 	if surface.toysurface != nil {
@@ -1989,6 +2182,9 @@ func surfaceDestroy(surface *surface) {
 //line 1577
 func (Window *Window) Destroy() {
 
+	if Window.fractionalScale != nil {
+		_ = Window.fractionalScale.Destroy()
+	}
 	if Window.xdgToplevel != nil {
 		Window.xdgToplevel.Destroy()
 	}
@@ -2096,52 +2292,75 @@ func (d *Display) ShellPing(shell *zxdg.WmBase, serial uint32) {
 	shell.Pong(serial)
 }
 
-func minU32(a, b uint32) uint32 {
-	if a < b {
-		return a
-	}
-	return b
-}
 func (d *Display) HandleRegistryGlobal(e wl.RegistryGlobalEvent) {
 	d.RegistryGlobal(d.registry, e.Name, e.Interface, e.Version)
 }
 func (d *Display) HandleRegistryGlobalRemove(e wl.RegistryGlobalRemoveEvent) {
 	d.RegistryGlobalRemove(d.registry, e.Name)
 }
+func (d *Display) HandleGlobalAdd(g wl.Global) {
+	d.RegistryGlobal(d.registry, g.Name, g.Interface, g.Version)
+}
+func (d *Display) HandleGlobalRemove(g wl.Global, proxies []wl.Proxy) {
+	for _, p := range proxies {
+		switch p := p.(type) {
+		case *wl.Seat:
+			displayRemoveInput(d, p)
+		case *wl.Output:
+			displayRemoveOutput(d, p)
+		}
+	}
+	d.RegistryGlobalRemove(d.registry, g.Name)
+}
 func (d *Display) RegistryGlobal(registry *wl.Registry, id uint32, iface string, version uint32) {
-	var global = &global{}
-
-	global.name = id
-	global.iface = iface
-	global.version = version
-
-	d.globalList = append(d.globalList, global)
+	var global = wl.Global{Name: id, Interface: iface, Version: version}
 
 	switch iface {
 
 	case "wl_compositor":
-		d.compositor = wlclient.RegistryBindCompositorInterface(d.registry, id, 3)
+		if p, err := d.globals.BindGlobal(global, 1, 6); err == nil {
+			d.compositor = p.(*wl.Compositor)
+		}
 
 	case "wl_output":
 
-		displayAddOutput(d, id)
+		displayAddOutput(d, global)
 		// TODO
 	case "wl_seat":
 
-		displayAddInput(d, id, int(version))
+		displayAddInput(d, global)
 
 	case "wl_shm":
-		d.shm = wlclient.RegistryBindShmInterface(d.registry, id, 1)
-		wlclient.ShmAddListener(d.shm, d)
+		if p, err := d.globals.BindGlobal(global, 1, 2); err == nil {
+			d.shm = p.(*wl.Shm)
+			wlclient.ShmAddListener(d.shm, d)
+		}
 	case "wl_data_device_manager":
-		displayAddDataDevice(d, id, version)
+		displayAddDataDevice(d, global)
 
 	//case "zxdg_shell_v6":
 	case "xdg_wm_base":
 
-		d.xdgShell = wlclient.RegistryBindWmBaseInterface(d.registry, id, 1)
+		if p, err := d.globals.BindGlobal(global, 1, 6); err == nil {
+			d.xdgShell = p.(*zxdg.WmBase)
+			zxdg.WmBaseAddListener(d.xdgShell, d)
+		}
+
+	case "wp_viewporter":
+		if p, err := d.globals.BindGlobal(global, 1, 1); err == nil {
+			d.viewporter = p.(*viewporter.Viewporter)
+		}
 
-		zxdg.WmBaseAddListener(d.xdgShell, d)
+	case "wp_fractional_scale_manager_v1":
+		if p, err := d.globals.BindGlobal(global, 1, 1); err == nil {
+			d.fractionalScale = p.(*fractionalscale.FractionalScaleManagerV1)
+		}
+
+	case "wp_presentation":
+		if p, err := d.globals.BindGlobal(global, 1, 1); err == nil {
+			d.presentation = p.(*presentation.Presentation)
+			d.presentation.AddClockIdHandler(d)
+		}
 
 	case "text_cursor_position":
 	case "wl_subcompositor":
@@ -2167,8 +2386,8 @@ func (d *Display) SetGlobalHandler(gh GlobalHandler) {
 	if gh == nil {
 		return
 	}
-	for _, v := range d.globalList {
-		d.globalHandler.HandleGlobal(d, v.name, v.iface, v.version, d.userData)
+	for _, v := range d.globals.Globals() {
+		d.globalHandler.HandleGlobal(d, v.Name, v.Interface, v.Version, d.userData)
 	}
 }
 
@@ -2215,6 +2434,65 @@ func widgetGetCairoSurface(Widget *Widget) cairo.Surface {
 	return surface.cairoSurface
 }
 
+func surfaceGetViewport(surface *surface) (*viewporter.Viewport, error) {
+	if surface.viewport != nil {
+		return surface.viewport, nil
+	}
+	if surface.Window.Display.viewporter == nil {
+		return nil, errors.New("no_viewporter")
+	}
+	viewport, err := surface.Window.Display.viewporter.GetViewport(surface.surface_)
+	if err != nil {
+		return nil, err
+	}
+	surface.viewport = viewport
+	return viewport, nil
+}
+
+func surfaceSetViewportSource(surface *surface, x, y, width, height float32) error {
+	viewport, err := surfaceGetViewport(surface)
+	if err != nil {
+		return err
+	}
+	if err := viewport.SetSource(x, y, width, height); err != nil {
+		return err
+	}
+	surfaceScheduleRedraw(surface)
+	return nil
+}
+
+func surfaceSetViewportDestination(surface *surface, width, height int32) error {
+	viewport, err := surfaceGetViewport(surface)
+	if err != nil {
+		return err
+	}
+	if err := viewport.SetDestination(width, height); err != nil {
+		return err
+	}
+	surface.viewportDestination.Width = width
+	surface.viewportDestination.Height = height
+	surfaceScheduleRedraw(surface)
+	return nil
+}
+
+// surfaceScheduleRedraw redraws the surface, so that its pending viewport state is committed
+func surfaceScheduleRedraw(surface *surface) {
+	surface.redrawNeeded = 1
+	windowScheduleRedrawTask(surface.Window)
+}
+
+// SetViewportSource crops the surface of the widget to the rectangle of its buffer,
+// -1 for all the values uncrops it, applied on the redraw it schedules
+func (Widget *Widget) SetViewportSource(x, y, width, height float32) error {
+	return surfaceSetViewportSource(Widget.surface, x, y, width, height)
+}
+
+// SetViewportDestination makes the compositor scale the surface of the widget to width and height,
+// -1 for both unscales it, applied on the redraw it schedules
+func (Widget *Widget) SetViewportDestination(width, height int32) error {
+	return surfaceSetViewportDestination(Widget.surface, width, height)
+}
+
 // line 1887
 func (parent *Widget) WidgetGetLastTime() uint32 {
 	return parent.surface.lastTime
@@ -2250,6 +2528,7 @@ func (Window *Window) FrameCreate(data WidgetHandler) *Widget {
 		frame = nil
 		return nil
 	}
+	frame.frame.setButtons(Window.wmCapabilities)
 
 	frame.widget = Window.AddWidget(frame)
 	frame.child = frame.widget.AddWidget(data)
@@ -2350,8 +2629,10 @@ func pointerHandleMotion(data *Input, pointer *wl.Pointer,
 	}
 	if Widget != nil {
 		if Widget.Userdata != nil {
+			// the widgets lay out in the pixels of their buffers
+			x, y := windowBufferPosition(Widget.Window, sx, sy)
 			cursor = Widget.Userdata.Motion(Input.focusWidget,
-				Input, time, sx, sy)
+				Input, time, x, y)
 		} else {
 			cursor = int(Widget.defaultCursor)
 		}
@@ -2372,6 +2653,11 @@ func (window *Window) SetFullscreen(fullscreen bool) error {
 		return errors.New("bad typ")
 	}
 
+	// leaving fullscreen is always allowed
+	if fullscreen && window.wmCapabilities&WmCapabilityFullscreen == 0 {
+		return errors.New("not_supported")
+	}
+
 	if fullscreen {
 		window.typ = TYPE_FULLSCREEN
 		return window.xdgToplevel.SetFullscreen(nil)
@@ -2626,11 +2912,14 @@ func surfaceResize(surface *surface) {
 	var Widget = surface.Widget
 
 	if Widget.Userdata != nil {
+		pwidth, pheight := windowBufferSize(Widget.Window,
+			Widget.allocation.Width, Widget.allocation.Height)
+
 		Widget.Userdata.Resize(Widget,
 			Widget.allocation.Width,
 			Widget.allocation.Height,
-			Widget.Window.pendingAllocation.Width,
-			Widget.Window.pendingAllocation.Height)
+			pwidth,
+			pheight)
 	}
 
 	if (surface.allocation.Width != Widget.allocation.Width) ||
@@ -2858,7 +3147,7 @@ func (Window *Window) Run(events uint32) {
 //line 4619
 
 func windowScheduleRedrawTask(Window *Window) {
-	if Window.redrawInhibited != 0 {
+	if Window.redrawInhibited != 0 || Window.suspended {
 		return
 	}
 
@@ -2900,6 +3189,11 @@ func windowSetMaximized(window *Window, maximized bool) error {
 		return errors.New("already_set")
 	}
 
+	// unmaximizing is always allowed
+	if maximized && window.wmCapabilities&WmCapabilityMaximize == 0 {
+		return errors.New("not_supported")
+	}
+
 	if maximized {
 		window.savedAllocation = window.mainSurface.allocation
 		return window.xdgToplevel.SetMaximized()
@@ -2913,6 +3207,10 @@ func windowSetMinimized(window *Window) error {
 		return errors.New("no_toplevel")
 	}
 
+	if window.wmCapabilities&WmCapabilityMinimize == 0 {
+		return errors.New("not_supported")
+	}
+
 	return window.xdgToplevel.SetMinimized()
 }
 
@@ -2932,7 +3230,6 @@ func surfaceCreate(Window *Window) *surface {
 	surf, err := Display.compositor.CreateSurface()
 	if err != nil {
 		panic(err.Error())
-		return nil
 	}
 	surface.surface_ = surf
 
@@ -2961,9 +3258,18 @@ func windowCreateInternal(Display *Display, custom int) *Window {
 	}
 	Window.custom = (int32)(custom)
 	Window.preferredFormat = PreferredFormatNone
+	Window.wmCapabilities = WmCapabilityAll
 
 	surface_.bufferType = BufferTypeShm
 
+	// fractional scales need a viewport to present the buffers at the logical size
+	if Display.fractionalScale != nil && Display.viewporter != nil {
+		if fs, err := Display.fractionalScale.GetFractionalScale(surface_.surface_); err == nil {
+			Window.fractionalScale = fs
+			fs.AddPreferredScaleHandler(Window)
+		}
+	}
+
 	wlclient.SurfaceSetUserData(surface_.surface_, Window)
 	Display.surface2window[surface_.surface_] = Window
 
@@ -2994,6 +3300,8 @@ func Create(Display *Display) *Window {
 		}
 
 		zxdg.ToplevelAddListener(Window.xdgToplevel, Window)
+		Window.xdgToplevel.AddConfigureBoundsHandler(Window)
+		Window.xdgToplevel.AddWmCapabilitiesHandler(Window)
 
 		Window.InhibitRedraw()
 
@@ -3008,6 +3316,111 @@ func (Window *Window) SetBufferType(t int32) {
 	Window.mainSurface.bufferType = t
 }
 
+// SetViewportSource crops the main surface to the rectangle of its buffer, see Widget.SetViewportSource
+func (Window *Window) SetViewportSource(x, y, width, height float32) error {
+	return surfaceSetViewportSource(Window.mainSurface, x, y, width, height)
+}
+
+// SetViewportDestination scales the main surface to width and height, see Widget.SetViewportDestination
+func (Window *Window) SetViewportDestination(width, height int32) error {
+	return surfaceSetViewportDestination(Window.mainSurface, width, height)
+}
+
+// windowBufferSize returns the size in pixels of a buffer of the logical size at the preferred scale
+// of the window, rounded half away from zero
+func windowBufferSize(Window *Window, width, height int32) (int32, int32) {
+	if Window.preferredScale == 0 {
+		return width, height
+	}
+	var scale = int32(Window.preferredScale)
+	return (width*scale + 60) / 120, (height*scale + 60) / 120
+}
+
+// windowBufferPosition converts a position on the surface to the pixels of the buffers rendered
+// at the preferred scale of the window
+func windowBufferPosition(Window *Window, x, y float32) (float32, float32) {
+	var scale = float32(Window.PreferredScale())
+	return x * scale, y * scale
+}
+
+func (Window *Window) HandleFractionalScaleV1PreferredScale(ev fractionalscale.FractionalScaleV1PreferredScaleEvent) {
+	if ev.Scale == 0 || ev.Scale == Window.preferredScale {
+		return
+	}
+	Window.preferredScale = ev.Scale
+
+	// render the buffers again at the new scale
+	if Window.mainSurface.allocation.Width > 0 {
+		Window.ScheduleResize(Window.mainSurface.allocation.Width, Window.mainSurface.allocation.Height)
+	}
+}
+
+// PreferredScale returns the scale the buffers of the window are rendered at, 1 without fractional scaling
+func (Window *Window) PreferredScale() float64 {
+	if Window.preferredScale == 0 {
+		return 1
+	}
+	return float64(Window.preferredScale) / 120
+}
+
+func (d *Display) HandlePresentationClockId(ev presentation.PresentationClockIdEvent) {
+	d.presentationClock = ev.ClkId
+}
+
+// OnPresented calls the handler with the feedback of the compositor on every frame of the window,
+// nil stops it. The handler is not called when the compositor has no wp_presentation.
+func (Window *Window) OnPresented(handler func(PresentationInfo)) {
+	Window.presentedHandler = handler
+}
+
+// presentationFeedback delivers the feedback on a frame to the handler of the window
+type presentationFeedback struct {
+	window   *Window
+	feedback *presentation.PresentationFeedback
+}
+
+func windowRequestPresentationFeedback(Window *Window) {
+	if Window.presentedHandler == nil || Window.Display.presentation == nil {
+		return
+	}
+	feedback, err := Window.Display.presentation.Feedback(Window.mainSurface.surface_)
+	if err != nil {
+		return
+	}
+	var pf = &presentationFeedback{window: Window, feedback: feedback}
+	feedback.AddPresentedHandler(pf)
+	feedback.AddDiscardedHandler(pf)
+}
+
+func (pf *presentationFeedback) HandlePresentationFeedbackPresented(ev presentation.PresentationFeedbackPresentedEvent) {
+	pf.feedback.Unregister()
+
+	var sec = uint64(ev.TvSecHi)<<32 | uint64(ev.TvSecLo)
+	var info = PresentationInfo{
+		Time:     time.Duration(sec)*time.Second + time.Duration(ev.TvNsec),
+		Refresh:  time.Duration(ev.Refresh),
+		Sequence: uint64(ev.SeqHi)<<32 | uint64(ev.SeqLo),
+		Flags:    PresentationFlag(ev.Flags),
+		ClockID:  pf.window.Display.presentationClock,
+	}
+	if pf.window.presentedHandler != nil {
+		pf.window.presentedHandler(info)
+	}
+}
+
+func (pf *presentationFeedback) HandlePresentationFeedbackDiscarded(ev presentation.PresentationFeedbackDiscardedEvent) {
+	pf.feedback.Unregister()
+
+	if pf.window.presentedHandler != nil {
+		pf.window.presentedHandler(PresentationInfo{Discarded: true})
+	}
+}
+
+// HasViewporter reports whether the compositor can crop and scale the surfaces
+func (d *Display) HasViewporter() bool {
+	return d.viewporter != nil
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -3028,6 +3441,12 @@ func (o *output) HandleOutputMode(ev wl.OutputModeEvent) {
 func (o *output) HandleOutputScale(ev wl.OutputScaleEvent) {
 	o.OutputScale(o.output, ev.Factor)
 }
+func (o *output) HandleOutputName(ev wl.OutputNameEvent) {
+	o.name = ev.Name
+}
+func (o *output) HandleOutputDescription(ev wl.OutputDescriptionEvent) {
+	o.description = ev.Description
+}
 
 func (o *output) OutputGeometry(wlOutput *wl.Output, x int, y int, physicalWidth int,
 	physicalHeight int, subpixel int, maker string, model string, transform int) {
@@ -3054,36 +3473,67 @@ func (o *output) OutputMode(
 }
 
 // line 5771
-func displayAddOutput(d *Display, id uint32) {
+func displayAddOutput(d *Display, global wl.Global) {
 
 	var output = &output{}
 
 	output.Display = d
 	output.scale = 1
-	output.output = wlclient.RegistryBindOutputInterface(d.registry, id, 2)
+	p, err := d.globals.BindGlobal(global, 1, 4)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	output.output = p.(*wl.Output)
 
-	output.serverOutputId = id
+	output.serverOutputId = global.Name
 
 	wlclient.OutputAddListener(output.output, output)
+	output.output.AddNameHandler(output)
+	output.output.AddDescriptionHandler(output)
+
+	d.outputList = append(d.outputList, output)
+}
+
+// displayRemoveOutput releases the output bound to a removed wl_output global
+func displayRemoveOutput(d *Display, o *wl.Output) {
+	for i, output := range d.outputList {
+		if output.output != o {
+			continue
+		}
+		d.outputList = append(d.outputList[:i:i], d.outputList[i+1:]...)
+		output.Destroy()
+		return
+	}
+}
 
+func (o *output) Destroy() {
+	if o.output.Version() >= wl.OutputReleaseSinceVersion {
+		_ = o.output.Release()
+	} else {
+		wlclient.OutputDestroy(o.output)
+	}
 }
 
 //line 5925
-func displayAddInput(d *Display, id uint32, displaySeatVersion int) {
+func displayAddInput(d *Display, global wl.Global) {
 
 	var input_ *Input
-	var seatVersion = min(displaySeatVersion, 7)
 
-	_ = seatVersion
+	p, err := d.globals.BindGlobal(global, 1, 9)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
 
 	input_ = new(Input)
 
 	input_.Display = d
-	input_.seat = wlclient.RegistryBindSeatInterface(d.registry, id, uint32(seatVersion))
+	input_.seat = p.(*wl.Seat)
 	input_.touchFocus = 0
 	input_.pointerFocus = nil
 	input_.keyboardFocus = nil
-	input_.seatVersion = int32(seatVersion)
+	input_.seatVersion = int32(input_.seat.Version())
 
 	d.inputList = append(d.inputList, input_)
 
@@ -3109,11 +3559,26 @@ func displayAddInput(d *Display, id uint32, displaySeatVersion int) {
 	}
 }
 
-func displayAddDataDevice(d *Display, id uint32, ddmVersion uint32) {
-	d.dataDeviceManagerVersion = minU32(ddmVersion, 3)
+// displayRemoveInput releases the input of a removed wl_seat global
+func displayRemoveInput(d *Display, seat *wl.Seat) {
+	for i, input := range d.inputList {
+		if input.seat != seat {
+			continue
+		}
+		d.inputList = append(d.inputList[:i:i], d.inputList[i+1:]...)
+		input.Destroy()
+		return
+	}
+}
 
-	d.dataDeviceManager = wlclient.RegistryBindDataDeviceManagerInterface(d.registry, id,
-		d.dataDeviceManagerVersion)
+func displayAddDataDevice(d *Display, global wl.Global) {
+	p, err := d.globals.BindGlobal(global, 1, 3)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	d.dataDeviceManager = p.(*wl.DataDeviceManager)
+	d.dataDeviceManagerVersion = d.dataDeviceManager.Version()
 
 	for _, input := range d.inputList {
 		if input.dataDevice == nil {
@@ -3133,13 +3598,20 @@ func displayAddDataDevice(d *Display, id uint32, ddmVersion uint32) {
 
 // line 6237
 func DisplayCreate(argv []string) (d *Display, e error) {
-
-	d = &Display{}
-
-	d.Display, e = wlclient.DisplayConnect(nil)
+	display, e := wlclient.DisplayConnect(nil)
 	if e != nil {
 		return nil, fmt.Errorf("failed to connect to Wayland Display: %w", e)
 	}
+	return DisplayCreateFrom(display)
+}
+
+// DisplayCreateFrom creates the Display on an already connected wl.Display, such as one
+// served by a wltest.Server
+func DisplayCreateFrom(display *wl.Display) (d *Display, e error) {
+
+	d = &Display{}
+
+	d.Display = display
 
 	d.xkbContext = xkb.ContextNew(xkb.ContextNoFlags)
 	if d.xkbContext == nil {
@@ -3159,7 +3631,9 @@ func DisplayCreate(argv []string) (d *Display, e error) {
 	if e != nil {
 		return nil, fmt.Errorf("failed to get Registry: %w", e)
 	}
-	wlclient.RegistryAddListener(d.registry, d)
+	d.globals = wl.NewGlobalTracker(d.registry)
+	d.globals.AddGlobalAddHandler(d)
+	d.globals.AddGlobalRemoveHandler(d)
 
 	if wlclient.DisplayRoundtrip(d.Display) != nil {
 		return nil, errors.New("failed to process Wayland connection")
@@ -3191,8 +3665,24 @@ func (d *Display) Destroy() {
 		d.xdgShell.Destroy()
 	}
 
+	if d.viewporter != nil {
+		_ = d.viewporter.Destroy()
+	}
+
+	if d.fractionalScale != nil {
+		_ = d.fractionalScale.Destroy()
+	}
+
+	if d.presentation != nil {
+		_ = d.presentation.Destroy()
+	}
+
 	if d.shm != nil {
-		wlclient.ShmDestroy(d.shm)
+		if d.shm.Version() >= wl.ShmReleaseSinceVersion {
+			_ = d.shm.Release()
+		} else {
+			wlclient.ShmDestroy(d.shm)
+		}
 	}
 
 	if d.dataDeviceManager != nil {
@@ -3242,6 +3732,11 @@ func DisplayRun(Display *Display) {
 			break
 		}
 
+		if err := wlclient.DisplayFlush(Display.Display); err != nil {
+			fmt.Println(err)
+			return
+		}
+
 		if err := wlclient.DisplayRun(Display.Display); err != nil {
 			fmt.Println(err)
 			return
@@ -0,0 +1,350 @@
+package window
+
+import (
+	"testing"
+
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wlclient"
+	"github.com/neurlang/wayland/wltest"
+	zxdg "github.com/neurlang/wayland/xdg"
+)
+
+// newTestDisplay creates a Display connected to a fake compositor advertising the globals
+// a toplevel window needs, and the extra globals at version 1
+func newTestDisplay(t *testing.T, extra ...string) (*Display, *wltest.Server) {
+	display, srv, err := wltest.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.AddGlobal("wl_compositor", 4)
+	srv.AddGlobal("wl_shm", 1)
+	srv.AddGlobal("xdg_wm_base", 5)
+	for _, iface := range extra {
+		srv.AddGlobal(iface, 1)
+	}
+
+	d, err := DisplayCreateFrom(display)
+	if err != nil {
+		display.Context().Close()
+		srv.Close()
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		d.Destroy()
+		srv.Close()
+	})
+	return d, srv
+}
+
+// roundtrip waits until the fake compositor processed the requests sent
+func roundtrip(t *testing.T, d *Display, srv *wltest.Server) {
+	if err := wlclient.DisplayFlush(d.Display); err != nil {
+		t.Fatal(err)
+	}
+	if err := wlclient.DisplayRoundtrip(d.Display); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// requestNames returns the names of the requests sent to the interface, in order
+func requestNames(srv *wltest.Server, iface string) (names []string) {
+	for _, r := range srv.RequestsTo(iface) {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
+func TestDisplayCreateFromBindsGlobals(t *testing.T) {
+	d, srv := newTestDisplay(t)
+	roundtrip(t, d, srv)
+
+	var bound []string
+	for _, r := range srv.RequestsTo("wl_registry") {
+		ev := r.Event()
+		ev.Uint32()
+		bound = append(bound, ev.String())
+	}
+	want := []string{"wl_compositor", "wl_shm", "xdg_wm_base"}
+	if len(bound) != len(want) {
+		t.Fatalf("bound %v, want %v", bound, want)
+	}
+	for i := range want {
+		if bound[i] != want[i] {
+			t.Fatalf("bound %v, want %v", bound, want)
+		}
+	}
+	if d.compositor == nil || d.shm == nil || d.xdgShell == nil {
+		t.Fatal("globals not bound")
+	}
+}
+
+// TestDisplayGlobalRemove checks the seats and outputs unplugged at runtime are released and
+// forgotten by the Display
+func TestDisplayGlobalRemove(t *testing.T) {
+	d, srv := newTestDisplay(t)
+	seat := srv.AddGlobal("wl_seat", 5)
+	output := srv.AddGlobal("wl_output", 3)
+	old := srv.AddGlobal("wl_output", 2)
+	roundtrip(t, d, srv)
+	if len(d.inputList) != 1 || len(d.outputList) != 2 {
+		t.Fatalf("%d inputs and %d outputs, want 1 and 2", len(d.inputList), len(d.outputList))
+	}
+
+	srv.RemoveGlobal(seat)
+	srv.RemoveGlobal(output)
+	srv.RemoveGlobal(old)
+	roundtrip(t, d, srv)
+	if got := requestNames(srv, "wl_seat"); len(got) != 1 || got[0] != "release" {
+		t.Errorf("wl_seat requests %v, want [release]", got)
+	}
+	// wl_output.release is new in version 3
+	if got := requestNames(srv, "wl_output"); len(got) != 1 || got[0] != "release" {
+		t.Errorf("wl_output requests %v, want [release]", got)
+	}
+	if len(d.inputList) != 0 || len(d.outputList) != 0 {
+		t.Errorf("%d inputs and %d outputs left, want none", len(d.inputList), len(d.outputList))
+	}
+}
+
+func TestWindowSetTitle(t *testing.T) {
+	d, srv := newTestDisplay(t)
+	w := Create(d)
+	if w == nil {
+		t.Fatal("no window")
+	}
+	w.SetTitle("go-wayland")
+	roundtrip(t, d, srv)
+
+	got := requestNames(srv, "xdg_wm_base")
+	if len(got) != 1 || got[0] != "get_xdg_surface" {
+		t.Fatalf("xdg_wm_base requests %v, want [get_xdg_surface]", got)
+	}
+	titles := srv.RequestsTo("xdg_toplevel")
+	if len(titles) != 1 || titles[0].Name != "set_title" {
+		t.Fatalf("xdg_toplevel requests %v, want [set_title]", requestNames(srv, "xdg_toplevel"))
+	}
+	if title := titles[0].Event().String(); title != "go-wayland" {
+		t.Errorf("title %q, want %q", title, "go-wayland")
+	}
+	if id := titles[0].Pid; id != w.xdgToplevel.Id() {
+		t.Errorf("set_title on %d, want the toplevel %d", id, w.xdgToplevel.Id())
+	}
+}
+
+// TestWindowStateCapabilities checks the window states missing from the wm_capabilities of the
+// compositor can be left but not entered
+func TestWindowStateCapabilities(t *testing.T) {
+	d, srv := newTestDisplay(t)
+	w := Create(d)
+	if w == nil {
+		t.Fatal("no window")
+	}
+	roundtrip(t, d, srv)
+	toplevel := w.xdgToplevel.Id()
+
+	// xdg_toplevel.wm_capabilities
+	srv.SendEvent(toplevel, 3, []int32{zxdg.ToplevelWmCapabilitiesFullscreen})
+	roundtrip(t, d, srv)
+	if err := w.SetFullscreen(true); err != nil {
+		t.Fatalf("set fullscreen: %v", err)
+	}
+
+	// the compositor maximized the window, without offering to maximize or fullscreen
+	srv.SendEvent(toplevel, 3, []int32{})
+	srv.SendEvent(toplevel, 0, int32(0), int32(0), []int32{zxdg.ToplevelStateMaximized})
+	roundtrip(t, d, srv)
+	if err := w.SetMaximized(false); err != nil {
+		t.Errorf("unset maximized: %v", err)
+	}
+	if err := w.SetFullscreen(false); err != nil {
+		t.Errorf("unset fullscreen: %v", err)
+	}
+	if err := w.SetFullscreen(true); err == nil {
+		t.Error("set fullscreen succeeded without the capability")
+	}
+	roundtrip(t, d, srv)
+
+	got := requestNames(srv, "xdg_toplevel")
+	want := []string{"set_fullscreen", "unset_maximized", "unset_fullscreen"}
+	if len(got) != len(want) {
+		t.Fatalf("xdg_toplevel requests %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("xdg_toplevel requests %v, want %v", got, want)
+		}
+	}
+}
+
+// TestWindowSetViewport checks setting the viewport schedules the redraw committing it
+func TestWindowSetViewport(t *testing.T) {
+	d, srv := newTestDisplay(t, "wp_viewporter")
+	w := Create(d)
+	if w == nil {
+		t.Fatal("no window")
+	}
+	// xdg_surface.configure ends the inhibition of the redraws
+	srv.SendEvent(w.xdgSurface.Id(), 0, srv.Serial())
+	roundtrip(t, d, srv)
+	w.redrawTaskScheduled = 0
+	w.mainSurface.redrawNeeded = 0
+
+	if err := w.SetViewportSource(0, 0, 50, 25); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.SetViewportDestination(100, 50); err != nil {
+		t.Fatal(err)
+	}
+	roundtrip(t, d, srv)
+	if got := requestNames(srv, "wp_viewport"); len(got) != 2 || got[0] != "set_source" || got[1] != "set_destination" {
+		t.Errorf("wp_viewport requests %v, want [set_source set_destination]", got)
+	}
+	if w.redrawTaskScheduled == 0 || w.mainSurface.redrawNeeded == 0 {
+		t.Error("no redraw scheduled to commit the viewport")
+	}
+}
+
+func TestWindowBufferSize(t *testing.T) {
+	for _, test := range []struct {
+		scale         uint32
+		width, height int32
+		pwidth        int32
+		pheight       int32
+	}{
+		{0, 101, 51, 101, 51},
+		{120, 101, 51, 101, 51},
+		{150, 100, 50, 125, 63},
+		{150, 101, 51, 126, 64},
+		{180, 100, 50, 150, 75},
+		{180, 101, 51, 152, 77},
+		{180, 1, 1, 2, 2},
+		{150, 0, 0, 0, 0},
+	} {
+		w := &Window{preferredScale: test.scale}
+		pwidth, pheight := windowBufferSize(w, test.width, test.height)
+		if pwidth != test.pwidth || pheight != test.pheight {
+			t.Errorf("%dx%d at scale %d/120 is %dx%d, want %dx%d", test.width, test.height, test.scale,
+				pwidth, pheight, test.pwidth, test.pheight)
+		}
+	}
+}
+
+// resizeRecorder is a widget recording the sizes it is given and drawing the window
+type resizeRecorder struct {
+	WidgetHandler
+	window          *Window
+	width, height   int32
+	pwidth, pheight int32
+}
+
+func (r *resizeRecorder) Resize(widget *Widget, width int32, height int32, pwidth int32, pheight int32) {
+	r.width, r.height, r.pwidth, r.pheight = width, height, pwidth, pheight
+}
+
+func (r *resizeRecorder) Redraw(widget *Widget) {
+	if surface := r.window.WindowGetSurface(); surface != nil {
+		surface.Destroy()
+	}
+}
+
+// TestWindowPreferredScale checks a window rendered at the fractional scale preferred by the
+// compositor attaches larger buffers, presented at the logical size by the viewport
+func TestWindowPreferredScale(t *testing.T) {
+	d, srv := newTestDisplay(t, "wp_viewporter", "wp_fractional_scale_manager_v1")
+	w := Create(d)
+	if w == nil {
+		t.Fatal("no window")
+	}
+	if w.fractionalScale == nil {
+		t.Fatal("no fractional scale object")
+	}
+	r := &resizeRecorder{window: w}
+	w.AddWidget(r)
+	srv.SendEvent(w.xdgSurface.Id(), 0, srv.Serial())
+	// wp_fractional_scale_v1.preferred_scale of 1.5
+	srv.SendEvent(w.fractionalScale.Id(), 0, uint32(180))
+	roundtrip(t, d, srv)
+	if w.PreferredScale() != 1.5 {
+		t.Fatalf("preferred scale %v, want 1.5", w.PreferredScale())
+	}
+
+	w.ScheduleResize(100, 50)
+	w.Run(0)
+	roundtrip(t, d, srv)
+
+	if r.width != 100 || r.height != 50 || r.pwidth != 150 || r.pheight != 75 {
+		t.Errorf("resized to %dx%d with a %dx%d buffer, want 100x50 with a 150x75 buffer",
+			r.width, r.height, r.pwidth, r.pheight)
+	}
+	buffers := srv.RequestsTo("wl_shm_pool")
+	if len(buffers) == 0 || buffers[len(buffers)-1].Name != "create_buffer" {
+		t.Fatalf("wl_shm_pool requests %v, want create_buffer", requestNames(srv, "wl_shm_pool"))
+	}
+	ev := buffers[len(buffers)-1].Event()
+	ev.Uint32()
+	ev.Int32()
+	if width, height := ev.Int32(), ev.Int32(); width != 150 || height != 75 {
+		t.Errorf("buffer of %dx%d, want 150x75", width, height)
+	}
+	destinations := srv.RequestsTo("wp_viewport")
+	if len(destinations) != 1 || destinations[0].Name != "set_destination" {
+		t.Fatalf("wp_viewport requests %v, want [set_destination]", requestNames(srv, "wp_viewport"))
+	}
+	ev = destinations[0].Event()
+	if width, height := ev.Int32(), ev.Int32(); width != 100 || height != 50 {
+		t.Errorf("viewport destination %dx%d, want the logical 100x50", width, height)
+	}
+}
+
+// motionRecorder is a widget recording the pointer positions it is given
+type motionRecorder struct {
+	resizeRecorder
+	motions [][2]float32
+}
+
+func (r *motionRecorder) Motion(widget *Widget, input *Input, time uint32, x float32, y float32) int {
+	r.motions = append(r.motions, [2]float32{x, y})
+	return CursorLeftPtr
+}
+
+// TestWindowPointerBufferPosition checks the widgets of a window rendered at a fractional scale
+// get the pointer position in the pixels of the buffer
+func TestWindowPointerBufferPosition(t *testing.T) {
+	d, srv := newTestDisplay(t, "wp_viewporter", "wp_fractional_scale_manager_v1", "wl_seat")
+	w := Create(d)
+	if w == nil {
+		t.Fatal("no window")
+	}
+	r := &motionRecorder{resizeRecorder: resizeRecorder{window: w}}
+	w.AddWidget(r)
+	srv.SendEvent(w.xdgSurface.Id(), 0, srv.Serial())
+	// wp_fractional_scale_v1.preferred_scale of 1.5
+	srv.SendEvent(w.fractionalScale.Id(), 0, uint32(180))
+	roundtrip(t, d, srv)
+	seats := srv.Bound("wl_seat")
+	if len(seats) != 1 {
+		t.Fatalf("seats %v, want one", seats)
+	}
+	// wl_seat.capabilities of a pointer
+	srv.SendEvent(seats[0], 0, uint32(wl.SeatCapabilityPointer))
+	roundtrip(t, d, srv)
+	w.ScheduleResize(100, 50)
+	w.Run(0)
+	roundtrip(t, d, srv)
+
+	pointers := srv.Bound("wl_pointer")
+	if len(pointers) != 1 {
+		t.Fatalf("pointers %v, want one", pointers)
+	}
+	// wl_pointer.enter and wl_pointer.motion
+	srv.SendEvent(pointers[0], 0, srv.Serial(), w.mainSurface.surface_, float32(10), float32(20))
+	srv.SendEvent(pointers[0], 2, uint32(0), float32(10), float32(20))
+	roundtrip(t, d, srv)
+
+	if len(r.motions) != 1 || r.motions[0] != [2]float32{15, 30} {
+		t.Errorf("motions %v, want the buffer position 15,30", r.motions)
+	}
+}
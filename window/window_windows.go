@@ -1,6 +1,7 @@
 package window
 
 import (
+	"errors"
 	cairo "github.com/neurlang/wayland/cairoshim"
 	"github.com/neurlang/wayland/wl"
 	"github.com/tadvi/winc"
@@ -270,3 +271,42 @@ func (w *Window) ScheduleResize(width int32, height int32) {
 	}
 
 }
+
+// WmCapabilities returns the window management features, all of them on Windows
+func (w *Window) WmCapabilities() WmCapability {
+	return WmCapabilityAll
+}
+
+// Suspended reports whether the window is not shown, never on Windows
+func (w *Window) Suspended() bool {
+	return false
+}
+
+// TiledEdges returns the edges adjacent to other windows of a tiling layout, none on Windows
+func (w *Window) TiledEdges() TiledEdge {
+	return 0
+}
+
+// Bounds returns the largest size recommended for the window, unknown on Windows
+func (w *Window) Bounds() (width int32, height int32) {
+	return 0, 0
+}
+
+// SetViewportSource crops the window to the rectangle of its buffer, not supported on Windows
+func (w *Window) SetViewportSource(x, y, width, height float32) error {
+	return errors.New("no_viewporter")
+}
+
+// SetViewportDestination scales the window to width and height, not supported on Windows
+func (w *Window) SetViewportDestination(width, height int32) error {
+	return errors.New("no_viewporter")
+}
+
+// PreferredScale returns the scale the buffers of the window are rendered at, always 1 on Windows
+func (w *Window) PreferredScale() float64 {
+	return 1
+}
+
+// OnPresented calls the handler with the feedback on every frame of the window, never on Windows
+func (w *Window) OnPresented(handler func(PresentationInfo)) {
+}
@@ -0,0 +1,169 @@
+package wl
+
+import (
+	"io"
+	"io/ioutil"
+	"testing"
+)
+
+// drain discards the requests received by the compositor end of the connection
+func drain(server io.Reader) {
+	go io.Copy(ioutil.Discard, server)
+}
+
+// newSurface returns a surface and a buffer created as if the requests were sent
+func newSurface(ctx *Context) (*Surface, *Buffer) {
+	surface := NewSurface(ctx)
+	ctx.allocate(surface)
+	buffer := NewBuffer(ctx)
+	ctx.allocate(buffer)
+	return surface, buffer
+}
+
+// TestMotionAllocs checks the allocation budget of dispatching a pointer motion event, zero
+func TestMotionAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector drops the pooled events")
+	}
+	ctx, server := socketpair(t)
+	defer ctx.Close()
+	defer server.Close()
+
+	pointer := NewPointer(ctx)
+	// as if created by wl_seat.get_pointer
+	ctx.allocate(pointer)
+	var motions motionCounter
+	pointer.AddMotionHandler(&motions)
+
+	event := motionEvents(pointer.Id(), 1)
+	allocs := testing.AllocsPerRun(1000, func() {
+		if _, err := server.Write(event); err != nil {
+			t.Fatal(err)
+		}
+		if err := ctx.Run(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("%v allocations per motion event, want 0", allocs)
+	}
+}
+
+// TestRequestAllocs checks the allocation budget of the generated requests redrawing a surface, zero
+func TestRequestAllocs(t *testing.T) {
+	ctx, server := socketpair(t)
+	defer ctx.Close()
+	defer server.Close()
+	drain(server)
+
+	surface, buffer := newSurface(ctx)
+	allocs := testing.AllocsPerRun(1000, func() {
+		surface.Attach(buffer, 0, 0)
+		surface.DamageBuffer(0, 0, 64, 64)
+		surface.Commit()
+		if err := ctx.Flush(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("%v allocations per redraw, want 0", allocs)
+	}
+}
+
+// BenchmarkSurfaceCommit marshals and sends the requests redrawing a surface
+func BenchmarkSurfaceCommit(b *testing.B) {
+	ctx, server := socketpair(b)
+	defer ctx.Close()
+	defer server.Close()
+	drain(server)
+
+	surface, buffer := newSurface(ctx)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		surface.Attach(buffer, 0, 0)
+		surface.DamageBuffer(0, 0, 64, 64)
+		surface.Commit()
+		if err := ctx.Flush(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSetTitle marshals a request with a string argument, which is padded without allocating
+func BenchmarkSetTitle(b *testing.B) {
+	ctx, server := socketpair(b)
+	defer ctx.Close()
+	defer server.Close()
+	drain(server)
+
+	shell := NewShellSurface(ctx)
+	ctx.allocate(shell)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := shell.SetTitle("go-wayland"); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// globalCounter counts the wl_registry.global events
+type globalCounter struct {
+	n     int
+	iface string
+}
+
+func (g *globalCounter) HandleRegistryGlobal(ev RegistryGlobalEvent) {
+	g.n++
+	g.iface = ev.Interface
+}
+
+// TestStringAllocs checks the allocation budget of decoding the string arguments, zero for the names
+// of the registered interfaces and for StringBytes
+func TestStringAllocs(t *testing.T) {
+	if raceEnabled {
+		t.Skip("the race detector drops the pooled events")
+	}
+	ctx, server := socketpair(t)
+	defer ctx.Close()
+	defer server.Close()
+
+	registry := NewRegistry(ctx)
+	// as if created by wl_display.get_registry
+	ctx.allocate(registry)
+	var globals globalCounter
+	registry.AddGlobalHandler(&globals)
+
+	event := globalEvent(registry.Id(), 1, "wl_compositor")
+	allocs := testing.AllocsPerRun(1000, func() {
+		if _, err := server.Write(event); err != nil {
+			t.Fatal(err)
+		}
+		if err := ctx.Run(); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("%v allocations per global event, want 0", allocs)
+	}
+	if globals.iface != "wl_compositor" {
+		t.Errorf("global interface %q, want wl_compositor", globals.iface)
+	}
+
+	// the title of a window is not interned
+	ev := Event{Data: globalEvent(1, 0, "go-wayland")[12:]}
+	allocs = testing.AllocsPerRun(1000, func() {
+		ev.off = 0
+		if title := ev.StringBytes(); string(title) != "go-wayland" {
+			t.Fatalf("string %q, want go-wayland", title)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("%v allocations per StringBytes, want 0", allocs)
+	}
+	ev.off = 0
+	if title := ev.String(); title != "go-wayland" {
+		t.Errorf("string %q, want go-wayland", title)
+	}
+}
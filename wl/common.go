@@ -1,6 +1,8 @@
 // Package wl implements the stable Wayland protocol
 package wl
 
+//go:generate go run ../cmd/go-wayland-scanner -pkg wl -prefix wl_ -i wayland.xml -o wayland.xml.go -fuzz wayland.xml_fuzz_test.go
+
 // ProxyId is a Proxy identifier that is sent to compositor over the wayland socket
 type ProxyId uint32
 
@@ -15,6 +17,8 @@ type Proxy interface {
 	SetContext(c *Context)
 	Id() ProxyId
 	SetId(id ProxyId)
+	Version() uint32
+	SetVersion(version uint32)
 	//	Name() uint32
 	//	SetName(name uint32)
 	Unregister()
@@ -22,9 +26,11 @@ type Proxy interface {
 
 // BaseProxy (Base Proxy) is a struct that stores Context and ProxyId explicitly
 type BaseProxy struct {
-	id   ProxyId
-	ctx  *Context
-	name uint32
+	id      ProxyId
+	ctx     *Context
+	name    uint32
+	queue   *EventQueue
+	version uint32
 }
 
 // Id BaseProxy implements Id to get ProxyId
@@ -47,6 +53,39 @@ func (p *BaseProxy) SetContext(c *Context) {
 	p.ctx = c
 }
 
+// Version BaseProxy implements Version to get the version of the interface the proxy is bound with,
+// 0 means unknown
+func (p *BaseProxy) Version() uint32 {
+	return p.version
+}
+
+// SetVersion BaseProxy implements SetVersion to set the version of the interface the proxy is bound with
+func (p *BaseProxy) SetVersion(version uint32) {
+	p.version = version
+}
+
+// Queue BaseProxy returns the event queue of the proxy, nil means the default queue of the Context
+func (p *BaseProxy) Queue() *EventQueue {
+	if p.ctx != nil {
+		p.ctx.rmu.Lock()
+		defer p.ctx.rmu.Unlock()
+	}
+	return p.queue
+}
+
+// SetQueue BaseProxy assigns the proxy to an event queue, nil means the default queue of the Context
+func (p *BaseProxy) SetQueue(q *EventQueue) {
+	if p.ctx != nil {
+		p.ctx.rmu.Lock()
+		defer p.ctx.rmu.Unlock()
+	}
+	p.queue = q
+}
+
+func (p *BaseProxy) eventQueue() *EventQueue {
+	return p.queue
+}
+
 // BaseProxy implements Name
 //func (p *BaseProxy) Name() uint32 {
 //	return p.name
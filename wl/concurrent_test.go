@@ -0,0 +1,289 @@
+package wl_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wlclient"
+	"github.com/neurlang/wayland/wltest"
+	"golang.org/x/sys/unix"
+)
+
+type motionCount int64
+
+func (m *motionCount) HandlePointerMotion(wl.PointerMotionEvent) {
+	atomic.AddInt64((*int64)(m), 1)
+}
+
+// concurrentClient is a client of the mock compositor with the globals bound
+type concurrentClient struct {
+	display    *wl.Display
+	srv        *wltest.Server
+	compositor *wl.Compositor
+	shm        *wl.Shm
+	seat       *wl.Seat
+}
+
+func newConcurrentClient(t *testing.T) *concurrentClient {
+	display, srv, err := wltest.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		display.Context().Close()
+		srv.Close()
+	})
+	srv.AddGlobal("wl_compositor", 4)
+	srv.AddGlobal("wl_shm", 1)
+	srv.AddGlobal("wl_seat", 5)
+
+	registry, err := display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	globals := wl.NewGlobalTracker(registry)
+	c := &concurrentClient{display: display, srv: srv}
+	c.roundtrip(t)
+	p, err := globals.Bind("wl_compositor", 1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.compositor = p.(*wl.Compositor)
+	if p, err = globals.Bind("wl_shm", 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	c.shm = p.(*wl.Shm)
+	if p, err = globals.Bind("wl_seat", 1, 5); err != nil {
+		t.Fatal(err)
+	}
+	c.seat = p.(*wl.Seat)
+	return c
+}
+
+// roundtrip waits for the compositor to process the requests sent, a client confused about
+// its ids never receives the done event
+func (c *concurrentClient) roundtrip(t *testing.T) {
+	deadline, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := wlclient.DisplayRoundtripContext(deadline, c.display); err != nil {
+		t.Fatalf("roundtrip: %v, compositor error: %v", err, c.srv.Err())
+	}
+}
+
+// dispatch runs the Context on a new goroutine until the returned stop function is called,
+// which returns the error that stopped dispatching early
+func (c *concurrentClient) dispatch() (stop func() error) {
+	cancellable, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		for {
+			err := c.display.Context().RunContext(cancellable)
+			if cancellable.Err() != nil {
+				done <- nil
+				return
+			}
+			if err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+	return func() error {
+		cancel()
+		return <-done
+	}
+}
+
+// render draws a frame the way a render goroutine does, on a new surface and pool with the size
+// of the pool unique to the goroutine
+func (c *concurrentClient) render(size int) error {
+	surface, err := c.compositor.CreateSurface()
+	if err != nil {
+		return err
+	}
+	file, err := sys.CreateAnonymousFile(int64(size))
+	if err != nil {
+		return err
+	}
+	pool, err := c.shm.CreatePool(file.Fd(), int32(size))
+	// the fd is duplicated when queued
+	file.Close()
+	if err != nil {
+		return err
+	}
+	buffer, err := pool.CreateBuffer(0, 16, 16, 64, wl.ShmFormatArgb8888)
+	if err != nil {
+		return err
+	}
+	if err := surface.Attach(buffer, 0, 0); err != nil {
+		return err
+	}
+	if err := surface.Damage(0, 0, 16, 16); err != nil {
+		return err
+	}
+	if err := surface.Commit(); err != nil {
+		return err
+	}
+	if err := buffer.Destroy(); err != nil {
+		return err
+	}
+	if err := pool.Destroy(); err != nil {
+		return err
+	}
+	if err := surface.Destroy(); err != nil {
+		return err
+	}
+	return c.display.Context().Flush()
+}
+
+// TestConcurrentRequests commits surfaces from several render goroutines while the UI goroutine
+// dispatches the pointer motion flooded by the compositor. The compositor checks the new ids
+// are in order and every pool receives its own fd.
+func TestConcurrentRequests(t *testing.T) {
+	const (
+		renderers = 4
+		frames    = 50
+	)
+	c := newConcurrentClient(t)
+
+	var mismatched int64
+	c.srv.Handle("wl_shm", 0, func(s *wltest.Server, r wltest.Request) {
+		ev := r.Event()
+		ev.Uint32()
+		size := ev.Int32()
+		var st unix.Stat_t
+		if len(r.Fds) != 1 || unix.Fstat(int(r.Fds[0]), &st) != nil || st.Size != int64(size) {
+			atomic.AddInt64(&mismatched, 1)
+		}
+	})
+
+	pointer, err := c.seat.GetPointer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var motions motionCount
+	pointer.AddMotionHandler(&motions)
+	c.roundtrip(t)
+
+	// the input of the UI goroutine
+	stopInput := make(chan struct{})
+	var sent int64
+	var input sync.WaitGroup
+	input.Add(1)
+	go func() {
+		defer input.Done()
+		for {
+			select {
+			case <-stopInput:
+				return
+			default:
+			}
+			if c.srv.SendEvent(pointer.Id(), 2, uint32(0), float32(1), float32(2)) != nil {
+				return
+			}
+			atomic.AddInt64(&sent, 1)
+			// the compositor buffers the events, as fast as the client reads them
+			time.Sleep(10 * time.Microsecond)
+		}
+	}()
+	stopDispatch := c.dispatch()
+
+	var render sync.WaitGroup
+	errs := make(chan error, renderers)
+	for i := 0; i < renderers; i++ {
+		render.Add(1)
+		go func(size int) {
+			defer render.Done()
+			for j := 0; j < frames; j++ {
+				if err := c.render(size); err != nil {
+					errs <- fmt.Errorf("frame %d: %w", j, err)
+					return
+				}
+			}
+		}(4096 * (i + 1))
+	}
+	render.Wait()
+	close(stopInput)
+	input.Wait()
+	if err := stopDispatch(); err != nil {
+		t.Fatal(err)
+	}
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	c.roundtrip(t)
+
+	if err := c.srv.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(c.srv.RequestsTo("wl_surface")); n != 4*renderers*frames {
+		t.Errorf("%d wl_surface requests, want %d", n, 4*renderers*frames)
+	}
+	if n := len(c.srv.RequestsTo("wl_shm")); n != renderers*frames {
+		t.Errorf("%d wl_shm requests, want %d", n, renderers*frames)
+	}
+	if mismatched != 0 {
+		t.Errorf("%d pools received the fd of another pool", mismatched)
+	}
+	if got := atomic.LoadInt64((*int64)(&motions)); got != sent {
+		t.Errorf("dispatched %d motion events, want %d", got, sent)
+	}
+}
+
+// TestConcurrentNewIds creates and destroys objects on several goroutines while the freed ids
+// are returned by the dispatching goroutine, so that the ids are reused concurrently
+func TestConcurrentNewIds(t *testing.T) {
+	const (
+		creators = 8
+		objects  = 200
+	)
+	c := newConcurrentClient(t)
+	stopDispatch := c.dispatch()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, creators)
+	for i := 0; i < creators; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < objects; j++ {
+				region, err := c.compositor.CreateRegion()
+				if err == nil {
+					err = region.Add(0, 0, int32(j), int32(j))
+				}
+				if err == nil {
+					err = region.Destroy()
+				}
+				if err == nil {
+					err = c.display.Context().Flush()
+				}
+				if err != nil {
+					errs <- err
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if err := stopDispatch(); err != nil {
+		t.Fatal(err)
+	}
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+	c.roundtrip(t)
+	if err := c.srv.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(c.srv.RequestsTo("wl_region")); n != 2*creators*objects {
+		t.Errorf("%d wl_region requests, want %d", n, 2*creators*objects)
+	}
+}
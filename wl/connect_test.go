@@ -0,0 +1,137 @@
+package wl_test
+
+import (
+	"errors"
+	"net"
+	stdos "os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wlclient"
+	"github.com/neurlang/wayland/wltest"
+)
+
+// setenv sets an environment variable for the duration of the test, unset when value is nil
+func setenv(t *testing.T, key string, value *string) {
+	old, ok := stdos.LookupEnv(key)
+	if value == nil {
+		stdos.Unsetenv(key)
+	} else {
+		stdos.Setenv(key, *value)
+	}
+	t.Cleanup(func() {
+		if ok {
+			stdos.Setenv(key, old)
+		} else {
+			stdos.Unsetenv(key)
+		}
+	})
+}
+
+func str(s string) *string {
+	return &s
+}
+
+// serve checks the connected client roundtrips with a fake compositor on conn
+func serve(t *testing.T, display *wl.Display, conn *net.UnixConn) {
+	srv := wltest.NewServer(conn)
+	defer srv.Close()
+	srv.AddGlobal("wl_compositor", 4)
+	registry, err := display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	globals := wl.NewGlobalTracker(registry)
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := globals.Lookup("wl_compositor"); !ok {
+		t.Error("wl_compositor not advertised")
+	}
+	if err := srv.Err(); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestConnectWaylandSocket connects on the fd passed in WAYLAND_SOCKET, which takes precedence
+// over WAYLAND_DISPLAY and is not inherited by the children
+func TestConnectWaylandSocket(t *testing.T) {
+	fds, err := sys.Socketpair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := stdos.NewFile(uintptr(fds[0]), "socketpair")
+	fc, err := net.FileConn(f)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	setenv(t, "WAYLAND_SOCKET", str(strconv.Itoa(fds[1])))
+	setenv(t, "WAYLAND_DISPLAY", str("/nonexistent/wayland-0"))
+
+	display, err := wl.Connect("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer display.Context().Close()
+	if _, ok := stdos.LookupEnv("WAYLAND_SOCKET"); ok {
+		t.Error("WAYLAND_SOCKET still set after connecting")
+	}
+	serve(t, display, fc.(*net.UnixConn))
+}
+
+func TestConnectWaylandSocketInvalid(t *testing.T) {
+	for _, socket := range []string{"", "wayland-0", "-1"} {
+		setenv(t, "WAYLAND_SOCKET", str(socket))
+		if _, err := wl.Connect(""); !errors.Is(err, wl.ErrWaylandSocketInvalid) {
+			t.Errorf("WAYLAND_SOCKET=%q returned %v, want %v", socket, err, wl.ErrWaylandSocketInvalid)
+		}
+	}
+
+	f, err := stdos.Open(stdos.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	// ConnectToFd closes the fd it is given
+	fd, err := sys.DupCloexec(int(f.Fd()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	setenv(t, "WAYLAND_SOCKET", str(strconv.Itoa(fd)))
+	if _, err := wl.Connect(""); err == nil {
+		t.Errorf("connecting to %s succeeded", stdos.DevNull)
+	}
+}
+
+// TestConnectAbsolutePath connects to the absolute path in WAYLAND_DISPLAY, without any XDG_RUNTIME_DIR
+func TestConnectAbsolutePath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "wayland-test")
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	setenv(t, "WAYLAND_SOCKET", nil)
+	setenv(t, "XDG_RUNTIME_DIR", nil)
+	setenv(t, "WAYLAND_DISPLAY", str(path))
+
+	display, err := wl.Connect("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer display.Context().Close()
+	conn, err := l.AcceptUnix()
+	if err != nil {
+		t.Fatal(err)
+	}
+	serve(t, display, conn)
+
+	// a relative name needs XDG_RUNTIME_DIR
+	if _, err := wl.Connect("wayland-test"); !errors.Is(err, wl.ErrXdgRuntimeDirNotSet) {
+		t.Errorf("relative name without XDG_RUNTIME_DIR returned %v, want %v", err, wl.ErrXdgRuntimeDirNotSet)
+	}
+}
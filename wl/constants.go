@@ -1,122 +0,0 @@
-package wl
-
-const DisplayErrorSinceVersion = 1
-const DisplayDeleteIdSinceVersion = 1
-const DisplaySyncSinceVersion = 1
-const DisplayGetRegistrySinceVersion = 1
-const RegistryGlobalSinceVersion = 1
-const RegistryGlobalRemoveSinceVersion = 1
-const RegistryBindSinceVersion = 1
-const CallbackDoneSinceVersion = 1
-const CompositorCreateSurfaceSinceVersion = 1
-const CompositorCreateRegionSinceVersion = 1
-const ShmPoolCreateBufferSinceVersion = 1
-const ShmPoolDestroySinceVersion = 1
-const ShmPoolResizeSinceVersion = 1
-const ShmFormatSinceVersion = 1
-const ShmCreatePoolSinceVersion = 1
-const BufferReleaseSinceVersion = 1
-const BufferDestroySinceVersion = 1
-const DataOfferOfferSinceVersion = 1
-const DataOfferSourceActionsSinceVersion = 3
-const DataOfferActionSinceVersion = 3
-const DataOfferAcceptSinceVersion = 1
-const DataOfferReceiveSinceVersion = 1
-const DataOfferDestroySinceVersion = 1
-const DataOfferFinishSinceVersion = 3
-const DataOfferSetActionsSinceVersion = 3
-const DataSourceTargetSinceVersion = 1
-const DataSourceSendSinceVersion = 1
-const DataSourceCancelledSinceVersion = 1
-const DataSourceDndDropPerformedSinceVersion = 3
-const DataSourceDndFinishedSinceVersion = 3
-const DataSourceActionSinceVersion = 3
-const DataSourceOfferSinceVersion = 1
-const DataSourceDestroySinceVersion = 1
-const DataSourceSetActionsSinceVersion = 3
-const DataDeviceDataOfferSinceVersion = 1
-const DataDeviceEnterSinceVersion = 1
-const DataDeviceLeaveSinceVersion = 1
-const DataDeviceMotionSinceVersion = 1
-const DataDeviceDropSinceVersion = 1
-const DataDeviceSelectionSinceVersion = 1
-const DataDeviceStartDragSinceVersion = 1
-const DataDeviceSetSelectionSinceVersion = 1
-const DataDeviceReleaseSinceVersion = 2
-const DataDeviceManagerCreateDataSourceSinceVersion = 1
-const DataDeviceManagerGetDataDeviceSinceVersion = 1
-const ShellGetShellSurfaceSinceVersion = 1
-const ShellSurfacePingSinceVersion = 1
-const ShellSurfaceConfigureSinceVersion = 1
-const ShellSurfacePopupDoneSinceVersion = 1
-const ShellSurfacePongSinceVersion = 1
-const ShellSurfaceMoveSinceVersion = 1
-const ShellSurfaceResizeSinceVersion = 1
-const ShellSurfaceSetToplevelSinceVersion = 1
-const ShellSurfaceSetTransientSinceVersion = 1
-const ShellSurfaceSetFullscreenSinceVersion = 1
-const ShellSurfaceSetPopupSinceVersion = 1
-const ShellSurfaceSetMaximizedSinceVersion = 1
-const ShellSurfaceSetTitleSinceVersion = 1
-const ShellSurfaceSetClassSinceVersion = 1
-const SurfaceEnterSinceVersion = 1
-const SurfaceLeaveSinceVersion = 1
-const SurfaceDestroySinceVersion = 1
-const SurfaceAttachSinceVersion = 1
-const SurfaceDamageSinceVersion = 1
-const SurfaceFrameSinceVersion = 1
-const SurfaceSetOpaqueRegionSinceVersion = 1
-const SurfaceSetInputRegionSinceVersion = 1
-const SurfaceCommitSinceVersion = 1
-const SurfaceSetBufferTransformSinceVersion = 2
-const SurfaceSetBufferScaleSinceVersion = 3
-const SurfaceDamageBufferSinceVersion = 4
-const SeatCapabilitiesSinceVersion = 1
-const SeatNameSinceVersion = 2
-const SeatGetPointerSinceVersion = 1
-const SeatGetKeyboardSinceVersion = 1
-const SeatGetTouchSinceVersion = 1
-const SeatReleaseSinceVersion = 5
-const PointerAxisSourceWheelTiltSinceVersion = 6
-const PointerEnterSinceVersion = 1
-const PointerLeaveSinceVersion = 1
-const PointerMotionSinceVersion = 1
-const PointerButtonSinceVersion = 1
-const PointerAxisSinceVersion = 1
-const PointerFrameSinceVersion = 5
-const PointerAxisSourceSinceVersion = 5
-const PointerAxisStopSinceVersion = 5
-const PointerAxisDiscreteSinceVersion = 5
-const PointerSetCursorSinceVersion = 1
-const PointerReleaseSinceVersion = 3
-const KeyboardKeymapSinceVersion = 1
-const KeyboardEnterSinceVersion = 1
-const KeyboardLeaveSinceVersion = 1
-const KeyboardKeySinceVersion = 1
-const KeyboardModifiersSinceVersion = 1
-const KeyboardRepeatInfoSinceVersion = 4
-const KeyboardReleaseSinceVersion = 3
-const TouchDownSinceVersion = 1
-const TouchUpSinceVersion = 1
-const TouchMotionSinceVersion = 1
-const TouchFrameSinceVersion = 1
-const TouchCancelSinceVersion = 1
-const TouchShapeSinceVersion = 6
-const TouchOrientationSinceVersion = 6
-const TouchReleaseSinceVersion = 3
-const OutputGeometrySinceVersion = 1
-const OutputModeSinceVersion = 1
-const OutputDoneSinceVersion = 2
-const OutputScaleSinceVersion = 2
-const OutputReleaseSinceVersion = 3
-const RegionDestroySinceVersion = 1
-const RegionAddSinceVersion = 1
-const RegionSubtractSinceVersion = 1
-const SubcompositorDestroySinceVersion = 1
-const SubcompositorGetSubsurfaceSinceVersion = 1
-const SubsurfaceDestroySinceVersion = 1
-const SubsurfaceSetPositionSinceVersion = 1
-const SubsurfacePlaceAboveSinceVersion = 1
-const SubsurfacePlaceBelowSinceVersion = 1
-const SubsurfaceSetSyncSinceVersion = 1
-const SubsurfaceSetDesyncSinceVersion = 1
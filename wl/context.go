@@ -1,11 +1,13 @@
 package wl
 
 import (
+	"context"
 	"errors"
 	"io"
 	"log"
 	"net"
 	"os"
+	"strconv"
 	"sync"
 	"time"
 
@@ -19,47 +21,150 @@ func init() {
 
 // Context wraps the wayland connection together with the map of all Context objects (proxies)
 type Context struct {
-	mu        sync.RWMutex
-	conn      *net.UnixConn
-	sockFD    int
-	currentId ProxyId
-	objects   map[ProxyId]Proxy
-	scms      []sys.SocketControlMessage
+	mu              sync.RWMutex
+	conn            *net.UnixConn
+	currentId       ProxyId
+	objects         map[ProxyId]Proxy
+	in              inBuffer
+	control         []byte
+	reads           int
+	fds             fdRing
+	outMu           sync.Mutex
+	out             []byte
+	outReq          Request
+	outFds          []int
+	rmu             sync.Mutex
+	rcond           *sync.Cond
+	reading         bool
+	readInterrupted bool
+	readers         int
+	readSerial      uint64
+	readErr         error
+	queue           *EventQueue
+	zombies         map[ProxyId]*Interface
+	deleted         map[ProxyId]bool
+	pending         map[ProxyId]*pendingObject
+	freeIds         []ProxyId
+	dead            *ProtocolError
+	traceMu         sync.Mutex
+	tracer          io.Writer
+	recMu           sync.Mutex
+	rec             *recorder
 }
 
+// serverIdStart is the first id allocated by the compositor for the objects it creates, such as wl_data_offer
+const serverIdStart = 0xff000000
+
+// RegisterMapped registers a proxy created by the compositor under the id it allocated
 func (ctx *Context) RegisterMapped(proxy Proxy, num uint32) {
 	ctx.mu.Lock()
 	proxy.SetId(ProxyId(num))
 	proxy.SetContext(ctx)
 	ctx.objects[ProxyId(num)] = proxy
+	delete(ctx.zombies, ProxyId(num))
+	pending := ctx.pending[ProxyId(num)]
+	delete(ctx.pending, ProxyId(num))
 	ctx.mu.Unlock()
+	if pending == nil {
+		return
+	}
+	// the events already received for the object were routed to the queue of its creator
+	proxy.SetVersion(pending.version)
+	if p, ok := proxy.(interface{ SetQueue(*EventQueue) }); ok && pending.queue != ctx.queue {
+		p.SetQueue(pending.queue)
+	}
 }
 
-// Register registers a proxy in the map of all Context objects (proxies)
+// Register attaches a proxy to the Context. Its id is allocated when it is sent as the new_id argument
+// of a request, in the same critical section that queues the request, so that concurrent requests
+// send the new ids in the order they are allocated, as the compositor requires.
 func (ctx *Context) Register(proxy Proxy) {
+	proxy.SetContext(ctx)
+}
+
+// allocate allocates the id of the proxy and adds it to the map of all Context objects (proxies).
+// The ids freed by wl_display.delete_id are reused first.
+func (ctx *Context) allocate(proxy Proxy) {
 	ctx.mu.Lock()
-	// avoid colliding with server side mapped proxy id?
-	for {
-		ctx.currentId += 1
-		if _, ok := ctx.objects[(ctx.currentId)]; !ok {
+	var id ProxyId
+	if n := len(ctx.freeIds); n > 0 {
+		id = ctx.freeIds[n-1]
+		ctx.freeIds = ctx.freeIds[:n-1]
+	} else {
+		for {
+			ctx.currentId += 1
+			if _, ok := ctx.objects[ctx.currentId]; ok {
+				continue
+			}
+			if _, ok := ctx.zombies[ctx.currentId]; ok {
+				continue
+			}
 			break
 		}
+		id = ctx.currentId
 	}
-	proxy.SetId(ctx.currentId)
+	proxy.SetId(id)
 	proxy.SetContext(ctx)
-	ctx.objects[ctx.currentId] = proxy
+	ctx.objects[id] = proxy
 	ctx.mu.Unlock()
 }
 
-// Unregister unregisters a proxy in the map of all Context objects (proxies)
-func (ctx *Context) Unregister(id ProxyId) {
+// release frees the id of a proxy allocated by the request that failed to be queued, the caller
+// holds outMu so no other id was allocated since
+func (ctx *Context) release(proxy Proxy) {
 	ctx.mu.Lock()
-	if ctx.objects != nil {
-		delete(ctx.objects, id)
+	id := proxy.Id()
+	delete(ctx.objects, id)
+	if id == ctx.currentId {
+		ctx.currentId--
+	} else {
+		ctx.freeIds = append(ctx.freeIds, id)
 	}
+	proxy.SetId(0)
 	ctx.mu.Unlock()
 }
 
+// Unregister unregisters a proxy in the map of all Context objects (proxies). Until the compositor
+// acknowledges the destruction by wl_display.delete_id, the id is kept as a zombie: the events still
+// in flight are dropped silently and their fds are closed. The ids allocated by the compositor stay
+// zombies until the compositor creates another object with the same id.
+func (ctx *Context) Unregister(id ProxyId) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if ctx.objects == nil {
+		return
+	}
+	proxy, ok := ctx.objects[id]
+	if !ok {
+		return
+	}
+	delete(ctx.objects, id)
+	if ctx.deleted[id] {
+		delete(ctx.deleted, id)
+		ctx.freeIds = append(ctx.freeIds, id)
+		return
+	}
+	ctx.zombies[id] = proxyInterface(proxy)
+}
+
+// deleteId handles wl_display.delete_id, the id of a zombie is freed for reuse
+func (ctx *Context) deleteId(id ProxyId) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	if ctx.objects == nil || id >= serverIdStart {
+		return
+	}
+	if _, ok := ctx.zombies[id]; ok {
+		delete(ctx.zombies, id)
+		ctx.freeIds = append(ctx.freeIds, id)
+		return
+	}
+	if _, ok := ctx.objects[id]; ok {
+		// the proxy is not destroyed yet, its id is freed by Unregister
+		ctx.deleted[id] = true
+	}
+}
+
 // LookupProxy looks up a specific proxy by it's Id in the map of all Context objects (proxies)
 func (ctx *Context) LookupProxy(id ProxyId) Proxy {
 	ctx.mu.RLock()
@@ -75,11 +180,26 @@ func (ctx *Context) LookupProxy(id ProxyId) Proxy {
 // XDG_RUNTIME_DIR environment variable
 var ErrXdgRuntimeDirNotSet = errors.New("variable XDG_RUNTIME_DIR not set in the environment")
 
-// Connect connects to a Wayland compositor running on a specific wayland unix socket
+// ErrWaylandSocketInvalid is returned by Connect when the WAYLAND_SOCKET environment variable is not
+// the number of a file descriptor
+var ErrWaylandSocketInvalid = errors.New("variable WAYLAND_SOCKET is not a file descriptor")
+
+// ErrNotUnixSocket is returned by ConnectToFd when the file descriptor is not a unix socket
+var ErrNotUnixSocket = errors.New("file descriptor is not a unix socket")
+
+// Connect connects to a Wayland compositor following the rules of libwayland. When the WAYLAND_SOCKET
+// environment variable is set, it is the number of an already connected file descriptor, passed by the
+// process that started the client, and it is unset so that it is not inherited by the children of the
+// client. Otherwise addr, by default WAYLAND_DISPLAY or else wayland-0, is the name of the socket in
+// XDG_RUNTIME_DIR, or the path of the socket when it is absolute.
 func Connect(addr string) (ret *Display, err error) {
-	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
-	if runtimeDir == "" {
-		return nil, ErrXdgRuntimeDirNotSet
+	if socket, ok := os.LookupEnv("WAYLAND_SOCKET"); ok {
+		fd, err := strconv.Atoi(socket)
+		if err != nil || fd < 0 {
+			return nil, ErrWaylandSocketInvalid
+		}
+		os.Unsetenv("WAYLAND_SOCKET")
+		return ConnectToFd(fd)
 	}
 	if addr == "" {
 		addr = os.Getenv("WAYLAND_DISPLAY")
@@ -87,21 +207,78 @@ func Connect(addr string) (ret *Display, err error) {
 	if addr == "" {
 		addr = "wayland-0"
 	}
-	addr = runtimeDir + "/" + addr
-	c := new(Context)
-	c.objects = make(map[ProxyId]Proxy)
-	c.currentId = 0
-	c.conn, err = net.DialUnix("unix", nil, &net.UnixAddr{Name: addr, Net: "unix"})
+	if addr[0] != '/' {
+		runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+		if runtimeDir == "" {
+			return nil, ErrXdgRuntimeDirNotSet
+		}
+		addr = runtimeDir + "/" + addr
+	}
+	conn, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: addr, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	ret, err = ConnectConn(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ConnectToFd creates the Context on a file descriptor of a unix socket connected to a Wayland
+// compositor. The Context takes the ownership of the file descriptor, it is closed even on error.
+func ConnectToFd(fd int) (*Display, error) {
+	f := os.NewFile(uintptr(fd), "wayland-socket")
+	if f == nil {
+		return nil, ErrWaylandSocketInvalid
+	}
+	// FileConn duplicates the file descriptor, the duplicate is close-on-exec
+	fc, err := net.FileConn(f)
+	f.Close()
 	if err != nil {
 		return nil, err
 	}
-	err = c.conn.SetReadDeadline(time.Time{})
+	conn, ok := fc.(*net.UnixConn)
+	if !ok {
+		fc.Close()
+		return nil, ErrNotUnixSocket
+	}
+	ret, err := ConnectConn(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return ret, nil
+}
+
+// ConnectConn creates the Context on an already established connection to a Wayland compositor,
+// such as one end of a socketpair. The Context takes the ownership of the connection.
+func ConnectConn(conn *net.UnixConn) (*Display, error) {
+	if conn == nil {
+		return nil, ErrContextConnNil
+	}
+	c := new(Context)
+	c.objects = make(map[ProxyId]Proxy)
+	c.zombies = make(map[ProxyId]*Interface)
+	c.deleted = make(map[ProxyId]bool)
+	c.pending = make(map[ProxyId]*pendingObject)
+	c.rcond = sync.NewCond(&c.rmu)
+	c.queue = c.NewEventQueue()
+	c.currentId = 0
+	c.conn = conn
+	err := c.conn.SetReadDeadline(time.Time{})
 	if err != nil {
 		return nil, err
 	}
+	c.traceFromEnv()
+	c.recordFromEnv()
 	//DON'T dispatch events in separate goroutine
 	//go c.Run()
-	return NewDisplay(c), nil
+	display := NewDisplay(c)
+	// the wl_display is the object 1, it exists without any request
+	c.allocate(display)
+	return display, nil
 }
 
 var errFoundMyCallback = errors.New("run found my callback")
@@ -109,8 +286,14 @@ var errFoundMyCallback = errors.New("run found my callback")
 // RunTill (Context RunTill) runs until a specific callback or an error occurs, see Context Run
 // for a description of a likely errors
 func (ctx *Context) RunTill(cb *Callback) (err error) {
+	return ctx.RunTillContext(context.Background(), cb)
+}
+
+// RunTillContext (Context RunTillContext) runs until a specific callback or an error occurs, like RunTill.
+// When c is cancelled or its deadline expires, the blocking read is interrupted and the error of c is returned.
+func (ctx *Context) RunTillContext(c context.Context, cb *Callback) (err error) {
 	for {
-		err = ctx.run(cb)
+		err = ctx.run(c, cb)
 		if err == errFoundMyCallback {
 			return nil
 		}
@@ -129,7 +312,8 @@ var ErrContextRunConnectionClosed = errors.New("connection closed")
 // ErrContextRunTimeout (Context Run timeout error)
 var ErrContextRunTimeout = errors.New("timeout error")
 
-// ErrContextRunProtocolError (Context Run protocol error), use InternalError to get the underlying cause
+// ErrContextRunProtocolError (Context Run protocol error), use InternalError to get the underlying cause.
+// The wl_display.error event of the compositor is returned as a *ProtocolError matching it.
 var ErrContextRunProtocolError = errors.New("protocol error")
 
 // ErrContextRunNotDispatched (Context Run not dispatched)
@@ -141,7 +325,13 @@ var ErrContextRunProxyNil = errors.New("proxy nil")
 // Run (Context Run) reads and processes one event, a specific ErrContextRunXXX error
 // may be returned in case of failure
 func (ctx *Context) Run() error {
-	return ctx.run(nil)
+	return ctx.run(context.Background(), nil)
+}
+
+// RunContext (Context RunContext) reads and processes one event like Run. When c is cancelled
+// or its deadline expires, the blocking read is interrupted and the error of c is returned.
+func (ctx *Context) RunContext(c context.Context) error {
+	return ctx.run(c, nil)
 }
 
 // ErrContextNil (Error context is nil) occurs if the thread closes context and then
@@ -152,48 +342,15 @@ var ErrContextNil = errors.New("context is nil")
 // it wants to run, another thread probably cannot close it safely
 var ErrContextConnNil = errors.New("context conn is nil")
 
-func (ctx *Context) run(cb *Callback) error {
-	// ctx := context.Background()
+// errQueueEmpty is returned when dispatching an empty queue without reading the connection
+var errQueueEmpty = errors.New("queue empty")
 
+func (ctx *Context) run(c context.Context, cb *Callback) error {
 	if ctx == nil {
 		return ErrContextNil
 	}
 
-	ev, err := ctx.readEvent()
-	if err != nil {
-		if err == io.EOF {
-			return ErrContextRunConnectionClosed
-		}
-
-		if neterr, ok := err.(net.Error); ok && neterr.Timeout() {
-			return ErrContextRunTimeout
-		}
-
-		return combinedError{ErrContextRunEventReadingError, err}
-	}
-
-	proxy := ctx.LookupProxy(ev.Pid)
-	if proxy != nil {
-		if dispatcher, ok := proxy.(Dispatcher); dispatcher != nil && ok {
-			if foundCb, ok := dispatcher.(*Callback); ok {
-				if foundCb == cb {
-					bytePool.Give(ev.Data)
-					return errFoundMyCallback
-				}
-			}
-			dispatcher.Dispatch(ev)
-			bytePool.Give(ev.Data)
-
-			if ev.err != nil {
-				return combinedError{ErrContextRunProtocolError, ev.err}
-			}
-		} else {
-			return ErrContextRunNotDispatched
-		}
-	} else {
-		return ErrContextRunProxyNil
-	}
-	return nil
+	return ctx.queue.dispatchOne(c, cb, true)
 }
 
 // Close (Context Close) closes Wayland connection
@@ -201,12 +358,19 @@ func (ctx *Context) Close() (err error) {
 	if ctx == nil {
 		return
 	}
+	ctx.Flush()
+	ctx.outMu.Lock()
 	ctx.mu.Lock()
 	if ctx.conn != nil {
 		err = ctx.conn.Close()
 		ctx.conn = nil
 	}
-	ctx.sockFD = -1
+	for _, fd := range ctx.outFds {
+		sys.Close(fd)
+	}
+	ctx.out = nil
+	ctx.outFds = nil
+	ctx.outMu.Unlock()
 	/*
 		for i, v := range ctx.objects {
 			print("close-time garbage: ")
@@ -216,7 +380,13 @@ func (ctx *Context) Close() (err error) {
 		}
 	*/
 	ctx.objects = nil
-	ctx.scms = nil
+	ctx.zombies = nil
+	ctx.deleted = nil
+	ctx.pending = nil
+	ctx.fds.closeAll()
 	ctx.mu.Unlock()
+	if rerr := ctx.SetRecorder(nil); err == nil {
+		err = rerr
+	}
 	return err
 }
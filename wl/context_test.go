@@ -0,0 +1,61 @@
+package wl_test
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestRunTillContextDeadline waits for a frame callback the compositor never answers, the deadline
+// interrupts the blocking read and the connection stays usable
+func TestRunTillContextDeadline(t *testing.T) {
+	c := newConcurrentClient(t)
+	ctx := c.display.Context()
+	surface, err := c.compositor.CreateSurface()
+	if err != nil {
+		t.Fatal(err)
+	}
+	frame, err := surface.Frame()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deadline, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if err := ctx.RunTillContext(deadline, frame); err != context.DeadlineExceeded {
+		t.Fatalf("RunTillContext returned %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("RunTillContext returned after %v", elapsed)
+	}
+	c.roundtrip(t)
+}
+
+// TestRunContextCancel cancels a RunContext blocked reading from another goroutine
+func TestRunContextCancel(t *testing.T) {
+	c := newConcurrentClient(t)
+	ctx := c.display.Context()
+	c.roundtrip(t)
+	// dispatch the events left by the roundtrip
+	if _, err := ctx.DispatchPending(); err != nil {
+		t.Fatal(err)
+	}
+
+	cancellable, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- ctx.RunContext(cancellable)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Fatalf("RunContext returned %v, want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunContext not interrupted")
+	}
+	c.roundtrip(t)
+}
@@ -1,5 +1,7 @@
 package wl
 
+import "fmt"
+
 // combinedError is a tuple of an External and an Internal error
 type combinedError [2]error
 
@@ -9,6 +11,81 @@ func (c combinedError) Error() string {
 func (c combinedError) Unwrap() error {
 	return c[1]
 }
+func (c combinedError) Is(target error) bool {
+	return c[0] == target
+}
 func (c combinedError) External() error {
 	return c[0]
 }
+
+// ProtocolError is returned by Context Run once the compositor has sent a wl_display.error event,
+// the compositor then closes the connection so the error is fatal. All the later requests and
+// reads of the Context fail with it. errors.Is reports it as ErrContextRunProtocolError.
+type ProtocolError struct {
+	// Interface is the name of the interface of the object the error is about, "" when unknown
+	Interface string
+	// ObjectId is the id of the object the error is about
+	ObjectId ProxyId
+	// Code is the error code, a value of the error enum of the interface
+	Code uint32
+	// Enum is the symbolic name of the code, such as "xdg_wm_base.error.invalid_surface_state",
+	// "" when unknown
+	Enum string
+	// Message is the description of the error sent by the compositor
+	Message string
+}
+
+func (e *ProtocolError) Error() string {
+	s := ErrContextRunProtocolError.Error() + ": "
+	if e.Interface != "" {
+		s += fmt.Sprintf("%s@%d: ", e.Interface, e.ObjectId)
+	} else {
+		s += fmt.Sprintf("object %d: ", e.ObjectId)
+	}
+	if e.Enum != "" {
+		s += fmt.Sprintf("%s (%d)", e.Enum, e.Code)
+	} else {
+		s += fmt.Sprintf("error %d", e.Code)
+	}
+	if e.Message != "" {
+		s += ": " + e.Message
+	}
+	return s
+}
+
+// Is (ProtocolError Is) reports whether the target is ErrContextRunProtocolError
+func (e *ProtocolError) Is(target error) bool {
+	return target == ErrContextRunProtocolError
+}
+
+// protocolError decodes the wl_display.error event and marks the connection dead, ctx.rmu must be held
+func (ctx *Context) protocolError(ev *Event) {
+	e := Event{Pid: ev.Pid, Opcode: ev.Opcode, Data: ev.Data}
+	perr := &ProtocolError{ObjectId: ProxyId(e.Uint32())}
+	perr.Code = e.Uint32()
+	perr.Message = e.String()
+
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	iface := proxyInterface(ctx.objects[perr.ObjectId])
+	if iface == nil {
+		iface = ctx.zombies[perr.ObjectId]
+	}
+	if iface != nil {
+		perr.Interface = iface.Name
+		perr.Enum = iface.EnumName("error", perr.Code)
+	}
+	if ctx.dead == nil {
+		ctx.dead = perr
+	}
+}
+
+// deadError returns the ProtocolError the connection died of, or nil
+func (ctx *Context) deadError() error {
+	ctx.mu.RLock()
+	defer ctx.mu.RUnlock()
+	if ctx.dead == nil {
+		return nil
+	}
+	return ctx.dead
+}
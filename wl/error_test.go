@@ -0,0 +1,61 @@
+package wl_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wlclient"
+	"github.com/neurlang/wayland/xdg"
+)
+
+// TestProtocolError posts an xdg_wm_base error, Run returns it named after the enum and the
+// connection is dead afterwards
+func TestProtocolError(t *testing.T) {
+	c := newConcurrentClient(t)
+	c.srv.AddGlobal("xdg_wm_base", 2)
+	registry, err := c.display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	globals := wl.NewGlobalTracker(registry)
+	c.roundtrip(t)
+	p, err := globals.Bind("xdg_wm_base", 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.roundtrip(t)
+
+	if err := c.srv.PostError(p.Id(), xdg.WmBaseErrorInvalidSurfaceState, "configure not acked"); err != nil {
+		t.Fatal(err)
+	}
+	err = wlclient.DisplayRoundtrip(c.display)
+	var perr *wl.ProtocolError
+	if !errors.As(err, &perr) {
+		t.Fatalf("roundtrip returned %v, want a ProtocolError", err)
+	}
+	if !errors.Is(err, wl.ErrContextRunProtocolError) {
+		t.Errorf("%v is not ErrContextRunProtocolError", err)
+	}
+	want := wl.ProtocolError{
+		Interface: "xdg_wm_base",
+		ObjectId:  p.Id(),
+		Code:      xdg.WmBaseErrorInvalidSurfaceState,
+		Enum:      "xdg_wm_base.error.invalid_surface_state",
+		Message:   "configure not acked",
+	}
+	if *perr != want {
+		t.Errorf("error %+v, want %+v", *perr, want)
+	}
+
+	// the later requests fail fast
+	if _, err := c.compositor.CreateSurface(); err == nil {
+		err = c.display.Context().Flush()
+		if !errors.As(err, &perr) {
+			t.Errorf("flush after the error returned %v, want the ProtocolError", err)
+		}
+	}
+	if err := c.display.Context().Run(); !errors.As(err, &perr) {
+		t.Errorf("run after the error returned %v, want the ProtocolError", err)
+	}
+}
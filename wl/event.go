@@ -3,18 +3,49 @@ package wl
 import (
 	"bytes"
 	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
 	"github.com/neurlang/wayland/os"
 	"github.com/yalue/native_endian"
 )
 
-// Event is the Wayland event (e.g. a response) from the compositor
+// Event is the Wayland event (e.g. a response) from the compositor. The events read by the Context
+// are reused once dispatched, so a Dispatcher must not keep the Event or its Data after Dispatch returns.
 type Event struct {
 	Pid    ProxyId
 	Opcode uint32
 	Data   []byte
 	off    int
 	err    error
-	ctx    *Context
+	fds    []int
+	proxy  Proxy
+	chunk  *inChunk
+}
+
+// eventPool holds the dispatched events for reuse
+var eventPool = sync.Pool{
+	New: func() interface{} {
+		return new(Event)
+	},
+}
+
+// release returns an event read by the Context to the pool, its fds must be closed
+func (ev *Event) release() {
+	if ev.chunk != nil {
+		atomic.AddInt32(&ev.chunk.refs, -1)
+	}
+	*ev = Event{}
+	eventPool.Put(ev)
+}
+
+// NewEvent returns a message received on the object, holding the encoded arguments and the fds
+// received along with it. Compositors use it to decode the requests of their clients by the
+// same decoders, the fds are taken by FD.
+func NewEvent(pid ProxyId, opcode uint32, data []byte, fds []int) *Event {
+	return &Event{Pid: pid, Opcode: opcode, Data: data, fds: fds}
 }
 
 // ErrReadHeader (Error unable to read message header) is returned when it is not possible to read enough bytes from the unix socket,
@@ -38,76 +69,233 @@ var ErrInvalidMsgSize = errors.New("invalid message size")
 // underlying cause and GetExternal to get this cause
 var ErrReadPayload = errors.New("cannot read message")
 
-func (ctx *Context) readEvent() (*Event, error) {
-	buf := bytePool.Take(8)
-	control := bytePool.Take(24)
+// controlLen is the size of the control message buffer, large enough for the maximum number of fds
+// passed in a single sendmsg
+var controlLen = os.CmsgSpace(maxFdsPerMsg * 4)
 
-	if ctx.conn == nil {
-		return nil, ErrContextConnNil
+// readMsg reads into buf, queueing the received file descriptors in order
+func (ctx *Context) readMsg(buf []byte) (int, error) {
+	// only one goroutine reads at a time
+	if ctx.control == nil {
+		ctx.control = make([]byte, controlLen)
 	}
+	control := ctx.control
 
-	n, oobn, _, _, err := ctx.conn.ReadMsgUnix(buf[:], control)
+	ctx.reads++
+	n, oobn, flags, _, err := ctx.conn.ReadMsgUnix(buf, control)
 	if err != nil {
-		return nil, combinedError{ErrReadHeader, err}
+		return n, err
 	}
-	if n != 8 {
-		return nil, ErrSizeOfHeaderWrong
-	}
-	ev := new(Event)
-	ev.ctx = ctx
 	if oobn > 0 {
 		if oobn > len(control) {
-			return nil, ErrControlMsgBuffer
+			return n, ErrControlMsgBuffer
 		}
-		scms, err := os.ParseSocketControlMessage(control)
+		scms, err := os.ParseSocketControlMessage(control[:oobn])
 		if err != nil {
-			return nil, combinedError{ErrControlMsgParseError, err}
+			return n, combinedError{ErrControlMsgParseError, err}
+		}
+		for i := range scms {
+			fds, err := os.ParseUnixRights(&scms[i])
+			if err != nil {
+				continue
+			}
+			for _, fd := range fds {
+				ctx.fds.push(fd)
+			}
 		}
-		ctx.scms = append(ctx.scms, scms...)
 	}
+	if flags&os.MsgCtrunc != 0 {
+		return n, ErrControlMsgBuffer
+	}
+	return n, nil
+}
 
-	ev.Pid = ProxyId(native_endian.NativeEndian().Uint32(buf[0:4]))
-	ev.Opcode = uint32(native_endian.NativeEndian().Uint16(buf[4:6]))
-	size := uint32(native_endian.NativeEndian().Uint16(buf[6:8]))
+// inBufferSize is the initial size of the connection input buffer, a single read
+// usually receives all the events the compositor has flushed
+const inBufferSize = 4096
 
-	// subtract 8 bytes from header
-	data := bytePool.Take(int(size) - 8)
-	n, err = ctx.conn.Read(data)
-	if err != nil {
-		return nil, combinedError{ErrReadPayload, err}
+// inBuffer holds the bytes received on the connection that were not decoded yet. The bytes
+// of the messages handed out are not overwritten, so that the events can be queued and
+// dispatched later without copying; a new buffer is started when the current one is full.
+// Once every event of the buffer is released, it is reused from the start.
+type inBuffer struct {
+	data       []byte
+	start, end int
+	chunk      *inChunk
+}
+
+// inChunk tracks the events handed out from the data of the inBuffer
+type inChunk struct {
+	refs int32
+}
+
+// unreferenced reports whether no event refers to the data
+func (b *inBuffer) unreferenced() bool {
+	return b.chunk != nil && atomic.LoadInt32(&b.chunk.refs) == 0
+}
+
+// recycle starts over at the beginning of the data when it is no longer referenced
+func (b *inBuffer) recycle() {
+	if b.start == b.end && b.start != 0 && b.unreferenced() {
+		b.start, b.end = 0, 0
+	}
+}
+
+// message returns the next complete message, or nil when more bytes need to be read
+func (b *inBuffer) message() (msg []byte, err error) {
+	if b.end-b.start < 8 {
+		return nil, nil
 	}
-	if n != int(size)-8 {
+	size := int(native_endian.NativeEndian().Uint16(b.data[b.start+6 : b.start+8]))
+	if size < 8 {
 		return nil, ErrInvalidMsgSize
 	}
-	ev.Data = data
+	if b.end-b.start < size {
+		return nil, nil
+	}
+	msg = b.data[b.start : b.start+size : b.start+size]
+	b.start += size
+	if b.chunk == nil {
+		b.chunk = new(inChunk)
+	}
+	atomic.AddInt32(&b.chunk.refs, 1)
+	return msg, nil
+}
 
-	bytePool.Give(buf)
-	bytePool.Give(control)
+// reserve makes room for a message of the size at the start of the free space
+func (b *inBuffer) reserve(size int) {
+	if len(b.data) == 0 {
+		b.data = make([]byte, inBufferSize)
+	}
+	if len(b.data)-b.start >= size && b.end < len(b.data) {
+		return
+	}
+	if b.unreferenced() && size <= len(b.data) {
+		// move the partial message to the start
+		copy(b.data, b.data[b.start:b.end])
+		b.end -= b.start
+		b.start = 0
+		return
+	}
+	n := inBufferSize
+	if n < size {
+		n = size
+	}
+	data := make([]byte, n)
+	copy(data, b.data[b.start:b.end])
+	b.data = data
+	b.end -= b.start
+	b.start = 0
+	b.chunk = nil
+}
 
+// free returns the space the next read is made into, room is made for the rest of a partial message
+func (b *inBuffer) free() []byte {
+	b.recycle()
+	size := 8
+	if b.end-b.start >= 8 {
+		size = int(native_endian.NativeEndian().Uint16(b.data[b.start+6 : b.start+8]))
+	}
+	b.reserve(size)
+	return b.data[b.end:]
+}
+
+// readEvent returns the next complete event of the input buffer, or nil when more bytes need to be read
+func (ctx *Context) readEvent() (*Event, error) {
+	msg, err := ctx.in.message()
+	if err != nil || msg == nil {
+		return nil, err
+	}
+	ev := eventPool.Get().(*Event)
+	ev.chunk = ctx.in.chunk
+	ev.Pid = ProxyId(native_endian.NativeEndian().Uint32(msg[0:4]))
+	ev.Opcode = uint32(native_endian.NativeEndian().Uint16(msg[4:6]))
+	ev.Data = msg[8:]
 	return ev, nil
 }
 
+// readChunk sends the queued requests and then reads the connection once into the input buffer,
+// it blocks until some bytes are received
+func (ctx *Context) readChunk() error {
+	if ctx.conn == nil {
+		return ErrContextConnNil
+	}
+
+	// send the queued requests before blocking, the compositor may be waiting for them
+	if err := ctx.Flush(); err != nil {
+		return err
+	}
+
+	partial := ctx.in.end > ctx.in.start
+	n, err := ctx.readMsg(ctx.in.free())
+	if n > 0 {
+		ctx.in.end += n
+	}
+	if err != nil {
+		if partial {
+			return combinedError{ErrReadPayload, err}
+		}
+		return combinedError{ErrReadHeader, err}
+	}
+	if n == 0 {
+		return io.EOF
+	}
+	return nil
+}
+
 // ErrNoControlMsgs (Error no socket control messages)
 var ErrNoControlMsgs = errors.New("no socket control messages")
 
 // ErrUnableToParseUnixRights (Error unable to parse unix rights)
 var ErrUnableToParseUnixRights = errors.New("unable to parse unix rights")
 
-// FD (Event FD) extracts the file descriptor and an optional error
+// DecodeError is returned when an event argument does not fit in the event message, Context Run
+// reports it as ErrContextRunProtocolError. Err is one of the ErrUnableToParseXXX errors.
+type DecodeError struct {
+	Pid    ProxyId
+	Opcode uint32
+	Offset int
+	Need   uint64
+	Size   int
+	Err    error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("%s: event %d of object %d needs %d bytes at offset %d of %d",
+		e.Err, e.Opcode, e.Pid, e.Need, e.Offset, e.Size)
+}
+
+// Unwrap (DecodeError Unwrap) returns the ErrUnableToParseXXX error
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// Err (Event Err) returns the first error of decoding the event arguments
+func (ev *Event) Err() error {
+	return ev.err
+}
+
+// FD (Event FD) extracts the next file descriptor received on the connection and an optional error
 func (ev *Event) FD() (uintptr, error) {
 	if ev.err != nil {
 		return 0, ev.err
 	}
-	if len(ev.ctx.scms) == 0 {
+	// the fds were taken off the connection when the event was routed to its queue
+	if len(ev.fds) == 0 {
 		return 0, ErrNoControlMsgs
 	}
-	fds, err := os.ParseUnixRights(&ev.ctx.scms[0])
-	if err != nil {
-		return 0, ErrUnableToParseUnixRights
+	fd := ev.fds[0]
+	ev.fds = ev.fds[1:]
+	return uintptr(fd), nil
+}
+
+// CloseFds (Event CloseFds) closes the file descriptors received with the event that were not
+// taken by FD. Compositors call it once a request decoded from NewEvent is dispatched.
+func (ev *Event) CloseFds() {
+	for _, fd := range ev.fds {
+		os.Close(fd)
 	}
-	//TODO: is this required??????????????
-	ev.ctx.scms = ev.ctx.scms[1:]
-	return uintptr(fds[0]), nil
+	ev.fds = nil
 }
 
 // ErrUnableToParseUint32 (Error unable to read unsigned int) is returned when the buffer is too short to contain a specific unsigned int
@@ -115,9 +303,8 @@ var ErrUnableToParseUint32 = errors.New("unable to read unsigned int")
 
 // Uint32 (Event Uint32) decodes an Uint32 from the Event
 func (ev *Event) Uint32() uint32 {
-	buf := ev.next(4)
-	if len(buf) != 4 {
-		ev.err = ErrUnableToParseUint32
+	buf := ev.next(4, ErrUnableToParseUint32)
+	if buf == nil {
 		return 0
 	}
 	return native_endian.NativeEndian().Uint32(buf)
@@ -135,20 +322,33 @@ func (ev *Event) Proxy(c *Context) Proxy {
 // ErrUnableToParseString (Error unable to parse string) is returned when the buffer is too short to contain a specific string
 var ErrUnableToParseString = errors.New("unable to parse string")
 
-// String (Event String) decodes a string from the Event
+// String (Event String) decodes a string from the Event. It is copied, except the names of the
+// registered interfaces, such as the interface of wl_registry.global, which are returned without allocating.
 func (ev *Event) String() string {
-	l := int(ev.Uint32())
-	buf := ev.next(l)
-	if len(buf) != l {
-		ev.err = ErrUnableToParseString
+	buf := ev.StringBytes()
+	if len(buf) == 0 {
 		return ""
 	}
-	ret := string(bytes.TrimRight(buf, "\x00"))
+	if name := internedName(buf); name != "" {
+		return name
+	}
+	return string(buf)
+}
+
+// StringBytes (Event StringBytes) decodes a string from the Event without copying it. The bytes are a view
+// of the input buffer, they are only valid until the handler of the event returns and must not be modified.
+func (ev *Event) StringBytes() []byte {
+	l := ev.Uint32()
+	if l == 0 {
+		return nil
+	}
 	//padding to 32 bit boundary
-	if (l & 0x3) != 0 {
-		ev.next(4 - (l & 0x3))
+	buf := ev.next(padded(l), ErrUnableToParseString)
+	if buf == nil {
+		return nil
 	}
-	return ret
+	buf = bytes.TrimRight(buf[:l], "\x00")
+	return buf[:len(buf):len(buf)]
 }
 
 // Int32 (Event Int32) decodes an Int32 from the Event
@@ -161,18 +361,45 @@ func (ev *Event) Float32() float32 {
 	return float32(FixedToFloat(ev.Int32()))
 }
 
+// ErrUnableToParseArray (Error unable to parse array) is returned when the buffer is too short to contain a specific array
+var ErrUnableToParseArray = errors.New("unable to parse array")
+
 // Array (Event Array) decodes an Array from the Event
 func (ev *Event) Array() []int32 {
-	l := int(ev.Uint32())
+	l := ev.Uint32()
+	buf := ev.next(padded(l), ErrUnableToParseArray)
+	if buf == nil {
+		return []int32{}
+	}
 	arr := make([]int32, l/4)
 	for i := range arr {
-		arr[i] = ev.Int32()
+		arr[i] = int32(native_endian.NativeEndian().Uint32(buf[4*i:]))
 	}
 	return arr
 }
 
-func (ev *Event) next(n int) []byte {
-	ret := ev.Data[ev.off : ev.off+n]
-	ev.off += n
+// padded returns the size of a string or array of l bytes padded to 32 bit boundary
+func padded(l uint32) uint64 {
+	return (uint64(l) + 3) &^ 3
+}
+
+// next returns the next n bytes of the event, or nil and sets the decoding error when there are not enough bytes
+func (ev *Event) next(n uint64, err error) []byte {
+	if ev.err != nil {
+		return nil
+	}
+	if n > uint64(len(ev.Data)-ev.off) {
+		ev.err = &DecodeError{
+			Pid:    ev.Pid,
+			Opcode: ev.Opcode,
+			Offset: ev.off,
+			Need:   n,
+			Size:   len(ev.Data),
+			Err:    err,
+		}
+		return nil
+	}
+	ret := ev.Data[ev.off : ev.off+int(n)]
+	ev.off += int(n)
 	return ret
 }
@@ -0,0 +1,183 @@
+package wl
+
+import (
+	"fmt"
+	"net"
+	stdos "os"
+	"testing"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/yalue/native_endian"
+)
+
+type motionCounter int
+
+func (m *motionCounter) HandlePointerMotion(PointerMotionEvent) {
+	*m++
+}
+
+// socketpair returns a client Context and the compositor end of the connection
+func socketpair(tb testing.TB) (*Context, *net.UnixConn) {
+	fds, err := sys.Socketpair()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	var conns [2]*net.UnixConn
+	for i, fd := range fds {
+		f := stdos.NewFile(uintptr(fd), "socketpair")
+		c, err := net.FileConn(f)
+		f.Close()
+		if err != nil {
+			tb.Fatal(err)
+		}
+		conns[i] = c.(*net.UnixConn)
+	}
+	display, err := ConnectConn(conns[0])
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return display.Context(), conns[1]
+}
+
+// motionEvents encodes n wl_pointer.motion events
+func motionEvents(id ProxyId, n int) []byte {
+	msg := make([]byte, 20)
+	ne := native_endian.NativeEndian()
+	ne.PutUint32(msg[0:], uint32(id))
+	ne.PutUint32(msg[4:], 20<<16|2)
+	ne.PutUint32(msg[8:], 1234)
+	ne.PutUint32(msg[12:], uint32(FloatToFixed(10.5)))
+	ne.PutUint32(msg[16:], uint32(FloatToFixed(20.25)))
+	var buf []byte
+	for i := 0; i < n; i++ {
+		buf = append(buf, msg...)
+	}
+	return buf
+}
+
+// globalEvent encodes a wl_registry.global event
+func globalEvent(id ProxyId, name uint32, iface string) []byte {
+	l := uint32(len(iface) + 1)
+	size := 8 + 4 + 4 + int(padded(l)) + 4
+	msg := make([]byte, size)
+	ne := native_endian.NativeEndian()
+	ne.PutUint32(msg[0:], uint32(id))
+	ne.PutUint32(msg[4:], uint32(size)<<16)
+	ne.PutUint32(msg[8:], name)
+	ne.PutUint32(msg[12:], l)
+	copy(msg[16:], iface)
+	ne.PutUint32(msg[size-4:], 1)
+	return msg
+}
+
+type globalNames []string
+
+func (g *globalNames) HandleRegistryGlobal(ev RegistryGlobalEvent) {
+	*g = append(*g, ev.Interface)
+}
+
+// TestStringEventsReuseBuffer checks the strings decoded from the events outlive the input buffer,
+// which is reused once the events are dispatched
+func TestStringEventsReuseBuffer(t *testing.T) {
+	ctx, server := socketpair(t)
+	defer ctx.Close()
+	defer server.Close()
+
+	registry := NewRegistry(ctx)
+	ctx.allocate(registry)
+	var names globalNames
+	registry.AddGlobalHandler(&names)
+
+	const n = 1000
+	var data *byte
+	for i := 0; i < n; i++ {
+		if _, err := server.Write(globalEvent(registry.Id(), uint32(i), fmt.Sprintf("wl_global_%d", i))); err != nil {
+			t.Fatal(err)
+		}
+		if err := ctx.Run(); err != nil {
+			t.Fatal(err)
+		}
+		if i == 0 {
+			data = &ctx.in.data[0]
+		} else if &ctx.in.data[0] != data {
+			t.Fatalf("a new input buffer was started after %d string events", i)
+		}
+	}
+	for i, name := range names {
+		if want := fmt.Sprintf("wl_global_%d", i); name != want {
+			t.Fatalf("global %d is %q, want %q", i, name, want)
+		}
+	}
+	if len(names) != n {
+		t.Errorf("%d globals, want %d", len(names), n)
+	}
+}
+
+// BenchmarkReadPointerMotion dispatches pointer motion events flushed by the compositor
+// in batches, the reads/event metric shows the number of recvmsg calls per event
+func BenchmarkReadPointerMotion(b *testing.B) {
+	ctx, server := socketpair(b)
+	defer ctx.Close()
+	defer server.Close()
+
+	pointer := NewPointer(ctx)
+	// as if created by wl_seat.get_pointer
+	ctx.allocate(pointer)
+	var motions motionCounter
+	pointer.AddMotionHandler(&motions)
+
+	const batch = 64
+	batchBytes := motionEvents(pointer.Id(), batch)
+	go func() {
+		for sent := 0; sent < b.N; sent += batch {
+			if _, err := server.Write(batchBytes); err != nil {
+				return
+			}
+		}
+	}()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := ctx.Run(); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	if int(motions) != b.N {
+		b.Fatalf("dispatched %d motion events, want %d", motions, b.N)
+	}
+	b.ReportMetric(float64(ctx.reads)/float64(b.N), "reads/event")
+}
+
+// FuzzReadEvent splits arbitrary bytes received on the connection into events, routes them
+// and dispatches them
+func FuzzReadEvent(f *testing.F) {
+	ctx, server := socketpair(f)
+	defer ctx.Close()
+	defer server.Close()
+
+	pointer := NewPointer(ctx)
+	// as if created by wl_seat.get_pointer
+	ctx.allocate(pointer)
+	var motions motionCounter
+	pointer.AddMotionHandler(&motions)
+
+	f.Add(motionEvents(pointer.Id(), 2))
+	f.Add([]byte{1, 0, 0, 0, 1, 0, 12, 0, 5, 0, 0, 0})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		ctx.in = inBuffer{data: append([]byte(nil), data...), end: len(data)}
+		for {
+			ev, err := ctx.readEvent()
+			if ev == nil || err != nil {
+				return
+			}
+			ctx.rmu.Lock()
+			q := ctx.route(ev)
+			ctx.rmu.Unlock()
+			if q != nil {
+				ctx.dispatchClosing(ev, nil)
+			}
+		}
+	})
+}
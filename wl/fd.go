@@ -1,6 +1,77 @@
 package wl
 
+import "github.com/neurlang/wayland/os"
+
 // Fd (KeyboardKeymapEvent Fd) is used internally to retrieve the file descriptor from an event
 func (e *KeyboardKeymapEvent) Fd() (uintptr, error) {
 	return e.fd, e.fdError
 }
+
+// maxFdsPerMsg is the maximum number of file descriptors passed in a single sendmsg (SCM_MAX_FD)
+const maxFdsPerMsg = 253
+
+// fdRing is the per-connection queue of the received file descriptors, they are consumed
+// in order by the event arguments declaring them
+type fdRing struct {
+	buf  []int
+	head int
+	n    int
+}
+
+func (r *fdRing) push(fd int) {
+	if r.n == len(r.buf) {
+		grown := make([]int, 2*len(r.buf)+8)
+		for i := 0; i < r.n; i++ {
+			grown[i] = r.buf[(r.head+i)%len(r.buf)]
+		}
+		r.buf = grown
+		r.head = 0
+	}
+	r.buf[(r.head+r.n)%len(r.buf)] = fd
+	r.n++
+}
+
+func (r *fdRing) pop() (int, bool) {
+	if r.n == 0 {
+		return -1, false
+	}
+	fd := r.buf[r.head]
+	r.head = (r.head + 1) % len(r.buf)
+	r.n--
+	return fd, true
+}
+
+func (r *fdRing) len() int {
+	return r.n
+}
+
+// drop closes the next n file descriptors
+func (r *fdRing) drop(n int) {
+	for ; n > 0; n-- {
+		fd, ok := r.pop()
+		if !ok {
+			return
+		}
+		os.Close(fd)
+	}
+}
+
+// closeAll closes all the queued file descriptors
+func (r *fdRing) closeAll() {
+	r.drop(r.n)
+}
+
+// fdCount returns the number of file descriptors an event carries according to
+// the interface description, or -1 when unknown
+func fdCount(iface *Interface, opcode uint32) int {
+	if iface == nil || int(opcode) >= len(iface.Events) {
+		return -1
+	}
+	n := 0
+	for _, a := range iface.Events[opcode].Args {
+		if a.Type == ArgFd {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,92 @@
+package wl_test
+
+import (
+	"testing"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wlclient"
+	"github.com/neurlang/wayland/wltest"
+	"golang.org/x/sys/unix"
+)
+
+// keymapSizes records the sizes of the files received by wl_keyboard.keymap
+type keymapSizes []int64
+
+func (k *keymapSizes) HandleKeyboardKeymap(ev wl.KeyboardKeymapEvent) {
+	fd, err := ev.Fd()
+	if err != nil {
+		*k = append(*k, -1)
+		return
+	}
+	defer sys.Close(int(fd))
+	var st unix.Stat_t
+	if unix.Fstat(int(fd), &st) != nil {
+		*k = append(*k, -1)
+		return
+	}
+	*k = append(*k, st.Size)
+}
+
+// sendFile sends an event with a new file of the size as the fd argument
+func sendFile(t *testing.T, srv *wltest.Server, id wl.ProxyId, opcode uint32, size int64) {
+	file, err := sys.CreateAnonymousFile(size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if err := srv.SendEvent(id, opcode, uint32(1), file.Fd(), uint32(size)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestEventFds sends fds to known and unknown objects, every event gets its own fds and the fds of
+// an event to an unknown object are closed, never passed to the next events
+func TestEventFds(t *testing.T) {
+	display, srv, err := wltest.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	defer display.Context().Close()
+	srv.AddGlobal("wl_seat", 5)
+	registry, err := display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	globals := wl.NewGlobalTracker(registry)
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+	p, err := globals.Bind("wl_seat", 1, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyboard, err := p.(*wl.Seat).GetKeyboard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sizes keymapSizes
+	keyboard.AddKeymapHandler(&sizes)
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+
+	sendFile(t, srv, keyboard.Id(), 0, 100)
+	sendFile(t, srv, keyboard.Id(), 0, 200)
+	sendFile(t, srv, 500, 0, 300)
+	// an event without fds to an unknown object, read along with the next keymap and its fd
+	if err := srv.SendEvent(501, 0, uint32(1)); err != nil {
+		t.Fatal(err)
+	}
+	sendFile(t, srv, keyboard.Id(), 0, 400)
+	srv.Flush()
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+
+	// only the fd of the unknown object is dropped, the last keymap received along with it keeps its own
+	if len(sizes) != 3 || sizes[0] != 100 || sizes[1] != 200 || sizes[2] != 400 {
+		t.Errorf("keymaps of %v bytes, want [100 200 400]", sizes)
+	}
+}
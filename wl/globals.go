@@ -0,0 +1,269 @@
+package wl
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var proxyConstructors = struct {
+	sync.RWMutex
+	m map[string]func(*Context) Proxy
+}{m: make(map[string]func(*Context) Proxy)}
+
+// RegisterProxy makes NewProxyOf create the proxies of the interface by new, the generated
+// packages register their proxy types on init
+func RegisterProxy(iface *Interface, new func(ctx *Context) Proxy) {
+	proxyConstructors.Lock()
+	proxyConstructors.m[iface.Name] = new
+	proxyConstructors.Unlock()
+}
+
+// NewProxyOf creates a new proxy of the interface registered in the Context, such as a *Seat
+// for "wl_seat". It returns nil when no generated package registered the interface.
+func NewProxyOf(ctx *Context, iface string) Proxy {
+	proxyConstructors.RLock()
+	new := proxyConstructors.m[iface]
+	proxyConstructors.RUnlock()
+	if new == nil {
+		return nil
+	}
+	return new(ctx)
+}
+
+// ErrGlobalNotFound is returned by GlobalTracker Bind when the compositor announced no global of the interface
+var ErrGlobalNotFound = errors.New("global not found")
+
+// ErrGlobalVersionTooOld is returned by the GlobalTracker bind methods when the global is older than the
+// minimum version
+var ErrGlobalVersionTooOld = errors.New("global version too old")
+
+// ErrUnknownInterface is returned by the GlobalTracker bind methods that create the proxy when no
+// generated package registered the interface, see GlobalTracker BindProxy
+var ErrUnknownInterface = errors.New("unknown interface")
+
+// Global is a global object announced by the compositor
+type Global struct {
+	Name      uint32
+	Interface string
+	Version   uint32
+}
+
+// GlobalAddHandler is implemented by the receivers of the globals added to a GlobalTracker
+type GlobalAddHandler interface {
+	HandleGlobalAdd(Global)
+}
+
+// GlobalRemoveHandler is implemented by the receivers of the globals removed from a GlobalTracker.
+// The proxies are the ones bound to the global by the GlobalTracker, the handler should destroy them.
+type GlobalRemoveHandler interface {
+	HandleGlobalRemove(g Global, proxies []Proxy)
+}
+
+// GlobalTracker records the globals announced on a Registry and binds them by interface name,
+// negotiating the version. It replaces the switch on the interface name of the
+// RegistryGlobalHandler of every client:
+//
+//	globals := wl.NewGlobalTracker(registry)
+//	// the sync gets done after the initial globals are announced
+//	err := wlclient.DisplayRoundtrip(display)
+//	p, err := globals.Bind("wl_compositor", 1, 4)
+//	compositor := p.(*wl.Compositor)
+//
+// Globals appearing and disappearing later, such as outputs and seats, are reported to the handlers
+// added by AddGlobalAddHandler and AddGlobalRemoveHandler. The handlers run on the goroutine
+// dispatching the events of the Registry.
+type GlobalTracker struct {
+	mu             sync.Mutex
+	registry       *Registry
+	globals        []Global
+	bound          map[uint32][]Proxy
+	addHandlers    []GlobalAddHandler
+	removeHandlers []GlobalRemoveHandler
+}
+
+// NewGlobalTracker creates a GlobalTracker of the globals announced on the registry from now on,
+// so it is created right after Display GetRegistry
+func NewGlobalTracker(registry *Registry) *GlobalTracker {
+	t := &GlobalTracker{
+		registry: registry,
+		bound:    make(map[uint32][]Proxy),
+	}
+	registry.AddGlobalHandler(t)
+	registry.AddGlobalRemoveHandler(t)
+	return t
+}
+
+// Registry (GlobalTracker Registry) returns the tracked Registry
+func (t *GlobalTracker) Registry() *Registry {
+	return t.registry
+}
+
+// HandleRegistryGlobal (GlobalTracker HandleRegistryGlobal) records the global and runs the add handlers
+func (t *GlobalTracker) HandleRegistryGlobal(ev RegistryGlobalEvent) {
+	g := Global{Name: ev.Name, Interface: ev.Interface, Version: ev.Version}
+	t.mu.Lock()
+	t.globals = append(t.globals, g)
+	handlers := t.addHandlers
+	t.mu.Unlock()
+	for _, h := range handlers {
+		h.HandleGlobalAdd(g)
+	}
+}
+
+// HandleRegistryGlobalRemove (GlobalTracker HandleRegistryGlobalRemove) forgets the global and runs the
+// remove handlers
+func (t *GlobalTracker) HandleRegistryGlobalRemove(ev RegistryGlobalRemoveEvent) {
+	t.mu.Lock()
+	var g Global
+	found := false
+	for i, e := range t.globals {
+		if e.Name == ev.Name {
+			g, found = e, true
+			t.globals = append(t.globals[:i:i], t.globals[i+1:]...)
+			break
+		}
+	}
+	proxies := t.bound[ev.Name]
+	delete(t.bound, ev.Name)
+	handlers := t.removeHandlers
+	t.mu.Unlock()
+	if !found {
+		return
+	}
+	for _, h := range handlers {
+		h.HandleGlobalRemove(g, proxies)
+	}
+}
+
+// AddGlobalAddHandler (GlobalTracker AddGlobalAddHandler) adds a handler of the added globals,
+// it is run for the globals already announced first
+func (t *GlobalTracker) AddGlobalAddHandler(h GlobalAddHandler) {
+	if h == nil {
+		return
+	}
+	t.mu.Lock()
+	t.addHandlers = append(t.addHandlers, h)
+	globals := append([]Global(nil), t.globals...)
+	t.mu.Unlock()
+	for _, g := range globals {
+		h.HandleGlobalAdd(g)
+	}
+}
+
+// RemoveGlobalAddHandler (GlobalTracker RemoveGlobalAddHandler) removes a handler previously added by
+// AddGlobalAddHandler
+func (t *GlobalTracker) RemoveGlobalAddHandler(h GlobalAddHandler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, e := range t.addHandlers {
+		if e == h {
+			t.addHandlers = append(t.addHandlers[:i:i], t.addHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// AddGlobalRemoveHandler (GlobalTracker AddGlobalRemoveHandler) adds a handler of the removed globals
+func (t *GlobalTracker) AddGlobalRemoveHandler(h GlobalRemoveHandler) {
+	if h == nil {
+		return
+	}
+	t.mu.Lock()
+	t.removeHandlers = append(t.removeHandlers, h)
+	t.mu.Unlock()
+}
+
+// RemoveGlobalRemoveHandler (GlobalTracker RemoveGlobalRemoveHandler) removes a handler previously added by
+// AddGlobalRemoveHandler
+func (t *GlobalTracker) RemoveGlobalRemoveHandler(h GlobalRemoveHandler) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for i, e := range t.removeHandlers {
+		if e == h {
+			t.removeHandlers = append(t.removeHandlers[:i:i], t.removeHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// Globals (GlobalTracker Globals) returns the globals announced and not removed, in the announce order
+func (t *GlobalTracker) Globals() []Global {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]Global(nil), t.globals...)
+}
+
+// Lookup (GlobalTracker Lookup) returns the first global of the interface
+func (t *GlobalTracker) Lookup(iface string) (Global, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, g := range t.globals {
+		if g.Interface == iface {
+			return g, true
+		}
+	}
+	return Global{}, false
+}
+
+// Bind (GlobalTracker Bind) binds the first global of the interface, see BindGlobal
+func (t *GlobalTracker) Bind(iface string, min, max uint32) (Proxy, error) {
+	g, ok := t.Lookup(iface)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrGlobalNotFound, iface)
+	}
+	return t.BindGlobal(g, min, max)
+}
+
+// BindGlobal (GlobalTracker BindGlobal) creates the proxy of the interface of the global, such as a *Seat
+// for a wl_seat, and binds it, see BindProxy for the version
+func (t *GlobalTracker) BindGlobal(g Global, min, max uint32) (Proxy, error) {
+	version, err := negotiate(g, min, max)
+	if err != nil {
+		return nil, err
+	}
+	p := NewProxyOf(t.registry.Context(), g.Interface)
+	if p == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownInterface, g.Interface)
+	}
+	if err := t.bind(g, version, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// BindProxy (GlobalTracker BindProxy) binds the proxy to the global, with the highest version supported by
+// the global, the bindings and max. Zero max means no limit besides the bindings. A global older
+// than min is not bound, an error wrapping ErrGlobalVersionTooOld is returned.
+func (t *GlobalTracker) BindProxy(g Global, min, max uint32, p Proxy) error {
+	version, err := negotiate(g, min, max)
+	if err != nil {
+		return err
+	}
+	return t.bind(g, version, p)
+}
+
+func (t *GlobalTracker) bind(g Global, version uint32, p Proxy) error {
+	if err := t.registry.Bind(g.Name, g.Interface, version, p); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	t.bound[g.Name] = append(t.bound[g.Name], p)
+	t.mu.Unlock()
+	return nil
+}
+
+// negotiate returns the version to bind the global with
+func negotiate(g Global, min, max uint32) (uint32, error) {
+	version := g.Version
+	if iface := LookupInterface(g.Interface); iface != nil && iface.Version < version {
+		version = iface.Version
+	}
+	if max != 0 && max < version {
+		version = max
+	}
+	if version < min || version == 0 {
+		return 0, fmt.Errorf("%w: %s version %d, need %d", ErrGlobalVersionTooOld, g.Interface, g.Version, min)
+	}
+	return version, nil
+}
@@ -0,0 +1,154 @@
+package wl_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/neurlang/wayland/wl"
+)
+
+type globalEvents struct {
+	added   []wl.Global
+	removed []wl.Global
+	proxies [][]wl.Proxy
+}
+
+func (e *globalEvents) HandleGlobalAdd(g wl.Global) {
+	e.added = append(e.added, g)
+}
+
+func (e *globalEvents) HandleGlobalRemove(g wl.Global, proxies []wl.Proxy) {
+	e.removed = append(e.removed, g)
+	e.proxies = append(e.proxies, proxies)
+}
+
+func TestGlobalTrackerBind(t *testing.T) {
+	c := newConcurrentClient(t)
+	c.srv.AddGlobal("zwp_unknown_v1", 1)
+	registry, err := c.display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	globals := wl.NewGlobalTracker(registry)
+	c.roundtrip(t)
+
+	seat := wl.LookupInterface("wl_seat").Version
+	if seat > 5 {
+		seat = 5
+	}
+	for _, test := range []struct {
+		iface    string
+		min, max uint32
+		version  uint32
+		err      error
+	}{
+		{"wl_seat", 1, 0, seat, nil},
+		{"wl_seat", 1, 3, 3, nil},
+		{"wl_seat", 5, 5, 5, nil},
+		{"wl_seat", 6, 7, 0, wl.ErrGlobalVersionTooOld},
+		{"wl_output", 1, 3, 0, wl.ErrGlobalNotFound},
+		{"zwp_unknown_v1", 1, 1, 0, wl.ErrUnknownInterface},
+	} {
+		p, err := globals.Bind(test.iface, test.min, test.max)
+		if !errors.Is(err, test.err) {
+			t.Errorf("bind %s %d-%d returned %v, want %v", test.iface, test.min, test.max, err, test.err)
+			continue
+		}
+		if err == nil && p.Version() != test.version {
+			t.Errorf("bind %s %d-%d at version %d, want %d", test.iface, test.min, test.max, p.Version(), test.version)
+		}
+	}
+	c.roundtrip(t)
+
+	// the versions sent in the bind requests
+	var versions []uint32
+	for _, r := range c.srv.RequestsTo("wl_registry") {
+		if r.Name != "bind" {
+			continue
+		}
+		// name, then the interface and version of the new_id
+		ev := r.Event()
+		ev.Uint32()
+		if ev.String() == "wl_seat" {
+			versions = append(versions, ev.Uint32())
+		}
+	}
+	// the wl_seat of newConcurrentClient is bound at 5
+	want := []uint32{5, seat, 3, 5}
+	if len(versions) != len(want) {
+		t.Fatalf("wl_seat bound at versions %v, want %v", versions, want)
+	}
+	for i := range want {
+		if versions[i] != want[i] {
+			t.Fatalf("wl_seat bound at versions %v, want %v", versions, want)
+		}
+	}
+}
+
+// TestGlobalTrackerRemove hotplugs outputs, the remove handler gets the proxies bound to the removed one
+func TestGlobalTrackerRemove(t *testing.T) {
+	c := newConcurrentClient(t)
+	registry, err := c.display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	globals := wl.NewGlobalTracker(registry)
+	c.roundtrip(t)
+
+	var events globalEvents
+	globals.AddGlobalAddHandler(&events)
+	globals.AddGlobalRemoveHandler(&events)
+	// the handler is run for the globals already announced
+	if len(events.added) != 3 {
+		t.Fatalf("added %v, want the 3 initial globals", events.added)
+	}
+
+	first := c.srv.AddGlobal("wl_output", 3)
+	second := c.srv.AddGlobal("wl_output", 2)
+	c.roundtrip(t)
+	if n := len(events.added); n != 5 || events.added[3].Name != first || events.added[4].Name != second {
+		t.Fatalf("added %v, want the outputs %d and %d last", events.added, first, second)
+	}
+
+	var bound []wl.Proxy
+	for i := 0; i < 2; i++ {
+		g, _ := globals.Lookup("wl_output")
+		p, err := globals.BindGlobal(g, 1, 3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bound = append(bound, p)
+	}
+	c.roundtrip(t)
+
+	c.srv.RemoveGlobal(first)
+	// removing an unknown global is ignored
+	c.srv.RemoveGlobal(first + 100)
+	c.roundtrip(t)
+
+	if len(events.removed) != 1 || events.removed[0].Name != first || events.removed[0].Interface != "wl_output" {
+		t.Fatalf("removed %v, want the output %d", events.removed, first)
+	}
+	if len(events.proxies[0]) != 2 || events.proxies[0][0] != bound[0] || events.proxies[0][1] != bound[1] {
+		t.Errorf("removed proxies %v, want the bound %v", events.proxies[0], bound)
+	}
+	g, ok := globals.Lookup("wl_output")
+	if !ok || g.Name != second {
+		t.Errorf("output %v left, want %d", g, second)
+	}
+	for _, g := range globals.Globals() {
+		if g.Name == first {
+			t.Errorf("removed output %d still tracked", first)
+		}
+	}
+
+	globals.RemoveGlobalRemoveHandler(&events)
+	c.srv.RemoveGlobal(second)
+	c.roundtrip(t)
+	if len(events.removed) != 1 {
+		t.Errorf("removed %v after removing the handler", events.removed)
+	}
+	if _, ok := globals.Lookup("wl_output"); ok {
+		t.Error("the last output is still tracked")
+	}
+}
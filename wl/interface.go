@@ -0,0 +1,123 @@
+package wl
+
+import "sync"
+
+// ArgType is the wire type of a request or event argument
+type ArgType byte
+
+// The argument types, named after the libwayland signature characters
+const (
+	ArgInt    ArgType = 'i'
+	ArgUint   ArgType = 'u'
+	ArgFixed  ArgType = 'f'
+	ArgString ArgType = 's'
+	ArgObject ArgType = 'o'
+	ArgNewId  ArgType = 'n'
+	ArgArray  ArgType = 'a'
+	ArgFd     ArgType = 'h'
+)
+
+// Arg describes a single argument as it appears on the wire. A new_id without
+// a specific interface is described by three args: the interface name, the version
+// and the new id.
+type Arg struct {
+	Name      string
+	Type      ArgType
+	Interface string
+	Nullable  bool
+}
+
+// Message describes a request or an event
+type Message struct {
+	Name       string
+	Since      uint32
+	Destructor bool
+	Args       []Arg
+}
+
+// EnumEntry is a single named value of an enum
+type EnumEntry struct {
+	Name  string
+	Value uint32
+}
+
+// Enum describes an enum, such as the error codes of an interface
+type Enum struct {
+	Name     string
+	Bitfield bool
+	Entries  []EnumEntry
+}
+
+// Interface describes a protocol interface, it is emitted by go-wayland-scanner
+// for every interface of the protocol XML
+type Interface struct {
+	Name     string
+	Version  uint32
+	Requests []Message
+	Events   []Message
+	Enums    []Enum
+}
+
+// EnumName (Interface EnumName) returns the symbolic name of the value of the enum,
+// such as "wl_display.error.invalid_object", or "" when the value is unknown
+func (i *Interface) EnumName(enum string, value uint32) string {
+	if i == nil {
+		return ""
+	}
+	for _, e := range i.Enums {
+		if e.Name != enum {
+			continue
+		}
+		for _, entry := range e.Entries {
+			if entry.Value == value {
+				return i.Name + "." + e.Name + "." + entry.Name
+			}
+		}
+	}
+	return ""
+}
+
+// describer is implemented by the generated proxies
+type describer interface {
+	Interface() *Interface
+}
+
+var interfaces = struct {
+	sync.RWMutex
+	m map[string]*Interface
+}{m: make(map[string]*Interface)}
+
+// RegisterInterface makes the interface description available to LookupInterface,
+// generated packages register their interfaces on init
+func RegisterInterface(i *Interface) {
+	interfaces.Lock()
+	interfaces.m[i.Name] = i
+	interfaces.Unlock()
+}
+
+// LookupInterface returns the description of a registered interface by its name, or nil
+func LookupInterface(name string) *Interface {
+	interfaces.RLock()
+	defer interfaces.RUnlock()
+	return interfaces.m[name]
+}
+
+// internedName returns the name of the registered interface spelled by b, or "" when there is none,
+// without allocating
+func internedName(b []byte) string {
+	interfaces.RLock()
+	i := interfaces.m[string(b)]
+	interfaces.RUnlock()
+	if i == nil {
+		return ""
+	}
+	return i.Name
+}
+
+// proxyInterface returns the description of the proxy interface, or nil when unknown
+func proxyInterface(p Proxy) *Interface {
+	if d, ok := p.(describer); ok {
+		return d.Interface()
+	}
+	return nil
+}
@@ -0,0 +1,402 @@
+package wl
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+
+	"github.com/yalue/native_endian"
+)
+
+// EventQueue is a queue of events that is dispatched separately from the other queues of the Context.
+// Every proxy belongs to the default queue of the Context, which is dispatched by Context Run, unless
+// it is assigned to another queue using SetQueue. The events are routed to the queue of the proxy they
+// are received on, so a goroutine dispatching its own queue may safely own the proxies of the queue.
+//
+// The proxies created by a request are assigned to the queue of the proxy the request is sent on.
+type EventQueue struct {
+	ctx       *Context
+	events    []*Event
+	head      int
+	destroyed bool
+}
+
+// queued is implemented by BaseProxy, ctx.rmu must be held
+type queued interface {
+	eventQueue() *EventQueue
+}
+
+// NewEventQueue (Context NewEventQueue) creates a new event queue
+func (ctx *Context) NewEventQueue() *EventQueue {
+	return &EventQueue{ctx: ctx}
+}
+
+// Dispatch (EventQueue Dispatch) dispatches the queued events, blocking until at least one event
+// arrives when the queue is empty. It returns the number of dispatched events. Events received on
+// destroyed proxies are dropped.
+func (q *EventQueue) Dispatch() (n int, err error) {
+	for {
+		err = q.dispatchOne(context.Background(), nil, true)
+		if err == ErrContextRunProxyNil {
+			continue
+		}
+		if err != nil {
+			return n, err
+		}
+		n++
+		break
+	}
+	for {
+		err = q.dispatchOne(context.Background(), nil, false)
+		if err == errQueueEmpty {
+			return n, nil
+		}
+		if err == ErrContextRunProxyNil {
+			continue
+		}
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// Roundtrip (EventQueue Roundtrip) sends a wl_display.sync request with the callback on the queue
+// and dispatches the queue until the compositor answers it
+func (q *EventQueue) Roundtrip() error {
+	ctx := q.ctx
+	display, ok := ctx.LookupProxy(1).(*Display)
+	if !ok {
+		return ErrContextRunProxyNil
+	}
+	cb := NewCallback(ctx)
+	cb.SetQueue(q)
+	defer cb.Unregister()
+	if err := ctx.SendRequest(display, 0, cb); err != nil {
+		return err
+	}
+	if err := ctx.Flush(); err != nil {
+		return err
+	}
+	for {
+		err := q.dispatchOne(context.Background(), cb, true)
+		if err == errFoundMyCallback {
+			return nil
+		}
+		if err != nil && err != ErrContextRunProxyNil {
+			return err
+		}
+	}
+}
+
+// Destroy (EventQueue Destroy) drops the events of the queue, the proxies still assigned to it
+// are moved to the default queue of the Context
+func (q *EventQueue) Destroy() {
+	ctx := q.ctx
+	ctx.rmu.Lock()
+	events := q.events[q.head:]
+	q.events = nil
+	q.head = 0
+	q.destroyed = true
+	ctx.rmu.Unlock()
+	for _, ev := range events {
+		ev.CloseFds()
+		ev.release()
+	}
+}
+
+// pending reports whether events are queued, ctx.rmu must be held
+func (q *EventQueue) pending() bool {
+	return q.head < len(q.events)
+}
+
+// dispatchOne dispatches a single event of the queue, reading the connection if needed and allowed,
+// until c is done
+func (q *EventQueue) dispatchOne(c context.Context, cb *Callback, block bool) error {
+	for {
+		ev, err := q.next(c, block)
+		if err != nil {
+			return err
+		}
+		err = q.ctx.dispatchClosing(ev, cb)
+		if err == errEventDiscarded {
+			continue
+		}
+		return err
+	}
+}
+
+// next returns the next event of the queue. When no event is queued and block is set, it reads the
+// connection, or waits for the goroutine reading it, routing the events received to their queues.
+// The reading or waiting is interrupted when c is done, returning the error of c.
+func (q *EventQueue) next(c context.Context, block bool) (*Event, error) {
+	ctx := q.ctx
+	ctx.rmu.Lock()
+	defer ctx.rmu.Unlock()
+	for {
+		if q.pending() {
+			ev := q.events[q.head]
+			q.events[q.head] = nil
+			q.head++
+			if q.head == len(q.events) {
+				// the events are appended to the start of the array again
+				q.events = q.events[:0]
+				q.head = 0
+			}
+			return ev, nil
+		}
+		if !block {
+			return nil, errQueueEmpty
+		}
+		if err := c.Err(); err != nil {
+			return nil, err
+		}
+		if err := ctx.deadError(); err != nil {
+			return nil, err
+		}
+
+		// make sure the compositor is not waiting for our requests
+		ctx.rmu.Unlock()
+		err := ctx.Flush()
+		ctx.rmu.Lock()
+		if err != nil {
+			return nil, err
+		}
+		if q.pending() {
+			continue
+		}
+
+		// the same as PrepareRead and ReadEvents
+		ctx.readers++
+		if err := ctx.readEventsLocked(c); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// pendingObject is an object created by an event of the compositor that was not dispatched yet
+type pendingObject struct {
+	iface   *Interface
+	queue   *EventQueue
+	version uint32
+}
+
+// route takes the fds of the event off the connection and returns the queue of its proxy, ctx.rmu must be held.
+// The events of zombie proxies are discarded, route returns nil for them. So are the events of unknown
+// objects, or unknown to the interface of the object: the number of their fds is unknown, the fds
+// received so far that the events read after it do not take are closed rather than passed to the next events.
+func (ctx *Context) route(ev *Event) *EventQueue {
+	ctx.mu.RLock()
+	proxy, iface, pending, zombie := ctx.lookupRoute(ev.Pid)
+	ctx.mu.RUnlock()
+
+	n := fdCountRoute(proxy, iface, zombie, ev.Opcode)
+	if n < 0 {
+		ctx.recordMessage(RecordEvent, iface, ev.Pid, ev.Opcode, ev.Data, nil)
+		ctx.traceEvent(iface, ev, true)
+		ctx.fds.drop(ctx.fds.len() - ctx.bufferedFds())
+		return nil
+	}
+	for ; n > 0; n-- {
+		fd, ok := ctx.fds.pop()
+		if !ok {
+			break
+		}
+		ev.fds = append(ev.fds, fd)
+	}
+
+	var queue *EventQueue
+	var version uint32
+	switch {
+	case proxy != nil:
+		if p, ok := proxy.(queued); ok {
+			queue = p.eventQueue()
+		}
+		version = proxy.Version()
+	case pending != nil:
+		queue = pending.queue
+		version = pending.version
+	}
+	if queue == nil || queue.destroyed {
+		queue = ctx.queue
+	}
+
+	if _, ok := proxy.(*Display); ok {
+		switch {
+		case ev.Opcode == 0:
+			ctx.protocolError(ev)
+		case ev.Opcode == 1 && len(ev.Data) >= 4:
+			ctx.deleteId(ProxyId(native_endian.NativeEndian().Uint32(ev.Data)))
+		}
+	}
+	ctx.newObjects(iface, ev, queue, version, zombie)
+	ctx.recordMessage(RecordEvent, iface, ev.Pid, ev.Opcode, ev.Data, ev.fds)
+
+	if zombie {
+		ctx.traceEvent(iface, ev, true)
+		ev.CloseFds()
+		return nil
+	}
+	ev.proxy = proxy
+	return queue
+}
+
+// lookupRoute returns the proxy, the interface, the pending object and whether the id is a zombie,
+// ctx.mu must be held
+func (ctx *Context) lookupRoute(id ProxyId) (proxy Proxy, iface *Interface, pending *pendingObject, zombie bool) {
+	if proxy = ctx.objects[id]; proxy != nil {
+		iface = proxyInterface(proxy)
+	} else if pending = ctx.pending[id]; pending != nil {
+		iface = pending.iface
+	} else {
+		iface, zombie = ctx.zombies[id]
+	}
+	return
+}
+
+// fdCountRoute returns the number of fds of the event to the object, or -1 when unknown.
+// The proxies of protocols without a description receive no fds.
+func fdCountRoute(proxy Proxy, iface *Interface, zombie bool, opcode uint32) int {
+	n := fdCount(iface, opcode)
+	if n < 0 && iface == nil && (proxy != nil || zombie) {
+		return 0
+	}
+	return n
+}
+
+// bufferedFds returns the number of fds taken by the events already received after the current one,
+// ctx.rmu must be held. Their fds were received before or along with their bytes.
+func (ctx *Context) bufferedFds() int {
+	data := ctx.in.data[ctx.in.start:ctx.in.end]
+	ctx.mu.RLock()
+	defer ctx.mu.RUnlock()
+	n := 0
+	for len(data) >= 8 {
+		proxy, iface, _, zombie := ctx.lookupRoute(ProxyId(native_endian.NativeEndian().Uint32(data[0:4])))
+		if count := fdCountRoute(proxy, iface, zombie, uint32(native_endian.NativeEndian().Uint16(data[4:6]))); count > 0 {
+			n += count
+		}
+		size := int(native_endian.NativeEndian().Uint16(data[6:8]))
+		if size < 8 || size > len(data) {
+			break
+		}
+		data = data[size:]
+	}
+	return n
+}
+
+// newObjects records the objects created by the event, so that their events are routed before
+// the event is dispatched. The objects created by the events of zombies are zombies too.
+func (ctx *Context) newObjects(iface *Interface, ev *Event, queue *EventQueue, version uint32, zombie bool) {
+	if iface == nil || int(ev.Opcode) >= len(iface.Events) {
+		return
+	}
+	msg := &iface.Events[ev.Opcode]
+	hasNewId := false
+	for _, a := range msg.Args {
+		if a.Type == ArgNewId && a.Interface != "" {
+			hasNewId = true
+		}
+	}
+	if !hasNewId {
+		return
+	}
+	data := ev.Data
+	off := 0
+	for _, a := range msg.Args {
+		if a.Type == ArgFd {
+			continue
+		}
+		if off+4 > len(data) {
+			return
+		}
+		v := native_endian.NativeEndian().Uint32(data[off : off+4])
+		off += 4
+		switch a.Type {
+		case ArgString, ArgArray:
+			off += int(v+3) &^ 3
+		case ArgNewId:
+			if a.Interface == "" || v == 0 {
+				break
+			}
+			id := ProxyId(v)
+			ctx.mu.Lock()
+			if zombie {
+				ctx.zombies[id] = LookupInterface(a.Interface)
+			} else {
+				delete(ctx.zombies, id)
+				ctx.pending[id] = &pendingObject{LookupInterface(a.Interface), queue, version}
+			}
+			ctx.mu.Unlock()
+		}
+	}
+}
+
+// errEventDiscarded is returned by dispatch for the events of destroyed proxies
+var errEventDiscarded = errors.New("event discarded")
+
+// dispatchClosing dispatches the event, closes the fds the handlers did not take and releases the event
+func (ctx *Context) dispatchClosing(ev *Event, cb *Callback) error {
+	defer ev.release()
+	defer ev.CloseFds()
+	return ctx.dispatch(ev, cb)
+}
+
+// dispatch runs the handlers of the event, unless it is the done event of the callback cb.
+// The events of proxies destroyed after the event was routed are discarded.
+func (ctx *Context) dispatch(ev *Event, cb *Callback) error {
+	proxy := ctx.LookupProxy(ev.Pid)
+	if proxy == nil || (ev.proxy != nil && proxy != ev.proxy) {
+		if ev.proxy != nil || ctx.isGone(ev.Pid) {
+			ctx.traceEvent(proxyInterface(ev.proxy), ev, true)
+			return errEventDiscarded
+		}
+		ctx.traceEvent(nil, ev, false)
+		return ErrContextRunProxyNil
+	}
+	ctx.traceEvent(proxyInterface(proxy), ev, false)
+	dispatcher, ok := proxy.(Dispatcher)
+	if !ok || dispatcher == nil {
+		return ErrContextRunNotDispatched
+	}
+	if foundCb, ok := dispatcher.(*Callback); ok && foundCb == cb {
+		return errFoundMyCallback
+	}
+	dispatcher.Dispatch(ev)
+	if _, ok := dispatcher.(*Display); ok && ev.Opcode == 0 {
+		if err := ctx.deadError(); err != nil {
+			return err
+		}
+	}
+	if ev.err != nil {
+		return combinedError{ErrContextRunProtocolError, ev.err}
+	}
+	return nil
+}
+
+// isGone reports whether the id belongs to a zombie, or to an object created by an event that was discarded
+func (ctx *Context) isGone(id ProxyId) bool {
+	ctx.mu.RLock()
+	defer ctx.mu.RUnlock()
+	_, zombie := ctx.zombies[id]
+	_, pending := ctx.pending[id]
+	return zombie || pending
+}
+
+// readError maps the errors of readEvent to the ErrContextRunXXX errors
+func readError(err error) error {
+	if err == io.EOF {
+		return ErrContextRunConnectionClosed
+	}
+	if isTimeout(err) {
+		return ErrContextRunTimeout
+	}
+	return combinedError{ErrContextRunEventReadingError, err}
+}
+
+// isTimeout reports whether the read error is caused by the read deadline
+func isTimeout(err error) bool {
+	var neterr net.Error
+	return errors.As(err, &neterr) && neterr.Timeout()
+}
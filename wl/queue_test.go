@@ -0,0 +1,84 @@
+package wl_test
+
+import (
+	"testing"
+
+	"github.com/neurlang/wayland/wl"
+)
+
+// TestEventQueue routes the events of a proxy on a private queue, they are dispatched by the queue
+// and not by the roundtrips of the default queue
+func TestEventQueue(t *testing.T) {
+	c := newConcurrentClient(t)
+	ctx := c.display.Context()
+
+	q := ctx.NewEventQueue()
+	defer q.Destroy()
+	pointer, err := c.seat.GetPointer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	pointer.SetQueue(q)
+	var motions motionCount
+	pointer.AddMotionHandler(&motions)
+	c.roundtrip(t)
+
+	for i := 0; i < 3; i++ {
+		if err := c.srv.SendEvent(pointer.Id(), 2, uint32(i), float32(1), float32(2)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	c.roundtrip(t)
+	if motions != 0 {
+		t.Fatalf("the default queue dispatched %d motion events of the private queue", motions)
+	}
+	if err := q.Roundtrip(); err != nil {
+		t.Fatal(err)
+	}
+	if motions != 3 {
+		t.Fatalf("the private queue dispatched %d motion events, want 3", motions)
+	}
+}
+
+// TestEventQueueInherited checks the proxies created by a request join the queue of the proxy
+func TestEventQueueInherited(t *testing.T) {
+	c := newConcurrentClient(t)
+	ctx := c.display.Context()
+
+	q := ctx.NewEventQueue()
+	surface, err := c.compositor.CreateSurface()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if surface.Queue() == q {
+		t.Fatal("new surface on the private queue")
+	}
+	surface.SetQueue(q)
+	frame, err := surface.Frame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frame.Queue() != q {
+		t.Error("frame callback not on the queue of its surface")
+	}
+
+	// the proxies of a destroyed queue are moved to the default queue
+	q.Destroy()
+	if err := c.srv.SendEvent(frame.Id(), 0, uint32(1)); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{}, 1)
+	frame.AddDoneHandler(doneFunc(func() { done <- struct{}{} }))
+	c.roundtrip(t)
+	select {
+	case <-done:
+	default:
+		t.Error("the default queue did not dispatch the event of a destroyed queue")
+	}
+}
+
+type doneFunc func()
+
+func (f doneFunc) HandleCallbackDone(wl.CallbackDoneEvent) {
+	f()
+}
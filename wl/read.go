@@ -0,0 +1,236 @@
+package wl
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrContextPrepareReadQueueNotEmpty is returned by PrepareRead when the queue has events
+// that need to be dispatched first, see DispatchPending
+var ErrContextPrepareReadQueueNotEmpty = errors.New("queue not empty, dispatch pending events first")
+
+// Fd (Context Fd) returns the file descriptor of the connection to the compositor, so that it can
+// be polled for reading together with other fds. Use PrepareRead, ReadEvents and DispatchPending
+// to read and dispatch the events, reading the fd directly corrupts the connection.
+func (ctx *Context) Fd() (fd int, err error) {
+	if ctx == nil {
+		return -1, ErrContextNil
+	}
+	ctx.mu.RLock()
+	conn := ctx.conn
+	ctx.mu.RUnlock()
+	if conn == nil {
+		return -1, ErrContextConnNil
+	}
+	raw, err := conn.SyscallConn()
+	if err != nil {
+		return -1, err
+	}
+	err = raw.Control(func(f uintptr) {
+		fd = int(f)
+	})
+	if err != nil {
+		return -1, err
+	}
+	return fd, nil
+}
+
+// DispatchPending (Context DispatchPending) dispatches the events of the default queue that were
+// already read from the connection, without reading it. It returns the number of dispatched events.
+func (ctx *Context) DispatchPending() (int, error) {
+	if ctx == nil {
+		return 0, ErrContextNil
+	}
+	return ctx.queue.DispatchPending()
+}
+
+// DispatchPending (EventQueue DispatchPending) dispatches the events of the queue that were
+// already read from the connection, without reading it. It returns the number of dispatched events.
+func (q *EventQueue) DispatchPending() (n int, err error) {
+	for {
+		err = q.dispatchOne(context.Background(), nil, false)
+		if err == errQueueEmpty {
+			return n, nil
+		}
+		if err == ErrContextRunProxyNil {
+			continue
+		}
+		if err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+// PrepareRead (Context PrepareRead) announces the intention to read events into the default queue,
+// it works like wl_display_prepare_read. When it succeeds, exactly one of ReadEvents or CancelRead
+// must follow. The usual sequence is:
+//
+//	for ctx.PrepareRead() != nil {
+//		ctx.DispatchPending()
+//	}
+//	ctx.Flush()
+//	// poll ctx.Fd() together with other fds
+//	if readable {
+//		ctx.ReadEvents()
+//	} else {
+//		ctx.CancelRead()
+//	}
+//	ctx.DispatchPending()
+//
+// Several goroutines may prepare to read at the same time, only the last one calling ReadEvents
+// reads the connection and the others wait for it.
+func (ctx *Context) PrepareRead() error {
+	if ctx == nil {
+		return ErrContextNil
+	}
+	return ctx.queue.PrepareRead()
+}
+
+// PrepareRead (EventQueue PrepareRead) announces the intention to read events into the queue,
+// it fails with ErrContextPrepareReadQueueNotEmpty when the queue has pending events,
+// see Context PrepareRead
+func (q *EventQueue) PrepareRead() error {
+	ctx := q.ctx
+	ctx.rmu.Lock()
+	defer ctx.rmu.Unlock()
+	if q.pending() {
+		return ErrContextPrepareReadQueueNotEmpty
+	}
+	ctx.readers++
+	return nil
+}
+
+// ReadEvents (Context ReadEvents) reads the events after a successful PrepareRead and routes them
+// to their queues, it works like wl_display_read_events. The last goroutine calling it reads
+// the connection once, blocking until some bytes arrive, so it should be called when the Fd
+// is readable. The other goroutines wait until the read is done.
+func (ctx *Context) ReadEvents() error {
+	if ctx == nil {
+		return ErrContextNil
+	}
+	ctx.rmu.Lock()
+	defer ctx.rmu.Unlock()
+	return ctx.readEventsLocked(context.Background())
+}
+
+// CancelRead (Context CancelRead) cancels a successful PrepareRead, it works like wl_display_cancel_read
+func (ctx *Context) CancelRead() {
+	if ctx == nil {
+		return
+	}
+	ctx.rmu.Lock()
+	ctx.readers--
+	if ctx.readers == 0 {
+		ctx.readSerial++
+		ctx.readErr = nil
+		ctx.rcond.Broadcast()
+	}
+	ctx.rmu.Unlock()
+}
+
+// readEventsLocked is ReadEvents with ctx.rmu held, the reading or waiting is interrupted when c is done
+func (ctx *Context) readEventsLocked(c context.Context) error {
+	ctx.readers--
+	if err := ctx.deadError(); err != nil {
+		return err
+	}
+
+	// another goroutine is going to read, or is reading, wait for it to route the events
+	if ctx.readers > 0 || ctx.reading {
+		serial := ctx.readSerial
+		stop := ctx.interruptOn(c, false)
+		for serial == ctx.readSerial && c.Err() == nil {
+			ctx.rcond.Wait()
+		}
+		stop()
+		if serial == ctx.readSerial {
+			return c.Err()
+		}
+		return ctx.readErr
+	}
+
+	ctx.reading = true
+	stop := ctx.interruptOn(c, true)
+	ctx.rmu.Unlock()
+	err := ctx.readChunk()
+	ctx.rmu.Lock()
+	stop()
+	interrupted := ctx.readInterrupted
+	if interrupted {
+		ctx.readInterrupted = false
+		if rerr := ctx.setReadDeadline(time.Time{}); err == nil {
+			err = rerr
+		}
+	}
+	ctx.reading = false
+
+	for err == nil {
+		var ev *Event
+		ev, err = ctx.readEvent()
+		if ev == nil {
+			break
+		}
+		if target := ctx.route(ev); target != nil {
+			target.events = append(target.events, ev)
+		} else {
+			ev.release()
+		}
+	}
+	if interrupted && isTimeout(err) {
+		// nothing was read, the waiting goroutines retry
+		err = c.Err()
+		ctx.readErr = nil
+	} else {
+		if err != nil {
+			err = readError(err)
+		}
+		ctx.readErr = err
+	}
+
+	ctx.readSerial++
+	ctx.rcond.Broadcast()
+	return err
+}
+
+// interruptOn wakes up the goroutines waiting on ctx.rcond once c is done. When reader is set,
+// it also interrupts the blocking read of the current round. The returned func stops it.
+func (ctx *Context) interruptOn(c context.Context, reader bool) (stop func()) {
+	done := c.Done()
+	if done == nil {
+		return func() {}
+	}
+	round := ctx.readSerial
+	quit := make(chan struct{})
+	go func() {
+		select {
+		case <-done:
+		case <-quit:
+			return
+		}
+		ctx.rmu.Lock()
+		if reader && ctx.reading && ctx.readSerial == round {
+			// a deadline in the past makes the read return immediately
+			if ctx.setReadDeadline(time.Unix(1, 0)) == nil {
+				ctx.readInterrupted = true
+			}
+		}
+		ctx.rcond.Broadcast()
+		ctx.rmu.Unlock()
+	}()
+	return func() {
+		close(quit)
+	}
+}
+
+// setReadDeadline sets the read deadline of the connection
+func (ctx *Context) setReadDeadline(t time.Time) error {
+	ctx.mu.RLock()
+	conn := ctx.conn
+	ctx.mu.RUnlock()
+	if conn == nil {
+		return ErrContextConnNil
+	}
+	return conn.SetReadDeadline(t)
+}
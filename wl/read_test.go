@@ -0,0 +1,127 @@
+package wl_test
+
+import (
+	"testing"
+
+	"github.com/neurlang/wayland/wl"
+	"golang.org/x/sys/unix"
+)
+
+// poll waits until the connection is readable, at most timeout milliseconds
+func poll(t *testing.T, ctx *wl.Context, timeout int) bool {
+	fd, err := ctx.Fd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fds := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}}
+	for {
+		n, err := unix.Poll(fds, timeout)
+		if err == unix.EINTR {
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n > 0
+	}
+}
+
+// prepareRead dispatches the pending events until the Context is prepared to read
+func prepareRead(t *testing.T, ctx *wl.Context) {
+	for ctx.PrepareRead() != nil {
+		if _, err := ctx.DispatchPending(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestPrepareRead reads the events the way an external poll loop does
+func TestPrepareRead(t *testing.T) {
+	c := newConcurrentClient(t)
+	ctx := c.display.Context()
+	pointer, err := c.seat.GetPointer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var motions motionCount
+	pointer.AddMotionHandler(&motions)
+	c.roundtrip(t)
+
+	prepareRead(t, ctx)
+	if err := ctx.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if poll(t, ctx, 50) {
+		t.Fatal("readable without events")
+	}
+	ctx.CancelRead()
+
+	for i := 0; i < 2; i++ {
+		if err := c.srv.SendEvent(pointer.Id(), 2, uint32(i), float32(1), float32(2)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read := 0
+	for read < 2 {
+		prepareRead(t, ctx)
+		if !poll(t, ctx, 5000) {
+			ctx.CancelRead()
+			t.Fatal("not readable after the events were sent")
+		}
+		if err := ctx.ReadEvents(); err != nil {
+			t.Fatal(err)
+		}
+		if int(motions) != read {
+			t.Fatal("ReadEvents dispatched the events")
+		}
+		if read == 0 && ctx.PrepareRead() != wl.ErrContextPrepareReadQueueNotEmpty {
+			t.Fatal("prepared to read with events pending")
+		}
+		n, err := ctx.DispatchPending()
+		if err != nil {
+			t.Fatal(err)
+		}
+		read += n
+		if int(motions) != read {
+			t.Fatalf("dispatched %d motion events, DispatchPending returned %d", motions, read)
+		}
+	}
+}
+
+// TestReadEventsTogether prepares to read on two goroutines, the last one reads and the other one
+// waits for it
+func TestReadEventsTogether(t *testing.T) {
+	c := newConcurrentClient(t)
+	ctx := c.display.Context()
+	pointer, err := c.seat.GetPointer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var motions motionCount
+	pointer.AddMotionHandler(&motions)
+	c.roundtrip(t)
+
+	prepareRead(t, ctx)
+	waiting := make(chan error, 1)
+	go func() {
+		waiting <- ctx.ReadEvents()
+	}()
+	if err := ctx.PrepareRead(); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.srv.SendEvent(pointer.Id(), 2, uint32(0), float32(1), float32(2)); err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.ReadEvents(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-waiting; err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ctx.DispatchPending(); err != nil {
+		t.Fatal(err)
+	}
+	if motions != 1 {
+		t.Fatalf("dispatched %d motion events, want 1", motions)
+	}
+}
@@ -0,0 +1,313 @@
+package wl
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"time"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/yalue/native_endian"
+)
+
+// RecordKind is the kind of a Record of a protocol recording
+type RecordKind byte
+
+// The kinds of the records
+const (
+	// RecordRequest is a request message sent by the client
+	RecordRequest RecordKind = 'R'
+	// RecordEvent is an event message received from the compositor
+	RecordEvent RecordKind = 'E'
+	// RecordFd is the placeholder of a file descriptor passed along the preceding message, Data
+	// holds the contents of the file when it is a regular file, such as a keymap or a shm pool
+	RecordFd RecordKind = 'F'
+	// RecordShm is a snapshot of the contents of the wl_buffer Id, attached to the wl_surface
+	// committed by the preceding request
+	RecordShm RecordKind = 'S'
+)
+
+// Record is a single entry of a protocol recording, see Context SetRecorder
+type Record struct {
+	Kind RecordKind
+	// Time is the time elapsed since the recording started
+	Time time.Duration
+	// Id is the wl_buffer of a RecordShm
+	Id ProxyId
+	// Fds is the number of the RecordFd placeholders following a message
+	Fds int
+	// Data is the whole message including its header, the contents of a file or of a buffer
+	Data []byte
+}
+
+// recordMagic starts every protocol recording
+var recordMagic = []byte("WLREC\x00\x00\x01")
+
+// recordHeaderSize is the size of the header of every record: kind, reserved byte, number of fds,
+// id, time in nanoseconds and the size of the data, little endian
+const recordHeaderSize = 20
+
+// maxFdSnapshot is the maximum size of a file whose contents are recorded by a RecordFd
+const maxFdSnapshot = 64 << 20
+
+// ErrRecordMagic is returned by NewRecordReader when the data is not a protocol recording
+var ErrRecordMagic = errors.New("not a protocol recording")
+
+// RecordReader reads the records of a protocol recording
+type RecordReader struct {
+	r *bufio.Reader
+}
+
+// NewRecordReader checks the start of a protocol recording and returns a reader of its records
+func NewRecordReader(r io.Reader) (*RecordReader, error) {
+	rr := &RecordReader{bufio.NewReader(r)}
+	magic := make([]byte, len(recordMagic))
+	if _, err := io.ReadFull(rr.r, magic); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, ErrRecordMagic
+		}
+		return nil, err
+	}
+	if string(magic) != string(recordMagic) {
+		return nil, ErrRecordMagic
+	}
+	return rr, nil
+}
+
+// Next (RecordReader Next) returns the next record, or io.EOF at the end of the recording
+func (rr *RecordReader) Next() (*Record, error) {
+	var h [recordHeaderSize]byte
+	if _, err := io.ReadFull(rr.r, h[:]); err != nil {
+		return nil, err
+	}
+	rec := &Record{
+		Kind: RecordKind(h[0]),
+		Fds:  int(binary.LittleEndian.Uint16(h[2:4])),
+		Id:   ProxyId(binary.LittleEndian.Uint32(h[4:8])),
+		Time: time.Duration(binary.LittleEndian.Uint64(h[8:16])),
+	}
+	// the buffer grows as the data is read, a corrupted size does not allocate up front
+	var data bytes.Buffer
+	if _, err := io.CopyN(&data, rr.r, int64(binary.LittleEndian.Uint32(h[16:20]))); err != nil {
+		return nil, io.ErrUnexpectedEOF
+	}
+	rec.Data = data.Bytes()
+	return rec, nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// recorder writes the protocol recording of a Context, it keeps the shm pools of the client
+// open to snapshot the buffers that are committed
+type recorder struct {
+	w        io.Writer
+	closer   io.Closer
+	start    time.Time
+	err      error
+	pools    map[ProxyId]*recordPool
+	buffers  map[ProxyId]*recordBuffer
+	attached map[ProxyId]ProxyId
+}
+
+// recordPool is a wl_shm_pool, its fd is released once the pool and all its buffers are destroyed
+type recordPool struct {
+	fd   int
+	refs int
+}
+
+// recordBuffer is a wl_buffer of a wl_shm_pool
+type recordBuffer struct {
+	pool   *recordPool
+	offset int64
+	size   int64
+}
+
+// SetRecorder (Context SetRecorder) records both directions of the protocol stream to w, so that
+// the compositor side can be replayed by go-wayland-replay. The file descriptors passed along the
+// messages are recorded as placeholders holding the contents of regular files, and the contents of
+// the wl_shm buffers are recorded on every wl_surface.commit. Passing nil stops the recording.
+// Recording to the file named by the WAYLAND_RECORD environment variable is enabled on connect.
+func (ctx *Context) SetRecorder(w io.Writer) error {
+	if ctx == nil {
+		return ErrContextNil
+	}
+	var rec *recorder
+	if w != nil {
+		rec = &recorder{
+			w:        w,
+			start:    time.Now(),
+			pools:    make(map[ProxyId]*recordPool),
+			buffers:  make(map[ProxyId]*recordBuffer),
+			attached: make(map[ProxyId]ProxyId),
+		}
+		if _, err := w.Write(recordMagic); err != nil {
+			return err
+		}
+	}
+	ctx.recMu.Lock()
+	old := ctx.rec
+	ctx.rec = rec
+	ctx.recMu.Unlock()
+	if old != nil {
+		return old.close()
+	}
+	return nil
+}
+
+func (ctx *Context) recordFromEnv() {
+	path := os.Getenv("WAYLAND_RECORD")
+	if path == "" {
+		return
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return
+	}
+	if ctx.SetRecorder(f) != nil {
+		f.Close()
+		return
+	}
+	ctx.recMu.Lock()
+	if ctx.rec != nil {
+		ctx.rec.closer = f
+	}
+	ctx.recMu.Unlock()
+}
+
+// recordMessage records a message and placeholders of its fds, requests are tracked to snapshot
+// the committed shm buffers
+func (ctx *Context) recordMessage(kind RecordKind, iface *Interface, pid ProxyId, opcode uint32, data []byte, fds []int) {
+	ctx.recMu.Lock()
+	defer ctx.recMu.Unlock()
+	rec := ctx.rec
+	if rec == nil {
+		return
+	}
+	msg := make([]byte, 8+len(data))
+	native_endian.NativeEndian().PutUint32(msg[0:4], uint32(pid))
+	native_endian.NativeEndian().PutUint32(msg[4:8], uint32(len(msg))<<16|opcode&0x0000ffff)
+	copy(msg[8:], data)
+	rec.write(&Record{Kind: kind, Fds: len(fds), Data: msg})
+	for _, fd := range fds {
+		rec.write(&Record{Kind: RecordFd, Data: snapshotFd(fd)})
+	}
+	if kind == RecordRequest && iface != nil && int(opcode) < len(iface.Requests) {
+		rec.request(iface.Name+"."+iface.Requests[opcode].Name, pid, data, fds)
+	}
+}
+
+// request tracks the shm pools, their buffers and the buffers attached to the surfaces
+func (rec *recorder) request(name string, pid ProxyId, data []byte, fds []int) {
+	args := &Event{Data: data}
+	switch name {
+	case "wl_shm.create_pool":
+		id := ProxyId(args.Uint32())
+		if len(fds) == 0 || args.Err() != nil {
+			return
+		}
+		fd, err := sys.DupCloexec(fds[0])
+		if err != nil {
+			return
+		}
+		rec.pools[id] = &recordPool{fd: fd, refs: 1}
+	case "wl_shm_pool.destroy":
+		if pool := rec.pools[pid]; pool != nil {
+			delete(rec.pools, pid)
+			pool.release()
+		}
+	case "wl_shm_pool.create_buffer":
+		id := ProxyId(args.Uint32())
+		offset := args.Int32()
+		args.Int32()
+		height := args.Int32()
+		stride := args.Int32()
+		pool := rec.pools[pid]
+		if pool == nil || args.Err() != nil || offset < 0 || height < 0 || stride < 0 {
+			return
+		}
+		pool.refs++
+		rec.buffers[id] = &recordBuffer{pool, int64(offset), int64(height) * int64(stride)}
+	case "wl_buffer.destroy":
+		if buf := rec.buffers[pid]; buf != nil {
+			delete(rec.buffers, pid)
+			buf.pool.release()
+		}
+	case "wl_surface.attach":
+		rec.attached[pid] = ProxyId(args.Uint32())
+	case "wl_surface.commit":
+		id, ok := rec.attached[pid]
+		delete(rec.attached, pid)
+		if buf := rec.buffers[id]; ok && buf != nil {
+			data := make([]byte, minInt(int(buf.size), maxFdSnapshot))
+			n, _ := sys.Pread(buf.pool.fd, data, buf.offset)
+			if n < 0 {
+				n = 0
+			}
+			rec.write(&Record{Kind: RecordShm, Id: id, Data: data[:n]})
+		}
+	case "wl_surface.destroy":
+		delete(rec.attached, pid)
+	}
+}
+
+func (pool *recordPool) release() {
+	pool.refs--
+	if pool.refs == 0 {
+		sys.Close(pool.fd)
+	}
+}
+
+// snapshotFd returns the contents of a regular file, or nil
+func snapshotFd(fd int) []byte {
+	size, ok := sys.RegularFileSize(fd)
+	if !ok || size <= 0 || size > maxFdSnapshot {
+		return nil
+	}
+	data := make([]byte, size)
+	n, err := sys.Pread(fd, data, 0)
+	if err != nil || n < 0 {
+		return nil
+	}
+	return data[:n]
+}
+
+// write writes the record, the recording stops at the first error
+func (rec *recorder) write(r *Record) {
+	if rec.err != nil {
+		return
+	}
+	var h [recordHeaderSize]byte
+	h[0] = byte(r.Kind)
+	binary.LittleEndian.PutUint16(h[2:4], uint16(r.Fds))
+	binary.LittleEndian.PutUint32(h[4:8], uint32(r.Id))
+	binary.LittleEndian.PutUint64(h[8:16], uint64(time.Since(rec.start)))
+	binary.LittleEndian.PutUint32(h[16:20], uint32(len(r.Data)))
+	if _, rec.err = rec.w.Write(h[:]); rec.err != nil {
+		return
+	}
+	_, rec.err = rec.w.Write(r.Data)
+}
+
+// close releases the shm pools and closes the file opened by recordFromEnv
+func (rec *recorder) close() error {
+	for _, buf := range rec.buffers {
+		buf.pool.release()
+	}
+	for _, pool := range rec.pools {
+		pool.release()
+	}
+	rec.buffers = nil
+	rec.pools = nil
+	if rec.closer != nil {
+		return rec.closer.Close()
+	}
+	return nil
+}
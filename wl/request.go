@@ -2,18 +2,36 @@ package wl
 
 import (
 	"errors"
-	"github.com/neurlang/wayland/os"
-	"net"
 	"reflect"
+
+	"github.com/neurlang/wayland/os"
 	"github.com/yalue/native_endian"
 )
 
 // Request is the request message from your program to the Wayland compositor
 type Request struct {
-	pid    ProxyId
-	Opcode uint32
-	data   []byte
-	oob    []byte
+	pid     ProxyId
+	Opcode  uint32
+	data    []byte
+	fds     []int
+	ctx     *Context
+	created []Proxy
+}
+
+// NewRequest returns an empty message to the object with the opcode, the arguments are added by
+// Write or the PutXXX methods. Compositors use it to encode their events, see Message.
+func NewRequest(pid ProxyId, opcode uint32) *Request {
+	return &Request{pid: pid, Opcode: opcode}
+}
+
+// Message (Request Message) returns the encoded message including its header, and the fds
+// to be sent along with it
+func (r *Request) Message() ([]byte, []int) {
+	msg := make([]byte, 8+len(r.data))
+	native_endian.NativeEndian().PutUint32(msg[0:4], uint32(r.pid))
+	native_endian.NativeEndian().PutUint32(msg[4:8], uint32(len(msg))<<16|r.Opcode&0x0000ffff)
+	copy(msg[8:], r.data)
+	return msg, r.fds
 }
 
 // ErrContextSendRequestNotPossible is raised in case sending wl request could not be done
@@ -28,30 +46,263 @@ var ErrContextSendRequestConn = errors.New("unable to send request using conn")
 // ErrContextSendRequestUnixLength is raised in case sending wl request fails
 var ErrContextSendRequestUnixLength = errors.New("unable to send request using unix, WriteMsgUnix length check failed")
 
-// SendRequest (Context SendRequest) sends a specific request with arguments to the compositor
+// ErrContextSendRequestFd is raised in case the fd argument of a request cannot be duplicated
+var ErrContextSendRequestFd = errors.New("unable to duplicate request fd")
+
+// outBufferSize is the size of the outgoing buffer, the queued requests are flushed when it is full
+const outBufferSize = 4096
+
+// maxFdsOut is the maximum number of fds sent with a single sendmsg, as in libwayland
+const maxFdsOut = 28
+
+// SendRequest (Context SendRequest) queues a specific request with arguments to be sent to the compositor.
+// The queued requests are sent by Flush, which is done before the Context blocks reading events or when
+// the outgoing buffer is full. The fd arguments are duplicated, so they may be closed once SendRequest returns.
+// After a protocol error, see ProtocolError, it fails with that error.
+//
+// SendRequest is safe to call from several goroutines. Every request is queued atomically together with
+// its fds, and the ids of the new objects it creates are allocated in the same critical section, so the
+// compositor sees them in order.
 func (ctx *Context) SendRequest(proxy Proxy, opcode uint32, args ...interface{}) (err error) {
+	// the compositor closes the connection after a protocol error
+	if err := ctx.deadError(); err != nil {
+		return err
+	}
+
+	for _, arg := range args {
+		if !validArg(arg) {
+			return ErrRequestArgument
+		}
+	}
+
+	ctx.outMu.Lock()
+	defer ctx.outMu.Unlock()
+
+	if ctx.conn == nil {
+		return ErrContextSendRequestNotPossible
+	}
+
+	// the new objects are the new_id arguments of the request, or the proxies without an id when
+	// the interface is not described; the other proxies must be registered objects
+	newIds := newIdArgs(proxyInterface(proxy), opcode, args)
+	var created []Proxy
+	for i, arg := range args {
+		p, ok := arg.(Proxy)
+		if !ok || isNil(p) {
+			continue
+		}
+		if newIds == nil && p.Id() == 0 || newIds != nil && newIds[i] {
+			if p.Id() == 0 {
+				ctx.allocate(p)
+				created = append(created, p)
+			}
+			continue
+		}
+		if !ctx.registered(p) {
+			for j := len(created) - 1; j >= 0; j-- {
+				ctx.release(created[j])
+			}
+			return ErrRequestObjectNotRegistered
+		}
+	}
+
 	req := Request{
 		pid:    proxy.Id(),
 		Opcode: opcode,
 	}
-
 	for _, arg := range args {
-		err1 := req.Write(arg)
-		if err1 != nil {
-			err = err1
+		req.Write(arg)
+	}
+
+	if err := ctx.queueRequest(proxyInterface(proxy), req); err != nil {
+		// the ids were never sent, the next requests take them
+		for i := len(created) - 1; i >= 0; i-- {
+			ctx.release(created[i])
 		}
+		return err
+	}
+
+	ctx.traceRequest(proxy, opcode, args)
+
+	return nil
+}
+
+// ErrRequestObjectNotRegistered is returned by SendRequest when an object argument is not a registered
+// object of the Context: it was never created by a request, or it was destroyed
+var ErrRequestObjectNotRegistered = errors.New("request object argument not registered")
+
+// newIdArgs reports which arguments are the new objects of the request according to the interface
+// description, nil when the interface is not described or the arguments do not follow its description
+func newIdArgs(iface *Interface, opcode uint32, args []interface{}) []bool {
+	if iface == nil || int(opcode) >= len(iface.Requests) {
+		return nil
+	}
+	newIds := make([]bool, len(args))
+	i := 0
+	// the description already has the interface name and version of an untyped new object, see Arg
+	for _, a := range iface.Requests[opcode].Args {
+		if i >= len(args) {
+			return nil
+		}
+		newIds[i] = a.Type == ArgNewId
+		i++
+	}
+	if i != len(args) {
+		return nil
 	}
+	return newIds
+}
+
+// registered reports whether the proxy is the registered object of its id
+func (ctx *Context) registered(p Proxy) bool {
+	ctx.mu.RLock()
+	defer ctx.mu.RUnlock()
+	return p.Id() != 0 && ctx.objects[p.Id()] == p
+}
 
-	if err != nil {
+// MarshalRequest (Context MarshalRequest) queues a request like SendRequest, put writes its arguments
+// in order by the PutXXX methods of the Request, and the new objects by PutNewId. The Request is
+// owned by the Context and must not be used after put returns. Unlike SendRequest, the arguments
+// are not boxed, so the generated requests do not allocate. put runs while other goroutines wait
+// to send their requests, it must not send requests itself.
+func (ctx *Context) MarshalRequest(proxy Proxy, opcode uint32, put func(r *Request)) error {
+	// the compositor closes the connection after a protocol error
+	if err := ctx.deadError(); err != nil {
 		return err
 	}
 
-	if ctx.conn != nil {
-		return writeRequest(ctx.conn, req)
-	} else if ctx.sockFD != -1 {
-		return writeRequestUnix(ctx.sockFD, req)
+	ctx.outMu.Lock()
+	defer ctx.outMu.Unlock()
+
+	if ctx.conn == nil {
+		return ErrContextSendRequestNotPossible
+	}
+
+	r := &ctx.outReq
+	r.pid = proxy.Id()
+	r.Opcode = opcode
+	r.data = r.data[:0]
+	r.fds = r.fds[:0]
+	r.ctx = ctx
+	if put != nil {
+		put(r)
+	}
+	r.ctx = nil
+	defer r.forgetCreated()
+
+	if err := ctx.queueRequest(proxyInterface(proxy), *r); err != nil {
+		// the ids were never sent, the next requests take them
+		for i := len(r.created) - 1; i >= 0; i-- {
+			ctx.release(r.created[i])
+		}
+		return err
 	}
-	return ErrContextSendRequestNotPossible
+
+	ctx.traceRequestData(proxy, r)
+
+	return nil
+}
+
+// queueRequest appends the request to the outgoing buffer, the caller holds outMu
+func (ctx *Context) queueRequest(iface *Interface, r Request) error {
+	size := 8 + len(r.data)
+	if len(ctx.out)+size > outBufferSize || len(ctx.outFds)+len(r.fds) > maxFdsOut {
+		if err := ctx.flush(); err != nil {
+			return err
+		}
+	}
+
+	for i, fd := range r.fds {
+		dup, err := os.DupCloexec(fd)
+		if err != nil {
+			for _, d := range ctx.outFds[len(ctx.outFds)-i:] {
+				os.Close(d)
+			}
+			ctx.outFds = ctx.outFds[:len(ctx.outFds)-i]
+			return combinedError{ErrContextSendRequestFd, err}
+		}
+		ctx.outFds = append(ctx.outFds, dup)
+	}
+
+	n := len(ctx.out)
+	ctx.out = append(ctx.out, 0, 0, 0, 0, 0, 0, 0, 0)
+	native_endian.NativeEndian().PutUint32(ctx.out[n:n+4], uint32(r.pid))
+	native_endian.NativeEndian().PutUint32(ctx.out[n+4:n+8], uint32(size)<<16|r.Opcode&0x0000ffff)
+	ctx.out = append(ctx.out, r.data...)
+	ctx.recordMessage(RecordRequest, iface, r.pid, r.Opcode, r.data, r.fds)
+
+	return nil
+}
+
+// Flush (Context Flush) sends all the queued requests to the compositor. Like wl_display_flush,
+// it handles short writes and waits while the socket buffer is full (EAGAIN) until everything is sent,
+// the requests that could not be sent stay queued when an error is returned.
+func (ctx *Context) Flush() error {
+	if ctx == nil {
+		return ErrContextNil
+	}
+	ctx.outMu.Lock()
+	defer ctx.outMu.Unlock()
+	return ctx.flush()
+}
+
+func (ctx *Context) flush() error {
+	if len(ctx.out) == 0 {
+		return nil
+	}
+	if ctx.conn == nil {
+		return ErrContextSendRequestNotPossible
+	}
+	if err := ctx.deadError(); err != nil {
+		return err
+	}
+	sent := 0
+	for sent < len(ctx.out) {
+		var oob []byte
+		if len(ctx.outFds) > 0 {
+			oob = os.UnixRights(ctx.outFds...)
+		}
+		n, _, err := ctx.conn.WriteMsgUnix(ctx.out[sent:], oob, nil)
+		if n > 0 && oob != nil {
+			// the fds are attached to the first byte sent
+			for _, fd := range ctx.outFds {
+				os.Close(fd)
+			}
+			ctx.outFds = ctx.outFds[:0]
+		}
+		sent += n
+		if err != nil {
+			ctx.out = append(ctx.out[:0], ctx.out[sent:]...)
+			return combinedError{ErrContextSendRequestConn, err}
+		}
+		if n == 0 {
+			ctx.out = append(ctx.out[:0], ctx.out[sent:]...)
+			return ErrContextSendRequestUnixLength
+		}
+	}
+	ctx.out = ctx.out[:0]
+	return nil
+}
+
+// ErrRequestArgument is returned when a request argument is not of a Wayland type
+var ErrRequestArgument = errors.New("invalid Wayland request parameter type")
+
+// validArg reports whether Request Write accepts the argument
+func validArg(arg interface{}) bool {
+	switch arg.(type) {
+	case Proxy, uint32, int32, float32, string, []int32, uintptr:
+		return true
+	}
+	return false
+}
+
+// CheckNewId returns ErrRequestArgument when the new object of a request is nil, it is called by the
+// generated requests creating an object of an interface chosen by the caller, such as wl_registry.bind
+func CheckNewId(p Proxy) error {
+	if isNil(p) {
+		return ErrRequestArgument
+	}
+	return nil
 }
 
 // Write (Request Write) writes a specific request argument to the compositor
@@ -72,16 +323,16 @@ func (r *Request) Write(arg interface{}) error {
 	case uintptr:
 		r.PutFd(t)
 	default:
-		return errors.New("invalid Wayland request parameter type")
+		return ErrRequestArgument
 	}
 	return nil
 }
 
 // PutUint32 (Request PutUint32) writes an uint32 argument to the compositor
 func (r *Request) PutUint32(u uint32) {
-	buf := bytePool.Take(4)
-	native_endian.NativeEndian().PutUint32(buf, u)
-	r.data = append(r.data, buf...)
+	n := len(r.data)
+	r.data = append(r.data, 0, 0, 0, 0)
+	native_endian.NativeEndian().PutUint32(r.data[n:], u)
 }
 
 func isNil(this Proxy) bool {
@@ -97,6 +348,29 @@ func (r *Request) PutProxy(p Proxy) {
 	r.PutUint32(uint32(p.Id()))
 }
 
+// PutObject (Request PutObject) writes an object argument by its id, 0 for a null object
+func (r *Request) PutObject(id ProxyId) {
+	r.PutUint32(uint32(id))
+}
+
+// PutNewId (Request PutNewId) writes the new object created by the request, allocating its id
+// when it has none. It is only valid within Context MarshalRequest.
+func (r *Request) PutNewId(p Proxy) {
+	if r.ctx != nil && p.Id() == 0 {
+		r.ctx.allocate(p)
+		r.created = append(r.created, p)
+	}
+	r.PutUint32(uint32(p.Id()))
+}
+
+// forgetCreated drops the proxies created by the request, so that the Context does not keep them
+func (r *Request) forgetCreated() {
+	for i := range r.created {
+		r.created[i] = nil
+	}
+	r.created = r.created[:0]
+}
+
 // PutInt32 (Request PutInt32) writes an int32 argument to the compositor
 func (r *Request) PutInt32(i int32) {
 	r.PutUint32(uint32(i))
@@ -112,17 +386,18 @@ func (r *Request) PutFloat32(f float32) {
 func (r *Request) PutString(s string) {
 	tail := 4 - (len(s) & 0x3)
 	r.PutUint32(uint32(len(s) + tail))
-	r.data = append(r.data, []byte(s)...)
-	// if padding required
-	if tail > 0 {
-		padding := make([]byte, tail)
-		r.data = append(r.data, padding...)
-	}
+	r.data = append(r.data, s...)
+	// the terminating zero and the padding
+	r.data = append(r.data, zeros[:tail]...)
 }
 
+// zeros are the bytes padding the strings
+var zeros [4]byte
+
 // PutArray (Request PutArray) writes an array argument to the compositor
 func (r *Request) PutArray(a []int32) {
-	r.PutUint32(uint32(len(a)))
+	// the size of the array in bytes
+	r.PutUint32(uint32(4 * len(a)))
 	for _, e := range a {
 		r.PutUint32(uint32(e))
 	}
@@ -130,49 +405,5 @@ func (r *Request) PutArray(a []int32) {
 
 // PutFd (Request PutFd) writes a file descriptor argument to the compositor
 func (r *Request) PutFd(fd uintptr) {
-	rights := os.UnixRights(int(fd))
-	r.oob = append(r.oob, rights...)
-}
-
-func writeRequest(conn *net.UnixConn, r Request) error {
-	var header []byte
-	// calculate message total size
-	size := uint32(len(r.data) + 8)
-	buf := make([]byte, 4)
-	native_endian.NativeEndian().PutUint32(buf, uint32(r.pid))
-	header = append(header, buf...)
-	native_endian.NativeEndian().PutUint32(buf, size<<16|r.Opcode&0x0000ffff)
-	header = append(header, buf...)
-
-	d, c, err := conn.WriteMsgUnix(append(header, r.data...), r.oob, nil)
-	if err != nil {
-		return combinedError{ErrContextSendRequestConn, err}
-	}
-	if c != len(r.oob) || d != (len(header)+len(r.data)) {
-		return ErrContextSendRequestUnixLength
-	}
-	bytePool.Give(r.data)
-
-	return nil
-}
-
-func writeRequestUnix(fd int, r Request) error {
-	var header []byte
-	// calculate message total size
-	size := uint32(len(r.data) + 8)
-	buf := make([]byte, 4)
-	native_endian.NativeEndian().PutUint32(buf, uint32(r.pid))
-	header = append(header, buf...)
-	native_endian.NativeEndian().PutUint32(buf, size<<16|r.Opcode&0x0000ffff)
-	header = append(header, buf...)
-
-	// unix.
-	var addr os.Sockaddr
-	err := os.Sendmsg(fd, append(header, r.data...), r.oob, addr, 0)
-	if err != nil {
-		return combinedError{ErrContextSendRequestUnix, err}
-	}
-	bytePool.Give(r.data)
-
-	return nil
+	r.fds = append(r.fds, int(fd))
 }
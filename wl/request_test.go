@@ -0,0 +1,209 @@
+package wl
+
+import (
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yalue/native_endian"
+)
+
+// TestFlushBatchesRequests checks the requests are queued until Flush sends them at once
+func TestFlushBatchesRequests(t *testing.T) {
+	ctx, server := socketpair(t)
+	defer ctx.Close()
+	defer server.Close()
+
+	surface, buffer := newSurface(ctx)
+	if err := surface.Attach(buffer, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := surface.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	buf := make([]byte, 64)
+	server.SetReadDeadline(time.Now().Add(50 * time.Millisecond))
+	if n, err := server.Read(buf); err == nil {
+		t.Fatalf("received %d bytes before Flush", n)
+	}
+	if err := ctx.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	server.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, err := server.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// attach with 3 arguments and commit without any
+	if n != 20+8 {
+		t.Fatalf("received %d bytes in one read, want both requests", n)
+	}
+	ne := native_endian.NativeEndian()
+	if op := ne.Uint32(buf[4:]) & 0xffff; op != 1 {
+		t.Errorf("first request opcode %d, want attach", op)
+	}
+	if op := ne.Uint32(buf[24:]) & 0xffff; op != 6 {
+		t.Errorf("second request opcode %d, want commit", op)
+	}
+}
+
+// TestFlushBackPressure queues more requests than the socket buffer holds while the compositor
+// is not reading, Flush waits and every request arrives in order
+func TestFlushBackPressure(t *testing.T) {
+	const commits = 100000
+	ctx, server := socketpair(t)
+	defer ctx.Close()
+	defer server.Close()
+
+	surface, _ := newSurface(ctx)
+	errs := make(chan error, 1)
+	go func() {
+		for i := 0; i < commits; i++ {
+			if err := surface.Commit(); err != nil {
+				errs <- err
+				return
+			}
+		}
+		errs <- ctx.Flush()
+	}()
+
+	// the client fills the socket buffer before the compositor starts reading
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case err := <-errs:
+		t.Fatalf("flushed %d commits without the compositor reading: %v", commits, err)
+	default:
+	}
+
+	server.SetReadDeadline(time.Now().Add(10 * time.Second))
+	buf := make([]byte, 8*commits)
+	if _, err := io.ReadFull(server, buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-errs; err != nil {
+		t.Fatal(err)
+	}
+	ne := native_endian.NativeEndian()
+	for i := 0; i < commits; i++ {
+		msg := buf[8*i:]
+		if ProxyId(ne.Uint32(msg)) != surface.Id() || ne.Uint32(msg[4:]) != 8<<16|6 {
+			t.Fatalf("request %d is not a commit of the surface", i)
+		}
+	}
+}
+
+// undescribed is a proxy of a protocol without an interface description
+type undescribed struct {
+	BaseProxy
+}
+
+// TestSendRequestNewIds checks the new objects of SendRequest are its new_id arguments, and that
+// the object arguments must be registered
+func TestSendRequestNewIds(t *testing.T) {
+	ctx, server := socketpair(t)
+	defer ctx.Close()
+	defer server.Close()
+
+	surface, buffer := newSurface(ctx)
+	compositor := NewCompositor(ctx)
+	ctx.allocate(compositor)
+	created := NewSurface(ctx)
+	if err := ctx.SendRequest(compositor, 0, created); err != nil {
+		t.Fatal(err)
+	}
+	if created.Id() == 0 || ctx.LookupProxy(created.Id()) != created {
+		t.Errorf("new_id argument not registered, id %d", created.Id())
+	}
+
+	// an object argument without an id is not a new object
+	if err := ctx.SendRequest(surface, 1, NewBuffer(ctx), int32(0), int32(0)); err != ErrRequestObjectNotRegistered {
+		t.Errorf("attach of a buffer never created returned %v, want %v", err, ErrRequestObjectNotRegistered)
+	}
+	ctx.Unregister(buffer.Id())
+	if err := ctx.SendRequest(surface, 1, buffer, int32(0), int32(0)); err != ErrRequestObjectNotRegistered {
+		t.Errorf("attach of a destroyed buffer returned %v, want %v", err, ErrRequestObjectNotRegistered)
+	}
+	// a null object is sent as 0
+	if err := ctx.SendRequest(surface, 1, (*Buffer)(nil), int32(0), int32(0)); err != nil {
+		t.Errorf("attach of a null buffer returned %v", err)
+	}
+
+	// the new objects of a failed request are released
+	manager := NewDataDeviceManager(ctx)
+	ctx.allocate(manager)
+	device := NewDataDevice(ctx)
+	if err := ctx.SendRequest(manager, 1, device, NewSeat(ctx)); err != ErrRequestObjectNotRegistered {
+		t.Errorf("get_data_device of a seat never created returned %v, want %v", err, ErrRequestObjectNotRegistered)
+	}
+	if device.Id() != 0 {
+		t.Errorf("new data device kept the id %d of the failed request", device.Id())
+	}
+
+	// without a description, the proxies without an id are the new objects
+	p := new(undescribed)
+	ctx.allocate(p)
+	child := new(undescribed)
+	if err := ctx.SendRequest(p, 0, child, surface); err != nil {
+		t.Fatal(err)
+	}
+	if child.Id() == 0 || ctx.LookupProxy(child.Id()) != child {
+		t.Errorf("undescribed new object not registered, id %d", child.Id())
+	}
+}
+
+// TestRegistryBindVersion checks Bind fails for a nil object and sets the version of the object only
+// once the request is queued
+func TestRegistryBindVersion(t *testing.T) {
+	ctx, server := socketpair(t)
+	defer server.Close()
+
+	registry := NewRegistry(ctx)
+	ctx.allocate(registry)
+	if err := registry.Bind(1, "wl_seat", 5, nil); err != ErrRequestArgument {
+		t.Errorf("bind of a nil object returned %v, want %v", err, ErrRequestArgument)
+	}
+	if err := registry.Bind(1, "wl_seat", 5, (*Seat)(nil)); err != ErrRequestArgument {
+		t.Errorf("bind of a nil seat returned %v, want %v", err, ErrRequestArgument)
+	}
+
+	seat := NewSeat(ctx)
+	if err := registry.Bind(1, "wl_seat", 5, seat); err != nil {
+		t.Fatal(err)
+	}
+	if v := seat.Version(); v != 5 {
+		t.Errorf("bound seat version %d, want 5", v)
+	}
+
+	ctx.Close()
+	seat = NewSeat(ctx)
+	if err := registry.Bind(2, "wl_seat", 5, seat); err == nil {
+		t.Fatal("bind on a closed connection succeeded")
+	}
+	if v := seat.Version(); v != 0 {
+		t.Errorf("seat of a failed bind has the version %d", v)
+	}
+}
+
+// TestNewIdArgs checks the new objects of the requests follow their descriptions, an untyped new_id
+// is described together with its interface name and version
+func TestNewIdArgs(t *testing.T) {
+	ctx, server := socketpair(t)
+	defer ctx.Close()
+	defer server.Close()
+
+	p := NewSeat(ctx)
+	got := newIdArgs(RegistryInterface, 0, []interface{}{uint32(1), "wl_seat", uint32(1), p})
+	if want := []bool{false, false, false, true}; !reflect.DeepEqual(got, want) {
+		t.Errorf("wl_registry.bind new objects %v, want %v", got, want)
+	}
+	surface := NewSurface(ctx)
+	got = newIdArgs(CompositorInterface, 0, []interface{}{surface})
+	if want := []bool{true}; !reflect.DeepEqual(got, want) {
+		t.Errorf("wl_compositor.create_surface new objects %v, want %v", got, want)
+	}
+	if got := newIdArgs(RegistryInterface, 0, []interface{}{uint32(1), p}); got != nil {
+		t.Errorf("arguments not following the description returned %v, want nil", got)
+	}
+}
@@ -0,0 +1,220 @@
+package wl
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// SetTracer (Context SetTracer) enables logging of every request sent and every event received
+// to w, in the format used by libwayland's WAYLAND_DEBUG. Passing nil disables the tracing.
+// Tracing to stderr is enabled on connect when WAYLAND_DEBUG is set to 1 or client.
+func (ctx *Context) SetTracer(w io.Writer) {
+	ctx.traceMu.Lock()
+	ctx.tracer = w
+	ctx.traceMu.Unlock()
+}
+
+func (ctx *Context) traceFromEnv() {
+	switch os.Getenv("WAYLAND_DEBUG") {
+	case "1", "client":
+		ctx.SetTracer(os.Stderr)
+	}
+}
+
+func (ctx *Context) tracing() bool {
+	ctx.traceMu.Lock()
+	defer ctx.traceMu.Unlock()
+	return ctx.tracer != nil
+}
+
+func (ctx *Context) trace(line []byte) {
+	ctx.traceMu.Lock()
+	if ctx.tracer != nil {
+		ctx.tracer.Write(line)
+	}
+	ctx.traceMu.Unlock()
+}
+
+func traceHeader(b *bytes.Buffer, prefix string, iface *Interface, id ProxyId) {
+	us := time.Now().UnixNano() / int64(time.Microsecond)
+	fmt.Fprintf(b, "[%7d.%03d] ", us/1000%1000000, us%1000)
+	b.WriteString(prefix)
+	traceObject(b, iface, id)
+}
+
+func traceObject(b *bytes.Buffer, iface *Interface, id ProxyId) {
+	if iface != nil {
+		b.WriteString(iface.Name)
+	} else {
+		b.WriteString("[unknown]")
+	}
+	b.WriteByte('@')
+	b.WriteString(strconv.FormatUint(uint64(id), 10))
+}
+
+func traceMessage(b *bytes.Buffer, msgs []Message, opcode uint32) *Message {
+	b.WriteByte('.')
+	if int(opcode) < len(msgs) {
+		b.WriteString(msgs[opcode].Name)
+		return &msgs[opcode]
+	}
+	b.WriteString("opcode ")
+	b.WriteString(strconv.FormatUint(uint64(opcode), 10))
+	return nil
+}
+
+func (ctx *Context) traceRequest(proxy Proxy, opcode uint32, args []interface{}) {
+	if !ctx.tracing() {
+		return
+	}
+	iface := proxyInterface(proxy)
+	var b bytes.Buffer
+	traceHeader(&b, " -> ", iface, proxy.Id())
+	var msg *Message
+	if iface != nil {
+		msg = traceMessage(&b, iface.Requests, opcode)
+	} else {
+		traceMessage(&b, nil, opcode)
+	}
+	b.WriteByte('(')
+	for i, arg := range args {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		var typ ArgType
+		if msg != nil && i < len(msg.Args) {
+			typ = msg.Args[i].Type
+		}
+		switch t := arg.(type) {
+		case Proxy:
+			if isNil(t) {
+				b.WriteString("nil")
+				break
+			}
+			if typ == ArgNewId {
+				b.WriteString("new id ")
+			}
+			traceObject(&b, proxyInterface(t), t.Id())
+		case string:
+			b.WriteString(strconv.Quote(t))
+		case float32:
+			b.WriteString(strconv.FormatFloat(float64(t), 'f', -1, 32))
+		case []int32:
+			fmt.Fprintf(&b, "array[%d]", 4*len(t))
+		case uintptr:
+			fmt.Fprintf(&b, "fd %d", t)
+		default:
+			fmt.Fprint(&b, t)
+		}
+	}
+	b.WriteString(")\n")
+	ctx.trace(b.Bytes())
+}
+
+func (ctx *Context) traceEvent(iface *Interface, ev *Event, discarded bool) {
+	if !ctx.tracing() {
+		return
+	}
+	prefix := ""
+	if discarded {
+		prefix = "discarded "
+	}
+	var b bytes.Buffer
+	traceHeader(&b, prefix, iface, ev.Pid)
+	if iface == nil {
+		traceMessage(&b, nil, ev.Opcode)
+		fmt.Fprintf(&b, "(%d bytes)\n", len(ev.Data))
+		ctx.trace(b.Bytes())
+		return
+	}
+	msg := traceMessage(&b, iface.Events, ev.Opcode)
+	b.WriteByte('(')
+	if msg != nil {
+		// decode a copy, so that the fds and the read offset are left for the Dispatch
+		ctx.traceArgs(&b, msg, ev.Data, ev.fds)
+	}
+	b.WriteString(")\n")
+	ctx.trace(b.Bytes())
+}
+
+// traceRequestData traces a request encoded by Context MarshalRequest
+func (ctx *Context) traceRequestData(proxy Proxy, r *Request) {
+	if !ctx.tracing() {
+		return
+	}
+	iface := proxyInterface(proxy)
+	var b bytes.Buffer
+	traceHeader(&b, " -> ", iface, r.pid)
+	if iface == nil {
+		traceMessage(&b, nil, r.Opcode)
+		fmt.Fprintf(&b, "(%d bytes)\n", len(r.data))
+		ctx.trace(b.Bytes())
+		return
+	}
+	msg := traceMessage(&b, iface.Requests, r.Opcode)
+	b.WriteByte('(')
+	if msg != nil {
+		ctx.traceArgs(&b, msg, r.data, r.fds)
+	}
+	b.WriteString(")\n")
+	ctx.trace(b.Bytes())
+}
+
+// traceArgs writes the arguments of the message decoded from the data, the fds are written when known
+func (ctx *Context) traceArgs(b *bytes.Buffer, msg *Message, data []byte, fds []int) {
+	dec := &Event{Data: data}
+	// the interface of a new_id without a specific interface precedes it
+	var lastString string
+	for i, a := range msg.Args {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		switch a.Type {
+		case ArgInt:
+			b.WriteString(strconv.FormatInt(int64(dec.Int32()), 10))
+		case ArgUint:
+			b.WriteString(strconv.FormatUint(uint64(dec.Uint32()), 10))
+		case ArgFixed:
+			b.WriteString(strconv.FormatFloat(FixedToFloat(dec.Int32()), 'f', -1, 64))
+		case ArgString:
+			lastString = dec.String()
+			b.WriteString(strconv.Quote(lastString))
+		case ArgArray:
+			fmt.Fprintf(b, "array[%d]", 4*len(dec.Array()))
+		case ArgFd:
+			if len(fds) == 0 {
+				b.WriteString("fd")
+				break
+			}
+			fmt.Fprintf(b, "fd %d", fds[0])
+			fds = fds[1:]
+		case ArgObject, ArgNewId:
+			id := ProxyId(dec.Uint32())
+			if id == 0 {
+				b.WriteString("nil")
+				break
+			}
+			if a.Type == ArgNewId {
+				b.WriteString("new id ")
+				name := a.Interface
+				if name == "" {
+					name = lastString
+				}
+				traceObject(b, LookupInterface(name), id)
+				break
+			}
+			var obj *Interface
+			if p := ctx.LookupProxy(id); p != nil {
+				obj = proxyInterface(p)
+			}
+			traceObject(b, obj, id)
+		}
+		if dec.err != nil {
+			break
+		}
+	}
+}
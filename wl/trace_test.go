@@ -0,0 +1,133 @@
+package wl_test
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wl"
+)
+
+// traceLines returns the traced lines without their timestamps
+func traceLines(trace *bytes.Buffer) []string {
+	stamp := regexp.MustCompile(`^\[ *[0-9]+\.[0-9]{3}\] `)
+	var lines []string
+	for _, l := range strings.Split(strings.TrimSuffix(trace.String(), "\n"), "\n") {
+		if !stamp.MatchString(l) {
+			lines = append(lines, "no timestamp: "+l)
+			continue
+		}
+		lines = append(lines, stamp.ReplaceAllString(l, ""))
+	}
+	return lines
+}
+
+// checkTrace checks the lines appear in the trace in order, "fd N" matches any fd number
+func checkTrace(t *testing.T, trace *bytes.Buffer, want ...string) {
+	t.Helper()
+	lines := traceLines(trace)
+	i := 0
+	for _, l := range lines {
+		if i == len(want) {
+			break
+		}
+		pattern := strings.ReplaceAll(regexp.QuoteMeta(want[i]), "fd N", `fd [0-9]+`)
+		if regexp.MustCompile("^" + pattern + "$").MatchString(l) {
+			i++
+		}
+	}
+	if i < len(want) {
+		t.Errorf("trace has no %q after the previous lines, trace:\n%s", want[i], strings.Join(lines, "\n"))
+	}
+}
+
+// TestTrace checks the WAYLAND_DEBUG format of libwayland
+func TestTrace(t *testing.T) {
+	c := newConcurrentClient(t)
+	keyboard, err := c.seat.GetKeyboard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	released, err := c.seat.GetKeyboard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	surface, err := c.compositor.CreateSurface()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.roundtrip(t)
+
+	var trace bytes.Buffer
+	c.display.Context().SetTracer(&trace)
+
+	registry, err := c.display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.roundtrip(t)
+	compositor := wl.NewCompositor(c.display.Context())
+	if err := registry.Bind(1, "wl_compositor", 4, compositor); err != nil {
+		t.Fatal(err)
+	}
+	file, err := sys.CreateAnonymousFile(4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	pool, err := c.shm.CreatePool(file.Fd(), 4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := surface.Attach(nil, 0, 0); err != nil {
+		t.Fatal(err)
+	}
+	c.roundtrip(t)
+
+	id := released.Id()
+	if err := released.Release(); err != nil {
+		t.Fatal(err)
+	}
+	sendFile(t, c.srv, keyboard.Id(), 0, 100)
+	if err := c.srv.SendEvent(keyboard.Id(), 1, uint32(5), surface, []int32{30, 31}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.srv.SendEvent(keyboard.Id(), 2, uint32(6), nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.srv.SendEvent(500, 3, uint32(7)); err != nil {
+		t.Fatal(err)
+	}
+	// wl_keyboard.key
+	if err := c.srv.SendEvent(id, 3, uint32(8), uint32(9), uint32(30), uint32(1)); err != nil {
+		t.Fatal(err)
+	}
+	c.roundtrip(t)
+
+	c.display.Context().SetTracer(nil)
+	checkTrace(t, &trace,
+		" -> wl_display@1.get_registry(new id wl_registry@"+itoa(registry.Id())+")",
+		"wl_registry@"+itoa(registry.Id())+".global(1, \"wl_compositor\", 4)",
+		" -> wl_registry@"+itoa(registry.Id())+".bind(1, \"wl_compositor\", 4, new id wl_compositor@"+itoa(compositor.Id())+")",
+		" -> wl_shm@"+itoa(c.shm.Id())+".create_pool(new id wl_shm_pool@"+itoa(pool.Id())+", fd N, 4096)",
+		" -> wl_surface@"+itoa(surface.Id())+".attach(nil, 0, 0)",
+		" -> wl_keyboard@"+itoa(id)+".release()",
+		"wl_keyboard@"+itoa(keyboard.Id())+".keymap(1, fd N, 100)",
+		"wl_keyboard@"+itoa(keyboard.Id())+".enter(5, wl_surface@"+itoa(surface.Id())+", array[8])",
+		"wl_keyboard@"+itoa(keyboard.Id())+".leave(6, nil)",
+	)
+	// the events to unknown objects and zombies are discarded as soon as they are read,
+	// before the events read earlier are dispatched
+	checkTrace(t, &trace,
+		" -> wl_keyboard@"+itoa(id)+".release()",
+		"discarded [unknown]@500.opcode 3(4 bytes)",
+		"discarded wl_keyboard@"+itoa(id)+".key(8, 9, 30, 1)",
+	)
+}
+
+func itoa(id wl.ProxyId) string {
+	return strconv.FormatUint(uint64(id), 10)
+}
@@ -10,16 +10,6 @@ type BytePool struct {
 	sync.Pool
 }
 
-var (
-	bytePool = &BytePool{
-		sync.Pool{
-			New: func() interface{} {
-				return make([]byte, 16)
-			},
-		},
-	}
-)
-
 // Take (BytePool Take) takes a specific number of bytes from the pool
 func (bp *BytePool) Take(n int) []byte {
 	buf := bp.Get().([]byte)
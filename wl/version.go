@@ -0,0 +1,53 @@
+package wl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrRequestNotSupportedByVersion (Error request not supported by version) is returned by the generated
+// request methods when the request is newer than the version the proxy is bound with, the request
+// is not sent. Use errors.As with *VersionError to get the details.
+var ErrRequestNotSupportedByVersion = errors.New("request not supported by version")
+
+// VersionError describes a request that is newer than the version of the proxy
+type VersionError struct {
+	Interface string
+	Request   string
+	Since     uint32
+	Version   uint32
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("%s: %s.%s needs version %d, bound version is %d",
+		ErrRequestNotSupportedByVersion, e.Interface, e.Request, e.Since, e.Version)
+}
+
+// Unwrap (VersionError Unwrap) returns ErrRequestNotSupportedByVersion
+func (e *VersionError) Unwrap() error {
+	return ErrRequestNotSupportedByVersion
+}
+
+// CheckRequestVersion returns a *VersionError when the request of the opcode is newer than the version
+// the proxy is bound with, it is called by the generated request methods. Proxies of unknown
+// version (0) or interface are not checked.
+func CheckRequestVersion(p Proxy, opcode uint32) error {
+	version := p.Version()
+	if version == 0 {
+		return nil
+	}
+	iface := proxyInterface(p)
+	if iface == nil || int(opcode) >= len(iface.Requests) {
+		return nil
+	}
+	req := &iface.Requests[opcode]
+	if req.Since <= version {
+		return nil
+	}
+	return &VersionError{
+		Interface: iface.Name,
+		Request:   req.Name,
+		Since:     req.Since,
+		Version:   version,
+	}
+}
@@ -0,0 +1,56 @@
+package wl_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/neurlang/wayland/wl"
+)
+
+// TestRequestVersion binds the compositor at an older version than advertised, the surfaces inherit
+// it and the requests newer than it fail without being sent
+func TestRequestVersion(t *testing.T) {
+	c := newConcurrentClient(t)
+	registry, err := c.display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	globals := wl.NewGlobalTracker(registry)
+	c.roundtrip(t)
+	p, err := globals.Bind("wl_compositor", 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compositor := p.(*wl.Compositor)
+	if v := compositor.Version(); v != 3 {
+		t.Fatalf("compositor version %d, want 3", v)
+	}
+	surface, err := compositor.CreateSurface()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := surface.Version(); v != 3 {
+		t.Fatalf("surface version %d, want the version 3 of its compositor", v)
+	}
+
+	if err := surface.SetBufferScale(2); err != nil {
+		t.Fatal(err)
+	}
+	err = surface.DamageBuffer(0, 0, 1, 1)
+	if !errors.Is(err, wl.ErrRequestNotSupportedByVersion) {
+		t.Fatalf("damage_buffer at version 3 returned %v, want %v", err, wl.ErrRequestNotSupportedByVersion)
+	}
+	var verr *wl.VersionError
+	if !errors.As(err, &verr) || verr.Request != "damage_buffer" || verr.Since != 4 || verr.Version != 3 {
+		t.Errorf("error %+v, want damage_buffer since 4", verr)
+	}
+	c.roundtrip(t)
+
+	var names []string
+	for _, r := range c.srv.RequestsTo("wl_surface") {
+		names = append(names, r.Name)
+	}
+	if len(names) != 1 || names[0] != "set_buffer_scale" {
+		t.Errorf("wl_surface requests %v, want [set_buffer_scale]", names)
+	}
+}
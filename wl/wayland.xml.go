@@ -1,7 +1,32 @@
-// generated by wl-scanner
-// https://github.com/dkolbly/wl-scanner
-// from: wayland.xml
-// on 2021-03-19 21:49:27 +0100
+// Code generated by go-wayland-scanner; DO NOT EDIT.
+// XML file: wayland.xml
+//
+// Wayland Protocol Copyright:
+//
+// Copyright © 2008-2011 Kristian Høgsberg
+// Copyright © 2010-2011 Intel Corporation
+// Copyright © 2012-2013 Collabora, Ltd.
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the
+// next paragraph) shall be included in all copies or substantial
+// portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT.  IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
 
 package wl
 
@@ -9,361 +34,702 @@ import (
 	"sync"
 )
 
+// Display: core global object
+//
+// The core global object.  This is a special singleton object.  It
+// is used for internal Wayland protocol features.
+type Display struct {
+	BaseProxy
+	mu               sync.RWMutex
+	errorHandlers    []DisplayErrorHandler
+	deleteIdHandlers []DisplayDeleteIdHandler
+}
+
+// NewDisplay creates a new wl_display proxy registered in the Context
+func NewDisplay(ctx *Context) *Display {
+	ret := new(Display)
+	ctx.Register(ret)
+	return ret
+}
+
+// Sync: asynchronous roundtrip
+//
+// The sync request asks the server to emit the 'done' event
+// on the returned wl_callback object.  Since requests are
+// handled in-order and events are delivered in-order, this can
+// be used as a barrier to ensure all previous requests and the
+// resulting events have been handled.
+//
+// The object returned by this request will be destroyed by the
+// compositor after the callback is fired and as such the client must not
+// attempt to use it after that point.
+//
+// The callback_data passed in the callback is the event serial.
+//
+//	callback: callback object for the sync request
+func (p *Display) Sync() (*Callback, error) {
+	ret := NewCallback(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 0, func(r *Request) {
+		r.PutNewId(ret)
+	})
+}
+
+// GetRegistry: get global registry object
+//
+// This request creates a registry object that allows the client
+// to list and bind the global objects available from the
+// compositor.
+//
+// It should be noted that the server side resources consumed in
+// response to a get_registry request can only be released when the
+// client disconnects, not when the client side proxy is destroyed.
+// Therefore, clients should invoke get_registry as infrequently as
+// possible to avoid wasting memory.
+//
+//	registry: global registry object
+func (p *Display) GetRegistry() (*Registry, error) {
+	ret := NewRegistry(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 1, func(r *Request) {
+		r.PutNewId(ret)
+	})
+}
+
+// DisplayError: global error values
+//
+// These errors are global and can be emitted in response to any
+// server request.
+const (
+	// DisplayErrorInvalidObject: server couldn't find object
+	DisplayErrorInvalidObject = 0
+	// DisplayErrorInvalidMethod: method doesn't exist on the specified interface or malformed request
+	DisplayErrorInvalidMethod = 1
+	// DisplayErrorNoMemory: server is out of memory
+	DisplayErrorNoMemory = 2
+	// DisplayErrorImplementation: implementation error in compositor
+	DisplayErrorImplementation = 3
+)
+
+// DisplayErrorEvent: fatal error event
+//
+// The error event is sent out when a fatal (non-recoverable)
+// error has occurred.  The object_id argument is the object
+// where the error occurred, most often in response to a request
+// to that object.  The code identifies the error and is defined
+// by the object interface.  As such, each interface defines its
+// own set of error codes.  The message is a brief description
+// of the error, for (debugging) convenience.
 type DisplayErrorEvent struct {
 	ObjectId Proxy
 	Code     uint32
 	Message  string
 }
 
+// DisplayErrorHandler is implemented by the receivers of DisplayErrorEvent
 type DisplayErrorHandler interface {
 	HandleDisplayError(DisplayErrorEvent)
 }
 
+// AddErrorHandler adds a handler for DisplayErrorEvent
 func (p *Display) AddErrorHandler(h DisplayErrorHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.errorHandlers = append(p.errorHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.errorHandlers = append(p.errorHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveErrorHandler removes a handler previously added by AddErrorHandler
 func (p *Display) RemoveErrorHandler(h DisplayErrorHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.errorHandlers {
 		if e == h {
-			p.errorHandlers = append(p.errorHandlers[:i], p.errorHandlers[i+1:]...)
+			p.errorHandlers = append(p.errorHandlers[:i:i], p.errorHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// DisplayDeleteIdEvent: acknowledge object ID deletion
+//
+// This event is used internally by the object ID management
+// logic. When a client deletes an object that it had created,
+// the server will send this event to acknowledge that it has
+// seen the delete request. When the client receives this event,
+// it will know that it can safely reuse the object ID.
 type DisplayDeleteIdEvent struct {
 	Id uint32
 }
 
+// DisplayDeleteIdHandler is implemented by the receivers of DisplayDeleteIdEvent
 type DisplayDeleteIdHandler interface {
 	HandleDisplayDeleteId(DisplayDeleteIdEvent)
 }
 
+// AddDeleteIdHandler adds a handler for DisplayDeleteIdEvent
 func (p *Display) AddDeleteIdHandler(h DisplayDeleteIdHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.deleteIdHandlers = append(p.deleteIdHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.deleteIdHandlers = append(p.deleteIdHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveDeleteIdHandler removes a handler previously added by AddDeleteIdHandler
 func (p *Display) RemoveDeleteIdHandler(h DisplayDeleteIdHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.deleteIdHandlers {
 		if e == h {
-			p.deleteIdHandlers = append(p.deleteIdHandlers[:i], p.deleteIdHandlers[i+1:]...)
+			p.deleteIdHandlers = append(p.deleteIdHandlers[:i:i], p.deleteIdHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// Dispatch decodes an event received on the wl_display and runs its handlers
 func (p *Display) Dispatch(event *Event) {
 	switch event.Opcode {
 	case 0:
-		if len(p.errorHandlers) > 0 {
-			ev := DisplayErrorEvent{}
-			ev.ObjectId = event.Proxy(p.Context())
-			ev.Code = event.Uint32()
-			ev.Message = event.String()
-			p.mu.RLock()
-			for _, h := range p.errorHandlers {
-				h.HandleDisplayError(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.errorHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := DisplayErrorEvent{}
+		ev.ObjectId = event.Proxy(p.Context())
+		ev.Code = event.Uint32()
+		ev.Message = event.String()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleDisplayError(ev)
 		}
 	case 1:
-		if len(p.deleteIdHandlers) > 0 {
-			ev := DisplayDeleteIdEvent{}
-			ev.Id = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.deleteIdHandlers {
-				h.HandleDisplayDeleteId(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.deleteIdHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := DisplayDeleteIdEvent{}
+		ev.Id = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleDisplayDeleteId(ev)
 		}
 	}
 }
 
-type Display struct {
+const (
+	DisplayErrorSinceVersion       = 1
+	DisplayDeleteIdSinceVersion    = 1
+	DisplaySyncSinceVersion        = 1
+	DisplayGetRegistrySinceVersion = 1
+)
+
+// DisplayInterface describes the wl_display interface
+var DisplayInterface = &Interface{
+	Name:    "wl_display",
+	Version: 1,
+	Requests: []Message{
+		{
+			Name:  "sync",
+			Since: 1,
+			Args: []Arg{
+				{Name: "callback", Type: ArgNewId, Interface: "wl_callback"},
+			},
+		},
+		{
+			Name:  "get_registry",
+			Since: 1,
+			Args: []Arg{
+				{Name: "registry", Type: ArgNewId, Interface: "wl_registry"},
+			},
+		},
+	},
+	Events: []Message{
+		{
+			Name:  "error",
+			Since: 1,
+			Args: []Arg{
+				{Name: "object_id", Type: ArgObject},
+				{Name: "code", Type: ArgUint},
+				{Name: "message", Type: ArgString},
+			},
+		},
+		{
+			Name:  "delete_id",
+			Since: 1,
+			Args: []Arg{
+				{Name: "id", Type: ArgUint},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "invalid_object", Value: 0},
+				{Name: "invalid_method", Value: 1},
+				{Name: "no_memory", Value: 2},
+				{Name: "implementation", Value: 3},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_display interface
+func (p *Display) Interface() *Interface {
+	return DisplayInterface
+}
+
+// Registry: global registry object
+//
+// The singleton global registry object.  The server has a number of
+// global objects that are available to all clients.  These objects
+// typically represent an actual object in the server (for example,
+// an input device) or they are singleton objects that provide
+// extension functionality.
+//
+// When a client creates a registry object, the registry object
+// will emit a global event for each global currently in the
+// registry.  Globals come and go as a result of device or
+// monitor hotplugs, reconfiguration or other events, and the
+// registry will send out global and global_remove events to
+// keep the client up to date with the changes.  To mark the end
+// of the initial burst of events, the client can use the
+// wl_display.sync request immediately after calling
+// wl_display.get_registry.
+//
+// A client can bind to a global object by using the bind
+// request.  This creates a client-side handle that lets the object
+// emit events to the client and lets the client invoke requests on
+// the object.
+type Registry struct {
 	BaseProxy
-	mu               sync.RWMutex
-	errorHandlers    []DisplayErrorHandler
-	deleteIdHandlers []DisplayDeleteIdHandler
+	mu                   sync.RWMutex
+	globalHandlers       []RegistryGlobalHandler
+	globalRemoveHandlers []RegistryGlobalRemoveHandler
+
+	Ctx *Context
 }
 
-func NewDisplay(ctx *Context) *Display {
-	ret := new(Display)
+// NewRegistry creates a new wl_registry proxy registered in the Context
+func NewRegistry(ctx *Context) *Registry {
+	ret := new(Registry)
+	ret.Ctx = ctx
 	ctx.Register(ret)
 	return ret
 }
 
-// Sync will asynchronous roundtrip.
-//
-//
-// The sync request asks the server to emit the 'done' event
-// on the returned wl_callback object.  Since requests are
-// handled in-order and events are delivered in-order, this can
-// be used as a barrier to ensure all previous requests and the
-// resulting events have been handled.
-//
-// The object returned by this request will be destroyed by the
-// compositor after the callback is fired and as such the client must not
-// attempt to use it after that point.
+// Bind: bind an object to the display
 //
-// The callback_data passed in the callback is the event serial.
+// Binds a new, client-created object to the server using the
+// specified name as the identifier.
 //
-func (p *Display) Sync() (*Callback, error) {
-	ret := NewCallback(p.Context())
-	return ret, p.Context().SendRequest(p, 0, Proxy(ret))
+//	name: unique numeric name of the object
+//	id: bounded object
+func (p *Registry) Bind(name uint32, iface string, version uint32, id Proxy) error {
+	if err := CheckNewId(id); err != nil {
+		return err
+	}
+	if err := p.Context().MarshalRequest(p, 0, func(r *Request) {
+		r.PutUint32(name)
+		r.PutString(iface)
+		r.PutUint32(version)
+		r.PutNewId(id)
+	}); err != nil {
+		return err
+	}
+	id.SetVersion(version)
+	return nil
 }
 
-// GetRegistry will get global registry object.
-//
-//
-// This request creates a registry object that allows the client
-// to list and bind the global objects available from the
-// compositor.
+// RegistryGlobalEvent: announce global object
 //
-// It should be noted that the server side resources consumed in
-// response to a get_registry request can only be released when the
-// client disconnects, not when the client side proxy is destroyed.
-// Therefore, clients should invoke get_registry as infrequently as
-// possible to avoid wasting memory.
+// Notify the client of global objects.
 //
-func (p *Display) GetRegistry() (*Registry, error) {
-	ret := NewRegistry(p.Context())
-	return ret, p.Context().SendRequest(p, 1, Proxy(ret))
-}
-
-const (
-	DisplayErrorInvalidObject  = 0
-	DisplayErrorInvalidMethod  = 1
-	DisplayErrorNoMemory       = 2
-	DisplayErrorImplementation = 3
-)
-
+// The event notifies the client that a global object with
+// the given name is now available, and it implements the
+// given version of the given interface.
 type RegistryGlobalEvent struct {
 	Name      uint32
 	Interface string
 	Version   uint32
 }
 
+// RegistryGlobalHandler is implemented by the receivers of RegistryGlobalEvent
 type RegistryGlobalHandler interface {
 	HandleRegistryGlobal(RegistryGlobalEvent)
 }
 
+// AddGlobalHandler adds a handler for RegistryGlobalEvent
 func (p *Registry) AddGlobalHandler(h RegistryGlobalHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.globalHandlers = append(p.globalHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.globalHandlers = append(p.globalHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveGlobalHandler removes a handler previously added by AddGlobalHandler
 func (p *Registry) RemoveGlobalHandler(h RegistryGlobalHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.globalHandlers {
 		if e == h {
-			p.globalHandlers = append(p.globalHandlers[:i], p.globalHandlers[i+1:]...)
+			p.globalHandlers = append(p.globalHandlers[:i:i], p.globalHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// RegistryGlobalRemoveEvent: announce removal of global object
+//
+// Notify the client of removed global objects.
+//
+// This event notifies the client that the global identified
+// by name is no longer available.  If the client bound to
+// the global using the bind request, the client should now
+// destroy that object.
+//
+// The object remains valid and requests to the object will be
+// ignored until the client destroys it, to avoid races between
+// the global going away and a client sending a request to it.
 type RegistryGlobalRemoveEvent struct {
 	Name uint32
 }
 
+// RegistryGlobalRemoveHandler is implemented by the receivers of RegistryGlobalRemoveEvent
 type RegistryGlobalRemoveHandler interface {
 	HandleRegistryGlobalRemove(RegistryGlobalRemoveEvent)
 }
 
+// AddGlobalRemoveHandler adds a handler for RegistryGlobalRemoveEvent
 func (p *Registry) AddGlobalRemoveHandler(h RegistryGlobalRemoveHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.globalRemoveHandlers = append(p.globalRemoveHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.globalRemoveHandlers = append(p.globalRemoveHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveGlobalRemoveHandler removes a handler previously added by AddGlobalRemoveHandler
 func (p *Registry) RemoveGlobalRemoveHandler(h RegistryGlobalRemoveHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.globalRemoveHandlers {
 		if e == h {
-			p.globalRemoveHandlers = append(
-				p.globalRemoveHandlers[:i],
-				p.globalRemoveHandlers[i+1:]...)
+			p.globalRemoveHandlers = append(p.globalRemoveHandlers[:i:i], p.globalRemoveHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// Dispatch decodes an event received on the wl_registry and runs its handlers
 func (p *Registry) Dispatch(event *Event) {
 	switch event.Opcode {
 	case 0:
-		if len(p.globalHandlers) > 0 {
-			ev := RegistryGlobalEvent{}
-			ev.Name = event.Uint32()
-			ev.Interface = event.String()
-			ev.Version = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.globalHandlers {
-				h.HandleRegistryGlobal(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.globalHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := RegistryGlobalEvent{}
+		ev.Name = event.Uint32()
+		ev.Interface = event.String()
+		ev.Version = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleRegistryGlobal(ev)
 		}
 	case 1:
-		if len(p.globalRemoveHandlers) > 0 {
-			ev := RegistryGlobalRemoveEvent{}
-			ev.Name = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.globalRemoveHandlers {
-				h.HandleRegistryGlobalRemove(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.globalRemoveHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := RegistryGlobalRemoveEvent{}
+		ev.Name = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleRegistryGlobalRemove(ev)
 		}
 	}
 }
 
-type Registry struct {
-	BaseProxy
-	mu                   sync.RWMutex
-	globalHandlers       []RegistryGlobalHandler
-	globalRemoveHandlers []RegistryGlobalRemoveHandler
+const (
+	RegistryGlobalSinceVersion       = 1
+	RegistryGlobalRemoveSinceVersion = 1
+	RegistryBindSinceVersion         = 1
+)
 
-	Ctx *Context
+// RegistryInterface describes the wl_registry interface
+var RegistryInterface = &Interface{
+	Name:    "wl_registry",
+	Version: 1,
+	Requests: []Message{
+		{
+			Name:  "bind",
+			Since: 1,
+			Args: []Arg{
+				{Name: "name", Type: ArgUint},
+				{Name: "interface", Type: ArgString},
+				{Name: "version", Type: ArgUint},
+				{Name: "id", Type: ArgNewId},
+			},
+		},
+	},
+	Events: []Message{
+		{
+			Name:  "global",
+			Since: 1,
+			Args: []Arg{
+				{Name: "name", Type: ArgUint},
+				{Name: "interface", Type: ArgString},
+				{Name: "version", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "global_remove",
+			Since: 1,
+			Args: []Arg{
+				{Name: "name", Type: ArgUint},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_registry interface
+func (p *Registry) Interface() *Interface {
+	return RegistryInterface
+}
+
+// Callback: callback object
+//
+// Clients can handle the 'done' event to get notified when
+// the related request is done.
+type Callback struct {
+	BaseProxy
+	mu           sync.RWMutex
+	doneHandlers []CallbackDoneHandler
 }
 
-func NewRegistry(ctx *Context) *Registry {
-	ret := new(Registry)
-	ret.Ctx = ctx
+// NewCallback creates a new wl_callback proxy registered in the Context
+func NewCallback(ctx *Context) *Callback {
+	ret := new(Callback)
 	ctx.Register(ret)
 	return ret
 }
 
-// Bind will bind an object to the display.
+// CallbackDoneEvent: done event
 //
-//
-// Binds a new, client-created object to the server using the
-// specified name as the identifier.
-//
-func (p *Registry) Bind(name uint32, iface string, version uint32, id Proxy) error {
-	return p.Context().SendRequest(p, 0, name, iface, version, id)
-}
-
+// Notify the client when the related request is done.
 type CallbackDoneEvent struct {
+	C            *Callback
 	CallbackData uint32
-
-	C *Callback
 }
 
+// CallbackDoneHandler is implemented by the receivers of CallbackDoneEvent
 type CallbackDoneHandler interface {
 	HandleCallbackDone(CallbackDoneEvent)
 }
 
+// AddDoneHandler adds a handler for CallbackDoneEvent
 func (p *Callback) AddDoneHandler(h CallbackDoneHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.doneHandlers = append(p.doneHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.doneHandlers = append(p.doneHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveDoneHandler removes a handler previously added by AddDoneHandler
 func (p *Callback) RemoveDoneHandler(h CallbackDoneHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.doneHandlers {
 		if e == h {
-			p.doneHandlers = append(p.doneHandlers[:i], p.doneHandlers[i+1:]...)
+			p.doneHandlers = append(p.doneHandlers[:i:i], p.doneHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// Dispatch decodes an event received on the wl_callback and runs its handlers
 func (p *Callback) Dispatch(event *Event) {
 	switch event.Opcode {
 	case 0:
-		if len(p.doneHandlers) > 0 {
-			ev := CallbackDoneEvent{C: p}
-			ev.CallbackData = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.doneHandlers {
-				h.HandleCallbackDone(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.doneHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := CallbackDoneEvent{}
+		ev.CallbackData = event.Uint32()
+		ev.C = p
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleCallbackDone(ev)
 		}
 	}
 }
 
-type Callback struct {
-	BaseProxy
-	mu           sync.RWMutex
-	doneHandlers []CallbackDoneHandler
+const (
+	CallbackDoneSinceVersion = 1
+)
+
+// CallbackInterface describes the wl_callback interface
+var CallbackInterface = &Interface{
+	Name:    "wl_callback",
+	Version: 1,
+	Events: []Message{
+		{
+			Name:       "done",
+			Since:      1,
+			Destructor: true,
+			Args: []Arg{
+				{Name: "callback_data", Type: ArgUint},
+			},
+		},
+	},
 }
 
-func NewCallback(ctx *Context) *Callback {
-	ret := new(Callback)
-	ctx.Register(ret)
-	return ret
+// Interface returns the description of the wl_callback interface
+func (p *Callback) Interface() *Interface {
+	return CallbackInterface
 }
 
+// Compositor: the compositor singleton
+//
+// A compositor.  This object is a singleton global.  The
+// compositor is in charge of combining the contents of multiple
+// surfaces into one displayable output.
 type Compositor struct {
 	BaseProxy
 }
 
+// NewCompositor creates a new wl_compositor proxy registered in the Context
 func NewCompositor(ctx *Context) *Compositor {
 	ret := new(Compositor)
 	ctx.Register(ret)
 	return ret
 }
 
-// CreateSurface will create new surface.
-//
+// CreateSurface: create new surface
 //
 // Ask the compositor to create a new surface.
 //
+//	id: the new surface
 func (p *Compositor) CreateSurface() (*Surface, error) {
 	ret := NewSurface(p.Context())
-	return ret, p.Context().SendRequest(p, 0, Proxy(ret))
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 0, func(r *Request) {
+		r.PutNewId(ret)
+	})
 }
 
-// CreateRegion will create new region.
-//
+// CreateRegion: create new region
 //
 // Ask the compositor to create a new region.
 //
+//	id: the new region
 func (p *Compositor) CreateRegion() (*Region, error) {
 	ret := NewRegion(p.Context())
-	return ret, p.Context().SendRequest(p, 1, Proxy(ret))
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 1, func(r *Request) {
+		r.PutNewId(ret)
+	})
 }
 
+const (
+	CompositorCreateSurfaceSinceVersion = 1
+	CompositorCreateRegionSinceVersion  = 1
+)
+
+// CompositorInterface describes the wl_compositor interface
+var CompositorInterface = &Interface{
+	Name:    "wl_compositor",
+	Version: 6,
+	Requests: []Message{
+		{
+			Name:  "create_surface",
+			Since: 1,
+			Args: []Arg{
+				{Name: "id", Type: ArgNewId, Interface: "wl_surface"},
+			},
+		},
+		{
+			Name:  "create_region",
+			Since: 1,
+			Args: []Arg{
+				{Name: "id", Type: ArgNewId, Interface: "wl_region"},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_compositor interface
+func (p *Compositor) Interface() *Interface {
+	return CompositorInterface
+}
+
+// ShmPool: a shared memory pool
+//
+// The wl_shm_pool object encapsulates a piece of memory shared
+// between the compositor and client.  Through the wl_shm_pool
+// object, the client can allocate shared memory wl_buffer objects.
+// All objects created through the same pool share the same
+// underlying mapped memory. Reusing the mapped memory avoids the
+// setup/teardown overhead and is useful when interactively resizing
+// a surface or for many small buffers.
 type ShmPool struct {
 	BaseProxy
 }
 
+// NewShmPool creates a new wl_shm_pool proxy registered in the Context
 func NewShmPool(ctx *Context) *ShmPool {
 	ret := new(ShmPool)
 	ctx.Register(ret)
 	return ret
 }
 
-// CreateBuffer will create a buffer from the pool.
-//
+// CreateBuffer: create a buffer from the pool
 //
 // Create a wl_buffer object from the pool.
 //
@@ -377,101 +743,127 @@ func NewShmPool(ctx *Context) *ShmPool {
 // so it is valid to destroy the pool immediately after creating
 // a buffer from it.
 //
-func (p *ShmPool) CreateBuffer(
-	offset int32,
-	width int32,
-	height int32,
-	stride int32,
-	format uint32,
-) (*Buffer, error) {
+//	id: buffer to create
+//	offset: buffer byte offset within the pool
+//	width: buffer width, in pixels
+//	height: buffer height, in pixels
+//	stride: number of bytes from the beginning of one row to the beginning of the next row
+//	format: buffer pixel format
+func (p *ShmPool) CreateBuffer(offset int32, width int32, height int32, stride int32, format uint32) (*Buffer, error) {
 	ret := NewBuffer(p.Context())
-	return ret, p.Context().SendRequest(p, 0, Proxy(ret), offset, width, height, stride, format)
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 0, func(r *Request) {
+		r.PutNewId(ret)
+		r.PutInt32(offset)
+		r.PutInt32(width)
+		r.PutInt32(height)
+		r.PutInt32(stride)
+		r.PutUint32(format)
+	})
 }
 
-// Destroy will destroy the pool.
-//
+// Destroy: destroy the pool
 //
 // Destroy the shared memory pool.
 //
 // The mmapped memory will be released when all
 // buffers that have been created from this pool
 // are gone.
-//
 func (p *ShmPool) Destroy() error {
-	err := p.Context().SendRequest(p, 1)
+	err := p.Context().MarshalRequest(p, 1, nil)
 	p.Unregister()
 	return err
 }
 
-// Resize will change the size of the pool mapping.
-//
+// Resize: change the size of the pool mapping
 //
 // This request will cause the server to remap the backing memory
 // for the pool from the file descriptor passed when the pool was
 // created, but using the new size.  This request can only be
 // used to make the pool bigger.
 //
+// This request only changes the amount of bytes that are mmapped
+// by the server and does not touch the file corresponding to the
+// file descriptor passed at creation time. It is the client's
+// responsibility to ensure that the file is at least as big as
+// the new pool size.
+//
+//	size: new size of the pool, in bytes
 func (p *ShmPool) Resize(size int32) error {
-	return p.Context().SendRequest(p, 2, size)
-}
-
-type ShmFormatEvent struct {
-	Format uint32
+	return p.Context().MarshalRequest(p, 2, func(r *Request) {
+		r.PutInt32(size)
+	})
 }
 
-type ShmFormatHandler interface {
-	HandleShmFormat(ShmFormatEvent)
-}
-
-func (p *Shm) AddFormatHandler(h ShmFormatHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.formatHandlers = append(p.formatHandlers, h)
-		p.mu.Unlock()
-	}
-}
-
-func (p *Shm) RemoveFormatHandler(h ShmFormatHandler) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	for i, e := range p.formatHandlers {
-		if e == h {
-			p.formatHandlers = append(p.formatHandlers[:i], p.formatHandlers[i+1:]...)
-			break
-		}
-	}
-}
-
-func (p *Shm) Dispatch(event *Event) {
-	switch event.Opcode {
-	case 0:
-		if len(p.formatHandlers) > 0 {
-			ev := ShmFormatEvent{}
-			ev.Format = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.formatHandlers {
-				h.HandleShmFormat(ev)
-			}
-			p.mu.RUnlock()
-		}
-	}
-}
+const (
+	ShmPoolCreateBufferSinceVersion = 1
+	ShmPoolDestroySinceVersion      = 1
+	ShmPoolResizeSinceVersion       = 1
+)
 
+// ShmPoolInterface describes the wl_shm_pool interface
+var ShmPoolInterface = &Interface{
+	Name:    "wl_shm_pool",
+	Version: 2,
+	Requests: []Message{
+		{
+			Name:  "create_buffer",
+			Since: 1,
+			Args: []Arg{
+				{Name: "id", Type: ArgNewId, Interface: "wl_buffer"},
+				{Name: "offset", Type: ArgInt},
+				{Name: "width", Type: ArgInt},
+				{Name: "height", Type: ArgInt},
+				{Name: "stride", Type: ArgInt},
+				{Name: "format", Type: ArgUint},
+			},
+		},
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "resize",
+			Since: 1,
+			Args: []Arg{
+				{Name: "size", Type: ArgInt},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_shm_pool interface
+func (p *ShmPool) Interface() *Interface {
+	return ShmPoolInterface
+}
+
+// Shm: shared memory support
+//
+// A singleton global object that provides support for shared
+// memory.
+//
+// Clients can create wl_shm_pool objects using the create_pool
+// request.
+//
+// At connection setup time, the wl_shm object emits one or more
+// format events to inform clients about the valid pixel formats
+// that can be used for buffers.
 type Shm struct {
 	BaseProxy
 	mu             sync.RWMutex
 	formatHandlers []ShmFormatHandler
 }
 
+// NewShm creates a new wl_shm proxy registered in the Context
 func NewShm(ctx *Context) *Shm {
 	ret := new(Shm)
 	ctx.Register(ret)
 	return ret
 }
 
-// CreatePool will create a shm pool.
-//
+// CreatePool: create a shm pool
 //
 // Create a new wl_shm_pool object.
 //
@@ -479,313 +871,668 @@ func NewShm(ctx *Context) *Shm {
 // objects.  The server will mmap size bytes of the passed file
 // descriptor, to use as backing memory for the pool.
 //
+//	id: pool to create
+//	fd: file descriptor for the pool
+//	size: pool size, in bytes
 func (p *Shm) CreatePool(fd uintptr, size int32) (*ShmPool, error) {
 	ret := NewShmPool(p.Context())
-	return ret, p.Context().SendRequest(p, 0, Proxy(ret), fd, size)
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 0, func(r *Request) {
+		r.PutNewId(ret)
+		r.PutFd(fd)
+		r.PutInt32(size)
+	})
+}
+
+// Release: release the shm object
+//
+// Using this request a client can tell the server that it is not going to
+// use the shm object anymore.
+//
+// Objects created via this interface remain unaffected.
+func (p *Shm) Release() error {
+	if err := CheckRequestVersion(p, 1); err != nil {
+		return err
+	}
+	err := p.Context().MarshalRequest(p, 1, nil)
+	p.Unregister()
+	return err
 }
 
+// ShmError: wl_shm error values
+//
+// These errors can be emitted in response to wl_shm requests.
 const (
+	// ShmErrorInvalidFormat: buffer format is not known
 	ShmErrorInvalidFormat = 0
+	// ShmErrorInvalidStride: invalid size or stride during pool or buffer creation
 	ShmErrorInvalidStride = 1
-	ShmErrorInvalidFd     = 2
+	// ShmErrorInvalidFd: mmapping the file descriptor failed
+	ShmErrorInvalidFd = 2
 )
 
+// ShmFormat: pixel formats
+//
+// This describes the memory layout of an individual pixel.
+//
+// All renderers should support argb8888 and xrgb8888 but any other
+// formats are optional and may not be supported by the particular
+// renderer in use.
+//
+// The drm format codes match the macros defined in drm_fourcc.h, except
+// argb8888 and xrgb8888. The formats actually supported by the compositor
+// will be reported by the format event.
 const (
-	ShmFormatArgb8888             = 0
-	ShmFormatXrgb8888             = 1
-	ShmFormatC8                   = 0x20203843
-	ShmFormatRgb332               = 0x38424752
-	ShmFormatBgr233               = 0x38524742
-	ShmFormatXrgb4444             = 0x32315258
-	ShmFormatXbgr4444             = 0x32314258
-	ShmFormatRgbx4444             = 0x32315852
-	ShmFormatBgrx4444             = 0x32315842
-	ShmFormatArgb4444             = 0x32315241
-	ShmFormatAbgr4444             = 0x32314241
-	ShmFormatRgba4444             = 0x32314152
-	ShmFormatBgra4444             = 0x32314142
-	ShmFormatXrgb1555             = 0x35315258
-	ShmFormatXbgr1555             = 0x35314258
-	ShmFormatRgbx5551             = 0x35315852
-	ShmFormatBgrx5551             = 0x35315842
-	ShmFormatArgb1555             = 0x35315241
-	ShmFormatAbgr1555             = 0x35314241
-	ShmFormatRgba5551             = 0x35314152
-	ShmFormatBgra5551             = 0x35314142
-	ShmFormatRgb565               = 0x36314752
-	ShmFormatBgr565               = 0x36314742
-	ShmFormatRgb888               = 0x34324752
-	ShmFormatBgr888               = 0x34324742
-	ShmFormatXbgr8888             = 0x34324258
-	ShmFormatRgbx8888             = 0x34325852
-	ShmFormatBgrx8888             = 0x34325842
-	ShmFormatAbgr8888             = 0x34324241
-	ShmFormatRgba8888             = 0x34324152
-	ShmFormatBgra8888             = 0x34324142
-	ShmFormatXrgb2101010          = 0x30335258
-	ShmFormatXbgr2101010          = 0x30334258
-	ShmFormatRgbx1010102          = 0x30335852
-	ShmFormatBgrx1010102          = 0x30335842
-	ShmFormatArgb2101010          = 0x30335241
-	ShmFormatAbgr2101010          = 0x30334241
-	ShmFormatRgba1010102          = 0x30334152
-	ShmFormatBgra1010102          = 0x30334142
-	ShmFormatYuyv                 = 0x56595559
-	ShmFormatYvyu                 = 0x55595659
-	ShmFormatUyvy                 = 0x59565955
-	ShmFormatVyuy                 = 0x59555956
-	ShmFormatAyuv                 = 0x56555941
-	ShmFormatNv12                 = 0x3231564e
-	ShmFormatNv21                 = 0x3132564e
-	ShmFormatNv16                 = 0x3631564e
-	ShmFormatNv61                 = 0x3136564e
-	ShmFormatYuv410               = 0x39565559
-	ShmFormatYvu410               = 0x39555659
-	ShmFormatYuv411               = 0x31315559
-	ShmFormatYvu411               = 0x31315659
-	ShmFormatYuv420               = 0x32315559
-	ShmFormatYvu420               = 0x32315659
-	ShmFormatYuv422               = 0x36315559
-	ShmFormatYvu422               = 0x36315659
-	ShmFormatYuv444               = 0x34325559
-	ShmFormatYvu444               = 0x34325659
-	ShmFormatR8                   = 0x20203852
-	ShmFormatR16                  = 0x20363152
-	ShmFormatRg88                 = 0x38384752
-	ShmFormatGr88                 = 0x38385247
-	ShmFormatRg1616               = 0x32334752
-	ShmFormatGr1616               = 0x32335247
-	ShmFormatXrgb16161616f        = 0x48345258
-	ShmFormatXbgr16161616f        = 0x48344258
-	ShmFormatArgb16161616f        = 0x48345241
-	ShmFormatAbgr16161616f        = 0x48344241
-	ShmFormatXyuv8888             = 0x56555958
-	ShmFormatVuy888               = 0x34325556
-	ShmFormatVuy101010            = 0x30335556
-	ShmFormatY210                 = 0x30313259
-	ShmFormatY212                 = 0x32313259
-	ShmFormatY216                 = 0x36313259
-	ShmFormatY410                 = 0x30313459
-	ShmFormatY412                 = 0x32313459
-	ShmFormatY416                 = 0x36313459
-	ShmFormatXvyu2101010          = 0x30335658
-	ShmFormatXvyu1216161616       = 0x36335658
-	ShmFormatXvyu16161616         = 0x38345658
-	ShmFormatY0l0                 = 0x304c3059
-	ShmFormatX0l0                 = 0x304c3058
-	ShmFormatY0l2                 = 0x324c3059
-	ShmFormatX0l2                 = 0x324c3058
-	ShmFormatYuv4208bit           = 0x38305559
-	ShmFormatYuv42010bit          = 0x30315559
-	ShmFormatXrgb8888A8           = 0x38415258
-	ShmFormatXbgr8888A8           = 0x38414258
-	ShmFormatRgbx8888A8           = 0x38415852
-	ShmFormatBgrx8888A8           = 0x38415842
-	ShmFormatRgb888A8             = 0x38413852
-	ShmFormatBgr888A8             = 0x38413842
-	ShmFormatRgb565A8             = 0x38413552
-	ShmFormatBgr565A8             = 0x38413542
-	ShmFormatNv24                 = 0x3432564e
-	ShmFormatNv42                 = 0x3234564e
-	ShmFormatP210                 = 0x30313250
-	ShmFormatP010                 = 0x30313050
-	ShmFormatP012                 = 0x32313050
-	ShmFormatP016                 = 0x36313050
+	// ShmFormatArgb8888: 32-bit ARGB format, [31:0] A:R:G:B 8:8:8:8 little endian
+	ShmFormatArgb8888 = 0
+	// ShmFormatXrgb8888: 32-bit RGB format, [31:0] x:R:G:B 8:8:8:8 little endian
+	ShmFormatXrgb8888 = 1
+	// ShmFormatC8: 8-bit color index format, [7:0] C
+	ShmFormatC8 = 0x20203843
+	// ShmFormatRgb332: 8-bit RGB format, [7:0] R:G:B 3:3:2
+	ShmFormatRgb332 = 0x38424752
+	// ShmFormatBgr233: 8-bit BGR format, [7:0] B:G:R 2:3:3
+	ShmFormatBgr233 = 0x38524742
+	// ShmFormatXrgb4444: 16-bit xRGB format, [15:0] x:R:G:B 4:4:4:4 little endian
+	ShmFormatXrgb4444 = 0x32315258
+	// ShmFormatXbgr4444: 16-bit xBGR format, [15:0] x:B:G:R 4:4:4:4 little endian
+	ShmFormatXbgr4444 = 0x32314258
+	// ShmFormatRgbx4444: 16-bit RGBx format, [15:0] R:G:B:x 4:4:4:4 little endian
+	ShmFormatRgbx4444 = 0x32315852
+	// ShmFormatBgrx4444: 16-bit BGRx format, [15:0] B:G:R:x 4:4:4:4 little endian
+	ShmFormatBgrx4444 = 0x32315842
+	// ShmFormatArgb4444: 16-bit ARGB format, [15:0] A:R:G:B 4:4:4:4 little endian
+	ShmFormatArgb4444 = 0x32315241
+	// ShmFormatAbgr4444: 16-bit ABGR format, [15:0] A:B:G:R 4:4:4:4 little endian
+	ShmFormatAbgr4444 = 0x32314241
+	// ShmFormatRgba4444: 16-bit RBGA format, [15:0] R:G:B:A 4:4:4:4 little endian
+	ShmFormatRgba4444 = 0x32314152
+	// ShmFormatBgra4444: 16-bit BGRA format, [15:0] B:G:R:A 4:4:4:4 little endian
+	ShmFormatBgra4444 = 0x32314142
+	// ShmFormatXrgb1555: 16-bit xRGB format, [15:0] x:R:G:B 1:5:5:5 little endian
+	ShmFormatXrgb1555 = 0x35315258
+	// ShmFormatXbgr1555: 16-bit xBGR 1555 format, [15:0] x:B:G:R 1:5:5:5 little endian
+	ShmFormatXbgr1555 = 0x35314258
+	// ShmFormatRgbx5551: 16-bit RGBx 5551 format, [15:0] R:G:B:x 5:5:5:1 little endian
+	ShmFormatRgbx5551 = 0x35315852
+	// ShmFormatBgrx5551: 16-bit BGRx 5551 format, [15:0] B:G:R:x 5:5:5:1 little endian
+	ShmFormatBgrx5551 = 0x35315842
+	// ShmFormatArgb1555: 16-bit ARGB 1555 format, [15:0] A:R:G:B 1:5:5:5 little endian
+	ShmFormatArgb1555 = 0x35315241
+	// ShmFormatAbgr1555: 16-bit ABGR 1555 format, [15:0] A:B:G:R 1:5:5:5 little endian
+	ShmFormatAbgr1555 = 0x35314241
+	// ShmFormatRgba5551: 16-bit RGBA 5551 format, [15:0] R:G:B:A 5:5:5:1 little endian
+	ShmFormatRgba5551 = 0x35314152
+	// ShmFormatBgra5551: 16-bit BGRA 5551 format, [15:0] B:G:R:A 5:5:5:1 little endian
+	ShmFormatBgra5551 = 0x35314142
+	// ShmFormatRgb565: 16-bit RGB 565 format, [15:0] R:G:B 5:6:5 little endian
+	ShmFormatRgb565 = 0x36314752
+	// ShmFormatBgr565: 16-bit BGR 565 format, [15:0] B:G:R 5:6:5 little endian
+	ShmFormatBgr565 = 0x36314742
+	// ShmFormatRgb888: 24-bit RGB format, [23:0] R:G:B little endian
+	ShmFormatRgb888 = 0x34324752
+	// ShmFormatBgr888: 24-bit BGR format, [23:0] B:G:R little endian
+	ShmFormatBgr888 = 0x34324742
+	// ShmFormatXbgr8888: 32-bit xBGR format, [31:0] x:B:G:R 8:8:8:8 little endian
+	ShmFormatXbgr8888 = 0x34324258
+	// ShmFormatRgbx8888: 32-bit RGBx format, [31:0] R:G:B:x 8:8:8:8 little endian
+	ShmFormatRgbx8888 = 0x34325852
+	// ShmFormatBgrx8888: 32-bit BGRx format, [31:0] B:G:R:x 8:8:8:8 little endian
+	ShmFormatBgrx8888 = 0x34325842
+	// ShmFormatAbgr8888: 32-bit ABGR format, [31:0] A:B:G:R 8:8:8:8 little endian
+	ShmFormatAbgr8888 = 0x34324241
+	// ShmFormatRgba8888: 32-bit RGBA format, [31:0] R:G:B:A 8:8:8:8 little endian
+	ShmFormatRgba8888 = 0x34324152
+	// ShmFormatBgra8888: 32-bit BGRA format, [31:0] B:G:R:A 8:8:8:8 little endian
+	ShmFormatBgra8888 = 0x34324142
+	// ShmFormatXrgb2101010: 32-bit xRGB format, [31:0] x:R:G:B 2:10:10:10 little endian
+	ShmFormatXrgb2101010 = 0x30335258
+	// ShmFormatXbgr2101010: 32-bit xBGR format, [31:0] x:B:G:R 2:10:10:10 little endian
+	ShmFormatXbgr2101010 = 0x30334258
+	// ShmFormatRgbx1010102: 32-bit RGBx format, [31:0] R:G:B:x 10:10:10:2 little endian
+	ShmFormatRgbx1010102 = 0x30335852
+	// ShmFormatBgrx1010102: 32-bit BGRx format, [31:0] B:G:R:x 10:10:10:2 little endian
+	ShmFormatBgrx1010102 = 0x30335842
+	// ShmFormatArgb2101010: 32-bit ARGB format, [31:0] A:R:G:B 2:10:10:10 little endian
+	ShmFormatArgb2101010 = 0x30335241
+	// ShmFormatAbgr2101010: 32-bit ABGR format, [31:0] A:B:G:R 2:10:10:10 little endian
+	ShmFormatAbgr2101010 = 0x30334241
+	// ShmFormatRgba1010102: 32-bit RGBA format, [31:0] R:G:B:A 10:10:10:2 little endian
+	ShmFormatRgba1010102 = 0x30334152
+	// ShmFormatBgra1010102: 32-bit BGRA format, [31:0] B:G:R:A 10:10:10:2 little endian
+	ShmFormatBgra1010102 = 0x30334142
+	// ShmFormatYuyv: packed YCbCr format, [31:0] Cr0:Y1:Cb0:Y0 8:8:8:8 little endian
+	ShmFormatYuyv = 0x56595559
+	// ShmFormatYvyu: packed YCbCr format, [31:0] Cb0:Y1:Cr0:Y0 8:8:8:8 little endian
+	ShmFormatYvyu = 0x55595659
+	// ShmFormatUyvy: packed YCbCr format, [31:0] Y1:Cr0:Y0:Cb0 8:8:8:8 little endian
+	ShmFormatUyvy = 0x59565955
+	// ShmFormatVyuy: packed YCbCr format, [31:0] Y1:Cb0:Y0:Cr0 8:8:8:8 little endian
+	ShmFormatVyuy = 0x59555956
+	// ShmFormatAyuv: packed AYCbCr format, [31:0] A:Y:Cb:Cr 8:8:8:8 little endian
+	ShmFormatAyuv = 0x56555941
+	// ShmFormatNv12: 2 plane YCbCr Cr:Cb format, 2x2 subsampled Cr:Cb plane
+	ShmFormatNv12 = 0x3231564e
+	// ShmFormatNv21: 2 plane YCbCr Cb:Cr format, 2x2 subsampled Cb:Cr plane
+	ShmFormatNv21 = 0x3132564e
+	// ShmFormatNv16: 2 plane YCbCr Cr:Cb format, 2x1 subsampled Cr:Cb plane
+	ShmFormatNv16 = 0x3631564e
+	// ShmFormatNv61: 2 plane YCbCr Cb:Cr format, 2x1 subsampled Cb:Cr plane
+	ShmFormatNv61 = 0x3136564e
+	// ShmFormatYuv410: 3 plane YCbCr format, 4x4 subsampled Cb (1) and Cr (2) planes
+	ShmFormatYuv410 = 0x39565559
+	// ShmFormatYvu410: 3 plane YCbCr format, 4x4 subsampled Cr (1) and Cb (2) planes
+	ShmFormatYvu410 = 0x39555659
+	// ShmFormatYuv411: 3 plane YCbCr format, 4x1 subsampled Cb (1) and Cr (2) planes
+	ShmFormatYuv411 = 0x31315559
+	// ShmFormatYvu411: 3 plane YCbCr format, 4x1 subsampled Cr (1) and Cb (2) planes
+	ShmFormatYvu411 = 0x31315659
+	// ShmFormatYuv420: 3 plane YCbCr format, 2x2 subsampled Cb (1) and Cr (2) planes
+	ShmFormatYuv420 = 0x32315559
+	// ShmFormatYvu420: 3 plane YCbCr format, 2x2 subsampled Cr (1) and Cb (2) planes
+	ShmFormatYvu420 = 0x32315659
+	// ShmFormatYuv422: 3 plane YCbCr format, 2x1 subsampled Cb (1) and Cr (2) planes
+	ShmFormatYuv422 = 0x36315559
+	// ShmFormatYvu422: 3 plane YCbCr format, 2x1 subsampled Cr (1) and Cb (2) planes
+	ShmFormatYvu422 = 0x36315659
+	// ShmFormatYuv444: 3 plane YCbCr format, non-subsampled Cb (1) and Cr (2) planes
+	ShmFormatYuv444 = 0x34325559
+	// ShmFormatYvu444: 3 plane YCbCr format, non-subsampled Cr (1) and Cb (2) planes
+	ShmFormatYvu444 = 0x34325659
+	// ShmFormatR8: [7:0] R
+	ShmFormatR8 = 0x20203852
+	// ShmFormatR16: [15:0] R little endian
+	ShmFormatR16 = 0x20363152
+	// ShmFormatRg88: [15:0] R:G 8:8 little endian
+	ShmFormatRg88 = 0x38384752
+	// ShmFormatGr88: [15:0] G:R 8:8 little endian
+	ShmFormatGr88 = 0x38385247
+	// ShmFormatRg1616: [31:0] R:G 16:16 little endian
+	ShmFormatRg1616 = 0x32334752
+	// ShmFormatGr1616: [31:0] G:R 16:16 little endian
+	ShmFormatGr1616 = 0x32335247
+	// ShmFormatXrgb16161616f: [63:0] x:R:G:B 16:16:16:16 little endian
+	ShmFormatXrgb16161616f = 0x48345258
+	// ShmFormatXbgr16161616f: [63:0] x:B:G:R 16:16:16:16 little endian
+	ShmFormatXbgr16161616f = 0x48344258
+	// ShmFormatArgb16161616f: [63:0] A:R:G:B 16:16:16:16 little endian
+	ShmFormatArgb16161616f = 0x48345241
+	// ShmFormatAbgr16161616f: [63:0] A:B:G:R 16:16:16:16 little endian
+	ShmFormatAbgr16161616f = 0x48344241
+	// ShmFormatXyuv8888: [31:0] X:Y:Cb:Cr 8:8:8:8 little endian
+	ShmFormatXyuv8888 = 0x56555958
+	// ShmFormatVuy888: [23:0] Cr:Cb:Y 8:8:8 little endian
+	ShmFormatVuy888 = 0x34325556
+	// ShmFormatVuy101010: Y followed by U then V, 10:10:10. Non-linear modifier only
+	ShmFormatVuy101010 = 0x30335556
+	// ShmFormatY210: [63:0] Cr0:0:Y1:0:Cb0:0:Y0:0 10:6:10:6:10:6:10:6 little endian per 2 Y pixels
+	ShmFormatY210 = 0x30313259
+	// ShmFormatY212: [63:0] Cr0:0:Y1:0:Cb0:0:Y0:0 12:4:12:4:12:4:12:4 little endian per 2 Y pixels
+	ShmFormatY212 = 0x32313259
+	// ShmFormatY216: [63:0] Cr0:Y1:Cb0:Y0 16:16:16:16 little endian per 2 Y pixels
+	ShmFormatY216 = 0x36313259
+	// ShmFormatY410: [31:0] A:Cr:Y:Cb 2:10:10:10 little endian
+	ShmFormatY410 = 0x30313459
+	// ShmFormatY412: [63:0] A:0:Cr:0:Y:0:Cb:0 12:4:12:4:12:4:12:4 little endian
+	ShmFormatY412 = 0x32313459
+	// ShmFormatY416: [63:0] A:Cr:Y:Cb 16:16:16:16 little endian
+	ShmFormatY416 = 0x36313459
+	// ShmFormatXvyu2101010: [31:0] X:Cr:Y:Cb 2:10:10:10 little endian
+	ShmFormatXvyu2101010 = 0x30335658
+	// ShmFormatXvyu1216161616: [63:0] X:0:Cr:0:Y:0:Cb:0 12:4:12:4:12:4:12:4 little endian
+	ShmFormatXvyu1216161616 = 0x36335658
+	// ShmFormatXvyu16161616: [63:0] X:Cr:Y:Cb 16:16:16:16 little endian
+	ShmFormatXvyu16161616 = 0x38345658
+	// ShmFormatY0l0: [63:0] A3:A2:Y3:0:Cr0:0:Y2:0:A1:A0:Y1:0:Cb0:0:Y0:0 1:1:8:2:8:2:8:2:1:1:8:2:8:2:8:2 little endian
+	ShmFormatY0l0 = 0x304c3059
+	// ShmFormatX0l0: [63:0] X3:X2:Y3:0:Cr0:0:Y2:0:X1:X0:Y1:0:Cb0:0:Y0:0 1:1:8:2:8:2:8:2:1:1:8:2:8:2:8:2 little endian
+	ShmFormatX0l0 = 0x304c3058
+	// ShmFormatY0l2: [63:0] A3:A2:Y3:Cr0:Y2:A1:A0:Y1:Cb0:Y0 1:1:10:10:10:1:1:10:10:10 little endian
+	ShmFormatY0l2 = 0x324c3059
+	// ShmFormatX0l2: [63:0] X3:X2:Y3:Cr0:Y2:X1:X0:Y1:Cb0:Y0 1:1:10:10:10:1:1:10:10:10 little endian
+	ShmFormatX0l2        = 0x324c3058
+	ShmFormatYuv4208bit  = 0x38305559
+	ShmFormatYuv42010bit = 0x30315559
+	ShmFormatXrgb8888A8  = 0x38415258
+	ShmFormatXbgr8888A8  = 0x38414258
+	ShmFormatRgbx8888A8  = 0x38415852
+	ShmFormatBgrx8888A8  = 0x38415842
+	ShmFormatRgb888A8    = 0x38413852
+	ShmFormatBgr888A8    = 0x38413842
+	ShmFormatRgb565A8    = 0x38413552
+	ShmFormatBgr565A8    = 0x38413542
+	// ShmFormatNv24: non-subsampled Cr:Cb plane
+	ShmFormatNv24 = 0x3432564e
+	// ShmFormatNv42: non-subsampled Cb:Cr plane
+	ShmFormatNv42 = 0x3234564e
+	// ShmFormatP210: 2x1 subsampled Cr:Cb plane, 10 bit per channel
+	ShmFormatP210 = 0x30313250
+	// ShmFormatP010: 2x2 subsampled Cr:Cb plane 10 bits per channel
+	ShmFormatP010 = 0x30313050
+	// ShmFormatP012: 2x2 subsampled Cr:Cb plane 12 bits per channel
+	ShmFormatP012 = 0x32313050
+	// ShmFormatP016: 2x2 subsampled Cr:Cb plane 16 bits per channel
+	ShmFormatP016 = 0x36313050
+	// ShmFormatAxbxgxrx106106106106: [63:0] A:x:B:x:G:x:R:x 10:6:10:6:10:6:10:6 little endian
 	ShmFormatAxbxgxrx106106106106 = 0x30314241
-	ShmFormatNv15                 = 0x3531564e
-	ShmFormatQ410                 = 0x30313451
-	ShmFormatQ401                 = 0x31303451
+	// ShmFormatNv15: 2x2 subsampled Cr:Cb plane
+	ShmFormatNv15 = 0x3531564e
+	ShmFormatQ410 = 0x30313451
+	ShmFormatQ401 = 0x31303451
+	// ShmFormatXrgb16161616: [63:0] x:R:G:B 16:16:16:16 little endian
+	ShmFormatXrgb16161616 = 0x38345258
+	// ShmFormatXbgr16161616: [63:0] x:B:G:R 16:16:16:16 little endian
+	ShmFormatXbgr16161616 = 0x38344258
+	// ShmFormatArgb16161616: [63:0] A:R:G:B 16:16:16:16 little endian
+	ShmFormatArgb16161616 = 0x38345241
+	// ShmFormatAbgr16161616: [63:0] A:B:G:R 16:16:16:16 little endian
+	ShmFormatAbgr16161616 = 0x38344241
+	// ShmFormatC1: [7:0] C0:C1:C2:C3:C4:C5:C6:C7 1:1:1:1:1:1:1:1 eight pixels/byte
+	ShmFormatC1 = 0x20203143
+	// ShmFormatC2: [7:0] C0:C1:C2:C3 2:2:2:2 four pixels/byte
+	ShmFormatC2 = 0x20203243
+	// ShmFormatC4: [7:0] C0:C1 4:4 two pixels/byte
+	ShmFormatC4 = 0x20203443
+	// ShmFormatD1: [7:0] D0:D1:D2:D3:D4:D5:D6:D7 1:1:1:1:1:1:1:1 eight pixels/byte
+	ShmFormatD1 = 0x20203144
+	// ShmFormatD2: [7:0] D0:D1:D2:D3 2:2:2:2 four pixels/byte
+	ShmFormatD2 = 0x20203244
+	// ShmFormatD4: [7:0] D0:D1 4:4 two pixels/byte
+	ShmFormatD4 = 0x20203444
+	// ShmFormatD8: [7:0] D
+	ShmFormatD8 = 0x20203844
+	// ShmFormatR1: [7:0] R0:R1:R2:R3:R4:R5:R6:R7 1:1:1:1:1:1:1:1 eight pixels/byte
+	ShmFormatR1 = 0x20203152
+	// ShmFormatR2: [7:0] R0:R1:R2:R3 2:2:2:2 four pixels/byte
+	ShmFormatR2 = 0x20203252
+	// ShmFormatR4: [7:0] R0:R1 4:4 two pixels/byte
+	ShmFormatR4 = 0x20203452
+	// ShmFormatR10: [15:0] x:R 6:10 little endian
+	ShmFormatR10 = 0x20303152
+	// ShmFormatR12: [15:0] x:R 4:12 little endian
+	ShmFormatR12 = 0x20323152
+	// ShmFormatAvuy8888: [31:0] A:Cr:Cb:Y 8:8:8:8 little endian
+	ShmFormatAvuy8888 = 0x59555641
+	// ShmFormatXvuy8888: [31:0] X:Cr:Cb:Y 8:8:8:8 little endian
+	ShmFormatXvuy8888 = 0x59555658
+	// ShmFormatP030: 2x2 subsampled Cr:Cb plane 10 bits per channel packed
+	ShmFormatP030 = 0x30333050
 )
 
-type BufferReleaseEvent struct {
-	B *Buffer
+// ShmFormatEvent: pixel format description
+//
+// Informs the client about a valid pixel format that
+// can be used for buffers. Known formats include
+// argb8888 and xrgb8888.
+type ShmFormatEvent struct {
+	Format uint32
 }
 
-type BufferReleaseHandler interface {
-	HandleBufferRelease(BufferReleaseEvent)
+// ShmFormatHandler is implemented by the receivers of ShmFormatEvent
+type ShmFormatHandler interface {
+	HandleShmFormat(ShmFormatEvent)
 }
 
-func (p *Buffer) AddReleaseHandler(h BufferReleaseHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.releaseHandlers = append(p.releaseHandlers, h)
-		p.mu.Unlock()
+// AddFormatHandler adds a handler for ShmFormatEvent
+func (p *Shm) AddFormatHandler(h ShmFormatHandler) {
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.formatHandlers = append(p.formatHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *Buffer) RemoveReleaseHandler(h BufferReleaseHandler) {
+// RemoveFormatHandler removes a handler previously added by AddFormatHandler
+func (p *Shm) RemoveFormatHandler(h ShmFormatHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.releaseHandlers {
+	for i, e := range p.formatHandlers {
 		if e == h {
-			p.releaseHandlers = append(p.releaseHandlers[:i], p.releaseHandlers[i+1:]...)
+			p.formatHandlers = append(p.formatHandlers[:i:i], p.formatHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-func (p *Buffer) Dispatch(event *Event) {
+// Dispatch decodes an event received on the wl_shm and runs its handlers
+func (p *Shm) Dispatch(event *Event) {
 	switch event.Opcode {
 	case 0:
-		if len(p.releaseHandlers) > 0 {
-			ev := BufferReleaseEvent{B: p}
-			p.mu.RLock()
-			for _, h := range p.releaseHandlers {
-				h.HandleBufferRelease(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.formatHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := ShmFormatEvent{}
+		ev.Format = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleShmFormat(ev)
 		}
 	}
 }
 
-type Buffer struct {
+const (
+	ShmFormatSinceVersion     = 1
+	ShmCreatePoolSinceVersion = 1
+	ShmReleaseSinceVersion    = 2
+)
+
+// ShmInterface describes the wl_shm interface
+var ShmInterface = &Interface{
+	Name:    "wl_shm",
+	Version: 2,
+	Requests: []Message{
+		{
+			Name:  "create_pool",
+			Since: 1,
+			Args: []Arg{
+				{Name: "id", Type: ArgNewId, Interface: "wl_shm_pool"},
+				{Name: "fd", Type: ArgFd},
+				{Name: "size", Type: ArgInt},
+			},
+		},
+		{
+			Name:       "release",
+			Since:      2,
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name:  "format",
+			Since: 1,
+			Args: []Arg{
+				{Name: "format", Type: ArgUint},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "invalid_format", Value: 0},
+				{Name: "invalid_stride", Value: 1},
+				{Name: "invalid_fd", Value: 2},
+			},
+		},
+		{
+			Name: "format",
+			Entries: []EnumEntry{
+				{Name: "argb8888", Value: 0},
+				{Name: "xrgb8888", Value: 1},
+				{Name: "c8", Value: 0x20203843},
+				{Name: "rgb332", Value: 0x38424752},
+				{Name: "bgr233", Value: 0x38524742},
+				{Name: "xrgb4444", Value: 0x32315258},
+				{Name: "xbgr4444", Value: 0x32314258},
+				{Name: "rgbx4444", Value: 0x32315852},
+				{Name: "bgrx4444", Value: 0x32315842},
+				{Name: "argb4444", Value: 0x32315241},
+				{Name: "abgr4444", Value: 0x32314241},
+				{Name: "rgba4444", Value: 0x32314152},
+				{Name: "bgra4444", Value: 0x32314142},
+				{Name: "xrgb1555", Value: 0x35315258},
+				{Name: "xbgr1555", Value: 0x35314258},
+				{Name: "rgbx5551", Value: 0x35315852},
+				{Name: "bgrx5551", Value: 0x35315842},
+				{Name: "argb1555", Value: 0x35315241},
+				{Name: "abgr1555", Value: 0x35314241},
+				{Name: "rgba5551", Value: 0x35314152},
+				{Name: "bgra5551", Value: 0x35314142},
+				{Name: "rgb565", Value: 0x36314752},
+				{Name: "bgr565", Value: 0x36314742},
+				{Name: "rgb888", Value: 0x34324752},
+				{Name: "bgr888", Value: 0x34324742},
+				{Name: "xbgr8888", Value: 0x34324258},
+				{Name: "rgbx8888", Value: 0x34325852},
+				{Name: "bgrx8888", Value: 0x34325842},
+				{Name: "abgr8888", Value: 0x34324241},
+				{Name: "rgba8888", Value: 0x34324152},
+				{Name: "bgra8888", Value: 0x34324142},
+				{Name: "xrgb2101010", Value: 0x30335258},
+				{Name: "xbgr2101010", Value: 0x30334258},
+				{Name: "rgbx1010102", Value: 0x30335852},
+				{Name: "bgrx1010102", Value: 0x30335842},
+				{Name: "argb2101010", Value: 0x30335241},
+				{Name: "abgr2101010", Value: 0x30334241},
+				{Name: "rgba1010102", Value: 0x30334152},
+				{Name: "bgra1010102", Value: 0x30334142},
+				{Name: "yuyv", Value: 0x56595559},
+				{Name: "yvyu", Value: 0x55595659},
+				{Name: "uyvy", Value: 0x59565955},
+				{Name: "vyuy", Value: 0x59555956},
+				{Name: "ayuv", Value: 0x56555941},
+				{Name: "nv12", Value: 0x3231564e},
+				{Name: "nv21", Value: 0x3132564e},
+				{Name: "nv16", Value: 0x3631564e},
+				{Name: "nv61", Value: 0x3136564e},
+				{Name: "yuv410", Value: 0x39565559},
+				{Name: "yvu410", Value: 0x39555659},
+				{Name: "yuv411", Value: 0x31315559},
+				{Name: "yvu411", Value: 0x31315659},
+				{Name: "yuv420", Value: 0x32315559},
+				{Name: "yvu420", Value: 0x32315659},
+				{Name: "yuv422", Value: 0x36315559},
+				{Name: "yvu422", Value: 0x36315659},
+				{Name: "yuv444", Value: 0x34325559},
+				{Name: "yvu444", Value: 0x34325659},
+				{Name: "r8", Value: 0x20203852},
+				{Name: "r16", Value: 0x20363152},
+				{Name: "rg88", Value: 0x38384752},
+				{Name: "gr88", Value: 0x38385247},
+				{Name: "rg1616", Value: 0x32334752},
+				{Name: "gr1616", Value: 0x32335247},
+				{Name: "xrgb16161616f", Value: 0x48345258},
+				{Name: "xbgr16161616f", Value: 0x48344258},
+				{Name: "argb16161616f", Value: 0x48345241},
+				{Name: "abgr16161616f", Value: 0x48344241},
+				{Name: "xyuv8888", Value: 0x56555958},
+				{Name: "vuy888", Value: 0x34325556},
+				{Name: "vuy101010", Value: 0x30335556},
+				{Name: "y210", Value: 0x30313259},
+				{Name: "y212", Value: 0x32313259},
+				{Name: "y216", Value: 0x36313259},
+				{Name: "y410", Value: 0x30313459},
+				{Name: "y412", Value: 0x32313459},
+				{Name: "y416", Value: 0x36313459},
+				{Name: "xvyu2101010", Value: 0x30335658},
+				{Name: "xvyu12_16161616", Value: 0x36335658},
+				{Name: "xvyu16161616", Value: 0x38345658},
+				{Name: "y0l0", Value: 0x304c3059},
+				{Name: "x0l0", Value: 0x304c3058},
+				{Name: "y0l2", Value: 0x324c3059},
+				{Name: "x0l2", Value: 0x324c3058},
+				{Name: "yuv420_8bit", Value: 0x38305559},
+				{Name: "yuv420_10bit", Value: 0x30315559},
+				{Name: "xrgb8888_a8", Value: 0x38415258},
+				{Name: "xbgr8888_a8", Value: 0x38414258},
+				{Name: "rgbx8888_a8", Value: 0x38415852},
+				{Name: "bgrx8888_a8", Value: 0x38415842},
+				{Name: "rgb888_a8", Value: 0x38413852},
+				{Name: "bgr888_a8", Value: 0x38413842},
+				{Name: "rgb565_a8", Value: 0x38413552},
+				{Name: "bgr565_a8", Value: 0x38413542},
+				{Name: "nv24", Value: 0x3432564e},
+				{Name: "nv42", Value: 0x3234564e},
+				{Name: "p210", Value: 0x30313250},
+				{Name: "p010", Value: 0x30313050},
+				{Name: "p012", Value: 0x32313050},
+				{Name: "p016", Value: 0x36313050},
+				{Name: "axbxgxrx106106106106", Value: 0x30314241},
+				{Name: "nv15", Value: 0x3531564e},
+				{Name: "q410", Value: 0x30313451},
+				{Name: "q401", Value: 0x31303451},
+				{Name: "xrgb16161616", Value: 0x38345258},
+				{Name: "xbgr16161616", Value: 0x38344258},
+				{Name: "argb16161616", Value: 0x38345241},
+				{Name: "abgr16161616", Value: 0x38344241},
+				{Name: "c1", Value: 0x20203143},
+				{Name: "c2", Value: 0x20203243},
+				{Name: "c4", Value: 0x20203443},
+				{Name: "d1", Value: 0x20203144},
+				{Name: "d2", Value: 0x20203244},
+				{Name: "d4", Value: 0x20203444},
+				{Name: "d8", Value: 0x20203844},
+				{Name: "r1", Value: 0x20203152},
+				{Name: "r2", Value: 0x20203252},
+				{Name: "r4", Value: 0x20203452},
+				{Name: "r10", Value: 0x20303152},
+				{Name: "r12", Value: 0x20323152},
+				{Name: "avuy8888", Value: 0x59555641},
+				{Name: "xvuy8888", Value: 0x59555658},
+				{Name: "p030", Value: 0x30333050},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_shm interface
+func (p *Shm) Interface() *Interface {
+	return ShmInterface
+}
+
+// Buffer: content for a wl_surface
+//
+// A buffer provides the content for a wl_surface. Buffers are
+// created through factory interfaces such as wl_drm, wl_shm or
+// similar. It has a width and a height and can be attached to a
+// wl_surface, but the mechanism by which a client provides and
+// updates the contents is defined by the buffer factory interface.
+type Buffer struct {
 	BaseProxy
 	mu              sync.RWMutex
 	releaseHandlers []BufferReleaseHandler
 }
 
+// NewBuffer creates a new wl_buffer proxy registered in the Context
 func NewBuffer(ctx *Context) *Buffer {
 	ret := new(Buffer)
 	ctx.Register(ret)
 	return ret
 }
 
-// Destroy will destroy a buffer.
-//
+// Destroy: destroy a buffer
 //
 // Destroy a buffer. If and how you need to release the backing
 // storage is defined by the buffer factory interface.
 //
 // For possible side-effects to a surface, see wl_surface.attach.
-//
 func (p *Buffer) Destroy() error {
-	err := p.Context().SendRequest(p, 0)
+	err := p.Context().MarshalRequest(p, 0, nil)
 	p.Unregister()
 	return err
 }
 
-type DataOfferOfferEvent struct {
-	MimeType string
-}
-
-type DataOfferOfferHandler interface {
-	HandleDataOfferOffer(DataOfferOfferEvent)
-}
-
-func (p *DataOffer) AddOfferHandler(h DataOfferOfferHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.offerHandlers = append(p.offerHandlers, h)
-		p.mu.Unlock()
-	}
-}
-
-func (p *DataOffer) RemoveOfferHandler(h DataOfferOfferHandler) {
-	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	for i, e := range p.offerHandlers {
-		if e == h {
-			p.offerHandlers = append(p.offerHandlers[:i], p.offerHandlers[i+1:]...)
-			break
-		}
-	}
-}
-
-type DataOfferSourceActionsEvent struct {
-	SourceActions uint32
+// BufferReleaseEvent: compositor releases buffer
+//
+// Sent when this wl_buffer is no longer used by the compositor.
+// The client is now free to reuse or destroy this buffer and its
+// backing storage.
+//
+// If a client receives a release event before the frame callback
+// requested in the same wl_surface.commit that attaches this
+// wl_buffer to a surface, then the client is immediately free to
+// reuse the buffer and its backing storage, and does not need a
+// second buffer for the next surface content update. Typically
+// this is possible, when the compositor maintains a copy of the
+// wl_surface contents, e.g. as a GL texture. This is an important
+// optimization for GL(ES) compositors with wl_shm clients.
+type BufferReleaseEvent struct {
+	B *Buffer
 }
 
-type DataOfferSourceActionsHandler interface {
-	HandleDataOfferSourceActions(DataOfferSourceActionsEvent)
+// BufferReleaseHandler is implemented by the receivers of BufferReleaseEvent
+type BufferReleaseHandler interface {
+	HandleBufferRelease(BufferReleaseEvent)
 }
 
-func (p *DataOffer) AddSourceActionsHandler(h DataOfferSourceActionsHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.sourceActionsHandlers = append(p.sourceActionsHandlers, h)
-		p.mu.Unlock()
+// AddReleaseHandler adds a handler for BufferReleaseEvent
+func (p *Buffer) AddReleaseHandler(h BufferReleaseHandler) {
+	if h == nil {
+		return
 	}
-}
 
-func (p *DataOffer) RemoveSourceActionsHandler(h DataOfferSourceActionsHandler) {
 	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	for i, e := range p.sourceActionsHandlers {
-		if e == h {
-			p.sourceActionsHandlers = append(
-				p.sourceActionsHandlers[:i],
-				p.sourceActionsHandlers[i+1:]...)
-			break
-		}
-	}
-}
-
-type DataOfferActionEvent struct {
-	DndAction uint32
-}
-
-type DataOfferActionHandler interface {
-	HandleDataOfferAction(DataOfferActionEvent)
-}
-
-func (p *DataOffer) AddActionHandler(h DataOfferActionHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.actionHandlers = append(p.actionHandlers, h)
-		p.mu.Unlock()
-	}
+	p.releaseHandlers = append(p.releaseHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *DataOffer) RemoveActionHandler(h DataOfferActionHandler) {
+// RemoveReleaseHandler removes a handler previously added by AddReleaseHandler
+func (p *Buffer) RemoveReleaseHandler(h BufferReleaseHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.actionHandlers {
+	for i, e := range p.releaseHandlers {
 		if e == h {
-			p.actionHandlers = append(p.actionHandlers[:i], p.actionHandlers[i+1:]...)
+			p.releaseHandlers = append(p.releaseHandlers[:i:i], p.releaseHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-func (p *DataOffer) Dispatch(event *Event) {
+// Dispatch decodes an event received on the wl_buffer and runs its handlers
+func (p *Buffer) Dispatch(event *Event) {
 	switch event.Opcode {
 	case 0:
-		if len(p.offerHandlers) > 0 {
-			ev := DataOfferOfferEvent{}
-			ev.MimeType = event.String()
-			p.mu.RLock()
-			for _, h := range p.offerHandlers {
-				h.HandleDataOfferOffer(ev)
-			}
-			p.mu.RUnlock()
-		}
-	case 1:
-		if len(p.sourceActionsHandlers) > 0 {
-			ev := DataOfferSourceActionsEvent{}
-			ev.SourceActions = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.sourceActionsHandlers {
-				h.HandleDataOfferSourceActions(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.releaseHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
 		}
-	case 2:
-		if len(p.actionHandlers) > 0 {
-			ev := DataOfferActionEvent{}
-			ev.DndAction = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.actionHandlers {
-				h.HandleDataOfferAction(ev)
-			}
-			p.mu.RUnlock()
+		ev := BufferReleaseEvent{}
+		ev.B = p
+		for _, h := range handlers {
+			h.HandleBufferRelease(ev)
 		}
 	}
 }
 
+const (
+	BufferReleaseSinceVersion = 1
+	BufferDestroySinceVersion = 1
+)
+
+// BufferInterface describes the wl_buffer interface
+var BufferInterface = &Interface{
+	Name:    "wl_buffer",
+	Version: 1,
+	Requests: []Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name:  "release",
+			Since: 1,
+		},
+	},
+}
+
+// Interface returns the description of the wl_buffer interface
+func (p *Buffer) Interface() *Interface {
+	return BufferInterface
+}
+
+// DataOffer: offer to transfer data
+//
+// A wl_data_offer represents a piece of data offered for transfer
+// by another client (the source client).  It is used by the
+// copy-and-paste and drag-and-drop mechanisms.  The offer
+// describes the different mime types that the data can be
+// converted to and provides the mechanism for transferring the
+// data directly from the source client.
 type DataOffer struct {
 	BaseProxy
 	mu                    sync.RWMutex
@@ -794,14 +1541,14 @@ type DataOffer struct {
 	actionHandlers        []DataOfferActionHandler
 }
 
+// NewDataOffer creates a new wl_data_offer proxy registered in the Context
 func NewDataOffer(ctx *Context) *DataOffer {
 	ret := new(DataOffer)
 	ctx.Register(ret)
 	return ret
 }
 
-// Accept will accept one of the offered mime types.
-//
+// Accept: accept one of the offered mime types
 //
 // Indicate that the client can accept the given mime type, or
 // NULL for not accepted.
@@ -818,12 +1565,16 @@ func NewDataOffer(ctx *Context) *DataOffer {
 // wl_data_source.cancelled. Clients may still use this event in
 // conjunction with wl_data_source.action for feedback.
 //
+//	serial: serial number of the accept request
+//	mimeType: mime type accepted by the client
 func (p *DataOffer) Accept(serial uint32, mimeType string) error {
-	return p.Context().SendRequest(p, 0, serial, mimeType)
+	return p.Context().MarshalRequest(p, 0, func(r *Request) {
+		r.PutUint32(serial)
+		r.PutString(mimeType)
+	})
 }
 
-// Receive will request that the data is transferred.
-//
+// Receive: request that the data is transferred
 //
 // To transfer the offered data, the client issues this request
 // and indicates the mime type it wants to receive.  The transfer
@@ -841,23 +1592,25 @@ func (p *DataOffer) Accept(serial uint32, mimeType string) error {
 // clients may preemptively fetch data or examine it more closely to
 // determine acceptance.
 //
+//	mimeType: mime type desired by receiver
+//	fd: file descriptor for data transfer
 func (p *DataOffer) Receive(mimeType string, fd uintptr) error {
-	return p.Context().SendRequest(p, 1, mimeType, fd)
+	return p.Context().MarshalRequest(p, 1, func(r *Request) {
+		r.PutString(mimeType)
+		r.PutFd(fd)
+	})
 }
 
-// Destroy will destroy data offer.
-//
+// Destroy: destroy data offer
 //
 // Destroy the data offer.
-//
 func (p *DataOffer) Destroy() error {
-	err := p.Context().SendRequest(p, 2)
+	err := p.Context().MarshalRequest(p, 2, nil)
 	p.Unregister()
 	return err
 }
 
-// Finish will the offer will no longer be used.
-//
+// Finish: the offer will no longer be used
 //
 // Notifies the compositor that the drag destination successfully
 // finished the drag-and-drop operation.
@@ -873,13 +1626,14 @@ func (p *DataOffer) Destroy() error {
 //
 // If wl_data_offer.finish request is received for a non drag and drop
 // operation, the invalid_finish protocol error is raised.
-//
 func (p *DataOffer) Finish() error {
-	return p.Context().SendRequest(p, 3)
+	if err := CheckRequestVersion(p, 3); err != nil {
+		return err
+	}
+	return p.Context().MarshalRequest(p, 3, nil)
 }
 
-// SetActions will set the available/preferred drag-and-drop actions.
-//
+// SetActions: set the available/preferred drag-and-drop actions
 //
 // Sets the actions that the destination side client supports for
 // this operation. This request may trigger the emission of
@@ -913,251 +1667,323 @@ func (p *DataOffer) Finish() error {
 // This request can only be made on drag-and-drop offers, a protocol error
 // will be raised otherwise.
 //
+//	dndActions: actions supported by the destination client
+//	preferredAction: action preferred by the destination client
 func (p *DataOffer) SetActions(dndActions uint32, preferredAction uint32) error {
-	return p.Context().SendRequest(p, 4, dndActions, preferredAction)
+	if err := CheckRequestVersion(p, 4); err != nil {
+		return err
+	}
+	return p.Context().MarshalRequest(p, 4, func(r *Request) {
+		r.PutUint32(dndActions)
+		r.PutUint32(preferredAction)
+	})
 }
 
+// DataOfferError:
 const (
-	DataOfferErrorInvalidFinish     = 0
+	// DataOfferErrorInvalidFinish: finish request was called untimely
+	DataOfferErrorInvalidFinish = 0
+	// DataOfferErrorInvalidActionMask: action mask contains invalid values
 	DataOfferErrorInvalidActionMask = 1
-	DataOfferErrorInvalidAction     = 2
-	DataOfferErrorInvalidOffer      = 3
+	// DataOfferErrorInvalidAction: action argument has an invalid value
+	DataOfferErrorInvalidAction = 2
+	// DataOfferErrorInvalidOffer: offer doesn't accept this request
+	DataOfferErrorInvalidOffer = 3
 )
 
-type DataSourceTargetEvent struct {
+// DataOfferOfferEvent: advertise offered mime type
+//
+// Sent immediately after creating the wl_data_offer object.  One
+// event per offered mime type.
+type DataOfferOfferEvent struct {
 	MimeType string
 }
 
-type DataSourceTargetHandler interface {
-	HandleDataSourceTarget(DataSourceTargetEvent)
+// DataOfferOfferHandler is implemented by the receivers of DataOfferOfferEvent
+type DataOfferOfferHandler interface {
+	HandleDataOfferOffer(DataOfferOfferEvent)
 }
 
-func (p *DataSource) AddTargetHandler(h DataSourceTargetHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.targetHandlers = append(p.targetHandlers, h)
-		p.mu.Unlock()
+// AddOfferHandler adds a handler for DataOfferOfferEvent
+func (p *DataOffer) AddOfferHandler(h DataOfferOfferHandler) {
+	if h == nil {
+		return
 	}
-}
 
-func (p *DataSource) RemoveTargetHandler(h DataSourceTargetHandler) {
 	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	for i, e := range p.targetHandlers {
-		if e == h {
-			p.targetHandlers = append(p.targetHandlers[:i], p.targetHandlers[i+1:]...)
-			break
-		}
-	}
-}
-
-type DataSourceSendEvent struct {
-	MimeType string
-	Fd       uintptr
-	FdError  error
-}
-
-type DataSourceSendHandler interface {
-	HandleDataSourceSend(DataSourceSendEvent)
+	p.offerHandlers = append(p.offerHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *DataSource) AddSendHandler(h DataSourceSendHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.sendHandlers = append(p.sendHandlers, h)
-		p.mu.Unlock()
-	}
-}
-
-func (p *DataSource) RemoveSendHandler(h DataSourceSendHandler) {
+// RemoveOfferHandler removes a handler previously added by AddOfferHandler
+func (p *DataOffer) RemoveOfferHandler(h DataOfferOfferHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.sendHandlers {
+	for i, e := range p.offerHandlers {
 		if e == h {
-			p.sendHandlers = append(p.sendHandlers[:i], p.sendHandlers[i+1:]...)
+			p.offerHandlers = append(p.offerHandlers[:i:i], p.offerHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-type DataSourceCancelledEvent struct {
+// DataOfferSourceActionsEvent: notify the source-side available actions
+//
+// This event indicates the actions offered by the data source. It
+// will be sent right after wl_data_device.enter, or anytime the source
+// side changes its offered actions through wl_data_source.set_actions.
+type DataOfferSourceActionsEvent struct {
+	SourceActions uint32
 }
 
-type DataSourceCancelledHandler interface {
-	HandleDataSourceCancelled(DataSourceCancelledEvent)
+// DataOfferSourceActionsHandler is implemented by the receivers of DataOfferSourceActionsEvent
+type DataOfferSourceActionsHandler interface {
+	HandleDataOfferSourceActions(DataOfferSourceActionsEvent)
 }
 
-func (p *DataSource) AddCancelledHandler(h DataSourceCancelledHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.cancelledHandlers = append(p.cancelledHandlers, h)
-		p.mu.Unlock()
+// AddSourceActionsHandler adds a handler for DataOfferSourceActionsEvent
+func (p *DataOffer) AddSourceActionsHandler(h DataOfferSourceActionsHandler) {
+	if h == nil {
+		return
 	}
-}
 
-func (p *DataSource) RemoveCancelledHandler(h DataSourceCancelledHandler) {
 	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	for i, e := range p.cancelledHandlers {
-		if e == h {
-			p.cancelledHandlers = append(p.cancelledHandlers[:i], p.cancelledHandlers[i+1:]...)
-			break
-		}
-	}
-}
-
-type DataSourceDndDropPerformedEvent struct {
-}
-
-type DataSourceDndDropPerformedHandler interface {
-	HandleDataSourceDndDropPerformed(DataSourceDndDropPerformedEvent)
-}
-
-func (p *DataSource) AddDndDropPerformedHandler(h DataSourceDndDropPerformedHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.dndDropPerformedHandlers = append(p.dndDropPerformedHandlers, h)
-		p.mu.Unlock()
-	}
+	p.sourceActionsHandlers = append(p.sourceActionsHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *DataSource) RemoveDndDropPerformedHandler(h DataSourceDndDropPerformedHandler) {
+// RemoveSourceActionsHandler removes a handler previously added by AddSourceActionsHandler
+func (p *DataOffer) RemoveSourceActionsHandler(h DataOfferSourceActionsHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.dndDropPerformedHandlers {
+	for i, e := range p.sourceActionsHandlers {
 		if e == h {
-			p.dndDropPerformedHandlers = append(
-				p.dndDropPerformedHandlers[:i],
-				p.dndDropPerformedHandlers[i+1:]...)
+			p.sourceActionsHandlers = append(p.sourceActionsHandlers[:i:i], p.sourceActionsHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-type DataSourceDndFinishedEvent struct {
+// DataOfferActionEvent: notify the selected action
+//
+// This event indicates the action selected by the compositor after
+// matching the source/destination side actions. Only one action (or
+// none) will be offered here.
+//
+// This event can be emitted multiple times during the drag-and-drop
+// operation in response to destination side action changes through
+// wl_data_offer.set_actions.
+//
+// This event will no longer be emitted after wl_data_device.drop
+// happened on the drag-and-drop destination, the client must
+// honor the last action received, or the last preferred one set
+// through wl_data_offer.set_actions when handling an "ask" action.
+//
+// Compositors may also change the selected action on the fly, mainly
+// in response to keyboard modifier changes during the drag-and-drop
+// operation.
+//
+// The most recent action received is always the valid one. Prior to
+// receiving wl_data_device.drop, the chosen action may change (e.g.
+// due to keyboard modifiers being pressed). At the time of receiving
+// wl_data_device.drop the drag-and-drop destination must honor the
+// last action received.
+//
+// Action changes may still happen after wl_data_device.drop,
+// especially on "ask" actions, where the drag-and-drop destination
+// may choose another action afterwards. Action changes happening
+// at this stage are always the result of inter-client negotiation, the
+// compositor shall no longer be able to induce a different action.
+//
+// Upon "ask" actions, it is expected that the drag-and-drop destination
+// may potentially choose a different action and/or mime type,
+// based on wl_data_offer.source_actions and finally chosen by the
+// user (e.g. popping up a menu with the available options). The
+// final wl_data_offer.set_actions and wl_data_offer.accept requests
+// must happen before the call to wl_data_offer.finish.
+type DataOfferActionEvent struct {
+	DndAction uint32
 }
 
-type DataSourceDndFinishedHandler interface {
-	HandleDataSourceDndFinished(DataSourceDndFinishedEvent)
+// DataOfferActionHandler is implemented by the receivers of DataOfferActionEvent
+type DataOfferActionHandler interface {
+	HandleDataOfferAction(DataOfferActionEvent)
 }
 
-func (p *DataSource) AddDndFinishedHandler(h DataSourceDndFinishedHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.dndFinishedHandlers = append(p.dndFinishedHandlers, h)
-		p.mu.Unlock()
+// AddActionHandler adds a handler for DataOfferActionEvent
+func (p *DataOffer) AddActionHandler(h DataOfferActionHandler) {
+	if h == nil {
+		return
 	}
-}
 
-func (p *DataSource) RemoveDndFinishedHandler(h DataSourceDndFinishedHandler) {
 	p.mu.Lock()
-	defer p.mu.Unlock()
-
-	for i, e := range p.dndFinishedHandlers {
-		if e == h {
-			p.dndFinishedHandlers = append(
-				p.dndFinishedHandlers[:i],
-				p.dndFinishedHandlers[i+1:]...)
-			break
-		}
-	}
-}
-
-type DataSourceActionEvent struct {
-	DndAction uint32
-}
-
-type DataSourceActionHandler interface {
-	HandleDataSourceAction(DataSourceActionEvent)
-}
-
-func (p *DataSource) AddActionHandler(h DataSourceActionHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.actionHandlers = append(p.actionHandlers, h)
-		p.mu.Unlock()
-	}
+	p.actionHandlers = append(p.actionHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *DataSource) RemoveActionHandler(h DataSourceActionHandler) {
+// RemoveActionHandler removes a handler previously added by AddActionHandler
+func (p *DataOffer) RemoveActionHandler(h DataOfferActionHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.actionHandlers {
 		if e == h {
-			p.actionHandlers = append(p.actionHandlers[:i], p.actionHandlers[i+1:]...)
+			p.actionHandlers = append(p.actionHandlers[:i:i], p.actionHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-func (p *DataSource) Dispatch(event *Event) {
+// Dispatch decodes an event received on the wl_data_offer and runs its handlers
+func (p *DataOffer) Dispatch(event *Event) {
 	switch event.Opcode {
 	case 0:
-		if len(p.targetHandlers) > 0 {
-			ev := DataSourceTargetEvent{}
-			ev.MimeType = event.String()
-			p.mu.RLock()
-			for _, h := range p.targetHandlers {
-				h.HandleDataSourceTarget(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.offerHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := DataOfferOfferEvent{}
+		ev.MimeType = event.String()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleDataOfferOffer(ev)
 		}
 	case 1:
-		if len(p.sendHandlers) > 0 {
-			ev := DataSourceSendEvent{}
-			ev.MimeType = event.String()
-			ev.Fd, ev.FdError = event.FD()
-			p.mu.RLock()
-			for _, h := range p.sendHandlers {
-				h.HandleDataSourceSend(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.sourceActionsHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
 		}
-	case 2:
-		if len(p.cancelledHandlers) > 0 {
-			ev := DataSourceCancelledEvent{}
-			p.mu.RLock()
-			for _, h := range p.cancelledHandlers {
-				h.HandleDataSourceCancelled(ev)
-			}
-			p.mu.RUnlock()
+		ev := DataOfferSourceActionsEvent{}
+		ev.SourceActions = event.Uint32()
+		if event.Err() != nil {
+			break
 		}
-	case 3:
-		if len(p.dndDropPerformedHandlers) > 0 {
-			ev := DataSourceDndDropPerformedEvent{}
-			p.mu.RLock()
-			for _, h := range p.dndDropPerformedHandlers {
-				h.HandleDataSourceDndDropPerformed(ev)
-			}
-			p.mu.RUnlock()
+		for _, h := range handlers {
+			h.HandleDataOfferSourceActions(ev)
 		}
-	case 4:
-		if len(p.dndFinishedHandlers) > 0 {
-			ev := DataSourceDndFinishedEvent{}
-			p.mu.RLock()
-			for _, h := range p.dndFinishedHandlers {
-				h.HandleDataSourceDndFinished(ev)
-			}
-			p.mu.RUnlock()
+	case 2:
+		p.mu.RLock()
+		handlers := p.actionHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
 		}
-	case 5:
-		if len(p.actionHandlers) > 0 {
-			ev := DataSourceActionEvent{}
-			ev.DndAction = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.actionHandlers {
-				h.HandleDataSourceAction(ev)
-			}
-			p.mu.RUnlock()
+		ev := DataOfferActionEvent{}
+		ev.DndAction = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleDataOfferAction(ev)
 		}
 	}
 }
 
+const (
+	DataOfferOfferSinceVersion         = 1
+	DataOfferSourceActionsSinceVersion = 3
+	DataOfferActionSinceVersion        = 3
+	DataOfferAcceptSinceVersion        = 1
+	DataOfferReceiveSinceVersion       = 1
+	DataOfferDestroySinceVersion       = 1
+	DataOfferFinishSinceVersion        = 3
+	DataOfferSetActionsSinceVersion    = 3
+)
+
+// DataOfferInterface describes the wl_data_offer interface
+var DataOfferInterface = &Interface{
+	Name:    "wl_data_offer",
+	Version: 3,
+	Requests: []Message{
+		{
+			Name:  "accept",
+			Since: 1,
+			Args: []Arg{
+				{Name: "serial", Type: ArgUint},
+				{Name: "mime_type", Type: ArgString, Nullable: true},
+			},
+		},
+		{
+			Name:  "receive",
+			Since: 1,
+			Args: []Arg{
+				{Name: "mime_type", Type: ArgString},
+				{Name: "fd", Type: ArgFd},
+			},
+		},
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "finish",
+			Since: 3,
+		},
+		{
+			Name:  "set_actions",
+			Since: 3,
+			Args: []Arg{
+				{Name: "dnd_actions", Type: ArgUint},
+				{Name: "preferred_action", Type: ArgUint},
+			},
+		},
+	},
+	Events: []Message{
+		{
+			Name:  "offer",
+			Since: 1,
+			Args: []Arg{
+				{Name: "mime_type", Type: ArgString},
+			},
+		},
+		{
+			Name:  "source_actions",
+			Since: 3,
+			Args: []Arg{
+				{Name: "source_actions", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "action",
+			Since: 3,
+			Args: []Arg{
+				{Name: "dnd_action", Type: ArgUint},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "invalid_finish", Value: 0},
+				{Name: "invalid_action_mask", Value: 1},
+				{Name: "invalid_action", Value: 2},
+				{Name: "invalid_offer", Value: 3},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_data_offer interface
+func (p *DataOffer) Interface() *Interface {
+	return DataOfferInterface
+}
+
+// DataSource: offer to transfer data
+//
+// The wl_data_source object is the source side of a wl_data_offer.
+// It is created by the source client in a data transfer and
+// provides a way to describe the offered data and a way to respond
+// to requests to transfer the data.
 type DataSource struct {
 	BaseProxy
 	mu                       sync.RWMutex
@@ -1169,36 +1995,36 @@ type DataSource struct {
 	actionHandlers           []DataSourceActionHandler
 }
 
+// NewDataSource creates a new wl_data_source proxy registered in the Context
 func NewDataSource(ctx *Context) *DataSource {
 	ret := new(DataSource)
 	ctx.Register(ret)
 	return ret
 }
 
-// Offer will add an offered mime type.
-//
+// Offer: add an offered mime type
 //
 // This request adds a mime type to the set of mime types
 // advertised to targets.  Can be called several times to offer
 // multiple types.
 //
+//	mimeType: mime type offered by the data source
 func (p *DataSource) Offer(mimeType string) error {
-	return p.Context().SendRequest(p, 0, mimeType)
+	return p.Context().MarshalRequest(p, 0, func(r *Request) {
+		r.PutString(mimeType)
+	})
 }
 
-// Destroy will destroy the data source.
-//
+// Destroy: destroy the data source
 //
 // Destroy the data source.
-//
 func (p *DataSource) Destroy() error {
-	err := p.Context().SendRequest(p, 1)
+	err := p.Context().MarshalRequest(p, 1, nil)
 	p.Unregister()
 	return err
 }
 
-// SetActions will set the available drag-and-drop actions.
-//
+// SetActions: set the available drag-and-drop actions
 //
 // Sets the actions that the source side client supports for this
 // operation. This request may trigger wl_data_source.action and
@@ -1214,268 +2040,478 @@ func (p *DataSource) Destroy() error {
 // wl_data_device.start_drag. Attempting to use the source other than
 // for drag-and-drop will raise a protocol error.
 //
+//	dndActions: actions supported by the data source
 func (p *DataSource) SetActions(dndActions uint32) error {
-	return p.Context().SendRequest(p, 2, dndActions)
+	if err := CheckRequestVersion(p, 2); err != nil {
+		return err
+	}
+	return p.Context().MarshalRequest(p, 2, func(r *Request) {
+		r.PutUint32(dndActions)
+	})
 }
 
+// DataSourceError:
 const (
+	// DataSourceErrorInvalidActionMask: action mask contains invalid values
 	DataSourceErrorInvalidActionMask = 0
-	DataSourceErrorInvalidSource     = 1
+	// DataSourceErrorInvalidSource: source doesn't accept this request
+	DataSourceErrorInvalidSource = 1
 )
 
-type DataDeviceDataOfferEvent struct {
-	Offer *DataOffer
+// DataSourceTargetEvent: a target accepts an offered mime type
+//
+// Sent when a target accepts pointer_focus or motion events.  If
+// a target does not accept any of the offered types, type is NULL.
+//
+// Used for feedback during drag-and-drop.
+type DataSourceTargetEvent struct {
+	MimeType string
 }
 
-type DataDeviceDataOfferHandler interface {
-	HandleDataDeviceDataOffer(DataDeviceDataOfferEvent)
+// DataSourceTargetHandler is implemented by the receivers of DataSourceTargetEvent
+type DataSourceTargetHandler interface {
+	HandleDataSourceTarget(DataSourceTargetEvent)
 }
 
-func (p *DataDevice) AddDataOfferHandler(h DataDeviceDataOfferHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.dataOfferHandlers = append(p.dataOfferHandlers, h)
-		p.mu.Unlock()
+// AddTargetHandler adds a handler for DataSourceTargetEvent
+func (p *DataSource) AddTargetHandler(h DataSourceTargetHandler) {
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.targetHandlers = append(p.targetHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *DataDevice) RemoveDataOfferHandler(h DataDeviceDataOfferHandler) {
+// RemoveTargetHandler removes a handler previously added by AddTargetHandler
+func (p *DataSource) RemoveTargetHandler(h DataSourceTargetHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.dataOfferHandlers {
+	for i, e := range p.targetHandlers {
 		if e == h {
-			p.dataOfferHandlers = append(p.dataOfferHandlers[:i], p.dataOfferHandlers[i+1:]...)
+			p.targetHandlers = append(p.targetHandlers[:i:i], p.targetHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-type DataDeviceEnterEvent struct {
-	Serial  uint32
-	Surface *Surface
-	X       float32
-	Y       float32
-	Offer   *DataOffer
+// DataSourceSendEvent: send the data
+//
+// Request for data from the client.  Send the data as the
+// specified mime type over the passed file descriptor, then
+// close it.
+type DataSourceSendEvent struct {
+	MimeType string
+	Fd       uintptr
+	FdError  error
 }
 
-type DataDeviceEnterHandler interface {
-	HandleDataDeviceEnter(DataDeviceEnterEvent)
+// DataSourceSendHandler is implemented by the receivers of DataSourceSendEvent
+type DataSourceSendHandler interface {
+	HandleDataSourceSend(DataSourceSendEvent)
 }
 
-func (p *DataDevice) AddEnterHandler(h DataDeviceEnterHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.enterHandlers = append(p.enterHandlers, h)
-		p.mu.Unlock()
+// AddSendHandler adds a handler for DataSourceSendEvent
+func (p *DataSource) AddSendHandler(h DataSourceSendHandler) {
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.sendHandlers = append(p.sendHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *DataDevice) RemoveEnterHandler(h DataDeviceEnterHandler) {
+// RemoveSendHandler removes a handler previously added by AddSendHandler
+func (p *DataSource) RemoveSendHandler(h DataSourceSendHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.enterHandlers {
+	for i, e := range p.sendHandlers {
 		if e == h {
-			p.enterHandlers = append(p.enterHandlers[:i], p.enterHandlers[i+1:]...)
+			p.sendHandlers = append(p.sendHandlers[:i:i], p.sendHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-type DataDeviceLeaveEvent struct {
+// DataSourceCancelledEvent: selection was cancelled
+//
+// This data source is no longer valid. There are several reasons why
+// this could happen:
+//
+// - The data source has been replaced by another data source.
+// - The drag-and-drop operation was performed, but the drop destination
+// did not accept any of the mime types offered through
+// wl_data_source.target.
+// - The drag-and-drop operation was performed, but the drop destination
+// did not select any of the actions present in the mask offered through
+// wl_data_source.action.
+// - The drag-and-drop operation was performed but didn't happen over a
+// surface.
+// - The compositor cancelled the drag-and-drop operation (e.g. compositor
+// dependent timeouts to avoid stale drag-and-drop transfers).
+//
+// The client should clean up and destroy this data source.
+//
+// For objects of version 2 or older, wl_data_source.cancelled will
+// only be emitted if the data source was replaced by another data
+// source.
+type DataSourceCancelledEvent struct {
 }
 
-type DataDeviceLeaveHandler interface {
-	HandleDataDeviceLeave(DataDeviceLeaveEvent)
+// DataSourceCancelledHandler is implemented by the receivers of DataSourceCancelledEvent
+type DataSourceCancelledHandler interface {
+	HandleDataSourceCancelled(DataSourceCancelledEvent)
 }
 
-func (p *DataDevice) AddLeaveHandler(h DataDeviceLeaveHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.leaveHandlers = append(p.leaveHandlers, h)
-		p.mu.Unlock()
+// AddCancelledHandler adds a handler for DataSourceCancelledEvent
+func (p *DataSource) AddCancelledHandler(h DataSourceCancelledHandler) {
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.cancelledHandlers = append(p.cancelledHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *DataDevice) RemoveLeaveHandler(h DataDeviceLeaveHandler) {
+// RemoveCancelledHandler removes a handler previously added by AddCancelledHandler
+func (p *DataSource) RemoveCancelledHandler(h DataSourceCancelledHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.leaveHandlers {
+	for i, e := range p.cancelledHandlers {
 		if e == h {
-			p.leaveHandlers = append(p.leaveHandlers[:i], p.leaveHandlers[i+1:]...)
+			p.cancelledHandlers = append(p.cancelledHandlers[:i:i], p.cancelledHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-type DataDeviceMotionEvent struct {
-	Time uint32
-	X    float32
-	Y    float32
+// DataSourceDndDropPerformedEvent: the drag-and-drop operation physically finished
+//
+// The user performed the drop action. This event does not indicate
+// acceptance, wl_data_source.cancelled may still be emitted afterwards
+// if the drop destination does not accept any mime type.
+//
+// However, this event might however not be received if the compositor
+// cancelled the drag-and-drop operation before this event could happen.
+//
+// Note that the data_source may still be used in the future and should
+// not be destroyed here.
+type DataSourceDndDropPerformedEvent struct {
 }
 
-type DataDeviceMotionHandler interface {
-	HandleDataDeviceMotion(DataDeviceMotionEvent)
+// DataSourceDndDropPerformedHandler is implemented by the receivers of DataSourceDndDropPerformedEvent
+type DataSourceDndDropPerformedHandler interface {
+	HandleDataSourceDndDropPerformed(DataSourceDndDropPerformedEvent)
 }
 
-func (p *DataDevice) AddMotionHandler(h DataDeviceMotionHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.motionHandlers = append(p.motionHandlers, h)
-		p.mu.Unlock()
+// AddDndDropPerformedHandler adds a handler for DataSourceDndDropPerformedEvent
+func (p *DataSource) AddDndDropPerformedHandler(h DataSourceDndDropPerformedHandler) {
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.dndDropPerformedHandlers = append(p.dndDropPerformedHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *DataDevice) RemoveMotionHandler(h DataDeviceMotionHandler) {
+// RemoveDndDropPerformedHandler removes a handler previously added by AddDndDropPerformedHandler
+func (p *DataSource) RemoveDndDropPerformedHandler(h DataSourceDndDropPerformedHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.motionHandlers {
+	for i, e := range p.dndDropPerformedHandlers {
 		if e == h {
-			p.motionHandlers = append(p.motionHandlers[:i], p.motionHandlers[i+1:]...)
+			p.dndDropPerformedHandlers = append(p.dndDropPerformedHandlers[:i:i], p.dndDropPerformedHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-type DataDeviceDropEvent struct {
+// DataSourceDndFinishedEvent: the drag-and-drop operation concluded
+//
+// The drop destination finished interoperating with this data
+// source, so the client is now free to destroy this data source and
+// free all associated data.
+//
+// If the action used to perform the operation was "move", the
+// source can now delete the transferred data.
+type DataSourceDndFinishedEvent struct {
 }
 
-type DataDeviceDropHandler interface {
-	HandleDataDeviceDrop(DataDeviceDropEvent)
+// DataSourceDndFinishedHandler is implemented by the receivers of DataSourceDndFinishedEvent
+type DataSourceDndFinishedHandler interface {
+	HandleDataSourceDndFinished(DataSourceDndFinishedEvent)
 }
 
-func (p *DataDevice) AddDropHandler(h DataDeviceDropHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.dropHandlers = append(p.dropHandlers, h)
-		p.mu.Unlock()
+// AddDndFinishedHandler adds a handler for DataSourceDndFinishedEvent
+func (p *DataSource) AddDndFinishedHandler(h DataSourceDndFinishedHandler) {
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.dndFinishedHandlers = append(p.dndFinishedHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *DataDevice) RemoveDropHandler(h DataDeviceDropHandler) {
+// RemoveDndFinishedHandler removes a handler previously added by AddDndFinishedHandler
+func (p *DataSource) RemoveDndFinishedHandler(h DataSourceDndFinishedHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.dropHandlers {
+	for i, e := range p.dndFinishedHandlers {
 		if e == h {
-			p.dropHandlers = append(p.dropHandlers[:i], p.dropHandlers[i+1:]...)
+			p.dndFinishedHandlers = append(p.dndFinishedHandlers[:i:i], p.dndFinishedHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-type DataDeviceSelectionEvent struct {
-	Offer *DataOffer
+// DataSourceActionEvent: notify the selected action
+//
+// This event indicates the action selected by the compositor after
+// matching the source/destination side actions. Only one action (or
+// none) will be offered here.
+//
+// This event can be emitted multiple times during the drag-and-drop
+// operation, mainly in response to destination side changes through
+// wl_data_offer.set_actions, and as the data device enters/leaves
+// surfaces.
+//
+// It is only possible to receive this event after
+// wl_data_source.dnd_drop_performed if the drag-and-drop operation
+// ended in an "ask" action, in which case the final wl_data_source.action
+// event will happen immediately before wl_data_source.dnd_finished.
+//
+// Compositors may also change the selected action on the fly, mainly
+// in response to keyboard modifier changes during the drag-and-drop
+// operation.
+//
+// The most recent action received is always the valid one. The chosen
+// action may change alongside negotiation (e.g. an "ask" action can turn
+// into a "move" operation), so the effects of the final action must
+// always be applied in wl_data_offer.dnd_finished.
+//
+// Clients can trigger cursor surface changes from this point, so
+// they reflect the current action.
+type DataSourceActionEvent struct {
+	DndAction uint32
 }
 
-type DataDeviceSelectionHandler interface {
-	HandleDataDeviceSelection(DataDeviceSelectionEvent)
+// DataSourceActionHandler is implemented by the receivers of DataSourceActionEvent
+type DataSourceActionHandler interface {
+	HandleDataSourceAction(DataSourceActionEvent)
 }
 
-func (p *DataDevice) AddSelectionHandler(h DataDeviceSelectionHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.selectionHandlers = append(p.selectionHandlers, h)
-		p.mu.Unlock()
+// AddActionHandler adds a handler for DataSourceActionEvent
+func (p *DataSource) AddActionHandler(h DataSourceActionHandler) {
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.actionHandlers = append(p.actionHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *DataDevice) RemoveSelectionHandler(h DataDeviceSelectionHandler) {
+// RemoveActionHandler removes a handler previously added by AddActionHandler
+func (p *DataSource) RemoveActionHandler(h DataSourceActionHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.selectionHandlers {
+	for i, e := range p.actionHandlers {
 		if e == h {
-			p.selectionHandlers = append(p.selectionHandlers[:i], p.selectionHandlers[i+1:]...)
+			p.actionHandlers = append(p.actionHandlers[:i:i], p.actionHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-func (p *DataDevice) Dispatch(event *Event) {
+// Dispatch decodes an event received on the wl_data_source and runs its handlers
+func (p *DataSource) Dispatch(event *Event) {
 	switch event.Opcode {
 	case 0:
-		if len(p.dataOfferHandlers) > 0 {
-			ev := DataDeviceDataOfferEvent{}
-			id := event.Uint32()
-			offer := new(DataOffer)
-			p.Context().RegisterMapped(offer, id)
-			ev.Offer = offer
-			p.mu.RLock()
-			for _, h := range p.dataOfferHandlers {
-				h.HandleDataDeviceDataOffer(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.targetHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := DataSourceTargetEvent{}
+		ev.MimeType = event.String()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleDataSourceTarget(ev)
 		}
 	case 1:
-		if len(p.enterHandlers) > 0 {
-			ev := DataDeviceEnterEvent{}
-			ev.Serial = event.Uint32()
-			ev.Surface = event.Proxy(p.Context()).(*Surface)
-			ev.X = event.Float32()
-			ev.Y = event.Float32()
-			id := event.Uint32()
-			proxy := p.Context().LookupProxy(ProxyId(id))
-			if proxy != nil {
-				ev.Offer = proxy.(*DataOffer)
-			}
-			p.mu.RLock()
-			for _, h := range p.enterHandlers {
-				h.HandleDataDeviceEnter(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.sendHandlers
+		p.mu.RUnlock()
+		ev := DataSourceSendEvent{}
+		ev.MimeType = event.String()
+		ev.Fd, ev.FdError = event.FD()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleDataSourceSend(ev)
 		}
 	case 2:
-		if len(p.leaveHandlers) > 0 {
-			ev := DataDeviceLeaveEvent{}
-			p.mu.RLock()
-			for _, h := range p.leaveHandlers {
-				h.HandleDataDeviceLeave(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.cancelledHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := DataSourceCancelledEvent{}
+		for _, h := range handlers {
+			h.HandleDataSourceCancelled(ev)
 		}
 	case 3:
-		if len(p.motionHandlers) > 0 {
-			ev := DataDeviceMotionEvent{}
-			ev.Time = event.Uint32()
-			ev.X = event.Float32()
-			ev.Y = event.Float32()
-			p.mu.RLock()
-			for _, h := range p.motionHandlers {
-				h.HandleDataDeviceMotion(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.dndDropPerformedHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := DataSourceDndDropPerformedEvent{}
+		for _, h := range handlers {
+			h.HandleDataSourceDndDropPerformed(ev)
 		}
 	case 4:
-		if len(p.dropHandlers) > 0 {
-			ev := DataDeviceDropEvent{}
-			p.mu.RLock()
-			for _, h := range p.dropHandlers {
-				h.HandleDataDeviceDrop(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.dndFinishedHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := DataSourceDndFinishedEvent{}
+		for _, h := range handlers {
+			h.HandleDataSourceDndFinished(ev)
 		}
 	case 5:
-		if len(p.selectionHandlers) > 0 {
-			ev := DataDeviceSelectionEvent{}
-			id := event.Uint32()
-			proxy := p.Context().LookupProxy(ProxyId(id))
-			if proxy != nil {
-				ev.Offer = proxy.(*DataOffer)
-			}
-			p.mu.RLock()
-			for _, h := range p.selectionHandlers {
-				h.HandleDataDeviceSelection(ev)
-			}
-			p.mu.RUnlock()
-
+		p.mu.RLock()
+		handlers := p.actionHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := DataSourceActionEvent{}
+		ev.DndAction = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleDataSourceAction(ev)
 		}
 	}
 }
 
+const (
+	DataSourceTargetSinceVersion           = 1
+	DataSourceSendSinceVersion             = 1
+	DataSourceCancelledSinceVersion        = 1
+	DataSourceDndDropPerformedSinceVersion = 3
+	DataSourceDndFinishedSinceVersion      = 3
+	DataSourceActionSinceVersion           = 3
+	DataSourceOfferSinceVersion            = 1
+	DataSourceDestroySinceVersion          = 1
+	DataSourceSetActionsSinceVersion       = 3
+)
+
+// DataSourceInterface describes the wl_data_source interface
+var DataSourceInterface = &Interface{
+	Name:    "wl_data_source",
+	Version: 3,
+	Requests: []Message{
+		{
+			Name:  "offer",
+			Since: 1,
+			Args: []Arg{
+				{Name: "mime_type", Type: ArgString},
+			},
+		},
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "set_actions",
+			Since: 3,
+			Args: []Arg{
+				{Name: "dnd_actions", Type: ArgUint},
+			},
+		},
+	},
+	Events: []Message{
+		{
+			Name:  "target",
+			Since: 1,
+			Args: []Arg{
+				{Name: "mime_type", Type: ArgString, Nullable: true},
+			},
+		},
+		{
+			Name:  "send",
+			Since: 1,
+			Args: []Arg{
+				{Name: "mime_type", Type: ArgString},
+				{Name: "fd", Type: ArgFd},
+			},
+		},
+		{
+			Name:  "cancelled",
+			Since: 1,
+		},
+		{
+			Name:  "dnd_drop_performed",
+			Since: 3,
+		},
+		{
+			Name:  "dnd_finished",
+			Since: 3,
+		},
+		{
+			Name:  "action",
+			Since: 3,
+			Args: []Arg{
+				{Name: "dnd_action", Type: ArgUint},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "invalid_action_mask", Value: 0},
+				{Name: "invalid_source", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_data_source interface
+func (p *DataSource) Interface() *Interface {
+	return DataSourceInterface
+}
+
+// DataDevice: data transfer device
+//
+// There is one wl_data_device per seat which can be obtained
+// from the global wl_data_device_manager singleton.
+//
+// A wl_data_device provides access to inter-client data transfer
+// mechanisms such as copy-and-paste and drag-and-drop.
 type DataDevice struct {
 	BaseProxy
 	mu                sync.RWMutex
@@ -1487,14 +2523,14 @@ type DataDevice struct {
 	selectionHandlers []DataDeviceSelectionHandler
 }
 
+// NewDataDevice creates a new wl_data_device proxy registered in the Context
 func NewDataDevice(ctx *Context) *DataDevice {
 	ret := new(DataDevice)
 	ctx.Register(ret)
 	return ret
 }
 
-// StartDrag will start drag-and-drop operation.
-//
+// StartDrag: start drag-and-drop operation
 //
 // This request asks the compositor to start a drag-and-drop
 // operation on behalf of the client.
@@ -1525,254 +2561,785 @@ func NewDataDevice(ctx *Context) *DataDevice {
 // as an icon ends, the current and pending input regions become
 // undefined, and the wl_surface is unmapped.
 //
-func (p *DataDevice) StartDrag(
-	source *DataSource,
-	origin *Surface,
-	icon *Surface,
-	serial uint32,
-) error {
-	return p.Context().SendRequest(p, 0, source, origin, icon, serial)
+//	source: data source for the eventual transfer
+//	origin: surface where the drag originates
+//	icon: drag-and-drop icon surface
+//	serial: serial number of the implicit grab on the origin
+func (p *DataDevice) StartDrag(source *DataSource, origin *Surface, icon *Surface, serial uint32) error {
+	return p.Context().MarshalRequest(p, 0, func(r *Request) {
+		if source != nil {
+			r.PutObject(source.Id())
+		} else {
+			r.PutObject(0)
+		}
+		if origin != nil {
+			r.PutObject(origin.Id())
+		} else {
+			r.PutObject(0)
+		}
+		if icon != nil {
+			r.PutObject(icon.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutUint32(serial)
+	})
 }
 
-// SetSelection will copy data to the selection.
-//
+// SetSelection: copy data to the selection
 //
 // This request asks the compositor to set the selection
 // to the data from the source on behalf of the client.
 //
 // To unset the selection, set the source to NULL.
 //
+//	source: data source for the selection
+//	serial: serial number of the event that triggered this request
 func (p *DataDevice) SetSelection(source *DataSource, serial uint32) error {
-	return p.Context().SendRequest(p, 1, source, serial)
+	return p.Context().MarshalRequest(p, 1, func(r *Request) {
+		if source != nil {
+			r.PutObject(source.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutUint32(serial)
+	})
 }
 
-// Release will destroy data device.
-//
+// Release: destroy data device
 //
 // This request destroys the data device.
-//
 func (p *DataDevice) Release() error {
-	err := p.Context().SendRequest(p, 2)
+	if err := CheckRequestVersion(p, 2); err != nil {
+		return err
+	}
+	err := p.Context().MarshalRequest(p, 2, nil)
 	p.Unregister()
 	return err
 }
 
+// DataDeviceError:
 const (
+	// DataDeviceErrorRole: given wl_surface has another role
 	DataDeviceErrorRole = 0
+	// DataDeviceErrorUsedSource: source has already been used
+	DataDeviceErrorUsedSource = 1
 )
 
-type DataDeviceManager struct {
-	BaseProxy
-}
-
-func NewDataDeviceManager(ctx *Context) *DataDeviceManager {
-	ret := new(DataDeviceManager)
-	ctx.Register(ret)
-	return ret
-}
-
-// CreateDataSource will create a new data source.
-//
+// DataDeviceDataOfferEvent: introduce a new wl_data_offer
 //
-// Create a new data source.
-//
-func (p *DataDeviceManager) CreateDataSource() (*DataSource, error) {
-	ret := NewDataSource(p.Context())
-	return ret, p.Context().SendRequest(p, 0, Proxy(ret))
+// The data_offer event introduces a new wl_data_offer object,
+// which will subsequently be used in either the
+// data_device.enter event (for drag-and-drop) or the
+// data_device.selection event (for selections).  Immediately
+// following the data_device_data_offer event, the new data_offer
+// object will send out data_offer.offer events to describe the
+// mime types it offers.
+type DataDeviceDataOfferEvent struct {
+	Offer *DataOffer
 }
 
-// GetDataDevice will create a new data device.
-//
-//
-// Create a new data device for a given seat.
-//
-func (p *DataDeviceManager) GetDataDevice(seat *Seat) (*DataDevice, error) {
-	ret := NewDataDevice(p.Context())
-	return ret, p.Context().SendRequest(p, 1, Proxy(ret), seat)
+// DataDeviceDataOfferHandler is implemented by the receivers of DataDeviceDataOfferEvent
+type DataDeviceDataOfferHandler interface {
+	HandleDataDeviceDataOffer(DataDeviceDataOfferEvent)
 }
 
-const (
-	DataDeviceManagerDndActionNone = 0
-	DataDeviceManagerDndActionCopy = 1
-	DataDeviceManagerDndActionMove = 2
-	DataDeviceManagerDndActionAsk  = 4
-)
+// AddDataOfferHandler adds a handler for DataDeviceDataOfferEvent
+func (p *DataDevice) AddDataOfferHandler(h DataDeviceDataOfferHandler) {
+	if h == nil {
+		return
+	}
 
-type Shell struct {
-	BaseProxy
+	p.mu.Lock()
+	p.dataOfferHandlers = append(p.dataOfferHandlers, h)
+	p.mu.Unlock()
 }
 
-func NewShell(ctx *Context) *Shell {
-	ret := new(Shell)
-	ctx.Register(ret)
-	return ret
-}
+// RemoveDataOfferHandler removes a handler previously added by AddDataOfferHandler
+func (p *DataDevice) RemoveDataOfferHandler(h DataDeviceDataOfferHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-// GetShellSurface will create a shell surface from a surface.
-//
-//
-// Create a shell surface for an existing surface. This gives
-// the wl_surface the role of a shell surface. If the wl_surface
-// already has another role, it raises a protocol error.
-//
-// Only one shell surface can be associated with a given surface.
-//
-func (p *Shell) GetShellSurface(surface *Surface) (*ShellSurface, error) {
-	ret := NewShellSurface(p.Context())
-	return ret, p.Context().SendRequest(p, 0, Proxy(ret), surface)
+	for i, e := range p.dataOfferHandlers {
+		if e == h {
+			p.dataOfferHandlers = append(p.dataOfferHandlers[:i:i], p.dataOfferHandlers[i+1:]...)
+			break
+		}
+	}
 }
 
-const (
-	ShellErrorRole = 0
-)
-
-type ShellSurfacePingEvent struct {
-	Serial uint32
+// DataDeviceEnterEvent: initiate drag-and-drop session
+//
+// This event is sent when an active drag-and-drop pointer enters
+// a surface owned by the client.  The position of the pointer at
+// enter time is provided by the x and y arguments, in surface-local
+// coordinates.
+type DataDeviceEnterEvent struct {
+	Serial  uint32
+	Surface *Surface
+	X       float32
+	Y       float32
+	Offer   *DataOffer
 }
 
-type ShellSurfacePingHandler interface {
-	HandleShellSurfacePing(ShellSurfacePingEvent)
+// DataDeviceEnterHandler is implemented by the receivers of DataDeviceEnterEvent
+type DataDeviceEnterHandler interface {
+	HandleDataDeviceEnter(DataDeviceEnterEvent)
 }
 
-func (p *ShellSurface) AddPingHandler(h ShellSurfacePingHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.pingHandlers = append(p.pingHandlers, h)
-		p.mu.Unlock()
+// AddEnterHandler adds a handler for DataDeviceEnterEvent
+func (p *DataDevice) AddEnterHandler(h DataDeviceEnterHandler) {
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.enterHandlers = append(p.enterHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *ShellSurface) RemovePingHandler(h ShellSurfacePingHandler) {
+// RemoveEnterHandler removes a handler previously added by AddEnterHandler
+func (p *DataDevice) RemoveEnterHandler(h DataDeviceEnterHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.pingHandlers {
+	for i, e := range p.enterHandlers {
 		if e == h {
-			p.pingHandlers = append(p.pingHandlers[:i], p.pingHandlers[i+1:]...)
+			p.enterHandlers = append(p.enterHandlers[:i:i], p.enterHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-type ShellSurfaceConfigureEvent struct {
-	Edges  uint32
-	Width  int32
-	Height int32
+// DataDeviceLeaveEvent: end drag-and-drop session
+//
+// This event is sent when the drag-and-drop pointer leaves the
+// surface and the session ends.  The client must destroy the
+// wl_data_offer introduced at enter time at this point.
+type DataDeviceLeaveEvent struct {
 }
 
-type ShellSurfaceConfigureHandler interface {
-	HandleShellSurfaceConfigure(ShellSurfaceConfigureEvent)
+// DataDeviceLeaveHandler is implemented by the receivers of DataDeviceLeaveEvent
+type DataDeviceLeaveHandler interface {
+	HandleDataDeviceLeave(DataDeviceLeaveEvent)
 }
 
-func (p *ShellSurface) AddConfigureHandler(h ShellSurfaceConfigureHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.configureHandlers = append(p.configureHandlers, h)
-		p.mu.Unlock()
+// AddLeaveHandler adds a handler for DataDeviceLeaveEvent
+func (p *DataDevice) AddLeaveHandler(h DataDeviceLeaveHandler) {
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.leaveHandlers = append(p.leaveHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *ShellSurface) RemoveConfigureHandler(h ShellSurfaceConfigureHandler) {
+// RemoveLeaveHandler removes a handler previously added by AddLeaveHandler
+func (p *DataDevice) RemoveLeaveHandler(h DataDeviceLeaveHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.configureHandlers {
+	for i, e := range p.leaveHandlers {
 		if e == h {
-			p.configureHandlers = append(p.configureHandlers[:i], p.configureHandlers[i+1:]...)
+			p.leaveHandlers = append(p.leaveHandlers[:i:i], p.leaveHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-type ShellSurfacePopupDoneEvent struct {
+// DataDeviceMotionEvent: drag-and-drop session motion
+//
+// This event is sent when the drag-and-drop pointer moves within
+// the currently focused surface. The new position of the pointer
+// is provided by the x and y arguments, in surface-local
+// coordinates.
+type DataDeviceMotionEvent struct {
+	Time uint32
+	X    float32
+	Y    float32
 }
 
-type ShellSurfacePopupDoneHandler interface {
-	HandleShellSurfacePopupDone(ShellSurfacePopupDoneEvent)
+// DataDeviceMotionHandler is implemented by the receivers of DataDeviceMotionEvent
+type DataDeviceMotionHandler interface {
+	HandleDataDeviceMotion(DataDeviceMotionEvent)
 }
 
-func (p *ShellSurface) AddPopupDoneHandler(h ShellSurfacePopupDoneHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.popupDoneHandlers = append(p.popupDoneHandlers, h)
-		p.mu.Unlock()
+// AddMotionHandler adds a handler for DataDeviceMotionEvent
+func (p *DataDevice) AddMotionHandler(h DataDeviceMotionHandler) {
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.motionHandlers = append(p.motionHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *ShellSurface) RemovePopupDoneHandler(h ShellSurfacePopupDoneHandler) {
+// RemoveMotionHandler removes a handler previously added by AddMotionHandler
+func (p *DataDevice) RemoveMotionHandler(h DataDeviceMotionHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.popupDoneHandlers {
+	for i, e := range p.motionHandlers {
 		if e == h {
-			p.popupDoneHandlers = append(p.popupDoneHandlers[:i], p.popupDoneHandlers[i+1:]...)
+			p.motionHandlers = append(p.motionHandlers[:i:i], p.motionHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-func (p *ShellSurface) Dispatch(event *Event) {
-	switch event.Opcode {
-	case 0:
-		if len(p.pingHandlers) > 0 {
-			ev := ShellSurfacePingEvent{}
-			ev.Serial = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.pingHandlers {
-				h.HandleShellSurfacePing(ev)
-			}
-			p.mu.RUnlock()
-		}
-	case 1:
-		if len(p.configureHandlers) > 0 {
-			ev := ShellSurfaceConfigureEvent{}
-			ev.Edges = event.Uint32()
-			ev.Width = event.Int32()
-			ev.Height = event.Int32()
-			p.mu.RLock()
-			for _, h := range p.configureHandlers {
-				h.HandleShellSurfaceConfigure(ev)
-			}
-			p.mu.RUnlock()
-		}
-	case 2:
-		if len(p.popupDoneHandlers) > 0 {
-			ev := ShellSurfacePopupDoneEvent{}
-			p.mu.RLock()
-			for _, h := range p.popupDoneHandlers {
-				h.HandleShellSurfacePopupDone(ev)
-			}
-			p.mu.RUnlock()
-		}
-	}
+// DataDeviceDropEvent: end drag-and-drop session successfully
+//
+// The event is sent when a drag-and-drop operation is ended
+// because the implicit grab is removed.
+//
+// The drag-and-drop destination is expected to honor the last action
+// received through wl_data_offer.action, if the resulting action is
+// "copy" or "move", the destination can still perform
+// wl_data_offer.receive requests, and is expected to end all
+// transfers with a wl_data_offer.finish request.
+//
+// If the resulting action is "ask", the action will not be considered
+// final. The drag-and-drop destination is expected to perform one last
+// wl_data_offer.set_actions request, or wl_data_offer.destroy in order
+// to cancel the operation.
+type DataDeviceDropEvent struct {
 }
 
-type ShellSurface struct {
-	BaseProxy
-	mu                sync.RWMutex
-	pingHandlers      []ShellSurfacePingHandler
-	configureHandlers []ShellSurfaceConfigureHandler
-	popupDoneHandlers []ShellSurfacePopupDoneHandler
+// DataDeviceDropHandler is implemented by the receivers of DataDeviceDropEvent
+type DataDeviceDropHandler interface {
+	HandleDataDeviceDrop(DataDeviceDropEvent)
 }
 
-func NewShellSurface(ctx *Context) *ShellSurface {
-	ret := new(ShellSurface)
-	ctx.Register(ret)
-	return ret
-}
+// AddDropHandler adds a handler for DataDeviceDropEvent
+func (p *DataDevice) AddDropHandler(h DataDeviceDropHandler) {
+	if h == nil {
+		return
+	}
 
-// Pong will respond to a ping event.
+	p.mu.Lock()
+	p.dropHandlers = append(p.dropHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemoveDropHandler removes a handler previously added by AddDropHandler
+func (p *DataDevice) RemoveDropHandler(h DataDeviceDropHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.dropHandlers {
+		if e == h {
+			p.dropHandlers = append(p.dropHandlers[:i:i], p.dropHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// DataDeviceSelectionEvent: advertise new selection
+//
+// The selection event is sent out to notify the client of a new
+// wl_data_offer for the selection for this device.  The
+// data_device.data_offer and the data_offer.offer events are
+// sent out immediately before this event to introduce the data
+// offer object.  The selection event is sent to a client
+// immediately before receiving keyboard focus and when a new
+// selection is set while the client has keyboard focus.  The
+// data_offer is valid until a new data_offer or NULL is received
+// or until the client loses keyboard focus.  The client must
+// destroy the previous selection data_offer, if any, upon receiving
+// this event.
+type DataDeviceSelectionEvent struct {
+	Offer *DataOffer
+}
+
+// DataDeviceSelectionHandler is implemented by the receivers of DataDeviceSelectionEvent
+type DataDeviceSelectionHandler interface {
+	HandleDataDeviceSelection(DataDeviceSelectionEvent)
+}
+
+// AddSelectionHandler adds a handler for DataDeviceSelectionEvent
+func (p *DataDevice) AddSelectionHandler(h DataDeviceSelectionHandler) {
+	if h == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.selectionHandlers = append(p.selectionHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemoveSelectionHandler removes a handler previously added by AddSelectionHandler
+func (p *DataDevice) RemoveSelectionHandler(h DataDeviceSelectionHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.selectionHandlers {
+		if e == h {
+			p.selectionHandlers = append(p.selectionHandlers[:i:i], p.selectionHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// Dispatch decodes an event received on the wl_data_device and runs its handlers
+func (p *DataDevice) Dispatch(event *Event) {
+	switch event.Opcode {
+	case 0:
+		p.mu.RLock()
+		handlers := p.dataOfferHandlers
+		p.mu.RUnlock()
+		ev := DataDeviceDataOfferEvent{}
+		if id := event.Uint32(); id != 0 && event.Err() == nil {
+			ev.Offer = new(DataOffer)
+			p.Context().RegisterMapped(ev.Offer, id)
+		}
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleDataDeviceDataOffer(ev)
+		}
+	case 1:
+		p.mu.RLock()
+		handlers := p.enterHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := DataDeviceEnterEvent{}
+		ev.Serial = event.Uint32()
+		ev.Surface, _ = event.Proxy(p.Context()).(*Surface)
+		ev.X = event.Float32()
+		ev.Y = event.Float32()
+		ev.Offer, _ = event.Proxy(p.Context()).(*DataOffer)
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleDataDeviceEnter(ev)
+		}
+	case 2:
+		p.mu.RLock()
+		handlers := p.leaveHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := DataDeviceLeaveEvent{}
+		for _, h := range handlers {
+			h.HandleDataDeviceLeave(ev)
+		}
+	case 3:
+		p.mu.RLock()
+		handlers := p.motionHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := DataDeviceMotionEvent{}
+		ev.Time = event.Uint32()
+		ev.X = event.Float32()
+		ev.Y = event.Float32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleDataDeviceMotion(ev)
+		}
+	case 4:
+		p.mu.RLock()
+		handlers := p.dropHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := DataDeviceDropEvent{}
+		for _, h := range handlers {
+			h.HandleDataDeviceDrop(ev)
+		}
+	case 5:
+		p.mu.RLock()
+		handlers := p.selectionHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := DataDeviceSelectionEvent{}
+		ev.Offer, _ = event.Proxy(p.Context()).(*DataOffer)
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleDataDeviceSelection(ev)
+		}
+	}
+}
+
+const (
+	DataDeviceDataOfferSinceVersion    = 1
+	DataDeviceEnterSinceVersion        = 1
+	DataDeviceLeaveSinceVersion        = 1
+	DataDeviceMotionSinceVersion       = 1
+	DataDeviceDropSinceVersion         = 1
+	DataDeviceSelectionSinceVersion    = 1
+	DataDeviceStartDragSinceVersion    = 1
+	DataDeviceSetSelectionSinceVersion = 1
+	DataDeviceReleaseSinceVersion      = 2
+)
+
+// DataDeviceInterface describes the wl_data_device interface
+var DataDeviceInterface = &Interface{
+	Name:    "wl_data_device",
+	Version: 3,
+	Requests: []Message{
+		{
+			Name:  "start_drag",
+			Since: 1,
+			Args: []Arg{
+				{Name: "source", Type: ArgObject, Interface: "wl_data_source", Nullable: true},
+				{Name: "origin", Type: ArgObject, Interface: "wl_surface"},
+				{Name: "icon", Type: ArgObject, Interface: "wl_surface", Nullable: true},
+				{Name: "serial", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "set_selection",
+			Since: 1,
+			Args: []Arg{
+				{Name: "source", Type: ArgObject, Interface: "wl_data_source", Nullable: true},
+				{Name: "serial", Type: ArgUint},
+			},
+		},
+		{
+			Name:       "release",
+			Since:      2,
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name:  "data_offer",
+			Since: 1,
+			Args: []Arg{
+				{Name: "id", Type: ArgNewId, Interface: "wl_data_offer"},
+			},
+		},
+		{
+			Name:  "enter",
+			Since: 1,
+			Args: []Arg{
+				{Name: "serial", Type: ArgUint},
+				{Name: "surface", Type: ArgObject, Interface: "wl_surface"},
+				{Name: "x", Type: ArgFixed},
+				{Name: "y", Type: ArgFixed},
+				{Name: "id", Type: ArgObject, Interface: "wl_data_offer", Nullable: true},
+			},
+		},
+		{
+			Name:  "leave",
+			Since: 1,
+		},
+		{
+			Name:  "motion",
+			Since: 1,
+			Args: []Arg{
+				{Name: "time", Type: ArgUint},
+				{Name: "x", Type: ArgFixed},
+				{Name: "y", Type: ArgFixed},
+			},
+		},
+		{
+			Name:  "drop",
+			Since: 1,
+		},
+		{
+			Name:  "selection",
+			Since: 1,
+			Args: []Arg{
+				{Name: "id", Type: ArgObject, Interface: "wl_data_offer", Nullable: true},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "role", Value: 0},
+				{Name: "used_source", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_data_device interface
+func (p *DataDevice) Interface() *Interface {
+	return DataDeviceInterface
+}
+
+// DataDeviceManager: data transfer interface
+//
+// The wl_data_device_manager is a singleton global object that
+// provides access to inter-client data transfer mechanisms such as
+// copy-and-paste and drag-and-drop.  These mechanisms are tied to
+// a wl_seat and this interface lets a client get a wl_data_device
+// corresponding to a wl_seat.
+//
+// Depending on the version bound, the objects created from the bound
+// wl_data_device_manager object will have different requirements for
+// functioning properly. See wl_data_source.set_actions,
+// wl_data_offer.accept and wl_data_offer.finish for details.
+type DataDeviceManager struct {
+	BaseProxy
+}
+
+// NewDataDeviceManager creates a new wl_data_device_manager proxy registered in the Context
+func NewDataDeviceManager(ctx *Context) *DataDeviceManager {
+	ret := new(DataDeviceManager)
+	ctx.Register(ret)
+	return ret
+}
+
+// CreateDataSource: create a new data source
+//
+// Create a new data source.
+//
+//	id: data source to create
+func (p *DataDeviceManager) CreateDataSource() (*DataSource, error) {
+	ret := NewDataSource(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 0, func(r *Request) {
+		r.PutNewId(ret)
+	})
+}
+
+// GetDataDevice: create a new data device
+//
+// Create a new data device for a given seat.
+//
+//	id: data device to create
+//	seat: seat associated with the data device
+func (p *DataDeviceManager) GetDataDevice(seat *Seat) (*DataDevice, error) {
+	ret := NewDataDevice(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 1, func(r *Request) {
+		r.PutNewId(ret)
+		if seat != nil {
+			r.PutObject(seat.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
+}
+
+// DataDeviceManagerDndAction: drag and drop actions
+//
+// This is a bitmask of the available/preferred actions in a
+// drag-and-drop operation.
+//
+// In the compositor, the selected action is a result of matching the
+// actions offered by the source and destination sides.  "action" events
+// with a "none" action will be sent to both source and destination if
+// there is no match. All further checks will effectively happen on
+// (source actions ∩ destination actions).
+//
+// In addition, compositors may also pick different actions in
+// reaction to key modifiers being pressed. One common design that
+// is used in major toolkits (and the behavior recommended for
+// compositors) is:
+//
+// - If no modifiers are pressed, the first match (in bit order)
+// will be used.
+// - Pressing Shift selects "move", if enabled in the mask.
+// - Pressing Control selects "copy", if enabled in the mask.
+//
+// Behavior beyond that is considered implementation-dependent.
+// Compositors may for example bind other modifiers (like Alt/Meta)
+// or drags initiated with other buttons than BTN_LEFT to specific
+// actions (e.g. "ask").
+const (
+	// DataDeviceManagerDndActionNone: no action
+	DataDeviceManagerDndActionNone = 0
+	// DataDeviceManagerDndActionCopy: copy action
+	DataDeviceManagerDndActionCopy = 1
+	// DataDeviceManagerDndActionMove: move action
+	DataDeviceManagerDndActionMove = 2
+	// DataDeviceManagerDndActionAsk: ask action
+	DataDeviceManagerDndActionAsk = 4
+)
+
+const (
+	DataDeviceManagerCreateDataSourceSinceVersion = 1
+	DataDeviceManagerGetDataDeviceSinceVersion    = 1
+)
+
+// DataDeviceManagerInterface describes the wl_data_device_manager interface
+var DataDeviceManagerInterface = &Interface{
+	Name:    "wl_data_device_manager",
+	Version: 3,
+	Requests: []Message{
+		{
+			Name:  "create_data_source",
+			Since: 1,
+			Args: []Arg{
+				{Name: "id", Type: ArgNewId, Interface: "wl_data_source"},
+			},
+		},
+		{
+			Name:  "get_data_device",
+			Since: 1,
+			Args: []Arg{
+				{Name: "id", Type: ArgNewId, Interface: "wl_data_device"},
+				{Name: "seat", Type: ArgObject, Interface: "wl_seat"},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name:     "dnd_action",
+			Bitfield: true,
+			Entries: []EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "copy", Value: 1},
+				{Name: "move", Value: 2},
+				{Name: "ask", Value: 4},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_data_device_manager interface
+func (p *DataDeviceManager) Interface() *Interface {
+	return DataDeviceManagerInterface
+}
+
+// Shell: create desktop-style surfaces
+//
+// This interface is implemented by servers that provide
+// desktop-style user interfaces.
+//
+// It allows clients to associate a wl_shell_surface with
+// a basic surface.
+//
+// Note! This protocol is deprecated and not intended for production use.
+// For desktop-style user interfaces, use xdg_shell.
+type Shell struct {
+	BaseProxy
+}
+
+// NewShell creates a new wl_shell proxy registered in the Context
+func NewShell(ctx *Context) *Shell {
+	ret := new(Shell)
+	ctx.Register(ret)
+	return ret
+}
+
+// GetShellSurface: create a shell surface from a surface
 //
+// Create a shell surface for an existing surface. This gives
+// the wl_surface the role of a shell surface. If the wl_surface
+// already has another role, it raises a protocol error.
+//
+// Only one shell surface can be associated with a given surface.
+//
+//	id: shell surface to create
+//	surface: surface to be given the shell surface role
+func (p *Shell) GetShellSurface(surface *Surface) (*ShellSurface, error) {
+	ret := NewShellSurface(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 0, func(r *Request) {
+		r.PutNewId(ret)
+		if surface != nil {
+			r.PutObject(surface.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
+}
+
+// ShellError:
+const (
+	// ShellErrorRole: given wl_surface has another role
+	ShellErrorRole = 0
+)
+
+const (
+	ShellGetShellSurfaceSinceVersion = 1
+)
+
+// ShellInterface describes the wl_shell interface
+var ShellInterface = &Interface{
+	Name:    "wl_shell",
+	Version: 1,
+	Requests: []Message{
+		{
+			Name:  "get_shell_surface",
+			Since: 1,
+			Args: []Arg{
+				{Name: "id", Type: ArgNewId, Interface: "wl_shell_surface"},
+				{Name: "surface", Type: ArgObject, Interface: "wl_surface"},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "role", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_shell interface
+func (p *Shell) Interface() *Interface {
+	return ShellInterface
+}
+
+// ShellSurface: desktop-style metadata interface
+//
+// An interface that may be implemented by a wl_surface, for
+// implementations that provide a desktop-style user interface.
+//
+// It provides requests to treat surfaces like toplevel, fullscreen
+// or popup windows, move, resize or maximize them, associate
+// metadata like title and class, etc.
+//
+// On the server side the object is automatically destroyed when
+// the related wl_surface is destroyed. On the client side,
+// wl_shell_surface_destroy() must be called before destroying
+// the wl_surface object.
+type ShellSurface struct {
+	BaseProxy
+	mu                sync.RWMutex
+	pingHandlers      []ShellSurfacePingHandler
+	configureHandlers []ShellSurfaceConfigureHandler
+	popupDoneHandlers []ShellSurfacePopupDoneHandler
+}
+
+// NewShellSurface creates a new wl_shell_surface proxy registered in the Context
+func NewShellSurface(ctx *Context) *ShellSurface {
+	ret := new(ShellSurface)
+	ctx.Register(ret)
+	return ret
+}
+
+// Pong: respond to a ping event
 //
 // A client must respond to a ping event with a pong request or
 // the client may be deemed unresponsive.
 //
+//	serial: serial number of the ping event
 func (p *ShellSurface) Pong(serial uint32) error {
-	return p.Context().SendRequest(p, 0, serial)
+	return p.Context().MarshalRequest(p, 0, func(r *Request) {
+		r.PutUint32(serial)
+	})
 }
 
-// Move will start an interactive move.
-//
+// Move: start an interactive move
 //
 // Start a pointer-driven move of the surface.
 //
@@ -1780,12 +3347,20 @@ func (p *ShellSurface) Pong(serial uint32) error {
 // The server may ignore move requests depending on the state of
 // the surface (e.g. fullscreen or maximized).
 //
+//	seat: seat whose pointer is used
+//	serial: serial number of the implicit grab on the pointer
 func (p *ShellSurface) Move(seat *Seat, serial uint32) error {
-	return p.Context().SendRequest(p, 1, seat, serial)
+	return p.Context().MarshalRequest(p, 1, func(r *Request) {
+		if seat != nil {
+			r.PutObject(seat.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutUint32(serial)
+	})
 }
 
-// Resize will start an interactive resize.
-//
+// Resize: start an interactive resize
 //
 // Start a pointer-driven resizing of the surface.
 //
@@ -1793,23 +3368,31 @@ func (p *ShellSurface) Move(seat *Seat, serial uint32) error {
 // The server may ignore resize requests depending on the state of
 // the surface (e.g. fullscreen or maximized).
 //
+//	seat: seat whose pointer is used
+//	serial: serial number of the implicit grab on the pointer
+//	edges: which edge or corner is being dragged
 func (p *ShellSurface) Resize(seat *Seat, serial uint32, edges uint32) error {
-	return p.Context().SendRequest(p, 2, seat, serial, edges)
+	return p.Context().MarshalRequest(p, 2, func(r *Request) {
+		if seat != nil {
+			r.PutObject(seat.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutUint32(serial)
+		r.PutUint32(edges)
+	})
 }
 
-// SetToplevel will make the surface a toplevel surface.
-//
+// SetToplevel: make the surface a toplevel surface
 //
 // Map the surface as a toplevel surface.
 //
 // A toplevel surface is not fullscreen, maximized or transient.
-//
 func (p *ShellSurface) SetToplevel() error {
-	return p.Context().SendRequest(p, 3)
+	return p.Context().MarshalRequest(p, 3, nil)
 }
 
-// SetTransient will make the surface a transient surface.
-//
+// SetTransient: make the surface a transient surface
 //
 // Map the surface relative to an existing surface.
 //
@@ -1819,12 +3402,24 @@ func (p *ShellSurface) SetToplevel() error {
 //
 // The flags argument controls details of the transient behaviour.
 //
+//	parent: parent surface
+//	x: surface-local x coordinate
+//	y: surface-local y coordinate
+//	flags: transient surface behavior
 func (p *ShellSurface) SetTransient(parent *Surface, x int32, y int32, flags uint32) error {
-	return p.Context().SendRequest(p, 4, parent, x, y, flags)
+	return p.Context().MarshalRequest(p, 4, func(r *Request) {
+		if parent != nil {
+			r.PutObject(parent.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutInt32(x)
+		r.PutInt32(y)
+		r.PutUint32(flags)
+	})
 }
 
-// SetFullscreen will make the surface a fullscreen surface.
-//
+// SetFullscreen: make the surface a fullscreen surface
 //
 // Map the surface as a fullscreen surface.
 //
@@ -1860,12 +3455,22 @@ func (p *ShellSurface) SetTransient(parent *Surface, x int32, y int32, flags uin
 // with the dimensions for the output on which the surface will
 // be made fullscreen.
 //
+//	method: method for resolving size conflict
+//	framerate: framerate in mHz
+//	output: output on which the surface is to be fullscreen
 func (p *ShellSurface) SetFullscreen(method uint32, framerate uint32, output *Output) error {
-	return p.Context().SendRequest(p, 5, method, framerate, output)
+	return p.Context().MarshalRequest(p, 5, func(r *Request) {
+		r.PutUint32(method)
+		r.PutUint32(framerate)
+		if output != nil {
+			r.PutObject(output.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
 }
 
-// SetPopup will make the surface a popup surface.
-//
+// SetPopup: make the surface a popup surface
 //
 // Map the surface as a popup.
 //
@@ -1887,19 +3492,32 @@ func (p *ShellSurface) SetFullscreen(method uint32, framerate uint32, output *Ou
 // corner of the surface relative to the upper left corner of the
 // parent surface, in surface-local coordinates.
 //
-func (p *ShellSurface) SetPopup(
-	seat *Seat,
-	serial uint32,
-	parent *Surface,
-	x int32,
-	y int32,
-	flags uint32,
-) error {
-	return p.Context().SendRequest(p, 6, seat, serial, parent, x, y, flags)
+//	seat: seat whose pointer is used
+//	serial: serial number of the implicit grab on the pointer
+//	parent: parent surface
+//	x: surface-local x coordinate
+//	y: surface-local y coordinate
+//	flags: transient surface behavior
+func (p *ShellSurface) SetPopup(seat *Seat, serial uint32, parent *Surface, x int32, y int32, flags uint32) error {
+	return p.Context().MarshalRequest(p, 6, func(r *Request) {
+		if seat != nil {
+			r.PutObject(seat.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutUint32(serial)
+		if parent != nil {
+			r.PutObject(parent.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutInt32(x)
+		r.PutInt32(y)
+		r.PutUint32(flags)
+	})
 }
 
-// SetMaximized will make the surface a maximized surface.
-//
+// SetMaximized: make the surface a maximized surface
 //
 // Map the surface as a maximized surface.
 //
@@ -1920,12 +3538,18 @@ func (p *ShellSurface) SetPopup(
 //
 // The details depend on the compositor implementation.
 //
+//	output: output on which the surface is to be maximized
 func (p *ShellSurface) SetMaximized(output *Output) error {
-	return p.Context().SendRequest(p, 7, output)
+	return p.Context().MarshalRequest(p, 7, func(r *Request) {
+		if output != nil {
+			r.PutObject(output.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
 }
 
-// SetTitle will set surface title.
-//
+// SetTitle: set surface title
 //
 // Set a short title for the surface.
 //
@@ -1935,12 +3559,14 @@ func (p *ShellSurface) SetMaximized(output *Output) error {
 //
 // The string must be encoded in UTF-8.
 //
+//	title: surface title
 func (p *ShellSurface) SetTitle(title string) error {
-	return p.Context().SendRequest(p, 8, title)
+	return p.Context().MarshalRequest(p, 8, func(r *Request) {
+		r.PutString(title)
+	})
 }
 
-// SetClass will set surface class.
-//
+// SetClass: set surface class
 //
 // Set a class for the surface.
 //
@@ -1949,156 +3575,491 @@ func (p *ShellSurface) SetTitle(title string) error {
 // file name (or the full path if it is a non-standard location) of
 // the application's .desktop file as the class.
 //
-func (p *ShellSurface) SetClass(class_ string) error {
-	return p.Context().SendRequest(p, 9, class_)
+//	class: surface class
+func (p *ShellSurface) SetClass(class string) error {
+	return p.Context().MarshalRequest(p, 9, func(r *Request) {
+		r.PutString(class)
+	})
 }
 
+// ShellSurfaceResize: edge values for resizing
+//
+// These values are used to indicate which edge of a surface
+// is being dragged in a resize operation. The server may
+// use this information to adapt its behavior, e.g. choose
+// an appropriate cursor image.
 const (
-	ShellSurfaceResizeNone        = 0
-	ShellSurfaceResizeTop         = 1
-	ShellSurfaceResizeBottom      = 2
-	ShellSurfaceResizeLeft        = 4
-	ShellSurfaceResizeTopLeft     = 5
-	ShellSurfaceResizeBottomLeft  = 6
-	ShellSurfaceResizeRight       = 8
-	ShellSurfaceResizeTopRight    = 9
+	// ShellSurfaceResizeNone: no edge
+	ShellSurfaceResizeNone = 0
+	// ShellSurfaceResizeTop: top edge
+	ShellSurfaceResizeTop = 1
+	// ShellSurfaceResizeBottom: bottom edge
+	ShellSurfaceResizeBottom = 2
+	// ShellSurfaceResizeLeft: left edge
+	ShellSurfaceResizeLeft = 4
+	// ShellSurfaceResizeTopLeft: top and left edges
+	ShellSurfaceResizeTopLeft = 5
+	// ShellSurfaceResizeBottomLeft: bottom and left edges
+	ShellSurfaceResizeBottomLeft = 6
+	// ShellSurfaceResizeRight: right edge
+	ShellSurfaceResizeRight = 8
+	// ShellSurfaceResizeTopRight: top and right edges
+	ShellSurfaceResizeTopRight = 9
+	// ShellSurfaceResizeBottomRight: bottom and right edges
 	ShellSurfaceResizeBottomRight = 10
 )
 
+// ShellSurfaceTransient: details of transient behaviour
+//
+// These flags specify details of the expected behaviour
+// of transient surfaces. Used in the set_transient request.
 const (
+	// ShellSurfaceTransientInactive: do not set keyboard focus
 	ShellSurfaceTransientInactive = 0x1
 )
 
+// ShellSurfaceFullscreenMethod: different method to set the surface fullscreen
+//
+// Hints to indicate to the compositor how to deal with a conflict
+// between the dimensions of the surface and the dimensions of the
+// output. The compositor is free to ignore this parameter.
 const (
+	// ShellSurfaceFullscreenMethodDefault: no preference, apply default policy
 	ShellSurfaceFullscreenMethodDefault = 0
-	ShellSurfaceFullscreenMethodScale   = 1
-	ShellSurfaceFullscreenMethodDriver  = 2
-	ShellSurfaceFullscreenMethodFill    = 3
+	// ShellSurfaceFullscreenMethodScale: scale, preserve the surface's aspect ratio and center on output
+	ShellSurfaceFullscreenMethodScale = 1
+	// ShellSurfaceFullscreenMethodDriver: switch output mode to the smallest mode that can fit the surface, add black borders to compensate size mismatch
+	ShellSurfaceFullscreenMethodDriver = 2
+	// ShellSurfaceFullscreenMethodFill: no upscaling, center on output and add black borders to compensate size mismatch
+	ShellSurfaceFullscreenMethodFill = 3
 )
 
-type SurfaceEnterEvent struct {
-	Output *Output
+// ShellSurfacePingEvent: ping client
+//
+// Ping a client to check if it is receiving events and sending
+// requests. A client is expected to reply with a pong request.
+type ShellSurfacePingEvent struct {
+	Serial uint32
 }
 
-type SurfaceEnterHandler interface {
-	HandleSurfaceEnter(SurfaceEnterEvent)
+// ShellSurfacePingHandler is implemented by the receivers of ShellSurfacePingEvent
+type ShellSurfacePingHandler interface {
+	HandleShellSurfacePing(ShellSurfacePingEvent)
 }
 
-func (p *Surface) AddEnterHandler(h SurfaceEnterHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.enterHandlers = append(p.enterHandlers, h)
-		p.mu.Unlock()
+// AddPingHandler adds a handler for ShellSurfacePingEvent
+func (p *ShellSurface) AddPingHandler(h ShellSurfacePingHandler) {
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.pingHandlers = append(p.pingHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *Surface) RemoveEnterHandler(h SurfaceEnterHandler) {
+// RemovePingHandler removes a handler previously added by AddPingHandler
+func (p *ShellSurface) RemovePingHandler(h ShellSurfacePingHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.enterHandlers {
+	for i, e := range p.pingHandlers {
 		if e == h {
-			p.enterHandlers = append(p.enterHandlers[:i], p.enterHandlers[i+1:]...)
+			p.pingHandlers = append(p.pingHandlers[:i:i], p.pingHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-type SurfaceLeaveEvent struct {
-	Output *Output
+// ShellSurfaceConfigureEvent: suggest resize
+//
+// The configure event asks the client to resize its surface.
+//
+// The size is a hint, in the sense that the client is free to
+// ignore it if it doesn't resize, pick a smaller size (to
+// satisfy aspect ratio or resize in steps of NxM pixels).
+//
+// The edges parameter provides a hint about how the surface
+// was resized. The client may use this information to decide
+// how to adjust its content to the new size (e.g. a scrolling
+// area might adjust its content position to leave the viewable
+// content unmoved).
+//
+// The client is free to dismiss all but the last configure
+// event it received.
+//
+// The width and height arguments specify the size of the window
+// in surface-local coordinates.
+type ShellSurfaceConfigureEvent struct {
+	Edges  uint32
+	Width  int32
+	Height int32
 }
 
-type SurfaceLeaveHandler interface {
-	HandleSurfaceLeave(SurfaceLeaveEvent)
+// ShellSurfaceConfigureHandler is implemented by the receivers of ShellSurfaceConfigureEvent
+type ShellSurfaceConfigureHandler interface {
+	HandleShellSurfaceConfigure(ShellSurfaceConfigureEvent)
 }
 
-func (p *Surface) AddLeaveHandler(h SurfaceLeaveHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.leaveHandlers = append(p.leaveHandlers, h)
-		p.mu.Unlock()
+// AddConfigureHandler adds a handler for ShellSurfaceConfigureEvent
+func (p *ShellSurface) AddConfigureHandler(h ShellSurfaceConfigureHandler) {
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.configureHandlers = append(p.configureHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *Surface) RemoveLeaveHandler(h SurfaceLeaveHandler) {
+// RemoveConfigureHandler removes a handler previously added by AddConfigureHandler
+func (p *ShellSurface) RemoveConfigureHandler(h ShellSurfaceConfigureHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.leaveHandlers {
+	for i, e := range p.configureHandlers {
 		if e == h {
-			p.leaveHandlers = append(p.leaveHandlers[:i], p.leaveHandlers[i+1:]...)
+			p.configureHandlers = append(p.configureHandlers[:i:i], p.configureHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-func (p *Surface) Dispatch(event *Event) {
-	switch event.Opcode {
-	case 0:
-		if len(p.enterHandlers) > 0 {
-			ev := SurfaceEnterEvent{}
-			ev.Output = event.Proxy(p.Context()).(*Output)
-			p.mu.RLock()
-			for _, h := range p.enterHandlers {
-				h.HandleSurfaceEnter(ev)
-			}
-			p.mu.RUnlock()
-		}
-	case 1:
-		if len(p.leaveHandlers) > 0 {
-			ev := SurfaceLeaveEvent{}
-			ev.Output = event.Proxy(p.Context()).(*Output)
-			p.mu.RLock()
-			for _, h := range p.leaveHandlers {
-				h.HandleSurfaceLeave(ev)
-			}
-			p.mu.RUnlock()
-		}
-	}
+// ShellSurfacePopupDoneEvent: popup interaction is done
+//
+// The popup_done event is sent out when a popup grab is broken,
+// that is, when the user clicks a surface that doesn't belong
+// to the client owning the popup surface.
+type ShellSurfacePopupDoneEvent struct {
 }
 
-type Surface struct {
-	BaseProxy
-	mu            sync.RWMutex
-	enterHandlers []SurfaceEnterHandler
-	leaveHandlers []SurfaceLeaveHandler
-
-	UserData interface{}
+// ShellSurfacePopupDoneHandler is implemented by the receivers of ShellSurfacePopupDoneEvent
+type ShellSurfacePopupDoneHandler interface {
+	HandleShellSurfacePopupDone(ShellSurfacePopupDoneEvent)
 }
 
-func NewSurface(ctx *Context) *Surface {
-	ret := new(Surface)
-	ctx.Register(ret)
-	return ret
-}
+// AddPopupDoneHandler adds a handler for ShellSurfacePopupDoneEvent
+func (p *ShellSurface) AddPopupDoneHandler(h ShellSurfacePopupDoneHandler) {
+	if h == nil {
+		return
+	}
 
-// Destroy will delete surface.
-//
-//
-// Deletes the surface and invalidates its object ID.
-//
-func (p *Surface) Destroy() error {
-	err := p.Context().SendRequest(p, 0)
-	p.Unregister()
-	return err
+	p.mu.Lock()
+	p.popupDoneHandlers = append(p.popupDoneHandlers, h)
+	p.mu.Unlock()
 }
 
-// Attach will set the surface contents.
-//
-//
-// Set a buffer as the content of this surface.
-//
-// The new size of the surface is calculated based on the buffer
-// size transformed by the inverse buffer_transform and the
+// RemovePopupDoneHandler removes a handler previously added by AddPopupDoneHandler
+func (p *ShellSurface) RemovePopupDoneHandler(h ShellSurfacePopupDoneHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.popupDoneHandlers {
+		if e == h {
+			p.popupDoneHandlers = append(p.popupDoneHandlers[:i:i], p.popupDoneHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// Dispatch decodes an event received on the wl_shell_surface and runs its handlers
+func (p *ShellSurface) Dispatch(event *Event) {
+	switch event.Opcode {
+	case 0:
+		p.mu.RLock()
+		handlers := p.pingHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := ShellSurfacePingEvent{}
+		ev.Serial = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleShellSurfacePing(ev)
+		}
+	case 1:
+		p.mu.RLock()
+		handlers := p.configureHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := ShellSurfaceConfigureEvent{}
+		ev.Edges = event.Uint32()
+		ev.Width = event.Int32()
+		ev.Height = event.Int32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleShellSurfaceConfigure(ev)
+		}
+	case 2:
+		p.mu.RLock()
+		handlers := p.popupDoneHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := ShellSurfacePopupDoneEvent{}
+		for _, h := range handlers {
+			h.HandleShellSurfacePopupDone(ev)
+		}
+	}
+}
+
+const (
+	ShellSurfacePingSinceVersion          = 1
+	ShellSurfaceConfigureSinceVersion     = 1
+	ShellSurfacePopupDoneSinceVersion     = 1
+	ShellSurfacePongSinceVersion          = 1
+	ShellSurfaceMoveSinceVersion          = 1
+	ShellSurfaceResizeSinceVersion        = 1
+	ShellSurfaceSetToplevelSinceVersion   = 1
+	ShellSurfaceSetTransientSinceVersion  = 1
+	ShellSurfaceSetFullscreenSinceVersion = 1
+	ShellSurfaceSetPopupSinceVersion      = 1
+	ShellSurfaceSetMaximizedSinceVersion  = 1
+	ShellSurfaceSetTitleSinceVersion      = 1
+	ShellSurfaceSetClassSinceVersion      = 1
+)
+
+// ShellSurfaceInterface describes the wl_shell_surface interface
+var ShellSurfaceInterface = &Interface{
+	Name:    "wl_shell_surface",
+	Version: 1,
+	Requests: []Message{
+		{
+			Name:  "pong",
+			Since: 1,
+			Args: []Arg{
+				{Name: "serial", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "move",
+			Since: 1,
+			Args: []Arg{
+				{Name: "seat", Type: ArgObject, Interface: "wl_seat"},
+				{Name: "serial", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "resize",
+			Since: 1,
+			Args: []Arg{
+				{Name: "seat", Type: ArgObject, Interface: "wl_seat"},
+				{Name: "serial", Type: ArgUint},
+				{Name: "edges", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "set_toplevel",
+			Since: 1,
+		},
+		{
+			Name:  "set_transient",
+			Since: 1,
+			Args: []Arg{
+				{Name: "parent", Type: ArgObject, Interface: "wl_surface"},
+				{Name: "x", Type: ArgInt},
+				{Name: "y", Type: ArgInt},
+				{Name: "flags", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "set_fullscreen",
+			Since: 1,
+			Args: []Arg{
+				{Name: "method", Type: ArgUint},
+				{Name: "framerate", Type: ArgUint},
+				{Name: "output", Type: ArgObject, Interface: "wl_output", Nullable: true},
+			},
+		},
+		{
+			Name:  "set_popup",
+			Since: 1,
+			Args: []Arg{
+				{Name: "seat", Type: ArgObject, Interface: "wl_seat"},
+				{Name: "serial", Type: ArgUint},
+				{Name: "parent", Type: ArgObject, Interface: "wl_surface"},
+				{Name: "x", Type: ArgInt},
+				{Name: "y", Type: ArgInt},
+				{Name: "flags", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "set_maximized",
+			Since: 1,
+			Args: []Arg{
+				{Name: "output", Type: ArgObject, Interface: "wl_output", Nullable: true},
+			},
+		},
+		{
+			Name:  "set_title",
+			Since: 1,
+			Args: []Arg{
+				{Name: "title", Type: ArgString},
+			},
+		},
+		{
+			Name:  "set_class",
+			Since: 1,
+			Args: []Arg{
+				{Name: "class_", Type: ArgString},
+			},
+		},
+	},
+	Events: []Message{
+		{
+			Name:  "ping",
+			Since: 1,
+			Args: []Arg{
+				{Name: "serial", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "configure",
+			Since: 1,
+			Args: []Arg{
+				{Name: "edges", Type: ArgUint},
+				{Name: "width", Type: ArgInt},
+				{Name: "height", Type: ArgInt},
+			},
+		},
+		{
+			Name:  "popup_done",
+			Since: 1,
+		},
+	},
+	Enums: []Enum{
+		{
+			Name:     "resize",
+			Bitfield: true,
+			Entries: []EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "top", Value: 1},
+				{Name: "bottom", Value: 2},
+				{Name: "left", Value: 4},
+				{Name: "top_left", Value: 5},
+				{Name: "bottom_left", Value: 6},
+				{Name: "right", Value: 8},
+				{Name: "top_right", Value: 9},
+				{Name: "bottom_right", Value: 10},
+			},
+		},
+		{
+			Name:     "transient",
+			Bitfield: true,
+			Entries: []EnumEntry{
+				{Name: "inactive", Value: 0x1},
+			},
+		},
+		{
+			Name: "fullscreen_method",
+			Entries: []EnumEntry{
+				{Name: "default", Value: 0},
+				{Name: "scale", Value: 1},
+				{Name: "driver", Value: 2},
+				{Name: "fill", Value: 3},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_shell_surface interface
+func (p *ShellSurface) Interface() *Interface {
+	return ShellSurfaceInterface
+}
+
+// Surface: an onscreen surface
+//
+// A surface is a rectangular area that may be displayed on zero
+// or more outputs, and shown any number of times at the compositor's
+// discretion. They can present wl_buffers, receive user input, and
+// define a local coordinate system.
+//
+// The size of a surface (and relative positions on it) is described
+// in surface-local coordinates, which may differ from the buffer
+// coordinates of the pixel content, in case a buffer_transform
+// or a buffer_scale is used.
+//
+// A surface without a "role" is fairly useless: a compositor does
+// not know where, when or how to present it. The role is the
+// purpose of a wl_surface. Examples of roles are a cursor for a
+// pointer (as set by wl_pointer.set_cursor), a drag icon
+// (wl_data_device.start_drag), a sub-surface
+// (wl_subcompositor.get_subsurface), and a window as defined by a
+// shell protocol (e.g. wl_shell.get_shell_surface).
+//
+// A surface can have only one role at a time. Initially a
+// wl_surface does not have a role. Once a wl_surface is given a
+// role, it is set permanently for the whole lifetime of the
+// wl_surface object. Giving the current role again is allowed,
+// unless explicitly forbidden by the relevant interface
+// specification.
+//
+// Surface roles are given by requests in other interfaces such as
+// wl_pointer.set_cursor. The request should explicitly mention
+// that this request gives a role to a wl_surface. Often, this
+// request also creates a new protocol object that represents the
+// role and adds additional functionality to wl_surface. When a
+// client wants to destroy a wl_surface, they must destroy this 'role
+// object' before the wl_surface.
+//
+// Destroying the role object does not remove the role from the
+// wl_surface, but it may stop the wl_surface from "playing the role".
+// For instance, if a wl_subsurface object is destroyed, the wl_surface
+// it was created for will be unmapped and forget its position and
+// z-order. It is allowed to create a wl_subsurface for the same
+// wl_surface again, but it is not allowed to use the wl_surface as
+// a cursor (cursor is a different role than sub-surface, and role
+// switching is not allowed).
+type Surface struct {
+	BaseProxy
+	mu                               sync.RWMutex
+	enterHandlers                    []SurfaceEnterHandler
+	leaveHandlers                    []SurfaceLeaveHandler
+	preferredBufferScaleHandlers     []SurfacePreferredBufferScaleHandler
+	preferredBufferTransformHandlers []SurfacePreferredBufferTransformHandler
+
+	UserData interface{}
+}
+
+// NewSurface creates a new wl_surface proxy registered in the Context
+func NewSurface(ctx *Context) *Surface {
+	ret := new(Surface)
+	ctx.Register(ret)
+	return ret
+}
+
+// Destroy: delete surface
+//
+// Deletes the surface and invalidates its object ID.
+func (p *Surface) Destroy() error {
+	err := p.Context().MarshalRequest(p, 0, nil)
+	p.Unregister()
+	return err
+}
+
+// Attach: set the surface contents
+//
+// Set a buffer as the content of this surface.
+//
+// The new size of the surface is calculated based on the buffer
+// size transformed by the inverse buffer_transform and the
 // inverse buffer_scale. This means that at commit time the supplied
 // buffer size must be an integer multiple of the buffer_scale. If
 // that's not the case, an invalid_size error is sent.
 //
-// The x and y arguments specify the location of the new pending
-// buffer's upper left corner, relative to the current buffer's upper
-// left corner, in surface-local coordinates. In other words, the
-// x and y, combined with the new surface size define in which
-// directions the surface's size changes.
+// When the bound wl_surface version is 5 or higher, passing any
+// non-zero x or y is a protocol violation, and will result in an
+// 'invalid_offset' error being raised. The x and y arguments are ignored
+// and do not change the pending state. To achieve equivalent semantics,
+// use wl_surface.offset.
 //
 // Surface contents are double-buffered state, see wl_surface.commit.
 //
@@ -2133,12 +4094,22 @@ func (p *Surface) Destroy() error {
 // If wl_surface.attach is sent with a NULL wl_buffer, the
 // following wl_surface.commit will remove the surface content.
 //
+//	buffer: buffer of surface contents
+//	x: surface-local x coordinate
+//	y: surface-local y coordinate
 func (p *Surface) Attach(buffer *Buffer, x int32, y int32) error {
-	return p.Context().SendRequest(p, 1, buffer, x, y)
+	return p.Context().MarshalRequest(p, 1, func(r *Request) {
+		if buffer != nil {
+			r.PutObject(buffer.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutInt32(x)
+		r.PutInt32(y)
+	})
 }
 
-// Damage will mark part of the surface damaged.
-//
+// Damage: mark part of the surface damaged
 //
 // This request is used to describe the regions where the pending
 // buffer is different from the current surface contents, and where
@@ -2162,12 +4133,20 @@ func (p *Surface) Attach(buffer *Buffer, x int32, y int32) error {
 // posted with wl_surface.damage_buffer which uses buffer coordinates
 // instead of surface coordinates.
 //
+//	x: surface-local x coordinate
+//	y: surface-local y coordinate
+//	width: width of damage rectangle
+//	height: height of damage rectangle
 func (p *Surface) Damage(x int32, y int32, width int32, height int32) error {
-	return p.Context().SendRequest(p, 2, x, y, width, height)
+	return p.Context().MarshalRequest(p, 2, func(r *Request) {
+		r.PutInt32(x)
+		r.PutInt32(y)
+		r.PutInt32(width)
+		r.PutInt32(height)
+	})
 }
 
-// Frame will request a frame throttling hint.
-//
+// Frame: request a frame throttling hint
 //
 // Request a notification when it is a good time to start drawing a new
 // frame, by creating a frame callback. This is useful for throttling
@@ -2202,13 +4181,17 @@ func (p *Surface) Damage(x int32, y int32, width int32, height int32) error {
 // The callback_data passed in the callback is the current time, in
 // milliseconds, with an undefined base.
 //
+//	callback: callback object for the frame request
 func (p *Surface) Frame() (*Callback, error) {
 	ret := NewCallback(p.Context())
-	return ret, p.Context().SendRequest(p, 3, Proxy(ret))
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 3, func(r *Request) {
+		r.PutNewId(ret)
+	})
 }
 
-// SetOpaqueRegion will set opaque region.
-//
+// SetOpaqueRegion: set opaque region
 //
 // This request sets the region of the surface that contains
 // opaque content.
@@ -2235,12 +4218,18 @@ func (p *Surface) Frame() (*Callback, error) {
 // destroyed immediately. A NULL wl_region causes the pending opaque
 // region to be set to empty.
 //
+//	region: opaque region of the surface
 func (p *Surface) SetOpaqueRegion(region *Region) error {
-	return p.Context().SendRequest(p, 4, region)
+	return p.Context().MarshalRequest(p, 4, func(r *Request) {
+		if region != nil {
+			r.PutObject(region.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
 }
 
-// SetInputRegion will set input region.
-//
+// SetInputRegion: set input region
 //
 // This request sets the region of the surface that can receive
 // pointer and touch events.
@@ -2265,12 +4254,18 @@ func (p *Surface) SetOpaqueRegion(region *Region) error {
 // immediately. A NULL wl_region causes the input region to be set
 // to infinite.
 //
+//	region: input region of the surface
 func (p *Surface) SetInputRegion(region *Region) error {
-	return p.Context().SendRequest(p, 5, region)
+	return p.Context().MarshalRequest(p, 5, func(r *Request) {
+		if region != nil {
+			r.PutObject(region.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
 }
 
-// Commit will commit pending surface state.
-//
+// Commit: commit pending surface state
 //
 // Surface state (input, opaque, and damage regions, attached buffers,
 // etc.) is double-buffered. Protocol requests modify the pending state,
@@ -2289,13 +4284,11 @@ func (p *Surface) SetInputRegion(region *Region) error {
 // to affect double-buffered state.
 //
 // Other interfaces may add further double-buffered surface state.
-//
 func (p *Surface) Commit() error {
-	return p.Context().SendRequest(p, 6)
+	return p.Context().MarshalRequest(p, 6, nil)
 }
 
-// SetBufferTransform will sets the buffer transformation.
-//
+// SetBufferTransform: sets the buffer transformation
 //
 // This request sets an optional transformation on how the compositor
 // interprets the contents of the buffer attached to the surface. The
@@ -2327,12 +4320,17 @@ func (p *Surface) Commit() error {
 // wl_output.transform enum the invalid_transform protocol error
 // is raised.
 //
+//	transform: transform for interpreting buffer contents
 func (p *Surface) SetBufferTransform(transform int32) error {
-	return p.Context().SendRequest(p, 7, transform)
+	if err := CheckRequestVersion(p, 7); err != nil {
+		return err
+	}
+	return p.Context().MarshalRequest(p, 7, func(r *Request) {
+		r.PutInt32(transform)
+	})
 }
 
-// SetBufferScale will sets the buffer scaling factor.
-//
+// SetBufferScale: sets the buffer scaling factor
 //
 // This request sets an optional scaling factor on how the compositor
 // interprets the contents of the buffer attached to the window.
@@ -2358,225 +4356,1011 @@ func (p *Surface) SetBufferTransform(transform int32) error {
 // If scale is not positive the invalid_scale protocol error is
 // raised.
 //
+//	scale: positive scale for interpreting buffer contents
 func (p *Surface) SetBufferScale(scale int32) error {
-	return p.Context().SendRequest(p, 8, scale)
+	if err := CheckRequestVersion(p, 8); err != nil {
+		return err
+	}
+	return p.Context().MarshalRequest(p, 8, func(r *Request) {
+		r.PutInt32(scale)
+	})
+}
+
+// DamageBuffer: mark part of the surface damaged using buffer coordinates
+//
+// This request is used to describe the regions where the pending
+// buffer is different from the current surface contents, and where
+// the surface therefore needs to be repainted. The compositor
+// ignores the parts of the damage that fall outside of the surface.
+//
+// Damage is double-buffered state, see wl_surface.commit.
+//
+// The damage rectangle is specified in buffer coordinates,
+// where x and y specify the upper left corner of the damage rectangle.
+//
+// The initial value for pending damage is empty: no damage.
+// wl_surface.damage_buffer adds pending damage: the new pending
+// damage is the union of old pending damage and the given rectangle.
+//
+// wl_surface.commit assigns pending damage as the current damage,
+// and clears pending damage. The server will clear the current
+// damage as it repaints the surface.
+//
+// This request differs from wl_surface.damage in only one way - it
+// takes damage in buffer coordinates instead of surface-local
+// coordinates. While this generally is more intuitive than surface
+// coordinates, it is especially desirable when using wp_viewport
+// or when a drawing library (like EGL) is unaware of buffer scale
+// and buffer transform.
+//
+// Note: Because buffer transformation changes and damage requests may
+// be interleaved in the protocol stream, it is impossible to determine
+// the actual mapping between surface and buffer damage until
+// wl_surface.commit time. Therefore, compositors wishing to take both
+// kinds of damage into account will have to accumulate damage from the
+// two requests separately and only transform from one to the other
+// after receiving the wl_surface.commit.
+//
+//	x: buffer-local x coordinate
+//	y: buffer-local y coordinate
+//	width: width of damage rectangle
+//	height: height of damage rectangle
+func (p *Surface) DamageBuffer(x int32, y int32, width int32, height int32) error {
+	if err := CheckRequestVersion(p, 9); err != nil {
+		return err
+	}
+	return p.Context().MarshalRequest(p, 9, func(r *Request) {
+		r.PutInt32(x)
+		r.PutInt32(y)
+		r.PutInt32(width)
+		r.PutInt32(height)
+	})
+}
+
+// Offset: set the surface contents offset
+//
+// The x and y arguments specify the location of the new pending
+// buffer's upper left corner, relative to the current buffer's upper
+// left corner, in surface-local coordinates. In other words, the
+// x and y, combined with the new surface size define in which
+// directions the surface's size changes.
+//
+// Surface location offset is double-buffered state, see
+// wl_surface.commit.
+//
+// This request is semantically equivalent to and the replaces the x and y
+// arguments in the wl_surface.attach request in wl_surface versions prior
+// to 5. See wl_surface.attach for details.
+//
+//	x: surface-local x coordinate
+//	y: surface-local y coordinate
+func (p *Surface) Offset(x int32, y int32) error {
+	if err := CheckRequestVersion(p, 10); err != nil {
+		return err
+	}
+	return p.Context().MarshalRequest(p, 10, func(r *Request) {
+		r.PutInt32(x)
+		r.PutInt32(y)
+	})
+}
+
+// SurfaceError: wl_surface error values
+//
+// These errors can be emitted in response to wl_surface requests.
+const (
+	// SurfaceErrorInvalidScale: buffer scale value is invalid
+	SurfaceErrorInvalidScale = 0
+	// SurfaceErrorInvalidTransform: buffer transform value is invalid
+	SurfaceErrorInvalidTransform = 1
+	// SurfaceErrorInvalidSize: buffer size is invalid
+	SurfaceErrorInvalidSize = 2
+	// SurfaceErrorInvalidOffset: buffer offset is invalid
+	SurfaceErrorInvalidOffset = 3
+	// SurfaceErrorDefunctRoleObject: surface was destroyed before its role object
+	SurfaceErrorDefunctRoleObject = 4
+)
+
+// SurfaceEnterEvent: surface enters an output
+//
+// This is emitted whenever a surface's creation, movement, or resizing
+// results in some part of it being within the scanout region of an
+// output.
+//
+// Note that a surface may be overlapping with zero or more outputs.
+type SurfaceEnterEvent struct {
+	Output *Output
+}
+
+// SurfaceEnterHandler is implemented by the receivers of SurfaceEnterEvent
+type SurfaceEnterHandler interface {
+	HandleSurfaceEnter(SurfaceEnterEvent)
+}
+
+// AddEnterHandler adds a handler for SurfaceEnterEvent
+func (p *Surface) AddEnterHandler(h SurfaceEnterHandler) {
+	if h == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.enterHandlers = append(p.enterHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemoveEnterHandler removes a handler previously added by AddEnterHandler
+func (p *Surface) RemoveEnterHandler(h SurfaceEnterHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.enterHandlers {
+		if e == h {
+			p.enterHandlers = append(p.enterHandlers[:i:i], p.enterHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// SurfaceLeaveEvent: surface leaves an output
+//
+// This is emitted whenever a surface's creation, movement, or resizing
+// results in it no longer having any part of it within the scanout region
+// of an output.
+//
+// Clients should not use the number of outputs the surface is on for frame
+// throttling purposes. The surface might be hidden even if no leave event
+// has been sent, and the compositor might expect new surface content
+// updates even if no enter event has been sent. The frame event should be
+// used instead.
+type SurfaceLeaveEvent struct {
+	Output *Output
+}
+
+// SurfaceLeaveHandler is implemented by the receivers of SurfaceLeaveEvent
+type SurfaceLeaveHandler interface {
+	HandleSurfaceLeave(SurfaceLeaveEvent)
+}
+
+// AddLeaveHandler adds a handler for SurfaceLeaveEvent
+func (p *Surface) AddLeaveHandler(h SurfaceLeaveHandler) {
+	if h == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.leaveHandlers = append(p.leaveHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemoveLeaveHandler removes a handler previously added by AddLeaveHandler
+func (p *Surface) RemoveLeaveHandler(h SurfaceLeaveHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.leaveHandlers {
+		if e == h {
+			p.leaveHandlers = append(p.leaveHandlers[:i:i], p.leaveHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// SurfacePreferredBufferScaleEvent: preferred buffer scale for the surface
+//
+// This event indicates the preferred buffer scale for this surface. It is
+// sent whenever the compositor's preference changes.
+//
+// Before receiving this event the preferred buffer scale for this surface
+// is 1.
+//
+// It is intended that scaling aware clients use this event to scale their
+// content and use wl_surface.set_buffer_scale to indicate the scale they
+// have rendered with. This allows clients to supply a higher detail
+// buffer.
+//
+// The compositor shall emit a scale value greater than 0.
+type SurfacePreferredBufferScaleEvent struct {
+	Factor int32
+}
+
+// SurfacePreferredBufferScaleHandler is implemented by the receivers of SurfacePreferredBufferScaleEvent
+type SurfacePreferredBufferScaleHandler interface {
+	HandleSurfacePreferredBufferScale(SurfacePreferredBufferScaleEvent)
+}
+
+// AddPreferredBufferScaleHandler adds a handler for SurfacePreferredBufferScaleEvent
+func (p *Surface) AddPreferredBufferScaleHandler(h SurfacePreferredBufferScaleHandler) {
+	if h == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.preferredBufferScaleHandlers = append(p.preferredBufferScaleHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemovePreferredBufferScaleHandler removes a handler previously added by AddPreferredBufferScaleHandler
+func (p *Surface) RemovePreferredBufferScaleHandler(h SurfacePreferredBufferScaleHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.preferredBufferScaleHandlers {
+		if e == h {
+			p.preferredBufferScaleHandlers = append(p.preferredBufferScaleHandlers[:i:i], p.preferredBufferScaleHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// SurfacePreferredBufferTransformEvent: preferred buffer transform for the surface
+//
+// This event indicates the preferred buffer transform for this surface.
+// It is sent whenever the compositor's preference changes.
+//
+// Before receiving this event the preferred buffer transform for this
+// surface is normal.
+//
+// Applying this transformation to the surface buffer contents and using
+// wl_surface.set_buffer_transform might allow the compositor to use the
+// surface buffer more efficiently.
+type SurfacePreferredBufferTransformEvent struct {
+	Transform uint32
+}
+
+// SurfacePreferredBufferTransformHandler is implemented by the receivers of SurfacePreferredBufferTransformEvent
+type SurfacePreferredBufferTransformHandler interface {
+	HandleSurfacePreferredBufferTransform(SurfacePreferredBufferTransformEvent)
+}
+
+// AddPreferredBufferTransformHandler adds a handler for SurfacePreferredBufferTransformEvent
+func (p *Surface) AddPreferredBufferTransformHandler(h SurfacePreferredBufferTransformHandler) {
+	if h == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.preferredBufferTransformHandlers = append(p.preferredBufferTransformHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemovePreferredBufferTransformHandler removes a handler previously added by AddPreferredBufferTransformHandler
+func (p *Surface) RemovePreferredBufferTransformHandler(h SurfacePreferredBufferTransformHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.preferredBufferTransformHandlers {
+		if e == h {
+			p.preferredBufferTransformHandlers = append(p.preferredBufferTransformHandlers[:i:i], p.preferredBufferTransformHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// Dispatch decodes an event received on the wl_surface and runs its handlers
+func (p *Surface) Dispatch(event *Event) {
+	switch event.Opcode {
+	case 0:
+		p.mu.RLock()
+		handlers := p.enterHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := SurfaceEnterEvent{}
+		ev.Output, _ = event.Proxy(p.Context()).(*Output)
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleSurfaceEnter(ev)
+		}
+	case 1:
+		p.mu.RLock()
+		handlers := p.leaveHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := SurfaceLeaveEvent{}
+		ev.Output, _ = event.Proxy(p.Context()).(*Output)
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleSurfaceLeave(ev)
+		}
+	case 2:
+		p.mu.RLock()
+		handlers := p.preferredBufferScaleHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := SurfacePreferredBufferScaleEvent{}
+		ev.Factor = event.Int32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleSurfacePreferredBufferScale(ev)
+		}
+	case 3:
+		p.mu.RLock()
+		handlers := p.preferredBufferTransformHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := SurfacePreferredBufferTransformEvent{}
+		ev.Transform = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleSurfacePreferredBufferTransform(ev)
+		}
+	}
+}
+
+const (
+	SurfaceEnterSinceVersion                    = 1
+	SurfaceLeaveSinceVersion                    = 1
+	SurfacePreferredBufferScaleSinceVersion     = 6
+	SurfacePreferredBufferTransformSinceVersion = 6
+	SurfaceDestroySinceVersion                  = 1
+	SurfaceAttachSinceVersion                   = 1
+	SurfaceDamageSinceVersion                   = 1
+	SurfaceFrameSinceVersion                    = 1
+	SurfaceSetOpaqueRegionSinceVersion          = 1
+	SurfaceSetInputRegionSinceVersion           = 1
+	SurfaceCommitSinceVersion                   = 1
+	SurfaceSetBufferTransformSinceVersion       = 2
+	SurfaceSetBufferScaleSinceVersion           = 3
+	SurfaceDamageBufferSinceVersion             = 4
+	SurfaceOffsetSinceVersion                   = 5
+)
+
+// SurfaceInterface describes the wl_surface interface
+var SurfaceInterface = &Interface{
+	Name:    "wl_surface",
+	Version: 6,
+	Requests: []Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "attach",
+			Since: 1,
+			Args: []Arg{
+				{Name: "buffer", Type: ArgObject, Interface: "wl_buffer", Nullable: true},
+				{Name: "x", Type: ArgInt},
+				{Name: "y", Type: ArgInt},
+			},
+		},
+		{
+			Name:  "damage",
+			Since: 1,
+			Args: []Arg{
+				{Name: "x", Type: ArgInt},
+				{Name: "y", Type: ArgInt},
+				{Name: "width", Type: ArgInt},
+				{Name: "height", Type: ArgInt},
+			},
+		},
+		{
+			Name:  "frame",
+			Since: 1,
+			Args: []Arg{
+				{Name: "callback", Type: ArgNewId, Interface: "wl_callback"},
+			},
+		},
+		{
+			Name:  "set_opaque_region",
+			Since: 1,
+			Args: []Arg{
+				{Name: "region", Type: ArgObject, Interface: "wl_region", Nullable: true},
+			},
+		},
+		{
+			Name:  "set_input_region",
+			Since: 1,
+			Args: []Arg{
+				{Name: "region", Type: ArgObject, Interface: "wl_region", Nullable: true},
+			},
+		},
+		{
+			Name:  "commit",
+			Since: 1,
+		},
+		{
+			Name:  "set_buffer_transform",
+			Since: 2,
+			Args: []Arg{
+				{Name: "transform", Type: ArgInt},
+			},
+		},
+		{
+			Name:  "set_buffer_scale",
+			Since: 3,
+			Args: []Arg{
+				{Name: "scale", Type: ArgInt},
+			},
+		},
+		{
+			Name:  "damage_buffer",
+			Since: 4,
+			Args: []Arg{
+				{Name: "x", Type: ArgInt},
+				{Name: "y", Type: ArgInt},
+				{Name: "width", Type: ArgInt},
+				{Name: "height", Type: ArgInt},
+			},
+		},
+		{
+			Name:  "offset",
+			Since: 5,
+			Args: []Arg{
+				{Name: "x", Type: ArgInt},
+				{Name: "y", Type: ArgInt},
+			},
+		},
+	},
+	Events: []Message{
+		{
+			Name:  "enter",
+			Since: 1,
+			Args: []Arg{
+				{Name: "output", Type: ArgObject, Interface: "wl_output"},
+			},
+		},
+		{
+			Name:  "leave",
+			Since: 1,
+			Args: []Arg{
+				{Name: "output", Type: ArgObject, Interface: "wl_output"},
+			},
+		},
+		{
+			Name:  "preferred_buffer_scale",
+			Since: 6,
+			Args: []Arg{
+				{Name: "factor", Type: ArgInt},
+			},
+		},
+		{
+			Name:  "preferred_buffer_transform",
+			Since: 6,
+			Args: []Arg{
+				{Name: "transform", Type: ArgUint},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "invalid_scale", Value: 0},
+				{Name: "invalid_transform", Value: 1},
+				{Name: "invalid_size", Value: 2},
+				{Name: "invalid_offset", Value: 3},
+				{Name: "defunct_role_object", Value: 4},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_surface interface
+func (p *Surface) Interface() *Interface {
+	return SurfaceInterface
+}
+
+// Seat: group of input devices
+//
+// A seat is a group of keyboards, pointer and touch devices. This
+// object is published as a global during start up, or when such a
+// device is hot plugged.  A seat typically has a pointer and
+// maintains a keyboard focus and a pointer focus.
+type Seat struct {
+	BaseProxy
+	mu                   sync.RWMutex
+	capabilitiesHandlers []SeatCapabilitiesHandler
+	nameHandlers         []SeatNameHandler
+}
+
+// NewSeat creates a new wl_seat proxy registered in the Context
+func NewSeat(ctx *Context) *Seat {
+	ret := new(Seat)
+	ctx.Register(ret)
+	return ret
+}
+
+// GetPointer: return pointer object
+//
+// The ID provided will be initialized to the wl_pointer interface
+// for this seat.
+//
+// This request only takes effect if the seat has the pointer
+// capability, or has had the pointer capability in the past.
+// It is a protocol violation to issue this request on a seat that has
+// never had the pointer capability. The missing_capability error will
+// be sent in this case.
+//
+//	id: seat pointer
+func (p *Seat) GetPointer() (*Pointer, error) {
+	ret := NewPointer(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 0, func(r *Request) {
+		r.PutNewId(ret)
+	})
+}
+
+// GetKeyboard: return keyboard object
+//
+// The ID provided will be initialized to the wl_keyboard interface
+// for this seat.
+//
+// This request only takes effect if the seat has the keyboard
+// capability, or has had the keyboard capability in the past.
+// It is a protocol violation to issue this request on a seat that has
+// never had the keyboard capability. The missing_capability error will
+// be sent in this case.
+//
+//	id: seat keyboard
+func (p *Seat) GetKeyboard() (*Keyboard, error) {
+	ret := NewKeyboard(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 1, func(r *Request) {
+		r.PutNewId(ret)
+	})
+}
+
+// GetTouch: return touch object
+//
+// The ID provided will be initialized to the wl_touch interface
+// for this seat.
+//
+// This request only takes effect if the seat has the touch
+// capability, or has had the touch capability in the past.
+// It is a protocol violation to issue this request on a seat that has
+// never had the touch capability. The missing_capability error will
+// be sent in this case.
+//
+//	id: seat touch interface
+func (p *Seat) GetTouch() (*Touch, error) {
+	ret := NewTouch(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 2, func(r *Request) {
+		r.PutNewId(ret)
+	})
+}
+
+// Release: release the seat object
+//
+// Using this request a client can tell the server that it is not going to
+// use the seat object anymore.
+func (p *Seat) Release() error {
+	if err := CheckRequestVersion(p, 3); err != nil {
+		return err
+	}
+	err := p.Context().MarshalRequest(p, 3, nil)
+	p.Unregister()
+	return err
 }
 
-// DamageBuffer will mark part of the surface damaged using buffer coordinates.
-//
-//
-// This request is used to describe the regions where the pending
-// buffer is different from the current surface contents, and where
-// the surface therefore needs to be repainted. The compositor
-// ignores the parts of the damage that fall outside of the surface.
+// SeatCapability: seat capability bitmask
 //
-// Damage is double-buffered state, see wl_surface.commit.
+// This is a bitmask of capabilities this seat has; if a member is
+// set, then it is present on the seat.
+const (
+	// SeatCapabilityPointer: the seat has pointer devices
+	SeatCapabilityPointer = 1
+	// SeatCapabilityKeyboard: the seat has one or more keyboards
+	SeatCapabilityKeyboard = 2
+	// SeatCapabilityTouch: the seat has touch devices
+	SeatCapabilityTouch = 4
+)
+
+// SeatError: wl_seat error values
 //
-// The damage rectangle is specified in buffer coordinates,
-// where x and y specify the upper left corner of the damage rectangle.
+// These errors can be emitted in response to wl_seat requests.
+const (
+	// SeatErrorMissingCapability: get_pointer, get_keyboard or get_touch called on seat without the matching capability
+	SeatErrorMissingCapability = 0
+)
+
+// SeatCapabilitiesEvent: seat capabilities changed
 //
-// The initial value for pending damage is empty: no damage.
-// wl_surface.damage_buffer adds pending damage: the new pending
-// damage is the union of old pending damage and the given rectangle.
+// This is emitted whenever a seat gains or loses the pointer,
+// keyboard or touch capabilities.  The argument is a capability
+// enum containing the complete set of capabilities this seat has.
 //
-// wl_surface.commit assigns pending damage as the current damage,
-// and clears pending damage. The server will clear the current
-// damage as it repaints the surface.
+// When the pointer capability is added, a client may create a
+// wl_pointer object using the wl_seat.get_pointer request. This object
+// will receive pointer events until the capability is removed in the
+// future.
 //
-// This request differs from wl_surface.damage in only one way - it
-// takes damage in buffer coordinates instead of surface-local
-// coordinates. While this generally is more intuitive than surface
-// coordinates, it is especially desirable when using wp_viewport
-// or when a drawing library (like EGL) is unaware of buffer scale
-// and buffer transform.
+// When the pointer capability is removed, a client should destroy the
+// wl_pointer objects associated with the seat where the capability was
+// removed, using the wl_pointer.release request. No further pointer
+// events will be received on these objects.
 //
-// Note: Because buffer transformation changes and damage requests may
-// be interleaved in the protocol stream, it is impossible to determine
-// the actual mapping between surface and buffer damage until
-// wl_surface.commit time. Therefore, compositors wishing to take both
-// kinds of damage into account will have to accumulate damage from the
-// two requests separately and only transform from one to the other
-// after receiving the wl_surface.commit.
+// In some compositors, if a seat regains the pointer capability and a
+// client has a previously obtained wl_pointer object of version 4 or
+// less, that object may start sending pointer events again. This
+// behavior is considered a misinterpretation of the intended behavior
+// and must not be relied upon by the client. wl_pointer objects of
+// version 5 or later must not send events if created before the most
+// recent event notifying the client of an added pointer capability.
 //
-func (p *Surface) DamageBuffer(x int32, y int32, width int32, height int32) error {
-	return p.Context().SendRequest(p, 9, x, y, width, height)
-}
-
-const (
-	SurfaceErrorInvalidScale     = 0
-	SurfaceErrorInvalidTransform = 1
-	SurfaceErrorInvalidSize      = 2
-)
-
+// The above behavior also applies to wl_keyboard and wl_touch with the
+// keyboard and touch capabilities, respectively.
 type SeatCapabilitiesEvent struct {
 	Capabilities uint32
 }
 
+// SeatCapabilitiesHandler is implemented by the receivers of SeatCapabilitiesEvent
 type SeatCapabilitiesHandler interface {
 	HandleSeatCapabilities(SeatCapabilitiesEvent)
 }
 
+// AddCapabilitiesHandler adds a handler for SeatCapabilitiesEvent
 func (p *Seat) AddCapabilitiesHandler(h SeatCapabilitiesHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.capabilitiesHandlers = append(p.capabilitiesHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.capabilitiesHandlers = append(p.capabilitiesHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveCapabilitiesHandler removes a handler previously added by AddCapabilitiesHandler
 func (p *Seat) RemoveCapabilitiesHandler(h SeatCapabilitiesHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.capabilitiesHandlers {
 		if e == h {
-			p.capabilitiesHandlers = append(
-				p.capabilitiesHandlers[:i],
-				p.capabilitiesHandlers[i+1:]...)
+			p.capabilitiesHandlers = append(p.capabilitiesHandlers[:i:i], p.capabilitiesHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// SeatNameEvent: unique identifier for this seat
+//
+// In a multiseat configuration this can be used by the client to help
+// identify which physical devices the seat represents. Based on
+// the seat configuration used by the compositor.
 type SeatNameEvent struct {
 	Name string
 }
 
+// SeatNameHandler is implemented by the receivers of SeatNameEvent
 type SeatNameHandler interface {
 	HandleSeatName(SeatNameEvent)
 }
 
+// AddNameHandler adds a handler for SeatNameEvent
 func (p *Seat) AddNameHandler(h SeatNameHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.nameHandlers = append(p.nameHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.nameHandlers = append(p.nameHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveNameHandler removes a handler previously added by AddNameHandler
 func (p *Seat) RemoveNameHandler(h SeatNameHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.nameHandlers {
 		if e == h {
-			p.nameHandlers = append(p.nameHandlers[:i], p.nameHandlers[i+1:]...)
+			p.nameHandlers = append(p.nameHandlers[:i:i], p.nameHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// Dispatch decodes an event received on the wl_seat and runs its handlers
 func (p *Seat) Dispatch(event *Event) {
 	switch event.Opcode {
 	case 0:
-		if len(p.capabilitiesHandlers) > 0 {
-			ev := SeatCapabilitiesEvent{}
-			ev.Capabilities = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.capabilitiesHandlers {
-				h.HandleSeatCapabilities(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.capabilitiesHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := SeatCapabilitiesEvent{}
+		ev.Capabilities = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleSeatCapabilities(ev)
 		}
 	case 1:
-		if len(p.nameHandlers) > 0 {
-			ev := SeatNameEvent{}
-			ev.Name = event.String()
-			p.mu.RLock()
-			for _, h := range p.nameHandlers {
-				h.HandleSeatName(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.nameHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := SeatNameEvent{}
+		ev.Name = event.String()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleSeatName(ev)
 		}
 	}
 }
 
-type Seat struct {
-	BaseProxy
-	mu                   sync.RWMutex
-	capabilitiesHandlers []SeatCapabilitiesHandler
-	nameHandlers         []SeatNameHandler
-}
+const (
+	SeatCapabilitiesSinceVersion = 1
+	SeatNameSinceVersion         = 2
+	SeatGetPointerSinceVersion   = 1
+	SeatGetKeyboardSinceVersion  = 1
+	SeatGetTouchSinceVersion     = 1
+	SeatReleaseSinceVersion      = 5
+)
 
-func NewSeat(ctx *Context) *Seat {
-	ret := new(Seat)
+// SeatInterface describes the wl_seat interface
+var SeatInterface = &Interface{
+	Name:    "wl_seat",
+	Version: 9,
+	Requests: []Message{
+		{
+			Name:  "get_pointer",
+			Since: 1,
+			Args: []Arg{
+				{Name: "id", Type: ArgNewId, Interface: "wl_pointer"},
+			},
+		},
+		{
+			Name:  "get_keyboard",
+			Since: 1,
+			Args: []Arg{
+				{Name: "id", Type: ArgNewId, Interface: "wl_keyboard"},
+			},
+		},
+		{
+			Name:  "get_touch",
+			Since: 1,
+			Args: []Arg{
+				{Name: "id", Type: ArgNewId, Interface: "wl_touch"},
+			},
+		},
+		{
+			Name:       "release",
+			Since:      5,
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name:  "capabilities",
+			Since: 1,
+			Args: []Arg{
+				{Name: "capabilities", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "name",
+			Since: 2,
+			Args: []Arg{
+				{Name: "name", Type: ArgString},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name:     "capability",
+			Bitfield: true,
+			Entries: []EnumEntry{
+				{Name: "pointer", Value: 1},
+				{Name: "keyboard", Value: 2},
+				{Name: "touch", Value: 4},
+			},
+		},
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "missing_capability", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_seat interface
+func (p *Seat) Interface() *Interface {
+	return SeatInterface
+}
+
+// Pointer: pointer input device
+//
+// The wl_pointer interface represents one or more input devices,
+// such as mice, which control the pointer location and pointer_focus
+// of a seat.
+//
+// The wl_pointer interface generates motion, enter and leave
+// events for the surfaces that the pointer is located over,
+// and button and axis events for button presses, button releases
+// and scrolling.
+type Pointer struct {
+	BaseProxy
+	mu                            sync.RWMutex
+	enterHandlers                 []PointerEnterHandler
+	leaveHandlers                 []PointerLeaveHandler
+	motionHandlers                []PointerMotionHandler
+	buttonHandlers                []PointerButtonHandler
+	axisHandlers                  []PointerAxisHandler
+	frameHandlers                 []PointerFrameHandler
+	axisSourceHandlers            []PointerAxisSourceHandler
+	axisStopHandlers              []PointerAxisStopHandler
+	axisDiscreteHandlers          []PointerAxisDiscreteHandler
+	axisValue120Handlers          []PointerAxisValue120Handler
+	axisRelativeDirectionHandlers []PointerAxisRelativeDirectionHandler
+}
+
+// NewPointer creates a new wl_pointer proxy registered in the Context
+func NewPointer(ctx *Context) *Pointer {
+	ret := new(Pointer)
 	ctx.Register(ret)
 	return ret
 }
 
-// GetPointer will return pointer object.
+// SetCursor: set the pointer surface
 //
+// Set the pointer surface, i.e., the surface that contains the
+// pointer image (cursor). This request gives the surface the role
+// of a cursor. If the surface already has another role, it raises
+// a protocol error.
 //
-// The ID provided will be initialized to the wl_pointer interface
-// for this seat.
-//
-// This request only takes effect if the seat has the pointer
-// capability, or has had the pointer capability in the past.
-// It is a protocol violation to issue this request on a seat that has
-// never had the pointer capability. The missing_capability error will
-// be sent in this case.
+// The cursor actually changes only if the pointer
+// focus for this device is one of the requesting client's surfaces
+// or the surface parameter is the current pointer surface. If
+// there was a previous surface set with this request it is
+// replaced. If surface is NULL, the pointer image is hidden.
 //
-func (p *Seat) GetPointer() (*Pointer, error) {
-	ret := NewPointer(p.Context())
-	return ret, p.Context().SendRequest(p, 0, Proxy(ret))
-}
-
-// GetKeyboard will return keyboard object.
+// The parameters hotspot_x and hotspot_y define the position of
+// the pointer surface relative to the pointer location. Its
+// top-left corner is always at (x, y) - (hotspot_x, hotspot_y),
+// where (x, y) are the coordinates of the pointer location, in
+// surface-local coordinates.
 //
+// On surface.attach requests to the pointer surface, hotspot_x
+// and hotspot_y are decremented by the x and y parameters
+// passed to the request. Attach must be confirmed by
+// wl_surface.commit as usual.
 //
-// The ID provided will be initialized to the wl_keyboard interface
-// for this seat.
+// The hotspot can also be updated by passing the currently set
+// pointer surface to this request with new values for hotspot_x
+// and hotspot_y.
 //
-// This request only takes effect if the seat has the keyboard
-// capability, or has had the keyboard capability in the past.
-// It is a protocol violation to issue this request on a seat that has
-// never had the keyboard capability. The missing_capability error will
-// be sent in this case.
+// The current and pending input regions of the wl_surface are
+// cleared, and wl_surface.set_input_region is ignored until the
+// wl_surface is no longer used as the cursor. When the use as a
+// cursor ends, the current and pending input regions become
+// undefined, and the wl_surface is unmapped.
 //
-func (p *Seat) GetKeyboard() (*Keyboard, error) {
-	ret := NewKeyboard(p.Context())
-	return ret, p.Context().SendRequest(p, 1, Proxy(ret))
+//	serial: serial number of the enter event
+//	surface: pointer surface
+//	hotspotX: surface-local x coordinate
+//	hotspotY: surface-local y coordinate
+func (p *Pointer) SetCursor(serial uint32, surface *Surface, hotspotX int32, hotspotY int32) error {
+	return p.Context().MarshalRequest(p, 0, func(r *Request) {
+		r.PutUint32(serial)
+		if surface != nil {
+			r.PutObject(surface.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutInt32(hotspotX)
+		r.PutInt32(hotspotY)
+	})
 }
 
-// GetTouch will return touch object.
+// Release: release the pointer object
 //
+// Using this request a client can tell the server that it is not going to
+// use the pointer object anymore.
 //
-// The ID provided will be initialized to the wl_touch interface
-// for this seat.
+// This request destroys the pointer proxy object, so clients must not call
+// wl_pointer_destroy() after using this request.
+func (p *Pointer) Release() error {
+	if err := CheckRequestVersion(p, 1); err != nil {
+		return err
+	}
+	err := p.Context().MarshalRequest(p, 1, nil)
+	p.Unregister()
+	return err
+}
+
+// PointerError:
+const (
+	// PointerErrorRole: given wl_surface has another role
+	PointerErrorRole = 0
+)
+
+// PointerButtonState: physical button state
 //
-// This request only takes effect if the seat has the touch
-// capability, or has had the touch capability in the past.
-// It is a protocol violation to issue this request on a seat that has
-// never had the touch capability. The missing_capability error will
-// be sent in this case.
+// Describes the physical state of a button that produced the button
+// event.
+const (
+	// PointerButtonStateReleased: the button is not pressed
+	PointerButtonStateReleased = 0
+	// PointerButtonStatePressed: the button is pressed
+	PointerButtonStatePressed = 1
+)
+
+// PointerAxis: axis types
 //
-func (p *Seat) GetTouch() (*Touch, error) {
-	ret := NewTouch(p.Context())
-	return ret, p.Context().SendRequest(p, 2, Proxy(ret))
-}
+// Describes the axis types of scroll events.
+const (
+	// PointerAxisVerticalScroll: vertical axis
+	PointerAxisVerticalScroll = 0
+	// PointerAxisHorizontalScroll: horizontal axis
+	PointerAxisHorizontalScroll = 1
+)
 
-// Release will release the seat object.
+// PointerAxisSource: axis source types
 //
+// Describes the source types for axis events. This indicates to the
+// client how an axis event was physically generated; a client may
+// adjust the user interface accordingly. For example, scroll events
+// from a "finger" source may be in a smooth coordinate space with
+// kinetic scrolling whereas a "wheel" source may be in discrete steps
+// of a number of lines.
 //
-// Using this request a client can tell the server that it is not going to
-// use the seat object anymore.
+// The "continuous" axis source is a device generating events in a
+// continuous coordinate space, but using something other than a
+// finger. One example for this source is button-based scrolling where
+// the vertical motion of a device is converted to scroll events while
+// a button is held down.
 //
-func (p *Seat) Release() error {
-	err := p.Context().SendRequest(p, 3)
-	p.Unregister()
-	return err
-}
-
+// The "wheel tilt" axis source indicates that the actual device is a
+// wheel but the scroll event is not caused by a rotation but a
+// (usually sideways) tilt of the wheel.
 const (
-	SeatCapabilityPointer  = 1
-	SeatCapabilityKeyboard = 2
-	SeatCapabilityTouch    = 4
+	// PointerAxisSourceWheel: a physical wheel rotation
+	PointerAxisSourceWheel = 0
+	// PointerAxisSourceFinger: finger on a touch surface
+	PointerAxisSourceFinger = 1
+	// PointerAxisSourceContinuous: continuous coordinate space
+	PointerAxisSourceContinuous = 2
+	// PointerAxisSourceWheelTilt: a physical wheel tilt
+	PointerAxisSourceWheelTilt = 3
 )
 
+// PointerAxisRelativeDirection: axis relative direction
+//
+// This specifies the direction of the physical motion that caused a
+// wl_pointer.axis event, relative to the wl_pointer.axis direction.
 const (
-	SeatErrorMissingCapability = 0
+	// PointerAxisRelativeDirectionIdentical: physical motion matches axis direction
+	PointerAxisRelativeDirectionIdentical = 0
+	// PointerAxisRelativeDirectionInverted: physical motion is the inverse of the axis direction
+	PointerAxisRelativeDirectionInverted = 1
 )
 
+// PointerEnterEvent: enter event
+//
+// Notification that this seat's pointer is focused on a certain
+// surface.
+//
+// When a seat's focus enters a surface, the pointer image
+// is undefined and a client should respond to this event by setting
+// an appropriate pointer image with the set_cursor request.
 type PointerEnterEvent struct {
 	Serial   uint32
 	Surface  *Surface
@@ -2584,568 +5368,1142 @@ type PointerEnterEvent struct {
 	SurfaceY float32
 }
 
+// PointerEnterHandler is implemented by the receivers of PointerEnterEvent
 type PointerEnterHandler interface {
 	HandlePointerEnter(PointerEnterEvent)
 }
 
+// AddEnterHandler adds a handler for PointerEnterEvent
 func (p *Pointer) AddEnterHandler(h PointerEnterHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.enterHandlers = append(p.enterHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.enterHandlers = append(p.enterHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveEnterHandler removes a handler previously added by AddEnterHandler
 func (p *Pointer) RemoveEnterHandler(h PointerEnterHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.enterHandlers {
 		if e == h {
-			p.enterHandlers = append(p.enterHandlers[:i], p.enterHandlers[i+1:]...)
+			p.enterHandlers = append(p.enterHandlers[:i:i], p.enterHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// PointerLeaveEvent: leave event
+//
+// Notification that this seat's pointer is no longer focused on
+// a certain surface.
+//
+// The leave notification is sent before the enter notification
+// for the new focus.
 type PointerLeaveEvent struct {
 	Serial  uint32
 	Surface *Surface
 }
 
+// PointerLeaveHandler is implemented by the receivers of PointerLeaveEvent
 type PointerLeaveHandler interface {
 	HandlePointerLeave(PointerLeaveEvent)
 }
 
+// AddLeaveHandler adds a handler for PointerLeaveEvent
 func (p *Pointer) AddLeaveHandler(h PointerLeaveHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.leaveHandlers = append(p.leaveHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.leaveHandlers = append(p.leaveHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveLeaveHandler removes a handler previously added by AddLeaveHandler
 func (p *Pointer) RemoveLeaveHandler(h PointerLeaveHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.leaveHandlers {
 		if e == h {
-			p.leaveHandlers = append(p.leaveHandlers[:i], p.leaveHandlers[i+1:]...)
+			p.leaveHandlers = append(p.leaveHandlers[:i:i], p.leaveHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// PointerMotionEvent: pointer motion event
+//
+// Notification of pointer location change. The arguments
+// surface_x and surface_y are the location relative to the
+// focused surface.
 type PointerMotionEvent struct {
-	P *Pointer
-
+	P        *Pointer
 	Time     uint32
 	SurfaceX float32
 	SurfaceY float32
 }
 
+// PointerMotionHandler is implemented by the receivers of PointerMotionEvent
 type PointerMotionHandler interface {
 	HandlePointerMotion(PointerMotionEvent)
 }
 
+// AddMotionHandler adds a handler for PointerMotionEvent
 func (p *Pointer) AddMotionHandler(h PointerMotionHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.motionHandlers = append(p.motionHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.motionHandlers = append(p.motionHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveMotionHandler removes a handler previously added by AddMotionHandler
 func (p *Pointer) RemoveMotionHandler(h PointerMotionHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.motionHandlers {
 		if e == h {
-			p.motionHandlers = append(p.motionHandlers[:i], p.motionHandlers[i+1:]...)
+			p.motionHandlers = append(p.motionHandlers[:i:i], p.motionHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// PointerButtonEvent: pointer button event
+//
+// Mouse button click and release notifications.
+//
+// The location of the click is given by the last motion or
+// enter event.
+// The time argument is a timestamp with millisecond
+// granularity, with an undefined base.
+//
+// The button is a button code as defined in the Linux kernel's
+// linux/input-event-codes.h header file, e.g. BTN_LEFT.
+//
+// Any 16-bit button code value is reserved for future additions to the
+// kernel's event code list. All other button codes above 0xFFFF are
+// currently undefined but may be used in future versions of this
+// protocol.
 type PointerButtonEvent struct {
-	P *Pointer
-
+	P      *Pointer
 	Serial uint32
 	Time   uint32
 	Button uint32
 	State  uint32
 }
 
+// PointerButtonHandler is implemented by the receivers of PointerButtonEvent
 type PointerButtonHandler interface {
 	HandlePointerButton(PointerButtonEvent)
 }
 
+// AddButtonHandler adds a handler for PointerButtonEvent
 func (p *Pointer) AddButtonHandler(h PointerButtonHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.buttonHandlers = append(p.buttonHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.buttonHandlers = append(p.buttonHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveButtonHandler removes a handler previously added by AddButtonHandler
 func (p *Pointer) RemoveButtonHandler(h PointerButtonHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.buttonHandlers {
 		if e == h {
-			p.buttonHandlers = append(p.buttonHandlers[:i], p.buttonHandlers[i+1:]...)
+			p.buttonHandlers = append(p.buttonHandlers[:i:i], p.buttonHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// PointerAxisEvent: axis event
+//
+// Scroll and other axis notifications.
+//
+// For scroll events (vertical and horizontal scroll axes), the
+// value parameter is the length of a vector along the specified
+// axis in a coordinate space identical to those of motion events,
+// representing a relative movement along the specified axis.
+//
+// For devices that support movements non-parallel to axes multiple
+// axis events will be emitted.
+//
+// When applicable, for example for touch pads, the server can
+// choose to emit scroll events where the motion vector is
+// equivalent to a motion event vector.
+//
+// When applicable, a client can transform its content relative to the
+// scroll distance.
 type PointerAxisEvent struct {
 	Time  uint32
 	Axis  uint32
 	Value float32
 }
 
+// PointerAxisHandler is implemented by the receivers of PointerAxisEvent
 type PointerAxisHandler interface {
 	HandlePointerAxis(PointerAxisEvent)
 }
 
+// AddAxisHandler adds a handler for PointerAxisEvent
 func (p *Pointer) AddAxisHandler(h PointerAxisHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.axisHandlers = append(p.axisHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.axisHandlers = append(p.axisHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveAxisHandler removes a handler previously added by AddAxisHandler
 func (p *Pointer) RemoveAxisHandler(h PointerAxisHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.axisHandlers {
 		if e == h {
-			p.axisHandlers = append(p.axisHandlers[:i], p.axisHandlers[i+1:]...)
+			p.axisHandlers = append(p.axisHandlers[:i:i], p.axisHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// PointerFrameEvent: end of a pointer event sequence
+//
+// Indicates the end of a set of events that logically belong together.
+// A client is expected to accumulate the data in all events within the
+// frame before proceeding.
+//
+// All wl_pointer events before a wl_pointer.frame event belong
+// logically together. For example, in a diagonal scroll motion the
+// compositor will send an optional wl_pointer.axis_source event, two
+// wl_pointer.axis events (horizontal and vertical) and finally a
+// wl_pointer.frame event. The client may use this information to
+// calculate a diagonal vector for scrolling.
+//
+// When multiple wl_pointer.axis events occur within the same frame,
+// the motion vector is the combined motion of all events.
+// When a wl_pointer.axis and a wl_pointer.axis_stop event occur within
+// the same frame, this indicates that axis movement in one axis has
+// stopped but continues in the other axis.
+// When multiple wl_pointer.axis_stop events occur within the same
+// frame, this indicates that these axes stopped in the same instance.
+//
+// A wl_pointer.frame event is sent for every logical event group,
+// even if the group only contains a single wl_pointer event.
+// Specifically, a client may get a sequence: motion, frame, button,
+// frame, axis, frame, axis_stop, frame.
+//
+// The wl_pointer.enter and wl_pointer.leave events are logical events
+// generated by the compositor and not the hardware. These events are
+// also grouped by a wl_pointer.frame. When a pointer moves from one
+// surface to another, a compositor should group the
+// wl_pointer.leave event within the same wl_pointer.frame.
+// However, a client must not rely on wl_pointer.leave and
+// wl_pointer.enter being in the same wl_pointer.frame.
+// Compositor-specific policies may require the wl_pointer.leave and
+// wl_pointer.enter event being split across multiple wl_pointer.frame
+// groups.
 type PointerFrameEvent struct {
 }
 
+// PointerFrameHandler is implemented by the receivers of PointerFrameEvent
 type PointerFrameHandler interface {
 	HandlePointerFrame(PointerFrameEvent)
 }
 
+// AddFrameHandler adds a handler for PointerFrameEvent
 func (p *Pointer) AddFrameHandler(h PointerFrameHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.frameHandlers = append(p.frameHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.frameHandlers = append(p.frameHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveFrameHandler removes a handler previously added by AddFrameHandler
 func (p *Pointer) RemoveFrameHandler(h PointerFrameHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.frameHandlers {
 		if e == h {
-			p.frameHandlers = append(p.frameHandlers[:i], p.frameHandlers[i+1:]...)
+			p.frameHandlers = append(p.frameHandlers[:i:i], p.frameHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// PointerAxisSourceEvent: axis source event
+//
+// Source information for scroll and other axes.
+//
+// This event does not occur on its own. It is sent before a
+// wl_pointer.frame event and carries the source information for
+// all events within that frame.
+//
+// The source specifies how this event was generated. If the source is
+// wl_pointer.axis_source.finger, a wl_pointer.axis_stop event will be
+// sent when the user lifts the finger off the device.
+//
+// If the source is wl_pointer.axis_source.wheel,
+// wl_pointer.axis_source.wheel_tilt or
+// wl_pointer.axis_source.continuous, a wl_pointer.axis_stop event may
+// or may not be sent. Whether a compositor sends an axis_stop event
+// for these sources is hardware-specific and implementation-dependent;
+// clients must not rely on receiving an axis_stop event for these
+// scroll sources and should treat scroll sequences from these scroll
+// sources as unterminated by default.
+//
+// This event is optional. If the source is unknown for a particular
+// axis event sequence, no event is sent.
+// Only one wl_pointer.axis_source event is permitted per frame.
+//
+// The order of wl_pointer.axis_discrete and wl_pointer.axis_source is
+// not guaranteed.
 type PointerAxisSourceEvent struct {
 	AxisSource uint32
 }
 
-type PointerAxisSourceHandler interface {
-	HandlePointerAxisSource(PointerAxisSourceEvent)
+// PointerAxisSourceHandler is implemented by the receivers of PointerAxisSourceEvent
+type PointerAxisSourceHandler interface {
+	HandlePointerAxisSource(PointerAxisSourceEvent)
+}
+
+// AddAxisSourceHandler adds a handler for PointerAxisSourceEvent
+func (p *Pointer) AddAxisSourceHandler(h PointerAxisSourceHandler) {
+	if h == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.axisSourceHandlers = append(p.axisSourceHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemoveAxisSourceHandler removes a handler previously added by AddAxisSourceHandler
+func (p *Pointer) RemoveAxisSourceHandler(h PointerAxisSourceHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.axisSourceHandlers {
+		if e == h {
+			p.axisSourceHandlers = append(p.axisSourceHandlers[:i:i], p.axisSourceHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// PointerAxisStopEvent: axis stop event
+//
+// Stop notification for scroll and other axes.
+//
+// For some wl_pointer.axis_source types, a wl_pointer.axis_stop event
+// is sent to notify a client that the axis sequence has terminated.
+// This enables the client to implement kinetic scrolling.
+// See the wl_pointer.axis_source documentation for information on when
+// this event may be generated.
+//
+// Any wl_pointer.axis events with the same axis_source after this
+// event should be considered as the start of a new axis motion.
+//
+// The timestamp is to be interpreted identical to the timestamp in the
+// wl_pointer.axis event. The timestamp value may be the same as a
+// preceding wl_pointer.axis event.
+type PointerAxisStopEvent struct {
+	Time uint32
+	Axis uint32
+}
+
+// PointerAxisStopHandler is implemented by the receivers of PointerAxisStopEvent
+type PointerAxisStopHandler interface {
+	HandlePointerAxisStop(PointerAxisStopEvent)
+}
+
+// AddAxisStopHandler adds a handler for PointerAxisStopEvent
+func (p *Pointer) AddAxisStopHandler(h PointerAxisStopHandler) {
+	if h == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.axisStopHandlers = append(p.axisStopHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemoveAxisStopHandler removes a handler previously added by AddAxisStopHandler
+func (p *Pointer) RemoveAxisStopHandler(h PointerAxisStopHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.axisStopHandlers {
+		if e == h {
+			p.axisStopHandlers = append(p.axisStopHandlers[:i:i], p.axisStopHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// PointerAxisDiscreteEvent: axis click event
+//
+// Discrete step information for scroll and other axes.
+//
+// This event carries the axis value of the wl_pointer.axis event in
+// discrete steps (e.g. mouse wheel clicks).
+//
+// This event does not occur on its own, it is coupled with a
+// wl_pointer.axis event that represents this axis value on a
+// continuous scale. The protocol guarantees that each axis_discrete
+// event is always followed by exactly one axis event with the same
+// axis number within the same wl_pointer.frame. Note that the protocol
+// allows for other events to occur between the axis_discrete and
+// its coupled axis event, including other axis_discrete or axis
+// events.
+//
+// This event is optional; continuous scrolling devices
+// like two-finger scrolling on touchpads do not have discrete
+// steps and do not generate this event.
+//
+// The discrete value carries the directional information. e.g. a value
+// of -2 is two steps towards the negative direction of this axis.
+//
+// The axis number is identical to the axis number in the associated
+// axis event.
+//
+// The order of wl_pointer.axis_discrete and wl_pointer.axis_source is
+// not guaranteed.
+type PointerAxisDiscreteEvent struct {
+	Axis     uint32
+	Discrete int32
+}
+
+// PointerAxisDiscreteHandler is implemented by the receivers of PointerAxisDiscreteEvent
+type PointerAxisDiscreteHandler interface {
+	HandlePointerAxisDiscrete(PointerAxisDiscreteEvent)
 }
 
-func (p *Pointer) AddAxisSourceHandler(h PointerAxisSourceHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.axisSourceHandlers = append(p.axisSourceHandlers, h)
-		p.mu.Unlock()
+// AddAxisDiscreteHandler adds a handler for PointerAxisDiscreteEvent
+func (p *Pointer) AddAxisDiscreteHandler(h PointerAxisDiscreteHandler) {
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.axisDiscreteHandlers = append(p.axisDiscreteHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *Pointer) RemoveAxisSourceHandler(h PointerAxisSourceHandler) {
+// RemoveAxisDiscreteHandler removes a handler previously added by AddAxisDiscreteHandler
+func (p *Pointer) RemoveAxisDiscreteHandler(h PointerAxisDiscreteHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.axisSourceHandlers {
+	for i, e := range p.axisDiscreteHandlers {
 		if e == h {
-			p.axisSourceHandlers = append(p.axisSourceHandlers[:i], p.axisSourceHandlers[i+1:]...)
+			p.axisDiscreteHandlers = append(p.axisDiscreteHandlers[:i:i], p.axisDiscreteHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-type PointerAxisStopEvent struct {
-	Time uint32
-	Axis uint32
+// PointerAxisValue120Event: axis high-resolution scroll event
+//
+// Discrete high-resolution scroll information.
+//
+// This event carries high-resolution wheel scroll information,
+// with each multiple of 120 representing one logical scroll step
+// (a wheel detent). For example, an axis_value120 of 30 is one quarter of
+// a logical scroll step in the positive direction, a value120 of
+// -240 are two logical scroll steps in the negative direction within the
+// same hardware event.
+// Clients that rely on discrete scrolling should accumulate the
+// value120 to multiples of 120 before processing the event.
+//
+// The value120 must not be zero.
+//
+// This event replaces the wl_pointer.axis_discrete event in clients
+// supporting wl_pointer version 8 or later.
+//
+// Where a wl_pointer.axis_source event occurs in the same
+// wl_pointer.frame, the axis source applies to this event.
+//
+// The order of wl_pointer.axis_value120 and wl_pointer.axis_source is
+// not guaranteed.
+type PointerAxisValue120Event struct {
+	Axis     uint32
+	Value120 int32
 }
 
-type PointerAxisStopHandler interface {
-	HandlePointerAxisStop(PointerAxisStopEvent)
+// PointerAxisValue120Handler is implemented by the receivers of PointerAxisValue120Event
+type PointerAxisValue120Handler interface {
+	HandlePointerAxisValue120(PointerAxisValue120Event)
 }
 
-func (p *Pointer) AddAxisStopHandler(h PointerAxisStopHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.axisStopHandlers = append(p.axisStopHandlers, h)
-		p.mu.Unlock()
+// AddAxisValue120Handler adds a handler for PointerAxisValue120Event
+func (p *Pointer) AddAxisValue120Handler(h PointerAxisValue120Handler) {
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.axisValue120Handlers = append(p.axisValue120Handlers, h)
+	p.mu.Unlock()
 }
 
-func (p *Pointer) RemoveAxisStopHandler(h PointerAxisStopHandler) {
+// RemoveAxisValue120Handler removes a handler previously added by AddAxisValue120Handler
+func (p *Pointer) RemoveAxisValue120Handler(h PointerAxisValue120Handler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.axisStopHandlers {
+	for i, e := range p.axisValue120Handlers {
 		if e == h {
-			p.axisStopHandlers = append(p.axisStopHandlers[:i], p.axisStopHandlers[i+1:]...)
+			p.axisValue120Handlers = append(p.axisValue120Handlers[:i:i], p.axisValue120Handlers[i+1:]...)
 			break
 		}
 	}
 }
 
-type PointerAxisDiscreteEvent struct {
-	Axis     uint32
-	Discrete int32
-}
-
-type PointerAxisDiscreteHandler interface {
-	HandlePointerAxisDiscrete(PointerAxisDiscreteEvent)
-}
-
-func (p *Pointer) AddAxisDiscreteHandler(h PointerAxisDiscreteHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.axisDiscreteHandlers = append(p.axisDiscreteHandlers, h)
-		p.mu.Unlock()
+// PointerAxisRelativeDirectionEvent: axis relative physical direction event
+//
+// Relative directional information of the entity causing the axis
+// motion.
+//
+// For a wl_pointer.axis event, the wl_pointer.axis_relative_direction
+// event specifies the movement direction of the entity causing the
+// wl_pointer.axis event. For example:
+// - if a user's fingers on a touchpad move down and this
+// causes a wl_pointer.axis vertical_scroll down event, the physical
+// direction is 'identical'
+// - if a user's fingers on a touchpad move down and this causes a
+// wl_pointer.axis vertical_scroll up scroll up event ('natural
+// scrolling'), the physical direction is 'inverted'.
+//
+// A client may use this information to adjust scroll motion of
+// components. Specifically, enabling natural scrolling causes the
+// content to change direction compared to traditional scrolling.
+// Some widgets like volume control sliders should usually match the
+// physical direction regardless of whether natural scrolling is
+// active. This event enables clients to match the scroll direction of
+// a widget to the physical direction.
+//
+// This event does not occur on its own, it is coupled with a
+// wl_pointer.axis event that represents this axis value.
+// The protocol guarantees that each axis_relative_direction event is
+// always followed by exactly one axis event with the same
+// axis number within the same wl_pointer.frame. Note that the protocol
+// allows for other events to occur between the axis_relative_direction
+// and its coupled axis event.
+//
+// The axis number is identical to the axis number in the associated
+// axis event.
+//
+// The order of wl_pointer.axis_relative_direction,
+// wl_pointer.axis_discrete and wl_pointer.axis_source is not
+// guaranteed.
+type PointerAxisRelativeDirectionEvent struct {
+	Axis      uint32
+	Direction uint32
+}
+
+// PointerAxisRelativeDirectionHandler is implemented by the receivers of PointerAxisRelativeDirectionEvent
+type PointerAxisRelativeDirectionHandler interface {
+	HandlePointerAxisRelativeDirection(PointerAxisRelativeDirectionEvent)
+}
+
+// AddAxisRelativeDirectionHandler adds a handler for PointerAxisRelativeDirectionEvent
+func (p *Pointer) AddAxisRelativeDirectionHandler(h PointerAxisRelativeDirectionHandler) {
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.axisRelativeDirectionHandlers = append(p.axisRelativeDirectionHandlers, h)
+	p.mu.Unlock()
 }
 
-func (p *Pointer) RemoveAxisDiscreteHandler(h PointerAxisDiscreteHandler) {
+// RemoveAxisRelativeDirectionHandler removes a handler previously added by AddAxisRelativeDirectionHandler
+func (p *Pointer) RemoveAxisRelativeDirectionHandler(h PointerAxisRelativeDirectionHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	for i, e := range p.axisDiscreteHandlers {
+	for i, e := range p.axisRelativeDirectionHandlers {
 		if e == h {
-			p.axisDiscreteHandlers = append(
-				p.axisDiscreteHandlers[:i],
-				p.axisDiscreteHandlers[i+1:]...)
+			p.axisRelativeDirectionHandlers = append(p.axisRelativeDirectionHandlers[:i:i], p.axisRelativeDirectionHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// Dispatch decodes an event received on the wl_pointer and runs its handlers
 func (p *Pointer) Dispatch(event *Event) {
 	switch event.Opcode {
 	case 0:
-		if len(p.enterHandlers) > 0 {
-			ev := PointerEnterEvent{}
-			ev.Serial = event.Uint32()
-			ev.Surface = event.Proxy(p.Context()).(*Surface)
-			ev.SurfaceX = event.Float32()
-			ev.SurfaceY = event.Float32()
-			p.mu.RLock()
-			for _, h := range p.enterHandlers {
-				h.HandlePointerEnter(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.enterHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := PointerEnterEvent{}
+		ev.Serial = event.Uint32()
+		ev.Surface, _ = event.Proxy(p.Context()).(*Surface)
+		ev.SurfaceX = event.Float32()
+		ev.SurfaceY = event.Float32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandlePointerEnter(ev)
 		}
 	case 1:
-		if len(p.leaveHandlers) > 0 {
-			ev := PointerLeaveEvent{}
-			ev.Serial = event.Uint32()
-			ev.Surface = event.Proxy(p.Context()).(*Surface)
-			p.mu.RLock()
-			for _, h := range p.leaveHandlers {
-				h.HandlePointerLeave(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.leaveHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := PointerLeaveEvent{}
+		ev.Serial = event.Uint32()
+		ev.Surface, _ = event.Proxy(p.Context()).(*Surface)
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandlePointerLeave(ev)
 		}
 	case 2:
-		if len(p.motionHandlers) > 0 {
-			ev := PointerMotionEvent{P: p}
-			ev.Time = event.Uint32()
-			ev.SurfaceX = event.Float32()
-			ev.SurfaceY = event.Float32()
-			p.mu.RLock()
-			for _, h := range p.motionHandlers {
-				h.HandlePointerMotion(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.motionHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := PointerMotionEvent{}
+		ev.Time = event.Uint32()
+		ev.SurfaceX = event.Float32()
+		ev.SurfaceY = event.Float32()
+		ev.P = p
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandlePointerMotion(ev)
 		}
 	case 3:
-		if len(p.buttonHandlers) > 0 {
-			ev := PointerButtonEvent{}
-			ev.P = p
-
-			ev.Serial = event.Uint32()
-			ev.Time = event.Uint32()
-			ev.Button = event.Uint32()
-			ev.State = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.buttonHandlers {
-				h.HandlePointerButton(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.buttonHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := PointerButtonEvent{}
+		ev.Serial = event.Uint32()
+		ev.Time = event.Uint32()
+		ev.Button = event.Uint32()
+		ev.State = event.Uint32()
+		ev.P = p
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandlePointerButton(ev)
 		}
 	case 4:
-		if len(p.axisHandlers) > 0 {
-			ev := PointerAxisEvent{}
-			ev.Time = event.Uint32()
-			ev.Axis = event.Uint32()
-			ev.Value = event.Float32()
-			p.mu.RLock()
-			for _, h := range p.axisHandlers {
-				h.HandlePointerAxis(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.axisHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := PointerAxisEvent{}
+		ev.Time = event.Uint32()
+		ev.Axis = event.Uint32()
+		ev.Value = event.Float32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandlePointerAxis(ev)
 		}
 	case 5:
-		if len(p.frameHandlers) > 0 {
-			ev := PointerFrameEvent{}
-			p.mu.RLock()
-			for _, h := range p.frameHandlers {
-				h.HandlePointerFrame(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.frameHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := PointerFrameEvent{}
+		for _, h := range handlers {
+			h.HandlePointerFrame(ev)
 		}
 	case 6:
-		if len(p.axisSourceHandlers) > 0 {
-			ev := PointerAxisSourceEvent{}
-			ev.AxisSource = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.axisSourceHandlers {
-				h.HandlePointerAxisSource(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.axisSourceHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := PointerAxisSourceEvent{}
+		ev.AxisSource = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandlePointerAxisSource(ev)
 		}
 	case 7:
-		if len(p.axisStopHandlers) > 0 {
-			ev := PointerAxisStopEvent{}
-			ev.Time = event.Uint32()
-			ev.Axis = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.axisStopHandlers {
-				h.HandlePointerAxisStop(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.axisStopHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := PointerAxisStopEvent{}
+		ev.Time = event.Uint32()
+		ev.Axis = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandlePointerAxisStop(ev)
 		}
 	case 8:
-		if len(p.axisDiscreteHandlers) > 0 {
-			ev := PointerAxisDiscreteEvent{}
-			ev.Axis = event.Uint32()
-			ev.Discrete = event.Int32()
-			p.mu.RLock()
-			for _, h := range p.axisDiscreteHandlers {
-				h.HandlePointerAxisDiscrete(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.axisDiscreteHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := PointerAxisDiscreteEvent{}
+		ev.Axis = event.Uint32()
+		ev.Discrete = event.Int32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandlePointerAxisDiscrete(ev)
+		}
+	case 9:
+		p.mu.RLock()
+		handlers := p.axisValue120Handlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := PointerAxisValue120Event{}
+		ev.Axis = event.Uint32()
+		ev.Value120 = event.Int32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandlePointerAxisValue120(ev)
+		}
+	case 10:
+		p.mu.RLock()
+		handlers := p.axisRelativeDirectionHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := PointerAxisRelativeDirectionEvent{}
+		ev.Axis = event.Uint32()
+		ev.Direction = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandlePointerAxisRelativeDirection(ev)
 		}
 	}
 }
 
-type Pointer struct {
+const (
+	PointerAxisSourceWheelTiltSinceVersion   = 6
+	PointerEnterSinceVersion                 = 1
+	PointerLeaveSinceVersion                 = 1
+	PointerMotionSinceVersion                = 1
+	PointerButtonSinceVersion                = 1
+	PointerAxisSinceVersion                  = 1
+	PointerFrameSinceVersion                 = 5
+	PointerAxisSourceSinceVersion            = 5
+	PointerAxisStopSinceVersion              = 5
+	PointerAxisDiscreteSinceVersion          = 5
+	PointerAxisValue120SinceVersion          = 8
+	PointerAxisRelativeDirectionSinceVersion = 9
+	PointerSetCursorSinceVersion             = 1
+	PointerReleaseSinceVersion               = 3
+)
+
+// PointerInterface describes the wl_pointer interface
+var PointerInterface = &Interface{
+	Name:    "wl_pointer",
+	Version: 9,
+	Requests: []Message{
+		{
+			Name:  "set_cursor",
+			Since: 1,
+			Args: []Arg{
+				{Name: "serial", Type: ArgUint},
+				{Name: "surface", Type: ArgObject, Interface: "wl_surface", Nullable: true},
+				{Name: "hotspot_x", Type: ArgInt},
+				{Name: "hotspot_y", Type: ArgInt},
+			},
+		},
+		{
+			Name:       "release",
+			Since:      3,
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name:  "enter",
+			Since: 1,
+			Args: []Arg{
+				{Name: "serial", Type: ArgUint},
+				{Name: "surface", Type: ArgObject, Interface: "wl_surface"},
+				{Name: "surface_x", Type: ArgFixed},
+				{Name: "surface_y", Type: ArgFixed},
+			},
+		},
+		{
+			Name:  "leave",
+			Since: 1,
+			Args: []Arg{
+				{Name: "serial", Type: ArgUint},
+				{Name: "surface", Type: ArgObject, Interface: "wl_surface"},
+			},
+		},
+		{
+			Name:  "motion",
+			Since: 1,
+			Args: []Arg{
+				{Name: "time", Type: ArgUint},
+				{Name: "surface_x", Type: ArgFixed},
+				{Name: "surface_y", Type: ArgFixed},
+			},
+		},
+		{
+			Name:  "button",
+			Since: 1,
+			Args: []Arg{
+				{Name: "serial", Type: ArgUint},
+				{Name: "time", Type: ArgUint},
+				{Name: "button", Type: ArgUint},
+				{Name: "state", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "axis",
+			Since: 1,
+			Args: []Arg{
+				{Name: "time", Type: ArgUint},
+				{Name: "axis", Type: ArgUint},
+				{Name: "value", Type: ArgFixed},
+			},
+		},
+		{
+			Name:  "frame",
+			Since: 5,
+		},
+		{
+			Name:  "axis_source",
+			Since: 5,
+			Args: []Arg{
+				{Name: "axis_source", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "axis_stop",
+			Since: 5,
+			Args: []Arg{
+				{Name: "time", Type: ArgUint},
+				{Name: "axis", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "axis_discrete",
+			Since: 5,
+			Args: []Arg{
+				{Name: "axis", Type: ArgUint},
+				{Name: "discrete", Type: ArgInt},
+			},
+		},
+		{
+			Name:  "axis_value120",
+			Since: 8,
+			Args: []Arg{
+				{Name: "axis", Type: ArgUint},
+				{Name: "value120", Type: ArgInt},
+			},
+		},
+		{
+			Name:  "axis_relative_direction",
+			Since: 9,
+			Args: []Arg{
+				{Name: "axis", Type: ArgUint},
+				{Name: "direction", Type: ArgUint},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "role", Value: 0},
+			},
+		},
+		{
+			Name: "button_state",
+			Entries: []EnumEntry{
+				{Name: "released", Value: 0},
+				{Name: "pressed", Value: 1},
+			},
+		},
+		{
+			Name: "axis",
+			Entries: []EnumEntry{
+				{Name: "vertical_scroll", Value: 0},
+				{Name: "horizontal_scroll", Value: 1},
+			},
+		},
+		{
+			Name: "axis_source",
+			Entries: []EnumEntry{
+				{Name: "wheel", Value: 0},
+				{Name: "finger", Value: 1},
+				{Name: "continuous", Value: 2},
+				{Name: "wheel_tilt", Value: 3},
+			},
+		},
+		{
+			Name: "axis_relative_direction",
+			Entries: []EnumEntry{
+				{Name: "identical", Value: 0},
+				{Name: "inverted", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_pointer interface
+func (p *Pointer) Interface() *Interface {
+	return PointerInterface
+}
+
+// Keyboard: keyboard input device
+//
+// The wl_keyboard interface represents one or more keyboards
+// associated with a seat.
+type Keyboard struct {
 	BaseProxy
-	mu                   sync.RWMutex
-	enterHandlers        []PointerEnterHandler
-	leaveHandlers        []PointerLeaveHandler
-	motionHandlers       []PointerMotionHandler
-	buttonHandlers       []PointerButtonHandler
-	axisHandlers         []PointerAxisHandler
-	frameHandlers        []PointerFrameHandler
-	axisSourceHandlers   []PointerAxisSourceHandler
-	axisStopHandlers     []PointerAxisStopHandler
-	axisDiscreteHandlers []PointerAxisDiscreteHandler
+	mu                 sync.RWMutex
+	keymapHandlers     []KeyboardKeymapHandler
+	enterHandlers      []KeyboardEnterHandler
+	leaveHandlers      []KeyboardLeaveHandler
+	keyHandlers        []KeyboardKeyHandler
+	modifiersHandlers  []KeyboardModifiersHandler
+	repeatInfoHandlers []KeyboardRepeatInfoHandler
 }
 
-func NewPointer(ctx *Context) *Pointer {
-	ret := new(Pointer)
+// NewKeyboard creates a new wl_keyboard proxy registered in the Context
+func NewKeyboard(ctx *Context) *Keyboard {
+	ret := new(Keyboard)
 	ctx.Register(ret)
 	return ret
 }
 
-// SetCursor will set the pointer surface.
-//
-//
-// Set the pointer surface, i.e., the surface that contains the
-// pointer image (cursor). This request gives the surface the role
-// of a cursor. If the surface already has another role, it raises
-// a protocol error.
-//
-// The cursor actually changes only if the pointer
-// focus for this device is one of the requesting client's surfaces
-// or the surface parameter is the current pointer surface. If
-// there was a previous surface set with this request it is
-// replaced. If surface is NULL, the pointer image is hidden.
-//
-// The parameters hotspot_x and hotspot_y define the position of
-// the pointer surface relative to the pointer location. Its
-// top-left corner is always at (x, y) - (hotspot_x, hotspot_y),
-// where (x, y) are the coordinates of the pointer location, in
-// surface-local coordinates.
-//
-// On surface.attach requests to the pointer surface, hotspot_x
-// and hotspot_y are decremented by the x and y parameters
-// passed to the request. Attach must be confirmed by
-// wl_surface.commit as usual.
-//
-// The hotspot can also be updated by passing the currently set
-// pointer surface to this request with new values for hotspot_x
-// and hotspot_y.
-//
-// The current and pending input regions of the wl_surface are
-// cleared, and wl_surface.set_input_region is ignored until the
-// wl_surface is no longer used as the cursor. When the use as a
-// cursor ends, the current and pending input regions become
-// undefined, and the wl_surface is unmapped.
-//
-func (p *Pointer) SetCursor(
-	serial uint32,
-	surface *Surface,
-	hotspotX int32,
-	hotspotY int32,
-) error {
-	return p.Context().SendRequest(p, 0, serial, surface, hotspotX, hotspotY)
-}
-
-// Release will release the pointer object.
-//
-//
-// Using this request a client can tell the server that it is not going to
-// use the pointer object anymore.
-//
-// This request destroys the pointer proxy object, so clients must not call
-// wl_pointer_destroy() after using this request.
-//
-func (p *Pointer) Release() error {
-	err := p.Context().SendRequest(p, 1)
+// Release: release the keyboard object
+func (p *Keyboard) Release() error {
+	if err := CheckRequestVersion(p, 0); err != nil {
+		return err
+	}
+	err := p.Context().MarshalRequest(p, 0, nil)
 	p.Unregister()
 	return err
 }
 
+// KeyboardKeymapFormat: keyboard mapping format
+//
+// This specifies the format of the keymap provided to the
+// client with the wl_keyboard.keymap event.
 const (
-	PointerErrorRole = 0
-)
-
-const (
-	PointerButtonStateReleased = 0
-	PointerButtonStatePressed  = 1
-)
-
-const (
-	PointerAxisVerticalScroll   = 0
-	PointerAxisHorizontalScroll = 1
+	// KeyboardKeymapFormatNoKeymap: no keymap; client must understand how to interpret the raw keycode
+	KeyboardKeymapFormatNoKeymap = 0
+	// KeyboardKeymapFormatXkbV1: libxkbcommon compatible; to determine the xkb keycode, clients must add 8 to the key event keycode
+	KeyboardKeymapFormatXkbV1 = 1
 )
 
+// KeyboardKeyState: physical key state
+//
+// Describes the physical state of a key that produced the key event.
 const (
-	PointerAxisSourceWheel      = 0
-	PointerAxisSourceFinger     = 1
-	PointerAxisSourceContinuous = 2
-	PointerAxisSourceWheelTilt  = 3
+	// KeyboardKeyStateReleased: key is not pressed
+	KeyboardKeyStateReleased = 0
+	// KeyboardKeyStatePressed: key is pressed
+	KeyboardKeyStatePressed = 1
 )
 
+// KeyboardKeymapEvent: keyboard mapping
+//
+// This event provides a file descriptor to the client which can be
+// memory-mapped to provide a keyboard mapping description.
+//
+// From version 7 onwards, the fd must be mapped with MAP_PRIVATE by
+// the recipient, as MAP_SHARED may fail.
 type KeyboardKeymapEvent struct {
 	Format  uint32
 	fd      uintptr
-	Size    uint32
 	fdError error
+	Size    uint32
 }
 
+// KeyboardKeymapHandler is implemented by the receivers of KeyboardKeymapEvent
 type KeyboardKeymapHandler interface {
 	HandleKeyboardKeymap(KeyboardKeymapEvent)
 }
 
+// AddKeymapHandler adds a handler for KeyboardKeymapEvent
 func (p *Keyboard) AddKeymapHandler(h KeyboardKeymapHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.keymapHandlers = append(p.keymapHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.keymapHandlers = append(p.keymapHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveKeymapHandler removes a handler previously added by AddKeymapHandler
 func (p *Keyboard) RemoveKeymapHandler(h KeyboardKeymapHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.keymapHandlers {
 		if e == h {
-			p.keymapHandlers = append(p.keymapHandlers[:i], p.keymapHandlers[i+1:]...)
+			p.keymapHandlers = append(p.keymapHandlers[:i:i], p.keymapHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// KeyboardEnterEvent: enter event
+//
+// Notification that this seat's keyboard focus is on a certain
+// surface.
+//
+// The compositor must send the wl_keyboard.modifiers event after this
+// event.
 type KeyboardEnterEvent struct {
 	Serial  uint32
 	Surface *Surface
 	Keys    []int32
 }
 
+// KeyboardEnterHandler is implemented by the receivers of KeyboardEnterEvent
 type KeyboardEnterHandler interface {
 	HandleKeyboardEnter(KeyboardEnterEvent)
 }
 
+// AddEnterHandler adds a handler for KeyboardEnterEvent
 func (p *Keyboard) AddEnterHandler(h KeyboardEnterHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.enterHandlers = append(p.enterHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.enterHandlers = append(p.enterHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveEnterHandler removes a handler previously added by AddEnterHandler
 func (p *Keyboard) RemoveEnterHandler(h KeyboardEnterHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.enterHandlers {
 		if e == h {
-			p.enterHandlers = append(p.enterHandlers[:i], p.enterHandlers[i+1:]...)
+			p.enterHandlers = append(p.enterHandlers[:i:i], p.enterHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// KeyboardLeaveEvent: leave event
+//
+// Notification that this seat's keyboard focus is no longer on
+// a certain surface.
+//
+// The leave notification is sent before the enter notification
+// for the new focus.
+//
+// After this event client must assume that all keys, including modifiers,
+// are lifted and also it must stop key repeating if there's some going on.
 type KeyboardLeaveEvent struct {
 	Serial  uint32
 	Surface *Surface
 }
 
+// KeyboardLeaveHandler is implemented by the receivers of KeyboardLeaveEvent
 type KeyboardLeaveHandler interface {
 	HandleKeyboardLeave(KeyboardLeaveEvent)
 }
 
+// AddLeaveHandler adds a handler for KeyboardLeaveEvent
 func (p *Keyboard) AddLeaveHandler(h KeyboardLeaveHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.leaveHandlers = append(p.leaveHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.leaveHandlers = append(p.leaveHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveLeaveHandler removes a handler previously added by AddLeaveHandler
 func (p *Keyboard) RemoveLeaveHandler(h KeyboardLeaveHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.leaveHandlers {
 		if e == h {
-			p.leaveHandlers = append(p.leaveHandlers[:i], p.leaveHandlers[i+1:]...)
+			p.leaveHandlers = append(p.leaveHandlers[:i:i], p.leaveHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// KeyboardKeyEvent: key event
+//
+// A key was pressed or released.
+// The time argument is a timestamp with millisecond
+// granularity, with an undefined base.
+//
+// The key is a platform-specific key code that can be interpreted
+// by feeding it to the keyboard mapping (see the keymap event).
+//
+// If this event produces a change in modifiers, then the resulting
+// wl_keyboard.modifiers event must be sent after this event.
 type KeyboardKeyEvent struct {
 	Serial uint32
 	Time   uint32
@@ -3153,30 +6511,39 @@ type KeyboardKeyEvent struct {
 	State  uint32
 }
 
+// KeyboardKeyHandler is implemented by the receivers of KeyboardKeyEvent
 type KeyboardKeyHandler interface {
 	HandleKeyboardKey(KeyboardKeyEvent)
 }
 
+// AddKeyHandler adds a handler for KeyboardKeyEvent
 func (p *Keyboard) AddKeyHandler(h KeyboardKeyHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.keyHandlers = append(p.keyHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.keyHandlers = append(p.keyHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveKeyHandler removes a handler previously added by AddKeyHandler
 func (p *Keyboard) RemoveKeyHandler(h KeyboardKeyHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.keyHandlers {
 		if e == h {
-			p.keyHandlers = append(p.keyHandlers[:i], p.keyHandlers[i+1:]...)
+			p.keyHandlers = append(p.keyHandlers[:i:i], p.keyHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// KeyboardModifiersEvent: modifier and group state
+//
+// Notifies clients that the modifier and/or group state has
+// changed, and it should update its local state.
 type KeyboardModifiersEvent struct {
 	Serial        uint32
 	ModsDepressed uint32
@@ -3185,173 +6552,335 @@ type KeyboardModifiersEvent struct {
 	Group         uint32
 }
 
+// KeyboardModifiersHandler is implemented by the receivers of KeyboardModifiersEvent
 type KeyboardModifiersHandler interface {
 	HandleKeyboardModifiers(KeyboardModifiersEvent)
 }
 
+// AddModifiersHandler adds a handler for KeyboardModifiersEvent
 func (p *Keyboard) AddModifiersHandler(h KeyboardModifiersHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.modifiersHandlers = append(p.modifiersHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.modifiersHandlers = append(p.modifiersHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveModifiersHandler removes a handler previously added by AddModifiersHandler
 func (p *Keyboard) RemoveModifiersHandler(h KeyboardModifiersHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.modifiersHandlers {
 		if e == h {
-			p.modifiersHandlers = append(p.modifiersHandlers[:i], p.modifiersHandlers[i+1:]...)
+			p.modifiersHandlers = append(p.modifiersHandlers[:i:i], p.modifiersHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// KeyboardRepeatInfoEvent: repeat rate and delay
+//
+// Informs the client about the keyboard's repeat rate and delay.
+//
+// This event is sent as soon as the wl_keyboard object has been created,
+// and is guaranteed to be received by the client before any key press
+// event.
+//
+// Negative values for either rate or delay are illegal. A rate of zero
+// will disable any repeating (regardless of the value of delay).
+//
+// This event can be sent later on as well with a new value if necessary,
+// so clients should continue listening for the event past the creation
+// of wl_keyboard.
 type KeyboardRepeatInfoEvent struct {
 	Rate  int32
 	Delay int32
 }
 
+// KeyboardRepeatInfoHandler is implemented by the receivers of KeyboardRepeatInfoEvent
 type KeyboardRepeatInfoHandler interface {
 	HandleKeyboardRepeatInfo(KeyboardRepeatInfoEvent)
 }
 
+// AddRepeatInfoHandler adds a handler for KeyboardRepeatInfoEvent
 func (p *Keyboard) AddRepeatInfoHandler(h KeyboardRepeatInfoHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.repeatInfoHandlers = append(p.repeatInfoHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.repeatInfoHandlers = append(p.repeatInfoHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveRepeatInfoHandler removes a handler previously added by AddRepeatInfoHandler
 func (p *Keyboard) RemoveRepeatInfoHandler(h KeyboardRepeatInfoHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.repeatInfoHandlers {
 		if e == h {
-			p.repeatInfoHandlers = append(p.repeatInfoHandlers[:i], p.repeatInfoHandlers[i+1:]...)
+			p.repeatInfoHandlers = append(p.repeatInfoHandlers[:i:i], p.repeatInfoHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// Dispatch decodes an event received on the wl_keyboard and runs its handlers
 func (p *Keyboard) Dispatch(event *Event) {
 	switch event.Opcode {
 	case 0:
-		if len(p.keymapHandlers) > 0 {
-			ev := KeyboardKeymapEvent{}
-			ev.Format = event.Uint32()
-			ev.fd, ev.fdError = event.FD()
-			ev.Size = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.keymapHandlers {
-				h.HandleKeyboardKeymap(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.keymapHandlers
+		p.mu.RUnlock()
+		ev := KeyboardKeymapEvent{}
+		ev.Format = event.Uint32()
+		ev.fd, ev.fdError = event.FD()
+		ev.Size = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleKeyboardKeymap(ev)
 		}
 	case 1:
-		if len(p.enterHandlers) > 0 {
-			ev := KeyboardEnterEvent{}
-			ev.Serial = event.Uint32()
-			ev.Surface = event.Proxy(p.Context()).(*Surface)
-			ev.Keys = event.Array()
-			p.mu.RLock()
-			for _, h := range p.enterHandlers {
-				h.HandleKeyboardEnter(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.enterHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := KeyboardEnterEvent{}
+		ev.Serial = event.Uint32()
+		ev.Surface, _ = event.Proxy(p.Context()).(*Surface)
+		ev.Keys = event.Array()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleKeyboardEnter(ev)
 		}
 	case 2:
-		if len(p.leaveHandlers) > 0 {
-			ev := KeyboardLeaveEvent{}
-			ev.Serial = event.Uint32()
-			ev.Surface = event.Proxy(p.Context()).(*Surface)
-			p.mu.RLock()
-			for _, h := range p.leaveHandlers {
-				h.HandleKeyboardLeave(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.leaveHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := KeyboardLeaveEvent{}
+		ev.Serial = event.Uint32()
+		ev.Surface, _ = event.Proxy(p.Context()).(*Surface)
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleKeyboardLeave(ev)
 		}
 	case 3:
-		if len(p.keyHandlers) > 0 {
-			ev := KeyboardKeyEvent{}
-			ev.Serial = event.Uint32()
-			ev.Time = event.Uint32()
-			ev.Key = event.Uint32()
-			ev.State = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.keyHandlers {
-				h.HandleKeyboardKey(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.keyHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := KeyboardKeyEvent{}
+		ev.Serial = event.Uint32()
+		ev.Time = event.Uint32()
+		ev.Key = event.Uint32()
+		ev.State = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleKeyboardKey(ev)
 		}
 	case 4:
-		if len(p.modifiersHandlers) > 0 {
-			ev := KeyboardModifiersEvent{}
-			ev.Serial = event.Uint32()
-			ev.ModsDepressed = event.Uint32()
-			ev.ModsLatched = event.Uint32()
-			ev.ModsLocked = event.Uint32()
-			ev.Group = event.Uint32()
-			p.mu.RLock()
-			for _, h := range p.modifiersHandlers {
-				h.HandleKeyboardModifiers(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.modifiersHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := KeyboardModifiersEvent{}
+		ev.Serial = event.Uint32()
+		ev.ModsDepressed = event.Uint32()
+		ev.ModsLatched = event.Uint32()
+		ev.ModsLocked = event.Uint32()
+		ev.Group = event.Uint32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleKeyboardModifiers(ev)
 		}
 	case 5:
-		if len(p.repeatInfoHandlers) > 0 {
-			ev := KeyboardRepeatInfoEvent{}
-			ev.Rate = event.Int32()
-			ev.Delay = event.Int32()
-			p.mu.RLock()
-			for _, h := range p.repeatInfoHandlers {
-				h.HandleKeyboardRepeatInfo(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.repeatInfoHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := KeyboardRepeatInfoEvent{}
+		ev.Rate = event.Int32()
+		ev.Delay = event.Int32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleKeyboardRepeatInfo(ev)
 		}
 	}
 }
 
-type Keyboard struct {
+const (
+	KeyboardKeymapSinceVersion     = 1
+	KeyboardEnterSinceVersion      = 1
+	KeyboardLeaveSinceVersion      = 1
+	KeyboardKeySinceVersion        = 1
+	KeyboardModifiersSinceVersion  = 1
+	KeyboardRepeatInfoSinceVersion = 4
+	KeyboardReleaseSinceVersion    = 3
+)
+
+// KeyboardInterface describes the wl_keyboard interface
+var KeyboardInterface = &Interface{
+	Name:    "wl_keyboard",
+	Version: 9,
+	Requests: []Message{
+		{
+			Name:       "release",
+			Since:      3,
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name:  "keymap",
+			Since: 1,
+			Args: []Arg{
+				{Name: "format", Type: ArgUint},
+				{Name: "fd", Type: ArgFd},
+				{Name: "size", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "enter",
+			Since: 1,
+			Args: []Arg{
+				{Name: "serial", Type: ArgUint},
+				{Name: "surface", Type: ArgObject, Interface: "wl_surface"},
+				{Name: "keys", Type: ArgArray},
+			},
+		},
+		{
+			Name:  "leave",
+			Since: 1,
+			Args: []Arg{
+				{Name: "serial", Type: ArgUint},
+				{Name: "surface", Type: ArgObject, Interface: "wl_surface"},
+			},
+		},
+		{
+			Name:  "key",
+			Since: 1,
+			Args: []Arg{
+				{Name: "serial", Type: ArgUint},
+				{Name: "time", Type: ArgUint},
+				{Name: "key", Type: ArgUint},
+				{Name: "state", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "modifiers",
+			Since: 1,
+			Args: []Arg{
+				{Name: "serial", Type: ArgUint},
+				{Name: "mods_depressed", Type: ArgUint},
+				{Name: "mods_latched", Type: ArgUint},
+				{Name: "mods_locked", Type: ArgUint},
+				{Name: "group", Type: ArgUint},
+			},
+		},
+		{
+			Name:  "repeat_info",
+			Since: 4,
+			Args: []Arg{
+				{Name: "rate", Type: ArgInt},
+				{Name: "delay", Type: ArgInt},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "keymap_format",
+			Entries: []EnumEntry{
+				{Name: "no_keymap", Value: 0},
+				{Name: "xkb_v1", Value: 1},
+			},
+		},
+		{
+			Name: "key_state",
+			Entries: []EnumEntry{
+				{Name: "released", Value: 0},
+				{Name: "pressed", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_keyboard interface
+func (p *Keyboard) Interface() *Interface {
+	return KeyboardInterface
+}
+
+// Touch: touchscreen input device
+//
+// The wl_touch interface represents a touchscreen
+// associated with a seat.
+//
+// Touch interactions can consist of one or more contacts.
+// For each contact, a series of events is generated, starting
+// with a down event, followed by zero or more motion events,
+// and ending with an up event. Events relating to the same
+// contact point can be identified by the ID of the sequence.
+type Touch struct {
 	BaseProxy
-	mu                 sync.RWMutex
-	keymapHandlers     []KeyboardKeymapHandler
-	enterHandlers      []KeyboardEnterHandler
-	leaveHandlers      []KeyboardLeaveHandler
-	keyHandlers        []KeyboardKeyHandler
-	modifiersHandlers  []KeyboardModifiersHandler
-	repeatInfoHandlers []KeyboardRepeatInfoHandler
+	mu                  sync.RWMutex
+	downHandlers        []TouchDownHandler
+	upHandlers          []TouchUpHandler
+	motionHandlers      []TouchMotionHandler
+	frameHandlers       []TouchFrameHandler
+	cancelHandlers      []TouchCancelHandler
+	shapeHandlers       []TouchShapeHandler
+	orientationHandlers []TouchOrientationHandler
 }
 
-func NewKeyboard(ctx *Context) *Keyboard {
-	ret := new(Keyboard)
+// NewTouch creates a new wl_touch proxy registered in the Context
+func NewTouch(ctx *Context) *Touch {
+	ret := new(Touch)
 	ctx.Register(ret)
 	return ret
 }
 
-// Release will release the keyboard object.
-//
-//
-func (p *Keyboard) Release() error {
-	err := p.Context().SendRequest(p, 0)
+// Release: release the touch object
+func (p *Touch) Release() error {
+	if err := CheckRequestVersion(p, 0); err != nil {
+		return err
+	}
+	err := p.Context().MarshalRequest(p, 0, nil)
 	p.Unregister()
 	return err
 }
 
-const (
-	KeyboardKeymapFormatNoKeymap = 0
-	KeyboardKeymapFormatXkbV1    = 1
-)
-
-const (
-	KeyboardKeyStateReleased = 0
-	KeyboardKeyStatePressed  = 1
-)
-
+// TouchDownEvent: touch down event and beginning of a touch sequence
+//
+// A new touch point has appeared on the surface. This touch point is
+// assigned a unique ID. Future events from this touch point reference
+// this ID. The ID ceases to be valid after a touch up event and may be
+// reused in the future.
 type TouchDownEvent struct {
 	Serial  uint32
 	Time    uint32
@@ -3361,60 +6890,78 @@ type TouchDownEvent struct {
 	Y       float32
 }
 
+// TouchDownHandler is implemented by the receivers of TouchDownEvent
 type TouchDownHandler interface {
 	HandleTouchDown(TouchDownEvent)
 }
 
+// AddDownHandler adds a handler for TouchDownEvent
 func (p *Touch) AddDownHandler(h TouchDownHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.downHandlers = append(p.downHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.downHandlers = append(p.downHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveDownHandler removes a handler previously added by AddDownHandler
 func (p *Touch) RemoveDownHandler(h TouchDownHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.downHandlers {
 		if e == h {
-			p.downHandlers = append(p.downHandlers[:i], p.downHandlers[i+1:]...)
+			p.downHandlers = append(p.downHandlers[:i:i], p.downHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// TouchUpEvent: end of a touch event sequence
+//
+// The touch point has disappeared. No further events will be sent for
+// this touch point and the touch point's ID is released and may be
+// reused in a future touch down event.
 type TouchUpEvent struct {
 	Serial uint32
 	Time   uint32
 	Id     int32
 }
 
+// TouchUpHandler is implemented by the receivers of TouchUpEvent
 type TouchUpHandler interface {
 	HandleTouchUp(TouchUpEvent)
 }
 
+// AddUpHandler adds a handler for TouchUpEvent
 func (p *Touch) AddUpHandler(h TouchUpHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.upHandlers = append(p.upHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.upHandlers = append(p.upHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveUpHandler removes a handler previously added by AddUpHandler
 func (p *Touch) RemoveUpHandler(h TouchUpHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.upHandlers {
 		if e == h {
-			p.upHandlers = append(p.upHandlers[:i], p.upHandlers[i+1:]...)
+			p.upHandlers = append(p.upHandlers[:i:i], p.upHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// TouchMotionEvent: update of touch point coordinates
+//
+// A touch point has changed coordinates.
 type TouchMotionEvent struct {
 	Time uint32
 	Id   int32
@@ -3422,258 +6969,557 @@ type TouchMotionEvent struct {
 	Y    float32
 }
 
+// TouchMotionHandler is implemented by the receivers of TouchMotionEvent
 type TouchMotionHandler interface {
 	HandleTouchMotion(TouchMotionEvent)
 }
 
+// AddMotionHandler adds a handler for TouchMotionEvent
 func (p *Touch) AddMotionHandler(h TouchMotionHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.motionHandlers = append(p.motionHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.motionHandlers = append(p.motionHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveMotionHandler removes a handler previously added by AddMotionHandler
 func (p *Touch) RemoveMotionHandler(h TouchMotionHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.motionHandlers {
 		if e == h {
-			p.motionHandlers = append(p.motionHandlers[:i], p.motionHandlers[i+1:]...)
+			p.motionHandlers = append(p.motionHandlers[:i:i], p.motionHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// TouchFrameEvent: end of touch frame event
+//
+// Indicates the end of a set of events that logically belong together.
+// A client is expected to accumulate the data in all events within the
+// frame before proceeding.
+//
+// A wl_touch.frame terminates at least one event but otherwise no
+// guarantee is provided about the set of events within a frame. A client
+// must assume that any state not updated in a frame is unchanged from the
+// previously known state.
 type TouchFrameEvent struct {
 }
 
+// TouchFrameHandler is implemented by the receivers of TouchFrameEvent
 type TouchFrameHandler interface {
 	HandleTouchFrame(TouchFrameEvent)
 }
 
+// AddFrameHandler adds a handler for TouchFrameEvent
 func (p *Touch) AddFrameHandler(h TouchFrameHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.frameHandlers = append(p.frameHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.frameHandlers = append(p.frameHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveFrameHandler removes a handler previously added by AddFrameHandler
 func (p *Touch) RemoveFrameHandler(h TouchFrameHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.frameHandlers {
 		if e == h {
-			p.frameHandlers = append(p.frameHandlers[:i], p.frameHandlers[i+1:]...)
+			p.frameHandlers = append(p.frameHandlers[:i:i], p.frameHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// TouchCancelEvent: touch session cancelled
+//
+// Sent if the compositor decides the touch stream is a global
+// gesture. No further events are sent to the clients from that
+// particular gesture. Touch cancellation applies to all touch points
+// currently active on this client's surface. The client is
+// responsible for finalizing the touch points, future touch points on
+// this surface may reuse the touch point ID.
 type TouchCancelEvent struct {
 }
 
+// TouchCancelHandler is implemented by the receivers of TouchCancelEvent
 type TouchCancelHandler interface {
 	HandleTouchCancel(TouchCancelEvent)
 }
 
+// AddCancelHandler adds a handler for TouchCancelEvent
 func (p *Touch) AddCancelHandler(h TouchCancelHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.cancelHandlers = append(p.cancelHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.cancelHandlers = append(p.cancelHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveCancelHandler removes a handler previously added by AddCancelHandler
 func (p *Touch) RemoveCancelHandler(h TouchCancelHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.cancelHandlers {
 		if e == h {
-			p.cancelHandlers = append(p.cancelHandlers[:i], p.cancelHandlers[i+1:]...)
+			p.cancelHandlers = append(p.cancelHandlers[:i:i], p.cancelHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// TouchShapeEvent: update shape of touch point
+//
+// Sent when a touchpoint has changed its shape.
+//
+// This event does not occur on its own. It is sent before a
+// wl_touch.frame event and carries the new shape information for
+// any previously reported, or new touch points of that frame.
+//
+// Other events describing the touch point such as wl_touch.down,
+// wl_touch.motion or wl_touch.orientation may be sent within the
+// same wl_touch.frame. A client should treat these events as a single
+// logical touch point update. The order of wl_touch.shape,
+// wl_touch.orientation and wl_touch.motion is not guaranteed.
+// A wl_touch.down event is guaranteed to occur before the first
+// wl_touch.shape event for this touch ID but both events may occur within
+// the same wl_touch.frame.
+//
+// A touchpoint shape is approximated by an ellipse through the major and
+// minor axis length. The major axis length describes the longer diameter
+// of the ellipse, while the minor axis length describes the shorter
+// diameter. Major and minor are orthogonal and both are specified in
+// surface-local coordinates. The center of the ellipse is always at the
+// touchpoint location as reported by wl_touch.down or wl_touch.move.
+//
+// This event is only sent by the compositor if the touch device supports
+// shape reports. The client has to make reasonable assumptions about the
+// shape if it did not receive this event.
 type TouchShapeEvent struct {
 	Id    int32
 	Major float32
 	Minor float32
 }
 
+// TouchShapeHandler is implemented by the receivers of TouchShapeEvent
 type TouchShapeHandler interface {
 	HandleTouchShape(TouchShapeEvent)
 }
 
+// AddShapeHandler adds a handler for TouchShapeEvent
 func (p *Touch) AddShapeHandler(h TouchShapeHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.shapeHandlers = append(p.shapeHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.shapeHandlers = append(p.shapeHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveShapeHandler removes a handler previously added by AddShapeHandler
 func (p *Touch) RemoveShapeHandler(h TouchShapeHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.shapeHandlers {
 		if e == h {
-			p.shapeHandlers = append(p.shapeHandlers[:i], p.shapeHandlers[i+1:]...)
+			p.shapeHandlers = append(p.shapeHandlers[:i:i], p.shapeHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// TouchOrientationEvent: update orientation of touch point
+//
+// Sent when a touchpoint has changed its orientation.
+//
+// This event does not occur on its own. It is sent before a
+// wl_touch.frame event and carries the new shape information for
+// any previously reported, or new touch points of that frame.
+//
+// Other events describing the touch point such as wl_touch.down,
+// wl_touch.motion or wl_touch.shape may be sent within the
+// same wl_touch.frame. A client should treat these events as a single
+// logical touch point update. The order of wl_touch.shape,
+// wl_touch.orientation and wl_touch.motion is not guaranteed.
+// A wl_touch.down event is guaranteed to occur before the first
+// wl_touch.orientation event for this touch ID but both events may occur
+// within the same wl_touch.frame.
+//
+// The orientation describes the clockwise angle of a touchpoint's major
+// axis to the positive surface y-axis and is normalized to the -180 to
+// +180 degree range. The granularity of orientation depends on the touch
+// device, some devices only support binary rotation values between 0 and
+// 90 degrees.
+//
+// This event is only sent by the compositor if the touch device supports
+// orientation reports.
 type TouchOrientationEvent struct {
 	Id          int32
 	Orientation float32
 }
 
+// TouchOrientationHandler is implemented by the receivers of TouchOrientationEvent
 type TouchOrientationHandler interface {
 	HandleTouchOrientation(TouchOrientationEvent)
 }
 
+// AddOrientationHandler adds a handler for TouchOrientationEvent
 func (p *Touch) AddOrientationHandler(h TouchOrientationHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.orientationHandlers = append(p.orientationHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.orientationHandlers = append(p.orientationHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveOrientationHandler removes a handler previously added by AddOrientationHandler
 func (p *Touch) RemoveOrientationHandler(h TouchOrientationHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.orientationHandlers {
 		if e == h {
-			p.orientationHandlers = append(
-				p.orientationHandlers[:i],
-				p.orientationHandlers[i+1:]...)
+			p.orientationHandlers = append(p.orientationHandlers[:i:i], p.orientationHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// Dispatch decodes an event received on the wl_touch and runs its handlers
 func (p *Touch) Dispatch(event *Event) {
 	switch event.Opcode {
 	case 0:
-		if len(p.downHandlers) > 0 {
-			ev := TouchDownEvent{}
-			ev.Serial = event.Uint32()
-			ev.Time = event.Uint32()
-			ev.Surface = event.Proxy(p.Context()).(*Surface)
-			ev.Id = event.Int32()
-			ev.X = event.Float32()
-			ev.Y = event.Float32()
-			p.mu.RLock()
-			for _, h := range p.downHandlers {
-				h.HandleTouchDown(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.downHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := TouchDownEvent{}
+		ev.Serial = event.Uint32()
+		ev.Time = event.Uint32()
+		ev.Surface, _ = event.Proxy(p.Context()).(*Surface)
+		ev.Id = event.Int32()
+		ev.X = event.Float32()
+		ev.Y = event.Float32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleTouchDown(ev)
 		}
 	case 1:
-		if len(p.upHandlers) > 0 {
-			ev := TouchUpEvent{}
-			ev.Serial = event.Uint32()
-			ev.Time = event.Uint32()
-			ev.Id = event.Int32()
-			p.mu.RLock()
-			for _, h := range p.upHandlers {
-				h.HandleTouchUp(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.upHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := TouchUpEvent{}
+		ev.Serial = event.Uint32()
+		ev.Time = event.Uint32()
+		ev.Id = event.Int32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleTouchUp(ev)
 		}
 	case 2:
-		if len(p.motionHandlers) > 0 {
-			ev := TouchMotionEvent{}
-			ev.Time = event.Uint32()
-			ev.Id = event.Int32()
-			ev.X = event.Float32()
-			ev.Y = event.Float32()
-			p.mu.RLock()
-			for _, h := range p.motionHandlers {
-				h.HandleTouchMotion(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.motionHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := TouchMotionEvent{}
+		ev.Time = event.Uint32()
+		ev.Id = event.Int32()
+		ev.X = event.Float32()
+		ev.Y = event.Float32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleTouchMotion(ev)
 		}
 	case 3:
-		if len(p.frameHandlers) > 0 {
-			ev := TouchFrameEvent{}
-			p.mu.RLock()
-			for _, h := range p.frameHandlers {
-				h.HandleTouchFrame(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.frameHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := TouchFrameEvent{}
+		for _, h := range handlers {
+			h.HandleTouchFrame(ev)
 		}
 	case 4:
-		if len(p.cancelHandlers) > 0 {
-			ev := TouchCancelEvent{}
-			p.mu.RLock()
-			for _, h := range p.cancelHandlers {
-				h.HandleTouchCancel(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.cancelHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := TouchCancelEvent{}
+		for _, h := range handlers {
+			h.HandleTouchCancel(ev)
 		}
 	case 5:
-		if len(p.shapeHandlers) > 0 {
-			ev := TouchShapeEvent{}
-			ev.Id = event.Int32()
-			ev.Major = event.Float32()
-			ev.Minor = event.Float32()
-			p.mu.RLock()
-			for _, h := range p.shapeHandlers {
-				h.HandleTouchShape(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.shapeHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := TouchShapeEvent{}
+		ev.Id = event.Int32()
+		ev.Major = event.Float32()
+		ev.Minor = event.Float32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleTouchShape(ev)
 		}
 	case 6:
-		if len(p.orientationHandlers) > 0 {
-			ev := TouchOrientationEvent{}
-			ev.Id = event.Int32()
-			ev.Orientation = event.Float32()
-			p.mu.RLock()
-			for _, h := range p.orientationHandlers {
-				h.HandleTouchOrientation(ev)
-			}
-			p.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.orientationHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := TouchOrientationEvent{}
+		ev.Id = event.Int32()
+		ev.Orientation = event.Float32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleTouchOrientation(ev)
 		}
 	}
 }
 
-type Touch struct {
+const (
+	TouchDownSinceVersion        = 1
+	TouchUpSinceVersion          = 1
+	TouchMotionSinceVersion      = 1
+	TouchFrameSinceVersion       = 1
+	TouchCancelSinceVersion      = 1
+	TouchShapeSinceVersion       = 6
+	TouchOrientationSinceVersion = 6
+	TouchReleaseSinceVersion     = 3
+)
+
+// TouchInterface describes the wl_touch interface
+var TouchInterface = &Interface{
+	Name:    "wl_touch",
+	Version: 9,
+	Requests: []Message{
+		{
+			Name:       "release",
+			Since:      3,
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name:  "down",
+			Since: 1,
+			Args: []Arg{
+				{Name: "serial", Type: ArgUint},
+				{Name: "time", Type: ArgUint},
+				{Name: "surface", Type: ArgObject, Interface: "wl_surface"},
+				{Name: "id", Type: ArgInt},
+				{Name: "x", Type: ArgFixed},
+				{Name: "y", Type: ArgFixed},
+			},
+		},
+		{
+			Name:  "up",
+			Since: 1,
+			Args: []Arg{
+				{Name: "serial", Type: ArgUint},
+				{Name: "time", Type: ArgUint},
+				{Name: "id", Type: ArgInt},
+			},
+		},
+		{
+			Name:  "motion",
+			Since: 1,
+			Args: []Arg{
+				{Name: "time", Type: ArgUint},
+				{Name: "id", Type: ArgInt},
+				{Name: "x", Type: ArgFixed},
+				{Name: "y", Type: ArgFixed},
+			},
+		},
+		{
+			Name:  "frame",
+			Since: 1,
+		},
+		{
+			Name:  "cancel",
+			Since: 1,
+		},
+		{
+			Name:  "shape",
+			Since: 6,
+			Args: []Arg{
+				{Name: "id", Type: ArgInt},
+				{Name: "major", Type: ArgFixed},
+				{Name: "minor", Type: ArgFixed},
+			},
+		},
+		{
+			Name:  "orientation",
+			Since: 6,
+			Args: []Arg{
+				{Name: "id", Type: ArgInt},
+				{Name: "orientation", Type: ArgFixed},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_touch interface
+func (p *Touch) Interface() *Interface {
+	return TouchInterface
+}
+
+// Output: compositor output region
+//
+// An output describes part of the compositor geometry.  The
+// compositor works in the 'compositor coordinate system' and an
+// output corresponds to a rectangular area in that space that is
+// actually visible.  This typically corresponds to a monitor that
+// displays part of the compositor space.  This object is published
+// as global during start up, or when a monitor is hotplugged.
+type Output struct {
 	BaseProxy
 	mu                  sync.RWMutex
-	downHandlers        []TouchDownHandler
-	upHandlers          []TouchUpHandler
-	motionHandlers      []TouchMotionHandler
-	frameHandlers       []TouchFrameHandler
-	cancelHandlers      []TouchCancelHandler
-	shapeHandlers       []TouchShapeHandler
-	orientationHandlers []TouchOrientationHandler
+	geometryHandlers    []OutputGeometryHandler
+	modeHandlers        []OutputModeHandler
+	doneHandlers        []OutputDoneHandler
+	scaleHandlers       []OutputScaleHandler
+	nameHandlers        []OutputNameHandler
+	descriptionHandlers []OutputDescriptionHandler
 }
 
-func NewTouch(ctx *Context) *Touch {
-	ret := new(Touch)
+// NewOutput creates a new wl_output proxy registered in the Context
+func NewOutput(ctx *Context) *Output {
+	ret := new(Output)
 	ctx.Register(ret)
 	return ret
 }
 
-// Release will release the touch object.
+// Release: release the output object
 //
-//
-func (p *Touch) Release() error {
-	err := p.Context().SendRequest(p, 0)
+// Using this request a client can tell the server that it is not going to
+// use the output object anymore.
+func (p *Output) Release() error {
+	if err := CheckRequestVersion(p, 0); err != nil {
+		return err
+	}
+	err := p.Context().MarshalRequest(p, 0, nil)
 	p.Unregister()
 	return err
 }
 
+// OutputSubpixel: subpixel geometry information
+//
+// This enumeration describes how the physical
+// pixels on an output are laid out.
+const (
+	// OutputSubpixelUnknown: unknown geometry
+	OutputSubpixelUnknown = 0
+	// OutputSubpixelNone: no geometry
+	OutputSubpixelNone = 1
+	// OutputSubpixelHorizontalRgb: horizontal RGB
+	OutputSubpixelHorizontalRgb = 2
+	// OutputSubpixelHorizontalBgr: horizontal BGR
+	OutputSubpixelHorizontalBgr = 3
+	// OutputSubpixelVerticalRgb: vertical RGB
+	OutputSubpixelVerticalRgb = 4
+	// OutputSubpixelVerticalBgr: vertical BGR
+	OutputSubpixelVerticalBgr = 5
+)
+
+// OutputTransform: transform from framebuffer to output
+//
+// This describes the transform that a compositor will apply to a
+// surface to compensate for the rotation or mirroring of an
+// output device.
+//
+// The flipped values correspond to an initial flip around a
+// vertical axis followed by rotation.
+//
+// The purpose is mainly to allow clients to render accordingly and
+// tell the compositor, so that for fullscreen surfaces, the
+// compositor will still be able to scan out directly from client
+// surfaces.
+const (
+	// OutputTransformNormal: no transform
+	OutputTransformNormal = 0
+	// OutputTransform90: 90 degrees counter-clockwise
+	OutputTransform90 = 1
+	// OutputTransform180: 180 degrees counter-clockwise
+	OutputTransform180 = 2
+	// OutputTransform270: 270 degrees counter-clockwise
+	OutputTransform270 = 3
+	// OutputTransformFlipped: 180 degree flip around a vertical axis
+	OutputTransformFlipped = 4
+	// OutputTransformFlipped90: flip and rotate 90 degrees counter-clockwise
+	OutputTransformFlipped90 = 5
+	// OutputTransformFlipped180: flip and rotate 180 degrees counter-clockwise
+	OutputTransformFlipped180 = 6
+	// OutputTransformFlipped270: flip and rotate 270 degrees counter-clockwise
+	OutputTransformFlipped270 = 7
+)
+
+// OutputMode: mode information
+//
+// These flags describe properties of an output mode.
+// They are used in the flags bitfield of the mode event.
+const (
+	// OutputModeCurrent: indicates this is the current mode
+	OutputModeCurrent = 0x1
+	// OutputModePreferred: indicates this is the preferred mode
+	OutputModePreferred = 0x2
+)
+
+// OutputGeometryEvent: properties of the output
+//
+// The geometry event describes geometric properties of the output.
+// The event is sent when binding to the output object and whenever
+// any of the properties change.
+//
+// The physical size can be set to zero if it doesn't make sense for this
+// output (e.g. for projectors or virtual outputs).
+//
+// Note: wl_output only advertises partial information about the output
+// position and identification. Some compositors, for instance those not
+// implementing a desktop-style output layout or those exposing virtual
+// outputs, might fake this information. Instead of using x and y, clients
+// should use xdg_output.logical_position. Instead of using make and model,
+// clients should use xdg_output.name and xdg_output.description.
 type OutputGeometryEvent struct {
 	X              int32
 	Y              int32
@@ -3685,30 +7531,67 @@ type OutputGeometryEvent struct {
 	Transform      int32
 }
 
+// OutputGeometryHandler is implemented by the receivers of OutputGeometryEvent
 type OutputGeometryHandler interface {
 	HandleOutputGeometry(OutputGeometryEvent)
 }
 
+// AddGeometryHandler adds a handler for OutputGeometryEvent
 func (p *Output) AddGeometryHandler(h OutputGeometryHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.geometryHandlers = append(p.geometryHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.geometryHandlers = append(p.geometryHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveGeometryHandler removes a handler previously added by AddGeometryHandler
 func (p *Output) RemoveGeometryHandler(h OutputGeometryHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.geometryHandlers {
 		if e == h {
-			p.geometryHandlers = append(p.geometryHandlers[:i], p.geometryHandlers[i+1:]...)
+			p.geometryHandlers = append(p.geometryHandlers[:i:i], p.geometryHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// OutputModeEvent: advertise available modes for the output
+//
+// The mode event describes an available mode for the output.
+//
+// The event is sent when binding to the output object and there
+// will always be one mode, the current mode.  The event is sent
+// again if an output changes mode, for the mode that is now
+// current.  In other words, the current mode is always the last
+// mode that was received with the current flag set.
+//
+// Non-current modes are deprecated. A compositor can decide to only
+// advertise the current mode and never send other modes. Clients
+// should not rely on non-current modes.
+//
+// The size of a mode is given in physical hardware units of
+// the output device. This is not necessarily the same as
+// the output size in the global compositor space. For instance,
+// the output may be scaled, as described in wl_output.scale,
+// or transformed, as described in wl_output.transform. Clients
+// willing to retrieve the output size in the global compositor
+// space should use xdg_output.logical_size instead.
+//
+// The vertical refresh rate can be set to zero if it doesn't make
+// sense for this output (e.g. for virtual outputs).
+//
+// Clients should not use the refresh rate to schedule frames. Instead,
+// they should use the wl_surface.frame event or the presentation-time
+// protocol.
+//
+// Note: this information is not always meaningful for all outputs. Some
+// compositors, such as those exposing virtual outputs, might fake the
+// refresh rate or the size.
 type OutputModeEvent struct {
 	Flags   uint32
 	Width   int32
@@ -3716,255 +7599,602 @@ type OutputModeEvent struct {
 	Refresh int32
 }
 
+// OutputModeHandler is implemented by the receivers of OutputModeEvent
 type OutputModeHandler interface {
 	HandleOutputMode(OutputModeEvent)
 }
 
+// AddModeHandler adds a handler for OutputModeEvent
 func (p *Output) AddModeHandler(h OutputModeHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.modeHandlers = append(p.modeHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.modeHandlers = append(p.modeHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveModeHandler removes a handler previously added by AddModeHandler
 func (p *Output) RemoveModeHandler(h OutputModeHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.modeHandlers {
 		if e == h {
-			p.modeHandlers = append(p.modeHandlers[:i], p.modeHandlers[i+1:]...)
+			p.modeHandlers = append(p.modeHandlers[:i:i], p.modeHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// OutputDoneEvent: sent all information about output
+//
+// This event is sent after all other properties have been
+// sent after binding to the output object and after any
+// other property changes done after that. This allows
+// changes to the output properties to be seen as
+// atomic, even if they happen via multiple events.
 type OutputDoneEvent struct {
 }
 
+// OutputDoneHandler is implemented by the receivers of OutputDoneEvent
 type OutputDoneHandler interface {
 	HandleOutputDone(OutputDoneEvent)
 }
 
+// AddDoneHandler adds a handler for OutputDoneEvent
 func (p *Output) AddDoneHandler(h OutputDoneHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.doneHandlers = append(p.doneHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.doneHandlers = append(p.doneHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveDoneHandler removes a handler previously added by AddDoneHandler
 func (p *Output) RemoveDoneHandler(h OutputDoneHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.doneHandlers {
 		if e == h {
-			p.doneHandlers = append(p.doneHandlers[:i], p.doneHandlers[i+1:]...)
+			p.doneHandlers = append(p.doneHandlers[:i:i], p.doneHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
+// OutputScaleEvent: output scaling properties
+//
+// This event contains scaling geometry information
+// that is not in the geometry event. It may be sent after
+// binding the output object or if the output scale changes
+// later. If it is not sent, the client should assume a
+// scale of 1.
+//
+// A scale larger than 1 means that the compositor will
+// automatically scale surface buffers by this amount
+// when rendering. This is used for very high resolution
+// displays where applications rendering at the native
+// resolution would be too small to be legible.
+//
+// It is intended that scaling aware clients track the
+// current output of a surface, and if it is on a scaled
+// output it should use wl_surface.set_buffer_scale with
+// the scale of the output. That way the compositor can
+// avoid scaling the surface, and the client can supply
+// a higher detail image.
 type OutputScaleEvent struct {
 	Factor int32
 }
 
+// OutputScaleHandler is implemented by the receivers of OutputScaleEvent
 type OutputScaleHandler interface {
 	HandleOutputScale(OutputScaleEvent)
 }
 
+// AddScaleHandler adds a handler for OutputScaleEvent
 func (p *Output) AddScaleHandler(h OutputScaleHandler) {
-	if h != nil {
-		p.mu.Lock()
-		p.scaleHandlers = append(p.scaleHandlers, h)
-		p.mu.Unlock()
+	if h == nil {
+		return
 	}
+
+	p.mu.Lock()
+	p.scaleHandlers = append(p.scaleHandlers, h)
+	p.mu.Unlock()
 }
 
+// RemoveScaleHandler removes a handler previously added by AddScaleHandler
 func (p *Output) RemoveScaleHandler(h OutputScaleHandler) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	for i, e := range p.scaleHandlers {
 		if e == h {
-			p.scaleHandlers = append(p.scaleHandlers[:i], p.scaleHandlers[i+1:]...)
+			p.scaleHandlers = append(p.scaleHandlers[:i:i], p.scaleHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-func (p *Output) Dispatch(event *Event) {
-	switch event.Opcode {
-	case 0:
-		if len(p.geometryHandlers) > 0 {
-			ev := OutputGeometryEvent{}
-			ev.X = event.Int32()
-			ev.Y = event.Int32()
-			ev.PhysicalWidth = event.Int32()
-			ev.PhysicalHeight = event.Int32()
-			ev.Subpixel = event.Int32()
-			ev.Make = event.String()
-			ev.Model = event.String()
-			ev.Transform = event.Int32()
-			p.mu.RLock()
-			for _, h := range p.geometryHandlers {
-				h.HandleOutputGeometry(ev)
-			}
-			p.mu.RUnlock()
-		}
-	case 1:
-		if len(p.modeHandlers) > 0 {
-			ev := OutputModeEvent{}
-			ev.Flags = event.Uint32()
-			ev.Width = event.Int32()
-			ev.Height = event.Int32()
-			ev.Refresh = event.Int32()
-			p.mu.RLock()
-			for _, h := range p.modeHandlers {
-				h.HandleOutputMode(ev)
-			}
-			p.mu.RUnlock()
-		}
-	case 2:
-		if len(p.doneHandlers) > 0 {
-			ev := OutputDoneEvent{}
-			p.mu.RLock()
-			for _, h := range p.doneHandlers {
-				h.HandleOutputDone(ev)
-			}
-			p.mu.RUnlock()
-		}
-	case 3:
-		if len(p.scaleHandlers) > 0 {
-			ev := OutputScaleEvent{}
-			ev.Factor = event.Int32()
-			p.mu.RLock()
-			for _, h := range p.scaleHandlers {
-				h.HandleOutputScale(ev)
-			}
-			p.mu.RUnlock()
-		}
-	}
+// OutputNameEvent: name of this output
+//
+// Many compositors will assign user-friendly names to their outputs, show
+// them to the user, allow the user to refer to an output, etc. The client
+// may wish to know this name as well to offer the user similar behaviors.
+//
+// The name is a UTF-8 string with no convention defined for its contents.
+// Each name is unique among all wl_output globals. The name is only
+// guaranteed to be unique for the compositor instance.
+//
+// The same output name is used for all clients for a given wl_output
+// global. Thus, the name can be shared across processes to refer to a
+// specific wl_output global.
+//
+// The name is not guaranteed to be persistent across sessions, thus cannot
+// be used to reliably identify an output in e.g. configuration files.
+//
+// Examples of names include 'HDMI-A-1', 'WL-1', 'X11-1', etc. However, do
+// not assume that the name is a reflection of an underlying DRM connector,
+// X11 connection, etc.
+//
+// The name event is sent after binding the output object. This event is
+// only sent once per output object, and the name does not change over the
+// lifetime of the wl_output global.
+//
+// Compositors may re-use the same output name if the wl_output global is
+// destroyed and re-created later. Compositors should avoid re-using the
+// same name if possible.
+//
+// The name event will be followed by a done event.
+type OutputNameEvent struct {
+	Name string
 }
 
-type Output struct {
-	BaseProxy
-	mu               sync.RWMutex
-	geometryHandlers []OutputGeometryHandler
-	modeHandlers     []OutputModeHandler
-	doneHandlers     []OutputDoneHandler
-	scaleHandlers    []OutputScaleHandler
+// OutputNameHandler is implemented by the receivers of OutputNameEvent
+type OutputNameHandler interface {
+	HandleOutputName(OutputNameEvent)
 }
 
-func NewOutput(ctx *Context) *Output {
-	ret := new(Output)
-	ctx.Register(ret)
-	return ret
+// AddNameHandler adds a handler for OutputNameEvent
+func (p *Output) AddNameHandler(h OutputNameHandler) {
+	if h == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.nameHandlers = append(p.nameHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemoveNameHandler removes a handler previously added by AddNameHandler
+func (p *Output) RemoveNameHandler(h OutputNameHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.nameHandlers {
+		if e == h {
+			p.nameHandlers = append(p.nameHandlers[:i:i], p.nameHandlers[i+1:]...)
+			break
+		}
+	}
 }
 
-// Release will release the output object.
+// OutputDescriptionEvent: human-readable description of this output
 //
+// Many compositors can produce human-readable descriptions of their
+// outputs. The client may wish to know this description as well, e.g. for
+// output selection purposes.
 //
-// Using this request a client can tell the server that it is not going to
-// use the output object anymore.
+// The description is a UTF-8 string with no convention defined for its
+// contents. The description is not guaranteed to be unique among all
+// wl_output globals. Examples might include 'Foocorp 11" Display' or
+// 'Virtual X11 output via :1'.
 //
-func (p *Output) Release() error {
-	err := p.Context().SendRequest(p, 0)
-	p.Unregister()
-	return err
+// The description event is sent after binding the output object and
+// whenever the description changes. The description is optional, and may
+// not be sent at all.
+//
+// The description event will be followed by a done event.
+type OutputDescriptionEvent struct {
+	Description string
 }
 
-const (
-	OutputSubpixelUnknown       = 0
-	OutputSubpixelNone          = 1
-	OutputSubpixelHorizontalRgb = 2
-	OutputSubpixelHorizontalBgr = 3
-	OutputSubpixelVerticalRgb   = 4
-	OutputSubpixelVerticalBgr   = 5
-)
+// OutputDescriptionHandler is implemented by the receivers of OutputDescriptionEvent
+type OutputDescriptionHandler interface {
+	HandleOutputDescription(OutputDescriptionEvent)
+}
 
-const (
-	OutputTransformNormal     = 0
-	OutputTransform90         = 1
-	OutputTransform180        = 2
-	OutputTransform270        = 3
-	OutputTransformFlipped    = 4
-	OutputTransformFlipped90  = 5
-	OutputTransformFlipped180 = 6
-	OutputTransformFlipped270 = 7
-)
+// AddDescriptionHandler adds a handler for OutputDescriptionEvent
+func (p *Output) AddDescriptionHandler(h OutputDescriptionHandler) {
+	if h == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.descriptionHandlers = append(p.descriptionHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemoveDescriptionHandler removes a handler previously added by AddDescriptionHandler
+func (p *Output) RemoveDescriptionHandler(h OutputDescriptionHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.descriptionHandlers {
+		if e == h {
+			p.descriptionHandlers = append(p.descriptionHandlers[:i:i], p.descriptionHandlers[i+1:]...)
+			break
+		}
+	}
+}
+
+// Dispatch decodes an event received on the wl_output and runs its handlers
+func (p *Output) Dispatch(event *Event) {
+	switch event.Opcode {
+	case 0:
+		p.mu.RLock()
+		handlers := p.geometryHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := OutputGeometryEvent{}
+		ev.X = event.Int32()
+		ev.Y = event.Int32()
+		ev.PhysicalWidth = event.Int32()
+		ev.PhysicalHeight = event.Int32()
+		ev.Subpixel = event.Int32()
+		ev.Make = event.String()
+		ev.Model = event.String()
+		ev.Transform = event.Int32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleOutputGeometry(ev)
+		}
+	case 1:
+		p.mu.RLock()
+		handlers := p.modeHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := OutputModeEvent{}
+		ev.Flags = event.Uint32()
+		ev.Width = event.Int32()
+		ev.Height = event.Int32()
+		ev.Refresh = event.Int32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleOutputMode(ev)
+		}
+	case 2:
+		p.mu.RLock()
+		handlers := p.doneHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := OutputDoneEvent{}
+		for _, h := range handlers {
+			h.HandleOutputDone(ev)
+		}
+	case 3:
+		p.mu.RLock()
+		handlers := p.scaleHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := OutputScaleEvent{}
+		ev.Factor = event.Int32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleOutputScale(ev)
+		}
+	case 4:
+		p.mu.RLock()
+		handlers := p.nameHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := OutputNameEvent{}
+		ev.Name = event.String()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleOutputName(ev)
+		}
+	case 5:
+		p.mu.RLock()
+		handlers := p.descriptionHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := OutputDescriptionEvent{}
+		ev.Description = event.String()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleOutputDescription(ev)
+		}
+	}
+}
 
 const (
-	OutputModeCurrent   = 0x1
-	OutputModePreferred = 0x2
+	OutputGeometrySinceVersion    = 1
+	OutputModeSinceVersion        = 1
+	OutputDoneSinceVersion        = 2
+	OutputScaleSinceVersion       = 2
+	OutputNameSinceVersion        = 4
+	OutputDescriptionSinceVersion = 4
+	OutputReleaseSinceVersion     = 3
 )
 
+// OutputInterface describes the wl_output interface
+var OutputInterface = &Interface{
+	Name:    "wl_output",
+	Version: 4,
+	Requests: []Message{
+		{
+			Name:       "release",
+			Since:      3,
+			Destructor: true,
+		},
+	},
+	Events: []Message{
+		{
+			Name:  "geometry",
+			Since: 1,
+			Args: []Arg{
+				{Name: "x", Type: ArgInt},
+				{Name: "y", Type: ArgInt},
+				{Name: "physical_width", Type: ArgInt},
+				{Name: "physical_height", Type: ArgInt},
+				{Name: "subpixel", Type: ArgInt},
+				{Name: "make", Type: ArgString},
+				{Name: "model", Type: ArgString},
+				{Name: "transform", Type: ArgInt},
+			},
+		},
+		{
+			Name:  "mode",
+			Since: 1,
+			Args: []Arg{
+				{Name: "flags", Type: ArgUint},
+				{Name: "width", Type: ArgInt},
+				{Name: "height", Type: ArgInt},
+				{Name: "refresh", Type: ArgInt},
+			},
+		},
+		{
+			Name:  "done",
+			Since: 2,
+		},
+		{
+			Name:  "scale",
+			Since: 2,
+			Args: []Arg{
+				{Name: "factor", Type: ArgInt},
+			},
+		},
+		{
+			Name:  "name",
+			Since: 4,
+			Args: []Arg{
+				{Name: "name", Type: ArgString},
+			},
+		},
+		{
+			Name:  "description",
+			Since: 4,
+			Args: []Arg{
+				{Name: "description", Type: ArgString},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "subpixel",
+			Entries: []EnumEntry{
+				{Name: "unknown", Value: 0},
+				{Name: "none", Value: 1},
+				{Name: "horizontal_rgb", Value: 2},
+				{Name: "horizontal_bgr", Value: 3},
+				{Name: "vertical_rgb", Value: 4},
+				{Name: "vertical_bgr", Value: 5},
+			},
+		},
+		{
+			Name: "transform",
+			Entries: []EnumEntry{
+				{Name: "normal", Value: 0},
+				{Name: "90", Value: 1},
+				{Name: "180", Value: 2},
+				{Name: "270", Value: 3},
+				{Name: "flipped", Value: 4},
+				{Name: "flipped_90", Value: 5},
+				{Name: "flipped_180", Value: 6},
+				{Name: "flipped_270", Value: 7},
+			},
+		},
+		{
+			Name:     "mode",
+			Bitfield: true,
+			Entries: []EnumEntry{
+				{Name: "current", Value: 0x1},
+				{Name: "preferred", Value: 0x2},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_output interface
+func (p *Output) Interface() *Interface {
+	return OutputInterface
+}
+
+// Region: region interface
+//
+// A region object describes an area.
+//
+// Region objects are used to describe the opaque and input
+// regions of a surface.
 type Region struct {
 	BaseProxy
 }
 
+// NewRegion creates a new wl_region proxy registered in the Context
 func NewRegion(ctx *Context) *Region {
 	ret := new(Region)
 	ctx.Register(ret)
 	return ret
 }
 
-// Destroy will destroy region.
-//
+// Destroy: destroy region
 //
 // Destroy the region.  This will invalidate the object ID.
-//
 func (p *Region) Destroy() error {
-	err := p.Context().SendRequest(p, 0)
+	err := p.Context().MarshalRequest(p, 0, nil)
 	p.Unregister()
 	return err
 }
 
-// Add will add rectangle to region.
-//
+// Add: add rectangle to region
 //
 // Add the specified rectangle to the region.
 //
+//	x: region-local x coordinate
+//	y: region-local y coordinate
+//	width: rectangle width
+//	height: rectangle height
 func (p *Region) Add(x int32, y int32, width int32, height int32) error {
-	return p.Context().SendRequest(p, 1, x, y, width, height)
+	return p.Context().MarshalRequest(p, 1, func(r *Request) {
+		r.PutInt32(x)
+		r.PutInt32(y)
+		r.PutInt32(width)
+		r.PutInt32(height)
+	})
 }
 
-// Subtract will subtract rectangle from region.
-//
+// Subtract: subtract rectangle from region
 //
 // Subtract the specified rectangle from the region.
 //
+//	x: region-local x coordinate
+//	y: region-local y coordinate
+//	width: rectangle width
+//	height: rectangle height
 func (p *Region) Subtract(x int32, y int32, width int32, height int32) error {
-	return p.Context().SendRequest(p, 2, x, y, width, height)
+	return p.Context().MarshalRequest(p, 2, func(r *Request) {
+		r.PutInt32(x)
+		r.PutInt32(y)
+		r.PutInt32(width)
+		r.PutInt32(height)
+	})
 }
 
+const (
+	RegionDestroySinceVersion  = 1
+	RegionAddSinceVersion      = 1
+	RegionSubtractSinceVersion = 1
+)
+
+// RegionInterface describes the wl_region interface
+var RegionInterface = &Interface{
+	Name:    "wl_region",
+	Version: 1,
+	Requests: []Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "add",
+			Since: 1,
+			Args: []Arg{
+				{Name: "x", Type: ArgInt},
+				{Name: "y", Type: ArgInt},
+				{Name: "width", Type: ArgInt},
+				{Name: "height", Type: ArgInt},
+			},
+		},
+		{
+			Name:  "subtract",
+			Since: 1,
+			Args: []Arg{
+				{Name: "x", Type: ArgInt},
+				{Name: "y", Type: ArgInt},
+				{Name: "width", Type: ArgInt},
+				{Name: "height", Type: ArgInt},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_region interface
+func (p *Region) Interface() *Interface {
+	return RegionInterface
+}
+
+// Subcompositor: sub-surface compositing
+//
+// The global interface exposing sub-surface compositing capabilities.
+// A wl_surface, that has sub-surfaces associated, is called the
+// parent surface. Sub-surfaces can be arbitrarily nested and create
+// a tree of sub-surfaces.
+//
+// The root surface in a tree of sub-surfaces is the main
+// surface. The main surface cannot be a sub-surface, because
+// sub-surfaces must always have a parent.
+//
+// A main surface with its sub-surfaces forms a (compound) window.
+// For window management purposes, this set of wl_surface objects is
+// to be considered as a single window, and it should also behave as
+// such.
+//
+// The aim of sub-surfaces is to offload some of the compositing work
+// within a window from clients to the compositor. A prime example is
+// a video player with decorations and video in separate wl_surface
+// objects. This should allow the compositor to pass YUV video buffer
+// processing to dedicated overlay hardware when possible.
 type Subcompositor struct {
 	BaseProxy
 }
 
+// NewSubcompositor creates a new wl_subcompositor proxy registered in the Context
 func NewSubcompositor(ctx *Context) *Subcompositor {
 	ret := new(Subcompositor)
 	ctx.Register(ret)
 	return ret
 }
 
-// Destroy will unbind from the subcompositor interface.
-//
+// Destroy: unbind from the subcompositor interface
 //
 // Informs the server that the client will not be using this
 // protocol object anymore. This does not affect any other
 // objects, wl_subsurface objects included.
-//
 func (p *Subcompositor) Destroy() error {
-	err := p.Context().SendRequest(p, 0)
+	err := p.Context().MarshalRequest(p, 0, nil)
 	p.Unregister()
 	return err
 }
 
-// GetSubsurface will give a surface the role sub-surface.
-//
+// GetSubsurface: give a surface the role sub-surface
 //
 // Create a sub-surface interface for the given surface, and
 // associate it with the given parent surface. This turns a
@@ -3982,42 +8212,153 @@ func (p *Subcompositor) Destroy() error {
 // This request modifies the behaviour of wl_surface.commit request on
 // the sub-surface, see the documentation on wl_subsurface interface.
 //
+//	id: the new sub-surface object ID
+//	surface: the surface to be turned into a sub-surface
+//	parent: the parent surface
 func (p *Subcompositor) GetSubsurface(surface *Surface, parent *Surface) (*Subsurface, error) {
 	ret := NewSubsurface(p.Context())
-	return ret, p.Context().SendRequest(p, 1, Proxy(ret), surface, parent)
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 1, func(r *Request) {
+		r.PutNewId(ret)
+		if surface != nil {
+			r.PutObject(surface.Id())
+		} else {
+			r.PutObject(0)
+		}
+		if parent != nil {
+			r.PutObject(parent.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
 }
 
+// SubcompositorError:
 const (
+	// SubcompositorErrorBadSurface: the to-be sub-surface is invalid
 	SubcompositorErrorBadSurface = 0
+	// SubcompositorErrorBadParent: the to-be sub-surface parent is invalid
+	SubcompositorErrorBadParent = 1
+)
+
+const (
+	SubcompositorDestroySinceVersion       = 1
+	SubcompositorGetSubsurfaceSinceVersion = 1
 )
 
+// SubcompositorInterface describes the wl_subcompositor interface
+var SubcompositorInterface = &Interface{
+	Name:    "wl_subcompositor",
+	Version: 1,
+	Requests: []Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "get_subsurface",
+			Since: 1,
+			Args: []Arg{
+				{Name: "id", Type: ArgNewId, Interface: "wl_subsurface"},
+				{Name: "surface", Type: ArgObject, Interface: "wl_surface"},
+				{Name: "parent", Type: ArgObject, Interface: "wl_surface"},
+			},
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "bad_surface", Value: 0},
+				{Name: "bad_parent", Value: 1},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_subcompositor interface
+func (p *Subcompositor) Interface() *Interface {
+	return SubcompositorInterface
+}
+
+// Subsurface: sub-surface interface to a wl_surface
+//
+// An additional interface to a wl_surface object, which has been
+// made a sub-surface. A sub-surface has one parent surface. A
+// sub-surface's size and position are not limited to that of the parent.
+// Particularly, a sub-surface is not automatically clipped to its
+// parent's area.
+//
+// A sub-surface becomes mapped, when a non-NULL wl_buffer is applied
+// and the parent surface is mapped. The order of which one happens
+// first is irrelevant. A sub-surface is hidden if the parent becomes
+// hidden, or if a NULL wl_buffer is applied. These rules apply
+// recursively through the tree of surfaces.
+//
+// The behaviour of a wl_surface.commit request on a sub-surface
+// depends on the sub-surface's mode. The possible modes are
+// synchronized and desynchronized, see methods
+// wl_subsurface.set_sync and wl_subsurface.set_desync. Synchronized
+// mode caches the wl_surface state to be applied when the parent's
+// state gets applied, and desynchronized mode applies the pending
+// wl_surface state directly. A sub-surface is initially in the
+// synchronized mode.
+//
+// Sub-surfaces also have another kind of state, which is managed by
+// wl_subsurface requests, as opposed to wl_surface requests. This
+// state includes the sub-surface position relative to the parent
+// surface (wl_subsurface.set_position), and the stacking order of
+// the parent and its sub-surfaces (wl_subsurface.place_above and
+// .place_below). This state is applied when the parent surface's
+// wl_surface state is applied, regardless of the sub-surface's mode.
+// As the exception, set_sync and set_desync are effective immediately.
+//
+// The main surface can be thought to be always in desynchronized mode,
+// since it does not have a parent in the sub-surfaces sense.
+//
+// Even if a sub-surface is in desynchronized mode, it will behave as
+// in synchronized mode, if its parent surface behaves as in
+// synchronized mode. This rule is applied recursively throughout the
+// tree of surfaces. This means, that one can set a sub-surface into
+// synchronized mode, and then assume that all its child and grand-child
+// sub-surfaces are synchronized, too, without explicitly setting them.
+//
+// If the wl_surface associated with the wl_subsurface is destroyed, the
+// wl_subsurface object becomes inert. Note, that destroying either object
+// takes effect immediately. If you need to synchronize the removal
+// of a sub-surface to the parent surface update, unmap the sub-surface
+// first by attaching a NULL wl_buffer, update parent, and then destroy
+// the sub-surface.
+//
+// If the parent wl_surface object is destroyed, the sub-surface is
+// unmapped.
 type Subsurface struct {
 	BaseProxy
 }
 
+// NewSubsurface creates a new wl_subsurface proxy registered in the Context
 func NewSubsurface(ctx *Context) *Subsurface {
 	ret := new(Subsurface)
 	ctx.Register(ret)
 	return ret
 }
 
-// Destroy will remove sub-surface interface.
-//
+// Destroy: remove sub-surface interface
 //
 // The sub-surface interface is removed from the wl_surface object
 // that was turned into a sub-surface with a
 // wl_subcompositor.get_subsurface request. The wl_surface's association
 // to the parent is deleted, and the wl_surface loses its role as
 // a sub-surface. The wl_surface is unmapped immediately.
-//
 func (p *Subsurface) Destroy() error {
-	err := p.Context().SendRequest(p, 0)
+	err := p.Context().MarshalRequest(p, 0, nil)
 	p.Unregister()
 	return err
 }
 
-// SetPosition will reposition the sub-surface.
-//
+// SetPosition: reposition the sub-surface
 //
 // This schedules a sub-surface position change.
 // The sub-surface will be moved so that its origin (top left
@@ -4036,12 +8377,16 @@ func (p *Subsurface) Destroy() error {
 //
 // The initial position is 0, 0.
 //
+//	x: x coordinate in the parent surface
+//	y: y coordinate in the parent surface
 func (p *Subsurface) SetPosition(x int32, y int32) error {
-	return p.Context().SendRequest(p, 1, x, y)
+	return p.Context().MarshalRequest(p, 1, func(r *Request) {
+		r.PutInt32(x)
+		r.PutInt32(y)
+	})
 }
 
-// PlaceAbove will restack the sub-surface.
-//
+// PlaceAbove: restack the sub-surface
 //
 // This sub-surface is taken from the stack, and put back just
 // above the reference surface, changing the z-order of the sub-surfaces.
@@ -4059,22 +8404,34 @@ func (p *Subsurface) SetPosition(x int32, y int32) error {
 // A new sub-surface is initially added as the top-most in the stack
 // of its siblings and parent.
 //
+//	sibling: the reference surface
 func (p *Subsurface) PlaceAbove(sibling *Surface) error {
-	return p.Context().SendRequest(p, 2, sibling)
+	return p.Context().MarshalRequest(p, 2, func(r *Request) {
+		if sibling != nil {
+			r.PutObject(sibling.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
 }
 
-// PlaceBelow will restack the sub-surface.
-//
+// PlaceBelow: restack the sub-surface
 //
 // The sub-surface is placed just below the reference surface.
 // See wl_subsurface.place_above.
 //
+//	sibling: the reference surface
 func (p *Subsurface) PlaceBelow(sibling *Surface) error {
-	return p.Context().SendRequest(p, 3, sibling)
+	return p.Context().MarshalRequest(p, 3, func(r *Request) {
+		if sibling != nil {
+			r.PutObject(sibling.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
 }
 
-// SetSync will set sub-surface to synchronized mode.
-//
+// SetSync: set sub-surface to synchronized mode
 //
 // Change the commit behaviour of the sub-surface to synchronized
 // mode, also described as the parent dependent mode.
@@ -4089,13 +8446,11 @@ func (p *Subsurface) PlaceBelow(sibling *Surface) error {
 // parent surface commits do not (re-)apply old state.
 //
 // See wl_subsurface for the recursive effect of this mode.
-//
 func (p *Subsurface) SetSync() error {
-	return p.Context().SendRequest(p, 4)
+	return p.Context().MarshalRequest(p, 4, nil)
 }
 
-// SetDesync will set sub-surface to desynchronized mode.
-//
+// SetDesync: set sub-surface to desynchronized mode
 //
 // Change the commit behaviour of the sub-surface to desynchronized
 // mode, also described as independent or freely running mode.
@@ -4116,11 +8471,168 @@ func (p *Subsurface) SetSync() error {
 //
 // If a surface's parent surface behaves as desynchronized, then
 // the cached state is applied on set_desync.
-//
 func (p *Subsurface) SetDesync() error {
-	return p.Context().SendRequest(p, 5)
+	return p.Context().MarshalRequest(p, 5, nil)
 }
 
+// SubsurfaceError:
 const (
+	// SubsurfaceErrorBadSurface: wl_surface is not a sibling or the parent
 	SubsurfaceErrorBadSurface = 0
 )
+
+const (
+	SubsurfaceDestroySinceVersion     = 1
+	SubsurfaceSetPositionSinceVersion = 1
+	SubsurfacePlaceAboveSinceVersion  = 1
+	SubsurfacePlaceBelowSinceVersion  = 1
+	SubsurfaceSetSyncSinceVersion     = 1
+	SubsurfaceSetDesyncSinceVersion   = 1
+)
+
+// SubsurfaceInterface describes the wl_subsurface interface
+var SubsurfaceInterface = &Interface{
+	Name:    "wl_subsurface",
+	Version: 1,
+	Requests: []Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "set_position",
+			Since: 1,
+			Args: []Arg{
+				{Name: "x", Type: ArgInt},
+				{Name: "y", Type: ArgInt},
+			},
+		},
+		{
+			Name:  "place_above",
+			Since: 1,
+			Args: []Arg{
+				{Name: "sibling", Type: ArgObject, Interface: "wl_surface"},
+			},
+		},
+		{
+			Name:  "place_below",
+			Since: 1,
+			Args: []Arg{
+				{Name: "sibling", Type: ArgObject, Interface: "wl_surface"},
+			},
+		},
+		{
+			Name:  "set_sync",
+			Since: 1,
+		},
+		{
+			Name:  "set_desync",
+			Since: 1,
+		},
+	},
+	Enums: []Enum{
+		{
+			Name: "error",
+			Entries: []EnumEntry{
+				{Name: "bad_surface", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the wl_subsurface interface
+func (p *Subsurface) Interface() *Interface {
+	return SubsurfaceInterface
+}
+
+func init() {
+	RegisterInterface(DisplayInterface)
+	RegisterInterface(RegistryInterface)
+	RegisterInterface(CallbackInterface)
+	RegisterInterface(CompositorInterface)
+	RegisterInterface(ShmPoolInterface)
+	RegisterInterface(ShmInterface)
+	RegisterInterface(BufferInterface)
+	RegisterInterface(DataOfferInterface)
+	RegisterInterface(DataSourceInterface)
+	RegisterInterface(DataDeviceInterface)
+	RegisterInterface(DataDeviceManagerInterface)
+	RegisterInterface(ShellInterface)
+	RegisterInterface(ShellSurfaceInterface)
+	RegisterInterface(SurfaceInterface)
+	RegisterInterface(SeatInterface)
+	RegisterInterface(PointerInterface)
+	RegisterInterface(KeyboardInterface)
+	RegisterInterface(TouchInterface)
+	RegisterInterface(OutputInterface)
+	RegisterInterface(RegionInterface)
+	RegisterInterface(SubcompositorInterface)
+	RegisterInterface(SubsurfaceInterface)
+	RegisterProxy(DisplayInterface, func(ctx *Context) Proxy {
+		return NewDisplay(ctx)
+	})
+	RegisterProxy(RegistryInterface, func(ctx *Context) Proxy {
+		return NewRegistry(ctx)
+	})
+	RegisterProxy(CallbackInterface, func(ctx *Context) Proxy {
+		return NewCallback(ctx)
+	})
+	RegisterProxy(CompositorInterface, func(ctx *Context) Proxy {
+		return NewCompositor(ctx)
+	})
+	RegisterProxy(ShmPoolInterface, func(ctx *Context) Proxy {
+		return NewShmPool(ctx)
+	})
+	RegisterProxy(ShmInterface, func(ctx *Context) Proxy {
+		return NewShm(ctx)
+	})
+	RegisterProxy(BufferInterface, func(ctx *Context) Proxy {
+		return NewBuffer(ctx)
+	})
+	RegisterProxy(DataOfferInterface, func(ctx *Context) Proxy {
+		return NewDataOffer(ctx)
+	})
+	RegisterProxy(DataSourceInterface, func(ctx *Context) Proxy {
+		return NewDataSource(ctx)
+	})
+	RegisterProxy(DataDeviceInterface, func(ctx *Context) Proxy {
+		return NewDataDevice(ctx)
+	})
+	RegisterProxy(DataDeviceManagerInterface, func(ctx *Context) Proxy {
+		return NewDataDeviceManager(ctx)
+	})
+	RegisterProxy(ShellInterface, func(ctx *Context) Proxy {
+		return NewShell(ctx)
+	})
+	RegisterProxy(ShellSurfaceInterface, func(ctx *Context) Proxy {
+		return NewShellSurface(ctx)
+	})
+	RegisterProxy(SurfaceInterface, func(ctx *Context) Proxy {
+		return NewSurface(ctx)
+	})
+	RegisterProxy(SeatInterface, func(ctx *Context) Proxy {
+		return NewSeat(ctx)
+	})
+	RegisterProxy(PointerInterface, func(ctx *Context) Proxy {
+		return NewPointer(ctx)
+	})
+	RegisterProxy(KeyboardInterface, func(ctx *Context) Proxy {
+		return NewKeyboard(ctx)
+	})
+	RegisterProxy(TouchInterface, func(ctx *Context) Proxy {
+		return NewTouch(ctx)
+	})
+	RegisterProxy(OutputInterface, func(ctx *Context) Proxy {
+		return NewOutput(ctx)
+	})
+	RegisterProxy(RegionInterface, func(ctx *Context) Proxy {
+		return NewRegion(ctx)
+	})
+	RegisterProxy(SubcompositorInterface, func(ctx *Context) Proxy {
+		return NewSubcompositor(ctx)
+	})
+	RegisterProxy(SubsurfaceInterface, func(ctx *Context) Proxy {
+		return NewSubsurface(ctx)
+	})
+}
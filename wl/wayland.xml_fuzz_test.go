@@ -0,0 +1,218 @@
+// Code generated by go-wayland-scanner; DO NOT EDIT.
+// XML file: wayland.xml
+
+package wl
+
+import (
+	"testing"
+)
+
+// fuzzHandler handles every event of the protocol
+type fuzzHandler struct{}
+
+func (fuzzHandler) HandleDisplayError(DisplayErrorEvent)                                       {}
+func (fuzzHandler) HandleDisplayDeleteId(DisplayDeleteIdEvent)                                 {}
+func (fuzzHandler) HandleRegistryGlobal(RegistryGlobalEvent)                                   {}
+func (fuzzHandler) HandleRegistryGlobalRemove(RegistryGlobalRemoveEvent)                       {}
+func (fuzzHandler) HandleCallbackDone(CallbackDoneEvent)                                       {}
+func (fuzzHandler) HandleShmFormat(ShmFormatEvent)                                             {}
+func (fuzzHandler) HandleBufferRelease(BufferReleaseEvent)                                     {}
+func (fuzzHandler) HandleDataOfferOffer(DataOfferOfferEvent)                                   {}
+func (fuzzHandler) HandleDataOfferSourceActions(DataOfferSourceActionsEvent)                   {}
+func (fuzzHandler) HandleDataOfferAction(DataOfferActionEvent)                                 {}
+func (fuzzHandler) HandleDataSourceTarget(DataSourceTargetEvent)                               {}
+func (fuzzHandler) HandleDataSourceSend(DataSourceSendEvent)                                   {}
+func (fuzzHandler) HandleDataSourceCancelled(DataSourceCancelledEvent)                         {}
+func (fuzzHandler) HandleDataSourceDndDropPerformed(DataSourceDndDropPerformedEvent)           {}
+func (fuzzHandler) HandleDataSourceDndFinished(DataSourceDndFinishedEvent)                     {}
+func (fuzzHandler) HandleDataSourceAction(DataSourceActionEvent)                               {}
+func (fuzzHandler) HandleDataDeviceDataOffer(DataDeviceDataOfferEvent)                         {}
+func (fuzzHandler) HandleDataDeviceEnter(DataDeviceEnterEvent)                                 {}
+func (fuzzHandler) HandleDataDeviceLeave(DataDeviceLeaveEvent)                                 {}
+func (fuzzHandler) HandleDataDeviceMotion(DataDeviceMotionEvent)                               {}
+func (fuzzHandler) HandleDataDeviceDrop(DataDeviceDropEvent)                                   {}
+func (fuzzHandler) HandleDataDeviceSelection(DataDeviceSelectionEvent)                         {}
+func (fuzzHandler) HandleShellSurfacePing(ShellSurfacePingEvent)                               {}
+func (fuzzHandler) HandleShellSurfaceConfigure(ShellSurfaceConfigureEvent)                     {}
+func (fuzzHandler) HandleShellSurfacePopupDone(ShellSurfacePopupDoneEvent)                     {}
+func (fuzzHandler) HandleSurfaceEnter(SurfaceEnterEvent)                                       {}
+func (fuzzHandler) HandleSurfaceLeave(SurfaceLeaveEvent)                                       {}
+func (fuzzHandler) HandleSurfacePreferredBufferScale(SurfacePreferredBufferScaleEvent)         {}
+func (fuzzHandler) HandleSurfacePreferredBufferTransform(SurfacePreferredBufferTransformEvent) {}
+func (fuzzHandler) HandleSeatCapabilities(SeatCapabilitiesEvent)                               {}
+func (fuzzHandler) HandleSeatName(SeatNameEvent)                                               {}
+func (fuzzHandler) HandlePointerEnter(PointerEnterEvent)                                       {}
+func (fuzzHandler) HandlePointerLeave(PointerLeaveEvent)                                       {}
+func (fuzzHandler) HandlePointerMotion(PointerMotionEvent)                                     {}
+func (fuzzHandler) HandlePointerButton(PointerButtonEvent)                                     {}
+func (fuzzHandler) HandlePointerAxis(PointerAxisEvent)                                         {}
+func (fuzzHandler) HandlePointerFrame(PointerFrameEvent)                                       {}
+func (fuzzHandler) HandlePointerAxisSource(PointerAxisSourceEvent)                             {}
+func (fuzzHandler) HandlePointerAxisStop(PointerAxisStopEvent)                                 {}
+func (fuzzHandler) HandlePointerAxisDiscrete(PointerAxisDiscreteEvent)                         {}
+func (fuzzHandler) HandlePointerAxisValue120(PointerAxisValue120Event)                         {}
+func (fuzzHandler) HandlePointerAxisRelativeDirection(PointerAxisRelativeDirectionEvent)       {}
+func (fuzzHandler) HandleKeyboardKeymap(KeyboardKeymapEvent)                                   {}
+func (fuzzHandler) HandleKeyboardEnter(KeyboardEnterEvent)                                     {}
+func (fuzzHandler) HandleKeyboardLeave(KeyboardLeaveEvent)                                     {}
+func (fuzzHandler) HandleKeyboardKey(KeyboardKeyEvent)                                         {}
+func (fuzzHandler) HandleKeyboardModifiers(KeyboardModifiersEvent)                             {}
+func (fuzzHandler) HandleKeyboardRepeatInfo(KeyboardRepeatInfoEvent)                           {}
+func (fuzzHandler) HandleTouchDown(TouchDownEvent)                                             {}
+func (fuzzHandler) HandleTouchUp(TouchUpEvent)                                                 {}
+func (fuzzHandler) HandleTouchMotion(TouchMotionEvent)                                         {}
+func (fuzzHandler) HandleTouchFrame(TouchFrameEvent)                                           {}
+func (fuzzHandler) HandleTouchCancel(TouchCancelEvent)                                         {}
+func (fuzzHandler) HandleTouchShape(TouchShapeEvent)                                           {}
+func (fuzzHandler) HandleTouchOrientation(TouchOrientationEvent)                               {}
+func (fuzzHandler) HandleOutputGeometry(OutputGeometryEvent)                                   {}
+func (fuzzHandler) HandleOutputMode(OutputModeEvent)                                           {}
+func (fuzzHandler) HandleOutputDone(OutputDoneEvent)                                           {}
+func (fuzzHandler) HandleOutputScale(OutputScaleEvent)                                         {}
+func (fuzzHandler) HandleOutputName(OutputNameEvent)                                           {}
+func (fuzzHandler) HandleOutputDescription(OutputDescriptionEvent)                             {}
+
+// fuzzProxy is a proxy receiving the fuzzed events
+type fuzzProxy interface {
+	Dispatcher
+	Interface() *Interface
+}
+
+// FuzzDispatch decodes arbitrary event messages by the Dispatch of every interface with events
+func FuzzDispatch(f *testing.F) {
+	ctx, _ := socketpair(f)
+
+	var h fuzzHandler
+	var proxies []fuzzProxy
+	{
+		p := NewDisplay(ctx)
+		p.AddErrorHandler(h)
+		p.AddDeleteIdHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewRegistry(ctx)
+		p.AddGlobalHandler(h)
+		p.AddGlobalRemoveHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewCallback(ctx)
+		p.AddDoneHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewShm(ctx)
+		p.AddFormatHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewBuffer(ctx)
+		p.AddReleaseHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewDataOffer(ctx)
+		p.AddOfferHandler(h)
+		p.AddSourceActionsHandler(h)
+		p.AddActionHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewDataSource(ctx)
+		p.AddTargetHandler(h)
+		p.AddSendHandler(h)
+		p.AddCancelledHandler(h)
+		p.AddDndDropPerformedHandler(h)
+		p.AddDndFinishedHandler(h)
+		p.AddActionHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewDataDevice(ctx)
+		p.AddDataOfferHandler(h)
+		p.AddEnterHandler(h)
+		p.AddLeaveHandler(h)
+		p.AddMotionHandler(h)
+		p.AddDropHandler(h)
+		p.AddSelectionHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewShellSurface(ctx)
+		p.AddPingHandler(h)
+		p.AddConfigureHandler(h)
+		p.AddPopupDoneHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewSurface(ctx)
+		p.AddEnterHandler(h)
+		p.AddLeaveHandler(h)
+		p.AddPreferredBufferScaleHandler(h)
+		p.AddPreferredBufferTransformHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewSeat(ctx)
+		p.AddCapabilitiesHandler(h)
+		p.AddNameHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewPointer(ctx)
+		p.AddEnterHandler(h)
+		p.AddLeaveHandler(h)
+		p.AddMotionHandler(h)
+		p.AddButtonHandler(h)
+		p.AddAxisHandler(h)
+		p.AddFrameHandler(h)
+		p.AddAxisSourceHandler(h)
+		p.AddAxisStopHandler(h)
+		p.AddAxisDiscreteHandler(h)
+		p.AddAxisValue120Handler(h)
+		p.AddAxisRelativeDirectionHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewKeyboard(ctx)
+		p.AddKeymapHandler(h)
+		p.AddEnterHandler(h)
+		p.AddLeaveHandler(h)
+		p.AddKeyHandler(h)
+		p.AddModifiersHandler(h)
+		p.AddRepeatInfoHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewTouch(ctx)
+		p.AddDownHandler(h)
+		p.AddUpHandler(h)
+		p.AddMotionHandler(h)
+		p.AddFrameHandler(h)
+		p.AddCancelHandler(h)
+		p.AddShapeHandler(h)
+		p.AddOrientationHandler(h)
+		proxies = append(proxies, p)
+	}
+	{
+		p := NewOutput(ctx)
+		p.AddGeometryHandler(h)
+		p.AddModeHandler(h)
+		p.AddDoneHandler(h)
+		p.AddScaleHandler(h)
+		p.AddNameHandler(h)
+		p.AddDescriptionHandler(h)
+		proxies = append(proxies, p)
+	}
+
+	for i, p := range proxies {
+		for opcode := range p.Interface().Events {
+			f.Add(uint8(i), uint16(opcode), make([]byte, 64))
+		}
+	}
+	f.Fuzz(func(t *testing.T, index uint8, opcode uint16, data []byte) {
+		p := proxies[int(index)%len(proxies)]
+		p.Dispatch(&Event{Opcode: uint32(opcode), Data: data})
+	})
+}
@@ -0,0 +1,115 @@
+package wl_test
+
+import (
+	"testing"
+
+	"github.com/neurlang/wayland/wl"
+)
+
+// TestZombieEvents sends events to a keyboard the client has released before the compositor noticed,
+// they are dropped along with their fds, and the id is reused once freed by delete_id
+func TestZombieEvents(t *testing.T) {
+	c := newConcurrentClient(t)
+	released, err := c.seat.GetKeyboard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var releasedSizes keymapSizes
+	released.AddKeymapHandler(&releasedSizes)
+	keyboard, err := c.seat.GetKeyboard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sizes keymapSizes
+	keyboard.AddKeymapHandler(&sizes)
+	c.roundtrip(t)
+
+	id := released.Id()
+	if err := released.Release(); err != nil {
+		t.Fatal(err)
+	}
+	// in flight while the release is not flushed yet
+	sendFile(t, c.srv, id, 0, 100)
+	sendFile(t, c.srv, keyboard.Id(), 0, 200)
+	c.roundtrip(t)
+
+	if len(releasedSizes) != 0 {
+		t.Errorf("the released keyboard received %d keymaps", len(releasedSizes))
+	}
+	if len(sizes) != 1 || sizes[0] != 200 {
+		t.Errorf("keymaps of %v bytes, want [200]", sizes)
+	}
+
+	// the freed ids are reused, the most recently freed first like libwayland
+	var ids []wl.ProxyId
+	for i := 0; i < 3; i++ {
+		region, err := c.compositor.CreateRegion()
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, region.Id())
+		if region.Id() == id {
+			break
+		}
+	}
+	if ids[len(ids)-1] != id {
+		t.Errorf("new region ids %v, want the freed id %d", ids, id)
+	}
+	c.roundtrip(t)
+}
+
+type offers struct {
+	offers []*wl.DataOffer
+	mimes  []string
+}
+
+func (o *offers) HandleDataDeviceDataOffer(ev wl.DataDeviceDataOfferEvent) {
+	o.offers = append(o.offers, ev.Offer)
+	ev.Offer.AddOfferHandler(o)
+}
+
+func (o *offers) HandleDataOfferOffer(ev wl.DataOfferOfferEvent) {
+	o.mimes = append(o.mimes, ev.MimeType)
+}
+
+// TestServerAllocatedIds creates a data offer by an event, its own events are dispatched to it
+func TestServerAllocatedIds(t *testing.T) {
+	c := newConcurrentClient(t)
+	c.srv.AddGlobal("wl_data_device_manager", 3)
+	registry, err := c.display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	globals := wl.NewGlobalTracker(registry)
+	c.roundtrip(t)
+	p, err := globals.Bind("wl_data_device_manager", 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	device, err := p.(*wl.DataDeviceManager).GetDataDevice(c.seat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var o offers
+	device.AddDataOfferHandler(&o)
+	c.roundtrip(t)
+
+	const offerId = 0xff000000
+	if err := c.srv.SendEvent(device.Id(), 0, wl.ProxyId(offerId)); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.srv.SendEvent(offerId, 0, "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+	c.roundtrip(t)
+
+	if len(o.offers) != 1 || o.offers[0].Id() != offerId {
+		t.Fatalf("offers %v, want one with the id %#x", o.offers, offerId)
+	}
+	if len(o.mimes) != 1 || o.mimes[0] != "text/plain" {
+		t.Errorf("offered %v, want [text/plain]", o.mimes)
+	}
+	if v := o.offers[0].Version(); v != 3 {
+		t.Errorf("offer version %d, want the version 3 of its data device", v)
+	}
+}
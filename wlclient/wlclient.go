@@ -1,6 +1,8 @@
 // Package wlclient implements a wayland-client like api
 package wlclient
 
+import "context"
+
 import "github.com/neurlang/wayland/wl"
 import "github.com/neurlang/wayland/xdg"
 import "github.com/neurlang/wayland/unstable"
@@ -71,6 +73,10 @@ func OutputAddListener(o *wl.Output, h OutputListener) {
 	o.AddDoneHandler(h)
 	o.AddScaleHandler(h)
 }
+func OutputDestroy(p *wl.Output) {
+	//p.Destroy()
+	p.Unregister()
+}
 
 type SeatListener interface {
 	wl.SeatCapabilitiesHandler
@@ -317,6 +323,9 @@ func DisplayGetRegistry(d *wl.Display) (*wl.Registry, error) {
 }
 
 func DisplayRun(d *wl.Display) error {
+	if err := d.Context().Flush(); err != nil {
+		return err
+	}
 	return d.Context().Run()
 }
 func DisplayRoundtrip(d *wl.Display) error {
@@ -324,9 +333,43 @@ func DisplayRoundtrip(d *wl.Display) error {
 	if err != nil {
 		return err
 	}
+	defer cb.Unregister()
+	if err = d.Context().Flush(); err != nil {
+		return err
+	}
 	err = d.Context().RunTill(cb)
 	return err
 }
+func DisplayRoundtripContext(ctx context.Context, d *wl.Display) error {
+	cb, err := d.Sync()
+	if err != nil {
+		return err
+	}
+	defer cb.Unregister()
+	if err = d.Context().Flush(); err != nil {
+		return err
+	}
+	err = d.Context().RunTillContext(ctx, cb)
+	return err
+}
+func DisplayFlush(d *wl.Display) error {
+	return d.Context().Flush()
+}
+func DisplayGetFd(d *wl.Display) (int, error) {
+	return d.Context().Fd()
+}
+func DisplayPrepareRead(d *wl.Display) error {
+	return d.Context().PrepareRead()
+}
+func DisplayReadEvents(d *wl.Display) error {
+	return d.Context().ReadEvents()
+}
+func DisplayCancelRead(d *wl.Display) {
+	d.Context().CancelRead()
+}
+func DisplayDispatchPending(d *wl.Display) (int, error) {
+	return d.Context().DispatchPending()
+}
 func DisplayDisconnect(display *wl.Display) {
 	display.Context().Close()
 }
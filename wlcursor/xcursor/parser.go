@@ -3,6 +3,7 @@ package xcursor
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 
 	"github.com/neurlang/wayland/external/swizzle"
 )
@@ -19,53 +20,96 @@ type Image struct {
 	Width    uint32
 }
 
+// ErrTruncated is returned when the cursor data ends in the middle of a header, table of contents
+// or image
+var ErrTruncated = errors.New("xcursor: truncated data")
+
+// ErrBadMagic is returned when the cursor data does not start with "Xcur"
+var ErrBadMagic = errors.New("xcursor: bad magic")
+
+// ErrImageTooLarge is returned when an image is larger than the Xcursor format allows
+var ErrImageTooLarge = errors.New("xcursor: image too large")
+
+// ErrNoImages is returned when the cursor data contains no images
+var ErrNoImages = errors.New("xcursor: no images")
+
+// maxImageSize is the maximum width and height of an image, as libXcursor limits it
+const maxImageSize = 0x7fff
+
+// tocSize is the size of a table of contents entry
+const tocSize = 12
+
 type toc struct {
 	toctype uint32
 	subtype uint32
 	pos     uint32
 }
 
-func parseHeader(buf *bytes.Buffer) uint32 {
-	buf.Next(4) // skip "Xcur"
-
-	buf.Next(4)
-	buf.Next(4)
-	nToc := binary.LittleEndian.Uint32(buf.Next(4))
+// next reads the next n bytes of buf, failing with ErrTruncated when there are not enough bytes
+func next(buf *bytes.Buffer, n int) ([]byte, error) {
+	if buf.Len() < n {
+		return nil, ErrTruncated
+	}
+	return buf.Next(n), nil
+}
 
-	return nToc
+// uint32s reads the next little endian uint32 values of buf into vs
+func uint32s(buf *bytes.Buffer, vs ...*uint32) error {
+	for _, v := range vs {
+		b, err := next(buf, 4)
+		if err != nil {
+			return err
+		}
+		*v = binary.LittleEndian.Uint32(b)
+	}
+	return nil
 }
 
-func parseToc(buf *bytes.Buffer) toc {
-	tocType := binary.LittleEndian.Uint32(buf.Next(4))
-	subType := binary.LittleEndian.Uint32(buf.Next(4))
-	pos := binary.LittleEndian.Uint32(buf.Next(4))
+func parseHeader(buf *bytes.Buffer) (uint32, error) {
+	magic, err := next(buf, 4)
+	if err != nil {
+		return 0, err
+	}
+	if string(magic) != "Xcur" {
+		return 0, ErrBadMagic
+	}
 
-	return toc{
-		toctype: tocType,
-		subtype: subType,
-		pos:     pos,
+	var headerSize, version, nToc uint32
+	if err := uint32s(buf, &headerSize, &version, &nToc); err != nil {
+		return 0, err
 	}
+
+	// every toc entry takes 12 bytes, do not trust a count the data cannot hold
+	if uint64(nToc)*tocSize > uint64(buf.Len()) {
+		return 0, ErrTruncated
+	}
+	return nToc, nil
+}
+
+func parseToc(buf *bytes.Buffer) (t toc, err error) {
+	err = uint32s(buf, &t.toctype, &t.subtype, &t.pos)
+	return t, err
 }
 
 func parseImg(b []byte) (*Image, error) {
 	buf := bytes.NewBuffer(b)
-	buf.Next(8) // skip header (header size, type)
-	size := binary.LittleEndian.Uint32(buf.Next(4))
-	buf.Next(4) // skip image version
-	width := binary.LittleEndian.Uint32(buf.Next(4))
-	height := binary.LittleEndian.Uint32(buf.Next(4))
-	hotspotX := binary.LittleEndian.Uint32(buf.Next(4))
-	hotspotY := binary.LittleEndian.Uint32(buf.Next(4))
-	delay := binary.LittleEndian.Uint32(buf.Next(4))
-
-	imageLength := 4 * width * height
-	pixRGBA := make([]uint8, imageLength)
-	_, err := buf.Read(pixRGBA)
+	var headerSize, imgType, size, version, width, height, hotspotX, hotspotY, delay uint32
+	err := uint32s(buf, &headerSize, &imgType, &size, &version, &width, &height, &hotspotX, &hotspotY, &delay)
+	if err != nil {
+		return nil, err
+	}
+	if width > maxImageSize || height > maxImageSize {
+		return nil, ErrImageTooLarge
+	}
+
+	pix, err := next(buf, int(4*width*height))
 	if err != nil {
 		return nil, err
 	}
+	pixRGBA := make([]uint8, len(pix))
+	copy(pixRGBA, pix)
 
-	pixBGRA := make([]uint8, imageLength)
+	pixBGRA := make([]uint8, len(pix))
 	copy(pixBGRA, pixRGBA)
 	swizzle.BGRA(pixBGRA)
 
@@ -81,24 +125,36 @@ func parseImg(b []byte) (*Image, error) {
 	}, nil
 }
 
-// ParseXcursor parses X cursor data
+// ParseXcursor parses X cursor data, it returns the images of the data in order. Malformed data is
+// reported by an error, it never makes ParseXcursor panic or allocate more than the data holds.
 func ParseXcursor(content []byte) (imgs []*Image, err error) {
 	buf := bytes.NewBuffer(content)
-	ntoc := parseHeader(buf)
-	imgs = make([]*Image, ntoc)
+	ntoc, err := parseHeader(buf)
+	if err != nil {
+		return nil, err
+	}
 
 	for i := uint32(0); i < ntoc; i++ {
-		toc := parseToc(buf)
+		toc, err := parseToc(buf)
+		if err != nil {
+			return nil, err
+		}
 
 		if toc.toctype == 0xfffd_0002 {
 			index := toc.pos
-			img, err1 := parseImg(content[index:])
-			if err1 != nil {
-				err = err1
+			if uint64(index) >= uint64(len(content)) {
+				return nil, ErrTruncated
+			}
+			img, err := parseImg(content[index:])
+			if err != nil {
+				return nil, err
 			}
-			imgs[i] = img
+			imgs = append(imgs, img)
 		}
 	}
 
-	return imgs, err
+	if len(imgs) == 0 {
+		return nil, ErrNoImages
+	}
+	return imgs, nil
 }
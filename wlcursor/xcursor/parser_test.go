@@ -0,0 +1,42 @@
+package xcursor
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+// testCursor returns the data of a cursor with a single image of the size
+func testCursor(width, height uint32) []byte {
+	var b []byte
+	put := func(vs ...uint32) {
+		for _, v := range vs {
+			var u [4]byte
+			binary.LittleEndian.PutUint32(u[:], v)
+			b = append(b, u[:]...)
+		}
+	}
+	b = append(b, "Xcur"...)
+	put(16, 0x10000, 1)
+	put(0xfffd_0002, 24, 28)
+	put(36, 0xfffd_0002, 24, 1, width, height, 1, 1, 50)
+	b = append(b, make([]byte, 4*width*height)...)
+	return b
+}
+
+// FuzzParseXcursor parses arbitrary cursor data
+func FuzzParseXcursor(f *testing.F) {
+	f.Add(testCursor(2, 2))
+	f.Add(testCursor(0, 0))
+	f.Add([]byte("Xcur"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		imgs, err := ParseXcursor(data)
+		if err != nil {
+			return
+		}
+		for _, img := range imgs {
+			if len(img.PixRGBA) != int(4*img.Width*img.Height) {
+				t.Fatalf("%dx%d image has %d bytes", img.Width, img.Height, len(img.PixRGBA))
+			}
+		}
+	})
+}
@@ -0,0 +1,336 @@
+// Package wlreplay replays the compositor side of a protocol recording against a client
+//
+// A recording is made by wl.Context SetRecorder, or by running the client with the
+// WAYLAND_RECORD environment variable naming the file. Replay sends the recorded events
+// to the client connected on conn, each one once the client has sent all the requests
+// recorded before it, and checks that the client sends the recorded requests. The fds
+// of the events are replaced by files holding the recorded contents.
+//
+// Clients are expected to behave deterministically, so a recording attached to a bug
+// report can be replayed as a regression test, for example against a window.Display
+// created by window.DisplayCreateFrom on one end of a socketpair.
+package wlreplay
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"time"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wl"
+	"github.com/yalue/native_endian"
+)
+
+// ErrClientDisconnected is returned by Replay when the client closes the connection while
+// a recorded request is expected
+var ErrClientDisconnected = errors.New("client disconnected")
+
+// ErrMalformedRequest is returned by Replay when the client sent a message that cannot be parsed
+var ErrMalformedRequest = errors.New("malformed request")
+
+// ErrMalformedRecording is returned by Replay when the recording ends in the middle of a message
+// or a message is not followed by its fd placeholders
+var ErrMalformedRecording = errors.New("malformed recording")
+
+// MismatchError is returned by Replay when the client sends a request other than the recorded one
+type MismatchError struct {
+	// Index is the number of the request in the recording, starting at 0
+	Index int
+	// Want and Got describe the recorded and the received request, such as "wl_surface@5.commit"
+	Want, Got string
+}
+
+func (e *MismatchError) Error() string {
+	if e.Want == e.Got {
+		return fmt.Sprintf("request %d: %s sent with different arguments", e.Index, e.Got)
+	}
+	return fmt.Sprintf("request %d: want %s, got %s", e.Index, e.Want, e.Got)
+}
+
+// Replayer replays recordings, the zero value is ready to use
+type Replayer struct {
+	// Timeout is the time to wait for every recorded request, 5 seconds when zero
+	Timeout time.Duration
+	// Strict makes the arguments of the requests compared too, not only their objects and opcodes
+	Strict bool
+	// Log receives a line for every replayed message when it is not nil
+	Log io.Writer
+}
+
+// Replay replays the recording against the client connected on conn, see the package documentation
+func Replay(conn *net.UnixConn, recording io.Reader) error {
+	var p Replayer
+	return p.Replay(conn, recording)
+}
+
+// replay is the state of a single Replay
+type replay struct {
+	*Replayer
+	conn     *net.UnixConn
+	records  *wl.RecordReader
+	objects  map[wl.ProxyId]*wl.Interface
+	pending  []byte
+	requests int
+}
+
+// Replay (Replayer Replay) replays the recording against the client connected on conn
+func (p *Replayer) Replay(conn *net.UnixConn, recording io.Reader) error {
+	records, err := wl.NewRecordReader(recording)
+	if err != nil {
+		return err
+	}
+	r := &replay{
+		Replayer: p,
+		conn:     conn,
+		records:  records,
+		objects:  map[wl.ProxyId]*wl.Interface{1: wl.DisplayInterface},
+	}
+	for {
+		rec, err := records.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch rec.Kind {
+		case wl.RecordRequest:
+			err = r.request(rec)
+		case wl.RecordEvent:
+			err = r.event(rec)
+		case wl.RecordShm:
+			r.logf("shm buffer %d: %d bytes\n", rec.Id, len(rec.Data))
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (r *replay) logf(format string, args ...interface{}) {
+	if r.Log != nil {
+		fmt.Fprintf(r.Log, format, args...)
+	}
+}
+
+// placeholders returns the contents of the fds of a message
+func (r *replay) placeholders(rec *wl.Record) ([][]byte, error) {
+	var files [][]byte
+	for i := 0; i < rec.Fds; i++ {
+		fd, err := r.records.Next()
+		if err == io.EOF || (err == nil && fd.Kind != wl.RecordFd) {
+			return nil, ErrMalformedRecording
+		}
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, fd.Data)
+	}
+	return files, nil
+}
+
+// request waits for the client to send the recorded request and compares them
+func (r *replay) request(rec *wl.Record) error {
+	if _, err := r.placeholders(rec); err != nil {
+		return err
+	}
+	want, ok := parse(rec.Data)
+	if !ok {
+		return ErrMalformedRecording
+	}
+	got, err := r.read()
+	if err != nil {
+		return err
+	}
+	index := r.requests
+	r.requests++
+	r.logf("%s\n", r.describe(got, true))
+	if got.pid != want.pid || got.opcode != want.opcode ||
+		(r.Strict && string(got.data) != string(want.data)) {
+		return &MismatchError{index, r.describe(want, true), r.describe(got, true)}
+	}
+	r.track(want, true)
+	return nil
+}
+
+// event sends the recorded event together with files holding the recorded contents of its fds
+func (r *replay) event(rec *wl.Record) error {
+	files, err := r.placeholders(rec)
+	if err != nil {
+		return err
+	}
+	ev, ok := parse(rec.Data)
+	if !ok {
+		return ErrMalformedRecording
+	}
+	var fds []int
+	defer func() {
+		for _, fd := range fds {
+			sys.Close(fd)
+		}
+	}()
+	for _, data := range files {
+		fd, err := placeholder(data)
+		if err != nil {
+			return err
+		}
+		fds = append(fds, fd)
+	}
+	var oob []byte
+	if len(fds) > 0 {
+		oob = sys.UnixRights(fds...)
+	}
+	r.logf("  -> %s\n", r.describe(ev, false))
+	if _, _, err := r.conn.WriteMsgUnix(rec.Data, oob, nil); err != nil {
+		return err
+	}
+	r.track(ev, false)
+	return nil
+}
+
+// placeholder returns the fd of an unlinked file holding the data
+func placeholder(data []byte) (int, error) {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	f, err := sys.CreateTmpfileCloexec(dir+string(os.PathSeparator), "go-wayland-replay-XXXXXX")
+	if err != nil && err != sys.ErrUnlink {
+		return -1, err
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		return -1, err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return -1, err
+	}
+	return sys.DupCloexec(int(f.Fd()))
+}
+
+// message is a parsed protocol message
+type message struct {
+	pid    wl.ProxyId
+	opcode uint32
+	data   []byte
+}
+
+func parse(msg []byte) (message, bool) {
+	if len(msg) < 8 {
+		return message{}, false
+	}
+	return message{
+		pid:    wl.ProxyId(native_endian.NativeEndian().Uint32(msg[0:4])),
+		opcode: uint32(native_endian.NativeEndian().Uint16(msg[4:6])),
+		data:   msg[8:],
+	}, true
+}
+
+// read returns the next request of the client, the fds it sent are closed
+func (r *replay) read() (message, error) {
+	timeout := r.Timeout
+	if timeout == 0 {
+		timeout = 5 * time.Second
+	}
+	if err := r.conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return message{}, err
+	}
+	buf := make([]byte, 4096)
+	control := make([]byte, 4096)
+	for {
+		if len(r.pending) >= 8 {
+			size := int(native_endian.NativeEndian().Uint16(r.pending[6:8]))
+			if size < 8 || size&3 != 0 {
+				return message{}, ErrMalformedRequest
+			}
+			if len(r.pending) >= size {
+				msg, _ := parse(append([]byte(nil), r.pending[:size]...))
+				r.pending = r.pending[size:]
+				return msg, nil
+			}
+		}
+		n, oobn, _, _, err := r.conn.ReadMsgUnix(buf, control)
+		if oobn > 0 {
+			closeRights(control[:oobn])
+		}
+		if n == 0 && err == nil || err == io.EOF {
+			return message{}, ErrClientDisconnected
+		}
+		if err != nil {
+			return message{}, err
+		}
+		r.pending = append(r.pending, buf[:n]...)
+	}
+}
+
+// closeRights closes the fds of the control messages
+func closeRights(control []byte) {
+	scms, err := sys.ParseSocketControlMessage(control)
+	if err != nil {
+		return
+	}
+	for i := range scms {
+		fds, err := sys.ParseUnixRights(&scms[i])
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			sys.Close(fd)
+		}
+	}
+}
+
+// describe names the message like "wl_surface@5.commit"
+func (r *replay) describe(m message, request bool) string {
+	iface := r.objects[m.pid]
+	if iface == nil {
+		return fmt.Sprintf("[unknown]@%d.opcode %d", m.pid, m.opcode)
+	}
+	msgs := iface.Events
+	if request {
+		msgs = iface.Requests
+	}
+	if int(m.opcode) >= len(msgs) {
+		return fmt.Sprintf("%s@%d.opcode %d", iface.Name, m.pid, m.opcode)
+	}
+	return fmt.Sprintf("%s@%d.%s", iface.Name, m.pid, msgs[m.opcode].Name)
+}
+
+// track records the interfaces of the objects created by the message
+func (r *replay) track(m message, request bool) {
+	iface := r.objects[m.pid]
+	if iface == nil {
+		return
+	}
+	msgs := iface.Events
+	if request {
+		msgs = iface.Requests
+	}
+	if int(m.opcode) >= len(msgs) {
+		return
+	}
+	ev := &wl.Event{Data: m.data}
+	var name string
+	for _, a := range msgs[m.opcode].Args {
+		switch a.Type {
+		case wl.ArgInt, wl.ArgUint, wl.ArgFixed, wl.ArgObject:
+			ev.Uint32()
+		case wl.ArgString:
+			// the interface of a new_id without a specific interface
+			name = ev.String()
+		case wl.ArgArray:
+			ev.Array()
+		case wl.ArgNewId:
+			id := wl.ProxyId(ev.Uint32())
+			if a.Interface != "" {
+				name = a.Interface
+			}
+			if id != 0 && ev.Err() == nil {
+				r.objects[id] = wl.LookupInterface(name)
+			}
+		}
+	}
+}
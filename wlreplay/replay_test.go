@@ -0,0 +1,128 @@
+package wlreplay_test
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	stdos "os"
+	"strings"
+	"testing"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wlclient"
+	"github.com/neurlang/wayland/wlreplay"
+	"github.com/neurlang/wayland/wltest"
+)
+
+// client is a deterministic client drawing a surface, created is the request creating
+// the object drawn on, create_surface or create_region
+func client(display *wl.Display, created string) error {
+	registry, err := display.GetRegistry()
+	if err != nil {
+		return err
+	}
+	globals := wl.NewGlobalTracker(registry)
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		return err
+	}
+	p, err := globals.Bind("wl_compositor", 1, 4)
+	if err != nil {
+		return err
+	}
+	compositor := p.(*wl.Compositor)
+	if created == "create_region" {
+		if _, err := compositor.CreateRegion(); err != nil {
+			return err
+		}
+	} else {
+		surface, err := compositor.CreateSurface()
+		if err != nil {
+			return err
+		}
+		if err := surface.Commit(); err != nil {
+			return err
+		}
+	}
+	return wlclient.DisplayRoundtrip(display)
+}
+
+// record runs the client against the fake compositor and returns the recording
+func record(t *testing.T) []byte {
+	display, srv, err := wltest.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+	srv.AddGlobal("wl_compositor", 4)
+	srv.AddGlobal("wl_shm", 1)
+
+	var recording bytes.Buffer
+	if err := display.Context().SetRecorder(&recording); err != nil {
+		t.Fatal(err)
+	}
+	if err := client(display, "create_surface"); err != nil {
+		t.Fatal(err)
+	}
+	if err := display.Context().SetRecorder(nil); err != nil {
+		t.Fatal(err)
+	}
+	display.Context().Close()
+	return recording.Bytes()
+}
+
+func unixConn(t *testing.T, fd int) *net.UnixConn {
+	f := stdos.NewFile(uintptr(fd), "socketpair")
+	defer f.Close()
+	conn, err := net.FileConn(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn.(*net.UnixConn)
+}
+
+// replay runs the client against the replayed recording, it returns the errors of the replay and of the client
+func replay(t *testing.T, recording []byte, created string) (replayErr, clientErr error) {
+	fds, err := sys.Socketpair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := unixConn(t, fds[0])
+	defer server.Close()
+	display, err := wl.ConnectConn(unixConn(t, fds[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer display.Context().Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- client(display, created)
+	}()
+	replayErr = wlreplay.Replay(server, bytes.NewReader(recording))
+	server.Close()
+	return replayErr, <-done
+}
+
+func TestRecordReplay(t *testing.T) {
+	recording := record(t)
+	replayErr, clientErr := replay(t, recording, "create_surface")
+	if replayErr != nil {
+		t.Fatal(replayErr)
+	}
+	if clientErr != nil {
+		t.Fatal(clientErr)
+	}
+}
+
+func TestReplayMismatch(t *testing.T) {
+	recording := record(t)
+	replayErr, _ := replay(t, recording, "create_region")
+	var mismatch *wlreplay.MismatchError
+	if !errors.As(replayErr, &mismatch) {
+		t.Fatalf("replay returned %v, want a MismatchError", replayErr)
+	}
+	if !strings.HasSuffix(mismatch.Want, ".create_surface") || !strings.HasSuffix(mismatch.Got, ".create_region") {
+		t.Errorf("mismatch %v, want create_surface replaced by create_region", mismatch)
+	}
+}
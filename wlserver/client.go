@@ -0,0 +1,535 @@
+package wlserver
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wl"
+	"github.com/yalue/native_endian"
+)
+
+// ErrInvalidObject is returned when a request refers to an object that does not exist
+// or has a wrong interface, the client gets the wl_display.error invalid_object
+var ErrInvalidObject = errors.New("invalid object")
+
+// ErrInvalidMethod is returned when a request cannot be decoded, the client gets the
+// wl_display.error invalid_method
+var ErrInvalidMethod = errors.New("invalid method")
+
+// ErrClientClosed is returned when sending an event to a disconnected client
+var ErrClientClosed = errors.New("client closed")
+
+// ErrInvalidEventArgument is returned by SendEvent for unsupported argument types
+var ErrInvalidEventArgument = errors.New("invalid Wayland event argument type")
+
+// ErrClientBufferFull is returned by SendEvent when the events buffered for a client that is not
+// reading them exceed maxBufferedEvents, the client is disconnected
+var ErrClientBufferFull = errors.New("client buffer full")
+
+// displayId is the id of the wl_display of every client
+const displayId = 1
+
+// serverIdStart is the first id of the objects created by the server
+const serverIdStart = 0xff000000
+
+// maxFdsPerMsg is the maximum number of fds received with a single recvmsg
+const maxFdsPerMsg = 28
+
+// maxBufferedEvents is the size of the events buffered for a client, in bytes
+const maxBufferedEvents = 1 << 20
+
+// closeTimeout is the time a disconnected client has to read the events buffered for it
+const closeTimeout = time.Second
+
+// Client is a client connected to the Server
+type Client struct {
+	server    *Server
+	conn      *net.UnixConn
+	display   *Display
+	objects   map[wl.ProxyId]Resource
+	zombies   map[wl.ProxyId]*wl.Interface
+	nextId    wl.ProxyId
+	in        []byte
+	fds       []int
+	dead      bool
+	closed    bool
+	onDestroy []func()
+
+	// the events are buffered and written by another goroutine, so that a client that is
+	// not reading does not block the server
+	wmu     sync.Mutex
+	wcond   *sync.Cond
+	out     []event
+	outSize int
+	wclosed bool
+}
+
+// event is an encoded event buffered for the writer, the fds are owned by it
+type event struct {
+	msg []byte
+	fds []int
+}
+
+func newClient(s *Server, conn *net.UnixConn) *Client {
+	c := &Client{
+		server:  s,
+		conn:    conn,
+		objects: make(map[wl.ProxyId]Resource),
+		zombies: make(map[wl.ProxyId]*wl.Interface),
+		nextId:  serverIdStart,
+	}
+	c.wcond = sync.NewCond(&c.wmu)
+	c.display = new(Display)
+	c.display.SetHandler(displayHandler{})
+	c.NewId(c.display, displayId, 1)
+	return c
+}
+
+// Server (Client Server) returns the server the client is connected to
+func (c *Client) Server() *Server {
+	return c.server
+}
+
+// Display (Client Display) returns the wl_display of the client
+func (c *Client) Display() *Display {
+	return c.display
+}
+
+// Conn (Client Conn) returns the connection of the client
+func (c *Client) Conn() *net.UnixConn {
+	return c.conn
+}
+
+// Resource (Client Resource) returns the resource of the client with the id, or nil
+func (c *Client) Resource(id wl.ProxyId) Resource {
+	return c.objects[id]
+}
+
+// Argument (Client Argument) returns the resource an object argument refers to. The resource
+// must be of the interface, unless it is nil, and the id may be 0 only when nullable.
+func (c *Client) Argument(id uint32, nullable bool, iface *wl.Interface) (Resource, error) {
+	if id == 0 {
+		if nullable {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: null object argument", ErrInvalidMethod)
+	}
+	r := c.objects[wl.ProxyId(id)]
+	if r == nil {
+		return nil, fmt.Errorf("%w %d", ErrInvalidObject, id)
+	}
+	if iface != nil && (r.Interface() == nil || r.Interface().Name != iface.Name) {
+		return nil, fmt.Errorf("%w %d: not a %s", ErrInvalidObject, id, iface.Name)
+	}
+	return r, nil
+}
+
+// NewId (Client NewId) adds the resource created by the client with the id
+func (c *Client) NewId(r Resource, id uint32, version uint32) error {
+	pid := wl.ProxyId(id)
+	if id == 0 || pid >= serverIdStart {
+		return fmt.Errorf("%w: invalid new id %d", ErrInvalidObject, id)
+	}
+	if c.objects[pid] != nil {
+		return fmt.Errorf("%w: id %d already in use", ErrInvalidObject, id)
+	}
+	c.add(r, pid, version)
+	return nil
+}
+
+// NewResource (Client NewResource) adds the resource created by the server, with an id of the
+// server range
+func (c *Client) NewResource(r Resource, version uint32) {
+	for c.objects[c.nextId] != nil || c.zombies[c.nextId] != nil {
+		c.nextId++
+		if c.nextId == 0 {
+			c.nextId = serverIdStart
+		}
+	}
+	c.add(r, c.nextId, version)
+	c.nextId++
+	if c.nextId == 0 {
+		c.nextId = serverIdStart
+	}
+}
+
+func (c *Client) add(r Resource, id wl.ProxyId, version uint32) {
+	b := r.base()
+	b.client = c
+	b.id = id
+	b.version = version
+	delete(c.zombies, id)
+	c.objects[id] = r
+}
+
+// Destroy (Client Destroy) destroys the resource. The client is told the id of an object it
+// created can be reused by wl_display.delete_id; the requests it sends to an object created
+// by the server before it learns about the destruction are ignored.
+func (c *Client) Destroy(r Resource) {
+	b := r.base()
+	if b.destroyed || b.client != c {
+		return
+	}
+	b.destroyed = true
+	if c.objects[b.id] == r {
+		delete(c.objects, b.id)
+	}
+	for i := len(b.onDestroy) - 1; i >= 0; i-- {
+		b.onDestroy[i]()
+	}
+	b.onDestroy = nil
+	if c.closed {
+		return
+	}
+	if b.id < serverIdStart {
+		c.display.SendDeleteId(uint32(b.id))
+	} else {
+		c.zombies[b.id] = r.Interface()
+	}
+}
+
+// SendEvent (Client SendEvent) sends an event of the resource with the arguments to the client.
+// The arguments are encoded the same way as by wl.Request Write, object arguments are Resources
+// or nil. The fds are duplicated, so the caller may close them. The event is buffered and written
+// once the socket is writable, a client that lets the buffer grow over maxBufferedEvents is
+// disconnected.
+func (c *Client) SendEvent(r Resource, opcode uint32, args ...interface{}) error {
+	req := wl.NewRequest(r.Id(), opcode)
+	for _, arg := range args {
+		switch t := arg.(type) {
+		case nil:
+			req.PutUint32(0)
+		case Resource:
+			if v := reflect.ValueOf(t); v.Kind() == reflect.Ptr && v.IsNil() {
+				req.PutUint32(0)
+			} else {
+				req.PutUint32(uint32(t.Id()))
+			}
+		case wl.ProxyId:
+			req.PutUint32(uint32(t))
+		default:
+			if err := req.Write(arg); err != nil {
+				return ErrInvalidEventArgument
+			}
+		}
+	}
+	msg, reqFds := req.Message()
+	var fds []int
+	for _, fd := range reqFds {
+		dup, err := sys.DupCloexec(fd)
+		if err != nil {
+			closeFds(fds)
+			return err
+		}
+		fds = append(fds, dup)
+	}
+
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	if c.wclosed {
+		closeFds(fds)
+		return ErrClientClosed
+	}
+	if c.outSize+len(msg) > maxBufferedEvents {
+		closeFds(fds)
+		c.server.logf("client buffer full, disconnecting")
+		c.stopWriting()
+		// the buffered events are dropped, the client is gone
+		c.conn.Close()
+		c.server.Invoke(func() {
+			c.Close()
+		})
+		return ErrClientBufferFull
+	}
+	c.out = append(c.out, event{msg, fds})
+	c.outSize += len(msg)
+	c.wcond.Signal()
+	return nil
+}
+
+// stopWriting makes SendEvent fail, the writer closes the connection once the buffered events
+// are written. c.wmu must be held.
+func (c *Client) stopWriting() {
+	if !c.wclosed {
+		c.wclosed = true
+		c.wcond.Signal()
+	}
+}
+
+// write writes the buffered events in order until the client is closed
+func (c *Client) write() {
+	defer c.conn.Close()
+	for {
+		c.wmu.Lock()
+		for len(c.out) == 0 && !c.wclosed {
+			c.wcond.Wait()
+		}
+		out := c.out
+		c.out = nil
+		c.outSize = 0
+		c.wmu.Unlock()
+		if len(out) == 0 {
+			return
+		}
+
+		for i, e := range out {
+			if err := c.writeEvent(e); err != nil {
+				for _, e := range out[i:] {
+					closeFds(e.fds)
+				}
+				c.wmu.Lock()
+				c.stopWriting()
+				for _, e := range c.out {
+					closeFds(e.fds)
+				}
+				c.out = nil
+				c.wmu.Unlock()
+				return
+			}
+		}
+	}
+}
+
+// writeEvent writes an event, the fds are sent with its first byte and closed
+func (c *Client) writeEvent(e event) error {
+	var oob []byte
+	if len(e.fds) > 0 {
+		oob = sys.UnixRights(e.fds...)
+	}
+	msg := e.msg
+	for len(msg) > 0 {
+		n, _, err := c.conn.WriteMsgUnix(msg, oob, nil)
+		if n > 0 && oob != nil {
+			closeFds(e.fds)
+			e.fds, oob = nil, nil
+		}
+		if err != nil {
+			closeFds(e.fds)
+			return err
+		}
+		msg = msg[n:]
+	}
+	return nil
+}
+
+func closeFds(fds []int) {
+	for _, fd := range fds {
+		sys.Close(fd)
+	}
+}
+
+// PostError (Client PostError) sends a wl_display.error about the resource to the client and
+// disconnects it, like wl_resource_post_error
+func (c *Client) PostError(r Resource, code uint32, message string) {
+	if c.dead {
+		return
+	}
+	c.dead = true
+	c.display.SendError(r, code, message)
+	c.Close()
+}
+
+// OnDestroy (Client OnDestroy) adds a function called when the client disconnects
+func (c *Client) OnDestroy(f func()) {
+	c.onDestroy = append(c.onDestroy, f)
+}
+
+// Close (Client Close) disconnects the client, destroying all its resources
+func (c *Client) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	c.dead = true
+
+	// the buffered events, such as the error of PostError, are still written unless the client
+	// does not read them in time
+	c.wmu.Lock()
+	c.stopWriting()
+	c.wmu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(closeTimeout))
+
+	// the objects created later depend on the earlier ones, destroy them first
+	ids := make([]wl.ProxyId, 0, len(c.objects))
+	for id := range c.objects {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] > ids[j] })
+	for _, id := range ids {
+		if r := c.objects[id]; r != nil {
+			c.Destroy(r)
+		}
+	}
+	closeFds(c.fds)
+	c.fds = nil
+	for i := len(c.onDestroy) - 1; i >= 0; i-- {
+		c.onDestroy[i]()
+	}
+	c.onDestroy = nil
+	c.server.removeClient(c)
+	return nil
+}
+
+// read receives the requests of the client and queues them to the goroutine running Server Run
+func (c *Client) read() {
+	buf := make([]byte, 4096)
+	control := make([]byte, sys.CmsgSpace(maxFdsPerMsg*4))
+	for {
+		n, oobn, flags, _, err := c.conn.ReadMsgUnix(buf, control)
+		if n < 0 {
+			n = 0
+		}
+		var fds []int
+		if oobn > 0 {
+			fds = parseRights(control[:oobn])
+		}
+		if flags&sys.MsgCtrunc != 0 {
+			// the fds that did not fit are lost, the following requests would get the wrong ones
+			c.server.Invoke(func() {
+				closeFds(fds)
+				c.PostError(c.display, DisplayErrorImplementation, "too many file descriptors")
+			})
+			return
+		}
+		data := append([]byte(nil), buf[:n]...)
+		if n > 0 || len(fds) > 0 {
+			c.server.Invoke(func() {
+				c.receive(data, fds)
+			})
+		}
+		if err != nil || n == 0 {
+			if err != nil && err != io.EOF && !errors.Is(err, net.ErrClosed) {
+				c.server.logf("client read: %v", err)
+			}
+			c.server.Invoke(func() {
+				c.Close()
+			})
+			return
+		}
+	}
+}
+
+// parseRights returns the fds of the control messages
+func parseRights(control []byte) (fds []int) {
+	scms, err := sys.ParseSocketControlMessage(control)
+	if err != nil {
+		return nil
+	}
+	for i := range scms {
+		rights, err := sys.ParseUnixRights(&scms[i])
+		if err != nil {
+			continue
+		}
+		fds = append(fds, rights...)
+	}
+	return fds
+}
+
+// receive dispatches the complete requests received so far
+func (c *Client) receive(data []byte, fds []int) {
+	if c.closed {
+		for _, fd := range fds {
+			sys.Close(fd)
+		}
+		return
+	}
+	c.in = append(c.in, data...)
+	c.fds = append(c.fds, fds...)
+	for !c.dead && len(c.in) >= 8 {
+		size := int(native_endian.NativeEndian().Uint16(c.in[6:8]))
+		if size < 8 || size&3 != 0 {
+			c.PostError(c.display, DisplayErrorInvalidMethod, "invalid message size")
+			return
+		}
+		if len(c.in) < size {
+			break
+		}
+		pid := wl.ProxyId(native_endian.NativeEndian().Uint32(c.in[0:4]))
+		opcode := uint32(native_endian.NativeEndian().Uint16(c.in[4:6]))
+		msg := append([]byte(nil), c.in[8:size]...)
+		c.in = c.in[size:]
+		c.dispatch(pid, opcode, msg)
+	}
+	if len(c.in) == 0 {
+		c.in = nil
+	}
+}
+
+// takeFds returns the fds of a request with the arguments
+func (c *Client) takeFds(args []wl.Arg) ([]int, bool) {
+	var n int
+	for _, a := range args {
+		if a.Type == wl.ArgFd {
+			n++
+		}
+	}
+	if n > len(c.fds) {
+		return nil, false
+	}
+	fds := c.fds[:n:n]
+	c.fds = c.fds[n:]
+	return fds, true
+}
+
+// dispatch checks a request and runs the Dispatch of its resource
+func (c *Client) dispatch(pid wl.ProxyId, opcode uint32, data []byte) {
+	r := c.objects[pid]
+	if r == nil {
+		if iface, ok := c.zombies[pid]; ok {
+			// the client did not learn about the destruction yet
+			if iface != nil && int(opcode) < len(iface.Requests) {
+				fds, _ := c.takeFds(iface.Requests[opcode].Args)
+				for _, fd := range fds {
+					sys.Close(fd)
+				}
+			}
+			return
+		}
+		c.PostError(c.display, DisplayErrorInvalidObject, fmt.Sprintf("invalid object %d", pid))
+		return
+	}
+	iface := r.Interface()
+	if iface == nil {
+		c.PostError(c.display, DisplayErrorInvalidObject, fmt.Sprintf("object %d of unknown interface", pid))
+		return
+	}
+	if int(opcode) >= len(iface.Requests) {
+		c.PostError(c.display, DisplayErrorInvalidMethod,
+			fmt.Sprintf("invalid method %d, object %s@%d", opcode, iface.Name, pid))
+		return
+	}
+	req := &iface.Requests[opcode]
+	if req.Since > r.Version() {
+		c.PostError(c.display, DisplayErrorInvalidMethod,
+			fmt.Sprintf("invalid method %d (since %d < %d), object %s@%d", opcode, r.Version(), req.Since, iface.Name, pid))
+		return
+	}
+	fds, ok := c.takeFds(req.Args)
+	if !ok {
+		c.PostError(c.display, DisplayErrorInvalidMethod,
+			fmt.Sprintf("file descriptor expected, object %s@%d.%s", iface.Name, pid, req.Name))
+		return
+	}
+	ev := wl.NewEvent(pid, opcode, data, fds)
+	err := r.Dispatch(ev)
+	// the fds were not taken when the request has no handler or failed to decode
+	ev.CloseFds()
+	if err != nil {
+		code := uint32(DisplayErrorInvalidMethod)
+		if errors.Is(err, ErrInvalidObject) {
+			code = DisplayErrorInvalidObject
+		}
+		c.PostError(c.display, code, fmt.Sprintf("%s@%d.%s: %v", iface.Name, pid, req.Name, err))
+		return
+	}
+	if req.Destructor {
+		c.Destroy(r)
+	}
+}
@@ -0,0 +1,61 @@
+package wlserver
+
+import (
+	"fmt"
+)
+
+// displayHandler handles the requests of the wl_display of every client
+type displayHandler struct{}
+
+func (displayHandler) HandleDisplaySync(req DisplaySyncRequest) {
+	req.Callback.SendDone(req.Resource.Client().Server().NextSerial())
+}
+
+func (displayHandler) HandleDisplayGetRegistry(req DisplayGetRegistryRequest) {
+	reg := req.Registry
+	reg.SetHandler(registryHandler{})
+	for _, g := range reg.Client().Server().globals {
+		if !g.removed {
+			reg.SendGlobal(g.name, g.iface.Name, g.version)
+		}
+	}
+}
+
+// registries returns the wl_registry resources of the client
+func (c *Client) registries() (regs []*Registry) {
+	for _, r := range c.objects {
+		if reg, ok := r.(*Registry); ok {
+			regs = append(regs, reg)
+		}
+	}
+	return regs
+}
+
+// registryHandler binds the globals
+type registryHandler struct{}
+
+func (registryHandler) HandleRegistryBind(req RegistryBindRequest) {
+	c := req.Resource.Client()
+	g := c.Server().global(req.Name)
+	switch {
+	case g == nil:
+		c.PostError(c.Display(), DisplayErrorInvalidObject, fmt.Sprintf("invalid global %s (%d)", req.Interface, req.Name))
+		return
+	case g.iface.Name != req.Interface:
+		c.PostError(c.Display(), DisplayErrorInvalidObject,
+			fmt.Sprintf("invalid interface for global %d: have %s, wanted %s", req.Name, req.Interface, g.iface.Name))
+		return
+	case req.Version == 0 || req.Version > g.version:
+		c.PostError(c.Display(), DisplayErrorInvalidObject,
+			fmt.Sprintf("invalid version for global %s (%d): have %d, wanted %d", req.Interface, req.Name, g.version, req.Version))
+		return
+	}
+	r := NewResourceOf(g.iface)
+	if err := c.NewId(r, uint32(req.Id), req.Version); err != nil {
+		c.PostError(c.Display(), DisplayErrorInvalidObject, err.Error())
+		return
+	}
+	if !g.removed && g.bind != nil {
+		g.bind(r)
+	}
+}
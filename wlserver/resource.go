@@ -0,0 +1,146 @@
+package wlserver
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wl"
+)
+
+// Resource is the server side of a protocol object of a client. The resource types of the
+// generated bindings embed BaseResource and decode the requests in Dispatch.
+type Resource interface {
+	Client() *Client
+	Id() wl.ProxyId
+	Version() uint32
+	Interface() *wl.Interface
+	Handler() interface{}
+	SetHandler(h interface{})
+	OnDestroy(f func())
+	Dispatch(request *wl.Event) error
+	base() *BaseResource
+}
+
+// BaseResource implements the bookkeeping of a Resource, it is embedded by the resource types
+type BaseResource struct {
+	client    *Client
+	id        wl.ProxyId
+	version   uint32
+	handler   interface{}
+	destroyed bool
+	onDestroy []func()
+}
+
+func (r *BaseResource) base() *BaseResource {
+	return r
+}
+
+// Client (BaseResource Client) returns the client owning the resource
+func (r *BaseResource) Client() *Client {
+	return r.client
+}
+
+// Id (BaseResource Id) returns the object id of the resource
+func (r *BaseResource) Id() wl.ProxyId {
+	return r.id
+}
+
+// Version (BaseResource Version) returns the version the resource was created with
+func (r *BaseResource) Version() uint32 {
+	return r.version
+}
+
+// Handler (BaseResource Handler) returns the handler of the requests of the resource
+func (r *BaseResource) Handler() interface{} {
+	return r.handler
+}
+
+// SetHandler (BaseResource SetHandler) sets the handler of the requests of the resource. The handler
+// implements the XxxYyyHandler interfaces of the requests it handles, the other requests are ignored.
+func (r *BaseResource) SetHandler(h interface{}) {
+	r.handler = h
+}
+
+// OnDestroy (BaseResource OnDestroy) adds a function called when the resource is destroyed,
+// by a destructor request, by Client Destroy or when the client disconnects
+func (r *BaseResource) OnDestroy(f func()) {
+	r.onDestroy = append(r.onDestroy, f)
+}
+
+// Destroyed (BaseResource Destroyed) reports whether the resource was destroyed
+func (r *BaseResource) Destroyed() bool {
+	return r.destroyed
+}
+
+// RequestHandler handles the requests of resources of interfaces without generated server
+// bindings, the arguments are decoded by the wl.Event decoders
+type RequestHandler interface {
+	HandleRequest(r Resource, request *wl.Event) error
+}
+
+// resource is a resource of an interface without generated server bindings
+type resource struct {
+	BaseResource
+	iface *wl.Interface
+}
+
+func (r *resource) Interface() *wl.Interface {
+	return r.iface
+}
+
+func (r *resource) Dispatch(request *wl.Event) error {
+	if h, ok := r.Handler().(RequestHandler); ok {
+		return h.HandleRequest(r, request)
+	}
+	return nil
+}
+
+var constructors = struct {
+	sync.RWMutex
+	m map[string]func() Resource
+}{m: make(map[string]func() Resource)}
+
+// RegisterResource makes NewResourceOf create the resources of the interface by new, the generated
+// bindings register their resource types on init
+func RegisterResource(iface *wl.Interface, new func() Resource) {
+	constructors.Lock()
+	constructors.m[iface.Name] = new
+	constructors.Unlock()
+}
+
+// NewResourceOf returns a new resource of the interface, not yet added to a client. Interfaces
+// without generated server bindings get a resource dispatching to a RequestHandler.
+func NewResourceOf(iface *wl.Interface) Resource {
+	constructors.RLock()
+	new := constructors.m[iface.Name]
+	constructors.RUnlock()
+	if new != nil {
+		return new()
+	}
+	return &resource{iface: iface}
+}
+
+// ErrEventNotSupportedByVersion is returned when sending an event newer than the version of the resource
+var ErrEventNotSupportedByVersion = errors.New("event not supported by the resource version")
+
+// CheckEventVersion returns an error when the event is newer than the version of the resource
+func CheckEventVersion(r Resource, opcode uint32) error {
+	iface := r.Interface()
+	if iface == nil || int(opcode) >= len(iface.Events) {
+		return nil
+	}
+	ev := &iface.Events[opcode]
+	if ev.Since > r.Version() {
+		return fmt.Errorf("%w: %s.%s since %d, version %d", ErrEventNotSupportedByVersion,
+			iface.Name, ev.Name, ev.Since, r.Version())
+	}
+	return nil
+}
+
+// CloseFd closes a file descriptor of a request that is not passed to a handler, the generated
+// Dispatch methods call it when the request fails to decode or has no handler
+func CloseFd(fd uintptr) {
+	sys.Close(int(fd))
+}
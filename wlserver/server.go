@@ -0,0 +1,330 @@
+// Package wlserver implements the compositor side of the Wayland wire protocol
+//
+// A Server listens on a $XDG_RUNTIME_DIR/wayland-N socket by Listen, or serves connections
+// added by AddClient, and runs the requests of all its clients on the goroutine calling Run.
+// Every Client tracks its resources by their object ids, the ids allocated by the server
+// start at 0xff000000. The globals added by AddGlobal are announced to the wl_registry of
+// every client, binding one creates a resource passed to the bind function of the global.
+//
+// The server flavor of the bindings of wayland.xml is generated in this package, the one
+// of xdg-shell in package xdgserver. Every interface has a resource type embedding
+// BaseResource: the requests are decoded into XxxYyyRequest structs passed to the handler
+// set by SetHandler when it implements the XxxYyyHandler interface, and the events are sent
+// by the SendXxx methods. The requests and events are encoded by the wl.Event and wl.Request
+// code shared with the client side.
+//
+// The resources and the clients, including the sending of events, are used on the goroutine
+// running Run, that is in the request handlers and in the functions passed to Invoke: sending
+// a destructor event destroys the resource. Only Invoke, Close and NextSerial may be called
+// from any goroutine. The events are buffered per client and written by another goroutine,
+// so a client that is not reading never blocks the server.
+package wlserver
+
+//go:generate go run ../cmd/go-wayland-scanner -server -client github.com/neurlang/wayland/wl -pkg wlserver -prefix wl_ -i ../wl/wayland.xml -o wayland.xml.go
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wl"
+)
+
+// ErrNoRuntimeDir is returned by Listen when XDG_RUNTIME_DIR is not set
+var ErrNoRuntimeDir = errors.New("XDG_RUNTIME_DIR is not set")
+
+// ErrNoFreeSocket is returned by Listen when all the wayland-N sockets are taken
+var ErrNoFreeSocket = errors.New("no free wayland socket")
+
+// ErrSocketInUse is returned by Listen when another compositor holds the lock of the socket
+var ErrSocketInUse = errors.New("wayland socket in use")
+
+// ErrServerClosed is returned by Run when the Server is closed
+var ErrServerClosed = errors.New("server closed")
+
+// maxDisplays is the number of wayland-N sockets tried by Listen
+const maxDisplays = 32
+
+// Server is a Wayland compositor serving its clients
+type Server struct {
+	mu        sync.Mutex
+	queue     []func()
+	signal    chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	listeners []*listener
+	serial    uint32
+
+	clients   map[*Client]struct{}
+	globals   []*Global
+	nextName  uint32
+	onClient  []func(*Client)
+	logWriter io.Writer
+}
+
+// listener is a listening socket and its lock file
+type listener struct {
+	l    *net.UnixListener
+	lock *os.File
+}
+
+// Global is a global object announced to the clients
+type Global struct {
+	server  *Server
+	name    uint32
+	iface   *wl.Interface
+	version uint32
+	bind    func(r Resource)
+	removed bool
+}
+
+// New creates a Server, see Listen and AddClient
+func New() *Server {
+	return &Server{
+		signal:  make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		clients: make(map[*Client]struct{}),
+	}
+}
+
+// SetLog (Server SetLog) makes the server log the connection errors of the clients to w
+func (s *Server) SetLog(w io.Writer) {
+	s.logWriter = w
+}
+
+func (s *Server) logf(format string, args ...interface{}) {
+	if s.logWriter != nil {
+		fmt.Fprintf(s.logWriter, format+"\n", args...)
+	}
+}
+
+// Listen (Server Listen) listens on the socket of the name in XDG_RUNTIME_DIR and accepts the clients,
+// an absolute name is used as is. An empty name picks the first free wayland-N socket. The name of
+// the socket is returned, to be passed to the clients in WAYLAND_DISPLAY.
+func (s *Server) Listen(name string) (string, error) {
+	if name != "" {
+		return name, s.listen(name)
+	}
+	for i := 0; i < maxDisplays; i++ {
+		name = "wayland-" + strconv.Itoa(i)
+		err := s.listen(name)
+		if err == nil {
+			return name, nil
+		}
+		if err != ErrSocketInUse {
+			return "", err
+		}
+	}
+	return "", ErrNoFreeSocket
+}
+
+func (s *Server) listen(name string) error {
+	path := name
+	if !filepath.IsAbs(name) {
+		dir := os.Getenv("XDG_RUNTIME_DIR")
+		if dir == "" {
+			return ErrNoRuntimeDir
+		}
+		path = filepath.Join(dir, name)
+	}
+	lock, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0660)
+	if err != nil {
+		return err
+	}
+	if sys.TryLockFile(int(lock.Fd())) != nil {
+		lock.Close()
+		return ErrSocketInUse
+	}
+	// the lock is held, the socket is a leftover of a crashed compositor
+	os.Remove(path)
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		lock.Close()
+		return err
+	}
+	l.SetUnlinkOnClose(true)
+
+	s.mu.Lock()
+	select {
+	case <-s.done:
+		s.mu.Unlock()
+		l.Close()
+		lock.Close()
+		return ErrServerClosed
+	default:
+	}
+	s.listeners = append(s.listeners, &listener{l, lock})
+	s.mu.Unlock()
+
+	go s.accept(l)
+	return nil
+}
+
+func (s *Server) accept(l *net.UnixListener) {
+	for {
+		conn, err := l.AcceptUnix()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				s.logf("accept: %v", err)
+			}
+			return
+		}
+		s.Invoke(func() {
+			s.AddClient(conn)
+		})
+	}
+}
+
+// AddClient (Server AddClient) starts serving a client connected on conn
+func (s *Server) AddClient(conn *net.UnixConn) *Client {
+	c := newClient(s, conn)
+	s.mu.Lock()
+	s.clients[c] = struct{}{}
+	s.mu.Unlock()
+	for _, f := range s.onClient {
+		f(c)
+	}
+	go c.write()
+	go c.read()
+	return c
+}
+
+// OnClient (Server OnClient) adds a function called for every new client
+func (s *Server) OnClient(f func(*Client)) {
+	s.onClient = append(s.onClient, f)
+}
+
+// Clients (Server Clients) returns the connected clients
+func (s *Server) Clients() (clients []*Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.clients {
+		clients = append(clients, c)
+	}
+	return clients
+}
+
+func (s *Server) removeClient(c *Client) {
+	s.mu.Lock()
+	delete(s.clients, c)
+	s.mu.Unlock()
+}
+
+// Invoke (Server Invoke) runs f on the goroutine running Run, in order with the requests of the clients
+func (s *Server) Invoke(f func()) {
+	s.mu.Lock()
+	s.queue = append(s.queue, f)
+	s.mu.Unlock()
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+// Run (Server Run) dispatches the requests of the clients until the Server is closed,
+// the clients are disconnected before it returns
+func (s *Server) Run() error {
+	for {
+		select {
+		case <-s.done:
+			for _, c := range s.Clients() {
+				c.Close()
+			}
+			return ErrServerClosed
+		case <-s.signal:
+		}
+		s.mu.Lock()
+		queue := s.queue
+		s.queue = nil
+		s.mu.Unlock()
+		for _, f := range queue {
+			f()
+		}
+	}
+}
+
+// Close (Server Close) stops listening and makes Run return
+func (s *Server) Close() error {
+	var err error
+	s.closeOnce.Do(func() {
+		s.mu.Lock()
+		close(s.done)
+		listeners := s.listeners
+		s.listeners = nil
+		s.mu.Unlock()
+		for _, l := range listeners {
+			if e := l.l.Close(); e != nil && err == nil {
+				err = e
+			}
+			l.lock.Close()
+			os.Remove(l.lock.Name())
+		}
+	})
+	return err
+}
+
+// NextSerial (Server NextSerial) returns a new serial of the events
+func (s *Server) NextSerial() uint32 {
+	return atomic.AddUint32(&s.serial, 1)
+}
+
+// AddGlobal (Server AddGlobal) announces a global of the interface to all the registries. Binding
+// it creates a resource of the version requested by the client, passed to bind.
+func (s *Server) AddGlobal(iface *wl.Interface, version uint32, bind func(r Resource)) *Global {
+	s.nextName++
+	g := &Global{server: s, name: s.nextName, iface: iface, version: version, bind: bind}
+	s.globals = append(s.globals, g)
+	for _, c := range s.Clients() {
+		for _, reg := range c.registries() {
+			reg.SendGlobal(g.name, iface.Name, version)
+		}
+	}
+	return g
+}
+
+// Name (Global Name) returns the numeric name of the global
+func (g *Global) Name() uint32 {
+	return g.name
+}
+
+// Interface (Global Interface) returns the interface of the global
+func (g *Global) Interface() *wl.Interface {
+	return g.iface
+}
+
+// Version (Global Version) returns the highest version of the global
+func (g *Global) Version() uint32 {
+	return g.version
+}
+
+// Remove (Global Remove) announces the removal of the global to all the registries. The clients
+// binding it before they learn about the removal get an inert resource.
+func (g *Global) Remove() {
+	if g.removed {
+		return
+	}
+	// the global stays bindable for the clients that did not learn about the removal yet
+	g.removed = true
+	for _, c := range g.server.Clients() {
+		for _, reg := range c.registries() {
+			reg.SendGlobalRemove(g.name)
+		}
+	}
+}
+
+// global returns the global of the name, including the removed ones
+func (s *Server) global(name uint32) *Global {
+	for _, g := range s.globals {
+		if g.name == name {
+			return g
+		}
+	}
+	return nil
+}
@@ -0,0 +1,354 @@
+package wlserver_test
+
+import (
+	"errors"
+	"io"
+	"net"
+	stdos "os"
+	"testing"
+	"time"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wlclient"
+	"github.com/neurlang/wayland/wlserver"
+	"github.com/yalue/native_endian"
+	"golang.org/x/sys/unix"
+)
+
+func unixConn(t *testing.T, fd int) *net.UnixConn {
+	f := stdos.NewFile(uintptr(fd), "socketpair")
+	defer f.Close()
+	conn, err := net.FileConn(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn.(*net.UnixConn)
+}
+
+// newServer runs a Server with a client connected over a socketpair, setup runs on the goroutine
+// of Run before the client connects
+func newServer(t *testing.T, setup func(s *wlserver.Server)) (*wlserver.Server, *wl.Display) {
+	fds, err := sys.Socketpair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := wlserver.New()
+	ran := make(chan error, 1)
+	go func() {
+		ran <- s.Run()
+	}()
+	conn := unixConn(t, fds[0])
+	s.Invoke(func() {
+		if setup != nil {
+			setup(s)
+		}
+		s.AddClient(conn)
+	})
+	display, err := wl.ConnectConn(unixConn(t, fds[1]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		display.Context().Close()
+		s.Close()
+		<-ran
+	})
+	return s, display
+}
+
+// invoke runs f on the goroutine of Run and waits for it
+func invoke(s *wlserver.Server, f func()) {
+	done := make(chan struct{})
+	s.Invoke(func() {
+		f()
+		close(done)
+	})
+	<-done
+}
+
+// shmHandler checks the fds of the pools created by the client
+type shmHandler struct {
+	sizes chan int64
+}
+
+func (h shmHandler) HandleShmCreatePool(req wlserver.ShmCreatePoolRequest) {
+	defer sys.Close(int(req.Fd))
+	var st unix.Stat_t
+	if unix.Fstat(int(req.Fd), &st) != nil {
+		h.sizes <- -1
+		return
+	}
+	h.sizes <- st.Size
+}
+
+func TestServerBindAndFd(t *testing.T) {
+	h := shmHandler{make(chan int64, 1)}
+	_, display := newServer(t, func(s *wlserver.Server) {
+		s.AddGlobal(wl.ShmInterface, 1, func(r wlserver.Resource) {
+			r.SetHandler(h)
+			r.(*wlserver.Shm).SendFormat(wl.ShmFormatArgb8888)
+		})
+	})
+	registry, err := display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	globals := wl.NewGlobalTracker(registry)
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+	p, err := globals.Bind("wl_shm", 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shm := p.(*wl.Shm)
+
+	file, err := sys.CreateAnonymousFile(4096)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if _, err := shm.CreatePool(file.Fd(), 4096); err != nil {
+		t.Fatal(err)
+	}
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case size := <-h.sizes:
+		if size != 4096 {
+			t.Errorf("pool of %d bytes, want 4096", size)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("create_pool not handled")
+	}
+}
+
+// TestServerDestructorEvent checks the done event of wl_display.sync destroys the callback
+// and its id is freed by delete_id
+func TestServerDestructorEvent(t *testing.T) {
+	_, display := newServer(t, nil)
+	ctx := display.Context()
+
+	cb, err := display.Sync()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.RunTill(cb); err != nil {
+		t.Fatal(err)
+	}
+	id := cb.Id()
+	cb.Unregister()
+	// the delete_id is sent right after the done event
+	if err := ctx.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	next, err := display.Sync()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next.Id() != id {
+		t.Errorf("new callback id %d, want the id %d freed by delete_id", next.Id(), id)
+	}
+}
+
+func TestServerPostError(t *testing.T) {
+	s, display := newServer(t, nil)
+	ctx := display.Context()
+	// sent before the server disconnects the client
+	cb, err := display.Sync()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	invoke(s, func() {
+		c := s.Clients()[0]
+		c.PostError(c.Display(), wlserver.DisplayErrorImplementation, "broken")
+	})
+	err = ctx.RunTill(cb)
+	var perr *wl.ProtocolError
+	if !errors.As(err, &perr) {
+		t.Fatalf("roundtrip returned %v, want a ProtocolError", err)
+	}
+	if perr.Interface != "wl_display" || perr.Code != wlserver.DisplayErrorImplementation || perr.Message != "broken" {
+		t.Errorf("error %+v, want wl_display.error.implementation", perr)
+	}
+}
+
+// TestServerSlowClient floods a client that is not reading, the server keeps running and
+// disconnects the client once its buffer is full
+func TestServerSlowClient(t *testing.T) {
+	s, _ := newServer(t, nil)
+	var err error
+	sent := 0
+	invoke(s, func() {
+		c := s.Clients()[0]
+		for err == nil {
+			err = c.Display().SendDeleteId(0xff000000)
+			sent++
+		}
+	})
+	if !errors.Is(err, wlserver.ErrClientBufferFull) {
+		t.Fatalf("sending %d events returned %v, want %v", sent, err, wlserver.ErrClientBufferFull)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		var clients int
+		invoke(s, func() {
+			clients = len(s.Clients())
+		})
+		if clients == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("the client was not disconnected")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// newRawClient runs a Server with a client connected over a socketpair that writes the requests
+// itself, the wl_shm resource of the client has no handler
+func newRawClient(t *testing.T) (s *wlserver.Server, conn *net.UnixConn, shm wl.ProxyId) {
+	fds, err := sys.Socketpair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s = wlserver.New()
+	ran := make(chan error, 1)
+	go func() {
+		ran <- s.Run()
+	}()
+	serverConn := unixConn(t, fds[0])
+	invoke(s, func() {
+		c := s.AddClient(serverConn)
+		r := new(wlserver.Shm)
+		c.NewResource(r, 1)
+		shm = r.Id()
+	})
+	conn = unixConn(t, fds[1])
+	t.Cleanup(func() {
+		conn.Close()
+		s.Close()
+		<-ran
+	})
+	return s, conn, shm
+}
+
+// sendRequest writes a request with the fds to the raw client connection
+func sendRequest(t *testing.T, conn *net.UnixConn, pid wl.ProxyId, opcode uint16, fds []int, args ...uint32) {
+	msg := make([]byte, 8+4*len(args))
+	native_endian.NativeEndian().PutUint32(msg[0:4], uint32(pid))
+	native_endian.NativeEndian().PutUint16(msg[4:6], opcode)
+	native_endian.NativeEndian().PutUint16(msg[6:8], uint16(len(msg)))
+	for i, a := range args {
+		native_endian.NativeEndian().PutUint32(msg[8+4*i:], a)
+	}
+	var oob []byte
+	if len(fds) > 0 {
+		oob = sys.UnixRights(fds...)
+	}
+	if _, _, err := conn.WriteMsgUnix(msg, oob, nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// pipe returns a pipe, the fds of the write end sent to the server are closed once the read end
+// reaches the end of file
+func pipe(t *testing.T) (r, w *stdos.File) {
+	r, w, err := stdos.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		r.Close()
+		w.Close()
+	})
+	return r, w
+}
+
+// checkClosed fails when a copy of the write end of the pipe is still open after a while
+func checkClosed(t *testing.T, r, w *stdos.File) {
+	t.Helper()
+	w.Close()
+	r.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := r.Read(make([]byte, 1)); err != io.EOF {
+		t.Errorf("reading the pipe returned %v, want EOF after the server closed the fds", err)
+	}
+}
+
+// readError reads the events up to the wl_display.error and returns its code
+func readError(t *testing.T, conn *net.UnixConn) uint32 {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var data []byte
+	buf := make([]byte, 4096)
+	for {
+		for len(data) >= 8 {
+			size := int(native_endian.NativeEndian().Uint16(data[6:8]))
+			if size < 8 || len(data) < size {
+				break
+			}
+			pid := native_endian.NativeEndian().Uint32(data[0:4])
+			opcode := native_endian.NativeEndian().Uint16(data[4:6])
+			if pid == 1 && opcode == 0 && size >= 16 {
+				return native_endian.NativeEndian().Uint32(data[12:16])
+			}
+			data = data[size:]
+		}
+		n, err := conn.Read(buf)
+		if err != nil {
+			t.Fatalf("no wl_display.error: %v", err)
+		}
+		data = append(data, buf[:n]...)
+	}
+}
+
+// TestServerUnhandledFd checks the fd of a request without a handler is closed
+func TestServerUnhandledFd(t *testing.T) {
+	_, conn, shm := newRawClient(t)
+	r, w := pipe(t)
+	// wl_shm.create_pool(id, fd, size)
+	sendRequest(t, conn, shm, 0, []int{int(w.Fd())}, 2, 4096)
+	checkClosed(t, r, w)
+}
+
+// TestServerInvalidRequestFd checks the fd of a request failing to decode after the fd is closed
+func TestServerInvalidRequestFd(t *testing.T) {
+	_, conn, shm := newRawClient(t)
+	r, w := pipe(t)
+	// wl_shm.create_pool without the size
+	sendRequest(t, conn, shm, 0, []int{int(w.Fd())}, 2)
+	if code := readError(t, conn); code != wlserver.DisplayErrorInvalidMethod {
+		t.Errorf("error code %d, want invalid_method", code)
+	}
+	checkClosed(t, r, w)
+}
+
+// TestServerTooManyFds sends more fds than the server receives at once, the client gets a
+// protocol error instead of requests with the wrong fds
+func TestServerTooManyFds(t *testing.T) {
+	_, conn, _ := newRawClient(t)
+	r, w := pipe(t)
+	fds := make([]int, 64)
+	for i := range fds {
+		fd, err := unix.Dup(int(w.Fd()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fds[i] = fd
+	}
+	// wl_display.sync(callback)
+	sendRequest(t, conn, 1, 0, fds, 2)
+	for _, fd := range fds {
+		unix.Close(fd)
+	}
+	if code := readError(t, conn); code != wlserver.DisplayErrorImplementation {
+		t.Errorf("error code %d, want implementation", code)
+	}
+	checkClosed(t, r, w)
+}
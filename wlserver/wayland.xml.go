@@ -0,0 +1,4915 @@
+// Code generated by go-wayland-scanner; DO NOT EDIT.
+// XML file: wayland.xml
+//
+// Wayland Protocol Copyright:
+//
+// Copyright © 2008-2011 Kristian Høgsberg
+// Copyright © 2010-2011 Intel Corporation
+// Copyright © 2012-2013 Collabora, Ltd.
+//
+// Permission is hereby granted, free of charge, to any person
+// obtaining a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice (including the
+// next paragraph) shall be included in all copies or substantial
+// portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT.  IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS
+// BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN
+// ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN
+// CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package wlserver
+
+import (
+	"github.com/neurlang/wayland/wl"
+)
+
+// Display: core global object
+//
+// The core global object.  This is a special singleton object.  It
+// is used for internal Wayland protocol features.
+type Display struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_display interface
+func (r *Display) Interface() *wl.Interface {
+	return wl.DisplayInterface
+}
+
+// DisplayError: global error values
+//
+// These errors are global and can be emitted in response to any
+// server request.
+const (
+	// DisplayErrorInvalidObject: server couldn't find object
+	DisplayErrorInvalidObject = 0
+	// DisplayErrorInvalidMethod: method doesn't exist on the specified interface or malformed request
+	DisplayErrorInvalidMethod = 1
+	// DisplayErrorNoMemory: server is out of memory
+	DisplayErrorNoMemory = 2
+	// DisplayErrorImplementation: implementation error in compositor
+	DisplayErrorImplementation = 3
+)
+
+// DisplaySyncRequest: asynchronous roundtrip
+//
+// The sync request asks the server to emit the 'done' event
+// on the returned wl_callback object.  Since requests are
+// handled in-order and events are delivered in-order, this can
+// be used as a barrier to ensure all previous requests and the
+// resulting events have been handled.
+//
+// The object returned by this request will be destroyed by the
+// compositor after the callback is fired and as such the client must not
+// attempt to use it after that point.
+//
+// The callback_data passed in the callback is the event serial.
+type DisplaySyncRequest struct {
+	Resource *Display
+	Callback *Callback
+}
+
+// DisplaySyncHandler is implemented by the handlers of DisplaySyncRequest, see SetHandler
+type DisplaySyncHandler interface {
+	HandleDisplaySync(DisplaySyncRequest)
+}
+
+// DisplayGetRegistryRequest: get global registry object
+//
+// This request creates a registry object that allows the client
+// to list and bind the global objects available from the
+// compositor.
+//
+// It should be noted that the server side resources consumed in
+// response to a get_registry request can only be released when the
+// client disconnects, not when the client side proxy is destroyed.
+// Therefore, clients should invoke get_registry as infrequently as
+// possible to avoid wasting memory.
+type DisplayGetRegistryRequest struct {
+	Resource *Display
+	Registry *Registry
+}
+
+// DisplayGetRegistryHandler is implemented by the handlers of DisplayGetRegistryRequest, see SetHandler
+type DisplayGetRegistryHandler interface {
+	HandleDisplayGetRegistry(DisplayGetRegistryRequest)
+}
+
+// Dispatch decodes a request received on the wl_display and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Display) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := DisplaySyncRequest{Resource: r}
+		req.Callback = new(Callback)
+		if err := c.NewId(req.Callback, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(DisplaySyncHandler); ok {
+			h.HandleDisplaySync(req)
+		}
+	case 1:
+		req := DisplayGetRegistryRequest{Resource: r}
+		req.Registry = new(Registry)
+		if err := c.NewId(req.Registry, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(DisplayGetRegistryHandler); ok {
+			h.HandleDisplayGetRegistry(req)
+		}
+	}
+	return nil
+}
+
+// SendError: fatal error event
+//
+// The error event is sent out when a fatal (non-recoverable)
+// error has occurred.  The object_id argument is the object
+// where the error occurred, most often in response to a request
+// to that object.  The code identifies the error and is defined
+// by the object interface.  As such, each interface defines its
+// own set of error codes.  The message is a brief description
+// of the error, for (debugging) convenience.
+//
+//	objectId: object where the error occurred
+//	code: error code
+//	message: error description
+func (r *Display) SendError(objectId Resource, code uint32, message string) error {
+	return r.Client().SendEvent(r, 0, objectId, code, message)
+}
+
+// SendDeleteId: acknowledge object ID deletion
+//
+// This event is used internally by the object ID management
+// logic. When a client deletes an object that it had created,
+// the server will send this event to acknowledge that it has
+// seen the delete request. When the client receives this event,
+// it will know that it can safely reuse the object ID.
+//
+//	id: deleted object ID
+func (r *Display) SendDeleteId(id uint32) error {
+	return r.Client().SendEvent(r, 1, id)
+}
+
+// Registry: global registry object
+//
+// The singleton global registry object.  The server has a number of
+// global objects that are available to all clients.  These objects
+// typically represent an actual object in the server (for example,
+// an input device) or they are singleton objects that provide
+// extension functionality.
+//
+// When a client creates a registry object, the registry object
+// will emit a global event for each global currently in the
+// registry.  Globals come and go as a result of device or
+// monitor hotplugs, reconfiguration or other events, and the
+// registry will send out global and global_remove events to
+// keep the client up to date with the changes.  To mark the end
+// of the initial burst of events, the client can use the
+// wl_display.sync request immediately after calling
+// wl_display.get_registry.
+//
+// A client can bind to a global object by using the bind
+// request.  This creates a client-side handle that lets the object
+// emit events to the client and lets the client invoke requests on
+// the object.
+type Registry struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_registry interface
+func (r *Registry) Interface() *wl.Interface {
+	return wl.RegistryInterface
+}
+
+// RegistryBindRequest: bind an object to the display
+//
+// Binds a new, client-created object to the server using the
+// specified name as the identifier.
+type RegistryBindRequest struct {
+	Resource  *Registry
+	Name      uint32
+	Interface string
+	Version   uint32
+	Id        wl.ProxyId
+}
+
+// RegistryBindHandler is implemented by the handlers of RegistryBindRequest, see SetHandler
+type RegistryBindHandler interface {
+	HandleRegistryBind(RegistryBindRequest)
+}
+
+// Dispatch decodes a request received on the wl_registry and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Registry) Dispatch(event *wl.Event) error {
+	switch event.Opcode {
+	case 0:
+		req := RegistryBindRequest{Resource: r}
+		req.Name = event.Uint32()
+		req.Interface = event.String()
+		req.Version = event.Uint32()
+		req.Id = wl.ProxyId(event.Uint32())
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(RegistryBindHandler); ok {
+			h.HandleRegistryBind(req)
+		}
+	}
+	return nil
+}
+
+// SendGlobal: announce global object
+//
+// Notify the client of global objects.
+//
+// The event notifies the client that a global object with
+// the given name is now available, and it implements the
+// given version of the given interface.
+//
+//	name: numeric name of the global object
+//	interface: interface implemented by the object
+//	version: interface version
+func (r *Registry) SendGlobal(name uint32, iface string, version uint32) error {
+	return r.Client().SendEvent(r, 0, name, iface, version)
+}
+
+// SendGlobalRemove: announce removal of global object
+//
+// Notify the client of removed global objects.
+//
+// This event notifies the client that the global identified
+// by name is no longer available.  If the client bound to
+// the global using the bind request, the client should now
+// destroy that object.
+//
+// The object remains valid and requests to the object will be
+// ignored until the client destroys it, to avoid races between
+// the global going away and a client sending a request to it.
+//
+//	name: numeric name of the global object
+func (r *Registry) SendGlobalRemove(name uint32) error {
+	return r.Client().SendEvent(r, 1, name)
+}
+
+// Callback: callback object
+//
+// Clients can handle the 'done' event to get notified when
+// the related request is done.
+type Callback struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_callback interface
+func (r *Callback) Interface() *wl.Interface {
+	return wl.CallbackInterface
+}
+
+// Dispatch decodes a request received on the wl_callback and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Callback) Dispatch(event *wl.Event) error {
+	return nil
+}
+
+// SendDone: done event
+//
+// Notify the client when the related request is done.
+//
+//	callbackData: request-specific data for the callback
+//
+// The resource is destroyed once the event is sent.
+func (r *Callback) SendDone(callbackData uint32) error {
+	defer r.Client().Destroy(r)
+	return r.Client().SendEvent(r, 0, callbackData)
+}
+
+// Compositor: the compositor singleton
+//
+// A compositor.  This object is a singleton global.  The
+// compositor is in charge of combining the contents of multiple
+// surfaces into one displayable output.
+type Compositor struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_compositor interface
+func (r *Compositor) Interface() *wl.Interface {
+	return wl.CompositorInterface
+}
+
+// CompositorCreateSurfaceRequest: create new surface
+//
+// Ask the compositor to create a new surface.
+type CompositorCreateSurfaceRequest struct {
+	Resource *Compositor
+	Id       *Surface
+}
+
+// CompositorCreateSurfaceHandler is implemented by the handlers of CompositorCreateSurfaceRequest, see SetHandler
+type CompositorCreateSurfaceHandler interface {
+	HandleCompositorCreateSurface(CompositorCreateSurfaceRequest)
+}
+
+// CompositorCreateRegionRequest: create new region
+//
+// Ask the compositor to create a new region.
+type CompositorCreateRegionRequest struct {
+	Resource *Compositor
+	Id       *Region
+}
+
+// CompositorCreateRegionHandler is implemented by the handlers of CompositorCreateRegionRequest, see SetHandler
+type CompositorCreateRegionHandler interface {
+	HandleCompositorCreateRegion(CompositorCreateRegionRequest)
+}
+
+// Dispatch decodes a request received on the wl_compositor and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Compositor) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := CompositorCreateSurfaceRequest{Resource: r}
+		req.Id = new(Surface)
+		if err := c.NewId(req.Id, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(CompositorCreateSurfaceHandler); ok {
+			h.HandleCompositorCreateSurface(req)
+		}
+	case 1:
+		req := CompositorCreateRegionRequest{Resource: r}
+		req.Id = new(Region)
+		if err := c.NewId(req.Id, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(CompositorCreateRegionHandler); ok {
+			h.HandleCompositorCreateRegion(req)
+		}
+	}
+	return nil
+}
+
+// ShmPool: a shared memory pool
+//
+// The wl_shm_pool object encapsulates a piece of memory shared
+// between the compositor and client.  Through the wl_shm_pool
+// object, the client can allocate shared memory wl_buffer objects.
+// All objects created through the same pool share the same
+// underlying mapped memory. Reusing the mapped memory avoids the
+// setup/teardown overhead and is useful when interactively resizing
+// a surface or for many small buffers.
+type ShmPool struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_shm_pool interface
+func (r *ShmPool) Interface() *wl.Interface {
+	return wl.ShmPoolInterface
+}
+
+// ShmPoolCreateBufferRequest: create a buffer from the pool
+//
+// Create a wl_buffer object from the pool.
+//
+// The buffer is created offset bytes into the pool and has
+// width and height as specified.  The stride argument specifies
+// the number of bytes from the beginning of one row to the beginning
+// of the next.  The format is the pixel format of the buffer and
+// must be one of those advertised through the wl_shm.format event.
+//
+// A buffer will keep a reference to the pool it was created from
+// so it is valid to destroy the pool immediately after creating
+// a buffer from it.
+type ShmPoolCreateBufferRequest struct {
+	Resource *ShmPool
+	Id       *Buffer
+	Offset   int32
+	Width    int32
+	Height   int32
+	Stride   int32
+	Format   uint32
+}
+
+// ShmPoolCreateBufferHandler is implemented by the handlers of ShmPoolCreateBufferRequest, see SetHandler
+type ShmPoolCreateBufferHandler interface {
+	HandleShmPoolCreateBuffer(ShmPoolCreateBufferRequest)
+}
+
+// ShmPoolDestroyRequest: destroy the pool
+//
+// Destroy the shared memory pool.
+//
+// The mmapped memory will be released when all
+// buffers that have been created from this pool
+// are gone.
+type ShmPoolDestroyRequest struct {
+	Resource *ShmPool
+}
+
+// ShmPoolDestroyHandler is implemented by the handlers of ShmPoolDestroyRequest, see SetHandler
+type ShmPoolDestroyHandler interface {
+	HandleShmPoolDestroy(ShmPoolDestroyRequest)
+}
+
+// ShmPoolResizeRequest: change the size of the pool mapping
+//
+// This request will cause the server to remap the backing memory
+// for the pool from the file descriptor passed when the pool was
+// created, but using the new size.  This request can only be
+// used to make the pool bigger.
+//
+// This request only changes the amount of bytes that are mmapped
+// by the server and does not touch the file corresponding to the
+// file descriptor passed at creation time. It is the client's
+// responsibility to ensure that the file is at least as big as
+// the new pool size.
+type ShmPoolResizeRequest struct {
+	Resource *ShmPool
+	Size     int32
+}
+
+// ShmPoolResizeHandler is implemented by the handlers of ShmPoolResizeRequest, see SetHandler
+type ShmPoolResizeHandler interface {
+	HandleShmPoolResize(ShmPoolResizeRequest)
+}
+
+// Dispatch decodes a request received on the wl_shm_pool and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *ShmPool) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := ShmPoolCreateBufferRequest{Resource: r}
+		req.Id = new(Buffer)
+		if err := c.NewId(req.Id, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		req.Offset = event.Int32()
+		req.Width = event.Int32()
+		req.Height = event.Int32()
+		req.Stride = event.Int32()
+		req.Format = event.Uint32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(ShmPoolCreateBufferHandler); ok {
+			h.HandleShmPoolCreateBuffer(req)
+		}
+	case 1:
+		req := ShmPoolDestroyRequest{Resource: r}
+		if h, ok := r.Handler().(ShmPoolDestroyHandler); ok {
+			h.HandleShmPoolDestroy(req)
+		}
+	case 2:
+		req := ShmPoolResizeRequest{Resource: r}
+		req.Size = event.Int32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(ShmPoolResizeHandler); ok {
+			h.HandleShmPoolResize(req)
+		}
+	}
+	return nil
+}
+
+// Shm: shared memory support
+//
+// A singleton global object that provides support for shared
+// memory.
+//
+// Clients can create wl_shm_pool objects using the create_pool
+// request.
+//
+// At connection setup time, the wl_shm object emits one or more
+// format events to inform clients about the valid pixel formats
+// that can be used for buffers.
+type Shm struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_shm interface
+func (r *Shm) Interface() *wl.Interface {
+	return wl.ShmInterface
+}
+
+// ShmError: wl_shm error values
+//
+// These errors can be emitted in response to wl_shm requests.
+const (
+	// ShmErrorInvalidFormat: buffer format is not known
+	ShmErrorInvalidFormat = 0
+	// ShmErrorInvalidStride: invalid size or stride during pool or buffer creation
+	ShmErrorInvalidStride = 1
+	// ShmErrorInvalidFd: mmapping the file descriptor failed
+	ShmErrorInvalidFd = 2
+)
+
+// ShmFormat: pixel formats
+//
+// This describes the memory layout of an individual pixel.
+//
+// All renderers should support argb8888 and xrgb8888 but any other
+// formats are optional and may not be supported by the particular
+// renderer in use.
+//
+// The drm format codes match the macros defined in drm_fourcc.h, except
+// argb8888 and xrgb8888. The formats actually supported by the compositor
+// will be reported by the format event.
+const (
+	// ShmFormatArgb8888: 32-bit ARGB format, [31:0] A:R:G:B 8:8:8:8 little endian
+	ShmFormatArgb8888 = 0
+	// ShmFormatXrgb8888: 32-bit RGB format, [31:0] x:R:G:B 8:8:8:8 little endian
+	ShmFormatXrgb8888 = 1
+	// ShmFormatC8: 8-bit color index format, [7:0] C
+	ShmFormatC8 = 0x20203843
+	// ShmFormatRgb332: 8-bit RGB format, [7:0] R:G:B 3:3:2
+	ShmFormatRgb332 = 0x38424752
+	// ShmFormatBgr233: 8-bit BGR format, [7:0] B:G:R 2:3:3
+	ShmFormatBgr233 = 0x38524742
+	// ShmFormatXrgb4444: 16-bit xRGB format, [15:0] x:R:G:B 4:4:4:4 little endian
+	ShmFormatXrgb4444 = 0x32315258
+	// ShmFormatXbgr4444: 16-bit xBGR format, [15:0] x:B:G:R 4:4:4:4 little endian
+	ShmFormatXbgr4444 = 0x32314258
+	// ShmFormatRgbx4444: 16-bit RGBx format, [15:0] R:G:B:x 4:4:4:4 little endian
+	ShmFormatRgbx4444 = 0x32315852
+	// ShmFormatBgrx4444: 16-bit BGRx format, [15:0] B:G:R:x 4:4:4:4 little endian
+	ShmFormatBgrx4444 = 0x32315842
+	// ShmFormatArgb4444: 16-bit ARGB format, [15:0] A:R:G:B 4:4:4:4 little endian
+	ShmFormatArgb4444 = 0x32315241
+	// ShmFormatAbgr4444: 16-bit ABGR format, [15:0] A:B:G:R 4:4:4:4 little endian
+	ShmFormatAbgr4444 = 0x32314241
+	// ShmFormatRgba4444: 16-bit RBGA format, [15:0] R:G:B:A 4:4:4:4 little endian
+	ShmFormatRgba4444 = 0x32314152
+	// ShmFormatBgra4444: 16-bit BGRA format, [15:0] B:G:R:A 4:4:4:4 little endian
+	ShmFormatBgra4444 = 0x32314142
+	// ShmFormatXrgb1555: 16-bit xRGB format, [15:0] x:R:G:B 1:5:5:5 little endian
+	ShmFormatXrgb1555 = 0x35315258
+	// ShmFormatXbgr1555: 16-bit xBGR 1555 format, [15:0] x:B:G:R 1:5:5:5 little endian
+	ShmFormatXbgr1555 = 0x35314258
+	// ShmFormatRgbx5551: 16-bit RGBx 5551 format, [15:0] R:G:B:x 5:5:5:1 little endian
+	ShmFormatRgbx5551 = 0x35315852
+	// ShmFormatBgrx5551: 16-bit BGRx 5551 format, [15:0] B:G:R:x 5:5:5:1 little endian
+	ShmFormatBgrx5551 = 0x35315842
+	// ShmFormatArgb1555: 16-bit ARGB 1555 format, [15:0] A:R:G:B 1:5:5:5 little endian
+	ShmFormatArgb1555 = 0x35315241
+	// ShmFormatAbgr1555: 16-bit ABGR 1555 format, [15:0] A:B:G:R 1:5:5:5 little endian
+	ShmFormatAbgr1555 = 0x35314241
+	// ShmFormatRgba5551: 16-bit RGBA 5551 format, [15:0] R:G:B:A 5:5:5:1 little endian
+	ShmFormatRgba5551 = 0x35314152
+	// ShmFormatBgra5551: 16-bit BGRA 5551 format, [15:0] B:G:R:A 5:5:5:1 little endian
+	ShmFormatBgra5551 = 0x35314142
+	// ShmFormatRgb565: 16-bit RGB 565 format, [15:0] R:G:B 5:6:5 little endian
+	ShmFormatRgb565 = 0x36314752
+	// ShmFormatBgr565: 16-bit BGR 565 format, [15:0] B:G:R 5:6:5 little endian
+	ShmFormatBgr565 = 0x36314742
+	// ShmFormatRgb888: 24-bit RGB format, [23:0] R:G:B little endian
+	ShmFormatRgb888 = 0x34324752
+	// ShmFormatBgr888: 24-bit BGR format, [23:0] B:G:R little endian
+	ShmFormatBgr888 = 0x34324742
+	// ShmFormatXbgr8888: 32-bit xBGR format, [31:0] x:B:G:R 8:8:8:8 little endian
+	ShmFormatXbgr8888 = 0x34324258
+	// ShmFormatRgbx8888: 32-bit RGBx format, [31:0] R:G:B:x 8:8:8:8 little endian
+	ShmFormatRgbx8888 = 0x34325852
+	// ShmFormatBgrx8888: 32-bit BGRx format, [31:0] B:G:R:x 8:8:8:8 little endian
+	ShmFormatBgrx8888 = 0x34325842
+	// ShmFormatAbgr8888: 32-bit ABGR format, [31:0] A:B:G:R 8:8:8:8 little endian
+	ShmFormatAbgr8888 = 0x34324241
+	// ShmFormatRgba8888: 32-bit RGBA format, [31:0] R:G:B:A 8:8:8:8 little endian
+	ShmFormatRgba8888 = 0x34324152
+	// ShmFormatBgra8888: 32-bit BGRA format, [31:0] B:G:R:A 8:8:8:8 little endian
+	ShmFormatBgra8888 = 0x34324142
+	// ShmFormatXrgb2101010: 32-bit xRGB format, [31:0] x:R:G:B 2:10:10:10 little endian
+	ShmFormatXrgb2101010 = 0x30335258
+	// ShmFormatXbgr2101010: 32-bit xBGR format, [31:0] x:B:G:R 2:10:10:10 little endian
+	ShmFormatXbgr2101010 = 0x30334258
+	// ShmFormatRgbx1010102: 32-bit RGBx format, [31:0] R:G:B:x 10:10:10:2 little endian
+	ShmFormatRgbx1010102 = 0x30335852
+	// ShmFormatBgrx1010102: 32-bit BGRx format, [31:0] B:G:R:x 10:10:10:2 little endian
+	ShmFormatBgrx1010102 = 0x30335842
+	// ShmFormatArgb2101010: 32-bit ARGB format, [31:0] A:R:G:B 2:10:10:10 little endian
+	ShmFormatArgb2101010 = 0x30335241
+	// ShmFormatAbgr2101010: 32-bit ABGR format, [31:0] A:B:G:R 2:10:10:10 little endian
+	ShmFormatAbgr2101010 = 0x30334241
+	// ShmFormatRgba1010102: 32-bit RGBA format, [31:0] R:G:B:A 10:10:10:2 little endian
+	ShmFormatRgba1010102 = 0x30334152
+	// ShmFormatBgra1010102: 32-bit BGRA format, [31:0] B:G:R:A 10:10:10:2 little endian
+	ShmFormatBgra1010102 = 0x30334142
+	// ShmFormatYuyv: packed YCbCr format, [31:0] Cr0:Y1:Cb0:Y0 8:8:8:8 little endian
+	ShmFormatYuyv = 0x56595559
+	// ShmFormatYvyu: packed YCbCr format, [31:0] Cb0:Y1:Cr0:Y0 8:8:8:8 little endian
+	ShmFormatYvyu = 0x55595659
+	// ShmFormatUyvy: packed YCbCr format, [31:0] Y1:Cr0:Y0:Cb0 8:8:8:8 little endian
+	ShmFormatUyvy = 0x59565955
+	// ShmFormatVyuy: packed YCbCr format, [31:0] Y1:Cb0:Y0:Cr0 8:8:8:8 little endian
+	ShmFormatVyuy = 0x59555956
+	// ShmFormatAyuv: packed AYCbCr format, [31:0] A:Y:Cb:Cr 8:8:8:8 little endian
+	ShmFormatAyuv = 0x56555941
+	// ShmFormatNv12: 2 plane YCbCr Cr:Cb format, 2x2 subsampled Cr:Cb plane
+	ShmFormatNv12 = 0x3231564e
+	// ShmFormatNv21: 2 plane YCbCr Cb:Cr format, 2x2 subsampled Cb:Cr plane
+	ShmFormatNv21 = 0x3132564e
+	// ShmFormatNv16: 2 plane YCbCr Cr:Cb format, 2x1 subsampled Cr:Cb plane
+	ShmFormatNv16 = 0x3631564e
+	// ShmFormatNv61: 2 plane YCbCr Cb:Cr format, 2x1 subsampled Cb:Cr plane
+	ShmFormatNv61 = 0x3136564e
+	// ShmFormatYuv410: 3 plane YCbCr format, 4x4 subsampled Cb (1) and Cr (2) planes
+	ShmFormatYuv410 = 0x39565559
+	// ShmFormatYvu410: 3 plane YCbCr format, 4x4 subsampled Cr (1) and Cb (2) planes
+	ShmFormatYvu410 = 0x39555659
+	// ShmFormatYuv411: 3 plane YCbCr format, 4x1 subsampled Cb (1) and Cr (2) planes
+	ShmFormatYuv411 = 0x31315559
+	// ShmFormatYvu411: 3 plane YCbCr format, 4x1 subsampled Cr (1) and Cb (2) planes
+	ShmFormatYvu411 = 0x31315659
+	// ShmFormatYuv420: 3 plane YCbCr format, 2x2 subsampled Cb (1) and Cr (2) planes
+	ShmFormatYuv420 = 0x32315559
+	// ShmFormatYvu420: 3 plane YCbCr format, 2x2 subsampled Cr (1) and Cb (2) planes
+	ShmFormatYvu420 = 0x32315659
+	// ShmFormatYuv422: 3 plane YCbCr format, 2x1 subsampled Cb (1) and Cr (2) planes
+	ShmFormatYuv422 = 0x36315559
+	// ShmFormatYvu422: 3 plane YCbCr format, 2x1 subsampled Cr (1) and Cb (2) planes
+	ShmFormatYvu422 = 0x36315659
+	// ShmFormatYuv444: 3 plane YCbCr format, non-subsampled Cb (1) and Cr (2) planes
+	ShmFormatYuv444 = 0x34325559
+	// ShmFormatYvu444: 3 plane YCbCr format, non-subsampled Cr (1) and Cb (2) planes
+	ShmFormatYvu444 = 0x34325659
+	// ShmFormatR8: [7:0] R
+	ShmFormatR8 = 0x20203852
+	// ShmFormatR16: [15:0] R little endian
+	ShmFormatR16 = 0x20363152
+	// ShmFormatRg88: [15:0] R:G 8:8 little endian
+	ShmFormatRg88 = 0x38384752
+	// ShmFormatGr88: [15:0] G:R 8:8 little endian
+	ShmFormatGr88 = 0x38385247
+	// ShmFormatRg1616: [31:0] R:G 16:16 little endian
+	ShmFormatRg1616 = 0x32334752
+	// ShmFormatGr1616: [31:0] G:R 16:16 little endian
+	ShmFormatGr1616 = 0x32335247
+	// ShmFormatXrgb16161616f: [63:0] x:R:G:B 16:16:16:16 little endian
+	ShmFormatXrgb16161616f = 0x48345258
+	// ShmFormatXbgr16161616f: [63:0] x:B:G:R 16:16:16:16 little endian
+	ShmFormatXbgr16161616f = 0x48344258
+	// ShmFormatArgb16161616f: [63:0] A:R:G:B 16:16:16:16 little endian
+	ShmFormatArgb16161616f = 0x48345241
+	// ShmFormatAbgr16161616f: [63:0] A:B:G:R 16:16:16:16 little endian
+	ShmFormatAbgr16161616f = 0x48344241
+	// ShmFormatXyuv8888: [31:0] X:Y:Cb:Cr 8:8:8:8 little endian
+	ShmFormatXyuv8888 = 0x56555958
+	// ShmFormatVuy888: [23:0] Cr:Cb:Y 8:8:8 little endian
+	ShmFormatVuy888 = 0x34325556
+	// ShmFormatVuy101010: Y followed by U then V, 10:10:10. Non-linear modifier only
+	ShmFormatVuy101010 = 0x30335556
+	// ShmFormatY210: [63:0] Cr0:0:Y1:0:Cb0:0:Y0:0 10:6:10:6:10:6:10:6 little endian per 2 Y pixels
+	ShmFormatY210 = 0x30313259
+	// ShmFormatY212: [63:0] Cr0:0:Y1:0:Cb0:0:Y0:0 12:4:12:4:12:4:12:4 little endian per 2 Y pixels
+	ShmFormatY212 = 0x32313259
+	// ShmFormatY216: [63:0] Cr0:Y1:Cb0:Y0 16:16:16:16 little endian per 2 Y pixels
+	ShmFormatY216 = 0x36313259
+	// ShmFormatY410: [31:0] A:Cr:Y:Cb 2:10:10:10 little endian
+	ShmFormatY410 = 0x30313459
+	// ShmFormatY412: [63:0] A:0:Cr:0:Y:0:Cb:0 12:4:12:4:12:4:12:4 little endian
+	ShmFormatY412 = 0x32313459
+	// ShmFormatY416: [63:0] A:Cr:Y:Cb 16:16:16:16 little endian
+	ShmFormatY416 = 0x36313459
+	// ShmFormatXvyu2101010: [31:0] X:Cr:Y:Cb 2:10:10:10 little endian
+	ShmFormatXvyu2101010 = 0x30335658
+	// ShmFormatXvyu1216161616: [63:0] X:0:Cr:0:Y:0:Cb:0 12:4:12:4:12:4:12:4 little endian
+	ShmFormatXvyu1216161616 = 0x36335658
+	// ShmFormatXvyu16161616: [63:0] X:Cr:Y:Cb 16:16:16:16 little endian
+	ShmFormatXvyu16161616 = 0x38345658
+	// ShmFormatY0l0: [63:0] A3:A2:Y3:0:Cr0:0:Y2:0:A1:A0:Y1:0:Cb0:0:Y0:0 1:1:8:2:8:2:8:2:1:1:8:2:8:2:8:2 little endian
+	ShmFormatY0l0 = 0x304c3059
+	// ShmFormatX0l0: [63:0] X3:X2:Y3:0:Cr0:0:Y2:0:X1:X0:Y1:0:Cb0:0:Y0:0 1:1:8:2:8:2:8:2:1:1:8:2:8:2:8:2 little endian
+	ShmFormatX0l0 = 0x304c3058
+	// ShmFormatY0l2: [63:0] A3:A2:Y3:Cr0:Y2:A1:A0:Y1:Cb0:Y0 1:1:10:10:10:1:1:10:10:10 little endian
+	ShmFormatY0l2 = 0x324c3059
+	// ShmFormatX0l2: [63:0] X3:X2:Y3:Cr0:Y2:X1:X0:Y1:Cb0:Y0 1:1:10:10:10:1:1:10:10:10 little endian
+	ShmFormatX0l2        = 0x324c3058
+	ShmFormatYuv4208bit  = 0x38305559
+	ShmFormatYuv42010bit = 0x30315559
+	ShmFormatXrgb8888A8  = 0x38415258
+	ShmFormatXbgr8888A8  = 0x38414258
+	ShmFormatRgbx8888A8  = 0x38415852
+	ShmFormatBgrx8888A8  = 0x38415842
+	ShmFormatRgb888A8    = 0x38413852
+	ShmFormatBgr888A8    = 0x38413842
+	ShmFormatRgb565A8    = 0x38413552
+	ShmFormatBgr565A8    = 0x38413542
+	// ShmFormatNv24: non-subsampled Cr:Cb plane
+	ShmFormatNv24 = 0x3432564e
+	// ShmFormatNv42: non-subsampled Cb:Cr plane
+	ShmFormatNv42 = 0x3234564e
+	// ShmFormatP210: 2x1 subsampled Cr:Cb plane, 10 bit per channel
+	ShmFormatP210 = 0x30313250
+	// ShmFormatP010: 2x2 subsampled Cr:Cb plane 10 bits per channel
+	ShmFormatP010 = 0x30313050
+	// ShmFormatP012: 2x2 subsampled Cr:Cb plane 12 bits per channel
+	ShmFormatP012 = 0x32313050
+	// ShmFormatP016: 2x2 subsampled Cr:Cb plane 16 bits per channel
+	ShmFormatP016 = 0x36313050
+	// ShmFormatAxbxgxrx106106106106: [63:0] A:x:B:x:G:x:R:x 10:6:10:6:10:6:10:6 little endian
+	ShmFormatAxbxgxrx106106106106 = 0x30314241
+	// ShmFormatNv15: 2x2 subsampled Cr:Cb plane
+	ShmFormatNv15 = 0x3531564e
+	ShmFormatQ410 = 0x30313451
+	ShmFormatQ401 = 0x31303451
+	// ShmFormatXrgb16161616: [63:0] x:R:G:B 16:16:16:16 little endian
+	ShmFormatXrgb16161616 = 0x38345258
+	// ShmFormatXbgr16161616: [63:0] x:B:G:R 16:16:16:16 little endian
+	ShmFormatXbgr16161616 = 0x38344258
+	// ShmFormatArgb16161616: [63:0] A:R:G:B 16:16:16:16 little endian
+	ShmFormatArgb16161616 = 0x38345241
+	// ShmFormatAbgr16161616: [63:0] A:B:G:R 16:16:16:16 little endian
+	ShmFormatAbgr16161616 = 0x38344241
+	// ShmFormatC1: [7:0] C0:C1:C2:C3:C4:C5:C6:C7 1:1:1:1:1:1:1:1 eight pixels/byte
+	ShmFormatC1 = 0x20203143
+	// ShmFormatC2: [7:0] C0:C1:C2:C3 2:2:2:2 four pixels/byte
+	ShmFormatC2 = 0x20203243
+	// ShmFormatC4: [7:0] C0:C1 4:4 two pixels/byte
+	ShmFormatC4 = 0x20203443
+	// ShmFormatD1: [7:0] D0:D1:D2:D3:D4:D5:D6:D7 1:1:1:1:1:1:1:1 eight pixels/byte
+	ShmFormatD1 = 0x20203144
+	// ShmFormatD2: [7:0] D0:D1:D2:D3 2:2:2:2 four pixels/byte
+	ShmFormatD2 = 0x20203244
+	// ShmFormatD4: [7:0] D0:D1 4:4 two pixels/byte
+	ShmFormatD4 = 0x20203444
+	// ShmFormatD8: [7:0] D
+	ShmFormatD8 = 0x20203844
+	// ShmFormatR1: [7:0] R0:R1:R2:R3:R4:R5:R6:R7 1:1:1:1:1:1:1:1 eight pixels/byte
+	ShmFormatR1 = 0x20203152
+	// ShmFormatR2: [7:0] R0:R1:R2:R3 2:2:2:2 four pixels/byte
+	ShmFormatR2 = 0x20203252
+	// ShmFormatR4: [7:0] R0:R1 4:4 two pixels/byte
+	ShmFormatR4 = 0x20203452
+	// ShmFormatR10: [15:0] x:R 6:10 little endian
+	ShmFormatR10 = 0x20303152
+	// ShmFormatR12: [15:0] x:R 4:12 little endian
+	ShmFormatR12 = 0x20323152
+	// ShmFormatAvuy8888: [31:0] A:Cr:Cb:Y 8:8:8:8 little endian
+	ShmFormatAvuy8888 = 0x59555641
+	// ShmFormatXvuy8888: [31:0] X:Cr:Cb:Y 8:8:8:8 little endian
+	ShmFormatXvuy8888 = 0x59555658
+	// ShmFormatP030: 2x2 subsampled Cr:Cb plane 10 bits per channel packed
+	ShmFormatP030 = 0x30333050
+)
+
+// ShmCreatePoolRequest: create a shm pool
+//
+// Create a new wl_shm_pool object.
+//
+// The pool can be used to create shared memory based buffer
+// objects.  The server will mmap size bytes of the passed file
+// descriptor, to use as backing memory for the pool.
+type ShmCreatePoolRequest struct {
+	Resource *Shm
+	Id       *ShmPool
+	Fd       uintptr
+	Size     int32
+}
+
+// ShmCreatePoolHandler is implemented by the handlers of ShmCreatePoolRequest, see SetHandler
+type ShmCreatePoolHandler interface {
+	HandleShmCreatePool(ShmCreatePoolRequest)
+}
+
+// ShmReleaseRequest: release the shm object
+//
+// Using this request a client can tell the server that it is not going to
+// use the shm object anymore.
+//
+// Objects created via this interface remain unaffected.
+type ShmReleaseRequest struct {
+	Resource *Shm
+}
+
+// ShmReleaseHandler is implemented by the handlers of ShmReleaseRequest, see SetHandler
+type ShmReleaseHandler interface {
+	HandleShmRelease(ShmReleaseRequest)
+}
+
+// Dispatch decodes a request received on the wl_shm and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Shm) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := ShmCreatePoolRequest{Resource: r}
+		var err error
+		req.Id = new(ShmPool)
+		if err := c.NewId(req.Id, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		if req.Fd, err = event.FD(); err != nil {
+			return err
+		}
+		req.Size = event.Int32()
+		if err := event.Err(); err != nil {
+			CloseFd(req.Fd)
+			return err
+		}
+		if h, ok := r.Handler().(ShmCreatePoolHandler); ok {
+			h.HandleShmCreatePool(req)
+		} else {
+			CloseFd(req.Fd)
+		}
+	case 1:
+		req := ShmReleaseRequest{Resource: r}
+		if h, ok := r.Handler().(ShmReleaseHandler); ok {
+			h.HandleShmRelease(req)
+		}
+	}
+	return nil
+}
+
+// SendFormat: pixel format description
+//
+// Informs the client about a valid pixel format that
+// can be used for buffers. Known formats include
+// argb8888 and xrgb8888.
+//
+//	format: buffer pixel format
+func (r *Shm) SendFormat(format uint32) error {
+	return r.Client().SendEvent(r, 0, format)
+}
+
+// Buffer: content for a wl_surface
+//
+// A buffer provides the content for a wl_surface. Buffers are
+// created through factory interfaces such as wl_drm, wl_shm or
+// similar. It has a width and a height and can be attached to a
+// wl_surface, but the mechanism by which a client provides and
+// updates the contents is defined by the buffer factory interface.
+type Buffer struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_buffer interface
+func (r *Buffer) Interface() *wl.Interface {
+	return wl.BufferInterface
+}
+
+// BufferDestroyRequest: destroy a buffer
+//
+// Destroy a buffer. If and how you need to release the backing
+// storage is defined by the buffer factory interface.
+//
+// For possible side-effects to a surface, see wl_surface.attach.
+type BufferDestroyRequest struct {
+	Resource *Buffer
+}
+
+// BufferDestroyHandler is implemented by the handlers of BufferDestroyRequest, see SetHandler
+type BufferDestroyHandler interface {
+	HandleBufferDestroy(BufferDestroyRequest)
+}
+
+// Dispatch decodes a request received on the wl_buffer and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Buffer) Dispatch(event *wl.Event) error {
+	switch event.Opcode {
+	case 0:
+		req := BufferDestroyRequest{Resource: r}
+		if h, ok := r.Handler().(BufferDestroyHandler); ok {
+			h.HandleBufferDestroy(req)
+		}
+	}
+	return nil
+}
+
+// SendRelease: compositor releases buffer
+//
+// Sent when this wl_buffer is no longer used by the compositor.
+// The client is now free to reuse or destroy this buffer and its
+// backing storage.
+//
+// If a client receives a release event before the frame callback
+// requested in the same wl_surface.commit that attaches this
+// wl_buffer to a surface, then the client is immediately free to
+// reuse the buffer and its backing storage, and does not need a
+// second buffer for the next surface content update. Typically
+// this is possible, when the compositor maintains a copy of the
+// wl_surface contents, e.g. as a GL texture. This is an important
+// optimization for GL(ES) compositors with wl_shm clients.
+func (r *Buffer) SendRelease() error {
+	return r.Client().SendEvent(r, 0)
+}
+
+// DataOffer: offer to transfer data
+//
+// A wl_data_offer represents a piece of data offered for transfer
+// by another client (the source client).  It is used by the
+// copy-and-paste and drag-and-drop mechanisms.  The offer
+// describes the different mime types that the data can be
+// converted to and provides the mechanism for transferring the
+// data directly from the source client.
+type DataOffer struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_data_offer interface
+func (r *DataOffer) Interface() *wl.Interface {
+	return wl.DataOfferInterface
+}
+
+// DataOfferError:
+const (
+	// DataOfferErrorInvalidFinish: finish request was called untimely
+	DataOfferErrorInvalidFinish = 0
+	// DataOfferErrorInvalidActionMask: action mask contains invalid values
+	DataOfferErrorInvalidActionMask = 1
+	// DataOfferErrorInvalidAction: action argument has an invalid value
+	DataOfferErrorInvalidAction = 2
+	// DataOfferErrorInvalidOffer: offer doesn't accept this request
+	DataOfferErrorInvalidOffer = 3
+)
+
+// DataOfferAcceptRequest: accept one of the offered mime types
+//
+// Indicate that the client can accept the given mime type, or
+// NULL for not accepted.
+//
+// For objects of version 2 or older, this request is used by the
+// client to give feedback whether the client can receive the given
+// mime type, or NULL if none is accepted; the feedback does not
+// determine whether the drag-and-drop operation succeeds or not.
+//
+// For objects of version 3 or newer, this request determines the
+// final result of the drag-and-drop operation. If the end result
+// is that no mime types were accepted, the drag-and-drop operation
+// will be cancelled and the corresponding drag source will receive
+// wl_data_source.cancelled. Clients may still use this event in
+// conjunction with wl_data_source.action for feedback.
+type DataOfferAcceptRequest struct {
+	Resource *DataOffer
+	Serial   uint32
+	MimeType string
+}
+
+// DataOfferAcceptHandler is implemented by the handlers of DataOfferAcceptRequest, see SetHandler
+type DataOfferAcceptHandler interface {
+	HandleDataOfferAccept(DataOfferAcceptRequest)
+}
+
+// DataOfferReceiveRequest: request that the data is transferred
+//
+// To transfer the offered data, the client issues this request
+// and indicates the mime type it wants to receive.  The transfer
+// happens through the passed file descriptor (typically created
+// with the pipe system call).  The source client writes the data
+// in the mime type representation requested and then closes the
+// file descriptor.
+//
+// The receiving client reads from the read end of the pipe until
+// EOF and then closes its end, at which point the transfer is
+// complete.
+//
+// This request may happen multiple times for different mime types,
+// both before and after wl_data_device.drop. Drag-and-drop destination
+// clients may preemptively fetch data or examine it more closely to
+// determine acceptance.
+type DataOfferReceiveRequest struct {
+	Resource *DataOffer
+	MimeType string
+	Fd       uintptr
+}
+
+// DataOfferReceiveHandler is implemented by the handlers of DataOfferReceiveRequest, see SetHandler
+type DataOfferReceiveHandler interface {
+	HandleDataOfferReceive(DataOfferReceiveRequest)
+}
+
+// DataOfferDestroyRequest: destroy data offer
+//
+// Destroy the data offer.
+type DataOfferDestroyRequest struct {
+	Resource *DataOffer
+}
+
+// DataOfferDestroyHandler is implemented by the handlers of DataOfferDestroyRequest, see SetHandler
+type DataOfferDestroyHandler interface {
+	HandleDataOfferDestroy(DataOfferDestroyRequest)
+}
+
+// DataOfferFinishRequest: the offer will no longer be used
+//
+// Notifies the compositor that the drag destination successfully
+// finished the drag-and-drop operation.
+//
+// Upon receiving this request, the compositor will emit
+// wl_data_source.dnd_finished on the drag source client.
+//
+// It is a client error to perform other requests than
+// wl_data_offer.destroy after this one. It is also an error to perform
+// this request after a NULL mime type has been set in
+// wl_data_offer.accept or no action was received through
+// wl_data_offer.action.
+//
+// If wl_data_offer.finish request is received for a non drag and drop
+// operation, the invalid_finish protocol error is raised.
+type DataOfferFinishRequest struct {
+	Resource *DataOffer
+}
+
+// DataOfferFinishHandler is implemented by the handlers of DataOfferFinishRequest, see SetHandler
+type DataOfferFinishHandler interface {
+	HandleDataOfferFinish(DataOfferFinishRequest)
+}
+
+// DataOfferSetActionsRequest: set the available/preferred drag-and-drop actions
+//
+// Sets the actions that the destination side client supports for
+// this operation. This request may trigger the emission of
+// wl_data_source.action and wl_data_offer.action events if the compositor
+// needs to change the selected action.
+//
+// This request can be called multiple times throughout the
+// drag-and-drop operation, typically in response to wl_data_device.enter
+// or wl_data_device.motion events.
+//
+// This request determines the final result of the drag-and-drop
+// operation. If the end result is that no action is accepted,
+// the drag source will receive wl_data_source.cancelled.
+//
+// The dnd_actions argument must contain only values expressed in the
+// wl_data_device_manager.dnd_actions enum, and the preferred_action
+// argument must only contain one of those values set, otherwise it
+// will result in a protocol error.
+//
+// While managing an "ask" action, the destination drag-and-drop client
+// may perform further wl_data_offer.receive requests, and is expected
+// to perform one last wl_data_offer.set_actions request with a preferred
+// action other than "ask" (and optionally wl_data_offer.accept) before
+// requesting wl_data_offer.finish, in order to convey the action selected
+// by the user. If the preferred action is not in the
+// wl_data_offer.source_actions mask, an error will be raised.
+//
+// If the "ask" action is dismissed (e.g. user cancellation), the client
+// is expected to perform wl_data_offer.destroy right away.
+//
+// This request can only be made on drag-and-drop offers, a protocol error
+// will be raised otherwise.
+type DataOfferSetActionsRequest struct {
+	Resource        *DataOffer
+	DndActions      uint32
+	PreferredAction uint32
+}
+
+// DataOfferSetActionsHandler is implemented by the handlers of DataOfferSetActionsRequest, see SetHandler
+type DataOfferSetActionsHandler interface {
+	HandleDataOfferSetActions(DataOfferSetActionsRequest)
+}
+
+// Dispatch decodes a request received on the wl_data_offer and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *DataOffer) Dispatch(event *wl.Event) error {
+	switch event.Opcode {
+	case 0:
+		req := DataOfferAcceptRequest{Resource: r}
+		req.Serial = event.Uint32()
+		req.MimeType = event.String()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(DataOfferAcceptHandler); ok {
+			h.HandleDataOfferAccept(req)
+		}
+	case 1:
+		req := DataOfferReceiveRequest{Resource: r}
+		var err error
+		req.MimeType = event.String()
+		if req.Fd, err = event.FD(); err != nil {
+			return err
+		}
+		if err := event.Err(); err != nil {
+			CloseFd(req.Fd)
+			return err
+		}
+		if h, ok := r.Handler().(DataOfferReceiveHandler); ok {
+			h.HandleDataOfferReceive(req)
+		} else {
+			CloseFd(req.Fd)
+		}
+	case 2:
+		req := DataOfferDestroyRequest{Resource: r}
+		if h, ok := r.Handler().(DataOfferDestroyHandler); ok {
+			h.HandleDataOfferDestroy(req)
+		}
+	case 3:
+		req := DataOfferFinishRequest{Resource: r}
+		if h, ok := r.Handler().(DataOfferFinishHandler); ok {
+			h.HandleDataOfferFinish(req)
+		}
+	case 4:
+		req := DataOfferSetActionsRequest{Resource: r}
+		req.DndActions = event.Uint32()
+		req.PreferredAction = event.Uint32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(DataOfferSetActionsHandler); ok {
+			h.HandleDataOfferSetActions(req)
+		}
+	}
+	return nil
+}
+
+// SendOffer: advertise offered mime type
+//
+// Sent immediately after creating the wl_data_offer object.  One
+// event per offered mime type.
+//
+//	mimeType: offered mime type
+func (r *DataOffer) SendOffer(mimeType string) error {
+	return r.Client().SendEvent(r, 0, mimeType)
+}
+
+// SendSourceActions: notify the source-side available actions
+//
+// This event indicates the actions offered by the data source. It
+// will be sent right after wl_data_device.enter, or anytime the source
+// side changes its offered actions through wl_data_source.set_actions.
+//
+//	sourceActions: actions offered by the data source
+func (r *DataOffer) SendSourceActions(sourceActions uint32) error {
+	if err := CheckEventVersion(r, 1); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 1, sourceActions)
+}
+
+// SendAction: notify the selected action
+//
+// This event indicates the action selected by the compositor after
+// matching the source/destination side actions. Only one action (or
+// none) will be offered here.
+//
+// This event can be emitted multiple times during the drag-and-drop
+// operation in response to destination side action changes through
+// wl_data_offer.set_actions.
+//
+// This event will no longer be emitted after wl_data_device.drop
+// happened on the drag-and-drop destination, the client must
+// honor the last action received, or the last preferred one set
+// through wl_data_offer.set_actions when handling an "ask" action.
+//
+// Compositors may also change the selected action on the fly, mainly
+// in response to keyboard modifier changes during the drag-and-drop
+// operation.
+//
+// The most recent action received is always the valid one. Prior to
+// receiving wl_data_device.drop, the chosen action may change (e.g.
+// due to keyboard modifiers being pressed). At the time of receiving
+// wl_data_device.drop the drag-and-drop destination must honor the
+// last action received.
+//
+// Action changes may still happen after wl_data_device.drop,
+// especially on "ask" actions, where the drag-and-drop destination
+// may choose another action afterwards. Action changes happening
+// at this stage are always the result of inter-client negotiation, the
+// compositor shall no longer be able to induce a different action.
+//
+// Upon "ask" actions, it is expected that the drag-and-drop destination
+// may potentially choose a different action and/or mime type,
+// based on wl_data_offer.source_actions and finally chosen by the
+// user (e.g. popping up a menu with the available options). The
+// final wl_data_offer.set_actions and wl_data_offer.accept requests
+// must happen before the call to wl_data_offer.finish.
+//
+//	dndAction: action selected by the compositor
+func (r *DataOffer) SendAction(dndAction uint32) error {
+	if err := CheckEventVersion(r, 2); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 2, dndAction)
+}
+
+// DataSource: offer to transfer data
+//
+// The wl_data_source object is the source side of a wl_data_offer.
+// It is created by the source client in a data transfer and
+// provides a way to describe the offered data and a way to respond
+// to requests to transfer the data.
+type DataSource struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_data_source interface
+func (r *DataSource) Interface() *wl.Interface {
+	return wl.DataSourceInterface
+}
+
+// DataSourceError:
+const (
+	// DataSourceErrorInvalidActionMask: action mask contains invalid values
+	DataSourceErrorInvalidActionMask = 0
+	// DataSourceErrorInvalidSource: source doesn't accept this request
+	DataSourceErrorInvalidSource = 1
+)
+
+// DataSourceOfferRequest: add an offered mime type
+//
+// This request adds a mime type to the set of mime types
+// advertised to targets.  Can be called several times to offer
+// multiple types.
+type DataSourceOfferRequest struct {
+	Resource *DataSource
+	MimeType string
+}
+
+// DataSourceOfferHandler is implemented by the handlers of DataSourceOfferRequest, see SetHandler
+type DataSourceOfferHandler interface {
+	HandleDataSourceOffer(DataSourceOfferRequest)
+}
+
+// DataSourceDestroyRequest: destroy the data source
+//
+// Destroy the data source.
+type DataSourceDestroyRequest struct {
+	Resource *DataSource
+}
+
+// DataSourceDestroyHandler is implemented by the handlers of DataSourceDestroyRequest, see SetHandler
+type DataSourceDestroyHandler interface {
+	HandleDataSourceDestroy(DataSourceDestroyRequest)
+}
+
+// DataSourceSetActionsRequest: set the available drag-and-drop actions
+//
+// Sets the actions that the source side client supports for this
+// operation. This request may trigger wl_data_source.action and
+// wl_data_offer.action events if the compositor needs to change the
+// selected action.
+//
+// The dnd_actions argument must contain only values expressed in the
+// wl_data_device_manager.dnd_actions enum, otherwise it will result
+// in a protocol error.
+//
+// This request must be made once only, and can only be made on sources
+// used in drag-and-drop, so it must be performed before
+// wl_data_device.start_drag. Attempting to use the source other than
+// for drag-and-drop will raise a protocol error.
+type DataSourceSetActionsRequest struct {
+	Resource   *DataSource
+	DndActions uint32
+}
+
+// DataSourceSetActionsHandler is implemented by the handlers of DataSourceSetActionsRequest, see SetHandler
+type DataSourceSetActionsHandler interface {
+	HandleDataSourceSetActions(DataSourceSetActionsRequest)
+}
+
+// Dispatch decodes a request received on the wl_data_source and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *DataSource) Dispatch(event *wl.Event) error {
+	switch event.Opcode {
+	case 0:
+		req := DataSourceOfferRequest{Resource: r}
+		req.MimeType = event.String()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(DataSourceOfferHandler); ok {
+			h.HandleDataSourceOffer(req)
+		}
+	case 1:
+		req := DataSourceDestroyRequest{Resource: r}
+		if h, ok := r.Handler().(DataSourceDestroyHandler); ok {
+			h.HandleDataSourceDestroy(req)
+		}
+	case 2:
+		req := DataSourceSetActionsRequest{Resource: r}
+		req.DndActions = event.Uint32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(DataSourceSetActionsHandler); ok {
+			h.HandleDataSourceSetActions(req)
+		}
+	}
+	return nil
+}
+
+// SendTarget: a target accepts an offered mime type
+//
+// Sent when a target accepts pointer_focus or motion events.  If
+// a target does not accept any of the offered types, type is NULL.
+//
+// Used for feedback during drag-and-drop.
+//
+//	mimeType: mime type accepted by the target
+func (r *DataSource) SendTarget(mimeType string) error {
+	return r.Client().SendEvent(r, 0, mimeType)
+}
+
+// SendSend: send the data
+//
+// Request for data from the client.  Send the data as the
+// specified mime type over the passed file descriptor, then
+// close it.
+//
+//	mimeType: mime type for the data
+//	fd: file descriptor for the data
+func (r *DataSource) SendSend(mimeType string, fd uintptr) error {
+	return r.Client().SendEvent(r, 1, mimeType, fd)
+}
+
+// SendCancelled: selection was cancelled
+//
+// This data source is no longer valid. There are several reasons why
+// this could happen:
+//
+// - The data source has been replaced by another data source.
+// - The drag-and-drop operation was performed, but the drop destination
+// did not accept any of the mime types offered through
+// wl_data_source.target.
+// - The drag-and-drop operation was performed, but the drop destination
+// did not select any of the actions present in the mask offered through
+// wl_data_source.action.
+// - The drag-and-drop operation was performed but didn't happen over a
+// surface.
+// - The compositor cancelled the drag-and-drop operation (e.g. compositor
+// dependent timeouts to avoid stale drag-and-drop transfers).
+//
+// The client should clean up and destroy this data source.
+//
+// For objects of version 2 or older, wl_data_source.cancelled will
+// only be emitted if the data source was replaced by another data
+// source.
+func (r *DataSource) SendCancelled() error {
+	return r.Client().SendEvent(r, 2)
+}
+
+// SendDndDropPerformed: the drag-and-drop operation physically finished
+//
+// The user performed the drop action. This event does not indicate
+// acceptance, wl_data_source.cancelled may still be emitted afterwards
+// if the drop destination does not accept any mime type.
+//
+// However, this event might however not be received if the compositor
+// cancelled the drag-and-drop operation before this event could happen.
+//
+// Note that the data_source may still be used in the future and should
+// not be destroyed here.
+func (r *DataSource) SendDndDropPerformed() error {
+	if err := CheckEventVersion(r, 3); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 3)
+}
+
+// SendDndFinished: the drag-and-drop operation concluded
+//
+// The drop destination finished interoperating with this data
+// source, so the client is now free to destroy this data source and
+// free all associated data.
+//
+// If the action used to perform the operation was "move", the
+// source can now delete the transferred data.
+func (r *DataSource) SendDndFinished() error {
+	if err := CheckEventVersion(r, 4); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 4)
+}
+
+// SendAction: notify the selected action
+//
+// This event indicates the action selected by the compositor after
+// matching the source/destination side actions. Only one action (or
+// none) will be offered here.
+//
+// This event can be emitted multiple times during the drag-and-drop
+// operation, mainly in response to destination side changes through
+// wl_data_offer.set_actions, and as the data device enters/leaves
+// surfaces.
+//
+// It is only possible to receive this event after
+// wl_data_source.dnd_drop_performed if the drag-and-drop operation
+// ended in an "ask" action, in which case the final wl_data_source.action
+// event will happen immediately before wl_data_source.dnd_finished.
+//
+// Compositors may also change the selected action on the fly, mainly
+// in response to keyboard modifier changes during the drag-and-drop
+// operation.
+//
+// The most recent action received is always the valid one. The chosen
+// action may change alongside negotiation (e.g. an "ask" action can turn
+// into a "move" operation), so the effects of the final action must
+// always be applied in wl_data_offer.dnd_finished.
+//
+// Clients can trigger cursor surface changes from this point, so
+// they reflect the current action.
+//
+//	dndAction: action selected by the compositor
+func (r *DataSource) SendAction(dndAction uint32) error {
+	if err := CheckEventVersion(r, 5); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 5, dndAction)
+}
+
+// DataDevice: data transfer device
+//
+// There is one wl_data_device per seat which can be obtained
+// from the global wl_data_device_manager singleton.
+//
+// A wl_data_device provides access to inter-client data transfer
+// mechanisms such as copy-and-paste and drag-and-drop.
+type DataDevice struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_data_device interface
+func (r *DataDevice) Interface() *wl.Interface {
+	return wl.DataDeviceInterface
+}
+
+// DataDeviceError:
+const (
+	// DataDeviceErrorRole: given wl_surface has another role
+	DataDeviceErrorRole = 0
+	// DataDeviceErrorUsedSource: source has already been used
+	DataDeviceErrorUsedSource = 1
+)
+
+// DataDeviceStartDragRequest: start drag-and-drop operation
+//
+// This request asks the compositor to start a drag-and-drop
+// operation on behalf of the client.
+//
+// The source argument is the data source that provides the data
+// for the eventual data transfer. If source is NULL, enter, leave
+// and motion events are sent only to the client that initiated the
+// drag and the client is expected to handle the data passing
+// internally. If source is destroyed, the drag-and-drop session will be
+// cancelled.
+//
+// The origin surface is the surface where the drag originates and
+// the client must have an active implicit grab that matches the
+// serial.
+//
+// The icon surface is an optional (can be NULL) surface that
+// provides an icon to be moved around with the cursor.  Initially,
+// the top-left corner of the icon surface is placed at the cursor
+// hotspot, but subsequent wl_surface.attach request can move the
+// relative position. Attach requests must be confirmed with
+// wl_surface.commit as usual. The icon surface is given the role of
+// a drag-and-drop icon. If the icon surface already has another role,
+// it raises a protocol error.
+//
+// The current and pending input regions of the icon wl_surface are
+// cleared, and wl_surface.set_input_region is ignored until the
+// wl_surface is no longer used as the icon surface. When the use
+// as an icon ends, the current and pending input regions become
+// undefined, and the wl_surface is unmapped.
+type DataDeviceStartDragRequest struct {
+	Resource *DataDevice
+	Source   *DataSource
+	Origin   *Surface
+	Icon     *Surface
+	Serial   uint32
+}
+
+// DataDeviceStartDragHandler is implemented by the handlers of DataDeviceStartDragRequest, see SetHandler
+type DataDeviceStartDragHandler interface {
+	HandleDataDeviceStartDrag(DataDeviceStartDragRequest)
+}
+
+// DataDeviceSetSelectionRequest: copy data to the selection
+//
+// This request asks the compositor to set the selection
+// to the data from the source on behalf of the client.
+//
+// To unset the selection, set the source to NULL.
+type DataDeviceSetSelectionRequest struct {
+	Resource *DataDevice
+	Source   *DataSource
+	Serial   uint32
+}
+
+// DataDeviceSetSelectionHandler is implemented by the handlers of DataDeviceSetSelectionRequest, see SetHandler
+type DataDeviceSetSelectionHandler interface {
+	HandleDataDeviceSetSelection(DataDeviceSetSelectionRequest)
+}
+
+// DataDeviceReleaseRequest: destroy data device
+//
+// This request destroys the data device.
+type DataDeviceReleaseRequest struct {
+	Resource *DataDevice
+}
+
+// DataDeviceReleaseHandler is implemented by the handlers of DataDeviceReleaseRequest, see SetHandler
+type DataDeviceReleaseHandler interface {
+	HandleDataDeviceRelease(DataDeviceReleaseRequest)
+}
+
+// Dispatch decodes a request received on the wl_data_device and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *DataDevice) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := DataDeviceStartDragRequest{Resource: r}
+		var err error
+		var sourceRes Resource
+		if sourceRes, err = c.Argument(event.Uint32(), true, wl.DataSourceInterface); err != nil {
+			return err
+		}
+		req.Source, _ = sourceRes.(*DataSource)
+		var originRes Resource
+		if originRes, err = c.Argument(event.Uint32(), false, wl.SurfaceInterface); err != nil {
+			return err
+		}
+		req.Origin, _ = originRes.(*Surface)
+		var iconRes Resource
+		if iconRes, err = c.Argument(event.Uint32(), true, wl.SurfaceInterface); err != nil {
+			return err
+		}
+		req.Icon, _ = iconRes.(*Surface)
+		req.Serial = event.Uint32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(DataDeviceStartDragHandler); ok {
+			h.HandleDataDeviceStartDrag(req)
+		}
+	case 1:
+		req := DataDeviceSetSelectionRequest{Resource: r}
+		var err error
+		var sourceRes Resource
+		if sourceRes, err = c.Argument(event.Uint32(), true, wl.DataSourceInterface); err != nil {
+			return err
+		}
+		req.Source, _ = sourceRes.(*DataSource)
+		req.Serial = event.Uint32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(DataDeviceSetSelectionHandler); ok {
+			h.HandleDataDeviceSetSelection(req)
+		}
+	case 2:
+		req := DataDeviceReleaseRequest{Resource: r}
+		if h, ok := r.Handler().(DataDeviceReleaseHandler); ok {
+			h.HandleDataDeviceRelease(req)
+		}
+	}
+	return nil
+}
+
+// SendDataOffer: introduce a new wl_data_offer
+//
+// The data_offer event introduces a new wl_data_offer object,
+// which will subsequently be used in either the
+// data_device.enter event (for drag-and-drop) or the
+// data_device.selection event (for selections).  Immediately
+// following the data_device_data_offer event, the new data_offer
+// object will send out data_offer.offer events to describe the
+// mime types it offers.
+//
+//	id: the new data_offer object
+func (r *DataDevice) SendDataOffer() (*DataOffer, error) {
+	ret := new(DataOffer)
+	r.Client().NewResource(ret, r.Version())
+	return ret, r.Client().SendEvent(r, 0, ret)
+}
+
+// SendEnter: initiate drag-and-drop session
+//
+// This event is sent when an active drag-and-drop pointer enters
+// a surface owned by the client.  The position of the pointer at
+// enter time is provided by the x and y arguments, in surface-local
+// coordinates.
+//
+//	serial: serial number of the enter event
+//	surface: client surface entered
+//	x: surface-local x coordinate
+//	y: surface-local y coordinate
+//	id: source data_offer object
+func (r *DataDevice) SendEnter(serial uint32, surface *Surface, x float32, y float32, id *DataOffer) error {
+	return r.Client().SendEvent(r, 1, serial, surface, x, y, id)
+}
+
+// SendLeave: end drag-and-drop session
+//
+// This event is sent when the drag-and-drop pointer leaves the
+// surface and the session ends.  The client must destroy the
+// wl_data_offer introduced at enter time at this point.
+func (r *DataDevice) SendLeave() error {
+	return r.Client().SendEvent(r, 2)
+}
+
+// SendMotion: drag-and-drop session motion
+//
+// This event is sent when the drag-and-drop pointer moves within
+// the currently focused surface. The new position of the pointer
+// is provided by the x and y arguments, in surface-local
+// coordinates.
+//
+//	time: timestamp with millisecond granularity
+//	x: surface-local x coordinate
+//	y: surface-local y coordinate
+func (r *DataDevice) SendMotion(time uint32, x float32, y float32) error {
+	return r.Client().SendEvent(r, 3, time, x, y)
+}
+
+// SendDrop: end drag-and-drop session successfully
+//
+// The event is sent when a drag-and-drop operation is ended
+// because the implicit grab is removed.
+//
+// The drag-and-drop destination is expected to honor the last action
+// received through wl_data_offer.action, if the resulting action is
+// "copy" or "move", the destination can still perform
+// wl_data_offer.receive requests, and is expected to end all
+// transfers with a wl_data_offer.finish request.
+//
+// If the resulting action is "ask", the action will not be considered
+// final. The drag-and-drop destination is expected to perform one last
+// wl_data_offer.set_actions request, or wl_data_offer.destroy in order
+// to cancel the operation.
+func (r *DataDevice) SendDrop() error {
+	return r.Client().SendEvent(r, 4)
+}
+
+// SendSelection: advertise new selection
+//
+// The selection event is sent out to notify the client of a new
+// wl_data_offer for the selection for this device.  The
+// data_device.data_offer and the data_offer.offer events are
+// sent out immediately before this event to introduce the data
+// offer object.  The selection event is sent to a client
+// immediately before receiving keyboard focus and when a new
+// selection is set while the client has keyboard focus.  The
+// data_offer is valid until a new data_offer or NULL is received
+// or until the client loses keyboard focus.  The client must
+// destroy the previous selection data_offer, if any, upon receiving
+// this event.
+//
+//	id: selection data_offer object
+func (r *DataDevice) SendSelection(id *DataOffer) error {
+	return r.Client().SendEvent(r, 5, id)
+}
+
+// DataDeviceManager: data transfer interface
+//
+// The wl_data_device_manager is a singleton global object that
+// provides access to inter-client data transfer mechanisms such as
+// copy-and-paste and drag-and-drop.  These mechanisms are tied to
+// a wl_seat and this interface lets a client get a wl_data_device
+// corresponding to a wl_seat.
+//
+// Depending on the version bound, the objects created from the bound
+// wl_data_device_manager object will have different requirements for
+// functioning properly. See wl_data_source.set_actions,
+// wl_data_offer.accept and wl_data_offer.finish for details.
+type DataDeviceManager struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_data_device_manager interface
+func (r *DataDeviceManager) Interface() *wl.Interface {
+	return wl.DataDeviceManagerInterface
+}
+
+// DataDeviceManagerDndAction: drag and drop actions
+//
+// This is a bitmask of the available/preferred actions in a
+// drag-and-drop operation.
+//
+// In the compositor, the selected action is a result of matching the
+// actions offered by the source and destination sides.  "action" events
+// with a "none" action will be sent to both source and destination if
+// there is no match. All further checks will effectively happen on
+// (source actions ∩ destination actions).
+//
+// In addition, compositors may also pick different actions in
+// reaction to key modifiers being pressed. One common design that
+// is used in major toolkits (and the behavior recommended for
+// compositors) is:
+//
+// - If no modifiers are pressed, the first match (in bit order)
+// will be used.
+// - Pressing Shift selects "move", if enabled in the mask.
+// - Pressing Control selects "copy", if enabled in the mask.
+//
+// Behavior beyond that is considered implementation-dependent.
+// Compositors may for example bind other modifiers (like Alt/Meta)
+// or drags initiated with other buttons than BTN_LEFT to specific
+// actions (e.g. "ask").
+const (
+	// DataDeviceManagerDndActionNone: no action
+	DataDeviceManagerDndActionNone = 0
+	// DataDeviceManagerDndActionCopy: copy action
+	DataDeviceManagerDndActionCopy = 1
+	// DataDeviceManagerDndActionMove: move action
+	DataDeviceManagerDndActionMove = 2
+	// DataDeviceManagerDndActionAsk: ask action
+	DataDeviceManagerDndActionAsk = 4
+)
+
+// DataDeviceManagerCreateDataSourceRequest: create a new data source
+//
+// Create a new data source.
+type DataDeviceManagerCreateDataSourceRequest struct {
+	Resource *DataDeviceManager
+	Id       *DataSource
+}
+
+// DataDeviceManagerCreateDataSourceHandler is implemented by the handlers of DataDeviceManagerCreateDataSourceRequest, see SetHandler
+type DataDeviceManagerCreateDataSourceHandler interface {
+	HandleDataDeviceManagerCreateDataSource(DataDeviceManagerCreateDataSourceRequest)
+}
+
+// DataDeviceManagerGetDataDeviceRequest: create a new data device
+//
+// Create a new data device for a given seat.
+type DataDeviceManagerGetDataDeviceRequest struct {
+	Resource *DataDeviceManager
+	Id       *DataDevice
+	Seat     *Seat
+}
+
+// DataDeviceManagerGetDataDeviceHandler is implemented by the handlers of DataDeviceManagerGetDataDeviceRequest, see SetHandler
+type DataDeviceManagerGetDataDeviceHandler interface {
+	HandleDataDeviceManagerGetDataDevice(DataDeviceManagerGetDataDeviceRequest)
+}
+
+// Dispatch decodes a request received on the wl_data_device_manager and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *DataDeviceManager) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := DataDeviceManagerCreateDataSourceRequest{Resource: r}
+		req.Id = new(DataSource)
+		if err := c.NewId(req.Id, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(DataDeviceManagerCreateDataSourceHandler); ok {
+			h.HandleDataDeviceManagerCreateDataSource(req)
+		}
+	case 1:
+		req := DataDeviceManagerGetDataDeviceRequest{Resource: r}
+		var err error
+		req.Id = new(DataDevice)
+		if err := c.NewId(req.Id, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		var seatRes Resource
+		if seatRes, err = c.Argument(event.Uint32(), false, wl.SeatInterface); err != nil {
+			return err
+		}
+		req.Seat, _ = seatRes.(*Seat)
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(DataDeviceManagerGetDataDeviceHandler); ok {
+			h.HandleDataDeviceManagerGetDataDevice(req)
+		}
+	}
+	return nil
+}
+
+// Shell: create desktop-style surfaces
+//
+// This interface is implemented by servers that provide
+// desktop-style user interfaces.
+//
+// It allows clients to associate a wl_shell_surface with
+// a basic surface.
+//
+// Note! This protocol is deprecated and not intended for production use.
+// For desktop-style user interfaces, use xdg_shell.
+type Shell struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_shell interface
+func (r *Shell) Interface() *wl.Interface {
+	return wl.ShellInterface
+}
+
+// ShellError:
+const (
+	// ShellErrorRole: given wl_surface has another role
+	ShellErrorRole = 0
+)
+
+// ShellGetShellSurfaceRequest: create a shell surface from a surface
+//
+// Create a shell surface for an existing surface. This gives
+// the wl_surface the role of a shell surface. If the wl_surface
+// already has another role, it raises a protocol error.
+//
+// Only one shell surface can be associated with a given surface.
+type ShellGetShellSurfaceRequest struct {
+	Resource *Shell
+	Id       *ShellSurface
+	Surface  *Surface
+}
+
+// ShellGetShellSurfaceHandler is implemented by the handlers of ShellGetShellSurfaceRequest, see SetHandler
+type ShellGetShellSurfaceHandler interface {
+	HandleShellGetShellSurface(ShellGetShellSurfaceRequest)
+}
+
+// Dispatch decodes a request received on the wl_shell and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Shell) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := ShellGetShellSurfaceRequest{Resource: r}
+		var err error
+		req.Id = new(ShellSurface)
+		if err := c.NewId(req.Id, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		var surfaceRes Resource
+		if surfaceRes, err = c.Argument(event.Uint32(), false, wl.SurfaceInterface); err != nil {
+			return err
+		}
+		req.Surface, _ = surfaceRes.(*Surface)
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(ShellGetShellSurfaceHandler); ok {
+			h.HandleShellGetShellSurface(req)
+		}
+	}
+	return nil
+}
+
+// ShellSurface: desktop-style metadata interface
+//
+// An interface that may be implemented by a wl_surface, for
+// implementations that provide a desktop-style user interface.
+//
+// It provides requests to treat surfaces like toplevel, fullscreen
+// or popup windows, move, resize or maximize them, associate
+// metadata like title and class, etc.
+//
+// On the server side the object is automatically destroyed when
+// the related wl_surface is destroyed. On the client side,
+// wl_shell_surface_destroy() must be called before destroying
+// the wl_surface object.
+type ShellSurface struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_shell_surface interface
+func (r *ShellSurface) Interface() *wl.Interface {
+	return wl.ShellSurfaceInterface
+}
+
+// ShellSurfaceResize: edge values for resizing
+//
+// These values are used to indicate which edge of a surface
+// is being dragged in a resize operation. The server may
+// use this information to adapt its behavior, e.g. choose
+// an appropriate cursor image.
+const (
+	// ShellSurfaceResizeNone: no edge
+	ShellSurfaceResizeNone = 0
+	// ShellSurfaceResizeTop: top edge
+	ShellSurfaceResizeTop = 1
+	// ShellSurfaceResizeBottom: bottom edge
+	ShellSurfaceResizeBottom = 2
+	// ShellSurfaceResizeLeft: left edge
+	ShellSurfaceResizeLeft = 4
+	// ShellSurfaceResizeTopLeft: top and left edges
+	ShellSurfaceResizeTopLeft = 5
+	// ShellSurfaceResizeBottomLeft: bottom and left edges
+	ShellSurfaceResizeBottomLeft = 6
+	// ShellSurfaceResizeRight: right edge
+	ShellSurfaceResizeRight = 8
+	// ShellSurfaceResizeTopRight: top and right edges
+	ShellSurfaceResizeTopRight = 9
+	// ShellSurfaceResizeBottomRight: bottom and right edges
+	ShellSurfaceResizeBottomRight = 10
+)
+
+// ShellSurfaceTransient: details of transient behaviour
+//
+// These flags specify details of the expected behaviour
+// of transient surfaces. Used in the set_transient request.
+const (
+	// ShellSurfaceTransientInactive: do not set keyboard focus
+	ShellSurfaceTransientInactive = 0x1
+)
+
+// ShellSurfaceFullscreenMethod: different method to set the surface fullscreen
+//
+// Hints to indicate to the compositor how to deal with a conflict
+// between the dimensions of the surface and the dimensions of the
+// output. The compositor is free to ignore this parameter.
+const (
+	// ShellSurfaceFullscreenMethodDefault: no preference, apply default policy
+	ShellSurfaceFullscreenMethodDefault = 0
+	// ShellSurfaceFullscreenMethodScale: scale, preserve the surface's aspect ratio and center on output
+	ShellSurfaceFullscreenMethodScale = 1
+	// ShellSurfaceFullscreenMethodDriver: switch output mode to the smallest mode that can fit the surface, add black borders to compensate size mismatch
+	ShellSurfaceFullscreenMethodDriver = 2
+	// ShellSurfaceFullscreenMethodFill: no upscaling, center on output and add black borders to compensate size mismatch
+	ShellSurfaceFullscreenMethodFill = 3
+)
+
+// ShellSurfacePongRequest: respond to a ping event
+//
+// A client must respond to a ping event with a pong request or
+// the client may be deemed unresponsive.
+type ShellSurfacePongRequest struct {
+	Resource *ShellSurface
+	Serial   uint32
+}
+
+// ShellSurfacePongHandler is implemented by the handlers of ShellSurfacePongRequest, see SetHandler
+type ShellSurfacePongHandler interface {
+	HandleShellSurfacePong(ShellSurfacePongRequest)
+}
+
+// ShellSurfaceMoveRequest: start an interactive move
+//
+// Start a pointer-driven move of the surface.
+//
+// This request must be used in response to a button press event.
+// The server may ignore move requests depending on the state of
+// the surface (e.g. fullscreen or maximized).
+type ShellSurfaceMoveRequest struct {
+	Resource *ShellSurface
+	Seat     *Seat
+	Serial   uint32
+}
+
+// ShellSurfaceMoveHandler is implemented by the handlers of ShellSurfaceMoveRequest, see SetHandler
+type ShellSurfaceMoveHandler interface {
+	HandleShellSurfaceMove(ShellSurfaceMoveRequest)
+}
+
+// ShellSurfaceResizeRequest: start an interactive resize
+//
+// Start a pointer-driven resizing of the surface.
+//
+// This request must be used in response to a button press event.
+// The server may ignore resize requests depending on the state of
+// the surface (e.g. fullscreen or maximized).
+type ShellSurfaceResizeRequest struct {
+	Resource *ShellSurface
+	Seat     *Seat
+	Serial   uint32
+	Edges    uint32
+}
+
+// ShellSurfaceResizeHandler is implemented by the handlers of ShellSurfaceResizeRequest, see SetHandler
+type ShellSurfaceResizeHandler interface {
+	HandleShellSurfaceResize(ShellSurfaceResizeRequest)
+}
+
+// ShellSurfaceSetToplevelRequest: make the surface a toplevel surface
+//
+// Map the surface as a toplevel surface.
+//
+// A toplevel surface is not fullscreen, maximized or transient.
+type ShellSurfaceSetToplevelRequest struct {
+	Resource *ShellSurface
+}
+
+// ShellSurfaceSetToplevelHandler is implemented by the handlers of ShellSurfaceSetToplevelRequest, see SetHandler
+type ShellSurfaceSetToplevelHandler interface {
+	HandleShellSurfaceSetToplevel(ShellSurfaceSetToplevelRequest)
+}
+
+// ShellSurfaceSetTransientRequest: make the surface a transient surface
+//
+// Map the surface relative to an existing surface.
+//
+// The x and y arguments specify the location of the upper left
+// corner of the surface relative to the upper left corner of the
+// parent surface, in surface-local coordinates.
+//
+// The flags argument controls details of the transient behaviour.
+type ShellSurfaceSetTransientRequest struct {
+	Resource *ShellSurface
+	Parent   *Surface
+	X        int32
+	Y        int32
+	Flags    uint32
+}
+
+// ShellSurfaceSetTransientHandler is implemented by the handlers of ShellSurfaceSetTransientRequest, see SetHandler
+type ShellSurfaceSetTransientHandler interface {
+	HandleShellSurfaceSetTransient(ShellSurfaceSetTransientRequest)
+}
+
+// ShellSurfaceSetFullscreenRequest: make the surface a fullscreen surface
+//
+// Map the surface as a fullscreen surface.
+//
+// If an output parameter is given then the surface will be made
+// fullscreen on that output. If the client does not specify the
+// output then the compositor will apply its policy - usually
+// choosing the output on which the surface has the biggest surface
+// area.
+//
+// The client may specify a method to resolve a size conflict
+// between the output size and the surface size - this is provided
+// through the method parameter.
+//
+// The framerate parameter is used only when the method is set
+// to "driver", to indicate the preferred framerate. A value of 0
+// indicates that the client does not care about framerate.  The
+// framerate is specified in mHz, that is framerate of 60000 is 60Hz.
+//
+// A method of "scale" or "driver" implies a scaling operation of
+// the surface, either via a direct scaling operation or a change of
+// the output mode. This will override any kind of output scaling, so
+// that mapping a surface with a buffer size equal to the mode can
+// fill the screen independent of buffer_scale.
+//
+// A method of "fill" means we don't scale up the buffer, however
+// any output scale is applied. This means that you may run into
+// an edge case where the application maps a buffer with the same
+// size of the output mode but buffer_scale 1 (thus making a
+// surface larger than the output). In this case it is allowed to
+// downscale the results to fit the screen.
+//
+// The compositor must reply to this request with a configure event
+// with the dimensions for the output on which the surface will
+// be made fullscreen.
+type ShellSurfaceSetFullscreenRequest struct {
+	Resource  *ShellSurface
+	Method    uint32
+	Framerate uint32
+	Output    *Output
+}
+
+// ShellSurfaceSetFullscreenHandler is implemented by the handlers of ShellSurfaceSetFullscreenRequest, see SetHandler
+type ShellSurfaceSetFullscreenHandler interface {
+	HandleShellSurfaceSetFullscreen(ShellSurfaceSetFullscreenRequest)
+}
+
+// ShellSurfaceSetPopupRequest: make the surface a popup surface
+//
+// Map the surface as a popup.
+//
+// A popup surface is a transient surface with an added pointer
+// grab.
+//
+// An existing implicit grab will be changed to owner-events mode,
+// and the popup grab will continue after the implicit grab ends
+// (i.e. releasing the mouse button does not cause the popup to
+// be unmapped).
+//
+// The popup grab continues until the window is destroyed or a
+// mouse button is pressed in any other client's window. A click
+// in any of the client's surfaces is reported as normal, however,
+// clicks in other clients' surfaces will be discarded and trigger
+// the callback.
+//
+// The x and y arguments specify the location of the upper left
+// corner of the surface relative to the upper left corner of the
+// parent surface, in surface-local coordinates.
+type ShellSurfaceSetPopupRequest struct {
+	Resource *ShellSurface
+	Seat     *Seat
+	Serial   uint32
+	Parent   *Surface
+	X        int32
+	Y        int32
+	Flags    uint32
+}
+
+// ShellSurfaceSetPopupHandler is implemented by the handlers of ShellSurfaceSetPopupRequest, see SetHandler
+type ShellSurfaceSetPopupHandler interface {
+	HandleShellSurfaceSetPopup(ShellSurfaceSetPopupRequest)
+}
+
+// ShellSurfaceSetMaximizedRequest: make the surface a maximized surface
+//
+// Map the surface as a maximized surface.
+//
+// If an output parameter is given then the surface will be
+// maximized on that output. If the client does not specify the
+// output then the compositor will apply its policy - usually
+// choosing the output on which the surface has the biggest surface
+// area.
+//
+// The compositor will reply with a configure event telling
+// the expected new surface size. The operation is completed
+// on the next buffer attach to this surface.
+//
+// A maximized surface typically fills the entire output it is
+// bound to, except for desktop elements such as panels. This is
+// the main difference between a maximized shell surface and a
+// fullscreen shell surface.
+//
+// The details depend on the compositor implementation.
+type ShellSurfaceSetMaximizedRequest struct {
+	Resource *ShellSurface
+	Output   *Output
+}
+
+// ShellSurfaceSetMaximizedHandler is implemented by the handlers of ShellSurfaceSetMaximizedRequest, see SetHandler
+type ShellSurfaceSetMaximizedHandler interface {
+	HandleShellSurfaceSetMaximized(ShellSurfaceSetMaximizedRequest)
+}
+
+// ShellSurfaceSetTitleRequest: set surface title
+//
+// Set a short title for the surface.
+//
+// This string may be used to identify the surface in a task bar,
+// window list, or other user interface elements provided by the
+// compositor.
+//
+// The string must be encoded in UTF-8.
+type ShellSurfaceSetTitleRequest struct {
+	Resource *ShellSurface
+	Title    string
+}
+
+// ShellSurfaceSetTitleHandler is implemented by the handlers of ShellSurfaceSetTitleRequest, see SetHandler
+type ShellSurfaceSetTitleHandler interface {
+	HandleShellSurfaceSetTitle(ShellSurfaceSetTitleRequest)
+}
+
+// ShellSurfaceSetClassRequest: set surface class
+//
+// Set a class for the surface.
+//
+// The surface class identifies the general class of applications
+// to which the surface belongs. A common convention is to use the
+// file name (or the full path if it is a non-standard location) of
+// the application's .desktop file as the class.
+type ShellSurfaceSetClassRequest struct {
+	Resource *ShellSurface
+	Class    string
+}
+
+// ShellSurfaceSetClassHandler is implemented by the handlers of ShellSurfaceSetClassRequest, see SetHandler
+type ShellSurfaceSetClassHandler interface {
+	HandleShellSurfaceSetClass(ShellSurfaceSetClassRequest)
+}
+
+// Dispatch decodes a request received on the wl_shell_surface and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *ShellSurface) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := ShellSurfacePongRequest{Resource: r}
+		req.Serial = event.Uint32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(ShellSurfacePongHandler); ok {
+			h.HandleShellSurfacePong(req)
+		}
+	case 1:
+		req := ShellSurfaceMoveRequest{Resource: r}
+		var err error
+		var seatRes Resource
+		if seatRes, err = c.Argument(event.Uint32(), false, wl.SeatInterface); err != nil {
+			return err
+		}
+		req.Seat, _ = seatRes.(*Seat)
+		req.Serial = event.Uint32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(ShellSurfaceMoveHandler); ok {
+			h.HandleShellSurfaceMove(req)
+		}
+	case 2:
+		req := ShellSurfaceResizeRequest{Resource: r}
+		var err error
+		var seatRes Resource
+		if seatRes, err = c.Argument(event.Uint32(), false, wl.SeatInterface); err != nil {
+			return err
+		}
+		req.Seat, _ = seatRes.(*Seat)
+		req.Serial = event.Uint32()
+		req.Edges = event.Uint32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(ShellSurfaceResizeHandler); ok {
+			h.HandleShellSurfaceResize(req)
+		}
+	case 3:
+		req := ShellSurfaceSetToplevelRequest{Resource: r}
+		if h, ok := r.Handler().(ShellSurfaceSetToplevelHandler); ok {
+			h.HandleShellSurfaceSetToplevel(req)
+		}
+	case 4:
+		req := ShellSurfaceSetTransientRequest{Resource: r}
+		var err error
+		var parentRes Resource
+		if parentRes, err = c.Argument(event.Uint32(), false, wl.SurfaceInterface); err != nil {
+			return err
+		}
+		req.Parent, _ = parentRes.(*Surface)
+		req.X = event.Int32()
+		req.Y = event.Int32()
+		req.Flags = event.Uint32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(ShellSurfaceSetTransientHandler); ok {
+			h.HandleShellSurfaceSetTransient(req)
+		}
+	case 5:
+		req := ShellSurfaceSetFullscreenRequest{Resource: r}
+		var err error
+		req.Method = event.Uint32()
+		req.Framerate = event.Uint32()
+		var outputRes Resource
+		if outputRes, err = c.Argument(event.Uint32(), true, wl.OutputInterface); err != nil {
+			return err
+		}
+		req.Output, _ = outputRes.(*Output)
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(ShellSurfaceSetFullscreenHandler); ok {
+			h.HandleShellSurfaceSetFullscreen(req)
+		}
+	case 6:
+		req := ShellSurfaceSetPopupRequest{Resource: r}
+		var err error
+		var seatRes Resource
+		if seatRes, err = c.Argument(event.Uint32(), false, wl.SeatInterface); err != nil {
+			return err
+		}
+		req.Seat, _ = seatRes.(*Seat)
+		req.Serial = event.Uint32()
+		var parentRes Resource
+		if parentRes, err = c.Argument(event.Uint32(), false, wl.SurfaceInterface); err != nil {
+			return err
+		}
+		req.Parent, _ = parentRes.(*Surface)
+		req.X = event.Int32()
+		req.Y = event.Int32()
+		req.Flags = event.Uint32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(ShellSurfaceSetPopupHandler); ok {
+			h.HandleShellSurfaceSetPopup(req)
+		}
+	case 7:
+		req := ShellSurfaceSetMaximizedRequest{Resource: r}
+		var err error
+		var outputRes Resource
+		if outputRes, err = c.Argument(event.Uint32(), true, wl.OutputInterface); err != nil {
+			return err
+		}
+		req.Output, _ = outputRes.(*Output)
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(ShellSurfaceSetMaximizedHandler); ok {
+			h.HandleShellSurfaceSetMaximized(req)
+		}
+	case 8:
+		req := ShellSurfaceSetTitleRequest{Resource: r}
+		req.Title = event.String()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(ShellSurfaceSetTitleHandler); ok {
+			h.HandleShellSurfaceSetTitle(req)
+		}
+	case 9:
+		req := ShellSurfaceSetClassRequest{Resource: r}
+		req.Class = event.String()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(ShellSurfaceSetClassHandler); ok {
+			h.HandleShellSurfaceSetClass(req)
+		}
+	}
+	return nil
+}
+
+// SendPing: ping client
+//
+// Ping a client to check if it is receiving events and sending
+// requests. A client is expected to reply with a pong request.
+//
+//	serial: serial number of the ping
+func (r *ShellSurface) SendPing(serial uint32) error {
+	return r.Client().SendEvent(r, 0, serial)
+}
+
+// SendConfigure: suggest resize
+//
+// The configure event asks the client to resize its surface.
+//
+// The size is a hint, in the sense that the client is free to
+// ignore it if it doesn't resize, pick a smaller size (to
+// satisfy aspect ratio or resize in steps of NxM pixels).
+//
+// The edges parameter provides a hint about how the surface
+// was resized. The client may use this information to decide
+// how to adjust its content to the new size (e.g. a scrolling
+// area might adjust its content position to leave the viewable
+// content unmoved).
+//
+// The client is free to dismiss all but the last configure
+// event it received.
+//
+// The width and height arguments specify the size of the window
+// in surface-local coordinates.
+//
+//	edges: how the surface was resized
+//	width: new width of the surface
+//	height: new height of the surface
+func (r *ShellSurface) SendConfigure(edges uint32, width int32, height int32) error {
+	return r.Client().SendEvent(r, 1, edges, width, height)
+}
+
+// SendPopupDone: popup interaction is done
+//
+// The popup_done event is sent out when a popup grab is broken,
+// that is, when the user clicks a surface that doesn't belong
+// to the client owning the popup surface.
+func (r *ShellSurface) SendPopupDone() error {
+	return r.Client().SendEvent(r, 2)
+}
+
+// Surface: an onscreen surface
+//
+// A surface is a rectangular area that may be displayed on zero
+// or more outputs, and shown any number of times at the compositor's
+// discretion. They can present wl_buffers, receive user input, and
+// define a local coordinate system.
+//
+// The size of a surface (and relative positions on it) is described
+// in surface-local coordinates, which may differ from the buffer
+// coordinates of the pixel content, in case a buffer_transform
+// or a buffer_scale is used.
+//
+// A surface without a "role" is fairly useless: a compositor does
+// not know where, when or how to present it. The role is the
+// purpose of a wl_surface. Examples of roles are a cursor for a
+// pointer (as set by wl_pointer.set_cursor), a drag icon
+// (wl_data_device.start_drag), a sub-surface
+// (wl_subcompositor.get_subsurface), and a window as defined by a
+// shell protocol (e.g. wl_shell.get_shell_surface).
+//
+// A surface can have only one role at a time. Initially a
+// wl_surface does not have a role. Once a wl_surface is given a
+// role, it is set permanently for the whole lifetime of the
+// wl_surface object. Giving the current role again is allowed,
+// unless explicitly forbidden by the relevant interface
+// specification.
+//
+// Surface roles are given by requests in other interfaces such as
+// wl_pointer.set_cursor. The request should explicitly mention
+// that this request gives a role to a wl_surface. Often, this
+// request also creates a new protocol object that represents the
+// role and adds additional functionality to wl_surface. When a
+// client wants to destroy a wl_surface, they must destroy this 'role
+// object' before the wl_surface.
+//
+// Destroying the role object does not remove the role from the
+// wl_surface, but it may stop the wl_surface from "playing the role".
+// For instance, if a wl_subsurface object is destroyed, the wl_surface
+// it was created for will be unmapped and forget its position and
+// z-order. It is allowed to create a wl_subsurface for the same
+// wl_surface again, but it is not allowed to use the wl_surface as
+// a cursor (cursor is a different role than sub-surface, and role
+// switching is not allowed).
+type Surface struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_surface interface
+func (r *Surface) Interface() *wl.Interface {
+	return wl.SurfaceInterface
+}
+
+// SurfaceError: wl_surface error values
+//
+// These errors can be emitted in response to wl_surface requests.
+const (
+	// SurfaceErrorInvalidScale: buffer scale value is invalid
+	SurfaceErrorInvalidScale = 0
+	// SurfaceErrorInvalidTransform: buffer transform value is invalid
+	SurfaceErrorInvalidTransform = 1
+	// SurfaceErrorInvalidSize: buffer size is invalid
+	SurfaceErrorInvalidSize = 2
+	// SurfaceErrorInvalidOffset: buffer offset is invalid
+	SurfaceErrorInvalidOffset = 3
+	// SurfaceErrorDefunctRoleObject: surface was destroyed before its role object
+	SurfaceErrorDefunctRoleObject = 4
+)
+
+// SurfaceDestroyRequest: delete surface
+//
+// Deletes the surface and invalidates its object ID.
+type SurfaceDestroyRequest struct {
+	Resource *Surface
+}
+
+// SurfaceDestroyHandler is implemented by the handlers of SurfaceDestroyRequest, see SetHandler
+type SurfaceDestroyHandler interface {
+	HandleSurfaceDestroy(SurfaceDestroyRequest)
+}
+
+// SurfaceAttachRequest: set the surface contents
+//
+// Set a buffer as the content of this surface.
+//
+// The new size of the surface is calculated based on the buffer
+// size transformed by the inverse buffer_transform and the
+// inverse buffer_scale. This means that at commit time the supplied
+// buffer size must be an integer multiple of the buffer_scale. If
+// that's not the case, an invalid_size error is sent.
+//
+// When the bound wl_surface version is 5 or higher, passing any
+// non-zero x or y is a protocol violation, and will result in an
+// 'invalid_offset' error being raised. The x and y arguments are ignored
+// and do not change the pending state. To achieve equivalent semantics,
+// use wl_surface.offset.
+//
+// Surface contents are double-buffered state, see wl_surface.commit.
+//
+// The initial surface contents are void; there is no content.
+// wl_surface.attach assigns the given wl_buffer as the pending
+// wl_buffer. wl_surface.commit makes the pending wl_buffer the new
+// surface contents, and the size of the surface becomes the size
+// calculated from the wl_buffer, as described above. After commit,
+// there is no pending buffer until the next attach.
+//
+// Committing a pending wl_buffer allows the compositor to read the
+// pixels in the wl_buffer. The compositor may access the pixels at
+// any time after the wl_surface.commit request. When the compositor
+// will not access the pixels anymore, it will send the
+// wl_buffer.release event. Only after receiving wl_buffer.release,
+// the client may reuse the wl_buffer. A wl_buffer that has been
+// attached and then replaced by another attach instead of committed
+// will not receive a release event, and is not used by the
+// compositor.
+//
+// If a pending wl_buffer has been committed to more than one wl_surface,
+// the delivery of wl_buffer.release events becomes undefined. A well
+// behaved client should not rely on wl_buffer.release events in this
+// case. Alternatively, a client could create multiple wl_buffer objects
+// from the same backing storage or use wp_linux_buffer_release.
+//
+// Destroying the wl_buffer after wl_buffer.release does not change
+// the surface contents. However, if the client destroys the
+// wl_buffer before receiving the wl_buffer.release event, the surface
+// contents become undefined immediately.
+//
+// If wl_surface.attach is sent with a NULL wl_buffer, the
+// following wl_surface.commit will remove the surface content.
+type SurfaceAttachRequest struct {
+	Resource *Surface
+	Buffer   *Buffer
+	X        int32
+	Y        int32
+}
+
+// SurfaceAttachHandler is implemented by the handlers of SurfaceAttachRequest, see SetHandler
+type SurfaceAttachHandler interface {
+	HandleSurfaceAttach(SurfaceAttachRequest)
+}
+
+// SurfaceDamageRequest: mark part of the surface damaged
+//
+// This request is used to describe the regions where the pending
+// buffer is different from the current surface contents, and where
+// the surface therefore needs to be repainted. The compositor
+// ignores the parts of the damage that fall outside of the surface.
+//
+// Damage is double-buffered state, see wl_surface.commit.
+//
+// The damage rectangle is specified in surface-local coordinates,
+// where x and y specify the upper left corner of the damage rectangle.
+//
+// The initial value for pending damage is empty: no damage.
+// wl_surface.damage adds pending damage: the new pending damage
+// is the union of old pending damage and the given rectangle.
+//
+// wl_surface.commit assigns pending damage as the current damage,
+// and clears pending damage. The server will clear the current
+// damage as it repaints the surface.
+//
+// Note! New clients should not use this request. Instead damage can be
+// posted with wl_surface.damage_buffer which uses buffer coordinates
+// instead of surface coordinates.
+type SurfaceDamageRequest struct {
+	Resource *Surface
+	X        int32
+	Y        int32
+	Width    int32
+	Height   int32
+}
+
+// SurfaceDamageHandler is implemented by the handlers of SurfaceDamageRequest, see SetHandler
+type SurfaceDamageHandler interface {
+	HandleSurfaceDamage(SurfaceDamageRequest)
+}
+
+// SurfaceFrameRequest: request a frame throttling hint
+//
+// Request a notification when it is a good time to start drawing a new
+// frame, by creating a frame callback. This is useful for throttling
+// redrawing operations, and driving animations.
+//
+// When a client is animating on a wl_surface, it can use the 'frame'
+// request to get notified when it is a good time to draw and commit the
+// next frame of animation. If the client commits an update earlier than
+// that, it is likely that some updates will not make it to the display,
+// and the client is wasting resources by drawing too often.
+//
+// The frame request will take effect on the next wl_surface.commit.
+// The notification will only be posted for one frame unless
+// requested again. For a wl_surface, the notifications are posted in
+// the order the frame requests were committed.
+//
+// The server must send the notifications so that a client
+// will not send excessive updates, while still allowing
+// the highest possible update rate for clients that wait for the reply
+// before drawing again. The server should give some time for the client
+// to draw and commit after sending the frame callback events to let it
+// hit the next output refresh.
+//
+// A server should avoid signaling the frame callbacks if the
+// surface is not visible in any way, e.g. the surface is off-screen,
+// or completely obscured by other opaque surfaces.
+//
+// The object returned by this request will be destroyed by the
+// compositor after the callback is fired and as such the client must not
+// attempt to use it after that point.
+//
+// The callback_data passed in the callback is the current time, in
+// milliseconds, with an undefined base.
+type SurfaceFrameRequest struct {
+	Resource *Surface
+	Callback *Callback
+}
+
+// SurfaceFrameHandler is implemented by the handlers of SurfaceFrameRequest, see SetHandler
+type SurfaceFrameHandler interface {
+	HandleSurfaceFrame(SurfaceFrameRequest)
+}
+
+// SurfaceSetOpaqueRegionRequest: set opaque region
+//
+// This request sets the region of the surface that contains
+// opaque content.
+//
+// The opaque region is an optimization hint for the compositor
+// that lets it optimize the redrawing of content behind opaque
+// regions.  Setting an opaque region is not required for correct
+// behaviour, but marking transparent content as opaque will result
+// in repaint artifacts.
+//
+// The opaque region is specified in surface-local coordinates.
+//
+// The compositor ignores the parts of the opaque region that fall
+// outside of the surface.
+//
+// Opaque region is double-buffered state, see wl_surface.commit.
+//
+// wl_surface.set_opaque_region changes the pending opaque region.
+// wl_surface.commit copies the pending region to the current region.
+// Otherwise, the pending and current regions are never changed.
+//
+// The initial value for an opaque region is empty. Setting the pending
+// opaque region has copy semantics, and the wl_region object can be
+// destroyed immediately. A NULL wl_region causes the pending opaque
+// region to be set to empty.
+type SurfaceSetOpaqueRegionRequest struct {
+	Resource *Surface
+	Region   *Region
+}
+
+// SurfaceSetOpaqueRegionHandler is implemented by the handlers of SurfaceSetOpaqueRegionRequest, see SetHandler
+type SurfaceSetOpaqueRegionHandler interface {
+	HandleSurfaceSetOpaqueRegion(SurfaceSetOpaqueRegionRequest)
+}
+
+// SurfaceSetInputRegionRequest: set input region
+//
+// This request sets the region of the surface that can receive
+// pointer and touch events.
+//
+// Input events happening outside of this region will try the next
+// surface in the server surface stack. The compositor ignores the
+// parts of the input region that fall outside of the surface.
+//
+// The input region is specified in surface-local coordinates.
+//
+// Input region is double-buffered state, see wl_surface.commit.
+//
+// wl_surface.set_input_region changes the pending input region.
+// wl_surface.commit copies the pending region to the current region.
+// Otherwise the pending and current regions are never changed,
+// except cursor and icon surfaces are special cases, see
+// wl_pointer.set_cursor and wl_data_device.start_drag.
+//
+// The initial value for an input region is infinite. That means the
+// whole surface will accept input. Setting the pending input region
+// has copy semantics, and the wl_region object can be destroyed
+// immediately. A NULL wl_region causes the input region to be set
+// to infinite.
+type SurfaceSetInputRegionRequest struct {
+	Resource *Surface
+	Region   *Region
+}
+
+// SurfaceSetInputRegionHandler is implemented by the handlers of SurfaceSetInputRegionRequest, see SetHandler
+type SurfaceSetInputRegionHandler interface {
+	HandleSurfaceSetInputRegion(SurfaceSetInputRegionRequest)
+}
+
+// SurfaceCommitRequest: commit pending surface state
+//
+// Surface state (input, opaque, and damage regions, attached buffers,
+// etc.) is double-buffered. Protocol requests modify the pending state,
+// as opposed to the current state in use by the compositor. A commit
+// request atomically applies all pending state, replacing the current
+// state. After commit, the new pending state is as documented for each
+// related request.
+//
+// On commit, a pending wl_buffer is applied first, and all other state
+// second. This means that all coordinates in double-buffered state are
+// relative to the new wl_buffer coming into use, except for
+// wl_surface.attach itself. If there is no pending wl_buffer, the
+// coordinates are relative to the current surface contents.
+//
+// All requests that need a commit to become effective are documented
+// to affect double-buffered state.
+//
+// Other interfaces may add further double-buffered surface state.
+type SurfaceCommitRequest struct {
+	Resource *Surface
+}
+
+// SurfaceCommitHandler is implemented by the handlers of SurfaceCommitRequest, see SetHandler
+type SurfaceCommitHandler interface {
+	HandleSurfaceCommit(SurfaceCommitRequest)
+}
+
+// SurfaceSetBufferTransformRequest: sets the buffer transformation
+//
+// This request sets an optional transformation on how the compositor
+// interprets the contents of the buffer attached to the surface. The
+// accepted values for the transform parameter are the values for
+// wl_output.transform.
+//
+// Buffer transform is double-buffered state, see wl_surface.commit.
+//
+// A newly created surface has its buffer transformation set to normal.
+//
+// wl_surface.set_buffer_transform changes the pending buffer
+// transformation. wl_surface.commit copies the pending buffer
+// transformation to the current one. Otherwise, the pending and current
+// values are never changed.
+//
+// The purpose of this request is to allow clients to render content
+// according to the output transform, thus permitting the compositor to
+// use certain optimizations even if the display is rotated. Using
+// hardware overlays and scanning out a client buffer for fullscreen
+// surfaces are examples of such optimizations. Those optimizations are
+// highly dependent on the compositor implementation, so the use of this
+// request should be considered on a case-by-case basis.
+//
+// Note that if the transform value includes 90 or 270 degree rotation,
+// the width of the buffer will become the surface height and the height
+// of the buffer will become the surface width.
+//
+// If transform is not one of the values from the
+// wl_output.transform enum the invalid_transform protocol error
+// is raised.
+type SurfaceSetBufferTransformRequest struct {
+	Resource  *Surface
+	Transform int32
+}
+
+// SurfaceSetBufferTransformHandler is implemented by the handlers of SurfaceSetBufferTransformRequest, see SetHandler
+type SurfaceSetBufferTransformHandler interface {
+	HandleSurfaceSetBufferTransform(SurfaceSetBufferTransformRequest)
+}
+
+// SurfaceSetBufferScaleRequest: sets the buffer scaling factor
+//
+// This request sets an optional scaling factor on how the compositor
+// interprets the contents of the buffer attached to the window.
+//
+// Buffer scale is double-buffered state, see wl_surface.commit.
+//
+// A newly created surface has its buffer scale set to 1.
+//
+// wl_surface.set_buffer_scale changes the pending buffer scale.
+// wl_surface.commit copies the pending buffer scale to the current one.
+// Otherwise, the pending and current values are never changed.
+//
+// The purpose of this request is to allow clients to supply higher
+// resolution buffer data for use on high resolution outputs. It is
+// intended that you pick the same buffer scale as the scale of the
+// output that the surface is displayed on. This means the compositor
+// can avoid scaling when rendering the surface on that output.
+//
+// Note that if the scale is larger than 1, then you have to attach
+// a buffer that is larger (by a factor of scale in each dimension)
+// than the desired surface size.
+//
+// If scale is not positive the invalid_scale protocol error is
+// raised.
+type SurfaceSetBufferScaleRequest struct {
+	Resource *Surface
+	Scale    int32
+}
+
+// SurfaceSetBufferScaleHandler is implemented by the handlers of SurfaceSetBufferScaleRequest, see SetHandler
+type SurfaceSetBufferScaleHandler interface {
+	HandleSurfaceSetBufferScale(SurfaceSetBufferScaleRequest)
+}
+
+// SurfaceDamageBufferRequest: mark part of the surface damaged using buffer coordinates
+//
+// This request is used to describe the regions where the pending
+// buffer is different from the current surface contents, and where
+// the surface therefore needs to be repainted. The compositor
+// ignores the parts of the damage that fall outside of the surface.
+//
+// Damage is double-buffered state, see wl_surface.commit.
+//
+// The damage rectangle is specified in buffer coordinates,
+// where x and y specify the upper left corner of the damage rectangle.
+//
+// The initial value for pending damage is empty: no damage.
+// wl_surface.damage_buffer adds pending damage: the new pending
+// damage is the union of old pending damage and the given rectangle.
+//
+// wl_surface.commit assigns pending damage as the current damage,
+// and clears pending damage. The server will clear the current
+// damage as it repaints the surface.
+//
+// This request differs from wl_surface.damage in only one way - it
+// takes damage in buffer coordinates instead of surface-local
+// coordinates. While this generally is more intuitive than surface
+// coordinates, it is especially desirable when using wp_viewport
+// or when a drawing library (like EGL) is unaware of buffer scale
+// and buffer transform.
+//
+// Note: Because buffer transformation changes and damage requests may
+// be interleaved in the protocol stream, it is impossible to determine
+// the actual mapping between surface and buffer damage until
+// wl_surface.commit time. Therefore, compositors wishing to take both
+// kinds of damage into account will have to accumulate damage from the
+// two requests separately and only transform from one to the other
+// after receiving the wl_surface.commit.
+type SurfaceDamageBufferRequest struct {
+	Resource *Surface
+	X        int32
+	Y        int32
+	Width    int32
+	Height   int32
+}
+
+// SurfaceDamageBufferHandler is implemented by the handlers of SurfaceDamageBufferRequest, see SetHandler
+type SurfaceDamageBufferHandler interface {
+	HandleSurfaceDamageBuffer(SurfaceDamageBufferRequest)
+}
+
+// SurfaceOffsetRequest: set the surface contents offset
+//
+// The x and y arguments specify the location of the new pending
+// buffer's upper left corner, relative to the current buffer's upper
+// left corner, in surface-local coordinates. In other words, the
+// x and y, combined with the new surface size define in which
+// directions the surface's size changes.
+//
+// Surface location offset is double-buffered state, see
+// wl_surface.commit.
+//
+// This request is semantically equivalent to and the replaces the x and y
+// arguments in the wl_surface.attach request in wl_surface versions prior
+// to 5. See wl_surface.attach for details.
+type SurfaceOffsetRequest struct {
+	Resource *Surface
+	X        int32
+	Y        int32
+}
+
+// SurfaceOffsetHandler is implemented by the handlers of SurfaceOffsetRequest, see SetHandler
+type SurfaceOffsetHandler interface {
+	HandleSurfaceOffset(SurfaceOffsetRequest)
+}
+
+// Dispatch decodes a request received on the wl_surface and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Surface) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := SurfaceDestroyRequest{Resource: r}
+		if h, ok := r.Handler().(SurfaceDestroyHandler); ok {
+			h.HandleSurfaceDestroy(req)
+		}
+	case 1:
+		req := SurfaceAttachRequest{Resource: r}
+		var err error
+		var bufferRes Resource
+		if bufferRes, err = c.Argument(event.Uint32(), true, wl.BufferInterface); err != nil {
+			return err
+		}
+		req.Buffer, _ = bufferRes.(*Buffer)
+		req.X = event.Int32()
+		req.Y = event.Int32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SurfaceAttachHandler); ok {
+			h.HandleSurfaceAttach(req)
+		}
+	case 2:
+		req := SurfaceDamageRequest{Resource: r}
+		req.X = event.Int32()
+		req.Y = event.Int32()
+		req.Width = event.Int32()
+		req.Height = event.Int32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SurfaceDamageHandler); ok {
+			h.HandleSurfaceDamage(req)
+		}
+	case 3:
+		req := SurfaceFrameRequest{Resource: r}
+		req.Callback = new(Callback)
+		if err := c.NewId(req.Callback, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SurfaceFrameHandler); ok {
+			h.HandleSurfaceFrame(req)
+		}
+	case 4:
+		req := SurfaceSetOpaqueRegionRequest{Resource: r}
+		var err error
+		var regionRes Resource
+		if regionRes, err = c.Argument(event.Uint32(), true, wl.RegionInterface); err != nil {
+			return err
+		}
+		req.Region, _ = regionRes.(*Region)
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SurfaceSetOpaqueRegionHandler); ok {
+			h.HandleSurfaceSetOpaqueRegion(req)
+		}
+	case 5:
+		req := SurfaceSetInputRegionRequest{Resource: r}
+		var err error
+		var regionRes Resource
+		if regionRes, err = c.Argument(event.Uint32(), true, wl.RegionInterface); err != nil {
+			return err
+		}
+		req.Region, _ = regionRes.(*Region)
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SurfaceSetInputRegionHandler); ok {
+			h.HandleSurfaceSetInputRegion(req)
+		}
+	case 6:
+		req := SurfaceCommitRequest{Resource: r}
+		if h, ok := r.Handler().(SurfaceCommitHandler); ok {
+			h.HandleSurfaceCommit(req)
+		}
+	case 7:
+		req := SurfaceSetBufferTransformRequest{Resource: r}
+		req.Transform = event.Int32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SurfaceSetBufferTransformHandler); ok {
+			h.HandleSurfaceSetBufferTransform(req)
+		}
+	case 8:
+		req := SurfaceSetBufferScaleRequest{Resource: r}
+		req.Scale = event.Int32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SurfaceSetBufferScaleHandler); ok {
+			h.HandleSurfaceSetBufferScale(req)
+		}
+	case 9:
+		req := SurfaceDamageBufferRequest{Resource: r}
+		req.X = event.Int32()
+		req.Y = event.Int32()
+		req.Width = event.Int32()
+		req.Height = event.Int32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SurfaceDamageBufferHandler); ok {
+			h.HandleSurfaceDamageBuffer(req)
+		}
+	case 10:
+		req := SurfaceOffsetRequest{Resource: r}
+		req.X = event.Int32()
+		req.Y = event.Int32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SurfaceOffsetHandler); ok {
+			h.HandleSurfaceOffset(req)
+		}
+	}
+	return nil
+}
+
+// SendEnter: surface enters an output
+//
+// This is emitted whenever a surface's creation, movement, or resizing
+// results in some part of it being within the scanout region of an
+// output.
+//
+// Note that a surface may be overlapping with zero or more outputs.
+//
+//	output: output entered by the surface
+func (r *Surface) SendEnter(output *Output) error {
+	return r.Client().SendEvent(r, 0, output)
+}
+
+// SendLeave: surface leaves an output
+//
+// This is emitted whenever a surface's creation, movement, or resizing
+// results in it no longer having any part of it within the scanout region
+// of an output.
+//
+// Clients should not use the number of outputs the surface is on for frame
+// throttling purposes. The surface might be hidden even if no leave event
+// has been sent, and the compositor might expect new surface content
+// updates even if no enter event has been sent. The frame event should be
+// used instead.
+//
+//	output: output left by the surface
+func (r *Surface) SendLeave(output *Output) error {
+	return r.Client().SendEvent(r, 1, output)
+}
+
+// SendPreferredBufferScale: preferred buffer scale for the surface
+//
+// This event indicates the preferred buffer scale for this surface. It is
+// sent whenever the compositor's preference changes.
+//
+// Before receiving this event the preferred buffer scale for this surface
+// is 1.
+//
+// It is intended that scaling aware clients use this event to scale their
+// content and use wl_surface.set_buffer_scale to indicate the scale they
+// have rendered with. This allows clients to supply a higher detail
+// buffer.
+//
+// The compositor shall emit a scale value greater than 0.
+//
+//	factor: preferred scaling factor
+func (r *Surface) SendPreferredBufferScale(factor int32) error {
+	if err := CheckEventVersion(r, 2); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 2, factor)
+}
+
+// SendPreferredBufferTransform: preferred buffer transform for the surface
+//
+// This event indicates the preferred buffer transform for this surface.
+// It is sent whenever the compositor's preference changes.
+//
+// Before receiving this event the preferred buffer transform for this
+// surface is normal.
+//
+// Applying this transformation to the surface buffer contents and using
+// wl_surface.set_buffer_transform might allow the compositor to use the
+// surface buffer more efficiently.
+//
+//	transform: preferred transform
+func (r *Surface) SendPreferredBufferTransform(transform uint32) error {
+	if err := CheckEventVersion(r, 3); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 3, transform)
+}
+
+// Seat: group of input devices
+//
+// A seat is a group of keyboards, pointer and touch devices. This
+// object is published as a global during start up, or when such a
+// device is hot plugged.  A seat typically has a pointer and
+// maintains a keyboard focus and a pointer focus.
+type Seat struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_seat interface
+func (r *Seat) Interface() *wl.Interface {
+	return wl.SeatInterface
+}
+
+// SeatCapability: seat capability bitmask
+//
+// This is a bitmask of capabilities this seat has; if a member is
+// set, then it is present on the seat.
+const (
+	// SeatCapabilityPointer: the seat has pointer devices
+	SeatCapabilityPointer = 1
+	// SeatCapabilityKeyboard: the seat has one or more keyboards
+	SeatCapabilityKeyboard = 2
+	// SeatCapabilityTouch: the seat has touch devices
+	SeatCapabilityTouch = 4
+)
+
+// SeatError: wl_seat error values
+//
+// These errors can be emitted in response to wl_seat requests.
+const (
+	// SeatErrorMissingCapability: get_pointer, get_keyboard or get_touch called on seat without the matching capability
+	SeatErrorMissingCapability = 0
+)
+
+// SeatGetPointerRequest: return pointer object
+//
+// The ID provided will be initialized to the wl_pointer interface
+// for this seat.
+//
+// This request only takes effect if the seat has the pointer
+// capability, or has had the pointer capability in the past.
+// It is a protocol violation to issue this request on a seat that has
+// never had the pointer capability. The missing_capability error will
+// be sent in this case.
+type SeatGetPointerRequest struct {
+	Resource *Seat
+	Id       *Pointer
+}
+
+// SeatGetPointerHandler is implemented by the handlers of SeatGetPointerRequest, see SetHandler
+type SeatGetPointerHandler interface {
+	HandleSeatGetPointer(SeatGetPointerRequest)
+}
+
+// SeatGetKeyboardRequest: return keyboard object
+//
+// The ID provided will be initialized to the wl_keyboard interface
+// for this seat.
+//
+// This request only takes effect if the seat has the keyboard
+// capability, or has had the keyboard capability in the past.
+// It is a protocol violation to issue this request on a seat that has
+// never had the keyboard capability. The missing_capability error will
+// be sent in this case.
+type SeatGetKeyboardRequest struct {
+	Resource *Seat
+	Id       *Keyboard
+}
+
+// SeatGetKeyboardHandler is implemented by the handlers of SeatGetKeyboardRequest, see SetHandler
+type SeatGetKeyboardHandler interface {
+	HandleSeatGetKeyboard(SeatGetKeyboardRequest)
+}
+
+// SeatGetTouchRequest: return touch object
+//
+// The ID provided will be initialized to the wl_touch interface
+// for this seat.
+//
+// This request only takes effect if the seat has the touch
+// capability, or has had the touch capability in the past.
+// It is a protocol violation to issue this request on a seat that has
+// never had the touch capability. The missing_capability error will
+// be sent in this case.
+type SeatGetTouchRequest struct {
+	Resource *Seat
+	Id       *Touch
+}
+
+// SeatGetTouchHandler is implemented by the handlers of SeatGetTouchRequest, see SetHandler
+type SeatGetTouchHandler interface {
+	HandleSeatGetTouch(SeatGetTouchRequest)
+}
+
+// SeatReleaseRequest: release the seat object
+//
+// Using this request a client can tell the server that it is not going to
+// use the seat object anymore.
+type SeatReleaseRequest struct {
+	Resource *Seat
+}
+
+// SeatReleaseHandler is implemented by the handlers of SeatReleaseRequest, see SetHandler
+type SeatReleaseHandler interface {
+	HandleSeatRelease(SeatReleaseRequest)
+}
+
+// Dispatch decodes a request received on the wl_seat and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Seat) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := SeatGetPointerRequest{Resource: r}
+		req.Id = new(Pointer)
+		if err := c.NewId(req.Id, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SeatGetPointerHandler); ok {
+			h.HandleSeatGetPointer(req)
+		}
+	case 1:
+		req := SeatGetKeyboardRequest{Resource: r}
+		req.Id = new(Keyboard)
+		if err := c.NewId(req.Id, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SeatGetKeyboardHandler); ok {
+			h.HandleSeatGetKeyboard(req)
+		}
+	case 2:
+		req := SeatGetTouchRequest{Resource: r}
+		req.Id = new(Touch)
+		if err := c.NewId(req.Id, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SeatGetTouchHandler); ok {
+			h.HandleSeatGetTouch(req)
+		}
+	case 3:
+		req := SeatReleaseRequest{Resource: r}
+		if h, ok := r.Handler().(SeatReleaseHandler); ok {
+			h.HandleSeatRelease(req)
+		}
+	}
+	return nil
+}
+
+// SendCapabilities: seat capabilities changed
+//
+// This is emitted whenever a seat gains or loses the pointer,
+// keyboard or touch capabilities.  The argument is a capability
+// enum containing the complete set of capabilities this seat has.
+//
+// When the pointer capability is added, a client may create a
+// wl_pointer object using the wl_seat.get_pointer request. This object
+// will receive pointer events until the capability is removed in the
+// future.
+//
+// When the pointer capability is removed, a client should destroy the
+// wl_pointer objects associated with the seat where the capability was
+// removed, using the wl_pointer.release request. No further pointer
+// events will be received on these objects.
+//
+// In some compositors, if a seat regains the pointer capability and a
+// client has a previously obtained wl_pointer object of version 4 or
+// less, that object may start sending pointer events again. This
+// behavior is considered a misinterpretation of the intended behavior
+// and must not be relied upon by the client. wl_pointer objects of
+// version 5 or later must not send events if created before the most
+// recent event notifying the client of an added pointer capability.
+//
+// The above behavior also applies to wl_keyboard and wl_touch with the
+// keyboard and touch capabilities, respectively.
+//
+//	capabilities: capabilities of the seat
+func (r *Seat) SendCapabilities(capabilities uint32) error {
+	return r.Client().SendEvent(r, 0, capabilities)
+}
+
+// SendName: unique identifier for this seat
+//
+// In a multiseat configuration this can be used by the client to help
+// identify which physical devices the seat represents. Based on
+// the seat configuration used by the compositor.
+//
+//	name: seat identifier
+func (r *Seat) SendName(name string) error {
+	if err := CheckEventVersion(r, 1); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 1, name)
+}
+
+// Pointer: pointer input device
+//
+// The wl_pointer interface represents one or more input devices,
+// such as mice, which control the pointer location and pointer_focus
+// of a seat.
+//
+// The wl_pointer interface generates motion, enter and leave
+// events for the surfaces that the pointer is located over,
+// and button and axis events for button presses, button releases
+// and scrolling.
+type Pointer struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_pointer interface
+func (r *Pointer) Interface() *wl.Interface {
+	return wl.PointerInterface
+}
+
+// PointerError:
+const (
+	// PointerErrorRole: given wl_surface has another role
+	PointerErrorRole = 0
+)
+
+// PointerButtonState: physical button state
+//
+// Describes the physical state of a button that produced the button
+// event.
+const (
+	// PointerButtonStateReleased: the button is not pressed
+	PointerButtonStateReleased = 0
+	// PointerButtonStatePressed: the button is pressed
+	PointerButtonStatePressed = 1
+)
+
+// PointerAxis: axis types
+//
+// Describes the axis types of scroll events.
+const (
+	// PointerAxisVerticalScroll: vertical axis
+	PointerAxisVerticalScroll = 0
+	// PointerAxisHorizontalScroll: horizontal axis
+	PointerAxisHorizontalScroll = 1
+)
+
+// PointerAxisSource: axis source types
+//
+// Describes the source types for axis events. This indicates to the
+// client how an axis event was physically generated; a client may
+// adjust the user interface accordingly. For example, scroll events
+// from a "finger" source may be in a smooth coordinate space with
+// kinetic scrolling whereas a "wheel" source may be in discrete steps
+// of a number of lines.
+//
+// The "continuous" axis source is a device generating events in a
+// continuous coordinate space, but using something other than a
+// finger. One example for this source is button-based scrolling where
+// the vertical motion of a device is converted to scroll events while
+// a button is held down.
+//
+// The "wheel tilt" axis source indicates that the actual device is a
+// wheel but the scroll event is not caused by a rotation but a
+// (usually sideways) tilt of the wheel.
+const (
+	// PointerAxisSourceWheel: a physical wheel rotation
+	PointerAxisSourceWheel = 0
+	// PointerAxisSourceFinger: finger on a touch surface
+	PointerAxisSourceFinger = 1
+	// PointerAxisSourceContinuous: continuous coordinate space
+	PointerAxisSourceContinuous = 2
+	// PointerAxisSourceWheelTilt: a physical wheel tilt
+	PointerAxisSourceWheelTilt = 3
+)
+
+// PointerAxisRelativeDirection: axis relative direction
+//
+// This specifies the direction of the physical motion that caused a
+// wl_pointer.axis event, relative to the wl_pointer.axis direction.
+const (
+	// PointerAxisRelativeDirectionIdentical: physical motion matches axis direction
+	PointerAxisRelativeDirectionIdentical = 0
+	// PointerAxisRelativeDirectionInverted: physical motion is the inverse of the axis direction
+	PointerAxisRelativeDirectionInverted = 1
+)
+
+// PointerSetCursorRequest: set the pointer surface
+//
+// Set the pointer surface, i.e., the surface that contains the
+// pointer image (cursor). This request gives the surface the role
+// of a cursor. If the surface already has another role, it raises
+// a protocol error.
+//
+// The cursor actually changes only if the pointer
+// focus for this device is one of the requesting client's surfaces
+// or the surface parameter is the current pointer surface. If
+// there was a previous surface set with this request it is
+// replaced. If surface is NULL, the pointer image is hidden.
+//
+// The parameters hotspot_x and hotspot_y define the position of
+// the pointer surface relative to the pointer location. Its
+// top-left corner is always at (x, y) - (hotspot_x, hotspot_y),
+// where (x, y) are the coordinates of the pointer location, in
+// surface-local coordinates.
+//
+// On surface.attach requests to the pointer surface, hotspot_x
+// and hotspot_y are decremented by the x and y parameters
+// passed to the request. Attach must be confirmed by
+// wl_surface.commit as usual.
+//
+// The hotspot can also be updated by passing the currently set
+// pointer surface to this request with new values for hotspot_x
+// and hotspot_y.
+//
+// The current and pending input regions of the wl_surface are
+// cleared, and wl_surface.set_input_region is ignored until the
+// wl_surface is no longer used as the cursor. When the use as a
+// cursor ends, the current and pending input regions become
+// undefined, and the wl_surface is unmapped.
+type PointerSetCursorRequest struct {
+	Resource *Pointer
+	Serial   uint32
+	Surface  *Surface
+	HotspotX int32
+	HotspotY int32
+}
+
+// PointerSetCursorHandler is implemented by the handlers of PointerSetCursorRequest, see SetHandler
+type PointerSetCursorHandler interface {
+	HandlePointerSetCursor(PointerSetCursorRequest)
+}
+
+// PointerReleaseRequest: release the pointer object
+//
+// Using this request a client can tell the server that it is not going to
+// use the pointer object anymore.
+//
+// This request destroys the pointer proxy object, so clients must not call
+// wl_pointer_destroy() after using this request.
+type PointerReleaseRequest struct {
+	Resource *Pointer
+}
+
+// PointerReleaseHandler is implemented by the handlers of PointerReleaseRequest, see SetHandler
+type PointerReleaseHandler interface {
+	HandlePointerRelease(PointerReleaseRequest)
+}
+
+// Dispatch decodes a request received on the wl_pointer and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Pointer) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := PointerSetCursorRequest{Resource: r}
+		var err error
+		req.Serial = event.Uint32()
+		var surfaceRes Resource
+		if surfaceRes, err = c.Argument(event.Uint32(), true, wl.SurfaceInterface); err != nil {
+			return err
+		}
+		req.Surface, _ = surfaceRes.(*Surface)
+		req.HotspotX = event.Int32()
+		req.HotspotY = event.Int32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(PointerSetCursorHandler); ok {
+			h.HandlePointerSetCursor(req)
+		}
+	case 1:
+		req := PointerReleaseRequest{Resource: r}
+		if h, ok := r.Handler().(PointerReleaseHandler); ok {
+			h.HandlePointerRelease(req)
+		}
+	}
+	return nil
+}
+
+// SendEnter: enter event
+//
+// Notification that this seat's pointer is focused on a certain
+// surface.
+//
+// When a seat's focus enters a surface, the pointer image
+// is undefined and a client should respond to this event by setting
+// an appropriate pointer image with the set_cursor request.
+//
+//	serial: serial number of the enter event
+//	surface: surface entered by the pointer
+//	surfaceX: surface-local x coordinate
+//	surfaceY: surface-local y coordinate
+func (r *Pointer) SendEnter(serial uint32, surface *Surface, surfaceX float32, surfaceY float32) error {
+	return r.Client().SendEvent(r, 0, serial, surface, surfaceX, surfaceY)
+}
+
+// SendLeave: leave event
+//
+// Notification that this seat's pointer is no longer focused on
+// a certain surface.
+//
+// The leave notification is sent before the enter notification
+// for the new focus.
+//
+//	serial: serial number of the leave event
+//	surface: surface left by the pointer
+func (r *Pointer) SendLeave(serial uint32, surface *Surface) error {
+	return r.Client().SendEvent(r, 1, serial, surface)
+}
+
+// SendMotion: pointer motion event
+//
+// Notification of pointer location change. The arguments
+// surface_x and surface_y are the location relative to the
+// focused surface.
+//
+//	time: timestamp with millisecond granularity
+//	surfaceX: surface-local x coordinate
+//	surfaceY: surface-local y coordinate
+func (r *Pointer) SendMotion(time uint32, surfaceX float32, surfaceY float32) error {
+	return r.Client().SendEvent(r, 2, time, surfaceX, surfaceY)
+}
+
+// SendButton: pointer button event
+//
+// Mouse button click and release notifications.
+//
+// The location of the click is given by the last motion or
+// enter event.
+// The time argument is a timestamp with millisecond
+// granularity, with an undefined base.
+//
+// The button is a button code as defined in the Linux kernel's
+// linux/input-event-codes.h header file, e.g. BTN_LEFT.
+//
+// Any 16-bit button code value is reserved for future additions to the
+// kernel's event code list. All other button codes above 0xFFFF are
+// currently undefined but may be used in future versions of this
+// protocol.
+//
+//	serial: serial number of the button event
+//	time: timestamp with millisecond granularity
+//	button: button that produced the event
+//	state: physical state of the button
+func (r *Pointer) SendButton(serial uint32, time uint32, button uint32, state uint32) error {
+	return r.Client().SendEvent(r, 3, serial, time, button, state)
+}
+
+// SendAxis: axis event
+//
+// Scroll and other axis notifications.
+//
+// For scroll events (vertical and horizontal scroll axes), the
+// value parameter is the length of a vector along the specified
+// axis in a coordinate space identical to those of motion events,
+// representing a relative movement along the specified axis.
+//
+// For devices that support movements non-parallel to axes multiple
+// axis events will be emitted.
+//
+// When applicable, for example for touch pads, the server can
+// choose to emit scroll events where the motion vector is
+// equivalent to a motion event vector.
+//
+// When applicable, a client can transform its content relative to the
+// scroll distance.
+//
+//	time: timestamp with millisecond granularity
+//	axis: axis type
+//	value: length of vector in surface-local coordinate space
+func (r *Pointer) SendAxis(time uint32, axis uint32, value float32) error {
+	return r.Client().SendEvent(r, 4, time, axis, value)
+}
+
+// SendFrame: end of a pointer event sequence
+//
+// Indicates the end of a set of events that logically belong together.
+// A client is expected to accumulate the data in all events within the
+// frame before proceeding.
+//
+// All wl_pointer events before a wl_pointer.frame event belong
+// logically together. For example, in a diagonal scroll motion the
+// compositor will send an optional wl_pointer.axis_source event, two
+// wl_pointer.axis events (horizontal and vertical) and finally a
+// wl_pointer.frame event. The client may use this information to
+// calculate a diagonal vector for scrolling.
+//
+// When multiple wl_pointer.axis events occur within the same frame,
+// the motion vector is the combined motion of all events.
+// When a wl_pointer.axis and a wl_pointer.axis_stop event occur within
+// the same frame, this indicates that axis movement in one axis has
+// stopped but continues in the other axis.
+// When multiple wl_pointer.axis_stop events occur within the same
+// frame, this indicates that these axes stopped in the same instance.
+//
+// A wl_pointer.frame event is sent for every logical event group,
+// even if the group only contains a single wl_pointer event.
+// Specifically, a client may get a sequence: motion, frame, button,
+// frame, axis, frame, axis_stop, frame.
+//
+// The wl_pointer.enter and wl_pointer.leave events are logical events
+// generated by the compositor and not the hardware. These events are
+// also grouped by a wl_pointer.frame. When a pointer moves from one
+// surface to another, a compositor should group the
+// wl_pointer.leave event within the same wl_pointer.frame.
+// However, a client must not rely on wl_pointer.leave and
+// wl_pointer.enter being in the same wl_pointer.frame.
+// Compositor-specific policies may require the wl_pointer.leave and
+// wl_pointer.enter event being split across multiple wl_pointer.frame
+// groups.
+func (r *Pointer) SendFrame() error {
+	if err := CheckEventVersion(r, 5); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 5)
+}
+
+// SendAxisSource: axis source event
+//
+// Source information for scroll and other axes.
+//
+// This event does not occur on its own. It is sent before a
+// wl_pointer.frame event and carries the source information for
+// all events within that frame.
+//
+// The source specifies how this event was generated. If the source is
+// wl_pointer.axis_source.finger, a wl_pointer.axis_stop event will be
+// sent when the user lifts the finger off the device.
+//
+// If the source is wl_pointer.axis_source.wheel,
+// wl_pointer.axis_source.wheel_tilt or
+// wl_pointer.axis_source.continuous, a wl_pointer.axis_stop event may
+// or may not be sent. Whether a compositor sends an axis_stop event
+// for these sources is hardware-specific and implementation-dependent;
+// clients must not rely on receiving an axis_stop event for these
+// scroll sources and should treat scroll sequences from these scroll
+// sources as unterminated by default.
+//
+// This event is optional. If the source is unknown for a particular
+// axis event sequence, no event is sent.
+// Only one wl_pointer.axis_source event is permitted per frame.
+//
+// The order of wl_pointer.axis_discrete and wl_pointer.axis_source is
+// not guaranteed.
+//
+//	axisSource: source of the axis event
+func (r *Pointer) SendAxisSource(axisSource uint32) error {
+	if err := CheckEventVersion(r, 6); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 6, axisSource)
+}
+
+// SendAxisStop: axis stop event
+//
+// Stop notification for scroll and other axes.
+//
+// For some wl_pointer.axis_source types, a wl_pointer.axis_stop event
+// is sent to notify a client that the axis sequence has terminated.
+// This enables the client to implement kinetic scrolling.
+// See the wl_pointer.axis_source documentation for information on when
+// this event may be generated.
+//
+// Any wl_pointer.axis events with the same axis_source after this
+// event should be considered as the start of a new axis motion.
+//
+// The timestamp is to be interpreted identical to the timestamp in the
+// wl_pointer.axis event. The timestamp value may be the same as a
+// preceding wl_pointer.axis event.
+//
+//	time: timestamp with millisecond granularity
+//	axis: the axis stopped with this event
+func (r *Pointer) SendAxisStop(time uint32, axis uint32) error {
+	if err := CheckEventVersion(r, 7); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 7, time, axis)
+}
+
+// SendAxisDiscrete: axis click event
+//
+// Discrete step information for scroll and other axes.
+//
+// This event carries the axis value of the wl_pointer.axis event in
+// discrete steps (e.g. mouse wheel clicks).
+//
+// This event does not occur on its own, it is coupled with a
+// wl_pointer.axis event that represents this axis value on a
+// continuous scale. The protocol guarantees that each axis_discrete
+// event is always followed by exactly one axis event with the same
+// axis number within the same wl_pointer.frame. Note that the protocol
+// allows for other events to occur between the axis_discrete and
+// its coupled axis event, including other axis_discrete or axis
+// events.
+//
+// This event is optional; continuous scrolling devices
+// like two-finger scrolling on touchpads do not have discrete
+// steps and do not generate this event.
+//
+// The discrete value carries the directional information. e.g. a value
+// of -2 is two steps towards the negative direction of this axis.
+//
+// The axis number is identical to the axis number in the associated
+// axis event.
+//
+// The order of wl_pointer.axis_discrete and wl_pointer.axis_source is
+// not guaranteed.
+//
+//	axis: axis type
+//	discrete: number of steps
+func (r *Pointer) SendAxisDiscrete(axis uint32, discrete int32) error {
+	if err := CheckEventVersion(r, 8); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 8, axis, discrete)
+}
+
+// SendAxisValue120: axis high-resolution scroll event
+//
+// Discrete high-resolution scroll information.
+//
+// This event carries high-resolution wheel scroll information,
+// with each multiple of 120 representing one logical scroll step
+// (a wheel detent). For example, an axis_value120 of 30 is one quarter of
+// a logical scroll step in the positive direction, a value120 of
+// -240 are two logical scroll steps in the negative direction within the
+// same hardware event.
+// Clients that rely on discrete scrolling should accumulate the
+// value120 to multiples of 120 before processing the event.
+//
+// The value120 must not be zero.
+//
+// This event replaces the wl_pointer.axis_discrete event in clients
+// supporting wl_pointer version 8 or later.
+//
+// Where a wl_pointer.axis_source event occurs in the same
+// wl_pointer.frame, the axis source applies to this event.
+//
+// The order of wl_pointer.axis_value120 and wl_pointer.axis_source is
+// not guaranteed.
+//
+//	axis: axis type
+//	value120: scroll distance as fraction of 120
+func (r *Pointer) SendAxisValue120(axis uint32, value120 int32) error {
+	if err := CheckEventVersion(r, 9); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 9, axis, value120)
+}
+
+// SendAxisRelativeDirection: axis relative physical direction event
+//
+// Relative directional information of the entity causing the axis
+// motion.
+//
+// For a wl_pointer.axis event, the wl_pointer.axis_relative_direction
+// event specifies the movement direction of the entity causing the
+// wl_pointer.axis event. For example:
+// - if a user's fingers on a touchpad move down and this
+// causes a wl_pointer.axis vertical_scroll down event, the physical
+// direction is 'identical'
+// - if a user's fingers on a touchpad move down and this causes a
+// wl_pointer.axis vertical_scroll up scroll up event ('natural
+// scrolling'), the physical direction is 'inverted'.
+//
+// A client may use this information to adjust scroll motion of
+// components. Specifically, enabling natural scrolling causes the
+// content to change direction compared to traditional scrolling.
+// Some widgets like volume control sliders should usually match the
+// physical direction regardless of whether natural scrolling is
+// active. This event enables clients to match the scroll direction of
+// a widget to the physical direction.
+//
+// This event does not occur on its own, it is coupled with a
+// wl_pointer.axis event that represents this axis value.
+// The protocol guarantees that each axis_relative_direction event is
+// always followed by exactly one axis event with the same
+// axis number within the same wl_pointer.frame. Note that the protocol
+// allows for other events to occur between the axis_relative_direction
+// and its coupled axis event.
+//
+// The axis number is identical to the axis number in the associated
+// axis event.
+//
+// The order of wl_pointer.axis_relative_direction,
+// wl_pointer.axis_discrete and wl_pointer.axis_source is not
+// guaranteed.
+//
+//	axis: axis type
+//	direction: physical direction relative to axis motion
+func (r *Pointer) SendAxisRelativeDirection(axis uint32, direction uint32) error {
+	if err := CheckEventVersion(r, 10); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 10, axis, direction)
+}
+
+// Keyboard: keyboard input device
+//
+// The wl_keyboard interface represents one or more keyboards
+// associated with a seat.
+type Keyboard struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_keyboard interface
+func (r *Keyboard) Interface() *wl.Interface {
+	return wl.KeyboardInterface
+}
+
+// KeyboardKeymapFormat: keyboard mapping format
+//
+// This specifies the format of the keymap provided to the
+// client with the wl_keyboard.keymap event.
+const (
+	// KeyboardKeymapFormatNoKeymap: no keymap; client must understand how to interpret the raw keycode
+	KeyboardKeymapFormatNoKeymap = 0
+	// KeyboardKeymapFormatXkbV1: libxkbcommon compatible; to determine the xkb keycode, clients must add 8 to the key event keycode
+	KeyboardKeymapFormatXkbV1 = 1
+)
+
+// KeyboardKeyState: physical key state
+//
+// Describes the physical state of a key that produced the key event.
+const (
+	// KeyboardKeyStateReleased: key is not pressed
+	KeyboardKeyStateReleased = 0
+	// KeyboardKeyStatePressed: key is pressed
+	KeyboardKeyStatePressed = 1
+)
+
+// KeyboardReleaseRequest: release the keyboard object
+type KeyboardReleaseRequest struct {
+	Resource *Keyboard
+}
+
+// KeyboardReleaseHandler is implemented by the handlers of KeyboardReleaseRequest, see SetHandler
+type KeyboardReleaseHandler interface {
+	HandleKeyboardRelease(KeyboardReleaseRequest)
+}
+
+// Dispatch decodes a request received on the wl_keyboard and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Keyboard) Dispatch(event *wl.Event) error {
+	switch event.Opcode {
+	case 0:
+		req := KeyboardReleaseRequest{Resource: r}
+		if h, ok := r.Handler().(KeyboardReleaseHandler); ok {
+			h.HandleKeyboardRelease(req)
+		}
+	}
+	return nil
+}
+
+// SendKeymap: keyboard mapping
+//
+// This event provides a file descriptor to the client which can be
+// memory-mapped to provide a keyboard mapping description.
+//
+// From version 7 onwards, the fd must be mapped with MAP_PRIVATE by
+// the recipient, as MAP_SHARED may fail.
+//
+//	format: keymap format
+//	fd: keymap file descriptor
+//	size: keymap size, in bytes
+func (r *Keyboard) SendKeymap(format uint32, fd uintptr, size uint32) error {
+	return r.Client().SendEvent(r, 0, format, fd, size)
+}
+
+// SendEnter: enter event
+//
+// Notification that this seat's keyboard focus is on a certain
+// surface.
+//
+// The compositor must send the wl_keyboard.modifiers event after this
+// event.
+//
+//	serial: serial number of the enter event
+//	surface: surface gaining keyboard focus
+//	keys: the currently pressed keys
+func (r *Keyboard) SendEnter(serial uint32, surface *Surface, keys []int32) error {
+	return r.Client().SendEvent(r, 1, serial, surface, keys)
+}
+
+// SendLeave: leave event
+//
+// Notification that this seat's keyboard focus is no longer on
+// a certain surface.
+//
+// The leave notification is sent before the enter notification
+// for the new focus.
+//
+// After this event client must assume that all keys, including modifiers,
+// are lifted and also it must stop key repeating if there's some going on.
+//
+//	serial: serial number of the leave event
+//	surface: surface that lost keyboard focus
+func (r *Keyboard) SendLeave(serial uint32, surface *Surface) error {
+	return r.Client().SendEvent(r, 2, serial, surface)
+}
+
+// SendKey: key event
+//
+// A key was pressed or released.
+// The time argument is a timestamp with millisecond
+// granularity, with an undefined base.
+//
+// The key is a platform-specific key code that can be interpreted
+// by feeding it to the keyboard mapping (see the keymap event).
+//
+// If this event produces a change in modifiers, then the resulting
+// wl_keyboard.modifiers event must be sent after this event.
+//
+//	serial: serial number of the key event
+//	time: timestamp with millisecond granularity
+//	key: key that produced the event
+//	state: physical state of the key
+func (r *Keyboard) SendKey(serial uint32, time uint32, key uint32, state uint32) error {
+	return r.Client().SendEvent(r, 3, serial, time, key, state)
+}
+
+// SendModifiers: modifier and group state
+//
+// Notifies clients that the modifier and/or group state has
+// changed, and it should update its local state.
+//
+//	serial: serial number of the modifiers event
+//	modsDepressed: depressed modifiers
+//	modsLatched: latched modifiers
+//	modsLocked: locked modifiers
+//	group: keyboard layout
+func (r *Keyboard) SendModifiers(serial uint32, modsDepressed uint32, modsLatched uint32, modsLocked uint32, group uint32) error {
+	return r.Client().SendEvent(r, 4, serial, modsDepressed, modsLatched, modsLocked, group)
+}
+
+// SendRepeatInfo: repeat rate and delay
+//
+// Informs the client about the keyboard's repeat rate and delay.
+//
+// This event is sent as soon as the wl_keyboard object has been created,
+// and is guaranteed to be received by the client before any key press
+// event.
+//
+// Negative values for either rate or delay are illegal. A rate of zero
+// will disable any repeating (regardless of the value of delay).
+//
+// This event can be sent later on as well with a new value if necessary,
+// so clients should continue listening for the event past the creation
+// of wl_keyboard.
+//
+//	rate: the rate of repeating keys in characters per second
+//	delay: delay in milliseconds since key down until repeating starts
+func (r *Keyboard) SendRepeatInfo(rate int32, delay int32) error {
+	if err := CheckEventVersion(r, 5); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 5, rate, delay)
+}
+
+// Touch: touchscreen input device
+//
+// The wl_touch interface represents a touchscreen
+// associated with a seat.
+//
+// Touch interactions can consist of one or more contacts.
+// For each contact, a series of events is generated, starting
+// with a down event, followed by zero or more motion events,
+// and ending with an up event. Events relating to the same
+// contact point can be identified by the ID of the sequence.
+type Touch struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_touch interface
+func (r *Touch) Interface() *wl.Interface {
+	return wl.TouchInterface
+}
+
+// TouchReleaseRequest: release the touch object
+type TouchReleaseRequest struct {
+	Resource *Touch
+}
+
+// TouchReleaseHandler is implemented by the handlers of TouchReleaseRequest, see SetHandler
+type TouchReleaseHandler interface {
+	HandleTouchRelease(TouchReleaseRequest)
+}
+
+// Dispatch decodes a request received on the wl_touch and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Touch) Dispatch(event *wl.Event) error {
+	switch event.Opcode {
+	case 0:
+		req := TouchReleaseRequest{Resource: r}
+		if h, ok := r.Handler().(TouchReleaseHandler); ok {
+			h.HandleTouchRelease(req)
+		}
+	}
+	return nil
+}
+
+// SendDown: touch down event and beginning of a touch sequence
+//
+// A new touch point has appeared on the surface. This touch point is
+// assigned a unique ID. Future events from this touch point reference
+// this ID. The ID ceases to be valid after a touch up event and may be
+// reused in the future.
+//
+//	serial: serial number of the touch down event
+//	time: timestamp with millisecond granularity
+//	surface: surface touched
+//	id: the unique ID of this touch point
+//	x: surface-local x coordinate
+//	y: surface-local y coordinate
+func (r *Touch) SendDown(serial uint32, time uint32, surface *Surface, id int32, x float32, y float32) error {
+	return r.Client().SendEvent(r, 0, serial, time, surface, id, x, y)
+}
+
+// SendUp: end of a touch event sequence
+//
+// The touch point has disappeared. No further events will be sent for
+// this touch point and the touch point's ID is released and may be
+// reused in a future touch down event.
+//
+//	serial: serial number of the touch up event
+//	time: timestamp with millisecond granularity
+//	id: the unique ID of this touch point
+func (r *Touch) SendUp(serial uint32, time uint32, id int32) error {
+	return r.Client().SendEvent(r, 1, serial, time, id)
+}
+
+// SendMotion: update of touch point coordinates
+//
+// A touch point has changed coordinates.
+//
+//	time: timestamp with millisecond granularity
+//	id: the unique ID of this touch point
+//	x: surface-local x coordinate
+//	y: surface-local y coordinate
+func (r *Touch) SendMotion(time uint32, id int32, x float32, y float32) error {
+	return r.Client().SendEvent(r, 2, time, id, x, y)
+}
+
+// SendFrame: end of touch frame event
+//
+// Indicates the end of a set of events that logically belong together.
+// A client is expected to accumulate the data in all events within the
+// frame before proceeding.
+//
+// A wl_touch.frame terminates at least one event but otherwise no
+// guarantee is provided about the set of events within a frame. A client
+// must assume that any state not updated in a frame is unchanged from the
+// previously known state.
+func (r *Touch) SendFrame() error {
+	return r.Client().SendEvent(r, 3)
+}
+
+// SendCancel: touch session cancelled
+//
+// Sent if the compositor decides the touch stream is a global
+// gesture. No further events are sent to the clients from that
+// particular gesture. Touch cancellation applies to all touch points
+// currently active on this client's surface. The client is
+// responsible for finalizing the touch points, future touch points on
+// this surface may reuse the touch point ID.
+func (r *Touch) SendCancel() error {
+	return r.Client().SendEvent(r, 4)
+}
+
+// SendShape: update shape of touch point
+//
+// Sent when a touchpoint has changed its shape.
+//
+// This event does not occur on its own. It is sent before a
+// wl_touch.frame event and carries the new shape information for
+// any previously reported, or new touch points of that frame.
+//
+// Other events describing the touch point such as wl_touch.down,
+// wl_touch.motion or wl_touch.orientation may be sent within the
+// same wl_touch.frame. A client should treat these events as a single
+// logical touch point update. The order of wl_touch.shape,
+// wl_touch.orientation and wl_touch.motion is not guaranteed.
+// A wl_touch.down event is guaranteed to occur before the first
+// wl_touch.shape event for this touch ID but both events may occur within
+// the same wl_touch.frame.
+//
+// A touchpoint shape is approximated by an ellipse through the major and
+// minor axis length. The major axis length describes the longer diameter
+// of the ellipse, while the minor axis length describes the shorter
+// diameter. Major and minor are orthogonal and both are specified in
+// surface-local coordinates. The center of the ellipse is always at the
+// touchpoint location as reported by wl_touch.down or wl_touch.move.
+//
+// This event is only sent by the compositor if the touch device supports
+// shape reports. The client has to make reasonable assumptions about the
+// shape if it did not receive this event.
+//
+//	id: the unique ID of this touch point
+//	major: length of the major axis in surface-local coordinates
+//	minor: length of the minor axis in surface-local coordinates
+func (r *Touch) SendShape(id int32, major float32, minor float32) error {
+	if err := CheckEventVersion(r, 5); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 5, id, major, minor)
+}
+
+// SendOrientation: update orientation of touch point
+//
+// Sent when a touchpoint has changed its orientation.
+//
+// This event does not occur on its own. It is sent before a
+// wl_touch.frame event and carries the new shape information for
+// any previously reported, or new touch points of that frame.
+//
+// Other events describing the touch point such as wl_touch.down,
+// wl_touch.motion or wl_touch.shape may be sent within the
+// same wl_touch.frame. A client should treat these events as a single
+// logical touch point update. The order of wl_touch.shape,
+// wl_touch.orientation and wl_touch.motion is not guaranteed.
+// A wl_touch.down event is guaranteed to occur before the first
+// wl_touch.orientation event for this touch ID but both events may occur
+// within the same wl_touch.frame.
+//
+// The orientation describes the clockwise angle of a touchpoint's major
+// axis to the positive surface y-axis and is normalized to the -180 to
+// +180 degree range. The granularity of orientation depends on the touch
+// device, some devices only support binary rotation values between 0 and
+// 90 degrees.
+//
+// This event is only sent by the compositor if the touch device supports
+// orientation reports.
+//
+//	id: the unique ID of this touch point
+//	orientation: angle between major axis and positive surface y-axis in degrees
+func (r *Touch) SendOrientation(id int32, orientation float32) error {
+	if err := CheckEventVersion(r, 6); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 6, id, orientation)
+}
+
+// Output: compositor output region
+//
+// An output describes part of the compositor geometry.  The
+// compositor works in the 'compositor coordinate system' and an
+// output corresponds to a rectangular area in that space that is
+// actually visible.  This typically corresponds to a monitor that
+// displays part of the compositor space.  This object is published
+// as global during start up, or when a monitor is hotplugged.
+type Output struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_output interface
+func (r *Output) Interface() *wl.Interface {
+	return wl.OutputInterface
+}
+
+// OutputSubpixel: subpixel geometry information
+//
+// This enumeration describes how the physical
+// pixels on an output are laid out.
+const (
+	// OutputSubpixelUnknown: unknown geometry
+	OutputSubpixelUnknown = 0
+	// OutputSubpixelNone: no geometry
+	OutputSubpixelNone = 1
+	// OutputSubpixelHorizontalRgb: horizontal RGB
+	OutputSubpixelHorizontalRgb = 2
+	// OutputSubpixelHorizontalBgr: horizontal BGR
+	OutputSubpixelHorizontalBgr = 3
+	// OutputSubpixelVerticalRgb: vertical RGB
+	OutputSubpixelVerticalRgb = 4
+	// OutputSubpixelVerticalBgr: vertical BGR
+	OutputSubpixelVerticalBgr = 5
+)
+
+// OutputTransform: transform from framebuffer to output
+//
+// This describes the transform that a compositor will apply to a
+// surface to compensate for the rotation or mirroring of an
+// output device.
+//
+// The flipped values correspond to an initial flip around a
+// vertical axis followed by rotation.
+//
+// The purpose is mainly to allow clients to render accordingly and
+// tell the compositor, so that for fullscreen surfaces, the
+// compositor will still be able to scan out directly from client
+// surfaces.
+const (
+	// OutputTransformNormal: no transform
+	OutputTransformNormal = 0
+	// OutputTransform90: 90 degrees counter-clockwise
+	OutputTransform90 = 1
+	// OutputTransform180: 180 degrees counter-clockwise
+	OutputTransform180 = 2
+	// OutputTransform270: 270 degrees counter-clockwise
+	OutputTransform270 = 3
+	// OutputTransformFlipped: 180 degree flip around a vertical axis
+	OutputTransformFlipped = 4
+	// OutputTransformFlipped90: flip and rotate 90 degrees counter-clockwise
+	OutputTransformFlipped90 = 5
+	// OutputTransformFlipped180: flip and rotate 180 degrees counter-clockwise
+	OutputTransformFlipped180 = 6
+	// OutputTransformFlipped270: flip and rotate 270 degrees counter-clockwise
+	OutputTransformFlipped270 = 7
+)
+
+// OutputMode: mode information
+//
+// These flags describe properties of an output mode.
+// They are used in the flags bitfield of the mode event.
+const (
+	// OutputModeCurrent: indicates this is the current mode
+	OutputModeCurrent = 0x1
+	// OutputModePreferred: indicates this is the preferred mode
+	OutputModePreferred = 0x2
+)
+
+// OutputReleaseRequest: release the output object
+//
+// Using this request a client can tell the server that it is not going to
+// use the output object anymore.
+type OutputReleaseRequest struct {
+	Resource *Output
+}
+
+// OutputReleaseHandler is implemented by the handlers of OutputReleaseRequest, see SetHandler
+type OutputReleaseHandler interface {
+	HandleOutputRelease(OutputReleaseRequest)
+}
+
+// Dispatch decodes a request received on the wl_output and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Output) Dispatch(event *wl.Event) error {
+	switch event.Opcode {
+	case 0:
+		req := OutputReleaseRequest{Resource: r}
+		if h, ok := r.Handler().(OutputReleaseHandler); ok {
+			h.HandleOutputRelease(req)
+		}
+	}
+	return nil
+}
+
+// SendGeometry: properties of the output
+//
+// The geometry event describes geometric properties of the output.
+// The event is sent when binding to the output object and whenever
+// any of the properties change.
+//
+// The physical size can be set to zero if it doesn't make sense for this
+// output (e.g. for projectors or virtual outputs).
+//
+// Note: wl_output only advertises partial information about the output
+// position and identification. Some compositors, for instance those not
+// implementing a desktop-style output layout or those exposing virtual
+// outputs, might fake this information. Instead of using x and y, clients
+// should use xdg_output.logical_position. Instead of using make and model,
+// clients should use xdg_output.name and xdg_output.description.
+//
+//	x: x position within the global compositor space
+//	y: y position within the global compositor space
+//	physicalWidth: width in millimeters of the output
+//	physicalHeight: height in millimeters of the output
+//	subpixel: subpixel orientation of the output
+//	make: textual description of the manufacturer
+//	model: textual description of the model
+//	transform: transform that maps framebuffer to output
+func (r *Output) SendGeometry(x int32, y int32, physicalWidth int32, physicalHeight int32, subpixel int32, make string, model string, transform int32) error {
+	return r.Client().SendEvent(r, 0, x, y, physicalWidth, physicalHeight, subpixel, make, model, transform)
+}
+
+// SendMode: advertise available modes for the output
+//
+// The mode event describes an available mode for the output.
+//
+// The event is sent when binding to the output object and there
+// will always be one mode, the current mode.  The event is sent
+// again if an output changes mode, for the mode that is now
+// current.  In other words, the current mode is always the last
+// mode that was received with the current flag set.
+//
+// Non-current modes are deprecated. A compositor can decide to only
+// advertise the current mode and never send other modes. Clients
+// should not rely on non-current modes.
+//
+// The size of a mode is given in physical hardware units of
+// the output device. This is not necessarily the same as
+// the output size in the global compositor space. For instance,
+// the output may be scaled, as described in wl_output.scale,
+// or transformed, as described in wl_output.transform. Clients
+// willing to retrieve the output size in the global compositor
+// space should use xdg_output.logical_size instead.
+//
+// The vertical refresh rate can be set to zero if it doesn't make
+// sense for this output (e.g. for virtual outputs).
+//
+// Clients should not use the refresh rate to schedule frames. Instead,
+// they should use the wl_surface.frame event or the presentation-time
+// protocol.
+//
+// Note: this information is not always meaningful for all outputs. Some
+// compositors, such as those exposing virtual outputs, might fake the
+// refresh rate or the size.
+//
+//	flags: bitfield of mode flags
+//	width: width of the mode in hardware units
+//	height: height of the mode in hardware units
+//	refresh: vertical refresh rate in mHz
+func (r *Output) SendMode(flags uint32, width int32, height int32, refresh int32) error {
+	return r.Client().SendEvent(r, 1, flags, width, height, refresh)
+}
+
+// SendDone: sent all information about output
+//
+// This event is sent after all other properties have been
+// sent after binding to the output object and after any
+// other property changes done after that. This allows
+// changes to the output properties to be seen as
+// atomic, even if they happen via multiple events.
+func (r *Output) SendDone() error {
+	if err := CheckEventVersion(r, 2); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 2)
+}
+
+// SendScale: output scaling properties
+//
+// This event contains scaling geometry information
+// that is not in the geometry event. It may be sent after
+// binding the output object or if the output scale changes
+// later. If it is not sent, the client should assume a
+// scale of 1.
+//
+// A scale larger than 1 means that the compositor will
+// automatically scale surface buffers by this amount
+// when rendering. This is used for very high resolution
+// displays where applications rendering at the native
+// resolution would be too small to be legible.
+//
+// It is intended that scaling aware clients track the
+// current output of a surface, and if it is on a scaled
+// output it should use wl_surface.set_buffer_scale with
+// the scale of the output. That way the compositor can
+// avoid scaling the surface, and the client can supply
+// a higher detail image.
+//
+//	factor: scaling factor of output
+func (r *Output) SendScale(factor int32) error {
+	if err := CheckEventVersion(r, 3); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 3, factor)
+}
+
+// SendName: name of this output
+//
+// Many compositors will assign user-friendly names to their outputs, show
+// them to the user, allow the user to refer to an output, etc. The client
+// may wish to know this name as well to offer the user similar behaviors.
+//
+// The name is a UTF-8 string with no convention defined for its contents.
+// Each name is unique among all wl_output globals. The name is only
+// guaranteed to be unique for the compositor instance.
+//
+// The same output name is used for all clients for a given wl_output
+// global. Thus, the name can be shared across processes to refer to a
+// specific wl_output global.
+//
+// The name is not guaranteed to be persistent across sessions, thus cannot
+// be used to reliably identify an output in e.g. configuration files.
+//
+// Examples of names include 'HDMI-A-1', 'WL-1', 'X11-1', etc. However, do
+// not assume that the name is a reflection of an underlying DRM connector,
+// X11 connection, etc.
+//
+// The name event is sent after binding the output object. This event is
+// only sent once per output object, and the name does not change over the
+// lifetime of the wl_output global.
+//
+// Compositors may re-use the same output name if the wl_output global is
+// destroyed and re-created later. Compositors should avoid re-using the
+// same name if possible.
+//
+// The name event will be followed by a done event.
+//
+//	name: output name
+func (r *Output) SendName(name string) error {
+	if err := CheckEventVersion(r, 4); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 4, name)
+}
+
+// SendDescription: human-readable description of this output
+//
+// Many compositors can produce human-readable descriptions of their
+// outputs. The client may wish to know this description as well, e.g. for
+// output selection purposes.
+//
+// The description is a UTF-8 string with no convention defined for its
+// contents. The description is not guaranteed to be unique among all
+// wl_output globals. Examples might include 'Foocorp 11" Display' or
+// 'Virtual X11 output via :1'.
+//
+// The description event is sent after binding the output object and
+// whenever the description changes. The description is optional, and may
+// not be sent at all.
+//
+// The description event will be followed by a done event.
+//
+//	description: output description
+func (r *Output) SendDescription(description string) error {
+	if err := CheckEventVersion(r, 5); err != nil {
+		return err
+	}
+	return r.Client().SendEvent(r, 5, description)
+}
+
+// Region: region interface
+//
+// A region object describes an area.
+//
+// Region objects are used to describe the opaque and input
+// regions of a surface.
+type Region struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_region interface
+func (r *Region) Interface() *wl.Interface {
+	return wl.RegionInterface
+}
+
+// RegionDestroyRequest: destroy region
+//
+// Destroy the region.  This will invalidate the object ID.
+type RegionDestroyRequest struct {
+	Resource *Region
+}
+
+// RegionDestroyHandler is implemented by the handlers of RegionDestroyRequest, see SetHandler
+type RegionDestroyHandler interface {
+	HandleRegionDestroy(RegionDestroyRequest)
+}
+
+// RegionAddRequest: add rectangle to region
+//
+// Add the specified rectangle to the region.
+type RegionAddRequest struct {
+	Resource *Region
+	X        int32
+	Y        int32
+	Width    int32
+	Height   int32
+}
+
+// RegionAddHandler is implemented by the handlers of RegionAddRequest, see SetHandler
+type RegionAddHandler interface {
+	HandleRegionAdd(RegionAddRequest)
+}
+
+// RegionSubtractRequest: subtract rectangle from region
+//
+// Subtract the specified rectangle from the region.
+type RegionSubtractRequest struct {
+	Resource *Region
+	X        int32
+	Y        int32
+	Width    int32
+	Height   int32
+}
+
+// RegionSubtractHandler is implemented by the handlers of RegionSubtractRequest, see SetHandler
+type RegionSubtractHandler interface {
+	HandleRegionSubtract(RegionSubtractRequest)
+}
+
+// Dispatch decodes a request received on the wl_region and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Region) Dispatch(event *wl.Event) error {
+	switch event.Opcode {
+	case 0:
+		req := RegionDestroyRequest{Resource: r}
+		if h, ok := r.Handler().(RegionDestroyHandler); ok {
+			h.HandleRegionDestroy(req)
+		}
+	case 1:
+		req := RegionAddRequest{Resource: r}
+		req.X = event.Int32()
+		req.Y = event.Int32()
+		req.Width = event.Int32()
+		req.Height = event.Int32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(RegionAddHandler); ok {
+			h.HandleRegionAdd(req)
+		}
+	case 2:
+		req := RegionSubtractRequest{Resource: r}
+		req.X = event.Int32()
+		req.Y = event.Int32()
+		req.Width = event.Int32()
+		req.Height = event.Int32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(RegionSubtractHandler); ok {
+			h.HandleRegionSubtract(req)
+		}
+	}
+	return nil
+}
+
+// Subcompositor: sub-surface compositing
+//
+// The global interface exposing sub-surface compositing capabilities.
+// A wl_surface, that has sub-surfaces associated, is called the
+// parent surface. Sub-surfaces can be arbitrarily nested and create
+// a tree of sub-surfaces.
+//
+// The root surface in a tree of sub-surfaces is the main
+// surface. The main surface cannot be a sub-surface, because
+// sub-surfaces must always have a parent.
+//
+// A main surface with its sub-surfaces forms a (compound) window.
+// For window management purposes, this set of wl_surface objects is
+// to be considered as a single window, and it should also behave as
+// such.
+//
+// The aim of sub-surfaces is to offload some of the compositing work
+// within a window from clients to the compositor. A prime example is
+// a video player with decorations and video in separate wl_surface
+// objects. This should allow the compositor to pass YUV video buffer
+// processing to dedicated overlay hardware when possible.
+type Subcompositor struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_subcompositor interface
+func (r *Subcompositor) Interface() *wl.Interface {
+	return wl.SubcompositorInterface
+}
+
+// SubcompositorError:
+const (
+	// SubcompositorErrorBadSurface: the to-be sub-surface is invalid
+	SubcompositorErrorBadSurface = 0
+	// SubcompositorErrorBadParent: the to-be sub-surface parent is invalid
+	SubcompositorErrorBadParent = 1
+)
+
+// SubcompositorDestroyRequest: unbind from the subcompositor interface
+//
+// Informs the server that the client will not be using this
+// protocol object anymore. This does not affect any other
+// objects, wl_subsurface objects included.
+type SubcompositorDestroyRequest struct {
+	Resource *Subcompositor
+}
+
+// SubcompositorDestroyHandler is implemented by the handlers of SubcompositorDestroyRequest, see SetHandler
+type SubcompositorDestroyHandler interface {
+	HandleSubcompositorDestroy(SubcompositorDestroyRequest)
+}
+
+// SubcompositorGetSubsurfaceRequest: give a surface the role sub-surface
+//
+// Create a sub-surface interface for the given surface, and
+// associate it with the given parent surface. This turns a
+// plain wl_surface into a sub-surface.
+//
+// The to-be sub-surface must not already have another role, and it
+// must not have an existing wl_subsurface object. Otherwise a protocol
+// error is raised.
+//
+// Adding sub-surfaces to a parent is a double-buffered operation on the
+// parent (see wl_surface.commit). The effect of adding a sub-surface
+// becomes visible on the next time the state of the parent surface is
+// applied.
+//
+// This request modifies the behaviour of wl_surface.commit request on
+// the sub-surface, see the documentation on wl_subsurface interface.
+type SubcompositorGetSubsurfaceRequest struct {
+	Resource *Subcompositor
+	Id       *Subsurface
+	Surface  *Surface
+	Parent   *Surface
+}
+
+// SubcompositorGetSubsurfaceHandler is implemented by the handlers of SubcompositorGetSubsurfaceRequest, see SetHandler
+type SubcompositorGetSubsurfaceHandler interface {
+	HandleSubcompositorGetSubsurface(SubcompositorGetSubsurfaceRequest)
+}
+
+// Dispatch decodes a request received on the wl_subcompositor and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Subcompositor) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := SubcompositorDestroyRequest{Resource: r}
+		if h, ok := r.Handler().(SubcompositorDestroyHandler); ok {
+			h.HandleSubcompositorDestroy(req)
+		}
+	case 1:
+		req := SubcompositorGetSubsurfaceRequest{Resource: r}
+		var err error
+		req.Id = new(Subsurface)
+		if err := c.NewId(req.Id, event.Uint32(), r.Version()); err != nil {
+			return err
+		}
+		var surfaceRes Resource
+		if surfaceRes, err = c.Argument(event.Uint32(), false, wl.SurfaceInterface); err != nil {
+			return err
+		}
+		req.Surface, _ = surfaceRes.(*Surface)
+		var parentRes Resource
+		if parentRes, err = c.Argument(event.Uint32(), false, wl.SurfaceInterface); err != nil {
+			return err
+		}
+		req.Parent, _ = parentRes.(*Surface)
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SubcompositorGetSubsurfaceHandler); ok {
+			h.HandleSubcompositorGetSubsurface(req)
+		}
+	}
+	return nil
+}
+
+// Subsurface: sub-surface interface to a wl_surface
+//
+// An additional interface to a wl_surface object, which has been
+// made a sub-surface. A sub-surface has one parent surface. A
+// sub-surface's size and position are not limited to that of the parent.
+// Particularly, a sub-surface is not automatically clipped to its
+// parent's area.
+//
+// A sub-surface becomes mapped, when a non-NULL wl_buffer is applied
+// and the parent surface is mapped. The order of which one happens
+// first is irrelevant. A sub-surface is hidden if the parent becomes
+// hidden, or if a NULL wl_buffer is applied. These rules apply
+// recursively through the tree of surfaces.
+//
+// The behaviour of a wl_surface.commit request on a sub-surface
+// depends on the sub-surface's mode. The possible modes are
+// synchronized and desynchronized, see methods
+// wl_subsurface.set_sync and wl_subsurface.set_desync. Synchronized
+// mode caches the wl_surface state to be applied when the parent's
+// state gets applied, and desynchronized mode applies the pending
+// wl_surface state directly. A sub-surface is initially in the
+// synchronized mode.
+//
+// Sub-surfaces also have another kind of state, which is managed by
+// wl_subsurface requests, as opposed to wl_surface requests. This
+// state includes the sub-surface position relative to the parent
+// surface (wl_subsurface.set_position), and the stacking order of
+// the parent and its sub-surfaces (wl_subsurface.place_above and
+// .place_below). This state is applied when the parent surface's
+// wl_surface state is applied, regardless of the sub-surface's mode.
+// As the exception, set_sync and set_desync are effective immediately.
+//
+// The main surface can be thought to be always in desynchronized mode,
+// since it does not have a parent in the sub-surfaces sense.
+//
+// Even if a sub-surface is in desynchronized mode, it will behave as
+// in synchronized mode, if its parent surface behaves as in
+// synchronized mode. This rule is applied recursively throughout the
+// tree of surfaces. This means, that one can set a sub-surface into
+// synchronized mode, and then assume that all its child and grand-child
+// sub-surfaces are synchronized, too, without explicitly setting them.
+//
+// If the wl_surface associated with the wl_subsurface is destroyed, the
+// wl_subsurface object becomes inert. Note, that destroying either object
+// takes effect immediately. If you need to synchronize the removal
+// of a sub-surface to the parent surface update, unmap the sub-surface
+// first by attaching a NULL wl_buffer, update parent, and then destroy
+// the sub-surface.
+//
+// If the parent wl_surface object is destroyed, the sub-surface is
+// unmapped.
+type Subsurface struct {
+	BaseResource
+}
+
+// Interface returns the description of the wl_subsurface interface
+func (r *Subsurface) Interface() *wl.Interface {
+	return wl.SubsurfaceInterface
+}
+
+// SubsurfaceError:
+const (
+	// SubsurfaceErrorBadSurface: wl_surface is not a sibling or the parent
+	SubsurfaceErrorBadSurface = 0
+)
+
+// SubsurfaceDestroyRequest: remove sub-surface interface
+//
+// The sub-surface interface is removed from the wl_surface object
+// that was turned into a sub-surface with a
+// wl_subcompositor.get_subsurface request. The wl_surface's association
+// to the parent is deleted, and the wl_surface loses its role as
+// a sub-surface. The wl_surface is unmapped immediately.
+type SubsurfaceDestroyRequest struct {
+	Resource *Subsurface
+}
+
+// SubsurfaceDestroyHandler is implemented by the handlers of SubsurfaceDestroyRequest, see SetHandler
+type SubsurfaceDestroyHandler interface {
+	HandleSubsurfaceDestroy(SubsurfaceDestroyRequest)
+}
+
+// SubsurfaceSetPositionRequest: reposition the sub-surface
+//
+// This schedules a sub-surface position change.
+// The sub-surface will be moved so that its origin (top left
+// corner pixel) will be at the location x, y of the parent surface
+// coordinate system. The coordinates are not restricted to the parent
+// surface area. Negative values are allowed.
+//
+// The scheduled coordinates will take effect whenever the state of the
+// parent surface is applied. When this happens depends on whether the
+// parent surface is in synchronized mode or not. See
+// wl_subsurface.set_sync and wl_subsurface.set_desync for details.
+//
+// If more than one set_position request is invoked by the client before
+// the commit of the parent surface, the position of a new request always
+// replaces the scheduled position from any previous request.
+//
+// The initial position is 0, 0.
+type SubsurfaceSetPositionRequest struct {
+	Resource *Subsurface
+	X        int32
+	Y        int32
+}
+
+// SubsurfaceSetPositionHandler is implemented by the handlers of SubsurfaceSetPositionRequest, see SetHandler
+type SubsurfaceSetPositionHandler interface {
+	HandleSubsurfaceSetPosition(SubsurfaceSetPositionRequest)
+}
+
+// SubsurfacePlaceAboveRequest: restack the sub-surface
+//
+// This sub-surface is taken from the stack, and put back just
+// above the reference surface, changing the z-order of the sub-surfaces.
+// The reference surface must be one of the sibling surfaces, or the
+// parent surface. Using any other surface, including this sub-surface,
+// will cause a protocol error.
+//
+// The z-order is double-buffered. Requests are handled in order and
+// applied immediately to a pending state. The final pending state is
+// copied to the active state the next time the state of the parent
+// surface is applied. When this happens depends on whether the parent
+// surface is in synchronized mode or not. See wl_subsurface.set_sync and
+// wl_subsurface.set_desync for details.
+//
+// A new sub-surface is initially added as the top-most in the stack
+// of its siblings and parent.
+type SubsurfacePlaceAboveRequest struct {
+	Resource *Subsurface
+	Sibling  *Surface
+}
+
+// SubsurfacePlaceAboveHandler is implemented by the handlers of SubsurfacePlaceAboveRequest, see SetHandler
+type SubsurfacePlaceAboveHandler interface {
+	HandleSubsurfacePlaceAbove(SubsurfacePlaceAboveRequest)
+}
+
+// SubsurfacePlaceBelowRequest: restack the sub-surface
+//
+// The sub-surface is placed just below the reference surface.
+// See wl_subsurface.place_above.
+type SubsurfacePlaceBelowRequest struct {
+	Resource *Subsurface
+	Sibling  *Surface
+}
+
+// SubsurfacePlaceBelowHandler is implemented by the handlers of SubsurfacePlaceBelowRequest, see SetHandler
+type SubsurfacePlaceBelowHandler interface {
+	HandleSubsurfacePlaceBelow(SubsurfacePlaceBelowRequest)
+}
+
+// SubsurfaceSetSyncRequest: set sub-surface to synchronized mode
+//
+// Change the commit behaviour of the sub-surface to synchronized
+// mode, also described as the parent dependent mode.
+//
+// In synchronized mode, wl_surface.commit on a sub-surface will
+// accumulate the committed state in a cache, but the state will
+// not be applied and hence will not change the compositor output.
+// The cached state is applied to the sub-surface immediately after
+// the parent surface's state is applied. This ensures atomic
+// updates of the parent and all its synchronized sub-surfaces.
+// Applying the cached state will invalidate the cache, so further
+// parent surface commits do not (re-)apply old state.
+//
+// See wl_subsurface for the recursive effect of this mode.
+type SubsurfaceSetSyncRequest struct {
+	Resource *Subsurface
+}
+
+// SubsurfaceSetSyncHandler is implemented by the handlers of SubsurfaceSetSyncRequest, see SetHandler
+type SubsurfaceSetSyncHandler interface {
+	HandleSubsurfaceSetSync(SubsurfaceSetSyncRequest)
+}
+
+// SubsurfaceSetDesyncRequest: set sub-surface to desynchronized mode
+//
+// Change the commit behaviour of the sub-surface to desynchronized
+// mode, also described as independent or freely running mode.
+//
+// In desynchronized mode, wl_surface.commit on a sub-surface will
+// apply the pending state directly, without caching, as happens
+// normally with a wl_surface. Calling wl_surface.commit on the
+// parent surface has no effect on the sub-surface's wl_surface
+// state. This mode allows a sub-surface to be updated on its own.
+//
+// If cached state exists when wl_surface.commit is called in
+// desynchronized mode, the pending state is added to the cached
+// state, and applied as a whole. This invalidates the cache.
+//
+// Note: even if a sub-surface is set to desynchronized, a parent
+// sub-surface may override it to behave as synchronized. For details,
+// see wl_subsurface.
+//
+// If a surface's parent surface behaves as desynchronized, then
+// the cached state is applied on set_desync.
+type SubsurfaceSetDesyncRequest struct {
+	Resource *Subsurface
+}
+
+// SubsurfaceSetDesyncHandler is implemented by the handlers of SubsurfaceSetDesyncRequest, see SetHandler
+type SubsurfaceSetDesyncHandler interface {
+	HandleSubsurfaceSetDesync(SubsurfaceSetDesyncRequest)
+}
+
+// Dispatch decodes a request received on the wl_subsurface and runs its handler. The opcode and
+// the version of the request are checked by the Client before.
+func (r *Subsurface) Dispatch(event *wl.Event) error {
+	c := r.Client()
+	switch event.Opcode {
+	case 0:
+		req := SubsurfaceDestroyRequest{Resource: r}
+		if h, ok := r.Handler().(SubsurfaceDestroyHandler); ok {
+			h.HandleSubsurfaceDestroy(req)
+		}
+	case 1:
+		req := SubsurfaceSetPositionRequest{Resource: r}
+		req.X = event.Int32()
+		req.Y = event.Int32()
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SubsurfaceSetPositionHandler); ok {
+			h.HandleSubsurfaceSetPosition(req)
+		}
+	case 2:
+		req := SubsurfacePlaceAboveRequest{Resource: r}
+		var err error
+		var siblingRes Resource
+		if siblingRes, err = c.Argument(event.Uint32(), false, wl.SurfaceInterface); err != nil {
+			return err
+		}
+		req.Sibling, _ = siblingRes.(*Surface)
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SubsurfacePlaceAboveHandler); ok {
+			h.HandleSubsurfacePlaceAbove(req)
+		}
+	case 3:
+		req := SubsurfacePlaceBelowRequest{Resource: r}
+		var err error
+		var siblingRes Resource
+		if siblingRes, err = c.Argument(event.Uint32(), false, wl.SurfaceInterface); err != nil {
+			return err
+		}
+		req.Sibling, _ = siblingRes.(*Surface)
+		if err := event.Err(); err != nil {
+			return err
+		}
+		if h, ok := r.Handler().(SubsurfacePlaceBelowHandler); ok {
+			h.HandleSubsurfacePlaceBelow(req)
+		}
+	case 4:
+		req := SubsurfaceSetSyncRequest{Resource: r}
+		if h, ok := r.Handler().(SubsurfaceSetSyncHandler); ok {
+			h.HandleSubsurfaceSetSync(req)
+		}
+	case 5:
+		req := SubsurfaceSetDesyncRequest{Resource: r}
+		if h, ok := r.Handler().(SubsurfaceSetDesyncHandler); ok {
+			h.HandleSubsurfaceSetDesync(req)
+		}
+	}
+	return nil
+}
+
+func init() {
+	RegisterResource(wl.DisplayInterface, func() Resource {
+		return new(Display)
+	})
+	RegisterResource(wl.RegistryInterface, func() Resource {
+		return new(Registry)
+	})
+	RegisterResource(wl.CallbackInterface, func() Resource {
+		return new(Callback)
+	})
+	RegisterResource(wl.CompositorInterface, func() Resource {
+		return new(Compositor)
+	})
+	RegisterResource(wl.ShmPoolInterface, func() Resource {
+		return new(ShmPool)
+	})
+	RegisterResource(wl.ShmInterface, func() Resource {
+		return new(Shm)
+	})
+	RegisterResource(wl.BufferInterface, func() Resource {
+		return new(Buffer)
+	})
+	RegisterResource(wl.DataOfferInterface, func() Resource {
+		return new(DataOffer)
+	})
+	RegisterResource(wl.DataSourceInterface, func() Resource {
+		return new(DataSource)
+	})
+	RegisterResource(wl.DataDeviceInterface, func() Resource {
+		return new(DataDevice)
+	})
+	RegisterResource(wl.DataDeviceManagerInterface, func() Resource {
+		return new(DataDeviceManager)
+	})
+	RegisterResource(wl.ShellInterface, func() Resource {
+		return new(Shell)
+	})
+	RegisterResource(wl.ShellSurfaceInterface, func() Resource {
+		return new(ShellSurface)
+	})
+	RegisterResource(wl.SurfaceInterface, func() Resource {
+		return new(Surface)
+	})
+	RegisterResource(wl.SeatInterface, func() Resource {
+		return new(Seat)
+	})
+	RegisterResource(wl.PointerInterface, func() Resource {
+		return new(Pointer)
+	})
+	RegisterResource(wl.KeyboardInterface, func() Resource {
+		return new(Keyboard)
+	})
+	RegisterResource(wl.TouchInterface, func() Resource {
+		return new(Touch)
+	})
+	RegisterResource(wl.OutputInterface, func() Resource {
+		return new(Output)
+	})
+	RegisterResource(wl.RegionInterface, func() Resource {
+		return new(Region)
+	})
+	RegisterResource(wl.SubcompositorInterface, func() Resource {
+		return new(Subcompositor)
+	})
+	RegisterResource(wl.SubsurfaceInterface, func() Resource {
+		return new(Subsurface)
+	})
+}
@@ -0,0 +1,615 @@
+// Package wltest implements an in-process fake Wayland compositor for testing clients
+//
+// New creates a socketpair, wraps one end into a wl.Context and serves the other end
+// by a scriptable Server. The Server answers wl_display.sync and wl_display.get_registry,
+// advertises the globals added by AddGlobal, tracks the objects created by wl_registry.bind,
+// sends arbitrary events by SendEvent and protocol errors by PostError, and records every request
+// the client has sent.
+// Destructor requests are acknowledged by wl_display.delete_id.
+// Like libwayland compositors, the Server requires the objects created by the client to take
+// the next id or an id freed by delete_id, otherwise Err reports ErrInvalidNewId.
+//
+// Requests are processed in order, so after a successful roundtrip
+// (for example wlclient.DisplayRoundtrip) all the requests sent before
+// it are present in Requests.
+package wltest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	stdos "os"
+	"sync"
+	"time"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wl"
+	"github.com/yalue/native_endian"
+)
+
+// Request is a request received from the client. Name and Fds are filled in when
+// the interface description is registered in the wl package.
+type Request struct {
+	Pid       wl.ProxyId
+	Interface string
+	Name      string
+	Opcode    uint32
+	Data      []byte
+	Fds       []uintptr
+}
+
+// Event returns the Request as a wl.Event, so that the arguments can be decoded
+// using the wl.Event decoders (Uint32, String, ...). File descriptors are in Fds.
+func (r Request) Event() *wl.Event {
+	return &wl.Event{Pid: r.Pid, Opcode: r.Opcode, Data: r.Data}
+}
+
+// RequestHandler is called on the server goroutine for every matching request
+type RequestHandler func(s *Server, r Request)
+
+// Global is a global advertised by the Server
+type Global struct {
+	Name      uint32
+	Interface string
+	Version   uint32
+}
+
+// ErrServerClosed is returned when sending an event on a closed Server
+var ErrServerClosed = errors.New("server closed")
+
+// ErrInvalidEventArgument is returned by SendEvent for unsupported argument types
+var ErrInvalidEventArgument = errors.New("invalid Wayland event argument type")
+
+// ErrMalformedRequest is reported by Err when the client sent a message that cannot be parsed
+var ErrMalformedRequest = errors.New("malformed request")
+
+// ErrInvalidNewId is reported by Err when the client created an object with an id in use, or
+// skipped ids, which libwayland compositors reject with a protocol error
+var ErrInvalidNewId = errors.New("invalid new id")
+
+const displayId = 1
+
+// serverIdStart is the first id of the objects created by the server
+const serverIdStart = 0xff000000
+
+type handlerKey struct {
+	iface  string
+	opcode uint32
+}
+
+// Server is the compositor side of the connection
+type Server struct {
+	mu       sync.Mutex
+	wmu      sync.Mutex
+	wcond    *sync.Cond
+	out      []event
+	sending  bool
+	closing  bool
+	written  chan struct{}
+	conn     *net.UnixConn
+	done     chan struct{}
+	err      error
+	serial   uint32
+	nextName uint32
+	globals  []Global
+	objects  map[wl.ProxyId]string
+	maxId    wl.ProxyId
+	untyped  bool
+	regs     []wl.ProxyId
+	requests []Request
+	handlers map[handlerKey][]RequestHandler
+}
+
+// New creates a connected pair of a client wl.Display and a fake Server
+func New() (*wl.Display, *Server, error) {
+	fds, err := sys.Socketpair()
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := unixConn(fds[0])
+	if err != nil {
+		sys.Close(fds[1])
+		return nil, nil, err
+	}
+	server, err := unixConn(fds[1])
+	if err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+	display, err := wl.ConnectConn(client)
+	if err != nil {
+		client.Close()
+		server.Close()
+		return nil, nil, err
+	}
+	return display, NewServer(server), nil
+}
+
+func unixConn(fd int) (*net.UnixConn, error) {
+	f := stdos.NewFile(uintptr(fd), "wltest")
+	defer f.Close()
+	conn, err := net.FileConn(f)
+	if err != nil {
+		return nil, err
+	}
+	return conn.(*net.UnixConn), nil
+}
+
+// NewServer starts serving a client connected on conn
+func NewServer(conn *net.UnixConn) *Server {
+	s := &Server{
+		conn:     conn,
+		done:     make(chan struct{}),
+		objects:  map[wl.ProxyId]string{displayId: "wl_display"},
+		maxId:    displayId,
+		handlers: make(map[handlerKey][]RequestHandler),
+		written:  make(chan struct{}),
+	}
+	s.wcond = sync.NewCond(&s.wmu)
+	go s.write()
+	go s.serve()
+	return s
+}
+
+// AddGlobal advertises a new global to all the registries and returns its name
+func (s *Server) AddGlobal(iface string, version uint32) uint32 {
+	s.mu.Lock()
+	s.nextName++
+	g := Global{Name: s.nextName, Interface: iface, Version: version}
+	s.globals = append(s.globals, g)
+	regs := append([]wl.ProxyId(nil), s.regs...)
+	s.mu.Unlock()
+
+	for _, reg := range regs {
+		s.SendEvent(reg, 0, g.Name, g.Interface, g.Version)
+	}
+	return g.Name
+}
+
+// RemoveGlobal removes a global and announces the removal to all the registries
+func (s *Server) RemoveGlobal(name uint32) {
+	s.mu.Lock()
+	for i, g := range s.globals {
+		if g.Name == name {
+			s.globals = append(s.globals[:i:i], s.globals[i+1:]...)
+			break
+		}
+	}
+	regs := append([]wl.ProxyId(nil), s.regs...)
+	s.mu.Unlock()
+
+	for _, reg := range regs {
+		s.SendEvent(reg, 1, name)
+	}
+}
+
+// Handle registers a handler called for requests with the opcode on objects of the interface
+func (s *Server) Handle(iface string, opcode uint32, h RequestHandler) {
+	s.mu.Lock()
+	k := handlerKey{iface, opcode}
+	s.handlers[k] = append(s.handlers[k], h)
+	s.mu.Unlock()
+}
+
+// SetInterface records the interface of an object created by the client. Objects created
+// by requests of interfaces registered in the wl package (wl.LookupInterface) are recorded
+// automatically.
+func (s *Server) SetInterface(id wl.ProxyId, iface string) {
+	s.mu.Lock()
+	s.objects[id] = iface
+	s.mu.Unlock()
+}
+
+// Interface returns the recorded interface of an object
+func (s *Server) Interface(id wl.ProxyId) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.objects[id]
+}
+
+// Bound returns the ids of the objects of the interface bound by the client
+func (s *Server) Bound(iface string) (ids []wl.ProxyId) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, i := range s.objects {
+		if i == iface {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Requests returns all the requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsTo returns the requests received on objects of the interface
+func (s *Server) RequestsTo(iface string) (ret []Request) {
+	for _, r := range s.Requests() {
+		if r.Interface == iface {
+			ret = append(ret, r)
+		}
+	}
+	return ret
+}
+
+// Serial returns a new event serial
+func (s *Server) Serial() uint32 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.serial++
+	return s.serial
+}
+
+// PostError sends a wl_display.error event about the object to the client, like wl_resource_post_error
+func (s *Server) PostError(id wl.ProxyId, code uint32, message string) error {
+	return s.SendEvent(displayId, 0, id, code, message)
+}
+
+// SendEvent sends an event with the arguments to the client. The arguments are encoded the same
+// way as by wl.Request Write: uint32, int32, float32 (fixed), string, []int32 (array),
+// uintptr (fd) and wl.Proxy or wl.ProxyId (object). The event is queued and sent in order by
+// another goroutine, so SendEvent does not block when the client is not reading.
+func (s *Server) SendEvent(id wl.ProxyId, opcode uint32, args ...interface{}) error {
+	var data []byte
+	var fds []int
+	put := func(u uint32) {
+		var buf [4]byte
+		native_endian.NativeEndian().PutUint32(buf[:], u)
+		data = append(data, buf[:]...)
+	}
+	for _, arg := range args {
+		switch t := arg.(type) {
+		case uint32:
+			put(t)
+		case int32:
+			put(uint32(t))
+		case float32:
+			put(uint32(wl.FloatToFixed(float64(t))))
+		case string:
+			put(uint32(len(t) + 1))
+			data = append(data, t...)
+			data = append(data, make([]byte, 4-len(t)&3)...)
+		case []int32:
+			put(uint32(4 * len(t)))
+			for _, e := range t {
+				put(uint32(e))
+			}
+		case uintptr:
+			fds = append(fds, int(t))
+		case wl.ProxyId:
+			put(uint32(t))
+		case wl.Proxy:
+			put(uint32(t.Id()))
+		case nil:
+			put(0)
+		default:
+			return ErrInvalidEventArgument
+		}
+	}
+
+	if id == displayId && opcode == 1 && len(args) == 1 {
+		// delete_id frees the id, the client may reuse it from now on
+		if freed, ok := args[0].(uint32); ok {
+			s.mu.Lock()
+			delete(s.objects, wl.ProxyId(freed))
+			s.mu.Unlock()
+		}
+	}
+
+	msg := make([]byte, 8, 8+len(data))
+	native_endian.NativeEndian().PutUint32(msg[0:4], uint32(id))
+	native_endian.NativeEndian().PutUint32(msg[4:8], uint32(8+len(data))<<16|opcode&0xffff)
+	msg = append(msg, data...)
+
+	// the fds are duplicated, so the caller may close them once the event is queued
+	for i, fd := range fds {
+		dup, err := sys.DupCloexec(fd)
+		if err != nil {
+			for _, d := range fds[:i] {
+				sys.Close(d)
+			}
+			return err
+		}
+		fds[i] = dup
+	}
+
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	if s.closing {
+		for _, fd := range fds {
+			sys.Close(fd)
+		}
+		return ErrServerClosed
+	}
+	s.out = append(s.out, event{msg, fds})
+	s.wcond.Broadcast()
+	return nil
+}
+
+// event is an encoded event queued for the writer
+type event struct {
+	msg []byte
+	fds []int
+}
+
+// write sends the queued events in order. Like a compositor, the Server never blocks on a client
+// that is not reading, the events are buffered instead.
+func (s *Server) write() {
+	defer close(s.written)
+	for {
+		s.wmu.Lock()
+		for len(s.out) == 0 && !s.closing {
+			s.wcond.Wait()
+		}
+		if len(s.out) == 0 {
+			s.wmu.Unlock()
+			return
+		}
+		e := s.out[0]
+		s.out = s.out[1:]
+		s.sending = true
+		s.wmu.Unlock()
+
+		var oob []byte
+		if len(e.fds) > 0 {
+			oob = sys.UnixRights(e.fds...)
+		}
+		_, _, err := s.conn.WriteMsgUnix(e.msg, oob, nil)
+		for _, fd := range e.fds {
+			sys.Close(fd)
+		}
+		s.wmu.Lock()
+		s.sending = false
+		s.wcond.Broadcast()
+		s.wmu.Unlock()
+		if err != nil {
+			// the client is gone, drop the rest
+			s.stopWriting()
+			s.wmu.Lock()
+			for _, e := range s.out {
+				for _, fd := range e.fds {
+					sys.Close(fd)
+				}
+			}
+			s.out = nil
+			s.wmu.Unlock()
+			return
+		}
+	}
+}
+
+// Flush blocks until the queued events are written to the connection, so that the client
+// receives the events sent so far together in its next read
+func (s *Server) Flush() {
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	for (len(s.out) > 0 || s.sending) && !s.closing {
+		s.wcond.Wait()
+	}
+}
+
+// stopWriting makes SendEvent fail, the writer returns after sending the events already queued
+func (s *Server) stopWriting() {
+	s.wmu.Lock()
+	s.closing = true
+	s.wcond.Broadcast()
+	s.wmu.Unlock()
+}
+
+// Err returns the error that stopped the server, nil while it is running
+// or when the client closed the connection
+func (s *Server) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Done is closed when the server stops
+func (s *Server) Done() <-chan struct{} {
+	return s.done
+}
+
+// Close closes the connection and all file descriptors received from the client. The events
+// already sent are delivered first, unless the client does not read them within a second.
+func (s *Server) Close() error {
+	s.stopWriting()
+	s.conn.SetWriteDeadline(time.Now().Add(time.Second))
+	<-s.written
+	err := s.conn.Close()
+	<-s.done
+	s.mu.Lock()
+	for _, r := range s.requests {
+		for _, fd := range r.Fds {
+			sys.Close(int(fd))
+		}
+	}
+	s.mu.Unlock()
+	return err
+}
+
+func (s *Server) serve() {
+	defer close(s.done)
+	defer s.stopWriting()
+
+	var pending []byte
+	var fds []uintptr
+	defer func() {
+		for _, fd := range fds {
+			sys.Close(int(fd))
+		}
+	}()
+	buf := make([]byte, 4096)
+	control := make([]byte, 4096)
+	for {
+		n, oobn, _, _, err := s.conn.ReadMsgUnix(buf, control)
+		if err != nil {
+			if err != io.EOF && !errors.Is(err, net.ErrClosed) {
+				s.fail(err)
+			}
+			return
+		}
+		if oobn > 0 {
+			scms, err := sys.ParseSocketControlMessage(control[:oobn])
+			if err != nil {
+				s.fail(err)
+				return
+			}
+			for i := range scms {
+				rights, err := sys.ParseUnixRights(&scms[i])
+				if err != nil {
+					continue
+				}
+				for _, fd := range rights {
+					fds = append(fds, uintptr(fd))
+				}
+			}
+		}
+		pending = append(pending, buf[:n]...)
+
+		// the fds are queued and consumed in order by the requests declaring fd arguments
+		for len(pending) >= 8 {
+			size := int(native_endian.NativeEndian().Uint16(pending[6:8]))
+			if size < 8 || size&3 != 0 {
+				s.fail(ErrMalformedRequest)
+				return
+			}
+			if len(pending) < size {
+				break
+			}
+			r := Request{
+				Pid:    wl.ProxyId(native_endian.NativeEndian().Uint32(pending[0:4])),
+				Opcode: uint32(native_endian.NativeEndian().Uint16(pending[4:6])),
+				Data:   append([]byte(nil), pending[8:size]...),
+			}
+			pending = pending[size:]
+			fds = s.process(r, fds)
+		}
+	}
+}
+
+func (s *Server) fail(err error) {
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+}
+
+// process records and answers a request, it returns the fds left after the request took its own
+func (s *Server) process(r Request, fds []uintptr) []uintptr {
+	s.mu.Lock()
+	r.Interface = s.objects[r.Pid]
+	msg := message(r.Interface, r.Opcode)
+	if msg != nil {
+		r.Name = msg.Name
+		for _, a := range msg.Args {
+			if a.Type == wl.ArgFd && len(fds) > 0 {
+				r.Fds = append(r.Fds, fds[0])
+				fds = fds[1:]
+			}
+		}
+	}
+	s.requests = append(s.requests, r)
+	handlers := s.handlers[handlerKey{r.Interface, r.Opcode}]
+	s.mu.Unlock()
+
+	s.trackNewIds(r, msg)
+
+	ev := r.Event()
+	switch {
+	case r.Interface == "wl_display" && r.Opcode == 0:
+		// sync
+		cb := wl.ProxyId(ev.Uint32())
+		s.SendEvent(cb, 0, s.Serial())
+		s.SendEvent(displayId, 1, uint32(cb))
+	case r.Interface == "wl_display" && r.Opcode == 1:
+		// get_registry
+		reg := wl.ProxyId(ev.Uint32())
+		s.mu.Lock()
+		s.objects[reg] = "wl_registry"
+		s.regs = append(s.regs, reg)
+		globals := append([]Global(nil), s.globals...)
+		s.mu.Unlock()
+		for _, g := range globals {
+			s.SendEvent(reg, 0, g.Name, g.Interface, g.Version)
+		}
+	}
+
+	for _, h := range handlers {
+		h(s, r)
+	}
+
+	if msg != nil && msg.Destructor {
+		// like libwayland, acknowledge the destruction of the objects the client has created
+		s.mu.Lock()
+		delete(s.objects, r.Pid)
+		s.mu.Unlock()
+		if r.Pid < serverIdStart {
+			s.SendEvent(displayId, 1, uint32(r.Pid))
+		}
+	}
+	return fds
+}
+
+// checkNewId checks the id of an object created by the client is free, and the next one unless
+// it is reused
+func (s *Server) checkNewId(id wl.ProxyId) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, used := s.objects[id]
+	if used || (!s.untyped && id > s.maxId+1) {
+		if s.err == nil {
+			s.err = fmt.Errorf("%w %d", ErrInvalidNewId, id)
+		}
+		return
+	}
+	if id > s.maxId {
+		s.maxId = id
+	}
+}
+
+func message(iface string, opcode uint32) *wl.Message {
+	i := wl.LookupInterface(iface)
+	if i == nil || int(opcode) >= len(i.Requests) {
+		return nil
+	}
+	return &i.Requests[opcode]
+}
+
+// trackNewIds records the interfaces of the objects created by the request
+func (s *Server) trackNewIds(r Request, msg *wl.Message) {
+	if msg == nil {
+		// the ids created by the requests of unknown interfaces cannot be checked
+		s.mu.Lock()
+		s.untyped = true
+		s.mu.Unlock()
+		return
+	}
+	ev := r.Event()
+	var iface string
+	for _, a := range msg.Args {
+		switch a.Type {
+		case wl.ArgInt, wl.ArgUint, wl.ArgFixed, wl.ArgObject:
+			ev.Uint32()
+		case wl.ArgString:
+			// the interface of a new_id without a specific interface
+			iface = ev.String()
+		case wl.ArgArray:
+			ev.Array()
+		case wl.ArgNewId:
+			id := wl.ProxyId(ev.Uint32())
+			if a.Interface != "" {
+				iface = a.Interface
+			}
+			if id != 0 && id < serverIdStart {
+				s.checkNewId(id)
+			}
+			if id != 0 && iface != "" {
+				s.SetInterface(id, iface)
+			}
+		}
+	}
+}
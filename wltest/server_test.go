@@ -0,0 +1,158 @@
+package wltest_test
+
+import (
+	"errors"
+	"testing"
+
+	sys "github.com/neurlang/wayland/os"
+	"github.com/neurlang/wayland/wl"
+	"github.com/neurlang/wayland/wlclient"
+	"github.com/neurlang/wayland/wltest"
+	"golang.org/x/sys/unix"
+)
+
+func newServer(t *testing.T) (*wl.Display, *wltest.Server) {
+	display, srv, err := wltest.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		display.Context().Close()
+		srv.Close()
+	})
+	return display, srv
+}
+
+func roundtrip(t *testing.T, display *wl.Display, srv *wltest.Server) {
+	if err := wlclient.DisplayRoundtrip(display); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Err(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type globals []wl.Global
+
+func (g *globals) HandleGlobalAdd(global wl.Global) {
+	*g = append(*g, global)
+}
+
+func TestServerRecordsRequests(t *testing.T) {
+	display, srv := newServer(t)
+	srv.AddGlobal("wl_compositor", 4)
+
+	registry, err := display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker := wl.NewGlobalTracker(registry)
+	var added globals
+	tracker.AddGlobalAddHandler(&added)
+	roundtrip(t, display, srv)
+	// a global added later is announced to the registry
+	srv.AddGlobal("wl_shm", 1)
+	roundtrip(t, display, srv)
+	if len(added) != 2 || added[0].Interface != "wl_compositor" || added[1].Interface != "wl_shm" {
+		t.Fatalf("globals %v, want wl_compositor and wl_shm", added)
+	}
+
+	p, err := tracker.Bind("wl_compositor", 1, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	surface, err := p.(*wl.Compositor).CreateSurface()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := surface.Destroy(); err != nil {
+		t.Fatal(err)
+	}
+	roundtrip(t, display, srv)
+
+	if ids := srv.Bound("wl_compositor"); len(ids) != 1 || ids[0] != p.Id() {
+		t.Errorf("bound compositors %v, want [%d]", ids, p.Id())
+	}
+	reqs := srv.RequestsTo("wl_compositor")
+	if len(reqs) != 1 || reqs[0].Name != "create_surface" {
+		t.Fatalf("wl_compositor requests %v, want create_surface", reqs)
+	}
+	if id := wl.ProxyId(reqs[0].Event().Uint32()); id != surface.Id() {
+		t.Errorf("created surface %d, want %d", id, surface.Id())
+	}
+	reqs = srv.RequestsTo("wl_surface")
+	if len(reqs) != 1 || reqs[0].Name != "destroy" {
+		t.Fatalf("wl_surface requests %v, want destroy", reqs)
+	}
+	// the destroyed id is freed by delete_id
+	if iface := srv.Interface(surface.Id()); iface != "" {
+		t.Errorf("destroyed surface is still a %s", iface)
+	}
+}
+
+type keymap struct {
+	fd  uintptr
+	err error
+}
+
+func (k *keymap) HandleKeyboardKeymap(ev wl.KeyboardKeymapEvent) {
+	k.fd, k.err = ev.Fd()
+}
+
+func TestServerSendsFds(t *testing.T) {
+	display, srv := newServer(t)
+	srv.AddGlobal("wl_seat", 5)
+	registry, err := display.GetRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker := wl.NewGlobalTracker(registry)
+	roundtrip(t, display, srv)
+	p, err := tracker.Bind("wl_seat", 1, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyboard, err := p.(*wl.Seat).GetKeyboard()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var k keymap
+	keyboard.AddKeymapHandler(&k)
+	roundtrip(t, display, srv)
+
+	file, err := sys.CreateAnonymousFile(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if err := srv.SendEvent(keyboard.Id(), 0, uint32(1), file.Fd(), uint32(100)); err != nil {
+		t.Fatal(err)
+	}
+	roundtrip(t, display, srv)
+	if k.err != nil {
+		t.Fatal(k.err)
+	}
+	defer sys.Close(int(k.fd))
+	var st unix.Stat_t
+	if err := unix.Fstat(int(k.fd), &st); err != nil {
+		t.Fatal(err)
+	}
+	if st.Size != 100 {
+		t.Errorf("received a file of %d bytes, want 100", st.Size)
+	}
+}
+
+func TestServerPostError(t *testing.T) {
+	display, srv := newServer(t)
+	if err := srv.PostError(1, 1, "bad method"); err != nil {
+		t.Fatal(err)
+	}
+	err := wlclient.DisplayRoundtrip(display)
+	var protocolErr *wl.ProtocolError
+	if !errors.As(err, &protocolErr) {
+		t.Fatalf("roundtrip: %v, want a ProtocolError", err)
+	}
+	if protocolErr.Interface != "wl_display" || protocolErr.Code != 1 || protocolErr.Message != "bad method" {
+		t.Errorf("error %+v, want wl_display invalid_method", protocolErr)
+	}
+}
@@ -1,7 +1,7 @@
 // Package xdg implements the stable XDG Window Manager Base protocol
 package xdg
 
-//go:generate ../../../../../bin/go-wayland-scanner -pkg xdg -i xdg-shell.xml -o xdg-shell.xml.go
+//go:generate go run ../cmd/go-wayland-scanner -pkg xdg -prefix xdg_ -i xdg-shell.xml -o xdg-shell.xml.go -fuzz xdg-shell.xml_fuzz_test.go
 
 import "github.com/neurlang/wayland/wl"
 
@@ -36,3 +36,13 @@ func ToplevelAddListener(tl *Toplevel, h ToplevelListener) {
 	tl.AddConfigureHandler(h)
 	tl.AddCloseHandler(h)
 }
+
+// GetSurface creates an xdg_surface for the given surface, see GetXdgSurface
+func (p *WmBase) GetSurface(surface *wl.Surface) (*Surface, error) {
+	return p.GetXdgSurface(surface)
+}
+
+// SetAppID sets the application ID of the toplevel, see SetAppId
+func (p *Toplevel) SetAppID(appID string) error {
+	return p.SetAppId(appID)
+}
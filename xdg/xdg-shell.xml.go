@@ -1,8 +1,7 @@
-// Generated by go-wayland-scanner
-// https://github.com/rajveermalviya/go-wayland/cmd/go-wayland-scanner
+// Code generated by go-wayland-scanner; DO NOT EDIT.
 // XML file: xdg-shell.xml
 //
-// Shell Protocol Copyright:
+// XdgShell Protocol Copyright:
 //
 // Copyright © 2008-2013 Kristian Høgsberg
 // Copyright © 2013      Rafael Antognolli
@@ -33,14 +32,13 @@
 package xdg
 
 import (
-	"sync"
-
 	"github.com/neurlang/wayland/wl"
+	"sync"
 )
 
 // WmBase: create desktop-style surfaces
 //
-// The wm_base interface is exposed as a global object enabling clients
+// The xdg_wm_base interface is exposed as a global object enabling clients
 // to turn their wl_surfaces into windows in a desktop environment. It
 // defines the basic functionality needed for clients and the compositor to
 // create windows that can be dragged, resized, maximized, etc, as well as
@@ -51,30 +49,23 @@ type WmBase struct {
 	pingHandlers []WmBasePingHandler
 }
 
-// NewWmBase: create desktop-style surfaces
-//
-// The wm_base interface is exposed as a global object enabling clients
-// to turn their wl_surfaces into windows in a desktop environment. It
-// defines the basic functionality needed for clients and the compositor to
-// create windows that can be dragged, resized, maximized, etc, as well as
-// creating transient windows such as popup menus.
+// NewWmBase creates a new xdg_wm_base proxy registered in the Context
 func NewWmBase(ctx *wl.Context) *WmBase {
-	WmBase := &WmBase{}
-	ctx.Register(WmBase)
-	return WmBase
+	ret := new(WmBase)
+	ctx.Register(ret)
+	return ret
 }
 
-// Destroy: destroy wm_base
+// Destroy: destroy xdg_wm_base
 //
-// Destroy this wm_base object.
+// Destroy this xdg_wm_base object.
 //
-// Destroying a bound wm_base object while there are surfaces
-// still alive created by this wm_base object instance is illegal
+// Destroying a bound xdg_wm_base object while there are surfaces
+// still alive created by this xdg_wm_base object instance is illegal
 // and will result in a protocol error.
-//
-func (i *WmBase) Destroy() error {
-	err := i.Context().SendRequest(i, 0)
-	i.Unregister()
+func (p *WmBase) Destroy() error {
+	err := p.Context().MarshalRequest(p, 0, nil)
+	p.Unregister()
 	return err
 }
 
@@ -82,50 +73,60 @@ func (i *WmBase) Destroy() error {
 //
 // Create a positioner object. A positioner object is used to position
 // surfaces relative to some parent surface. See the interface description
-// and surface.get_popup for details.
-//
-func (i *WmBase) CreatePositioner() (*Positioner, error) {
-	id := NewPositioner(i.Context())
-	err := i.Context().SendRequest(i, 1, id)
-	return id, err
+// and xdg_surface.get_popup for details.
+func (p *WmBase) CreatePositioner() (*Positioner, error) {
+	ret := NewPositioner(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 1, func(r *wl.Request) {
+		r.PutNewId(ret)
+	})
 }
 
-// GetSurface: create a shell surface from a surface
+// GetXdgSurface: create a shell surface from a surface
 //
-// This creates an surface for the given surface. While surface
+// This creates an xdg_surface for the given surface. While xdg_surface
 // itself is not a role, the corresponding surface may only be assigned
-// a role extending surface, such as toplevel or popup.
+// a role extending xdg_surface, such as xdg_toplevel or xdg_popup.
 //
-// This creates an surface for the given surface. An surface is
-// used as basis to define a role to a given surface, such as toplevel
-// or popup. It also manages functionality shared between surface
+// This creates an xdg_surface for the given surface. An xdg_surface is
+// used as basis to define a role to a given surface, such as xdg_toplevel
+// or xdg_popup. It also manages functionality shared between xdg_surface
 // based surface roles.
 //
-// See the documentation of surface for more details about what an
-// surface is and how it is used.
-//
-func (i *WmBase) GetSurface(surface *wl.Surface) (*Surface, error) {
-	id := NewSurface(i.Context())
-	err := i.Context().SendRequest(i, 2, id, surface)
-	return id, err
+// See the documentation of xdg_surface for more details about what an
+// xdg_surface is and how it is used.
+func (p *WmBase) GetXdgSurface(surface *wl.Surface) (*Surface, error) {
+	ret := NewSurface(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 2, func(r *wl.Request) {
+		r.PutNewId(ret)
+		if surface != nil {
+			r.PutObject(surface.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
 }
 
 // Pong: respond to a ping event
 //
 // A client must respond to a ping event with a pong request or
-// the client may be deemed unresponsive. See wm_base.ping.
+// the client may be deemed unresponsive. See xdg_wm_base.ping.
 //
-//  serial: serial of the ping event
-func (i *WmBase) Pong(serial uint32) error {
-	err := i.Context().SendRequest(i, 3, serial)
-	return err
+//	serial: serial of the ping event
+func (p *WmBase) Pong(serial uint32) error {
+	return p.Context().MarshalRequest(p, 3, func(r *wl.Request) {
+		r.PutUint32(serial)
+	})
 }
 
 // WmBaseError:
 const (
 	// WmBaseErrorRole: given wl_surface has another role
 	WmBaseErrorRole = 0
-	// WmBaseErrorDefunctSurfaces: wm_base was destroyed before children
+	// WmBaseErrorDefunctSurfaces: xdg_wm_base was destroyed before children
 	WmBaseErrorDefunctSurfaces = 1
 	// WmBaseErrorNotTheTopmostPopup: the client tried to map or destroy a non-topmost popup
 	WmBaseErrorNotTheTopmostPopup = 2
@@ -135,13 +136,15 @@ const (
 	WmBaseErrorInvalidSurfaceState = 4
 	// WmBaseErrorInvalidPositioner: the client provided an invalid positioner
 	WmBaseErrorInvalidPositioner = 5
+	// WmBaseErrorUnresponsive: the client didn’t respond to a ping event in time
+	WmBaseErrorUnresponsive = 6
 )
 
 // WmBasePingEvent: check if the client is alive
 //
 // The ping event asks the client if it's still alive. Pass the
 // serial specified in the event back to the compositor by sending
-// a "pong" request back with the specified serial. See wm_base.pong.
+// a "pong" request back with the specified serial. See xdg_wm_base.pong.
 //
 // Compositors can use this to determine if the client is still
 // alive. It's unspecified what will happen if the client doesn't
@@ -149,67 +152,135 @@ const (
 // try to respond in a reasonable amount of time.
 //
 // A compositor is free to ping in any way it wants, but a client must
-// always respond to any wm_base object it created.
+// always respond to any xdg_wm_base object it created.
 type WmBasePingEvent struct {
 	Serial uint32
 }
 
+// WmBasePingHandler is implemented by the receivers of WmBasePingEvent
 type WmBasePingHandler interface {
 	HandleWmBasePing(WmBasePingEvent)
 }
 
-// AddPingHandler: adds handler for WmBasePingEvent
-func (i *WmBase) AddPingHandler(h WmBasePingHandler) {
+// AddPingHandler adds a handler for WmBasePingEvent
+func (p *WmBase) AddPingHandler(h WmBasePingHandler) {
 	if h == nil {
 		return
 	}
 
-	i.mu.Lock()
-	i.pingHandlers = append(i.pingHandlers, h)
-	i.mu.Unlock()
+	p.mu.Lock()
+	p.pingHandlers = append(p.pingHandlers, h)
+	p.mu.Unlock()
 }
 
-func (i *WmBase) RemovePingHandler(h WmBasePingHandler) {
-	i.mu.Lock()
-	defer i.mu.Unlock()
+// RemovePingHandler removes a handler previously added by AddPingHandler
+func (p *WmBase) RemovePingHandler(h WmBasePingHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	for j, e := range i.pingHandlers {
+	for i, e := range p.pingHandlers {
 		if e == h {
-			i.pingHandlers = append(i.pingHandlers[:j], i.pingHandlers[j+1:]...)
+			p.pingHandlers = append(p.pingHandlers[:i:i], p.pingHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-func (i *WmBase) Dispatch(event *wl.Event) {
+// Dispatch decodes an event received on the xdg_wm_base and runs its handlers
+func (p *WmBase) Dispatch(event *wl.Event) {
 	switch event.Opcode {
 	case 0:
-		i.mu.RLock()
-		if len(i.pingHandlers) == 0 {
-			i.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.pingHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
 			break
 		}
-		i.mu.RUnlock()
-
-		e := WmBasePingEvent{
-			Serial: event.Uint32(),
+		ev := WmBasePingEvent{}
+		ev.Serial = event.Uint32()
+		if event.Err() != nil {
+			break
 		}
-
-		i.mu.RLock()
-		for _, h := range i.pingHandlers {
-			i.mu.RUnlock()
-
-			h.HandleWmBasePing(e)
-
-			i.mu.RLock()
+		for _, h := range handlers {
+			h.HandleWmBasePing(ev)
 		}
-		i.mu.RUnlock()
 	}
 }
 
+const (
+	WmBasePingSinceVersion             = 1
+	WmBaseDestroySinceVersion          = 1
+	WmBaseCreatePositionerSinceVersion = 1
+	WmBaseGetXdgSurfaceSinceVersion    = 1
+	WmBasePongSinceVersion             = 1
+)
+
+// WmBaseInterface describes the xdg_wm_base interface
+var WmBaseInterface = &wl.Interface{
+	Name:    "xdg_wm_base",
+	Version: 6,
+	Requests: []wl.Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "create_positioner",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "id", Type: wl.ArgNewId, Interface: "xdg_positioner"},
+			},
+		},
+		{
+			Name:  "get_xdg_surface",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "id", Type: wl.ArgNewId, Interface: "xdg_surface"},
+				{Name: "surface", Type: wl.ArgObject, Interface: "wl_surface"},
+			},
+		},
+		{
+			Name:  "pong",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "serial", Type: wl.ArgUint},
+			},
+		},
+	},
+	Events: []wl.Message{
+		{
+			Name:  "ping",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "serial", Type: wl.ArgUint},
+			},
+		},
+	},
+	Enums: []wl.Enum{
+		{
+			Name: "error",
+			Entries: []wl.EnumEntry{
+				{Name: "role", Value: 0},
+				{Name: "defunct_surfaces", Value: 1},
+				{Name: "not_the_topmost_popup", Value: 2},
+				{Name: "invalid_popup_parent", Value: 3},
+				{Name: "invalid_surface_state", Value: 4},
+				{Name: "invalid_positioner", Value: 5},
+				{Name: "unresponsive", Value: 6},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the xdg_wm_base interface
+func (p *WmBase) Interface() *wl.Interface {
+	return WmBaseInterface
+}
+
 // Positioner: child surface positioner
 //
-// The positioner provides a collection of rules for the placement of a
+// The xdg_positioner provides a collection of rules for the placement of a
 // child surface relative to a parent surface. Rules can be defined to ensure
 // the child surface remains within the visible area's borders, and to
 // specify how the child surface changes its position, such as sliding along
@@ -220,52 +291,31 @@ func (i *WmBase) Dispatch(event *wl.Event) {
 // See the various requests for details about possible rules.
 //
 // At the time of the request, the compositor makes a copy of the rules
-// specified by the positioner. Thus, after the request is complete the
-// positioner object can be destroyed or reused; further changes to the
+// specified by the xdg_positioner. Thus, after the request is complete the
+// xdg_positioner object can be destroyed or reused; further changes to the
 // object will have no effect on previous usages.
 //
-// For an positioner object to be considered complete, it must have a
+// For an xdg_positioner object to be considered complete, it must have a
 // non-zero size set by set_size, and a non-zero anchor rectangle set by
-// set_anchor_rect. Passing an incomplete positioner object when
+// set_anchor_rect. Passing an incomplete xdg_positioner object when
 // positioning a surface raises an error.
 type Positioner struct {
 	wl.BaseProxy
 }
 
-// NewPositioner: child surface positioner
-//
-// The positioner provides a collection of rules for the placement of a
-// child surface relative to a parent surface. Rules can be defined to ensure
-// the child surface remains within the visible area's borders, and to
-// specify how the child surface changes its position, such as sliding along
-// an axis, or flipping around a rectangle. These positioner-created rules are
-// constrained by the requirement that a child surface must intersect with or
-// be at least partially adjacent to its parent surface.
-//
-// See the various requests for details about possible rules.
-//
-// At the time of the request, the compositor makes a copy of the rules
-// specified by the positioner. Thus, after the request is complete the
-// positioner object can be destroyed or reused; further changes to the
-// object will have no effect on previous usages.
-//
-// For an positioner object to be considered complete, it must have a
-// non-zero size set by set_size, and a non-zero anchor rectangle set by
-// set_anchor_rect. Passing an incomplete positioner object when
-// positioning a surface raises an error.
+// NewPositioner creates a new xdg_positioner proxy registered in the Context
 func NewPositioner(ctx *wl.Context) *Positioner {
-	Positioner := &Positioner{}
-	ctx.Register(Positioner)
-	return Positioner
+	ret := new(Positioner)
+	ctx.Register(ret)
+	return ret
 }
 
-// Destroy: destroy the positioner object
-//
-// Notify the compositor that the positioner will no longer be used.
+// Destroy: destroy the xdg_positioner object
 //
-func (i *Positioner) Destroy() error {
-	err := i.Context().SendRequest(i, 0)
-	i.Unregister()
+// Notify the compositor that the xdg_positioner will no longer be used.
+func (p *Positioner) Destroy() error {
+	err := p.Context().MarshalRequest(p, 0, nil)
+	p.Unregister()
 	return err
 }
 
@@ -273,37 +323,43 @@ func (i *Positioner) Destroy() error {
 //
 // Set the size of the surface that is to be positioned with the positioner
 // object. The size is in surface-local coordinates and corresponds to the
-// window geometry. See surface.set_window_geometry.
+// window geometry. See xdg_surface.set_window_geometry.
 //
 // If a zero or negative size is set the invalid_input error is raised.
 //
-//  width: width of positioned rectangle
-//  height: height of positioned rectangle
-func (i *Positioner) SetSize(width, height int32) error {
-	err := i.Context().SendRequest(i, 1, width, height)
-	return err
+//	width: width of positioned rectangle
+//	height: height of positioned rectangle
+func (p *Positioner) SetSize(width int32, height int32) error {
+	return p.Context().MarshalRequest(p, 1, func(r *wl.Request) {
+		r.PutInt32(width)
+		r.PutInt32(height)
+	})
 }
 
 // SetAnchorRect: set the anchor rectangle within the parent surface
 //
 // Specify the anchor rectangle within the parent surface that the child
 // surface will be placed relative to. The rectangle is relative to the
-// window geometry as defined by surface.set_window_geometry of the
+// window geometry as defined by xdg_surface.set_window_geometry of the
 // parent surface.
 //
-// When the positioner object is used to position a child surface, the
+// When the xdg_positioner object is used to position a child surface, the
 // anchor rectangle may not extend outside the window geometry of the
 // positioned child's parent surface.
 //
 // If a negative size is set the invalid_input error is raised.
 //
-//  x: x position of anchor rectangle
-//  y: y position of anchor rectangle
-//  width: width of anchor rectangle
-//  height: height of anchor rectangle
-func (i *Positioner) SetAnchorRect(x, y, width, height int32) error {
-	err := i.Context().SendRequest(i, 2, x, y, width, height)
-	return err
+//	x: x position of anchor rectangle
+//	y: y position of anchor rectangle
+//	width: width of anchor rectangle
+//	height: height of anchor rectangle
+func (p *Positioner) SetAnchorRect(x int32, y int32, width int32, height int32) error {
+	return p.Context().MarshalRequest(p, 2, func(r *wl.Request) {
+		r.PutInt32(x)
+		r.PutInt32(y)
+		r.PutInt32(width)
+		r.PutInt32(height)
+	})
 }
 
 // SetAnchor: set anchor rectangle anchor
@@ -315,10 +371,11 @@ func (i *Positioner) SetAnchorRect(x, y, width, height int32) error {
 // otherwise, the derived anchor point will be centered on the specified
 // edge, or in the center of the anchor rectangle if no edge is specified.
 //
-//  anchor: anchor
-func (i *Positioner) SetAnchor(anchor uint32) error {
-	err := i.Context().SendRequest(i, 3, anchor)
-	return err
+//	anchor: anchor
+func (p *Positioner) SetAnchor(anchor uint32) error {
+	return p.Context().MarshalRequest(p, 3, func(r *wl.Request) {
+		r.PutUint32(anchor)
+	})
 }
 
 // SetGravity: set child surface gravity
@@ -330,10 +387,11 @@ func (i *Positioner) SetAnchor(anchor uint32) error {
 // surface will be centered over the anchor point on any axis that had no
 // gravity specified.
 //
-//  gravity: gravity direction
-func (i *Positioner) SetGravity(gravity uint32) error {
-	err := i.Context().SendRequest(i, 4, gravity)
-	return err
+//	gravity: gravity direction
+func (p *Positioner) SetGravity(gravity uint32) error {
+	return p.Context().MarshalRequest(p, 4, func(r *wl.Request) {
+		r.PutUint32(gravity)
+	})
 }
 
 // SetConstraintAdjustment: set the adjustment to be done when constrained
@@ -352,10 +410,11 @@ func (i *Positioner) SetGravity(gravity uint32) error {
 //
 // The default adjustment is none.
 //
-//  constraintAdjustment: bit mask of constraint adjustments
-func (i *Positioner) SetConstraintAdjustment(constraintAdjustment uint32) error {
-	err := i.Context().SendRequest(i, 5, constraintAdjustment)
-	return err
+//	constraintAdjustment: bit mask of constraint adjustments
+func (p *Positioner) SetConstraintAdjustment(constraintAdjustment uint32) error {
+	return p.Context().MarshalRequest(p, 5, func(r *wl.Request) {
+		r.PutUint32(constraintAdjustment)
+	})
 }
 
 // SetOffset: set surface position offset
@@ -372,11 +431,13 @@ func (i *Positioner) SetConstraintAdjustment(constraintAdjustment uint32) error
 // element, while aligning the user interface element of the parent surface
 // with some user interface element placed somewhere in the popup surface.
 //
-//  x: surface position x offset
-//  y: surface position y offset
-func (i *Positioner) SetOffset(x, y int32) error {
-	err := i.Context().SendRequest(i, 6, x, y)
-	return err
+//	x: surface position x offset
+//	y: surface position y offset
+func (p *Positioner) SetOffset(x int32, y int32) error {
+	return p.Context().MarshalRequest(p, 6, func(r *wl.Request) {
+		r.PutInt32(x)
+		r.PutInt32(y)
+	})
 }
 
 // SetReactive: continuously reconstrain the surface
@@ -385,12 +446,13 @@ func (i *Positioner) SetOffset(x, y int32) error {
 // for constraining changed, e.g. the parent window moved.
 //
 // If the conditions changed and the popup was reconstrained, an
-// popup.configure event is sent with updated geometry, followed by an
-// surface.configure event.
-//
-func (i *Positioner) SetReactive() error {
-	err := i.Context().SendRequest(i, 7)
-	return err
+// xdg_popup.configure event is sent with updated geometry, followed by an
+// xdg_surface.configure event.
+func (p *Positioner) SetReactive() error {
+	if err := wl.CheckRequestVersion(p, 7); err != nil {
+		return err
+	}
+	return p.Context().MarshalRequest(p, 7, nil)
 }
 
 // SetParentSize:
@@ -403,24 +465,33 @@ func (i *Positioner) SetReactive() error {
 //
 // The arguments are given in the surface-local coordinate space.
 //
-//  parentWidth: future window geometry width of parent
-//  parentHeight: future window geometry height of parent
-func (i *Positioner) SetParentSize(parentWidth, parentHeight int32) error {
-	err := i.Context().SendRequest(i, 8, parentWidth, parentHeight)
-	return err
+//	parentWidth: future window geometry width of parent
+//	parentHeight: future window geometry height of parent
+func (p *Positioner) SetParentSize(parentWidth int32, parentHeight int32) error {
+	if err := wl.CheckRequestVersion(p, 8); err != nil {
+		return err
+	}
+	return p.Context().MarshalRequest(p, 8, func(r *wl.Request) {
+		r.PutInt32(parentWidth)
+		r.PutInt32(parentHeight)
+	})
 }
 
 // SetParentConfigure: set parent configure this is a response to
 //
-// Set the serial of an surface.configure event this positioner will be
+// Set the serial of an xdg_surface.configure event this positioner will be
 // used in response to. The compositor may use this information together
 // with set_parent_size to determine what future state the popup should be
 // constrained using.
 //
-//  serial: serial of parent configure event
-func (i *Positioner) SetParentConfigure(serial uint32) error {
-	err := i.Context().SendRequest(i, 9, serial)
-	return err
+//	serial: serial of parent configure event
+func (p *Positioner) SetParentConfigure(serial uint32) error {
+	if err := wl.CheckRequestVersion(p, 9); err != nil {
+		return err
+	}
+	return p.Context().MarshalRequest(p, 9, func(r *wl.Request) {
+		r.PutUint32(serial)
+	})
 }
 
 // PositionerError:
@@ -478,6 +549,152 @@ const (
 	PositionerConstraintAdjustmentResizeY = 32
 )
 
+const (
+	PositionerDestroySinceVersion                 = 1
+	PositionerSetSizeSinceVersion                 = 1
+	PositionerSetAnchorRectSinceVersion           = 1
+	PositionerSetAnchorSinceVersion               = 1
+	PositionerSetGravitySinceVersion              = 1
+	PositionerSetConstraintAdjustmentSinceVersion = 1
+	PositionerSetOffsetSinceVersion               = 1
+	PositionerSetReactiveSinceVersion             = 3
+	PositionerSetParentSizeSinceVersion           = 3
+	PositionerSetParentConfigureSinceVersion      = 3
+)
+
+// PositionerInterface describes the xdg_positioner interface
+var PositionerInterface = &wl.Interface{
+	Name:    "xdg_positioner",
+	Version: 6,
+	Requests: []wl.Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "set_size",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "width", Type: wl.ArgInt},
+				{Name: "height", Type: wl.ArgInt},
+			},
+		},
+		{
+			Name:  "set_anchor_rect",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "x", Type: wl.ArgInt},
+				{Name: "y", Type: wl.ArgInt},
+				{Name: "width", Type: wl.ArgInt},
+				{Name: "height", Type: wl.ArgInt},
+			},
+		},
+		{
+			Name:  "set_anchor",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "anchor", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "set_gravity",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "gravity", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "set_constraint_adjustment",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "constraint_adjustment", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "set_offset",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "x", Type: wl.ArgInt},
+				{Name: "y", Type: wl.ArgInt},
+			},
+		},
+		{
+			Name:  "set_reactive",
+			Since: 3,
+		},
+		{
+			Name:  "set_parent_size",
+			Since: 3,
+			Args: []wl.Arg{
+				{Name: "parent_width", Type: wl.ArgInt},
+				{Name: "parent_height", Type: wl.ArgInt},
+			},
+		},
+		{
+			Name:  "set_parent_configure",
+			Since: 3,
+			Args: []wl.Arg{
+				{Name: "serial", Type: wl.ArgUint},
+			},
+		},
+	},
+	Enums: []wl.Enum{
+		{
+			Name: "error",
+			Entries: []wl.EnumEntry{
+				{Name: "invalid_input", Value: 0},
+			},
+		},
+		{
+			Name: "anchor",
+			Entries: []wl.EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "top", Value: 1},
+				{Name: "bottom", Value: 2},
+				{Name: "left", Value: 3},
+				{Name: "right", Value: 4},
+				{Name: "top_left", Value: 5},
+				{Name: "bottom_left", Value: 6},
+				{Name: "top_right", Value: 7},
+				{Name: "bottom_right", Value: 8},
+			},
+		},
+		{
+			Name: "gravity",
+			Entries: []wl.EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "top", Value: 1},
+				{Name: "bottom", Value: 2},
+				{Name: "left", Value: 3},
+				{Name: "right", Value: 4},
+				{Name: "top_left", Value: 5},
+				{Name: "bottom_left", Value: 6},
+				{Name: "top_right", Value: 7},
+				{Name: "bottom_right", Value: 8},
+			},
+		},
+		{
+			Name:     "constraint_adjustment",
+			Bitfield: true,
+			Entries: []wl.EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "slide_x", Value: 1},
+				{Name: "slide_y", Value: 2},
+				{Name: "flip_x", Value: 4},
+				{Name: "flip_y", Value: 8},
+				{Name: "resize_x", Value: 16},
+				{Name: "resize_y", Value: 32},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the xdg_positioner interface
+func (p *Positioner) Interface() *wl.Interface {
+	return PositionerInterface
+}
+
 // Surface: desktop user interface surface base interface
 //
 // An interface that may be implemented by a wl_surface, for
@@ -486,38 +703,38 @@ const (
 // It provides a base set of functionality required to construct user
 // interface elements requiring management by the compositor, such as
 // toplevel windows, menus, etc. The types of functionality are split into
-// surface roles.
+// xdg_surface roles.
 //
-// Creating an surface does not set the role for a wl_surface. In order
-// to map an surface, the client must create a role-specific object
+// Creating an xdg_surface does not set the role for a wl_surface. In order
+// to map an xdg_surface, the client must create a role-specific object
 // using, e.g., get_toplevel, get_popup. The wl_surface for any given
-// surface can have at most one role, and may not be assigned any role
-// not based on surface.
+// xdg_surface can have at most one role, and may not be assigned any role
+// not based on xdg_surface.
 //
 // A role must be assigned before any other requests are made to the
-// surface object.
+// xdg_surface object.
 //
 // The client must call wl_surface.commit on the corresponding wl_surface
-// for the surface state to take effect.
+// for the xdg_surface state to take effect.
 //
-// Creating an surface from a wl_surface which has a buffer attached or
+// Creating an xdg_surface from a wl_surface which has a buffer attached or
 // committed is a client error, and any attempts by a client to attach or
-// manipulate a buffer prior to the first surface.configure call must
+// manipulate a buffer prior to the first xdg_surface.configure call must
 // also be treated as errors.
 //
 // After creating a role-specific object and setting it up, the client must
 // perform an initial commit without any buffer attached. The compositor
-// will reply with an surface.configure event. The client must
+// will reply with an xdg_surface.configure event. The client must
 // acknowledge it and is then allowed to attach a buffer to map the surface.
 //
-// Mapping an surface-based role surface is defined as making it
+// Mapping an xdg_surface-based role surface is defined as making it
 // possible for the surface to be shown by the compositor. Note that
 // a mapped surface is not guaranteed to be visible once it is mapped.
 //
-// For an surface to be mapped by the compositor, the following
+// For an xdg_surface to be mapped by the compositor, the following
 // conditions must be met:
-// (1) the client has assigned an surface-based role to the surface
-// (2) the client has set and committed the surface state and the
+// (1) the client has assigned an xdg_surface-based role to the surface
+// (2) the client has set and committed the xdg_surface state and the
 // role-dependent state to the surface
 // (3) the client has committed a buffer to the surface
 //
@@ -530,98 +747,66 @@ type Surface struct {
 	configureHandlers []SurfaceConfigureHandler
 }
 
-// NewSurface: desktop user interface surface base interface
-//
-// An interface that may be implemented by a wl_surface, for
-// implementations that provide a desktop-style user interface.
-//
-// It provides a base set of functionality required to construct user
-// interface elements requiring management by the compositor, such as
-// toplevel windows, menus, etc. The types of functionality are split into
-// surface roles.
-//
-// Creating an surface does not set the role for a wl_surface. In order
-// to map an surface, the client must create a role-specific object
-// using, e.g., get_toplevel, get_popup. The wl_surface for any given
-// surface can have at most one role, and may not be assigned any role
-// not based on surface.
-//
-// A role must be assigned before any other requests are made to the
-// surface object.
-//
-// The client must call wl_surface.commit on the corresponding wl_surface
-// for the surface state to take effect.
-//
-// Creating an surface from a wl_surface which has a buffer attached or
-// committed is a client error, and any attempts by a client to attach or
-// manipulate a buffer prior to the first surface.configure call must
-// also be treated as errors.
-//
-// After creating a role-specific object and setting it up, the client must
-// perform an initial commit without any buffer attached. The compositor
-// will reply with an surface.configure event. The client must
-// acknowledge it and is then allowed to attach a buffer to map the surface.
-//
-// Mapping an surface-based role surface is defined as making it
-// possible for the surface to be shown by the compositor. Note that
-// a mapped surface is not guaranteed to be visible once it is mapped.
-//
-// For an surface to be mapped by the compositor, the following
-// conditions must be met:
-// (1) the client has assigned an surface-based role to the surface
-// (2) the client has set and committed the surface state and the
-// role-dependent state to the surface
-// (3) the client has committed a buffer to the surface
-//
-// A newly-unmapped surface is considered to have met condition (1) out
-// of the 3 required conditions for mapping a surface if its role surface
-// has not been destroyed.
+// NewSurface creates a new xdg_surface proxy registered in the Context
 func NewSurface(ctx *wl.Context) *Surface {
-	Surface := &Surface{}
-	ctx.Register(Surface)
-	return Surface
+	ret := new(Surface)
+	ctx.Register(ret)
+	return ret
 }
 
-// Destroy: destroy the surface
+// Destroy: destroy the xdg_surface
 //
-// Destroy the surface object. An surface must only be destroyed
+// Destroy the xdg_surface object. An xdg_surface must only be destroyed
 // after its role object has been destroyed.
-//
-func (s *Surface) Destroy() error {
-	err := s.Context().SendRequest(s, 0)
-	s.Unregister()
+func (p *Surface) Destroy() error {
+	err := p.Context().MarshalRequest(p, 0, nil)
+	p.Unregister()
 	return err
 }
 
-// GetToplevel: assign the toplevel surface role
-//
-// This creates an toplevel object for the given surface and gives
-// the associated wl_surface the toplevel role.
+// GetToplevel: assign the xdg_toplevel surface role
 //
-// See the documentation of toplevel for more details about what an
-// toplevel is and how it is used.
+// This creates an xdg_toplevel object for the given xdg_surface and gives
+// the associated wl_surface the xdg_toplevel role.
 //
-func (s *Surface) GetToplevel() (*Toplevel, error) {
-	id := NewToplevel(s.Context())
-	err := s.Context().SendRequest(s, 1, id)
-	return id, err
+// See the documentation of xdg_toplevel for more details about what an
+// xdg_toplevel is and how it is used.
+func (p *Surface) GetToplevel() (*Toplevel, error) {
+	ret := NewToplevel(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 1, func(r *wl.Request) {
+		r.PutNewId(ret)
+	})
 }
 
-// GetPopup: assign the popup surface role
+// GetPopup: assign the xdg_popup surface role
 //
-// This creates an popup object for the given surface and gives
-// the associated wl_surface the popup role.
+// This creates an xdg_popup object for the given xdg_surface and gives
+// the associated wl_surface the xdg_popup role.
 //
 // If null is passed as a parent, a parent surface must be specified using
 // some other protocol, before committing the initial state.
 //
-// See the documentation of popup for more details about what an
-// popup is and how it is used.
-//
-func (s *Surface) GetPopup(parent *Surface, positioner *Positioner) (*Popup, error) {
-	id := NewPopup(s.Context())
-	err := s.Context().SendRequest(s, 2, id, parent, positioner)
-	return id, err
+// See the documentation of xdg_popup for more details about what an
+// xdg_popup is and how it is used.
+func (p *Surface) GetPopup(parent *Surface, positioner *Positioner) (*Popup, error) {
+	ret := NewPopup(p.Context())
+	ret.SetQueue(p.Queue())
+	ret.SetVersion(p.Version())
+	return ret, p.Context().MarshalRequest(p, 2, func(r *wl.Request) {
+		r.PutNewId(ret)
+		if parent != nil {
+			r.PutObject(parent.Id())
+		} else {
+			r.PutObject(0)
+		}
+		if positioner != nil {
+			r.PutObject(positioner.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
 }
 
 // SetWindowGeometry: set the new window geometry
@@ -648,17 +833,20 @@ func (s *Surface) GetPopup(parent *Surface, positioner *Positioner) (*Popup, err
 // commit. This unset is meant for extremely simple clients.
 //
 // The arguments are given in the surface-local coordinate space of
-// the wl_surface associated with this surface.
+// the wl_surface associated with this xdg_surface.
 //
 // The width and height must be greater than zero. Setting an invalid size
 // will raise an error. When applied, the effective window geometry will be
 // the set window geometry clamped to the bounding rectangle of the
-// combined geometry of the surface of the surface and the associated
+// combined geometry of the surface of the xdg_surface and the associated
 // subsurfaces.
-//
-func (s *Surface) SetWindowGeometry(x, y, width, height int32) error {
-	err := s.Context().SendRequest(s, 3, x, y, width, height)
-	return err
+func (p *Surface) SetWindowGeometry(x int32, y int32, width int32, height int32) error {
+	return p.Context().MarshalRequest(p, 3, func(r *wl.Request) {
+		r.PutInt32(x)
+		r.PutInt32(y)
+		r.PutInt32(width)
+		r.PutInt32(height)
+	})
 }
 
 // AckConfigure: ack a configure event
@@ -683,10 +871,11 @@ func (s *Surface) SetWindowGeometry(x, y, width, height int32) error {
 // only the last request sent before a commit indicates which configure
 // event the client really is responding to.
 //
-//  serial: the serial from the configure event
-func (s *Surface) AckConfigure(serial uint32) error {
-	err := s.Context().SendRequest(s, 4, serial)
-	return err
+//	serial: the serial from the configure event
+func (p *Surface) AckConfigure(serial uint32) error {
+	return p.Context().MarshalRequest(p, 4, func(r *wl.Request) {
+		r.PutUint32(serial)
+	})
 }
 
 // SurfaceError:
@@ -694,19 +883,25 @@ const (
 	SurfaceErrorNotConstructed     = 1
 	SurfaceErrorAlreadyConstructed = 2
 	SurfaceErrorUnconfiguredBuffer = 3
+	// SurfaceErrorInvalidSerial: Invalid serial number when acking a configure event
+	SurfaceErrorInvalidSerial = 4
+	// SurfaceErrorInvalidSize: Width or height was zero or negative
+	SurfaceErrorInvalidSize = 5
+	// SurfaceErrorDefunctRoleObject: Surface was destroyed before its role object
+	SurfaceErrorDefunctRoleObject = 6
 )
 
 // SurfaceConfigureEvent: suggest a surface change
 //
 // The configure event marks the end of a configure sequence. A configure
 // sequence is a set of one or more events configuring the state of the
-// surface, including the final surface.configure event.
+// xdg_surface, including the final xdg_surface.configure event.
 //
-// Where applicable, surface surface roles will during a configure
+// Where applicable, xdg_surface surface roles will during a configure
 // sequence extend this event as a latched state sent as events before the
-// surface.configure event. Such events should be considered to make up
+// xdg_surface.configure event. Such events should be considered to make up
 // a set of atomically applied configuration states, where the
-// surface.configure commits the accumulated state.
+// xdg_surface.configure commits the accumulated state.
 //
 // Clients should arrange their surface for the new states, and then send
 // an ack_configure request with the serial sent in this configure event at
@@ -718,118 +913,180 @@ type SurfaceConfigureEvent struct {
 	Serial uint32
 }
 
+// SurfaceConfigureHandler is implemented by the receivers of SurfaceConfigureEvent
 type SurfaceConfigureHandler interface {
 	HandleSurfaceConfigure(SurfaceConfigureEvent)
 }
 
-// AddConfigureHandler: adds handler for SurfaceConfigureEvent
-func (s *Surface) AddConfigureHandler(h SurfaceConfigureHandler) {
+// AddConfigureHandler adds a handler for SurfaceConfigureEvent
+func (p *Surface) AddConfigureHandler(h SurfaceConfigureHandler) {
 	if h == nil {
 		return
 	}
 
-	s.mu.Lock()
-	s.configureHandlers = append(s.configureHandlers, h)
-	s.mu.Unlock()
+	p.mu.Lock()
+	p.configureHandlers = append(p.configureHandlers, h)
+	p.mu.Unlock()
 }
 
-func (s *Surface) RemoveConfigureHandler(h SurfaceConfigureHandler) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// RemoveConfigureHandler removes a handler previously added by AddConfigureHandler
+func (p *Surface) RemoveConfigureHandler(h SurfaceConfigureHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	for j, e := range s.configureHandlers {
+	for i, e := range p.configureHandlers {
 		if e == h {
-			s.configureHandlers = append(s.configureHandlers[:j], s.configureHandlers[j+1:]...)
+			p.configureHandlers = append(p.configureHandlers[:i:i], p.configureHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-func (s *Surface) Dispatch(event *wl.Event) {
+// Dispatch decodes an event received on the xdg_surface and runs its handlers
+func (p *Surface) Dispatch(event *wl.Event) {
 	switch event.Opcode {
 	case 0:
-		s.mu.RLock()
-		if len(s.configureHandlers) == 0 {
-			s.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.configureHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
 			break
 		}
-		s.mu.RUnlock()
-
-		e := SurfaceConfigureEvent{
-			Serial: event.Uint32(),
+		ev := SurfaceConfigureEvent{}
+		ev.Serial = event.Uint32()
+		if event.Err() != nil {
+			break
 		}
-
-		s.mu.RLock()
-		for _, h := range s.configureHandlers {
-			s.mu.RUnlock()
-
-			h.HandleSurfaceConfigure(e)
-
-			s.mu.RLock()
+		for _, h := range handlers {
+			h.HandleSurfaceConfigure(ev)
 		}
-		s.mu.RUnlock()
 	}
 }
 
+const (
+	SurfaceConfigureSinceVersion         = 1
+	SurfaceDestroySinceVersion           = 1
+	SurfaceGetToplevelSinceVersion       = 1
+	SurfaceGetPopupSinceVersion          = 1
+	SurfaceSetWindowGeometrySinceVersion = 1
+	SurfaceAckConfigureSinceVersion      = 1
+)
+
+// SurfaceInterface describes the xdg_surface interface
+var SurfaceInterface = &wl.Interface{
+	Name:    "xdg_surface",
+	Version: 6,
+	Requests: []wl.Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "get_toplevel",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "id", Type: wl.ArgNewId, Interface: "xdg_toplevel"},
+			},
+		},
+		{
+			Name:  "get_popup",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "id", Type: wl.ArgNewId, Interface: "xdg_popup"},
+				{Name: "parent", Type: wl.ArgObject, Interface: "xdg_surface", Nullable: true},
+				{Name: "positioner", Type: wl.ArgObject, Interface: "xdg_positioner"},
+			},
+		},
+		{
+			Name:  "set_window_geometry",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "x", Type: wl.ArgInt},
+				{Name: "y", Type: wl.ArgInt},
+				{Name: "width", Type: wl.ArgInt},
+				{Name: "height", Type: wl.ArgInt},
+			},
+		},
+		{
+			Name:  "ack_configure",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "serial", Type: wl.ArgUint},
+			},
+		},
+	},
+	Events: []wl.Message{
+		{
+			Name:  "configure",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "serial", Type: wl.ArgUint},
+			},
+		},
+	},
+	Enums: []wl.Enum{
+		{
+			Name: "error",
+			Entries: []wl.EnumEntry{
+				{Name: "not_constructed", Value: 1},
+				{Name: "already_constructed", Value: 2},
+				{Name: "unconfigured_buffer", Value: 3},
+				{Name: "invalid_serial", Value: 4},
+				{Name: "invalid_size", Value: 5},
+				{Name: "defunct_role_object", Value: 6},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the xdg_surface interface
+func (p *Surface) Interface() *wl.Interface {
+	return SurfaceInterface
+}
+
 // Toplevel: toplevel surface
 //
-// This interface defines an surface role which allows a surface to,
+// This interface defines an xdg_surface role which allows a surface to,
 // among other things, set window-like properties such as maximize,
 // fullscreen, and minimize, set application-specific metadata like title and
 // id, and well as trigger user interactive operations such as interactive
 // resize and move.
 //
-// Unmapping an toplevel means that the surface cannot be shown
+// Unmapping an xdg_toplevel means that the surface cannot be shown
 // by the compositor until it is explicitly mapped again.
 // All active operations (e.g., move, resize) are canceled and all
 // attributes (e.g. title, state, stacking, ...) are discarded for
-// an toplevel surface when it is unmapped. The toplevel returns to
-// the state it had right after surface.get_toplevel. The client
+// an xdg_toplevel surface when it is unmapped. The xdg_toplevel returns to
+// the state it had right after xdg_surface.get_toplevel. The client
 // can re-map the toplevel by perfoming a commit without any buffer
 // attached, waiting for a configure event and handling it as usual (see
-// surface description).
+// xdg_surface description).
 //
 // Attaching a null buffer to a toplevel unmaps the surface.
 type Toplevel struct {
 	wl.BaseProxy
-	mu                sync.RWMutex
-	configureHandlers []ToplevelConfigureHandler
-	closeHandlers     []ToplevelCloseHandler
+	mu                      sync.RWMutex
+	configureHandlers       []ToplevelConfigureHandler
+	closeHandlers           []ToplevelCloseHandler
+	configureBoundsHandlers []ToplevelConfigureBoundsHandler
+	wmCapabilitiesHandlers  []ToplevelWmCapabilitiesHandler
 }
 
-// NewToplevel: toplevel surface
-//
-// This interface defines an surface role which allows a surface to,
-// among other things, set window-like properties such as maximize,
-// fullscreen, and minimize, set application-specific metadata like title and
-// id, and well as trigger user interactive operations such as interactive
-// resize and move.
-//
-// Unmapping an toplevel means that the surface cannot be shown
-// by the compositor until it is explicitly mapped again.
-// All active operations (e.g., move, resize) are canceled and all
-// attributes (e.g. title, state, stacking, ...) are discarded for
-// an toplevel surface when it is unmapped. The toplevel returns to
-// the state it had right after surface.get_toplevel. The client
-// can re-map the toplevel by perfoming a commit without any buffer
-// attached, waiting for a configure event and handling it as usual (see
-// surface description).
-//
-// Attaching a null buffer to a toplevel unmaps the surface.
+// NewToplevel creates a new xdg_toplevel proxy registered in the Context
 func NewToplevel(ctx *wl.Context) *Toplevel {
-	Toplevel := &Toplevel{}
-	ctx.Register(Toplevel)
-	return Toplevel
+	ret := new(Toplevel)
+	ctx.Register(ret)
+	return ret
 }
 
-// Destroy: destroy the toplevel
+// Destroy: destroy the xdg_toplevel
 //
 // This request destroys the role surface and unmaps the surface;
 // see "Unmapping" behavior in interface section for details.
-//
-func (i *Toplevel) Destroy() error {
-	err := i.Context().SendRequest(i, 0)
-	i.Unregister()
+func (p *Toplevel) Destroy() error {
+	err := p.Context().MarshalRequest(p, 0, nil)
+	p.Unregister()
 	return err
 }
 
@@ -851,10 +1108,14 @@ func (i *Toplevel) Destroy() error {
 // parent of this surface. If no parent exists for the now-unmapped
 // parent then the children are managed as though they have no
 // parent surface.
-//
-func (i *Toplevel) SetParent(parent *Toplevel) error {
-	err := i.Context().SendRequest(i, 1, parent)
-	return err
+func (p *Toplevel) SetParent(parent *Toplevel) error {
+	return p.Context().MarshalRequest(p, 1, func(r *wl.Request) {
+		if parent != nil {
+			r.PutObject(parent.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
 }
 
 // SetTitle: set surface title
@@ -866,13 +1127,13 @@ func (i *Toplevel) SetParent(parent *Toplevel) error {
 // compositor.
 //
 // The string must be encoded in UTF-8.
-//
-func (i *Toplevel) SetTitle(title string) error {
-	err := i.Context().SendRequest(i, 2, title)
-	return err
+func (p *Toplevel) SetTitle(title string) error {
+	return p.Context().MarshalRequest(p, 2, func(r *wl.Request) {
+		r.PutString(title)
+	})
 }
 
-// SetAppID: set application ID
+// SetAppId: set application ID
 //
 // Set an application identifier for the surface.
 //
@@ -890,17 +1151,17 @@ func (i *Toplevel) SetTitle(title string) error {
 // "org.freedesktop.FooViewer.desktop".
 //
 // Like other properties, a set_app_id request can be sent after the
-// toplevel has been mapped to update the property.
+// xdg_toplevel has been mapped to update the property.
 //
 // See the desktop-entry specification [0] for more details on
 // application identifiers and how they relate to well-known D-Bus
 // names and .desktop files.
 //
 // [0] http://standards.freedesktop.org/desktop-entry-spec/
-//
-func (i *Toplevel) SetAppID(appID string) error {
-	err := i.Context().SendRequest(i, 3, appID)
-	return err
+func (p *Toplevel) SetAppId(appId string) error {
+	return p.Context().MarshalRequest(p, 3, func(r *wl.Request) {
+		r.PutString(appId)
+	})
 }
 
 // ShowWindowMenu: show the window menu
@@ -917,13 +1178,21 @@ func (i *Toplevel) SetAppID(appID string) error {
 // This request must be used in response to some sort of user action
 // like a button press, key press, or touch down event.
 //
-//  seat: the wl_seat of the user event
-//  serial: the serial of the user event
-//  x: the x position to pop up the window menu at
-//  y: the y position to pop up the window menu at
-func (i *Toplevel) ShowWindowMenu(seat *wl.Seat, serial uint32, x, y int32) error {
-	err := i.Context().SendRequest(i, 4, seat, serial, x, y)
-	return err
+//	seat: the wl_seat of the user event
+//	serial: the serial of the user event
+//	x: the x position to pop up the window menu at
+//	y: the y position to pop up the window menu at
+func (p *Toplevel) ShowWindowMenu(seat *wl.Seat, serial uint32, x int32, y int32) error {
+	return p.Context().MarshalRequest(p, 4, func(r *wl.Request) {
+		if seat != nil {
+			r.PutObject(seat.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutUint32(serial)
+		r.PutInt32(x)
+		r.PutInt32(y)
+	})
 }
 
 // Move: start an interactive move
@@ -945,11 +1214,17 @@ func (i *Toplevel) ShowWindowMenu(seat *wl.Seat, serial uint32, x, y int32) erro
 // updating a pointer cursor, during the move. There is no guarantee
 // that the device focus will return when the move is completed.
 //
-//  seat: the wl_seat of the user event
-//  serial: the serial of the user event
-func (i *Toplevel) Move(seat *wl.Seat, serial uint32) error {
-	err := i.Context().SendRequest(i, 5, seat, serial)
-	return err
+//	seat: the wl_seat of the user event
+//	serial: the serial of the user event
+func (p *Toplevel) Move(seat *wl.Seat, serial uint32) error {
+	return p.Context().MarshalRequest(p, 5, func(r *wl.Request) {
+		if seat != nil {
+			r.PutObject(seat.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutUint32(serial)
+	})
 }
 
 // Resize: start an interactive resize
@@ -985,12 +1260,19 @@ func (i *Toplevel) Move(seat *wl.Seat, serial uint32) error {
 // use this information to adapt its behavior, e.g. choose an
 // appropriate cursor image.
 //
-//  seat: the wl_seat of the user event
-//  serial: the serial of the user event
-//  edges: which edge or corner is being dragged
-func (i *Toplevel) Resize(seat *wl.Seat, serial, edges uint32) error {
-	err := i.Context().SendRequest(i, 6, seat, serial, edges)
-	return err
+//	seat: the wl_seat of the user event
+//	serial: the serial of the user event
+//	edges: which edge or corner is being dragged
+func (p *Toplevel) Resize(seat *wl.Seat, serial uint32, edges uint32) error {
+	return p.Context().MarshalRequest(p, 6, func(r *wl.Request) {
+		if seat != nil {
+			r.PutObject(seat.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutUint32(serial)
+		r.PutUint32(edges)
+	})
 }
 
 // SetMaxSize: set the maximum size
@@ -1001,7 +1283,7 @@ func (i *Toplevel) Resize(seat *wl.Seat, serial, edges uint32) error {
 // not try to configure the window beyond this size.
 //
 // The width and height arguments are in window geometry coordinates.
-// See surface.set_window_geometry.
+// See xdg_surface.set_window_geometry.
 //
 // Values set in this way are double-buffered. They will get applied
 // on the next commit.
@@ -1029,10 +1311,11 @@ func (i *Toplevel) Resize(seat *wl.Seat, serial, edges uint32) error {
 // The width and height must be greater than or equal to zero. Using
 // strictly negative values for width and height will result in a
 // protocol error.
-//
-func (i *Toplevel) SetMaxSize(width, height int32) error {
-	err := i.Context().SendRequest(i, 7, width, height)
-	return err
+func (p *Toplevel) SetMaxSize(width int32, height int32) error {
+	return p.Context().MarshalRequest(p, 7, func(r *wl.Request) {
+		r.PutInt32(width)
+		r.PutInt32(height)
+	})
 }
 
 // SetMinSize: set the minimum size
@@ -1043,7 +1326,7 @@ func (i *Toplevel) SetMaxSize(width, height int32) error {
 // not try to configure the window below this size.
 //
 // The width and height arguments are in window geometry coordinates.
-// See surface.set_window_geometry.
+// See xdg_surface.set_window_geometry.
 //
 // Values set in this way are double-buffered. They will get applied
 // on the next commit.
@@ -1071,10 +1354,11 @@ func (i *Toplevel) SetMaxSize(width, height int32) error {
 // The width and height must be greater than or equal to zero. Using
 // strictly negative values for width and height will result in a
 // protocol error.
-//
-func (i *Toplevel) SetMinSize(width, height int32) error {
-	err := i.Context().SendRequest(i, 8, width, height)
-	return err
+func (p *Toplevel) SetMinSize(width int32, height int32) error {
+	return p.Context().MarshalRequest(p, 8, func(r *wl.Request) {
+		r.PutInt32(width)
+		r.PutInt32(height)
+	})
 }
 
 // SetMaximized: maximize the window
@@ -1098,10 +1382,8 @@ func (i *Toplevel) SetMinSize(width, height int32) error {
 // If the surface is in a fullscreen state, this request has no direct
 // effect. It may alter the state the surface is returned to when
 // unmaximized unless overridden by the compositor.
-//
-func (i *Toplevel) SetMaximized() error {
-	err := i.Context().SendRequest(i, 9)
-	return err
+func (p *Toplevel) SetMaximized() error {
+	return p.Context().MarshalRequest(p, 9, nil)
 }
 
 // UnsetMaximized: unmaximize the window
@@ -1127,10 +1409,8 @@ func (i *Toplevel) SetMaximized() error {
 // If the surface is in a fullscreen state, this request has no direct
 // effect. It may alter the state the surface is returned to when
 // unmaximized unless overridden by the compositor.
-//
-func (i *Toplevel) UnsetMaximized() error {
-	err := i.Context().SendRequest(i, 10)
-	return err
+func (p *Toplevel) UnsetMaximized() error {
+	return p.Context().MarshalRequest(p, 10, nil)
 }
 
 // SetFullscreen: set the window as fullscreen on an output
@@ -1158,10 +1438,14 @@ func (i *Toplevel) UnsetMaximized() error {
 // sure that other screen content not part of the same surface tree (made
 // up of subsurfaces, popups or similarly coupled surfaces) are not
 // visible below the fullscreened surface.
-//
-func (i *Toplevel) SetFullscreen(output *wl.Output) error {
-	err := i.Context().SendRequest(i, 11, output)
-	return err
+func (p *Toplevel) SetFullscreen(output *wl.Output) error {
+	return p.Context().MarshalRequest(p, 11, func(r *wl.Request) {
+		if output != nil {
+			r.PutObject(output.Id())
+		} else {
+			r.PutObject(0)
+		}
+	})
 }
 
 // UnsetFullscreen: unset the window as fullscreen
@@ -1183,10 +1467,8 @@ func (i *Toplevel) SetFullscreen(output *wl.Output) error {
 //
 // The client must also acknowledge the configure when committing the new
 // content (see ack_configure).
-//
-func (i *Toplevel) UnsetFullscreen() error {
-	err := i.Context().SendRequest(i, 12)
-	return err
+func (p *Toplevel) UnsetFullscreen() error {
+	return p.Context().MarshalRequest(p, 12, nil)
 }
 
 // SetMinimized: set the window as minimized
@@ -1199,12 +1481,20 @@ func (i *Toplevel) UnsetFullscreen() error {
 // instead use the wl_surface.frame event for this, as this will
 // also work with live previews on windows in Alt-Tab, Expose or
 // similar compositor features.
-//
-func (i *Toplevel) SetMinimized() error {
-	err := i.Context().SendRequest(i, 13)
-	return err
+func (p *Toplevel) SetMinimized() error {
+	return p.Context().MarshalRequest(p, 13, nil)
 }
 
+// ToplevelError:
+const (
+	// ToplevelErrorInvalidResizeEdge: provided value is not a valid variant of the resize_edge enum
+	ToplevelErrorInvalidResizeEdge = 0
+	// ToplevelErrorInvalidParent: invalid parent toplevel
+	ToplevelErrorInvalidParent = 1
+	// ToplevelErrorInvalidSize: client provided an invalid min or max size
+	ToplevelErrorInvalidSize = 2
+)
+
 // ToplevelResizeEdge: edge values for resizing
 //
 // These values are used to indicate which edge of a surface
@@ -1243,13 +1533,26 @@ const (
 	ToplevelStateTiledRight  = 6
 	ToplevelStateTiledTop    = 7
 	ToplevelStateTiledBottom = 8
+	ToplevelStateSuspended   = 9
+)
+
+// ToplevelWmCapabilities:
+const (
+	// ToplevelWmCapabilitiesWindowMenu: show_window_menu is available
+	ToplevelWmCapabilitiesWindowMenu = 1
+	// ToplevelWmCapabilitiesMaximize: set_maximized and unset_maximized are available
+	ToplevelWmCapabilitiesMaximize = 2
+	// ToplevelWmCapabilitiesFullscreen: set_fullscreen and unset_fullscreen are available
+	ToplevelWmCapabilitiesFullscreen = 3
+	// ToplevelWmCapabilitiesMinimize: set_minimized is available
+	ToplevelWmCapabilitiesMinimize = 4
 )
 
 // ToplevelConfigureEvent: suggest a surface change
 //
 // This configure event asks the client to resize its toplevel surface or
 // to change its state. The configured state should not be applied
-// immediately. See surface.configure for details.
+// immediately. See xdg_surface.configure for details.
 //
 // The width and height arguments specify a hint to the window
 // about how its surface should be resized in window geometry
@@ -1265,35 +1568,37 @@ const (
 // drawn.
 //
 // Clients must send an ack_configure in response to this event. See
-// surface.configure and surface.ack_configure for details.
+// xdg_surface.configure and xdg_surface.ack_configure for details.
 type ToplevelConfigureEvent struct {
 	Width  int32
 	Height int32
 	States []int32
 }
 
+// ToplevelConfigureHandler is implemented by the receivers of ToplevelConfigureEvent
 type ToplevelConfigureHandler interface {
 	HandleToplevelConfigure(ToplevelConfigureEvent)
 }
 
-// AddConfigureHandler: adds handler for ToplevelConfigureEvent
-func (i *Toplevel) AddConfigureHandler(h ToplevelConfigureHandler) {
+// AddConfigureHandler adds a handler for ToplevelConfigureEvent
+func (p *Toplevel) AddConfigureHandler(h ToplevelConfigureHandler) {
 	if h == nil {
 		return
 	}
 
-	i.mu.Lock()
-	i.configureHandlers = append(i.configureHandlers, h)
-	i.mu.Unlock()
+	p.mu.Lock()
+	p.configureHandlers = append(p.configureHandlers, h)
+	p.mu.Unlock()
 }
 
-func (i *Toplevel) RemoveConfigureHandler(h ToplevelConfigureHandler) {
-	i.mu.Lock()
-	defer i.mu.Unlock()
+// RemoveConfigureHandler removes a handler previously added by AddConfigureHandler
+func (p *Toplevel) RemoveConfigureHandler(h ToplevelConfigureHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	for j, e := range i.configureHandlers {
+	for i, e := range p.configureHandlers {
 		if e == h {
-			i.configureHandlers = append(i.configureHandlers[:j], i.configureHandlers[j+1:]...)
+			p.configureHandlers = append(p.configureHandlers[:i:i], p.configureHandlers[i+1:]...)
 			break
 		}
 	}
@@ -1309,107 +1614,443 @@ func (i *Toplevel) RemoveConfigureHandler(h ToplevelConfigureHandler) {
 // This is only a request that the user intends to close the
 // window. The client may choose to ignore this request, or show
 // a dialog to ask the user to save their data, etc.
-type ToplevelCloseEvent struct{}
+type ToplevelCloseEvent struct {
+}
+
+// ToplevelCloseHandler is implemented by the receivers of ToplevelCloseEvent
 type ToplevelCloseHandler interface {
 	HandleToplevelClose(ToplevelCloseEvent)
 }
 
-// AddCloseHandler: adds handler for ToplevelCloseEvent
-func (i *Toplevel) AddCloseHandler(h ToplevelCloseHandler) {
+// AddCloseHandler adds a handler for ToplevelCloseEvent
+func (p *Toplevel) AddCloseHandler(h ToplevelCloseHandler) {
 	if h == nil {
 		return
 	}
 
-	i.mu.Lock()
-	i.closeHandlers = append(i.closeHandlers, h)
-	i.mu.Unlock()
+	p.mu.Lock()
+	p.closeHandlers = append(p.closeHandlers, h)
+	p.mu.Unlock()
 }
 
-func (i *Toplevel) RemoveCloseHandler(h ToplevelCloseHandler) {
-	i.mu.Lock()
-	defer i.mu.Unlock()
+// RemoveCloseHandler removes a handler previously added by AddCloseHandler
+func (p *Toplevel) RemoveCloseHandler(h ToplevelCloseHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	for j, e := range i.closeHandlers {
+	for i, e := range p.closeHandlers {
 		if e == h {
-			i.closeHandlers = append(i.closeHandlers[:j], i.closeHandlers[j+1:]...)
+			p.closeHandlers = append(p.closeHandlers[:i:i], p.closeHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-func (i *Toplevel) Dispatch(event *wl.Event) {
-	switch event.Opcode {
-	case 0:
-		i.mu.RLock()
-		if len(i.configureHandlers) == 0 {
-			i.mu.RUnlock()
-			break
-		}
-		i.mu.RUnlock()
+// ToplevelConfigureBoundsEvent: recommended window geometry bounds
+//
+// The configure_bounds event may be sent prior to a xdg_toplevel.configure
+// event to communicate the bounds a window geometry size is recommended
+// to constrain to.
+//
+// The passed width and height are in surface coordinate space. If width
+// and height are 0, it means bounds is unknown and equivalent to as if no
+// configure_bounds event was ever sent for this surface.
+//
+// The bounds can for example correspond to the size of a monitor excluding
+// any panels or other shell components, so that a surface isn't created in
+// a way that it cannot fit.
+//
+// The bounds may change at any point, and in such a case, a new
+// xdg_toplevel.configure_bounds will be sent, followed by
+// xdg_toplevel.configure and xdg_surface.configure.
+type ToplevelConfigureBoundsEvent struct {
+	Width  int32
+	Height int32
+}
 
-		e := ToplevelConfigureEvent{
-			Width:  event.Int32(),
-			Height: event.Int32(),
-			States: event.Array(),
-		}
+// ToplevelConfigureBoundsHandler is implemented by the receivers of ToplevelConfigureBoundsEvent
+type ToplevelConfigureBoundsHandler interface {
+	HandleToplevelConfigureBounds(ToplevelConfigureBoundsEvent)
+}
 
-		i.mu.RLock()
-		for _, h := range i.configureHandlers {
-			i.mu.RUnlock()
+// AddConfigureBoundsHandler adds a handler for ToplevelConfigureBoundsEvent
+func (p *Toplevel) AddConfigureBoundsHandler(h ToplevelConfigureBoundsHandler) {
+	if h == nil {
+		return
+	}
 
-			h.HandleToplevelConfigure(e)
+	p.mu.Lock()
+	p.configureBoundsHandlers = append(p.configureBoundsHandlers, h)
+	p.mu.Unlock()
+}
 
-			i.mu.RLock()
-		}
-		i.mu.RUnlock()
-	case 1:
-		i.mu.RLock()
-		if len(i.closeHandlers) == 0 {
-			i.mu.RUnlock()
+// RemoveConfigureBoundsHandler removes a handler previously added by AddConfigureBoundsHandler
+func (p *Toplevel) RemoveConfigureBoundsHandler(h ToplevelConfigureBoundsHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, e := range p.configureBoundsHandlers {
+		if e == h {
+			p.configureBoundsHandlers = append(p.configureBoundsHandlers[:i:i], p.configureBoundsHandlers[i+1:]...)
 			break
 		}
-		i.mu.RUnlock()
+	}
+}
 
-		e := ToplevelCloseEvent{}
+// ToplevelWmCapabilitiesEvent: compositor capabilities
+//
+// This event advertises the capabilities supported by the compositor. If
+// a capability isn't supported, clients should hide or disable the UI
+// elements that expose this functionality. For instance, if the
+// compositor doesn't advertise support for minimized toplevels, a button
+// triggering the set_minimized request should not be displayed.
+//
+// The compositor will ignore requests it doesn't support. For instance,
+// a compositor which doesn't advertise support for minimized will ignore
+// set_minimized requests.
+//
+// Compositors must send this event once before the first
+// xdg_surface.configure event. When the capabilities change, compositors
+// must send this event again and then send an xdg_surface.configure
+// event.
+//
+// The configured state should not be applied immediately. See
+// xdg_surface.configure for details.
+//
+// The capabilities are sent as an array of 32-bit unsigned integers in
+// native endianness.
+type ToplevelWmCapabilitiesEvent struct {
+	Capabilities []int32
+}
 
-		i.mu.RLock()
-		for _, h := range i.closeHandlers {
-			i.mu.RUnlock()
+// ToplevelWmCapabilitiesHandler is implemented by the receivers of ToplevelWmCapabilitiesEvent
+type ToplevelWmCapabilitiesHandler interface {
+	HandleToplevelWmCapabilities(ToplevelWmCapabilitiesEvent)
+}
+
+// AddWmCapabilitiesHandler adds a handler for ToplevelWmCapabilitiesEvent
+func (p *Toplevel) AddWmCapabilitiesHandler(h ToplevelWmCapabilitiesHandler) {
+	if h == nil {
+		return
+	}
+
+	p.mu.Lock()
+	p.wmCapabilitiesHandlers = append(p.wmCapabilitiesHandlers, h)
+	p.mu.Unlock()
+}
+
+// RemoveWmCapabilitiesHandler removes a handler previously added by AddWmCapabilitiesHandler
+func (p *Toplevel) RemoveWmCapabilitiesHandler(h ToplevelWmCapabilitiesHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-			h.HandleToplevelClose(e)
+	for i, e := range p.wmCapabilitiesHandlers {
+		if e == h {
+			p.wmCapabilitiesHandlers = append(p.wmCapabilitiesHandlers[:i:i], p.wmCapabilitiesHandlers[i+1:]...)
+			break
+		}
+	}
+}
 
-			i.mu.RLock()
+// Dispatch decodes an event received on the xdg_toplevel and runs its handlers
+func (p *Toplevel) Dispatch(event *wl.Event) {
+	switch event.Opcode {
+	case 0:
+		p.mu.RLock()
+		handlers := p.configureHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := ToplevelConfigureEvent{}
+		ev.Width = event.Int32()
+		ev.Height = event.Int32()
+		ev.States = event.Array()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleToplevelConfigure(ev)
+		}
+	case 1:
+		p.mu.RLock()
+		handlers := p.closeHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := ToplevelCloseEvent{}
+		for _, h := range handlers {
+			h.HandleToplevelClose(ev)
+		}
+	case 2:
+		p.mu.RLock()
+		handlers := p.configureBoundsHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := ToplevelConfigureBoundsEvent{}
+		ev.Width = event.Int32()
+		ev.Height = event.Int32()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleToplevelConfigureBounds(ev)
+		}
+	case 3:
+		p.mu.RLock()
+		handlers := p.wmCapabilitiesHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
+			break
+		}
+		ev := ToplevelWmCapabilitiesEvent{}
+		ev.Capabilities = event.Array()
+		if event.Err() != nil {
+			break
+		}
+		for _, h := range handlers {
+			h.HandleToplevelWmCapabilities(ev)
 		}
-		i.mu.RUnlock()
 	}
 }
 
+const (
+	ToplevelStateTiledLeftSinceVersion   = 2
+	ToplevelStateTiledRightSinceVersion  = 2
+	ToplevelStateTiledTopSinceVersion    = 2
+	ToplevelStateTiledBottomSinceVersion = 2
+	ToplevelStateSuspendedSinceVersion   = 6
+	ToplevelConfigureSinceVersion        = 1
+	ToplevelCloseSinceVersion            = 1
+	ToplevelConfigureBoundsSinceVersion  = 4
+	ToplevelWmCapabilitiesSinceVersion   = 5
+	ToplevelDestroySinceVersion          = 1
+	ToplevelSetParentSinceVersion        = 1
+	ToplevelSetTitleSinceVersion         = 1
+	ToplevelSetAppIdSinceVersion         = 1
+	ToplevelShowWindowMenuSinceVersion   = 1
+	ToplevelMoveSinceVersion             = 1
+	ToplevelResizeSinceVersion           = 1
+	ToplevelSetMaxSizeSinceVersion       = 1
+	ToplevelSetMinSizeSinceVersion       = 1
+	ToplevelSetMaximizedSinceVersion     = 1
+	ToplevelUnsetMaximizedSinceVersion   = 1
+	ToplevelSetFullscreenSinceVersion    = 1
+	ToplevelUnsetFullscreenSinceVersion  = 1
+	ToplevelSetMinimizedSinceVersion     = 1
+)
+
+// ToplevelInterface describes the xdg_toplevel interface
+var ToplevelInterface = &wl.Interface{
+	Name:    "xdg_toplevel",
+	Version: 6,
+	Requests: []wl.Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "set_parent",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "parent", Type: wl.ArgObject, Interface: "xdg_toplevel", Nullable: true},
+			},
+		},
+		{
+			Name:  "set_title",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "title", Type: wl.ArgString},
+			},
+		},
+		{
+			Name:  "set_app_id",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "app_id", Type: wl.ArgString},
+			},
+		},
+		{
+			Name:  "show_window_menu",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "seat", Type: wl.ArgObject, Interface: "wl_seat"},
+				{Name: "serial", Type: wl.ArgUint},
+				{Name: "x", Type: wl.ArgInt},
+				{Name: "y", Type: wl.ArgInt},
+			},
+		},
+		{
+			Name:  "move",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "seat", Type: wl.ArgObject, Interface: "wl_seat"},
+				{Name: "serial", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "resize",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "seat", Type: wl.ArgObject, Interface: "wl_seat"},
+				{Name: "serial", Type: wl.ArgUint},
+				{Name: "edges", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "set_max_size",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "width", Type: wl.ArgInt},
+				{Name: "height", Type: wl.ArgInt},
+			},
+		},
+		{
+			Name:  "set_min_size",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "width", Type: wl.ArgInt},
+				{Name: "height", Type: wl.ArgInt},
+			},
+		},
+		{
+			Name:  "set_maximized",
+			Since: 1,
+		},
+		{
+			Name:  "unset_maximized",
+			Since: 1,
+		},
+		{
+			Name:  "set_fullscreen",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "output", Type: wl.ArgObject, Interface: "wl_output", Nullable: true},
+			},
+		},
+		{
+			Name:  "unset_fullscreen",
+			Since: 1,
+		},
+		{
+			Name:  "set_minimized",
+			Since: 1,
+		},
+	},
+	Events: []wl.Message{
+		{
+			Name:  "configure",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "width", Type: wl.ArgInt},
+				{Name: "height", Type: wl.ArgInt},
+				{Name: "states", Type: wl.ArgArray},
+			},
+		},
+		{
+			Name:  "close",
+			Since: 1,
+		},
+		{
+			Name:  "configure_bounds",
+			Since: 4,
+			Args: []wl.Arg{
+				{Name: "width", Type: wl.ArgInt},
+				{Name: "height", Type: wl.ArgInt},
+			},
+		},
+		{
+			Name:  "wm_capabilities",
+			Since: 5,
+			Args: []wl.Arg{
+				{Name: "capabilities", Type: wl.ArgArray},
+			},
+		},
+	},
+	Enums: []wl.Enum{
+		{
+			Name: "error",
+			Entries: []wl.EnumEntry{
+				{Name: "invalid_resize_edge", Value: 0},
+				{Name: "invalid_parent", Value: 1},
+				{Name: "invalid_size", Value: 2},
+			},
+		},
+		{
+			Name: "resize_edge",
+			Entries: []wl.EnumEntry{
+				{Name: "none", Value: 0},
+				{Name: "top", Value: 1},
+				{Name: "bottom", Value: 2},
+				{Name: "left", Value: 4},
+				{Name: "top_left", Value: 5},
+				{Name: "bottom_left", Value: 6},
+				{Name: "right", Value: 8},
+				{Name: "top_right", Value: 9},
+				{Name: "bottom_right", Value: 10},
+			},
+		},
+		{
+			Name: "state",
+			Entries: []wl.EnumEntry{
+				{Name: "maximized", Value: 1},
+				{Name: "fullscreen", Value: 2},
+				{Name: "resizing", Value: 3},
+				{Name: "activated", Value: 4},
+				{Name: "tiled_left", Value: 5},
+				{Name: "tiled_right", Value: 6},
+				{Name: "tiled_top", Value: 7},
+				{Name: "tiled_bottom", Value: 8},
+				{Name: "suspended", Value: 9},
+			},
+		},
+		{
+			Name: "wm_capabilities",
+			Entries: []wl.EnumEntry{
+				{Name: "window_menu", Value: 1},
+				{Name: "maximize", Value: 2},
+				{Name: "fullscreen", Value: 3},
+				{Name: "minimize", Value: 4},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the xdg_toplevel interface
+func (p *Toplevel) Interface() *wl.Interface {
+	return ToplevelInterface
+}
+
 // Popup: short-lived, popup surfaces for menus
 //
 // A popup surface is a short-lived, temporary surface. It can be used to
 // implement for example menus, popovers, tooltips and other similar user
 // interface concepts.
 //
-// A popup can be made to take an explicit grab. See popup.grab for
+// A popup can be made to take an explicit grab. See xdg_popup.grab for
 // details.
 //
 // When the popup is dismissed, a popup_done event will be sent out, and at
-// the same time the surface will be unmapped. See the popup.popup_done
+// the same time the surface will be unmapped. See the xdg_popup.popup_done
 // event for details.
 //
-// Explicitly destroying the popup object will also dismiss the popup and
+// Explicitly destroying the xdg_popup object will also dismiss the popup and
 // unmap the surface. Clients that want to dismiss the popup when another
 // surface of their own is clicked should dismiss the popup using the destroy
 // request.
 //
-// A newly created popup will be stacked on top of all previously created
-// popup surfaces associated with the same toplevel.
+// A newly created xdg_popup will be stacked on top of all previously created
+// xdg_popup surfaces associated with the same xdg_toplevel.
 //
-// The parent of an popup must be mapped (see the surface
-// description) before the popup itself.
+// The parent of an xdg_popup must be mapped (see the xdg_surface
+// description) before the xdg_popup itself.
 //
 // The client must call wl_surface.commit on the corresponding wl_surface
-// for the popup state to take effect.
+// for the xdg_popup state to take effect.
 type Popup struct {
 	wl.BaseProxy
 	mu                   sync.RWMutex
@@ -1418,49 +2059,23 @@ type Popup struct {
 	repositionedHandlers []PopupRepositionedHandler
 }
 
-// NewPopup: short-lived, popup surfaces for menus
-//
-// A popup surface is a short-lived, temporary surface. It can be used to
-// implement for example menus, popovers, tooltips and other similar user
-// interface concepts.
-//
-// A popup can be made to take an explicit grab. See popup.grab for
-// details.
-//
-// When the popup is dismissed, a popup_done event will be sent out, and at
-// the same time the surface will be unmapped. See the popup.popup_done
-// event for details.
-//
-// Explicitly destroying the popup object will also dismiss the popup and
-// unmap the surface. Clients that want to dismiss the popup when another
-// surface of their own is clicked should dismiss the popup using the destroy
-// request.
-//
-// A newly created popup will be stacked on top of all previously created
-// popup surfaces associated with the same toplevel.
-//
-// The parent of an popup must be mapped (see the surface
-// description) before the popup itself.
-//
-// The client must call wl_surface.commit on the corresponding wl_surface
-// for the popup state to take effect.
+// NewPopup creates a new xdg_popup proxy registered in the Context
 func NewPopup(ctx *wl.Context) *Popup {
-	Popup := &Popup{}
-	ctx.Register(Popup)
-	return Popup
+	ret := new(Popup)
+	ctx.Register(ret)
+	return ret
 }
 
-// Destroy: remove popup interface
+// Destroy: remove xdg_popup interface
 //
-// This destroys the popup. Explicitly destroying the popup
+// This destroys the popup. Explicitly destroying the xdg_popup
 // object will also dismiss the popup, and unmap the surface.
 //
-// If this popup is not the "topmost" popup, a protocol error
+// If this xdg_popup is not the "topmost" popup, a protocol error
 // will be sent.
-//
-func (i *Popup) Destroy() error {
-	err := i.Context().SendRequest(i, 0)
-	i.Unregister()
+func (p *Popup) Destroy() error {
+	err := p.Context().MarshalRequest(p, 0, nil)
+	p.Unregister()
 	return err
 }
 
@@ -1468,7 +2083,7 @@ func (i *Popup) Destroy() error {
 //
 // This request makes the created popup take an explicit grab. An explicit
 // grab will be dismissed when the user dismisses the popup, or when the
-// client destroys the popup. This can be done by the user clicking
+// client destroys the xdg_popup. This can be done by the user clicking
 // outside the surface, using the keyboard, or even locking the screen
 // through closing the lid or a timeout.
 //
@@ -1479,9 +2094,9 @@ func (i *Popup) Destroy() error {
 // button press, key press, or touch down event. The serial number of the
 // event should be passed as 'serial'.
 //
-// The parent of a grabbing popup must either be an toplevel surface or
-// another popup with an explicit grab. If the parent is another
-// popup it means that the popups are nested, with this popup now being
+// The parent of a grabbing popup must either be an xdg_toplevel surface or
+// another xdg_popup with an explicit grab. If the parent is another
+// xdg_popup it means that the popups are nested, with this popup now being
 // the topmost popup.
 //
 // Nested popups must be destroyed in the reverse order they were created
@@ -1490,10 +2105,10 @@ func (i *Popup) Destroy() error {
 //
 // When compositors choose to dismiss a popup, they may dismiss every
 // nested grabbing popup as well. When a compositor dismisses popups, it
-// will follow the same dismissing order as required from the wl.
+// will follow the same dismissing order as required from the client.
 //
-// The parent of a grabbing popup must either be another popup with an
-// active explicit grab, or an popup or toplevel, if there are no
+// The parent of a grabbing popup must either be another xdg_popup with an
+// active explicit grab, or an xdg_popup or xdg_toplevel, if there are no
 // explicit grabs already taken.
 //
 // If the topmost grabbing popup is destroyed, the grab will be returned to
@@ -1508,43 +2123,58 @@ func (i *Popup) Destroy() error {
 // "owner-events" grab in X11 parlance), while the top most grabbing popup
 // will always have keyboard focus.
 //
-//  seat: the wl_seat of the user event
-//  serial: the serial of the user event
-func (i *Popup) Grab(seat *wl.Seat, serial uint32) error {
-	err := i.Context().SendRequest(i, 1, seat, serial)
-	return err
+//	seat: the wl_seat of the user event
+//	serial: the serial of the user event
+func (p *Popup) Grab(seat *wl.Seat, serial uint32) error {
+	return p.Context().MarshalRequest(p, 1, func(r *wl.Request) {
+		if seat != nil {
+			r.PutObject(seat.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutUint32(serial)
+	})
 }
 
 // Reposition: recalculate the popup's location
 //
 // Reposition an already-mapped popup. The popup will be placed given the
-// details in the passed positioner object, and a
-// popup.repositioned followed by popup.configure and
-// surface.configure will be emitted in response. Any parameters set
+// details in the passed xdg_positioner object, and a
+// xdg_popup.repositioned followed by xdg_popup.configure and
+// xdg_surface.configure will be emitted in response. Any parameters set
 // by the previous positioner will be discarded.
 //
 // The passed token will be sent in the corresponding
-// popup.repositioned event. The new popup position will not take
+// xdg_popup.repositioned event. The new popup position will not take
 // effect until the corresponding configure event is acknowledged by the
-// wl. See popup.repositioned for details. The token itself is
+// client. See xdg_popup.repositioned for details. The token itself is
 // opaque, and has no other special meaning.
 //
 // If multiple reposition requests are sent, the compositor may skip all
 // but the last one.
 //
 // If the popup is repositioned in response to a configure event for its
-// parent, the client should send an positioner.set_parent_configure
-// and possibly an positioner.set_parent_size request to allow the
+// parent, the client should send an xdg_positioner.set_parent_configure
+// and possibly an xdg_positioner.set_parent_size request to allow the
 // compositor to properly constrain the popup.
 //
 // If the popup is repositioned together with a parent that is being
 // resized, but not in response to a configure event, the client should
-// send an positioner.set_parent_size request.
+// send an xdg_positioner.set_parent_size request.
 //
-//  token: reposition request token
-func (i *Popup) Reposition(positioner *Positioner, token uint32) error {
-	err := i.Context().SendRequest(i, 2, positioner, token)
-	return err
+//	token: reposition request token
+func (p *Popup) Reposition(positioner *Positioner, token uint32) error {
+	if err := wl.CheckRequestVersion(p, 2); err != nil {
+		return err
+	}
+	return p.Context().MarshalRequest(p, 2, func(r *wl.Request) {
+		if positioner != nil {
+			r.PutObject(positioner.Id())
+		} else {
+			r.PutObject(0)
+		}
+		r.PutUint32(token)
+	})
 }
 
 // PopupError:
@@ -1557,16 +2187,16 @@ const (
 //
 // This event asks the popup surface to configure itself given the
 // configuration. The configured state should not be applied immediately.
-// See surface.configure for details.
+// See xdg_surface.configure for details.
 //
 // The x and y arguments represent the position the popup was placed at
-// given the positioner rule, relative to the upper left corner of the
+// given the xdg_positioner rule, relative to the upper left corner of the
 // window geometry of the parent surface.
 //
-// For version 2 or older, the configure event for an popup is only
+// For version 2 or older, the configure event for an xdg_popup is only
 // ever sent once for the initial configuration. Starting with version 3,
-// it may be sent again if the popup is setup with an positioner with
-// set_reactive requested, or in response to popup.reposition requests.
+// it may be sent again if the popup is setup with an xdg_positioner with
+// set_reactive requested, or in response to xdg_popup.reposition requests.
 type PopupConfigureEvent struct {
 	X      int32
 	Y      int32
@@ -1574,28 +2204,30 @@ type PopupConfigureEvent struct {
 	Height int32
 }
 
+// PopupConfigureHandler is implemented by the receivers of PopupConfigureEvent
 type PopupConfigureHandler interface {
 	HandlePopupConfigure(PopupConfigureEvent)
 }
 
-// AddConfigureHandler: adds handler for PopupConfigureEvent
-func (i *Popup) AddConfigureHandler(h PopupConfigureHandler) {
+// AddConfigureHandler adds a handler for PopupConfigureEvent
+func (p *Popup) AddConfigureHandler(h PopupConfigureHandler) {
 	if h == nil {
 		return
 	}
 
-	i.mu.Lock()
-	i.configureHandlers = append(i.configureHandlers, h)
-	i.mu.Unlock()
+	p.mu.Lock()
+	p.configureHandlers = append(p.configureHandlers, h)
+	p.mu.Unlock()
 }
 
-func (i *Popup) RemoveConfigureHandler(h PopupConfigureHandler) {
-	i.mu.Lock()
-	defer i.mu.Unlock()
+// RemoveConfigureHandler removes a handler previously added by AddConfigureHandler
+func (p *Popup) RemoveConfigureHandler(h PopupConfigureHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	for j, e := range i.configureHandlers {
+	for i, e := range p.configureHandlers {
 		if e == h {
-			i.configureHandlers = append(i.configureHandlers[:j], i.configureHandlers[j+1:]...)
+			p.configureHandlers = append(p.configureHandlers[:i:i], p.configureHandlers[i+1:]...)
 			break
 		}
 	}
@@ -1604,31 +2236,35 @@ func (i *Popup) RemoveConfigureHandler(h PopupConfigureHandler) {
 // PopupPopupDoneEvent: popup interaction is done
 //
 // The popup_done event is sent out when a popup is dismissed by the
-// compositor. The client should destroy the popup object at this
+// compositor. The client should destroy the xdg_popup object at this
 // point.
-type PopupPopupDoneEvent struct{}
+type PopupPopupDoneEvent struct {
+}
+
+// PopupPopupDoneHandler is implemented by the receivers of PopupPopupDoneEvent
 type PopupPopupDoneHandler interface {
 	HandlePopupPopupDone(PopupPopupDoneEvent)
 }
 
-// AddPopupDoneHandler: adds handler for PopupPopupDoneEvent
-func (i *Popup) AddPopupDoneHandler(h PopupPopupDoneHandler) {
+// AddPopupDoneHandler adds a handler for PopupPopupDoneEvent
+func (p *Popup) AddPopupDoneHandler(h PopupPopupDoneHandler) {
 	if h == nil {
 		return
 	}
 
-	i.mu.Lock()
-	i.popupDoneHandlers = append(i.popupDoneHandlers, h)
-	i.mu.Unlock()
+	p.mu.Lock()
+	p.popupDoneHandlers = append(p.popupDoneHandlers, h)
+	p.mu.Unlock()
 }
 
-func (i *Popup) RemovePopupDoneHandler(h PopupPopupDoneHandler) {
-	i.mu.Lock()
-	defer i.mu.Unlock()
+// RemovePopupDoneHandler removes a handler previously added by AddPopupDoneHandler
+func (p *Popup) RemovePopupDoneHandler(h PopupPopupDoneHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	for j, e := range i.popupDoneHandlers {
+	for i, e := range p.popupDoneHandlers {
 		if e == h {
-			i.popupDoneHandlers = append(i.popupDoneHandlers[:j], i.popupDoneHandlers[j+1:]...)
+			p.popupDoneHandlers = append(p.popupDoneHandlers[:i:i], p.popupDoneHandlers[i+1:]...)
 			break
 		}
 	}
@@ -1637,116 +2273,196 @@ func (i *Popup) RemovePopupDoneHandler(h PopupPopupDoneHandler) {
 // PopupRepositionedEvent: signal the completion of a repositioned request
 //
 // The repositioned event is sent as part of a popup configuration
-// sequence, together with popup.configure and lastly
-// surface.configure to notify the completion of a reposition request.
+// sequence, together with xdg_popup.configure and lastly
+// xdg_surface.configure to notify the completion of a reposition request.
 //
 // The repositioned event is to notify about the completion of a
-// popup.reposition request. The token argument is the token passed
-// in the popup.reposition request.
+// xdg_popup.reposition request. The token argument is the token passed
+// in the xdg_popup.reposition request.
 //
-// Immediately after this event is emitted, popup.configure and
-// surface.configure will be sent with the updated size and position,
+// Immediately after this event is emitted, xdg_popup.configure and
+// xdg_surface.configure will be sent with the updated size and position,
 // as well as a new configure serial.
 //
 // The client should optionally update the content of the popup, but must
 // acknowledge the new popup configuration for the new position to take
-// effect. See surface.ack_configure for details.
+// effect. See xdg_surface.ack_configure for details.
 type PopupRepositionedEvent struct {
 	Token uint32
 }
 
+// PopupRepositionedHandler is implemented by the receivers of PopupRepositionedEvent
 type PopupRepositionedHandler interface {
 	HandlePopupRepositioned(PopupRepositionedEvent)
 }
 
-// AddRepositionedHandler: adds handler for PopupRepositionedEvent
-func (i *Popup) AddRepositionedHandler(h PopupRepositionedHandler) {
+// AddRepositionedHandler adds a handler for PopupRepositionedEvent
+func (p *Popup) AddRepositionedHandler(h PopupRepositionedHandler) {
 	if h == nil {
 		return
 	}
 
-	i.mu.Lock()
-	i.repositionedHandlers = append(i.repositionedHandlers, h)
-	i.mu.Unlock()
+	p.mu.Lock()
+	p.repositionedHandlers = append(p.repositionedHandlers, h)
+	p.mu.Unlock()
 }
 
-func (i *Popup) RemoveRepositionedHandler(h PopupRepositionedHandler) {
-	i.mu.Lock()
-	defer i.mu.Unlock()
+// RemoveRepositionedHandler removes a handler previously added by AddRepositionedHandler
+func (p *Popup) RemoveRepositionedHandler(h PopupRepositionedHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
 
-	for j, e := range i.repositionedHandlers {
+	for i, e := range p.repositionedHandlers {
 		if e == h {
-			i.repositionedHandlers = append(i.repositionedHandlers[:j], i.repositionedHandlers[j+1:]...)
+			p.repositionedHandlers = append(p.repositionedHandlers[:i:i], p.repositionedHandlers[i+1:]...)
 			break
 		}
 	}
 }
 
-func (i *Popup) Dispatch(event *wl.Event) {
+// Dispatch decodes an event received on the xdg_popup and runs its handlers
+func (p *Popup) Dispatch(event *wl.Event) {
 	switch event.Opcode {
 	case 0:
-		i.mu.RLock()
-		if len(i.configureHandlers) == 0 {
-			i.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.configureHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
 			break
 		}
-		i.mu.RUnlock()
-
-		e := PopupConfigureEvent{
-			X:      event.Int32(),
-			Y:      event.Int32(),
-			Width:  event.Int32(),
-			Height: event.Int32(),
+		ev := PopupConfigureEvent{}
+		ev.X = event.Int32()
+		ev.Y = event.Int32()
+		ev.Width = event.Int32()
+		ev.Height = event.Int32()
+		if event.Err() != nil {
+			break
 		}
-
-		i.mu.RLock()
-		for _, h := range i.configureHandlers {
-			i.mu.RUnlock()
-
-			h.HandlePopupConfigure(e)
-
-			i.mu.RLock()
+		for _, h := range handlers {
+			h.HandlePopupConfigure(ev)
 		}
-		i.mu.RUnlock()
 	case 1:
-		i.mu.RLock()
-		if len(i.popupDoneHandlers) == 0 {
-			i.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.popupDoneHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
 			break
 		}
-		i.mu.RUnlock()
-
-		e := PopupPopupDoneEvent{}
-
-		i.mu.RLock()
-		for _, h := range i.popupDoneHandlers {
-			i.mu.RUnlock()
-
-			h.HandlePopupPopupDone(e)
-
-			i.mu.RLock()
+		ev := PopupPopupDoneEvent{}
+		for _, h := range handlers {
+			h.HandlePopupPopupDone(ev)
 		}
-		i.mu.RUnlock()
 	case 2:
-		i.mu.RLock()
-		if len(i.repositionedHandlers) == 0 {
-			i.mu.RUnlock()
+		p.mu.RLock()
+		handlers := p.repositionedHandlers
+		p.mu.RUnlock()
+		if len(handlers) == 0 {
 			break
 		}
-		i.mu.RUnlock()
-
-		e := PopupRepositionedEvent{
-			Token: event.Uint32(),
+		ev := PopupRepositionedEvent{}
+		ev.Token = event.Uint32()
+		if event.Err() != nil {
+			break
 		}
-
-		i.mu.RLock()
-		for _, h := range i.repositionedHandlers {
-			i.mu.RUnlock()
-
-			h.HandlePopupRepositioned(e)
-
-			i.mu.RLock()
+		for _, h := range handlers {
+			h.HandlePopupRepositioned(ev)
 		}
-		i.mu.RUnlock()
 	}
 }
+
+const (
+	PopupConfigureSinceVersion    = 1
+	PopupPopupDoneSinceVersion    = 1
+	PopupRepositionedSinceVersion = 3
+	PopupDestroySinceVersion      = 1
+	PopupGrabSinceVersion         = 1
+	PopupRepositionSinceVersion   = 3
+)
+
+// PopupInterface describes the xdg_popup interface
+var PopupInterface = &wl.Interface{
+	Name:    "xdg_popup",
+	Version: 6,
+	Requests: []wl.Message{
+		{
+			Name:       "destroy",
+			Since:      1,
+			Destructor: true,
+		},
+		{
+			Name:  "grab",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "seat", Type: wl.ArgObject, Interface: "wl_seat"},
+				{Name: "serial", Type: wl.ArgUint},
+			},
+		},
+		{
+			Name:  "reposition",
+			Since: 3,
+			Args: []wl.Arg{
+				{Name: "positioner", Type: wl.ArgObject, Interface: "xdg_positioner"},
+				{Name: "token", Type: wl.ArgUint},
+			},
+		},
+	},
+	Events: []wl.Message{
+		{
+			Name:  "configure",
+			Since: 1,
+			Args: []wl.Arg{
+				{Name: "x", Type: wl.ArgInt},
+				{Name: "y", Type: wl.ArgInt},
+				{Name: "width", Type: wl.ArgInt},
+				{Name: "height", Type: wl.ArgInt},
+			},
+		},
+		{
+			Name:  "popup_done",
+			Since: 1,
+		},
+		{
+			Name:  "repositioned",
+			Since: 3,
+			Args: []wl.Arg{
+				{Name: "token", Type: wl.ArgUint},
+			},
+		},
+	},
+	Enums: []wl.Enum{
+		{
+			Name: "error",
+			Entries: []wl.EnumEntry{
+				{Name: "invalid_grab", Value: 0},
+			},
+		},
+	},
+}
+
+// Interface returns the description of the xdg_popup interface
+func (p *Popup) Interface() *wl.Interface {
+	return PopupInterface
+}
+
+func init() {
+	wl.RegisterInterface(WmBaseInterface)
+	wl.RegisterInterface(PositionerInterface)
+	wl.RegisterInterface(SurfaceInterface)
+	wl.RegisterInterface(ToplevelInterface)
+	wl.RegisterInterface(PopupInterface)
+	wl.RegisterProxy(WmBaseInterface, func(ctx *wl.Context) wl.Proxy {
+		return NewWmBase(ctx)
+	})
+	wl.RegisterProxy(PositionerInterface, func(ctx *wl.Context) wl.Proxy {
+		return NewPositioner(ctx)
+	})
+	wl.RegisterProxy(SurfaceInterface, func(ctx *wl.Context) wl.Proxy {
+		return NewSurface(ctx)
+	})
+	wl.RegisterProxy(ToplevelInterface, func(ctx *wl.Context) wl.Proxy {
+		return NewToplevel(ctx)
+	})
+	wl.RegisterProxy(PopupInterface, func(ctx *wl.Context) wl.Proxy {
+		return NewPopup(ctx)
+	})
+}
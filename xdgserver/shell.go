@@ -0,0 +1,11 @@
+// Package xdgserver implements the compositor side of the stable xdg-shell protocol
+//
+// The resource types are generated from xdg-shell.xml in the server flavor described in
+// package wlserver, a global of the xdg_wm_base is added by
+//
+//	server.AddGlobal(xdg.WmBaseInterface, 3, func(r wlserver.Resource) {
+//		r.SetHandler(myWmBase)
+//	})
+package xdgserver
+
+//go:generate go run ../cmd/go-wayland-scanner -server -client github.com/neurlang/wayland/xdg -pkg xdgserver -prefix xdg_ -i ../xdg/xdg-shell.xml -o xdg-shell.xml.go